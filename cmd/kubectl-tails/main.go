@@ -0,0 +1,67 @@
+// Command kubectl-tails is ktails as a kubectl plugin: installed on the
+// PATH (by krew, or by hand) under this name, it runs as `kubectl tails`.
+// It takes kubectl's flags for picking the cluster — --kubeconfig,
+// --context, -n/--namespace — and resolves them the way kubectl does:
+// --kubeconfig over KUBECONFIG over ~/.kube/config, the kubeconfig's
+// current context unless --context names another, and that context's
+// namespace unless --namespace does. The TUI starts on that context and
+// namespace rather than offering the saved session.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ktails/ktails/internal/app"
+	"github.com/ktails/ktails/internal/version"
+)
+
+const usage = `usage: kubectl tails [flags]
+
+Browse pods and tail their logs in the ktails TUI, starting on the context
+and namespace kubectl would use.
+
+`
+
+func main() {
+	fs := flag.NewFlagSet("kubectl tails", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	var opts app.Options
+	fs.StringVar(&opts.Kubeconfig, "kubeconfig", "", "kubeconfig file to use (default KUBECONFIG, or ~/.kube/config)")
+	fs.StringVar(&opts.Context, "context", "", "kubeconfig context to start on (default the current context)")
+	fs.StringVar(&opts.Namespace, "namespace", "", "namespace to start in (default the context's own)")
+	fs.StringVar(&opts.Namespace, "n", "", "shorthand for --namespace")
+	fs.StringVar(&opts.ConfigPath, "config", "", "ktails config file to use (default $XDG_CONFIG_HOME/ktails/config.yaml, or ~/.config/ktails/config.yaml)")
+	fs.StringVar(&opts.StateDir, "state-dir", "", "directory to keep the ktails state and debug log in (default $XDG_STATE_HOME/ktails, or ~/.local/state/ktails)")
+	fs.BoolVar(&opts.Demo, "demo", false, "show contexts, namespaces, names and IPs as pseudonyms, for screen sharing")
+	fs.BoolVar(&opts.ReadOnly, "read-only", false, "lock every action that changes a cluster or runs a process in a container")
+	showVersion := fs.Bool("version", false, "print the version and exit")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "kubectl tails: unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *showVersion {
+		fmt.Println(version.Get())
+		return
+	}
+
+	// As kubectl: --kubeconfig, else KUBECONFIG — both over the ktails
+	// config's own kubeconfig settings — else ~/.kube/config.
+	if opts.Kubeconfig == "" {
+		opts.Kubeconfig = os.Getenv("KUBECONFIG")
+	}
+	opts.LoadCurrent = true
+	os.Exit(app.Run(opts))
+}
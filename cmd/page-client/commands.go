@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ktails/ktails/internal/app"
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/version"
+)
+
+// command is a `ktails <name>` subcommand: run gets the arguments after
+// the name and returns the exit code. Without one, ktails starts the TUI.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"get", "print pods, deployments or contexts as JSON or YAML", runGet},
+	{"tail", "stream logs to stdout without the TUI", runTail},
+	{"version", "print the version", runVersion},
+}
+
+// findCommand returns the subcommand called name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// printUsage is the top-level usage: the TUI's flags, then the
+// subcommands.
+func printUsage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "usage: ktails [flags]            start the TUI")
+	fmt.Fprintln(out, "       ktails <command> [args]   run a command without it")
+	fmt.Fprintln(out, "\nflags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(out, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(out, "  %-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(out, "\nRun `ktails <command> -h` for a command's flags.")
+}
+
+func runVersion([]string) int {
+	fmt.Println(version.Get())
+	return 0
+}
+
+// parseInterleaved parses fs's flags out of args wherever they appear and
+// returns the remaining arguments in order — flag stops at the first
+// argument, so they're picked out one by one and parsing resumes after
+// each.
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// loadClient loads the config (from path, or the default location) and
+// creates the client a subcommand talks to the clusters with. Failures are
+// reported to stderr, prefixed with the command's name.
+func loadClient(name, path string) (*config.Config, *k8s.Client, bool) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ktails %s: failed to load config: %v\n", name, err)
+		return nil, nil, false
+	}
+	client, err := app.NewClient(cfg, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ktails %s: failed to create client: %v\n", name, err)
+		return nil, nil, false
+	}
+	return cfg, client, true
+}
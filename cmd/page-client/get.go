@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ktails/ktails/internal/k8s"
+)
+
+const getUsage = `usage: ktails get pods|deployments|contexts [flags]
+
+Prints the same listings the TUI's tables show, as JSON (an array of
+objects) or YAML, for scripts. Pods and deployments are listed from each
+--context, in its --namespace (default: the context's own).
+
+`
+
+// deploymentRecord is a deployments listing entry: the row plus which
+// context it came from.
+type deploymentRecord struct {
+	Context string `json:"context"`
+	k8s.DeploymentInfo
+}
+
+// getKinds maps the kinds `ktails get` takes, kubectl's short names
+// included, to their listing.
+var getKinds = map[string]string{
+	"contexts": "contexts", "context": "contexts", "ctx": "contexts",
+	"pods": "pods", "pod": "pods", "po": "pods",
+	"deployments": "deployments", "deployment": "deployments", "deploy": "deployments",
+}
+
+// runGet is `ktails get`.
+func runGet(args []string) int {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), getUsage)
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", "", "config file to use (default $XDG_CONFIG_HOME/ktails/config.yaml, or ~/.config/ktails/config.yaml)")
+	contexts := fs.String("context", "", "comma-separated contexts to list from (default: the current context)")
+	namespace := fs.String("namespace", "", "namespace to list in (default: each context's own)")
+	fs.StringVar(namespace, "n", "", "shorthand for --namespace")
+	selector := fs.String("selector", "", "label/field selector, as the TUI's \":\" takes it (app=api,status.phase=Running)")
+	fs.StringVar(selector, "l", "", "shorthand for --selector")
+	output := fs.String("output", "json", "output format: json or yaml")
+	fs.StringVar(output, "o", "json", "shorthand for --output")
+
+	positional, err := parseInterleaved(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if len(positional) != 1 || (*output != "json" && *output != "yaml") {
+		fs.Usage()
+		return 2
+	}
+	kind, ok := getKinds[positional[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "ktails get: unknown kind %q (want pods, deployments or contexts)\n", positional[0])
+		return 2
+	}
+	listOpts, err := k8s.ParseSelectors(*selector)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ktails get: %v\n", err)
+		return 2
+	}
+
+	_, client, ok := loadClient("get", *configPath)
+	if !ok {
+		return 1
+	}
+	targets := []string{client.GetCurrentContext()}
+	if *contexts != "" {
+		targets = strings.Split(*contexts, ",")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var records any
+	switch kind {
+	case "contexts":
+		records, err = getContexts(client)
+	case "pods":
+		records, err = getPods(ctx, client, targets, *namespace, listOpts)
+	case "deployments":
+		records, err = getDeployments(ctx, client, targets, *namespace, listOpts)
+	}
+	if err == nil {
+		err = writeRecords(os.Stdout, records, *output)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ktails get: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func getContexts(client *k8s.Client) ([]k8s.ContextsInfo, error) {
+	contexts, err := client.ListContexts()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, nil
+}
+
+func getPods(ctx context.Context, client *k8s.Client, contexts []string, namespace string, opts metav1.ListOptions) ([]*k8s.PodInfo, error) {
+	pods := []*k8s.PodInfo{}
+	for _, kctx := range contexts {
+		infos, err := client.ListPodInfo(ctx, kctx, namespaceFor(client, kctx, namespace), opts)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, infos...)
+	}
+	return pods, nil
+}
+
+func getDeployments(ctx context.Context, client *k8s.Client, contexts []string, namespace string, opts metav1.ListOptions) ([]deploymentRecord, error) {
+	records := []deploymentRecord{}
+	for _, kctx := range contexts {
+		infos, err := client.GetDeploymentInfo(ctx, kctx, namespaceFor(client, kctx, namespace), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			records = append(records, deploymentRecord{Context: kctx, DeploymentInfo: info})
+		}
+	}
+	return records, nil
+}
+
+// namespaceFor is the namespace to list kctx in: the one asked for, else
+// the context's own.
+func namespaceFor(client *k8s.Client, kctx, namespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	return client.DefaultNamespace(kctx)
+}
+
+// writeRecords prints records to w as indented JSON or as YAML (converted
+// from the JSON, so both use the same field names).
+func writeRecords(w io.Writer, records any, format string) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	if format == "yaml" {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+	} else {
+		data = append(data, '\n')
+	}
+	_, err = w.Write(data)
+	return err
+}
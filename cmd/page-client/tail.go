@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/ktails/ktails/internal/tail"
+)
+
+const tailUsage = `usage: ktails tail [flags] <context> <namespace> <pod|selector>
+
+Streams the logs of a pod, or of every pod a label/field selector matches
+(app=api, tier!=cache), to stdout without the TUI, each line prefixed with
+its pod/container. Flags may come before or after the arguments.
+
+`
+
+// runTail is `ktails tail`: the headless log tail (see package tail).
+func runTail(args []string) int {
+	fs := flag.NewFlagSet("tail", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tailUsage)
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", "", "config file to use (default $XDG_CONFIG_HOME/ktails/config.yaml, or ~/.config/ktails/config.yaml)")
+	container := fs.String("container", "", "tail only this container of each pod (default: all of them)")
+	fs.StringVar(container, "c", "", "shorthand for --container")
+	since := fs.Duration("since", 0, "start this far back, e.g. 5m or 1h (instead of --tail)")
+	tailLines := fs.Int64("tail", -1, "existing lines to print per container, 0 for all (default: tail_lines from the config)")
+	follow := fs.Bool("follow", false, "keep streaming new lines until interrupted")
+	fs.BoolVar(follow, "f", false, "shorthand for --follow")
+	timestamps := fs.Bool("timestamps", false, "prefix each line with its timestamp")
+	noColor := fs.Bool("no-color", false, "don't color the output (also off when stdout isn't a terminal, or NO_COLOR is set)")
+
+	positional, err := parseInterleaved(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if len(positional) != 3 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, client, ok := loadClient("tail", *configPath)
+	if !ok {
+		return 1
+	}
+
+	opts := tail.Options{
+		Context:    positional[0],
+		Namespace:  positional[1],
+		Target:     positional[2],
+		Container:  *container,
+		Since:      *since,
+		Follow:     *follow,
+		Timestamps: *timestamps,
+		Color:      !*noColor && os.Getenv("NO_COLOR") == "" && term.IsTerminal(os.Stdout.Fd()),
+	}
+	// Like a log pane: flags win, then log_since, then tail_lines.
+	switch {
+	case *since > 0:
+	case *tailLines >= 0:
+		opts.TailLines = *tailLines
+	case cfg.Preferences.Since() > 0:
+		opts.Since = cfg.Preferences.Since()
+	default:
+		opts.TailLines = int64(cfg.Preferences.TailLines)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := tail.Run(ctx, client, opts, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "ktails tail: %v\n", err)
+		return 1
+	}
+	return 0
+}
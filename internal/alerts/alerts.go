@@ -0,0 +1,286 @@
+// Package alerts evaluates config-defined alert rules (see
+// config.AlertRule) against the log lines ktails tails and the pods it
+// watches, turning a matching line, a climbing restart count or a status
+// change into an Alert for MainPage to flash, count and ring about.
+package alerts
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+// maxAlerts bounds the fired alerts kept for the alert panel; the oldest
+// go first.
+const maxAlerts = 200
+
+// quietPeriod is how long after ringing (bell or desktop notification) a
+// rule stays quiet: a burst of matching lines rings once, though every
+// line is still an Alert.
+const quietPeriod = 10 * time.Second
+
+// Alert is one firing of a rule.
+type Alert struct {
+	Rule      string
+	At        time.Time
+	Context   string
+	Namespace string
+	Pod       string
+	// Text is what tripped the rule: the log line, "restarts 2 → 3",
+	// "Running → CrashLoopBackOff".
+	Text string
+	// Bell and Notify are the rule's, unless it rang within quietPeriod.
+	Bell   bool
+	Notify bool
+}
+
+// Pod is what the pod rules look at of one watched pod.
+type Pod struct {
+	Namespace string
+	Name      string
+	Status    string
+	Restarts  int32
+}
+
+// RuleStatus is a rule as the alert panel lists it.
+type RuleStatus struct {
+	Name      string
+	Condition string // "log /panic/", "restarts ≥ 5", "status CrashLoopBackOff, Failed"
+	Context   string
+	Muted     bool
+	Fired     int
+	LastFired time.Time
+}
+
+// Engine holds compiled rules, in config order, with what they've fired
+// and the pod statuses they compare against. It isn't safe for
+// concurrent use; MainPage drives it from Update.
+type Engine struct {
+	rules  []rule
+	alerts []Alert
+	unseen int
+	// pods are the last seen status per context, keyed by namespace/name.
+	pods map[string]map[string]Pod
+}
+
+type rule struct {
+	config.AlertRule
+	pattern  *regexp.Regexp
+	muted    bool
+	fired    int
+	last     time.Time
+	lastRang time.Time
+}
+
+// Compile checks and compiles rules. An error names the offending rule, so
+// it can be reported as a config problem at startup.
+func Compile(rules []config.AlertRule) (*Engine, error) {
+	e := &Engine{pods: make(map[string]map[string]Pod)}
+	for i, r := range rules {
+		compiled := rule{AlertRule: r}
+		if r.Pattern != "" {
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("alert_rules[%d] (%s): invalid pattern: %w", i, r.Name, err)
+			}
+			compiled.pattern = re
+		}
+		e.rules = append(e.rules, compiled)
+	}
+	return e, nil
+}
+
+// Empty reports whether there are no rules, so callers can skip the work
+// of feeding the engine.
+func (e *Engine) Empty() bool {
+	return e == nil || len(e.rules) == 0
+}
+
+// Line checks a log line from a container in context against the log
+// rules.
+func (e *Engine) Line(context, namespace, pod, line string, at time.Time) []Alert {
+	if e.Empty() {
+		return nil
+	}
+	var fired []Alert
+	for i := range e.rules {
+		r := &e.rules[i]
+		if r.pattern == nil || !r.applies(context) || !r.pattern.MatchString(line) {
+			continue
+		}
+		fired = append(fired, e.fire(r, Alert{Context: context, Namespace: namespace, Pod: pod, Text: line}, at))
+	}
+	return fired
+}
+
+// Pods checks context's pods, all of them as currently watched, against
+// the restart and status rules, comparing each with how it was last seen.
+// A pod seen for the first time never fires: loading a namespace of old
+// crash loops isn't news.
+func (e *Engine) Pods(context string, pods []Pod, at time.Time) []Alert {
+	if e.Empty() {
+		return nil
+	}
+	before, known := e.pods[context]
+	now := make(map[string]Pod, len(pods))
+	var fired []Alert
+	for _, p := range pods {
+		key := p.Namespace + "/" + p.Name
+		now[key] = p
+		prev, seen := before[key]
+		if !known || !seen {
+			continue
+		}
+		for i := range e.rules {
+			r := &e.rules[i]
+			if !r.applies(context) {
+				continue
+			}
+			alert := Alert{Context: context, Namespace: p.Namespace, Pod: p.Name}
+			switch {
+			case r.Restarts > 0 && p.Restarts > prev.Restarts && p.Restarts >= r.Restarts:
+				alert.Text = fmt.Sprintf("restarts %d → %d", prev.Restarts, p.Restarts)
+			case len(r.Phases) > 0 && p.Status != prev.Status && (slices.Contains(r.Phases, "*") || slices.Contains(r.Phases, p.Status)):
+				alert.Text = prev.Status + " → " + p.Status
+			default:
+				continue
+			}
+			fired = append(fired, e.fire(r, alert, at))
+		}
+	}
+	e.pods[context] = now
+	return fired
+}
+
+// ForgetContext drops what was last seen of context's pods, once it's no
+// longer watched; reloading it starts afresh.
+func (e *Engine) ForgetContext(context string) {
+	if e != nil {
+		delete(e.pods, context)
+	}
+}
+
+func (r *rule) applies(context string) bool {
+	return !r.muted && (r.Context == "" || config.ContextMatches(r.Context, context))
+}
+
+// fire records alert as r's, ringing if r asks to and hasn't within
+// quietPeriod.
+func (e *Engine) fire(r *rule, alert Alert, at time.Time) Alert {
+	alert.Rule, alert.At = r.Name, at
+	if (r.Bell || r.Notify) && at.Sub(r.lastRang) >= quietPeriod {
+		alert.Bell, alert.Notify = r.Bell, r.Notify
+		r.lastRang = at
+	}
+	r.fired++
+	r.last = at
+	e.alerts = append(e.alerts, alert)
+	if len(e.alerts) > maxAlerts {
+		e.alerts = slices.Delete(e.alerts, 0, len(e.alerts)-maxAlerts)
+	}
+	e.unseen = min(e.unseen+1, maxAlerts)
+	return alert
+}
+
+// Alerts returns the fired alerts still kept, newest first.
+func (e *Engine) Alerts() []Alert {
+	if e == nil {
+		return nil
+	}
+	out := slices.Clone(e.alerts)
+	slices.Reverse(out)
+	return out
+}
+
+// Unseen is how many alerts have fired since MarkSeen, for the status
+// bar's badge.
+func (e *Engine) Unseen() int {
+	if e == nil {
+		return 0
+	}
+	return e.unseen
+}
+
+// MarkSeen clears the badge's count.
+func (e *Engine) MarkSeen() {
+	if e != nil {
+		e.unseen = 0
+	}
+}
+
+// Clear drops the fired alerts and the rules' counts.
+func (e *Engine) Clear() {
+	if e == nil {
+		return
+	}
+	e.alerts, e.unseen = nil, 0
+	for i := range e.rules {
+		e.rules[i].fired = 0
+		e.rules[i].last = time.Time{}
+	}
+}
+
+// Rules returns the rules with their state, in config order.
+func (e *Engine) Rules() []RuleStatus {
+	if e == nil {
+		return nil
+	}
+	out := make([]RuleStatus, 0, len(e.rules))
+	for _, r := range e.rules {
+		out = append(out, RuleStatus{
+			Name:      r.Name,
+			Condition: r.condition(),
+			Context:   r.Context,
+			Muted:     r.muted,
+			Fired:     r.fired,
+			LastFired: r.last,
+		})
+	}
+	return out
+}
+
+func (r *rule) condition() string {
+	switch {
+	case r.pattern != nil:
+		return "log /" + r.Pattern + "/"
+	case r.Restarts > 0:
+		return fmt.Sprintf("restarts ≥ %d", r.Restarts)
+	}
+	if slices.Contains(r.Phases, "*") {
+		return "status, any change"
+	}
+	return "status " + strings.Join(r.Phases, ", ")
+}
+
+// SetMuted mutes or unmutes the rule named name: a muted rule doesn't
+// fire. It reports whether there's such a rule.
+func (e *Engine) SetMuted(name string, muted bool) bool {
+	if e == nil {
+		return false
+	}
+	for i := range e.rules {
+		if e.rules[i].Name == name {
+			e.rules[i].muted = muted
+			return true
+		}
+	}
+	return false
+}
+
+// Muted returns the names of the muted rules, in config order.
+func (e *Engine) Muted() []string {
+	if e == nil {
+		return nil
+	}
+	var names []string
+	for _, r := range e.rules {
+		if r.muted {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,80 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+func TestLine_MatchesPatternInItsContextsAndRingsOncePerBurst(t *testing.T) {
+	e, err := Compile([]config.AlertRule{{Name: "panics", Pattern: `panic:|fatal error`, Context: "prod-*", Bell: true}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if got := e.Line("staging", "shop", "api-0", "panic: nil map", at); len(got) != 0 {
+		t.Errorf("fired in a context the rule doesn't cover: %+v", got)
+	}
+	if got := e.Line("prod-eu", "shop", "api-0", "GET /healthz 200", at); len(got) != 0 {
+		t.Errorf("fired on a line not matching: %+v", got)
+	}
+	first := e.Line("prod-eu", "shop", "api-0", "panic: nil map", at)
+	if len(first) != 1 || !first[0].Bell || first[0].Rule != "panics" || first[0].Pod != "api-0" {
+		t.Fatalf("first match = %+v, want one ringing alert for api-0", first)
+	}
+	second := e.Line("prod-eu", "shop", "api-1", "fatal error: out of memory", at.Add(time.Second))
+	if len(second) != 1 || second[0].Bell {
+		t.Errorf("second match within the quiet period = %+v, want it listed but not ringing", second)
+	}
+	if e.Unseen() != 2 || e.Alerts()[0].Pod != "api-1" {
+		t.Errorf("Unseen = %d, newest = %+v; want 2, api-1's first", e.Unseen(), e.Alerts()[0])
+	}
+
+	e.SetMuted("panics", true)
+	if got := e.Line("prod-eu", "shop", "api-0", "panic: again", at.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("a muted rule fired: %+v", got)
+	}
+}
+
+func TestPods_FiresOnRestartsAndStatusChangesAfterFirstSight(t *testing.T) {
+	e, err := Compile([]config.AlertRule{
+		{Name: "restarting", Restarts: 3},
+		{Name: "crashing", Phases: []string{"CrashLoopBackOff", "Failed"}},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	at := time.Now()
+	pod := func(status string, restarts int32) []Pod {
+		return []Pod{{Namespace: "shop", Name: "api-0", Status: status, Restarts: restarts}}
+	}
+
+	if got := e.Pods("prod", pod("CrashLoopBackOff", 9), at); len(got) != 0 {
+		t.Errorf("fired on first sight: %+v", got)
+	}
+	if got := e.Pods("prod", pod("Running", 9), at); len(got) != 0 {
+		t.Errorf("fired on a status not listed, restarts unchanged: %+v", got)
+	}
+	got := e.Pods("prod", pod("CrashLoopBackOff", 10), at)
+	if len(got) != 2 || got[0].Text != "restarts 9 → 10" || got[1].Text != "Running → CrashLoopBackOff" {
+		t.Errorf("restart and crash = %+v, want both rules' alerts", got)
+	}
+
+	e.ForgetContext("prod")
+	if got := e.Pods("prod", pod("Failed", 11), at); len(got) != 0 {
+		t.Errorf("fired after the context was forgotten: %+v", got)
+	}
+
+	rules := e.Rules()
+	if rules[0].Fired != 1 || rules[0].Condition != "restarts ≥ 3" || rules[1].Condition != "status CrashLoopBackOff, Failed" {
+		t.Errorf("Rules = %+v", rules)
+	}
+}
+
+func TestCompile_RejectsInvalidPattern(t *testing.T) {
+	if _, err := Compile([]config.AlertRule{{Name: "bad", Pattern: "(unclosed"}}); err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+}
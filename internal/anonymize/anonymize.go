@@ -0,0 +1,293 @@
+// Package anonymize rewrites the environment details in rendered output —
+// contexts, namespaces, pod and other object names, IP addresses — into
+// pseudonyms, so a screen can be shared or screenshotted without leaking
+// them. The same input always gets the same pseudonym within a session, and
+// a pseudonym keeps its input's length and shape (letters for letters,
+// digits for digits, punctuation as is), so table columns and pane borders
+// stay exactly where they were.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// minNameLen is the shortest learned name that's rewritten, and the
+// shortest truncated prefix ("api…") that's matched against one — any
+// shorter and ordinary words in the UI would start getting caught.
+const minNameLen = 3
+
+var (
+	// escapeRE matches the ANSI escape sequences styling inserts (CSI, OSC
+	// and the two-byte forms), which are passed through untouched.
+	escapeRE = regexp.MustCompile(`\x1b(?:\[[0-9;:?<=>]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
+	ipv4RE   = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6RE   = regexp.MustCompile(`(?i)[0-9a-f]{0,4}(?::[0-9a-f]{0,4}){2,7}`)
+)
+
+// Anonymizer holds a session's pseudonyms. It isn't safe for concurrent
+// use; MainPage only calls it from View.
+type Anonymizer struct {
+	key []byte
+
+	// names is every learned name with its pseudonym. sorted lists the
+	// names in order, for matching truncated ones, and lengths their
+	// lengths, longest first; both are rebuilt lazily.
+	names   map[string]string
+	sorted  []string
+	lengths []int
+	dirty   bool
+
+	ips map[string]string
+}
+
+// New returns an Anonymizer keyed with key, or with a random key (so
+// pseudonyms can't be reversed by hashing guesses) when key is empty.
+func New(key []byte) *Anonymizer {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &Anonymizer{
+		key:   key,
+		names: make(map[string]string),
+		ips:   make(map[string]string),
+	}
+}
+
+// Learn registers names to rewrite wherever they appear whole, not as part
+// of a longer word — anything may surround them but letters, digits, "_",
+// "-" and a "." that isn't ending a sentence. A name is matched literally,
+// so one with ":" or "/" in it, like an EKS context's ARN, is caught as a
+// whole. Names shorter than minNameLen are skipped.
+func (a *Anonymizer) Learn(names ...string) {
+	for _, name := range names {
+		if len(name) < minNameLen {
+			continue
+		}
+		if _, ok := a.names[name]; ok {
+			continue
+		}
+		a.names[name] = a.Name(name)
+		a.dirty = true
+	}
+}
+
+// Name returns s's pseudonym: each ASCII letter and digit replaced by one
+// derived from s, everything else kept.
+func (a *Anonymizer) Name(s string) string {
+	if p, ok := a.names[s]; ok {
+		return p
+	}
+	stream := a.stream(s)
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z':
+			b[i] = 'a' + stream(26)
+		case c >= 'A' && c <= 'Z':
+			b[i] = 'A' + stream(26)
+		case c >= '0' && c <= '9':
+			b[i] = '0' + stream(10)
+		}
+	}
+	return string(b)
+}
+
+// IP returns the pseudonym of an IPv4 or IPv6 address: another valid
+// address of the same length and shape. Anything else comes back as is.
+func (a *Anonymizer) IP(s string) string {
+	if p, ok := a.ips[s]; ok {
+		return p
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return s
+	}
+	stream := a.stream("ip:" + s)
+	b := []byte(s)
+	if ip.To4() != nil && !strings.Contains(s, ":") {
+		// Keep every octet in range: three digits become 1xx, two become
+		// 10-99, one any digit.
+		for start := 0; start < len(b); {
+			end := start
+			for end < len(b) && b[end] != '.' {
+				end++
+			}
+			for i := start; i < end; i++ {
+				switch {
+				case end-start == 3 && i == start:
+					b[i] = '1'
+				case end-start == 2 && i == start:
+					b[i] = '1' + stream(9)
+				default:
+					b[i] = '0' + stream(10)
+				}
+			}
+			start = end + 1
+		}
+	} else {
+		for i, c := range b {
+			if c != ':' && c != '.' {
+				b[i] = "0123456789abcdef"[stream(16)]
+			}
+		}
+	}
+	a.ips[s] = string(b)
+	return a.ips[s]
+}
+
+// Apply rewrites every IP address and learned name in rendered, which may
+// carry ANSI styling. A learned name cut short by truncation ("my-deplo…")
+// is rewritten to the same prefix of its pseudonym.
+func (a *Anonymizer) Apply(rendered string) string {
+	if a.dirty {
+		a.sorted, a.lengths = a.sorted[:0], a.lengths[:0]
+		for name := range a.names {
+			a.sorted = append(a.sorted, name)
+			if !slices.Contains(a.lengths, len(name)) {
+				a.lengths = append(a.lengths, len(name))
+			}
+		}
+		sort.Strings(a.sorted)
+		sort.Sort(sort.Reverse(sort.IntSlice(a.lengths)))
+		a.dirty = false
+	}
+
+	var b strings.Builder
+	b.Grow(len(rendered))
+	last := 0
+	for _, loc := range escapeRE.FindAllStringIndex(rendered, -1) {
+		b.WriteString(a.applyText(rendered[last:loc[0]]))
+		b.WriteString(rendered[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(a.applyText(rendered[last:]))
+	return b.String()
+}
+
+// applyText rewrites one run of plain text.
+func (a *Anonymizer) applyText(text string) string {
+	if text == "" {
+		return text
+	}
+	text = ipv4RE.ReplaceAllStringFunc(text, a.IP)
+	if strings.Contains(text, ":") {
+		text = ipv6RE.ReplaceAllStringFunc(text, func(s string) string {
+			if strings.Count(s, ":") < 2 || net.ParseIP(s) == nil {
+				return s
+			}
+			return a.IP(s)
+		})
+	}
+	if len(a.names) == 0 {
+		return text
+	}
+
+	// Names are tried where a word starts, longest first, so a name
+	// containing a shorter one is rewritten whole.
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(text); i++ {
+		if !isAlnum(text[i]) || (i > 0 && isNameByte(text[i-1])) {
+			continue
+		}
+		n, replacement, ok := a.nameAt(text, i)
+		if !ok {
+			continue
+		}
+		b.WriteString(text[last:i])
+		b.WriteString(replacement)
+		last = i + n
+		i = last - 1
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// nameAt matches the learned name starting text[i:], whole or cut short
+// by truncation ("my-deplo…"), returning how much of text it covers and
+// what replaces that.
+func (a *Anonymizer) nameAt(text string, i int) (int, string, bool) {
+	for _, n := range a.lengths {
+		if i+n > len(text) || !endsName(text, i+n) {
+			continue
+		}
+		if p, ok := a.names[text[i:i+n]]; ok {
+			return n, p, true
+		}
+	}
+	window := text[i:min(len(text), i+a.lengths[0]+len("…"))]
+	if n := strings.Index(window, "…"); n >= minNameLen && !strings.ContainsAny(window[:n], " \t") {
+		if p, ok := a.truncated(window[:n]); ok {
+			return n, p, true
+		}
+	}
+	return 0, "", false
+}
+
+// isNameByte reports whether c can be part of a name's word.
+func isNameByte(c byte) bool {
+	return isAlnum(c) || c == '.' || c == '_' || c == '-'
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// endsName reports whether a name can end at text[end]: at the end of the
+// text, or before anything but the rest of a word. A dot ends it when
+// it's ending a sentence, not joining on more of the word.
+func endsName(text string, end int) bool {
+	if end == len(text) {
+		return true
+	}
+	if text[end] == '.' {
+		return end+1 == len(text) || !isNameByte(text[end+1])
+	}
+	return !isNameByte(text[end])
+}
+
+// truncated returns the pseudonym prefix for a token that is the start of
+// a learned name.
+func (a *Anonymizer) truncated(prefix string) (string, bool) {
+	if len(prefix) < minNameLen {
+		return "", false
+	}
+	i := sort.SearchStrings(a.sorted, prefix)
+	if i == len(a.sorted) || !strings.HasPrefix(a.sorted[i], prefix) {
+		return "", false
+	}
+	return a.names[a.sorted[i]][:len(prefix)], true
+}
+
+// stream returns a generator of values in [0,n) derived from the keyed
+// hash of s, extended block by block as needed.
+func (a *Anonymizer) stream(s string) func(n int) byte {
+	var block []byte
+	counter := uint32(0)
+	return func(n int) byte {
+		if len(block) == 0 {
+			mac := hmac.New(sha256.New, a.key)
+			var c [4]byte
+			binary.BigEndian.PutUint32(c[:], counter)
+			mac.Write(c[:])
+			mac.Write([]byte(s))
+			block = mac.Sum(nil)
+			counter++
+		}
+		v := block[0]
+		block = block[1:]
+		return byte(int(v) % n)
+	}
+}
@@ -0,0 +1,73 @@
+package anonymize
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestApply_RewritesNamesAndIPsConsistently(t *testing.T) {
+	a := New([]byte("test-key"))
+	a.Learn("payments", "api-7d9f8-x2k4q", "prod-eu")
+
+	in := "\x1b[1mapi-7d9f8-x2k4q\x1b[0m payments prod-eu 10.12.0.254 api-7d9f8… talked to api-7d9f8-x2k4q."
+	out := a.Apply(in)
+
+	for _, leaked := range []string{"payments", "api-7d9f8", "prod-eu", "10.12.0.254"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("%q leaked into %q", leaked, out)
+		}
+	}
+	if len(out) != len(in) {
+		t.Errorf("length changed: %d -> %d (%q)", len(in), len(out), out)
+	}
+	if !strings.HasPrefix(out, "\x1b[1m") || !strings.Contains(out, "\x1b[0m") {
+		t.Errorf("styling was rewritten: %q", out)
+	}
+	if a.Apply(in) != out {
+		t.Error("same input gave a different result")
+	}
+
+	pod := a.Name("api-7d9f8-x2k4q")
+	if !strings.Contains(out, pod) || !strings.Contains(out, pod[:len("api-7d9f8")]+"…") || !strings.Contains(out, pod+".") {
+		t.Errorf("expected %q whole, truncated and before a dot in %q", pod, out)
+	}
+}
+
+func TestApply_RewritesARNContextNamesWhole(t *testing.T) {
+	a := New([]byte("test-key"))
+	arn := "arn:aws:eks:us-east-1:123456789012:cluster/prod-payments"
+	a.Learn(arn, "prod-payments", "pay")
+
+	in := "ctx " + arn + " │ " + arn[:30] + "… │ prod-payments │ payments"
+	out := a.Apply(in)
+
+	for _, leaked := range []string{"123456789012", "prod-payments", "us-east-1:12345"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("%q leaked into %q", leaked, out)
+		}
+	}
+	pseudonym := a.Name(arn)
+	if !strings.Contains(out, "ctx "+pseudonym+" │") || !strings.Contains(out, pseudonym[:30]+"…") {
+		t.Errorf("expected %q whole and truncated in %q", pseudonym, out)
+	}
+	if !strings.HasSuffix(out, "│ payments") {
+		t.Errorf("a learned name was rewritten inside a longer word: %q", out)
+	}
+	if len(out) != len(in) {
+		t.Errorf("length changed: %d -> %d (%q)", len(in), len(out), out)
+	}
+}
+
+func TestIP_KeepsValidAddressOfSameShape(t *testing.T) {
+	a := New(nil)
+	for _, ip := range []string{"10.0.0.1", "192.168.255.254", "fd00::1:2"} {
+		p := a.IP(ip)
+		if p == ip || len(p) != len(ip) || net.ParseIP(p) == nil {
+			t.Errorf("%s -> %s: want a different valid address of the same length", ip, p)
+		}
+	}
+	if got := a.IP("not-an-ip"); got != "not-an-ip" {
+		t.Errorf("non-address rewritten to %q", got)
+	}
+}
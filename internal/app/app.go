@@ -0,0 +1,230 @@
+// Package app starts the TUI: it loads the config and state, creates the
+// client and runs MainPage until the user quits. It's shared by the ktails
+// binary (cmd/page-client) and the kubectl plugin (cmd/kubectl-tails),
+// which differ only in the flags they take.
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/alerts"
+	"github.com/ktails/ktails/internal/audit"
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/health"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/pages"
+	"github.com/ktails/ktails/internal/share"
+	"github.com/ktails/ktails/internal/startup"
+	"github.com/ktails/ktails/utils"
+)
+
+// Options are the TUI's command-line settings.
+type Options struct {
+	ConfigPath   string // config file; empty means the default location
+	StateDir     string // session and debug log directory; empty means the default
+	Demo         bool   // pseudonyms for screen sharing
+	ReadOnly     bool   // lock every action that changes a cluster
+	StartupTrace bool   // time the startup into the debug log
+
+	// Kubeconfig, if set, overrides the config's kubeconfig_paths and
+	// kubeconfig_path: a path, or a list the way KUBECONFIG takes one.
+	Kubeconfig string
+	// Context, if set, is the kubeconfig context to take as the current
+	// one in its place.
+	Context string
+	// LoadCurrent loads the current context straight away, in Namespace
+	// (empty: the context's own), instead of offering the saved session.
+	LoadCurrent bool
+	Namespace   string
+}
+
+// setupLogging routes the standard log package's output away from
+// os.Stderr, which the bubbletea program shares with the terminal it's
+// rendering into. log's default output writes straight there, outside
+// bubbletea's alt-screen render loop — any log.Printf call (e.g.
+// pages.logSlowUpdate) would otherwise bleed raw text into the TUI and
+// corrupt the frame. Debug logging (KTAILS_DEBUG=1, or on for
+// --startup-trace) goes to a file in the state directory (logDir, or the
+// default one if empty) instead; without it, log output is discarded
+// entirely.
+func setupLogging(logDir string, debug bool) (close func()) {
+	if !debug {
+		log.SetOutput(io.Discard)
+		return func() {}
+	}
+
+	if logDir == "" {
+		var err error
+		if logDir, err = config.GetDefaultStateDir(); err != nil {
+			log.SetOutput(io.Discard)
+			return func() {}
+		}
+	}
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		log.SetOutput(io.Discard)
+		return func() {}
+	}
+
+	logPath := filepath.Join(logDir, "debug.log")
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		log.SetOutput(io.Discard)
+		return func() {}
+	}
+
+	log.SetOutput(f)
+	return func() { f.Close() }
+}
+
+// NewClient creates the client from kubeconfig if set, else the config's
+// kubeconfig_paths, else its kubeconfig_path (else KUBECONFIG or
+// ~/.kube/config).
+func NewClient(cfg *config.Config, kubeconfig string) (*k8s.Client, error) {
+	if kubeconfig != "" {
+		return k8s.NewClient(kubeconfig)
+	}
+	if len(cfg.KubeconfigPaths) > 0 {
+		return k8s.NewClientFromPaths(cfg.KubeconfigPaths)
+	}
+	return k8s.NewClient(cfg.KubeconfigPath)
+}
+
+// Run starts the TUI and returns the exit code once it's quit.
+func Run(opts Options) int {
+	var trace *startup.Trace
+	if opts.StartupTrace {
+		trace = startup.New()
+	}
+
+	closeLog := setupLogging(opts.StateDir, os.Getenv("KTAILS_DEBUG") != "" || opts.StartupTrace)
+	defer closeLog()
+
+	// A missing default config file just means defaults; one named with
+	// --config has to exist.
+	if opts.ConfigPath != "" {
+		if _, err := os.Stat(opts.ConfigPath); err != nil {
+			fmt.Printf("❌ Failed to load config: %v\n", err)
+			return 1
+		}
+	}
+	cfg, err := config.Load(opts.ConfigPath)
+	if err != nil {
+		fmt.Printf("❌ Failed to load config: %v\n", err)
+		return 1
+	}
+	trace.Mark(startup.StepConfig)
+
+	// Create client
+	client, err := NewClient(cfg, opts.Kubeconfig)
+	if err != nil {
+		fmt.Printf("❌ Failed to create client: %v\n", err)
+		return 1
+	}
+	if opts.Context != "" {
+		if err := client.SetCurrentContext(opts.Context); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+	}
+	fmt.Println("✅ Client created successfully")
+	trace.Mark(startup.StepKubeconfig)
+
+	healthRules, err := health.Compile(cfg.HealthRules)
+	if err != nil {
+		fmt.Printf("❌ Invalid health rules in config: %v\n", err)
+		return 1
+	}
+	client.SetHealthRules(healthRules)
+	parallelism, timeout := cfg.Loading.Limits()
+	client.SetLoadLimits(k8s.LoadLimits{Parallelism: parallelism, Timeout: timeout})
+
+	mp := pages.NewMainPageModel(client, cfg.Preferences.RefreshInterval)
+	if err := mp.SetKeyBindings(cfg.Keys); err != nil {
+		fmt.Printf("❌ Invalid keys in config: %v\n", err)
+		return 1
+	}
+	mp.SetLogPreferences(cfg.Preferences)
+	mp.SetLogLevelSwitches(cfg.LogLevelSwitches)
+	mp.SetPaneTemplates(cfg.PaneTemplates)
+	mp.SetWatermarks(cfg.Watermarks)
+	alertRules, err := alerts.Compile(cfg.AlertRules)
+	if err != nil {
+		fmt.Printf("❌ Invalid alert rules in config: %v\n", err)
+		return 1
+	}
+	mp.SetAlertRules(alertRules)
+	// A missing token only turns sharing off: it's the environment, not
+	// the config, and shouldn't stop ktails from starting.
+	sharer, err := share.New(cfg.Share)
+	if err != nil {
+		fmt.Printf("⚠ Sharing logs is off: %v\n", err)
+	}
+	mp.SetSharer(sharer)
+	mp.SetLogLinks(cfg.LogLinks)
+	mp.SetProfiles(cfg.Profiles)
+	mp.SetColumns(cfg.Columns)
+	mp.SetRequestBudget(cfg.RequestBudget)
+	mp.SetIdlePause(cfg.Preferences.IdleAfter())
+	mp.SetDemoMode(opts.Demo || cfg.Preferences.DemoMode)
+	mp.SetAccessChecks(cfg.Preferences.AccessChecks)
+	mp.SetMouse(cfg.Preferences.Mouse)
+	mp.SetStartupTrace(trace)
+	// Last: it locks whatever the setters above enabled.
+	mp.SetReadOnly(opts.ReadOnly || cfg.Preferences.ReadOnly)
+
+	// Empty paths mean the default state directory.
+	sessionPath, statePath, auditPath := "", "", ""
+	if opts.StateDir != "" {
+		sessionPath = filepath.Join(opts.StateDir, "session.yaml")
+		statePath = filepath.Join(opts.StateDir, "state.yaml")
+		auditPath = filepath.Join(opts.StateDir, "audit.jsonl")
+	}
+	auditLog, err := audit.New(auditPath)
+	if err != nil {
+		fmt.Printf("⚠ Actions aren't audited: %v\n", err)
+	}
+	mp.SetAuditLog(auditLog)
+	state, err := config.LoadState(statePath)
+	if err != nil {
+		log.Printf("ignoring saved state: %v", err)
+		state = &config.State{}
+	}
+	mp.SetState(state)
+	session, err := config.LoadSession(sessionPath)
+	if err != nil {
+		log.Printf("ignoring saved session: %v", err)
+	}
+	mp.SetSession(session)
+	if opts.LoadCurrent {
+		mp.SetStartContext(client.GetCurrentContext(), opts.Namespace)
+	}
+
+	// The program's context is MainPage's too: whatever API call or stream
+	// is still in flight when the program ends is cancelled with it.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mp.SetContext(ctx)
+	p := tea.NewProgram(mp, tea.WithContext(ctx))
+	if r, err := p.Run(); err != nil {
+		utils.PrintJSON(r)
+		panic(err)
+	}
+
+	if s := mp.Session(); s != nil {
+		if err := s.Save(sessionPath); err != nil {
+			fmt.Printf("⚠ Failed to save session: %v\n", err)
+		}
+	}
+	if err := mp.State().Save(statePath); err != nil {
+		fmt.Printf("⚠ Failed to save state: %v\n", err)
+	}
+	fmt.Print(trace.Summary())
+	return 0
+}
@@ -0,0 +1,140 @@
+// Package audit keeps the audit log: a line for every action ktails took
+// that changed something in a cluster or opened a shell in a container —
+// who took it, against what, when, and whether it went through — appended
+// to a file in the state directory for reviewing after an incident.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+// Entry is one action taken, a JSON line in the audit file.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Context string    `json:"context"`
+	// User is the kubeconfig user the context authenticates as.
+	User string `json:"user,omitempty"`
+	// Action is what was done: "delete", "restart", "rollback", "annotate",
+	// "set key", "cordon", "uncordon", "drain" or "exec".
+	Action    string `json:"action"`
+	Kind      string `json:"kind"` // "pod", "deployment", "configmap", "node"
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Detail is what the action needs besides its target, e.g.
+	// "log-level=debug" or the command exec ran.
+	Detail string `json:"detail,omitempty"`
+	// Error is why the action failed; empty if it went through.
+	Error string `json:"error,omitempty"`
+}
+
+// Resource is the entry's target the way kubectl names it, e.g.
+// "pod/payments/api-7d9f"; a node has no namespace.
+func (e Entry) Resource() string {
+	if e.Namespace == "" {
+		return e.Kind + "/" + e.Name
+	}
+	return e.Kind + "/" + e.Namespace + "/" + e.Name
+}
+
+// Log appends entries to an audit file, safe for concurrent use.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New returns the log kept at path, the default path (see DefaultPath) if
+// empty. Nothing is created before the first entry.
+func New(path string) (*Log, error) {
+	if path == "" {
+		defaultPath, err := DefaultPath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default audit log path: %w", err)
+		}
+		path = defaultPath
+	}
+	return &Log{path: path}, nil
+}
+
+// DefaultPath returns the audit file's path, in the state directory: the
+// config directory is kept for hand-edited files (see
+// config.GetDefaultStateDir).
+func DefaultPath() (string, error) {
+	stateDir, err := config.GetDefaultStateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, "audit.jsonl"), nil
+}
+
+// Path returns the file the log is kept in.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends one entry. The file is only ever appended to, and
+// readable by its owner alone.
+func (l *Log) Record(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Entries reads back every entry recorded, oldest first. A missing file
+// has none; a line that doesn't parse — one cut short when ktails was
+// killed mid-write — is skipped.
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecord_AppendsAndReadsBackSkippingTornLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "audit.jsonl")
+	l, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if entries, err := l.Entries(); err != nil || len(entries) != 0 {
+		t.Fatalf("Entries before anything's recorded = %+v, %v; want none", entries, err)
+	}
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := l.Record(Entry{Time: at, Context: "prod", User: "admin", Action: "delete", Kind: "pod", Namespace: "shop", Name: "api-0"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	// A write cut short, as by ktails being killed mid-line.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"time":"2026-01-02T03:04:06Z","context":"pr` + "\n")
+	f.Close()
+	if err := l.Record(Entry{Time: at.Add(time.Minute), Context: "prod", Action: "cordon", Kind: "node", Name: "node-1", Error: "forbidden"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Entries = %+v, want the two recorded", entries)
+	}
+	if got := entries[0]; !got.Time.Equal(at) || got.User != "admin" || got.Resource() != "pod/shop/api-0" {
+		t.Errorf("first entry = %+v", got)
+	}
+	if got := entries[1]; got.Resource() != "node/node-1" || got.Error != "forbidden" {
+		t.Errorf("second entry = %+v", got)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("audit file mode = %v, want 0600", perm)
+	}
+}
@@ -2,9 +2,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,11 +21,405 @@ type Config struct {
 	// Preferences
 	Preferences Preferences `yaml:"preferences"`
 
-	// Recent pods for quick access
-	RecentPods []RecentPod `yaml:"recent_pods"`
-
 	// Kubeconfig path (defaults to ~/.kube/config)
 	KubeconfigPath string `yaml:"kubeconfig_path"`
+
+	// KubeconfigPaths lists kubeconfig files to merge, in order, as
+	// KUBECONFIG does; it takes precedence over KubeconfigPath.
+	KubeconfigPaths []string `yaml:"kubeconfig_paths"`
+
+	// Profiles are named environments to switch between while running ('p'
+	// in the contexts pane, or :profile), each with its own kubeconfig files
+	// and the contexts to load on switching. See Profile.
+	Profiles []Profile `yaml:"profiles"`
+
+	// HealthRules give resources ktails has no built-in notion of health
+	// for (custom resources, mostly) a Healthy/Degraded status, checked in
+	// order — the first rule matching an object's kind wins. See HealthRule.
+	HealthRules []HealthRule `yaml:"health_rules"`
+
+	// LogLevelSwitches change a running app's log level without a restart,
+	// for apps that watch an annotation or a ConfigMap key for it. The first
+	// switch whose selector matches a pod is offered from its log pane.
+	LogLevelSwitches []LogLevelSwitch `yaml:"log_level_switches"`
+
+	// PaneTemplates open a predefined set of panes for a deployment in one
+	// action ("o" on the Deployments tab); the first template whose
+	// selector matches the deployment's labels is used. See PaneTemplate.
+	PaneTemplates []PaneTemplate `yaml:"pane_templates"`
+
+	// Watermarks label panes bound to sensitive contexts with a prominent
+	// badge, e.g. "PROD eu-west-1", so production is never mistaken for
+	// staging; the first watermark matching a context is used. See
+	// Watermark.
+	Watermarks []Watermark `yaml:"watermarks"`
+
+	// AlertRules raise an alert — a flash, a badge in the status bar and,
+	// if asked, a bell or desktop notification — on a log line, a pod's
+	// restarts or a pod's status change. See AlertRule.
+	AlertRules []AlertRule `yaml:"alert_rules"`
+
+	// Share is the paste service "S" in the log pane posts selected lines
+	// to, for a link to drop in an incident channel. See Share.
+	Share Share `yaml:"share"`
+
+	// LogLinks link a pod, or a span of its log pane, to its history in a
+	// log store — a Grafana Explore or Loki query — for looking further back
+	// than the live tail; "H" copies the first one whose Context matches.
+	// See LogLink.
+	LogLinks []LogLink `yaml:"log_links"`
+
+	// RequestBudget caps the API load ktails puts on each context; near it,
+	// refreshes back off. See RequestBudget.
+	RequestBudget RequestBudget `yaml:"request_budget"`
+
+	// Loading bounds the requests ktails sends at once across every
+	// context, and how long each may take. See Loading.
+	Loading Loading `yaml:"loading"`
+
+	// Columns picks the columns the Pods and Deployments tables show
+	// outside wide mode. See Columns.
+	Columns Columns `yaml:"columns"`
+
+	// Keys remaps actions to other keys, by the action's name — e.g.
+	// quit, up, focus_next — to the keys that trigger it instead of its
+	// defaults; an empty list unbinds it. Actions not listed keep their
+	// defaults. The names and keys are checked where they're applied, by
+	// the TUI's keymap.
+	Keys map[string][]string `yaml:"keys"`
+}
+
+// Columns lists, in order, the columns a table shows outside wide mode
+// (which shows them all), by the names in PodColumns and
+// DeploymentColumns; Name always comes first and isn't listed. An empty
+// list keeps the built-in set. "C" on either tab changes them for the
+// session.
+type Columns struct {
+	Pods        []string `yaml:"pods"`
+	Deployments []string `yaml:"deployments"`
+}
+
+// PodColumns and DeploymentColumns are the column names Columns takes,
+// in wide mode's order.
+var (
+	PodColumns        = []string{"namespace", "status", "ready", "restarts", "age", "node", "node_ip", "pod_ip", "image", "owner", "qos", "priority", "context"}
+	DeploymentColumns = []string{"namespace", "age", "replicas", "available", "updated", "strategy", "image", "context", "selector"}
+)
+
+// RequestBudget is the per-context API request load ktails aims to stay
+// under on shared clusters. Zero means the built-in default
+// (DefaultMaxInFlight, DefaultMaxPerMinute).
+type RequestBudget struct {
+	MaxInFlight  int `yaml:"max_in_flight"`  // requests awaiting a response at once
+	MaxPerMinute int `yaml:"max_per_minute"` // requests sent in any one minute
+}
+
+// Built-in request budget limits, well under the API server's default
+// per-client rate limits.
+const (
+	DefaultMaxInFlight  = 20
+	DefaultMaxPerMinute = 600
+)
+
+// Limits returns the budget with the built-in default filled in for any
+// unset limit.
+func (b RequestBudget) Limits() RequestBudget {
+	if b.MaxInFlight == 0 {
+		b.MaxInFlight = DefaultMaxInFlight
+	}
+	if b.MaxPerMinute == 0 {
+		b.MaxPerMinute = DefaultMaxPerMinute
+	}
+	return b
+}
+
+// Loading is how many API requests ktails has awaiting a response at once
+// across every selected context, and how long one may go unanswered
+// before its context is reported slow. Zero means the built-in default
+// (DefaultLoadParallelism, DefaultLoadTimeout).
+type Loading struct {
+	Parallelism int    `yaml:"parallelism"`
+	Timeout     string `yaml:"timeout"` // a duration, e.g. "15s"
+}
+
+// Built-in loading limits.
+const (
+	DefaultLoadParallelism = 8
+	DefaultLoadTimeout     = 15 * time.Second
+)
+
+// Limits returns the parallelism and timeout, with the built-in default
+// for either left unset. Validate has already rejected a timeout that
+// doesn't parse.
+func (l Loading) Limits() (parallelism int, timeout time.Duration) {
+	parallelism, timeout = l.Parallelism, DefaultLoadTimeout
+	if parallelism == 0 {
+		parallelism = DefaultLoadParallelism
+	}
+	if d, err := time.ParseDuration(l.Timeout); err == nil && d > 0 {
+		timeout = d
+	}
+	return parallelism, timeout
+}
+
+// HealthRule maps one kind's status to a health. Either Field (a path into
+// the object, e.g. "status.conditions[type=Ready].status") is looked up and
+// compared against Healthy/Degraded, or Expr — a CEL expression over
+// `object` — is evaluated: true/false mean Healthy/Degraded, and a string
+// result is used as the status verbatim. Expr wins when both are set.
+type HealthRule struct {
+	Group    string   `yaml:"group"` // API group, e.g. "cert-manager.io"; empty matches any
+	Kind     string   `yaml:"kind"`
+	Field    string   `yaml:"field"`
+	Healthy  []string `yaml:"healthy"`
+	Degraded []string `yaml:"degraded"`
+	Expr     string   `yaml:"expr"`
+}
+
+// DefaultProfile names the profile of the top-level kubeconfig settings,
+// the one ktails starts in. A profile of that name in the config replaces
+// it, to give it contexts to load.
+const DefaultProfile = "default"
+
+// Profile is an environment to switch to: the kubeconfig files merged in
+// place of the top-level ones, and the contexts loaded on switching.
+type Profile struct {
+	Name string `yaml:"name"`
+	// KubeconfigPaths are merged in order, as KUBECONFIG would be. Empty
+	// keeps the top-level kubeconfig, for a profile that only picks
+	// contexts.
+	KubeconfigPaths []string `yaml:"kubeconfig_paths"`
+	// Contexts are loaded on switching, each in Namespaces (empty: the
+	// context's own). With none, the contexts pane is left to pick from.
+	Contexts   []string `yaml:"contexts"`
+	Namespaces []string `yaml:"namespaces"`
+}
+
+// Selection is the contexts to load on switching to the profile, each with
+// its namespaces.
+func (p Profile) Selection() map[string][]string {
+	selection := make(map[string][]string, len(p.Contexts))
+	for _, context := range p.Contexts {
+		selection[context] = p.Namespaces
+	}
+	return selection
+}
+
+// LogLevelSwitch is one way of changing an app's log level: either
+// Annotation is set on the pod, or Key is set in ConfigMap. ConfigMap and
+// Value are Go templates over LogLevelTemplateData, e.g. ConfigMap
+// "{{.Labels.app}}-logging" and Value `{"level":"{{.Level}}"}`; an empty
+// Value writes the level itself.
+type LogLevelSwitch struct {
+	Name       string            `yaml:"name"`
+	Selector   map[string]string `yaml:"selector"` // pod labels to match; empty matches every pod
+	Levels     []string          `yaml:"levels"`
+	Annotation string            `yaml:"annotation"`
+	ConfigMap  string            `yaml:"configmap"`
+	Key        string            `yaml:"key"`
+	Value      string            `yaml:"value"`
+}
+
+// PaneTemplate is a layout for one kind of workload, e.g. a "web app"
+// tailing its app and nginx sidecar containers alongside its events.
+type PaneTemplate struct {
+	Name       string            `yaml:"name"`
+	Selector   map[string]string `yaml:"selector"`   // deployment labels to match; empty matches every deployment
+	Containers []string          `yaml:"containers"` // containers to tail in each pod; empty tails all of them
+	Events     bool              `yaml:"events"`     // also show the deployment's, its ReplicaSets' and its pods' events
+}
+
+// Matches reports whether the template applies to a deployment with these
+// labels.
+func (t PaneTemplate) Matches(labels map[string]string) bool {
+	for k, v := range t.Selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Watermark is the badge shown on panes bound to contexts matching
+// Context, a pattern in which "*" matches any run of characters ("/" and
+// ":" included, for EKS ARNs), e.g. "prod-*" or "*production*".
+type Watermark struct {
+	Context string `yaml:"context"`
+	Text    string `yaml:"text"`  // the badge's text; empty shows the context's name
+	Color   string `yaml:"color"` // badge background, a hex color or ANSI number; default red
+}
+
+// Matches reports whether the watermark applies to context.
+func (w Watermark) Matches(context string) bool {
+	return ContextMatches(w.Context, context)
+}
+
+// ContextMatches reports whether context matches pattern, in which "*"
+// matches any run of characters, as Watermark.Context and
+// AlertRule.Context do.
+func ContextMatches(pattern, context string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return context == pattern
+	}
+	last := len(parts) - 1
+	if !strings.HasPrefix(context, parts[0]) || !strings.HasSuffix(context[len(parts[0]):], parts[last]) {
+		return false
+	}
+	rest := context[len(parts[0]) : len(context)-len(parts[last])]
+	for _, part := range parts[1:last] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	return true
+}
+
+// Label is the badge's text for context.
+func (w Watermark) Label(context string) string {
+	if w.Text != "" {
+		return w.Text
+	}
+	return context
+}
+
+// AlertRule is one condition worth being told about: a log line in an
+// open log pane matching Pattern (a regular expression), a pod's restarts
+// rising to Restarts or past it, or a pod's status changing to one of
+// Phases, as the Pods tab's Status column shows it ("CrashLoopBackOff",
+// "Failed"; "*" for any change). Exactly one of the three is set.
+type AlertRule struct {
+	Name     string   `yaml:"name"`
+	Pattern  string   `yaml:"pattern"`
+	Restarts int32    `yaml:"restarts"`
+	Phases   []string `yaml:"phases"`
+	Context  string   `yaml:"context"` // a pattern as for Watermark.Context; empty matches every context
+	Bell     bool     `yaml:"bell"`    // ring the terminal bell
+	Notify   bool     `yaml:"notify"`  // desktop notification, for terminals that support OSC 9
+}
+
+// Share is a paste service: a GitHub gist (Kind "gist", authenticated
+// with the token in the environment variable TokenEnv) or any endpoint
+// that takes the text as an HTTP POST and answers with the paste's URL
+// (Kind "http"). An empty Kind turns sharing off.
+type Share struct {
+	Kind string `yaml:"kind"`
+	// URL is the endpoint posted to; for a gist, GitHub's API (see
+	// DefaultGistURL) unless it's a GitHub Enterprise one.
+	URL string `yaml:"url"`
+	// TokenEnv names the variable holding the gist token; GITHUB_TOKEN if
+	// empty.
+	TokenEnv string `yaml:"token_env"`
+	// Public makes a gist public rather than secret.
+	Public bool `yaml:"public"`
+	// Headers are sent with an http paste, ${VAR}s expanded from the
+	// environment so a token needn't live in the config.
+	Headers map[string]string `yaml:"headers"`
+	// URLField is the field of an http paste's JSON response holding its
+	// URL; empty takes the Location header, else the whole body.
+	URLField string `yaml:"url_field"`
+}
+
+// DefaultGistURL is GitHub's gist API, where a gist Share posts unless
+// URL says otherwise.
+const DefaultGistURL = "https://api.github.com/gists"
+
+// LogLink is a URL template (Go text/template, executed with
+// LogLinkData) for pods in contexts matching Context, a pattern as for
+// Watermark.Context; empty matches every context. Window is how far back a
+// link from the Pods tab reaches, a duration; DefaultLogLinkWindow if
+// empty.
+type LogLink struct {
+	Context string `yaml:"context"`
+	URL     string `yaml:"url"`
+	Window  string `yaml:"window"`
+}
+
+// DefaultLogLinkWindow is how far back a LogLink without a Window reaches.
+const DefaultLogLinkWindow = time.Hour
+
+// LogLinkData is what a LogLink's template is executed with: the pod, and
+// the time window to query. From and To are time.Times, so a template can
+// write {{.From.UnixMilli}} or {{.To.Format "2006-01-02T15:04:05Z07:00"}};
+// urlquery escapes a value for the URL.
+type LogLinkData struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Container string // empty from the Pods tab, which links every container
+	From      time.Time
+	To        time.Time
+}
+
+// Matches reports whether the link applies to pods in context.
+func (l LogLink) Matches(context string) bool {
+	return l.Context == "" || ContextMatches(l.Context, context)
+}
+
+// Span is how far back a link from the Pods tab reaches. Validate has
+// already rejected a window that doesn't parse.
+func (l LogLink) Span() time.Duration {
+	if d, err := time.ParseDuration(l.Window); err == nil && d > 0 {
+		return d
+	}
+	return DefaultLogLinkWindow
+}
+
+// Render executes the link's template for data.
+func (l LogLink) Render(data LogLinkData) (string, error) {
+	return execTemplate("url", l.URL, data)
+}
+
+// LogLevelTemplateData is what a LogLevelSwitch's templates are executed
+// with.
+type LogLevelTemplateData struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Container string
+	Labels    map[string]string
+	Level     string
+}
+
+// Matches reports whether the switch applies to a pod with these labels.
+func (s LogLevelSwitch) Matches(labels map[string]string) bool {
+	for k, v := range s.Selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Render executes the switch's templates: the ConfigMap to write to (""
+// for an annotation switch) and the value to write.
+func (s LogLevelSwitch) Render(data LogLevelTemplateData) (configMap, value string, err error) {
+	if s.ConfigMap != "" {
+		if configMap, err = execTemplate("configmap", s.ConfigMap, data); err != nil {
+			return "", "", err
+		}
+	}
+	if s.Value == "" {
+		return configMap, data.Level, nil
+	}
+	if value, err = execTemplate("value", s.Value, data); err != nil {
+		return "", "", err
+	}
+	return configMap, value, nil
+}
+
+func execTemplate(name, text string, data any) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return b.String(), nil
 }
 
 // Preferences contains user preferences
@@ -30,43 +430,127 @@ type Preferences struct {
 	RefreshInterval int    `yaml:"refresh_interval"`  // Seconds between pod info refresh
 	ShowTimestamps  bool   `yaml:"show_timestamps"`   // Show log timestamps
 	ColorCodeLogs   bool   `yaml:"color_code_logs"`   // Color code log levels
+	CollapseRepeats bool   `yaml:"collapse_repeats"`  // Fold runs of identical log lines into one "×N"
 	SyncScroll      bool   `yaml:"sync_scroll"`       // Sync scrolling between panes
+
+	// IdlePause is how long ktails may sit without a key pressed before it
+	// closes its watches and log streams, resuming them on the next key —
+	// so a session forgotten overnight doesn't hold connections open on
+	// every cluster. A duration like "1h"; "0" never pauses.
+	IdlePause string `yaml:"idle_pause"`
+
+	// DemoMode shows contexts, namespaces, names and IPs as consistent
+	// pseudonyms, for screen sharing (also --demo).
+	DemoMode bool `yaml:"demo_mode"`
+
+	// ReadOnly turns off every action that changes a cluster or runs a
+	// process in a container — deleting pods, restarting and rolling back
+	// Deployments, switching app log levels, shells and the file browser —
+	// for pointing ktails at production (also --read-only).
+	ReadOnly bool `yaml:"read_only"`
+
+	// AccessChecks has ktails ask the API server (SelfSubjectAccessReview)
+	// before listing a tab's resources whether it may, so a tab RBAC
+	// forbids says which permission is missing, and the pod actions the
+	// user may not perform are disabled.
+	AccessChecks bool `yaml:"access_checks"`
+
+	// Mouse lets clicks focus panes, table rows, contexts and tabs, and
+	// the wheel scroll them. Off, the terminal keeps its own text
+	// selection.
+	Mouse bool `yaml:"mouse"`
+
+	// TailLines is how many existing lines a log pane backfills per source
+	// (0: all of them); LogSince, a duration like "5m" or "1h", instead
+	// starts each source that far back. The log pane's "T" cycles through
+	// since presets for the current pane.
+	TailLines int    `yaml:"tail_lines"`
+	LogSince  string `yaml:"log_since"`
+
+	// BacklogWarnLines is how long a log source's backfill may get before
+	// the pane stops reading it and asks whether to load only the newest
+	// lines, every Nth line, or all of it; 0 never asks.
+	BacklogWarnLines int `yaml:"backlog_warn_lines"`
+
+	// LogFields picks the columns the Log pane's structured view shows for
+	// JSON/logfmt lines, in order. "level", "msg" and "timestamp" match their
+	// common aliases (lvl, message, ts, ...); any other name matches that key
+	// literally.
+	LogFields []string `yaml:"log_fields"`
+}
+
+// DefaultTailLines is the built-in Preferences.TailLines, matching
+// `kubectl logs -f --tail=200`.
+const DefaultTailLines = 200
+
+// DefaultBacklogWarnLines is the built-in Preferences.BacklogWarnLines.
+const DefaultBacklogWarnLines = 100000
+
+// Since returns LogSince as a duration, 0 when unset. Validate has already
+// rejected one that doesn't parse.
+func (p Preferences) Since() time.Duration {
+	d, _ := time.ParseDuration(p.LogSince)
+	return d
 }
 
-// RecentPod represents a recently viewed pod
-type RecentPod struct {
-	Context   string    `yaml:"context"`
-	Namespace string    `yaml:"namespace"`
-	Pod       string    `yaml:"pod"`
-	LastUsed  time.Time `yaml:"last_used"`
+// IdleAfter returns IdlePause as a duration, 0 when pausing is off.
+// Validate has already rejected one that doesn't parse.
+func (p Preferences) IdleAfter() time.Duration {
+	d, _ := time.ParseDuration(p.IdlePause)
+	return d
 }
 
 // DefaultConfig returns a config with default values
 func DefaultConfig() *Config {
 	return &Config{
 		Preferences: Preferences{
-			Theme:           "dark",
-			FollowByDefault: true,
-			MaxLogLines:     1000,
-			RefreshInterval: 5,
-			ShowTimestamps:  true,
-			ColorCodeLogs:   true,
-			SyncScroll:      false,
+			Theme:            "dark",
+			FollowByDefault:  true,
+			MaxLogLines:      1000,
+			RefreshInterval:  5,
+			ShowTimestamps:   true,
+			ColorCodeLogs:    true,
+			SyncScroll:       false,
+			TailLines:        DefaultTailLines,
+			BacklogWarnLines: DefaultBacklogWarnLines,
+			IdlePause:        "1h",
+			Mouse:            true,
+			LogFields:        []string{"timestamp", "level", "msg"},
 		},
-		RecentPods:     make([]RecentPod, 0),
 		KubeconfigPath: "", // Will use default
 	}
 }
 
-// GetDefaultConfigPath returns the default config file path
+// GetDefaultConfigPath returns the default config file path:
+// $XDG_CONFIG_HOME/ktails/config.yaml, or ~/.config/ktails/config.yaml
 func GetDefaultConfigPath() (string, error) {
+	configDir, err := xdgDir("XDG_CONFIG_HOME", ".config")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config.yaml"), nil
+}
+
+// GetDefaultStateDir returns the directory ktails keeps what it records
+// while running (the session, recent pods) in, apart from the config so
+// the config can be kept in version control without churn:
+// $XDG_STATE_HOME/ktails, or ~/.local/state/ktails
+func GetDefaultStateDir() (string, error) {
+	return xdgDir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// xdgDir returns ktails' directory under the base directory named by the
+// XDG variable env, or under fallback in the home directory when it's
+// unset (or, as the spec requires, not absolute).
+func xdgDir(env, fallback string) (string, error) {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, "ktails"), nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get user home directory: %w", err)
 	}
-
-	configDir := filepath.Join(home, ".config", "ktails")
-	return filepath.Join(configDir, "config.yaml"), nil
+	return filepath.Join(home, fallback, "ktails"), nil
 }
 
 // EnsureConfigDir creates the config directory if it doesn't exist
@@ -109,18 +593,42 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse YAML
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	cfg, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+	return cfg, nil
+}
+
+// parse reads a config file's contents over the defaults — a file only
+// needs the settings it changes, and a section it names keeps the
+// defaults of the keys it leaves out — and checks the result against the
+// schema (checkKeys) and Validate. Every problem found is reported, each
+// on its own line.
+func parse(data []byte) (*Config, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	// Validate config
+	cfg := DefaultConfig()
+	errs := checkKeys(&root, reflect.TypeOf(cfg), "")
+	if err := root.Decode(cfg); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		for _, e := range typeErr.Errors {
+			errs = append(errs, errors.New(e))
+		}
+	}
 	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
+		errs = append(errs, err)
 	}
-
-	return &cfg, nil
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
 }
 
 // Save saves configuration to file
@@ -154,82 +662,209 @@ func (c *Config) Save(path string) error {
 	return nil
 }
 
-// Validate checks if the config has valid values
+// Validate checks the config's values, reporting every problem rather
+// than just the first, so one run of ktails is enough to fix them all.
 func (c *Config) Validate() error {
+	var errs []error
+
 	// Validate theme
 	if c.Preferences.Theme != "dark" && c.Preferences.Theme != "light" {
-		return fmt.Errorf("invalid theme: %s (must be 'dark' or 'light')", c.Preferences.Theme)
+		errs = append(errs, fmt.Errorf("invalid theme: %s (must be 'dark' or 'light')", c.Preferences.Theme))
 	}
 
 	// Validate numeric values
 	if c.Preferences.MaxLogLines < 100 {
-		return fmt.Errorf("max_log_lines must be at least 100, got %d", c.Preferences.MaxLogLines)
+		errs = append(errs, fmt.Errorf("max_log_lines must be at least 100, got %d", c.Preferences.MaxLogLines))
 	}
 
 	if c.Preferences.RefreshInterval < 1 {
-		return fmt.Errorf("refresh_interval must be at least 1 second, got %d", c.Preferences.RefreshInterval)
+		errs = append(errs, fmt.Errorf("refresh_interval must be at least 1 second, got %d", c.Preferences.RefreshInterval))
 	}
 
-	return nil
-}
+	if c.Preferences.TailLines < 0 {
+		errs = append(errs, fmt.Errorf("tail_lines must not be negative, got %d", c.Preferences.TailLines))
+	}
 
-// AddRecentPod adds a pod to recent history
-func (c *Config) AddRecentPod(context, namespace, pod string) {
-	// Validate inputs
-	if context == "" || namespace == "" || pod == "" {
-		return
+	if c.Preferences.BacklogWarnLines < 0 {
+		errs = append(errs, fmt.Errorf("backlog_warn_lines must not be negative, got %d", c.Preferences.BacklogWarnLines))
 	}
 
-	// Remove if already exists
-	for i, rp := range c.RecentPods {
-		if rp.Context == context && rp.Namespace == namespace && rp.Pod == pod {
-			c.RecentPods = append(c.RecentPods[:i], c.RecentPods[i+1:]...)
-			break
+	if c.Preferences.LogSince != "" {
+		if d, err := time.ParseDuration(c.Preferences.LogSince); err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("log_since must be a positive duration like 5m or 1h, got %q", c.Preferences.LogSince))
 		}
 	}
 
-	// Add to front
-	c.RecentPods = append([]RecentPod{
-		{
-			Context:   context,
-			Namespace: namespace,
-			Pod:       pod,
-			LastUsed:  time.Now(),
-		},
-	}, c.RecentPods...)
+	if c.Preferences.IdlePause != "" {
+		if d, err := time.ParseDuration(c.Preferences.IdlePause); err != nil || (d != 0 && d < time.Minute) {
+			errs = append(errs, fmt.Errorf("idle_pause must be a duration of at least 1m, or 0 to never pause, got %q", c.Preferences.IdlePause))
+		}
+	}
 
-	// Keep only last 20
-	if len(c.RecentPods) > 20 {
-		c.RecentPods = c.RecentPods[:20]
+	for i, f := range c.Preferences.LogFields {
+		if f == "" {
+			errs = append(errs, fmt.Errorf("log_fields[%d] must not be empty", i))
+		}
+	}
+
+	errs = append(errs, checkColumns("columns.pods", c.Columns.Pods, PodColumns)...)
+	errs = append(errs, checkColumns("columns.deployments", c.Columns.Deployments, DeploymentColumns)...)
+
+	if c.RequestBudget.MaxInFlight < 0 || c.RequestBudget.MaxPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("request_budget limits must not be negative"))
+	}
+	if c.Loading.Parallelism < 0 {
+		errs = append(errs, fmt.Errorf("loading.parallelism must not be negative, got %d", c.Loading.Parallelism))
+	}
+	if c.Loading.Timeout != "" {
+		if d, err := time.ParseDuration(c.Loading.Timeout); err != nil || d < time.Second {
+			errs = append(errs, fmt.Errorf("loading.timeout must be a duration of at least 1s, got %q", c.Loading.Timeout))
+		}
 	}
-}
 
-// GetRecentPods returns recent pods, optionally filtered by context
-func (c *Config) GetRecentPods(context string) []RecentPod {
-	if context == "" {
-		return c.RecentPods
+	// CEL expressions and field paths are compiled (and so fully checked)
+	// by health.Compile; this only catches rules that can never apply.
+	for i, r := range c.HealthRules {
+		if r.Kind == "" {
+			errs = append(errs, fmt.Errorf("health_rules[%d]: kind is required", i))
+		}
+		if r.Field == "" && r.Expr == "" {
+			errs = append(errs, fmt.Errorf("health_rules[%d] (%s): one of field or expr is required", i, r.Kind))
+		}
+		if r.Expr == "" && len(r.Healthy) == 0 && len(r.Degraded) == 0 {
+			errs = append(errs, fmt.Errorf("health_rules[%d] (%s): field needs healthy and/or degraded values", i, r.Kind))
+		}
 	}
 
-	filtered := make([]RecentPod, 0)
-	for _, rp := range c.RecentPods {
-		if rp.Context == context {
-			filtered = append(filtered, rp)
+	for i, sw := range c.LogLevelSwitches {
+		if sw.Name == "" {
+			errs = append(errs, fmt.Errorf("log_level_switches[%d]: name is required", i))
+		}
+		if len(sw.Levels) == 0 {
+			errs = append(errs, fmt.Errorf("log_level_switches[%d] (%s): levels are required", i, sw.Name))
+		}
+		if (sw.Annotation == "") == (sw.ConfigMap == "") {
+			errs = append(errs, fmt.Errorf("log_level_switches[%d] (%s): exactly one of annotation or configmap is required", i, sw.Name))
+		}
+		if sw.ConfigMap != "" && sw.Key == "" {
+			errs = append(errs, fmt.Errorf("log_level_switches[%d] (%s): configmap needs a key", i, sw.Name))
+		}
+		if _, err := template.New("configmap").Parse(sw.ConfigMap); err != nil {
+			errs = append(errs, fmt.Errorf("log_level_switches[%d] (%s): invalid configmap template: %w", i, sw.Name, err))
+		}
+		if _, err := template.New("value").Parse(sw.Value); err != nil {
+			errs = append(errs, fmt.Errorf("log_level_switches[%d] (%s): invalid value template: %w", i, sw.Name, err))
+		}
+	}
+
+	for i, t := range c.PaneTemplates {
+		if t.Name == "" {
+			errs = append(errs, fmt.Errorf("pane_templates[%d]: name is required", i))
+		}
+		for _, cntnr := range t.Containers {
+			if cntnr == "" {
+				errs = append(errs, fmt.Errorf("pane_templates[%d] (%s): container names must not be empty", i, t.Name))
+			}
+		}
+	}
+
+	for i, p := range c.KubeconfigPaths {
+		if p == "" {
+			errs = append(errs, fmt.Errorf("kubeconfig_paths[%d] must not be empty", i))
+		}
+	}
+
+	profiles := make(map[string]bool)
+	for i, p := range c.Profiles {
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("profiles[%d]: name is required", i))
+		} else if profiles[p.Name] {
+			errs = append(errs, fmt.Errorf("profiles[%d]: name %q is used twice", i, p.Name))
+		}
+		profiles[p.Name] = true
+		for j, path := range p.KubeconfigPaths {
+			if path == "" {
+				errs = append(errs, fmt.Errorf("profiles[%d] (%s): kubeconfig_paths[%d] must not be empty", i, p.Name, j))
+			}
+		}
+		if slices.Contains(p.Contexts, "") || slices.Contains(p.Namespaces, "") {
+			errs = append(errs, fmt.Errorf("profiles[%d] (%s): contexts and namespaces must not be empty", i, p.Name))
+		}
+	}
+
+	for i, w := range c.Watermarks {
+		if w.Context == "" {
+			errs = append(errs, fmt.Errorf("watermarks[%d]: context is required", i))
+		}
+	}
+
+	// Patterns are compiled (and so fully checked) by alerts.Compile.
+	names := make(map[string]bool, len(c.AlertRules))
+	for i, r := range c.AlertRules {
+		if r.Name == "" {
+			errs = append(errs, fmt.Errorf("alert_rules[%d]: name is required", i))
+		} else if names[r.Name] {
+			errs = append(errs, fmt.Errorf("alert_rules[%d]: name %q is used twice", i, r.Name))
+		}
+		names[r.Name] = true
+		set := 0
+		for _, isSet := range []bool{r.Pattern != "", r.Restarts != 0, len(r.Phases) > 0} {
+			if isSet {
+				set++
+			}
+		}
+		if set != 1 {
+			errs = append(errs, fmt.Errorf("alert_rules[%d] (%s): exactly one of pattern, restarts or phases is required", i, r.Name))
+		}
+		if r.Restarts < 0 {
+			errs = append(errs, fmt.Errorf("alert_rules[%d] (%s): restarts must not be negative, got %d", i, r.Name, r.Restarts))
+		}
+	}
+
+	for i, l := range c.LogLinks {
+		if l.URL == "" {
+			errs = append(errs, fmt.Errorf("log_links[%d]: url is required", i))
+		} else if _, err := template.New("url").Parse(l.URL); err != nil {
+			errs = append(errs, fmt.Errorf("log_links[%d]: invalid url template: %w", i, err))
+		}
+		if l.Window != "" {
+			if d, err := time.ParseDuration(l.Window); err != nil || d <= 0 {
+				errs = append(errs, fmt.Errorf("log_links[%d]: window must be a positive duration like 1h, got %q", i, l.Window))
+			}
+		}
+	}
+
+	switch c.Share.Kind {
+	case "", "gist":
+	case "http":
+		if c.Share.URL == "" {
+			errs = append(errs, fmt.Errorf("share: kind http needs a url"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("share: invalid kind %q (must be 'gist' or 'http')", c.Share.Kind))
+	}
+	if c.Share.URL != "" {
+		if u, err := url.Parse(c.Share.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("share: url must be an http(s) URL, got %q", c.Share.URL))
 		}
 	}
-	return filtered
-}
 
-// ClearRecentPods removes all recent pod entries
-func (c *Config) ClearRecentPods() {
-	c.RecentPods = make([]RecentPod, 0)
+	return errors.Join(errs...)
 }
 
-// RemoveRecentPod removes a specific pod from recent history
-func (c *Config) RemoveRecentPod(context, namespace, pod string) {
-	for i, rp := range c.RecentPods {
-		if rp.Context == context && rp.Namespace == namespace && rp.Pod == pod {
-			c.RecentPods = append(c.RecentPods[:i], c.RecentPods[i+1:]...)
-			return
+// checkColumns reports names in a columns list that aren't among known,
+// or that repeat.
+func checkColumns(path string, names, known []string) []error {
+	var errs []error
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		switch {
+		case !slices.Contains(known, name):
+			errs = append(errs, fmt.Errorf("%s[%d]: unknown column %q (one of %s)", path, i, name, strings.Join(known, ", ")))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("%s[%d]: column %q is listed twice", path, i, name))
 		}
+		seen[name] = true
 	}
+	return errs
 }
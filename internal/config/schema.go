@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// checkKeys reports every key in the mapping node that the type it
+// decodes into has no field for — with yaml.v3 those are dropped without
+// a word, so a misspelt setting would silently keep its default. Nested
+// mappings and sequences are checked too; each problem names the key's
+// path and line, and the closest known key if one is near enough to be a
+// typo.
+func checkKeys(node *yaml.Node, t reflect.Type, path string) []error {
+	if node == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		var errs []error
+		for _, child := range node.Content {
+			errs = append(errs, checkKeys(child, t, path)...)
+		}
+		return errs
+	case yaml.SequenceNode:
+		if t.Kind() != reflect.Slice {
+			return nil
+		}
+		var errs []error
+		for i, child := range node.Content {
+			errs = append(errs, checkKeys(child, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	case yaml.MappingNode:
+		if t.Kind() != reflect.Struct {
+			// A map (a selector, say) takes any key.
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	fields := yamlFields(t)
+	var errs []error
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+		field, ok := fields[key.Value]
+		if !ok {
+			errs = append(errs, unknownKey(key, keyPath, fields))
+			continue
+		}
+		errs = append(errs, checkKeys(value, field, keyPath)...)
+	}
+	return errs
+}
+
+// yamlFields maps the yaml keys of struct type t to their fields' types.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// unknownKey is the problem for a key no field takes, suggesting the known
+// key it's likely a typo of.
+func unknownKey(key *yaml.Node, keyPath string, fields map[string]reflect.Type) error {
+	best, bestDist := "", 0
+	for name := range fields {
+		d := editDistance(key.Value, name)
+		if best == "" || d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if best != "" && bestDist <= max(2, len(key.Value)/3) {
+		return fmt.Errorf("line %d: unknown key %q (did you mean %q?)", key.Line, keyPath, best)
+	}
+	return fmt.Errorf("line %d: unknown key %q", key.Line, keyPath)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_ReportsUnknownKeysWithSuggestions(t *testing.T) {
+	_, err := parse([]byte(`preferences:
+  tial_lines: 500
+  max_log_lines: 50
+request_budget:
+  max_per_minit: 100
+watermarks:
+  - context: prod-*
+    colour: "#ff0000"
+frobnicate: true
+`))
+	if err == nil {
+		t.Fatal("expected the config to be rejected")
+	}
+	for _, want := range []string{
+		`line 2: unknown key "preferences.tial_lines" (did you mean "tail_lines"?)`,
+		`line 5: unknown key "request_budget.max_per_minit" (did you mean "max_per_minute"?)`,
+		`line 8: unknown key "watermarks[0].colour" (did you mean "color"?)`,
+		`line 9: unknown key "frobnicate"`,
+		"max_log_lines must be at least 100, got 50",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), `"frobnicate" (did you mean`) {
+		t.Errorf("no known key is close to frobnicate, yet one was suggested:\n%v", err)
+	}
+}
+
+func TestParse_MergesOverDefaults(t *testing.T) {
+	cfg, err := parse([]byte("preferences:\n  tail_lines: 500\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := DefaultConfig().Preferences
+	if cfg.Preferences.TailLines != 500 {
+		t.Errorf("TailLines = %d, want 500", cfg.Preferences.TailLines)
+	}
+	if cfg.Preferences.MaxLogLines != def.MaxLogLines || cfg.Preferences.BacklogWarnLines != def.BacklogWarnLines {
+		t.Errorf("keys left out of preferences lost their defaults: %+v", cfg.Preferences)
+	}
+
+	if _, err := parse(nil); err != nil {
+		t.Errorf("an empty file should give the defaults, got %v", err)
+	}
+}
+
+func TestParse_ChecksLoadingLimits(t *testing.T) {
+	cfg, err := parse([]byte("loading:\n  parallelism: 4\n  timeout: 30s\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, timeout := cfg.Loading.Limits(); p != 4 || timeout != 30*time.Second {
+		t.Errorf("Limits() = %d, %v, want 4, 30s", p, timeout)
+	}
+	if p, timeout := DefaultConfig().Loading.Limits(); p != DefaultLoadParallelism || timeout != DefaultLoadTimeout {
+		t.Errorf("default Limits() = %d, %v", p, timeout)
+	}
+
+	_, err = parse([]byte("loading:\n  parallelism: -1\n  timeout: 100ms\n"))
+	if err == nil {
+		t.Fatal("expected the config to be rejected")
+	}
+	for _, want := range []string{"loading.parallelism must not be negative", `loading.timeout must be a duration of at least 1s, got "100ms"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestParse_ChecksColumnNames(t *testing.T) {
+	_, err := parse([]byte("columns:\n  pods: [status, node, imag, node]\n  deployments: [image]\n"))
+	if err == nil {
+		t.Fatal("expected the config to be rejected")
+	}
+	for _, want := range []string{
+		`columns.pods[2]: unknown column "imag"`,
+		`columns.pods[3]: column "node" is listed twice`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "columns.deployments") {
+		t.Errorf("image is a deployments column, yet it was rejected:\n%v", err)
+	}
+}
+
+func TestParse_KeepsKeys(t *testing.T) {
+	cfg, err := parse([]byte("keys:\n  quit: [ctrl+q, ctrl+c]\n  up: [up, j]\n  down: [down, k]\n  focus_next: []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Keys["up"]; len(got) != 2 || got[1] != "j" {
+		t.Errorf("Keys[up] = %v, want [up j]", got)
+	}
+	if got, ok := cfg.Keys["focus_next"]; !ok || len(got) != 0 {
+		t.Errorf("Keys[focus_next] = %v (listed %v), want it kept, empty", got, ok)
+	}
+}
+
+func TestParse_ChecksShare(t *testing.T) {
+	if _, err := parse([]byte("share:\n  kind: gist\n  public: true\n")); err != nil {
+		t.Fatalf("a gist on the default API should be accepted: %v", err)
+	}
+	_, err := parse([]byte("share:\n  kind: http\n"))
+	if err == nil || !strings.Contains(err.Error(), "share: kind http needs a url") {
+		t.Errorf("expected http without a url rejected, got %v", err)
+	}
+	_, err = parse([]byte("share:\n  kind: pastebin\n  url: ftp://paste.example\n"))
+	if err == nil {
+		t.Fatal("expected the config to be rejected")
+	}
+	for _, want := range []string{`share: invalid kind "pastebin"`, `share: url must be an http(s) URL, got "ftp://paste.example"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestParse_Profiles(t *testing.T) {
+	cfg, err := parse([]byte(`profiles:
+  - name: prod
+    kubeconfig_paths: [/etc/kube/prod-eu, /etc/kube/prod-us]
+    contexts: [prod-eu, prod-us]
+    namespaces: [payments]
+  - name: staging
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := cfg.Profiles[0].Selection()
+	if len(got) != 2 || !slices.Equal(got["prod-us"], []string{"payments"}) {
+		t.Errorf("Selection = %v", got)
+	}
+	if len(cfg.Profiles[1].Selection()) != 0 {
+		t.Errorf("expected a profile without contexts to load none")
+	}
+
+	_, err = parse([]byte("profiles:\n  - name: prod\n    contexts: [\"\"]\n  - name: prod\n  - name: dev\n    kubeconfig_paths: [\"\"]\n  - contexts: [dev]\n"))
+	if err == nil {
+		t.Fatal("expected the config to be rejected")
+	}
+	for _, want := range []string{
+		"profiles[0] (prod): contexts and namespaces must not be empty",
+		`profiles[1]: name "prod" is used twice`,
+		"profiles[2] (dev): kubeconfig_paths[0] must not be empty",
+		"profiles[3]: name is required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestParse_LogLinks(t *testing.T) {
+	cfg, err := parse([]byte(`log_links:
+  - context: "prod-*"
+    url: 'https://grafana.example/explore?left={{urlquery (printf "{\"expr\":\"{pod=\\\"%s\\\"}\",\"from\":%d,\"to\":%d}" .Pod .From.UnixMilli .To.UnixMilli)}}'
+    window: 30m
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	l := cfg.LogLinks[0]
+	if !l.Matches("prod-eu") || l.Matches("staging") || l.Span() != 30*time.Minute {
+		t.Errorf("link %+v: wrong context match or window", l)
+	}
+	to := time.UnixMilli(1700000000000)
+	got, err := l.Render(LogLinkData{Pod: "api-0", From: to.Add(-l.Span()), To: to})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := "https://grafana.example/explore?left=%7B%22expr%22%3A%22%7Bpod%3D%5C%22api-0%5C%22%7D%22%2C%22from%22%3A1699998200000%2C%22to%22%3A1700000000000%7D"
+	if got != want {
+		t.Errorf("Render =\n%s\nwant\n%s", got, want)
+	}
+
+	_, err = parse([]byte("log_links:\n  - window: soon\n"))
+	if err == nil {
+		t.Fatal("expected the config to be rejected")
+	}
+	for _, want := range []string{"log_links[0]: url is required", `log_links[0]: window must be a positive duration like 1h, got "soon"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Session is the layout ktails was quit with — which contexts were loaded
+// from which namespaces, the active tab and the pods being tailed — so the
+// next start can offer to pick up where it left off.
+type Session struct {
+	Contexts  []SessionContext `yaml:"contexts"`
+	ActiveTab string           `yaml:"active_tab"`
+	Pods      []SessionPod     `yaml:"pods"` // pods in the log pane
+	SavedAt   time.Time        `yaml:"saved_at"`
+}
+
+// SessionContext is one loaded context. Namespaces are the ones picked
+// with the namespace picker; empty means the context's default.
+type SessionContext struct {
+	Name       string   `yaml:"name"`
+	Namespaces []string `yaml:"namespaces,omitempty"`
+}
+
+// SessionPod is one pod whose logs were being tailed.
+type SessionPod struct {
+	Context   string `yaml:"context"`
+	Namespace string `yaml:"namespace"`
+	Pod       string `yaml:"pod"`
+}
+
+// Empty reports whether there's nothing to restore.
+func (s *Session) Empty() bool {
+	return s == nil || len(s.Contexts) == 0
+}
+
+// GetDefaultSessionPath returns the session file's path, in the state
+// directory.
+func GetDefaultSessionPath() (string, error) {
+	stateDir, err := GetDefaultStateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, "session.yaml"), nil
+}
+
+// legacySessionPath is where the session file was kept before it moved to
+// the state directory: next to the config file.
+func legacySessionPath() (string, error) {
+	configPath, err := GetDefaultConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "session.yaml"), nil
+}
+
+// LoadSession reads the saved session. A missing file is no session (nil,
+// nil). If path is empty, uses the default session path, falling back to
+// one saved next to the config by an older ktails.
+func LoadSession(path string) (*Session, error) {
+	if path == "" {
+		defaultPath, err := GetDefaultSessionPath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default session path: %w", err)
+		}
+		path = defaultPath
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if legacy, err := legacySessionPath(); err == nil {
+				path = legacy
+			}
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	var s Session
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session file: %w", err)
+	}
+	return &s, nil
+}
+
+// Save writes the session. If path is empty, uses the default session path.
+func (s *Session) Save(path string) error {
+	if path == "" {
+		defaultPath, err := GetDefaultSessionPath()
+		if err != nil {
+			return fmt.Errorf("failed to get default session path: %w", err)
+		}
+		path = defaultPath
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is what ktails records as it runs, kept in the state directory
+// (see GetDefaultStateDir) rather than the config file, which stays
+// hand-edited preferences only.
+type State struct {
+	// Recent pods for quick access
+	RecentPods []RecentPod `yaml:"recent_pods"`
+
+	// ContextSort is the order of the contexts pane: "name" (the
+	// default), "cluster", "recent" (last loaded first) or "manual", the
+	// order in ContextOrder, set with shift+up/down. ContextsUsed is when
+	// each context was last loaded.
+	ContextSort  string               `yaml:"context_sort,omitempty"`
+	ContextOrder []string             `yaml:"context_order,omitempty"`
+	ContextsUsed map[string]time.Time `yaml:"contexts_used,omitempty"`
+
+	// SplitPercent is the detail/log pane's share of the tab area, as last
+	// resized with ctrl+up/down; 0 for the default.
+	SplitPercent int `yaml:"split_percent,omitempty"`
+
+	// ContextsCollapsed is whether the contexts pane was last left
+	// collapsed to its strip of badges (ctrl+b).
+	ContextsCollapsed bool `yaml:"contexts_collapsed,omitempty"`
+
+	// Watchlist is the pods and deployments pinned (w) to the watch tab,
+	// in the order they were pinned.
+	Watchlist []WatchedResource `yaml:"watchlist,omitempty"`
+
+	// LogWrap is whether log panes wrap long lines rather than pan them
+	// sideways, as last toggled (w).
+	LogWrap bool `yaml:"log_wrap,omitempty"`
+
+	// MutedAlerts names the alert rules muted (m) in the alert panel.
+	MutedAlerts []string `yaml:"muted_alerts,omitempty"`
+}
+
+// WatchedResource is a pod or deployment on the watchlist.
+type WatchedResource struct {
+	Kind      string `yaml:"kind"` // "pod" or "deployment"
+	Context   string `yaml:"context"`
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+}
+
+// RecentPod represents a recently viewed pod
+type RecentPod struct {
+	Context   string    `yaml:"context"`
+	Namespace string    `yaml:"namespace"`
+	Pod       string    `yaml:"pod"`
+	LastUsed  time.Time `yaml:"last_used"`
+}
+
+// GetDefaultStatePath returns the state file's path, in the state
+// directory.
+func GetDefaultStatePath() (string, error) {
+	stateDir, err := GetDefaultStateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, "state.yaml"), nil
+}
+
+// LoadState reads the state file. A missing file is an empty state. If
+// path is empty, uses the default state path.
+func LoadState(path string) (*State, error) {
+	if path == "" {
+		defaultPath, err := GetDefaultStatePath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default state path: %w", err)
+		}
+		path = defaultPath
+	}
+
+	s := &State{RecentPods: make([]RecentPod, 0)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	return s, nil
+}
+
+// Save writes the state file. If path is empty, uses the default state
+// path.
+func (s *State) Save(path string) error {
+	if path == "" {
+		defaultPath, err := GetDefaultStatePath()
+		if err != nil {
+			return fmt.Errorf("failed to get default state path: %w", err)
+		}
+		path = defaultPath
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// AddRecentPod adds a pod to recent history
+func (s *State) AddRecentPod(context, namespace, pod string) {
+	// Validate inputs
+	if context == "" || namespace == "" || pod == "" {
+		return
+	}
+
+	// Remove if already exists
+	for i, rp := range s.RecentPods {
+		if rp.Context == context && rp.Namespace == namespace && rp.Pod == pod {
+			s.RecentPods = append(s.RecentPods[:i], s.RecentPods[i+1:]...)
+			break
+		}
+	}
+
+	// Add to front
+	s.RecentPods = append([]RecentPod{
+		{
+			Context:   context,
+			Namespace: namespace,
+			Pod:       pod,
+			LastUsed:  time.Now(),
+		},
+	}, s.RecentPods...)
+
+	// Keep only last 20
+	if len(s.RecentPods) > 20 {
+		s.RecentPods = s.RecentPods[:20]
+	}
+}
+
+// GetRecentPods returns recent pods, optionally filtered by context
+func (s *State) GetRecentPods(context string) []RecentPod {
+	if context == "" {
+		return s.RecentPods
+	}
+
+	filtered := make([]RecentPod, 0)
+	for _, rp := range s.RecentPods {
+		if rp.Context == context {
+			filtered = append(filtered, rp)
+		}
+	}
+	return filtered
+}
+
+// ClearRecentPods removes all recent pod entries
+func (s *State) ClearRecentPods() {
+	s.RecentPods = make([]RecentPod, 0)
+}
+
+// RemoveRecentPod removes a specific pod from recent history
+func (s *State) RemoveRecentPod(context, namespace, pod string) {
+	for i, rp := range s.RecentPods {
+		if rp.Context == context && rp.Namespace == namespace && rp.Pod == pod {
+			s.RecentPods = append(s.RecentPods[:i], s.RecentPods[i+1:]...)
+			return
+		}
+	}
+}
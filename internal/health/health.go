@@ -0,0 +1,179 @@
+// Package health evaluates config-defined health rules (see
+// config.HealthRule) against arbitrary Kubernetes objects, so kinds ktails
+// knows nothing about — operators' custom resources — still get a
+// meaningful Healthy/Degraded status.
+package health
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+// Statuses a rule resolves to. A CEL rule returning a string may produce
+// any other status verbatim.
+const (
+	Healthy  = "Healthy"
+	Degraded = "Degraded"
+	Unknown  = "Unknown"
+)
+
+// celCostLimit bounds a single expression evaluation, so a rule looping over
+// a huge status list can't stall the UI.
+const celCostLimit = 100000
+
+// Result is one object's evaluated health.
+type Result struct {
+	Status string
+	// Reason says why the status is what it is — the field value matched,
+	// or the evaluation error behind an Unknown.
+	Reason string
+}
+
+// Evaluator holds compiled rules, in config order.
+type Evaluator struct {
+	rules []rule
+}
+
+type rule struct {
+	group    string
+	kind     string
+	path     []pathStep
+	field    string
+	healthy  []string
+	degraded []string
+	program  cel.Program
+}
+
+// Compile checks and compiles rules. An error names the offending rule, so
+// it can be reported as a config problem at startup.
+func Compile(rules []config.HealthRule) (*Evaluator, error) {
+	var env *cel.Env
+	e := &Evaluator{}
+	for i, r := range rules {
+		compiled := rule{
+			group:    r.Group,
+			kind:     r.Kind,
+			field:    r.Field,
+			healthy:  r.Healthy,
+			degraded: r.Degraded,
+		}
+
+		if r.Expr != "" {
+			if env == nil {
+				var err error
+				env, err = cel.NewEnv(cel.Variable("object", cel.DynType))
+				if err != nil {
+					return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+				}
+			}
+			ast, iss := env.Compile(r.Expr)
+			if iss.Err() != nil {
+				return nil, fmt.Errorf("health_rules[%d] (%s): invalid expr: %w", i, r.Kind, iss.Err())
+			}
+			out := ast.OutputType()
+			if !out.IsExactType(cel.BoolType) && !out.IsExactType(cel.StringType) && !out.IsExactType(cel.DynType) {
+				return nil, fmt.Errorf("health_rules[%d] (%s): expr must return a bool or a string, not %s", i, r.Kind, out)
+			}
+			prg, err := env.Program(ast, cel.CostLimit(celCostLimit))
+			if err != nil {
+				return nil, fmt.Errorf("health_rules[%d] (%s): invalid expr: %w", i, r.Kind, err)
+			}
+			compiled.program = prg
+		} else {
+			path, err := parsePath(r.Field)
+			if err != nil {
+				return nil, fmt.Errorf("health_rules[%d] (%s): invalid field: %w", i, r.Kind, err)
+			}
+			compiled.path = path
+		}
+
+		e.rules = append(e.rules, compiled)
+	}
+	return e, nil
+}
+
+// Evaluate applies the first rule matching obj's kind (and API group, if the
+// rule names one). obj is the object in unstructured form, apiVersion and
+// kind included. ok is false when no rule matches.
+func (e *Evaluator) Evaluate(obj map[string]any) (res Result, ok bool) {
+	if e == nil {
+		return Result{}, false
+	}
+	kind, _ := obj["kind"].(string)
+	apiVersion, _ := obj["apiVersion"].(string)
+	group := ""
+	if g, _, found := strings.Cut(apiVersion, "/"); found {
+		group = g
+	}
+
+	for _, r := range e.rules {
+		if !strings.EqualFold(r.kind, kind) || (r.group != "" && r.group != group) {
+			continue
+		}
+		if r.program != nil {
+			return r.evalExpr(obj), true
+		}
+		return r.evalField(obj), true
+	}
+	return Result{}, false
+}
+
+func (r rule) evalExpr(obj map[string]any) Result {
+	out, _, err := r.program.Eval(map[string]any{"object": obj})
+	if err != nil {
+		return Result{Status: Unknown, Reason: err.Error()}
+	}
+	switch v := out.Value().(type) {
+	case bool:
+		if v {
+			return Result{Status: Healthy}
+		}
+		return Result{Status: Degraded}
+	case string:
+		return Result{Status: v}
+	default:
+		return Result{Status: Unknown, Reason: fmt.Sprintf("expr returned %T, want bool or string", v)}
+	}
+}
+
+func (r rule) evalField(obj map[string]any) Result {
+	v, found := lookup(obj, r.path)
+	if !found {
+		return Result{Status: Unknown, Reason: r.field + " not set"}
+	}
+	s := formatValue(v)
+	reason := r.field + "=" + s
+	for _, h := range r.healthy {
+		if h == s {
+			return Result{Status: Healthy, Reason: reason}
+		}
+	}
+	for _, d := range r.degraded {
+		if d == s {
+			return Result{Status: Degraded, Reason: reason}
+		}
+	}
+	return Result{Status: Unknown, Reason: reason}
+}
+
+// formatValue renders a looked-up value the way it'd be written in the
+// rule's healthy/degraded lists.
+func formatValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprint(t)
+	}
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+func certificate(ready string) map[string]any {
+	return map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Issuing", "status": "False"},
+				map[string]any{"type": "Ready", "status": ready},
+			},
+		},
+	}
+}
+
+func TestEvaluate_FieldRuleWithSelector(t *testing.T) {
+	e, err := Compile([]config.HealthRule{{
+		Group:    "cert-manager.io",
+		Kind:     "Certificate",
+		Field:    "status.conditions[type=Ready].status",
+		Healthy:  []string{"True"},
+		Degraded: []string{"False"},
+	}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	for ready, want := range map[string]string{"True": Healthy, "False": Degraded, "Maybe": Unknown} {
+		res, ok := e.Evaluate(certificate(ready))
+		if !ok || res.Status != want {
+			t.Fatalf("Ready=%s: expected %s, got %+v (ok=%v)", ready, want, res, ok)
+		}
+	}
+
+	other := certificate("True")
+	other["apiVersion"] = "example.com/v1"
+	if _, ok := e.Evaluate(other); ok {
+		t.Fatal("expected no match for a Certificate from another group")
+	}
+}
+
+func TestEvaluate_CELRules(t *testing.T) {
+	e, err := Compile([]config.HealthRule{
+		{Kind: "Certificate", Expr: `object.status.conditions.exists(c, c.type == "Ready" && c.status == "True")`},
+		{Kind: "Backup", Expr: `has(object.status.phase) ? object.status.phase : "Pending"`},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	if res, _ := e.Evaluate(certificate("False")); res.Status != Degraded {
+		t.Fatalf("expected Degraded, got %+v", res)
+	}
+	backup := map[string]any{"apiVersion": "velero.io/v1", "kind": "Backup", "status": map[string]any{}}
+	if res, _ := e.Evaluate(backup); res.Status != "Pending" {
+		t.Fatalf("expected the expression's string, got %+v", res)
+	}
+	broken := map[string]any{"apiVersion": "cert-manager.io/v1", "kind": "Certificate"}
+	if res, _ := e.Evaluate(broken); res.Status != Unknown || res.Reason == "" {
+		t.Fatalf("expected Unknown with a reason for a missing field, got %+v", res)
+	}
+}
+
+func TestCompile_RejectsBadRules(t *testing.T) {
+	bad := []config.HealthRule{
+		{Kind: "A", Expr: "object.status ==="},
+		{Kind: "B", Expr: "1 + 2"},
+		{Kind: "C", Field: "status.conditions[type=Ready", Healthy: []string{"True"}},
+		{Kind: "D", Field: "status..phase", Healthy: []string{"Ok"}},
+	}
+	for _, r := range bad {
+		if _, err := Compile([]config.HealthRule{r}); err == nil {
+			t.Fatalf("expected rule %+v to be rejected", r)
+		}
+	}
+}
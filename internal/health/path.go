@@ -0,0 +1,117 @@
+package health
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathStep is one hop of a field path: a map key, then optionally a list
+// selector — an index ("[0]") or the first element whose field equals a
+// value ("[type=Ready]").
+type pathStep struct {
+	key string
+
+	hasIndex bool
+	index    int
+
+	matchKey   string
+	matchValue string
+}
+
+// parsePath parses a dotted field path like
+// "status.conditions[type=Ready].status". Selector values may contain dots
+// ("[type=cert-manager.io/Ready]"); they end at the closing bracket.
+func parsePath(path string) ([]pathStep, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+
+	var steps []pathStep
+	rest := path
+	for rest != "" {
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		step := pathStep{key: rest[:end]}
+		rest = rest[end:]
+
+		if strings.HasPrefix(rest, "[") {
+			closeIdx := strings.IndexByte(rest, ']')
+			if closeIdx < 0 {
+				return nil, fmt.Errorf("%q: unclosed [", path)
+			}
+			sel := rest[1:closeIdx]
+			rest = rest[closeIdx+1:]
+			if k, v, ok := strings.Cut(sel, "="); ok {
+				if k == "" {
+					return nil, fmt.Errorf("%q: selector [%s] has no field", path, sel)
+				}
+				step.matchKey, step.matchValue = k, v
+			} else {
+				n, err := strconv.Atoi(sel)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("%q: [%s] is neither an index nor a field=value selector", path, sel)
+				}
+				step.hasIndex, step.index = true, n
+			}
+		}
+		if step.key == "" && !step.hasIndex && step.matchKey == "" {
+			return nil, fmt.Errorf("%q: empty path segment", path)
+		}
+		steps = append(steps, step)
+
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("%q: trailing dot", path)
+			}
+		} else if rest != "" {
+			return nil, fmt.Errorf("%q: unexpected %q", path, rest)
+		}
+	}
+	return steps, nil
+}
+
+// lookup walks obj (unstructured: maps, slices, scalars) along steps.
+func lookup(obj any, steps []pathStep) (any, bool) {
+	cur := obj
+	for _, st := range steps {
+		if st.key != "" {
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if cur, ok = m[st.key]; !ok {
+				return nil, false
+			}
+		}
+
+		switch {
+		case st.hasIndex:
+			list, ok := cur.([]any)
+			if !ok || st.index >= len(list) {
+				return nil, false
+			}
+			cur = list[st.index]
+		case st.matchKey != "":
+			list, ok := cur.([]any)
+			if !ok {
+				return nil, false
+			}
+			found := false
+			for _, item := range list {
+				m, ok := item.(map[string]any)
+				if ok && formatValue(m[st.matchKey]) == st.matchValue {
+					cur, found = item, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		}
+	}
+	return cur, true
+}
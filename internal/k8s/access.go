@@ -0,0 +1,176 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// accessTTL is how long an access review's answer is trusted; RBAC rarely
+// changes under a running session, and when it does a few minutes' lag is
+// no worse than the raw Forbidden the check replaces.
+const accessTTL = 5 * time.Minute
+
+// Access is an API permission as `kubectl auth can-i` asks about it: a verb
+// on a resource, or one of its subresources, in an API group.
+type Access struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+}
+
+// String is the permission as "resource[/subresource]/verb", e.g.
+// "pods/list" or "pods/exec/create".
+func (a Access) String() string {
+	s := a.Resource
+	if a.Subresource != "" {
+		s += "/" + a.Subresource
+	}
+	return s + "/" + a.Verb
+}
+
+// The permissions ktails checks before listing a tab's resources and before
+// its pod actions.
+var (
+	ListPods           = Access{Verb: "list", Resource: "pods"}
+	ListDeployments    = Access{Verb: "list", Group: "apps", Resource: "deployments"}
+	ListServices       = Access{Verb: "list", Resource: "services"}
+	ListStatefulSets   = Access{Verb: "list", Group: "apps", Resource: "statefulsets"}
+	ListDaemonSets     = Access{Verb: "list", Group: "apps", Resource: "daemonsets"}
+	ListNodes          = Access{Verb: "list", Resource: "nodes"}
+	ListIngresses      = Access{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses"}
+	DeletePods         = Access{Verb: "delete", Resource: "pods"}
+	ExecPods           = Access{Verb: "create", Resource: "pods", Subresource: "exec"}
+	ForwardPods        = Access{Verb: "create", Resource: "pods", Subresource: "portforward"}
+	RestartDeployments = Access{Verb: "patch", Group: "apps", Resource: "deployments"}
+)
+
+// PermissionError is a call ktails didn't make because an access review
+// said RBAC would forbid it. Namespace is "" for all namespaces, or for a
+// cluster-scoped resource.
+type PermissionError struct {
+	Access    Access
+	Namespace string
+}
+
+func (e *PermissionError) Error() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("no permission (%s)", e.Access)
+	}
+	return fmt.Sprintf("no permission (%s in ns %s)", e.Access, e.Namespace)
+}
+
+// accessKey is one cached review: access in a context's namespace.
+type accessKey struct {
+	context, namespace string
+	access             Access
+}
+
+type accessAnswer struct {
+	allowed bool
+	at      time.Time
+}
+
+// accessCache holds access reviews' answers for accessTTL. enabled is
+// whether listing calls check first (see SetAccessChecks).
+type accessCache struct {
+	mu      sync.Mutex
+	enabled bool
+	answers map[accessKey]accessAnswer
+}
+
+// SetAccessChecks turns on checking, with a SelfSubjectAccessReview, that
+// a tab's resources may be listed before listing them; a forbidden list
+// then fails with a *PermissionError naming the permission missing rather
+// than the API server's Forbidden. Off by default, as it costs a request
+// per resource and namespace (cached for a few minutes).
+func (c *Client) SetAccessChecks(enabled bool) {
+	if c.access == nil {
+		return
+	}
+	c.access.mu.Lock()
+	defer c.access.mu.Unlock()
+	c.access.enabled = enabled
+}
+
+// CanI asks the API server whether the context's user may perform access
+// in namespace ("" for all namespaces, or a cluster-scoped resource), with
+// a SelfSubjectAccessReview. Answers are cached for accessTTL.
+func (c *Client) CanI(ctx context.Context, kubeContext, namespace string, access Access) (bool, error) {
+	key := accessKey{context: kubeContext, namespace: namespace, access: access}
+	if c.access != nil {
+		c.access.mu.Lock()
+		answer, ok := c.access.answers[key]
+		c.access.mu.Unlock()
+		if ok && time.Since(answer.at) < accessTTL {
+			return answer.allowed, nil
+		}
+	}
+
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return false, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        access.Verb,
+				Group:       access.Group,
+				Resource:    access.Resource,
+				Subresource: access.Subresource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access to %s (context %s): %w", access, kubeContext, err)
+	}
+
+	if c.access != nil {
+		c.access.mu.Lock()
+		c.access.answers[key] = accessAnswer{allowed: review.Status.Allowed, at: time.Now()}
+		c.access.mu.Unlock()
+	}
+	return review.Status.Allowed, nil
+}
+
+// KnownAccess answers from the cache alone whether access is allowed in
+// namespace, falling back to what was found for all namespaces; known is
+// false if neither was reviewed recently.
+func (c *Client) KnownAccess(kubeContext, namespace string, access Access) (allowed, known bool) {
+	if c.access == nil {
+		return false, false
+	}
+	c.access.mu.Lock()
+	defer c.access.mu.Unlock()
+	for _, ns := range []string{namespace, ""} {
+		if answer, ok := c.access.answers[accessKey{context: kubeContext, namespace: ns, access: access}]; ok && time.Since(answer.at) < accessTTL {
+			return answer.allowed, true
+		}
+	}
+	return false, false
+}
+
+// checkAccess is the check listing calls make first when access checks are
+// on: a *PermissionError if access is denied, nil otherwise — including
+// when the review itself fails, which leaves it to the list to say.
+func (c *Client) checkAccess(ctx context.Context, kubeContext, namespace string, access Access) error {
+	if c.access == nil {
+		return nil
+	}
+	c.access.mu.Lock()
+	enabled := c.access.enabled
+	c.access.mu.Unlock()
+	if !enabled {
+		return nil
+	}
+	if allowed, err := c.CanI(ctx, kubeContext, namespace, access); err == nil && !allowed {
+		return &PermissionError{Access: access, Namespace: namespace}
+	}
+	return nil
+}
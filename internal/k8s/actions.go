@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartedAtAnnotation is the pod template annotation `kubectl rollout
+// restart` bumps; changing the template is what rolls the pods.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// DeletePod deletes a pod with its default grace period.
+func (c *Client) DeletePod(ctx context.Context, kubeContext, namespace, podName string) (err error) {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	defer c.record(c.auditEntry(kubeContext, "delete", "pod", namespace, podName, ""), &err)
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	if err := clientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
+	}
+	return nil
+}
+
+// GetPodDeployment returns the Deployment owning a pod through its
+// ReplicaSet, or "" if the pod isn't managed by one.
+func (c *Client) GetPodDeployment(ctx context.Context, kubeContext, namespace, podName string) (string, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return "", fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	return podDeployment(ctx, clientset, namespace, podName)
+}
+
+func podDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, podName string) (string, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %s in namespace %s: %w", podName, namespace, err)
+	}
+	rsRef := metav1.GetControllerOf(pod)
+	if rsRef == nil || rsRef.Kind != "ReplicaSet" {
+		return "", nil
+	}
+	rs, err := clientset.AppsV1().ReplicaSets(namespace).Get(ctx, rsRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get replicaset %s in namespace %s: %w", rsRef.Name, namespace, err)
+	}
+	if ref := metav1.GetControllerOf(rs); ref != nil && ref.Kind == "Deployment" {
+		return ref.Name, nil
+	}
+	return "", nil
+}
+
+// RestartDeployment triggers a rolling restart of a Deployment the way
+// `kubectl rollout restart` does: by stamping its pod template with the
+// current time.
+func (c *Client) RestartDeployment(ctx context.Context, kubeContext, namespace, name string) (err error) {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	defer c.record(c.auditEntry(kubeContext, "restart", "deployment", namespace, name, ""), &err)
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build restart patch: %w", err)
+	}
+	if _, err := clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to restart deployment %s in namespace %s (context %s): %w", name, namespace, kubeContext, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"log"
+	"time"
+
+	"github.com/ktails/ktails/internal/audit"
+)
+
+// SetAuditLog has the client record every action that changes something in
+// a cluster, and every shell session it opens, to l — whether it went
+// through or not. Calls read-only mode refuses never reach the cluster and
+// aren't recorded. Set once at startup.
+func (c *Client) SetAuditLog(l *audit.Log) {
+	c.auditLog = l
+}
+
+// AuditLog returns the log set with SetAuditLog, nil without one.
+func (c *Client) AuditLog() *audit.Log {
+	return c.auditLog
+}
+
+// auditEntry starts the audit entry for an action about to be taken in
+// kubeContext, stamped now and with the kubeconfig user the context
+// authenticates as.
+func (c *Client) auditEntry(kubeContext, action, kind, namespace, name, detail string) audit.Entry {
+	e := audit.Entry{
+		Time:      time.Now(),
+		Context:   kubeContext,
+		Action:    action,
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Detail:    detail,
+	}
+	c.mu.RLock()
+	if c.rawConfig != nil {
+		if ctx, ok := c.rawConfig.Contexts[kubeContext]; ok {
+			e.User = ctx.AuthInfo
+		}
+	}
+	c.mu.RUnlock()
+	return e
+}
+
+// record appends e to the audit log with the action's outcome, *err; it's
+// deferred by every audited call. Failing to write the log doesn't fail
+// the action, which has been taken by then.
+func (c *Client) record(e audit.Entry, err *error) {
+	if c.auditLog == nil {
+		return
+	}
+	if *err != nil {
+		e.Error = (*err).Error()
+	}
+	if werr := c.auditLog.Record(e); werr != nil {
+		log.Printf("audit: %v", werr)
+	}
+}
@@ -6,51 +6,100 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/yaml"
+
+	"github.com/ktails/ktails/internal/audit"
+	"github.com/ktails/ktails/internal/health"
 )
 
 // Client wraps Kubernetes client operations with support for multiple contexts
 type Client struct {
 	clientsByContext map[string]kubernetes.Interface
-	rawConfig        *api.Config
-	kubeconfigPath   string
-	currentContext   string
-	mu               sync.RWMutex // Protect concurrent access
+	// restConfigsByContext holds the rest config each cached clientset was
+	// built from — exec and port-forward need it to open their own
+	// streaming connections. Populated and cleared alongside clientsByContext.
+	restConfigsByContext map[string]*rest.Config
+	// dynamicByContext holds the dynamic clients the custom resource viewer
+	// lists through (see custom.go), built from restConfigsByContext's
+	// config on first use and cleared alongside it.
+	dynamicByContext map[string]dynamic.Interface
+	// healthRules, if set, give resource details a config-defined health
+	// (see SetHealthRules). Set once at startup, before any fetch.
+	healthRules *health.Evaluator
+	// telemetry counts every context's API requests (see Telemetry).
+	telemetry *Telemetry
+	// limiter holds every context's requests to the LoadLimits (see
+	// SetLoadLimits).
+	limiter *loadLimiter
+	// retries tracks list calls backing off before a retry (see Retries).
+	retries *retryTracker
+	// access caches access reviews' answers (see CanI).
+	access          *accessCache
+	rawConfig       *api.Config
+	kubeconfigPaths []string
+	// conflicts lists the entries renamed while merging kubeconfigPaths.
+	conflicts      []ContextConflict
+	currentContext string
+	mu             sync.RWMutex // Protect concurrent access
+
+	// reauthing holds the contexts being re-authenticated after a 401 (see
+	// reauth.go). It has its own lock: the rebuild runs under mu.
+	reauthMu  sync.Mutex
+	reauthing map[string]bool
+
+	// readOnly refuses every mutating call (see SetReadOnly).
+	readOnly atomic.Bool
+	// auditLog, if set, records every mutating call (see SetAuditLog).
+	auditLog *audit.Log
 }
 
 // PodInfo contains pod metadata
+// PodInfo is a pod's table row. The JSON names are what `ktails get pods`
+// prints.
 type PodInfo struct {
-	Name            string
-	Namespace       string
-	Status          string
-	Restarts        int32
-	Age             string
-	Image           string
-	Container       string
-	Containers      []string
-	Node            string
-	NodeIP          string
-	PodIP           string
-	ReadyContainers string // e.g. "2/3", ready vs total container statuses
-	Context         string
+	Name            string   `json:"name"`
+	Namespace       string   `json:"namespace"`
+	Status          string   `json:"status"`
+	Restarts        int32    `json:"restarts"`
+	Age             string   `json:"age"`
+	Image           string   `json:"image"`     // the first container's
+	Container       string   `json:"container"` // the first container
+	Containers      []string `json:"containers"`
+	Node            string   `json:"node,omitempty"`
+	NodeIP          string   `json:"nodeIP,omitempty"`
+	PodIP           string   `json:"podIP,omitempty"`
+	ReadyContainers string   `json:"ready"`                   // e.g. "2/3", ready vs total container statuses
+	QOSClass        string   `json:"qosClass,omitempty"`      // Guaranteed, Burstable or BestEffort
+	PriorityClass   string   `json:"priorityClass,omitempty"` // spec.priorityClassName, "" if unset
+	Deployment      string   `json:"deployment,omitempty"`    // owning Deployment, see DeploymentOf
+	Owner           string   `json:"owner,omitempty"`         // controlling owner as kind/name, e.g. "StatefulSet/db"
+	Context         string   `json:"context"`
 }
 
 type ContextsInfo struct {
-	Name             string
-	Cluster          string
-	DefaultNamespace string
+	Name             string `json:"name"`
+	Cluster          string `json:"cluster"`
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+	File             string `json:"file"` // kubeconfig file the context was loaded from
 }
 
-// getDefaultKubeconfigPath returns the default kubeconfig path
+// getDefaultKubeconfigPath returns the default kubeconfig path — possibly a
+// KUBECONFIG-style list of several files
 func getDefaultKubeconfigPath() string {
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
 		return kubeconfig
@@ -62,7 +111,9 @@ func getDefaultKubeconfigPath() string {
 	return filepath.Join(home, ".kube", "config")
 }
 
-// NewClient creates a new K8s client
+// NewClient creates a new K8s client. kubeconfigPath may list several files
+// the way KUBECONFIG does; they're merged with clashing names disambiguated
+// (see ContextConflicts).
 func NewClient(kubeconfigPath string) (*Client, error) {
 	if kubeconfigPath == "" {
 		kubeconfigPath = getDefaultKubeconfigPath()
@@ -70,19 +121,18 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 			return nil, fmt.Errorf("kubeconfig path is empty and could not determine default path")
 		}
 	}
+	return NewClientFromPaths(kubeconfigPaths(kubeconfigPath))
+}
 
-	if _, err := os.Stat(kubeconfigPath); err != nil {
-		return nil, fmt.Errorf("kubeconfig not accessible at %s: %w", kubeconfigPath, err)
+// NewClientFromPaths creates a new K8s client from the given kubeconfig
+// files, merged in order like NewClient merges a KUBECONFIG list.
+func NewClientFromPaths(paths []string) (*Client, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no kubeconfig paths given")
 	}
 
 	// Load raw config for context/namespace operations
-	loadingRules := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: kubeconfigPath,
-	}
-	rawConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		loadingRules,
-		&clientcmd.ConfigOverrides{},
-	).RawConfig()
+	rawConfig, conflicts, err := loadKubeconfigs(paths)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
@@ -93,62 +143,77 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 	}
 
 	client := &Client{
-		clientsByContext: make(map[string]kubernetes.Interface),
-		rawConfig:        &rawConfig,
-		kubeconfigPath:   kubeconfigPath,
-		currentContext:   currentContext,
-	}
-
-	// Pre-create client for current context and test connection
+		clientsByContext:     make(map[string]kubernetes.Interface),
+		restConfigsByContext: make(map[string]*rest.Config),
+		rawConfig:            rawConfig,
+		kubeconfigPaths:      paths,
+		conflicts:            conflicts,
+		currentContext:       currentContext,
+		telemetry:            NewTelemetry(),
+		limiter:              &loadLimiter{},
+		retries:              newRetryTracker(),
+		access:               &accessCache{answers: make(map[accessKey]accessAnswer)},
+	}
+
+	// Pre-create the current context's client, which catches a broken
+	// kubeconfig entry early; whether its cluster answers is left to
+	// CheckConnection, so an unreachable one doesn't hold up startup.
 	if _, err := client.GetClientForContext(currentContext); err != nil {
-		return nil, fmt.Errorf("failed to connect to current context %s: %w", currentContext, err)
+		return nil, fmt.Errorf("failed to create client for current context %s: %w", currentContext, err)
 	}
 
 	return client, nil
 }
 
-// createClientForContext creates a new clientset for the specified context
-func (c *Client) createClientForContext(contextName string) (*kubernetes.Clientset, error) {
+// createClientForContext creates a new clientset for the specified context,
+// returning the rest config it was built from alongside it. Nothing is sent
+// to the cluster: see CheckConnection.
+func (c *Client) createClientForContext(contextName string) (*kubernetes.Clientset, *rest.Config, error) {
 	// Check if context exists in config
 	if _, exists := c.rawConfig.Contexts[contextName]; !exists {
-		return nil, fmt.Errorf("context %s not found in kubeconfig", contextName)
+		return nil, nil, fmt.Errorf("context %s not found in kubeconfig", contextName)
 	}
 
-	// Create config with specific context
-	loadingRules := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: c.kubeconfigPath,
+	// Create config with specific context, from the merged config rather
+	// than the files: a renamed context only exists in the former. Refreshed
+	// auth-provider tokens are written back to the files, except for a
+	// renamed user, which the files don't know by that name.
+	var configAccess clientcmd.ConfigAccess = &clientcmd.ClientConfigLoadingRules{
+		Precedence: c.kubeconfigPaths,
 	}
-	configOverrides := &clientcmd.ConfigOverrides{
-		CurrentContext: contextName,
+	if c.isRenamed(ConflictUser, c.rawConfig.Contexts[contextName].AuthInfo) {
+		configAccess = nil
 	}
-
-	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		loadingRules,
-		configOverrides,
+	clientConfig := clientcmd.NewNonInteractiveClientConfig(
+		*c.rawConfig,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		configAccess,
 	)
 
 	// Build rest config for this context
 	restConfig, err := clientConfig.ClientConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create client config for context %s: %w", contextName, err)
+		return nil, nil, fmt.Errorf("failed to create client config for context %s: %w", contextName, err)
+	}
+	if c.telemetry != nil {
+		restConfig.Wrap(c.telemetry.wrap(contextName))
 	}
+	if c.limiter != nil {
+		restConfig.Wrap(c.limiter.wrap(contextName))
+	}
+	armed := new(atomic.Bool)
+	restConfig.Wrap(c.reauthWrap(contextName, armed))
 
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kubernetes client for context %s: %w", contextName, err)
+		return nil, nil, fmt.Errorf("failed to create kubernetes client for context %s: %w", contextName, err)
 	}
 
-	// Test the connection
-	_, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	armed.Store(true)
 
-	_, err = clientset.Discovery().ServerVersion()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to cluster in context %s: %w", contextName, err)
-	}
-
-	return clientset, nil
+	return clientset, restConfig, nil
 }
 
 // GetClientForContext returns a clientset for the specified context
@@ -172,16 +237,53 @@ func (c *Client) GetClientForContext(contextName string) (kubernetes.Interface,
 	}
 
 	// Create the client
-	client, err := c.createClientForContext(contextName)
+	client, restConfig, err := c.createClientForContext(contextName)
 	if err != nil {
 		return nil, err
 	}
 
 	// Cache it
 	c.clientsByContext[contextName] = client
+	if c.restConfigsByContext == nil {
+		c.restConfigsByContext = make(map[string]*rest.Config)
+	}
+	c.restConfigsByContext[contextName] = restConfig
 	return client, nil
 }
 
+// restConfigForContext returns the rest config behind the context's cached
+// clientset, creating the client first if needed.
+func (c *Client) restConfigForContext(contextName string) (*rest.Config, error) {
+	if _, err := c.GetClientForContext(contextName); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cfg, ok := c.restConfigsByContext[contextName]
+	if !ok {
+		return nil, fmt.Errorf("no rest config for context %s", contextName)
+	}
+	return cfg, nil
+}
+
+// RequestUsage returns kubeContext's current API request load, zero for a
+// client without telemetry (as in tests).
+func (c *Client) RequestUsage(kubeContext string) RequestUsage {
+	if c.telemetry == nil {
+		return RequestUsage{}
+	}
+	return c.telemetry.Usage(kubeContext)
+}
+
+// SetLoadLimits bounds the requests every context has out at once and how
+// long each may wait for its response; it applies to requests sent
+// afterwards. A failure to answer in time is a *SlowResponseError.
+func (c *Client) SetLoadLimits(limits LoadLimits) {
+	if c.limiter != nil {
+		c.limiter.set(limits)
+	}
+}
+
 // GetCurrentContext returns the currently active context
 func (c *Client) GetCurrentContext() string {
 	c.mu.RLock()
@@ -236,14 +338,18 @@ func (c *Client) ListContexts() ([]ContextsInfo, error) {
 			Name:             name,
 			Cluster:          value.Cluster,
 			DefaultNamespace: value.Namespace,
+			File:             value.LocationOfOrigin,
 		}
 		contexts = append(contexts, ctx)
 	}
+	// By name, rather than the kubeconfig map's order, which changes
+	// from run to run.
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
 	return contexts, nil
 }
 
 // ListNamespaces returns namespaces from the specified Kubernetes context
-func (c *Client) ListNamespaces(kubeContext string) ([]string, error) {
+func (c *Client) ListNamespaces(ctx context.Context, kubeContext string) ([]string, error) {
 	// Get client for this context
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
@@ -251,8 +357,9 @@ func (c *Client) ListNamespaces(kubeContext string) ([]string, error) {
 	}
 
 	// List namespaces
-	ctx := context.Background()
-	namespaceList, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	namespaceList, err := withRetry(ctx, c, kubeContext, func() (*v1.NamespaceList, error) {
+		return clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces in context %s: %w", kubeContext, err)
 	}
@@ -266,14 +373,34 @@ func (c *Client) ListNamespaces(kubeContext string) ([]string, error) {
 	return namespaces, nil
 }
 
-// ListPods returns pods in the given namespace
-func (c *Client) ListPods(kubeContext, namespace string) ([]v1.Pod, error) {
+// NamespaceExists reports whether namespace exists in the given context.
+func (c *Client) NamespaceExists(ctx context.Context, kubeContext, namespace string) (bool, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return false, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	_, err = clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get namespace %s in context %s: %w", namespace, kubeContext, err)
+	}
+	return true, nil
+}
+
+// ListPods returns pods in the given namespace matching opts' label and
+// field selectors.
+func (c *Client) ListPods(ctx context.Context, kubeContext, namespace string, opts metav1.ListOptions) ([]v1.Pod, error) {
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	pList, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	pList, err := withRetry(ctx, c, kubeContext, func() (*v1.PodList, error) {
+		return clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods in namespace %s (context %s): %w", namespace, kubeContext, err)
 	}
@@ -281,17 +408,42 @@ func (c *Client) ListPods(kubeContext, namespace string) ([]v1.Pod, error) {
 	return pList.Items, nil
 }
 
+// ResyncPods lists pods the way ListPods does, but from the API server's
+// watch cache (resourceVersion "0") — a cheap full snapshot to reconcile a
+// watch-fed cache against, rather than a quorum read from etcd.
+func (c *Client) ResyncPods(ctx context.Context, kubeContext, namespace string, opts metav1.ListOptions) (*v1.PodList, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	opts.ResourceVersion = "0"
+	pList, err := withRetry(ctx, c, kubeContext, func() (*v1.PodList, error) {
+		return clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s (context %s): %w", namespace, kubeContext, err)
+	}
+	return pList, nil
+}
+
 // WatchPods opens a watch on pods in the given namespace. A bare Watch with
 // no ResourceVersion set has the server replay every currently-existing
 // object as a synthetic Added event before continuing with live changes, so
-// no separate initial List() call is needed.
-func (c *Client) WatchPods(ctx context.Context, kubeContext, namespace string) (watch.Interface, error) {
+// no separate initial List() call is needed. opts' label and field selectors
+// narrow both the replay and the live events.
+func (c *Client) WatchPods(ctx context.Context, kubeContext, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	w, err := clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{})
+	if err := c.checkAccess(ctx, kubeContext, namespace, ListPods); err != nil {
+		return nil, err
+	}
+	w, err := withRetry(ctx, c, kubeContext, func() (watch.Interface, error) {
+		return clientset.CoreV1().Pods(namespace).Watch(ctx, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to watch pods in namespace %s (context %s): %w", namespace, kubeContext, err)
 	}
@@ -300,13 +452,18 @@ func (c *Client) WatchPods(ctx context.Context, kubeContext, namespace string) (
 
 // WatchDeployments opens a watch on deployments in the given namespace. See
 // WatchPods for the implicit list-then-watch behavior.
-func (c *Client) WatchDeployments(ctx context.Context, kubeContext, namespace string) (watch.Interface, error) {
+func (c *Client) WatchDeployments(ctx context.Context, kubeContext, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	w, err := clientset.AppsV1().Deployments(namespace).Watch(ctx, metav1.ListOptions{})
+	if err := c.checkAccess(ctx, kubeContext, namespace, ListDeployments); err != nil {
+		return nil, err
+	}
+	w, err := withRetry(ctx, c, kubeContext, func() (watch.Interface, error) {
+		return clientset.AppsV1().Deployments(namespace).Watch(ctx, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to watch deployments in namespace %s (context %s): %w", namespace, kubeContext, err)
 	}
@@ -315,22 +472,28 @@ func (c *Client) WatchDeployments(ctx context.Context, kubeContext, namespace st
 
 // WatchServices opens a watch on services in the given namespace. See
 // WatchPods for the implicit list-then-watch behavior.
-func (c *Client) WatchServices(ctx context.Context, kubeContext, namespace string) (watch.Interface, error) {
+func (c *Client) WatchServices(ctx context.Context, kubeContext, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	w, err := clientset.CoreV1().Services(namespace).Watch(ctx, metav1.ListOptions{})
+	if err := c.checkAccess(ctx, kubeContext, namespace, ListServices); err != nil {
+		return nil, err
+	}
+	w, err := withRetry(ctx, c, kubeContext, func() (watch.Interface, error) {
+		return clientset.CoreV1().Services(namespace).Watch(ctx, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to watch services in namespace %s (context %s): %w", namespace, kubeContext, err)
 	}
 	return w, nil
 }
 
-// ListPodInfo returns pods with detailed information
-func (c *Client) ListPodInfo(kubeContext, namespace string) ([]*PodInfo, error) {
-	pods, err := c.ListPods(kubeContext, namespace)
+// ListPodInfo returns pods with detailed information, narrowed by opts as
+// ListPods is.
+func (c *Client) ListPodInfo(ctx context.Context, kubeContext, namespace string, opts metav1.ListOptions) ([]*PodInfo, error) {
+	pods, err := c.ListPods(ctx, kubeContext, namespace, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -345,13 +508,12 @@ func (c *Client) ListPodInfo(kubeContext, namespace string) ([]*PodInfo, error)
 }
 
 // GetPodInfo fetches detailed pod information
-func (c *Client) GetPodInfo(kubeContext, namespace, podName string) (*PodInfo, error) {
+func (c *Client) GetPodInfo(ctx context.Context, kubeContext, namespace, podName string) (*PodInfo, error) {
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	ctx := context.Background()
 	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
@@ -361,14 +523,14 @@ func (c *Client) GetPodInfo(kubeContext, namespace, podName string) (*PodInfo, e
 }
 
 // GetPodDetail fetches a single pod's status, rendered YAML, and recent events.
-func (c *Client) GetPodDetail(kubeContext, namespace, podName string) (ResourceDetail, error) {
+func (c *Client) GetPodDetail(ctx context.Context, kubeContext, namespace, podName string) (ResourceDetail, error) {
 	d := ResourceDetail{Kind: "Pod"}
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return d, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	pod, err := clientset.CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return d, fmt.Errorf("failed to get pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
 	}
@@ -381,6 +543,7 @@ func (c *Client) GetPodDetail(kubeContext, namespace, podName string) (ResourceD
 	d.Name = pod.Name
 	d.Namespace = pod.Namespace
 	d.Age = formatDuration(time.Since(pod.CreationTimestamp.Time))
+	d.State = PodStatus(pod)
 	d.Summary = fmt.Sprintf("Phase: %s  Restarts: %d  Node: %s", pod.Status.Phase, restarts, pod.Spec.NodeName)
 	for _, condition := range pod.Status.Conditions {
 		d.Status = append(d.Status, formatCondition(string(condition.Type), string(condition.Status), condition.Reason, condition.Message))
@@ -394,18 +557,28 @@ func (c *Client) GetPodDetail(kubeContext, namespace, podName string) (ResourceD
 		}
 	}
 
+	if events, err := c.getEvents(ctx, kubeContext, namespace, "Pod", podName); err == nil {
+		d.Events = events
+	}
+
+	// Probes and their recent failures sit with the container states above:
+	// a failing liveness probe is the usual explanation for a restart count.
+	d.Status = append(d.Status, "", "Probes:")
+	d.Status = append(d.Status, probeLines(pod.Spec.Containers)...)
+	if failures := probeFailureLines(d.Events); len(failures) > 0 {
+		d.Status = append(d.Status, "", "Recent probe failures:")
+		d.Status = append(d.Status, failures...)
+	}
+
 	pod.ManagedFields = nil
 	pod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+	c.applyHealth(&d, pod)
 	if yamlBytes, yamlErr := yaml.Marshal(pod); yamlErr == nil {
 		d.YAML = string(yamlBytes)
 	} else {
 		d.YAML = fmt.Sprintf("failed to render YAML: %v", yamlErr)
 	}
 
-	if events, err := c.getEvents(kubeContext, namespace, "Pod", podName); err == nil {
-		d.Events = events
-	}
-
 	return d, nil
 }
 
@@ -428,19 +601,21 @@ func PodToPodInfo(pod *v1.Pod, kubeContext string) *PodInfo {
 		containers = append(containers, c.Name)
 	}
 
+	// Counted against the spec, as kubectl does: a pod whose containers
+	// haven't started yet has no statuses, and is 0/N ready, not 0/0.
 	var readyCount int
 	for _, cs := range pod.Status.ContainerStatuses {
 		if cs.Ready {
 			readyCount++
 		}
 	}
-	readyContainers := fmt.Sprintf("%d/%d", readyCount, len(pod.Status.ContainerStatuses))
+	readyContainers := fmt.Sprintf("%d/%d", readyCount, len(pod.Spec.Containers))
 
 	return &PodInfo{
 		Name:            pod.Name,
 		Namespace:       pod.Namespace,
 		Context:         kubeContext,
-		Status:          string(pod.Status.Phase),
+		Status:          PodStatus(pod),
 		Restarts:        restarts,
 		Age:             formatDuration(age),
 		Image:           image,
@@ -450,9 +625,39 @@ func PodToPodInfo(pod *v1.Pod, kubeContext string) *PodInfo {
 		NodeIP:          pod.Status.HostIP,
 		PodIP:           pod.Status.PodIP,
 		ReadyContainers: readyContainers,
+		QOSClass:        string(pod.Status.QOSClass),
+		PriorityClass:   pod.Spec.PriorityClassName,
+		Deployment:      DeploymentOf(pod),
+		Owner:           ownerOf(pod),
 	}
 }
 
+// ownerOf names a pod's controlling owner as kind/name, "" if it has none.
+func ownerOf(pod *v1.Pod) string {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return ""
+	}
+	return ref.Kind + "/" + ref.Name
+}
+
+// DeploymentOf names the Deployment a pod belongs to, read off the pod
+// alone: a Deployment's ReplicaSets are named after it plus their pod
+// template hash, which their pods carry as a label. "" if the pod isn't
+// owned by such a ReplicaSet. GetPodDeployment asks the API server instead.
+func DeploymentOf(pod *v1.Pod) string {
+	ref := metav1.GetControllerOf(pod)
+	hash := pod.Labels["pod-template-hash"]
+	if ref == nil || ref.Kind != "ReplicaSet" || hash == "" {
+		return ""
+	}
+	name, ok := strings.CutSuffix(ref.Name, "-"+hash)
+	if !ok {
+		return ""
+	}
+	return name
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -493,21 +698,48 @@ func containerStateString(state v1.ContainerState) string {
 	}
 }
 
+// LogOptions is what a pod log stream is opened with: the subset of
+// v1.PodLogOptions ktails offers, in its own terms.
+type LogOptions struct {
+	Container  string
+	Follow     bool
+	TailLines  int64         // only the last N lines; 0 means all of them
+	Since      time.Duration // only lines newer than this; 0 means no limit
+	SinceTime  time.Time     // only lines from this time on; wins over Since
+	Previous   bool          // the previous, terminated container instance
+	Timestamps bool          // prefix each line with its RFC 3339 timestamp
+}
+
+// podLogOptions maps o onto the API's options.
+func (o LogOptions) podLogOptions() *v1.PodLogOptions {
+	opts := &v1.PodLogOptions{
+		Container:  o.Container,
+		Follow:     o.Follow,
+		Previous:   o.Previous,
+		Timestamps: o.Timestamps,
+	}
+	if o.TailLines > 0 {
+		opts.TailLines = &o.TailLines
+	}
+	switch {
+	case !o.SinceTime.IsZero():
+		since := metav1.NewTime(o.SinceTime)
+		opts.SinceTime = &since
+	case o.Since > 0:
+		seconds := max(int64(o.Since/time.Second), 1)
+		opts.SinceSeconds = &seconds
+	}
+	return opts
+}
+
 // StreamLogs streams logs from a pod
-func (c *Client) StreamLogs(kubeContext, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+func (c *Client) StreamLogs(ctx context.Context, kubeContext, namespace, podName string, opts LogOptions) (io.ReadCloser, error) {
 	clientset, err := c.GetClientForContext(kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
 	}
 
-	if opts == nil {
-		opts = &v1.PodLogOptions{
-			Follow: true,
-		}
-	}
-
-	ctx := context.Background()
-	req := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts)
+	req := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts.podLogOptions())
 	stream, err := req.Stream(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stream logs from pod %s: %w", podName, err)
@@ -522,6 +754,8 @@ func (c *Client) ClearClientCache(contextName string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	delete(c.clientsByContext, contextName)
+	delete(c.restConfigsByContext, contextName)
+	delete(c.dynamicByContext, contextName)
 }
 
 // ClearAllClientCaches removes all cached clients
@@ -529,4 +763,6 @@ func (c *Client) ClearAllClientCaches() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.clientsByContext = make(map[string]kubernetes.Interface)
+	c.restConfigsByContext = make(map[string]*rest.Config)
+	c.dynamicByContext = nil
 }
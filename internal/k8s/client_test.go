@@ -1,17 +1,46 @@
 package k8s
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/ktails/ktails/internal/audit"
 )
 
 // newTestClient builds a Client backed by a fake clientset for the given
@@ -33,7 +62,7 @@ func TestWatchPods_ReplaysExistingThenLiveEvents(t *testing.T) {
 	}
 	c, clientset := newTestClient("ctx1", existingPod)
 
-	w, err := c.WatchPods(context.Background(), "ctx1", "default")
+	w, err := c.WatchPods(context.Background(), "ctx1", "default", metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("WatchPods returned error: %v", err)
 	}
@@ -79,7 +108,7 @@ func TestWatchDeployments_ReplaysExisting(t *testing.T) {
 	}
 	c, _ := newTestClient("ctx1", existing)
 
-	w, err := c.WatchDeployments(context.Background(), "ctx1", "default")
+	w, err := c.WatchDeployments(context.Background(), "ctx1", "default", metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("WatchDeployments returned error: %v", err)
 	}
@@ -105,7 +134,7 @@ func TestWatchServices_ReplaysExisting(t *testing.T) {
 	}
 	c, _ := newTestClient("ctx1", existing)
 
-	w, err := c.WatchServices(context.Background(), "ctx1", "default")
+	w, err := c.WatchServices(context.Background(), "ctx1", "default", metav1.ListOptions{})
 	if err != nil {
 		t.Fatalf("WatchServices returned error: %v", err)
 	}
@@ -130,7 +159,1696 @@ func TestWatchPods_UnknownContext(t *testing.T) {
 		clientsByContext: map[string]kubernetes.Interface{},
 		rawConfig:        &api.Config{Contexts: map[string]*api.Context{}},
 	}
-	if _, err := c.WatchPods(context.Background(), "missing", "default"); err == nil {
+	if _, err := c.WatchPods(context.Background(), "missing", "default", metav1.ListOptions{}); err == nil {
 		t.Fatal("expected error for unknown context, got nil")
 	}
 }
+
+func TestGetPodEnv_ResolvesRefsAndMasksSecrets(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "app",
+				EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cfg"}}}},
+				Env: []corev1.EnvVar{
+					{Name: "PLAIN", Value: "1"},
+					{Name: "LEVEL", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cfg"}, Key: "level"}}},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}, Key: "token"}}},
+					{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{PodIP: "10.0.0.7"},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cfg", Namespace: "default"},
+		Data:       map[string]string{"level": "debug"},
+	}
+	c, _ := newTestClient("ctx1", pod, cm)
+
+	envs, err := c.GetPodEnv(context.Background(), "ctx1", "default", "pod-a")
+	if err != nil {
+		t.Fatalf("GetPodEnv returned error: %v", err)
+	}
+	if len(envs) != 1 || envs[0].Container != "app" {
+		t.Fatalf("expected one app container, got %+v", envs)
+	}
+
+	got := make(map[string]EnvVar)
+	for _, v := range envs[0].Vars {
+		got[v.Name] = v
+	}
+	if got["level"].Value != "debug" {
+		t.Fatalf("envFrom configmap key not resolved: %+v", got["level"])
+	}
+	if got["PLAIN"].Value != "1" || got["PLAIN"].Source != "" {
+		t.Fatalf("literal var wrong: %+v", got["PLAIN"])
+	}
+	if got["LEVEL"].Value != "debug" {
+		t.Fatalf("configMapKeyRef not resolved: %+v", got["LEVEL"])
+	}
+	if !got["TOKEN"].Masked || got["TOKEN"].Value == "" {
+		t.Fatalf("secret ref must be masked: %+v", got["TOKEN"])
+	}
+	if got["POD_IP"].Value != "10.0.0.7" {
+		t.Fatalf("fieldRef not resolved: %+v", got["POD_IP"])
+	}
+}
+
+func TestGetPodEnv_ListsEnvFromSecretKeysWithoutValues(t *testing.T) {
+	envFrom := []corev1.EnvFromSource{{Prefix: "DB_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}}}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate", EnvFrom: envFrom}},
+			Containers:     []corev1.Container{{Name: "app", EnvFrom: envFrom}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data:       map[string][]byte{"PASSWORD": []byte("hunter2"), "USER": []byte("app-admin")},
+	}
+	c, clientset := newTestClient("ctx1", pod, secret)
+
+	envs, err := c.GetPodEnv(context.Background(), "ctx1", "default", "pod-a")
+	if err != nil {
+		t.Fatalf("GetPodEnv returned error: %v", err)
+	}
+	for _, ce := range envs {
+		var names []string
+		for _, v := range ce.Vars {
+			names = append(names, v.Name)
+			if !v.Masked || v.Value != maskedValue {
+				t.Errorf("%s: %s = %q, want it masked", ce.Container, v.Name, v.Value)
+			}
+		}
+		if !slices.Equal(names, []string{"DB_PASSWORD", "DB_USER"}) {
+			t.Errorf("%s: vars %v, want the secret's keys, prefixed", ce.Container, names)
+		}
+	}
+	if rows := fmt.Sprintf("%+v", envs); strings.Contains(rows, "hunter2") || strings.Contains(rows, "app-admin") {
+		t.Errorf("a secret value reached the env rows: %s", rows)
+	}
+
+	gets := 0
+	for _, a := range clientset.Actions() {
+		if a.GetVerb() == "get" && a.GetResource().Resource == "secrets" {
+			gets++
+		}
+	}
+	if gets != 1 {
+		t.Errorf("the secret was read %d times for two containers, want once", gets)
+	}
+}
+
+func TestParseLsOutput_SkipsMalformedLines(t *testing.T) {
+	out := "-rw-r--r--\t1\troot\troot\t123\tJul\t19\t12:00\tapp.yaml\n" +
+		"-rw-r--r--    1 root     root           123 Jul 19 12:00\n" +
+		"-rw-r--r--    1 root     root            42 Jul 19 12:00 ok.txt\n"
+	entries := parseLsOutput(out)
+	if len(entries) != 1 || entries[0].Name != "ok.txt" {
+		t.Fatalf("expected only the well-formed line, got %+v", entries)
+	}
+}
+
+func TestParseLsOutput_GNUAndBusybox(t *testing.T) {
+	out := `total 16
+drwxr-xr-x    1 root     root          4096 Jul 19 12:00 .
+drwxr-xr-x    1 root     root          4096 Jul 19 11:00 ..
+-rw-r--r--    1 root     root           123 Jul 19 12:00 app config.yaml
+lrwxrwxrwx    1 root     root             7 Jul 19 12:00 current -> v2
+crw-rw-rw-    1 root     root        1,   3 Jul 19 12:00 null
+drwxr-xr-x 2 app app 4096 Jul 19 12:00 logs
+`
+	entries := parseLsOutput(out)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries (.., file, link, dir), got %+v", entries)
+	}
+	if entries[0].Name != ".." || !entries[0].IsDir() {
+		t.Fatalf("expected .. directory first, got %+v", entries[0])
+	}
+	if entries[1].Name != "app config.yaml" || entries[1].Size != 123 {
+		t.Fatalf("expected name with spaces and size preserved, got %+v", entries[1])
+	}
+	if !entries[2].IsLink() || entries[2].Name != "current" || entries[2].LinkTarget != "v2" {
+		t.Fatalf("expected symlink split into name and target, got %+v", entries[2])
+	}
+	if entries[3].Name != "logs" || !entries[3].IsDir() {
+		t.Fatalf("expected GNU-layout directory, got %+v", entries[3])
+	}
+}
+
+func TestExtractTar_RenamesRootAndRejectsEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	write := func(hdr *tar.Header, body string) {
+		hdr.Size = int64(len(body))
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0755}, "")
+	write(&tar.Header{Name: "logs/a.txt", Typeflag: tar.TypeReg, Mode: 0644}, "alpha")
+	write(&tar.Header{Name: "logs/sub/b.txt", Typeflag: tar.TypeReg, Mode: 0644}, "beta")
+	write(&tar.Header{Name: "logs/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}, "")
+	tw.Close()
+
+	dest := filepath.Join(t.TempDir(), "copied")
+	if err := extractTar(bytes.NewReader(buf.Bytes()), "logs", dest); err != nil {
+		t.Fatalf("extractTar returned error: %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dest, "sub", "b.txt")); string(got) != "beta" {
+		t.Fatalf("expected nested file under the renamed root, got %q", got)
+	}
+	if _, err := os.Lstat(filepath.Join(dest, "link")); !os.IsNotExist(err) {
+		t.Fatalf("symlinks must be skipped, got err=%v", err)
+	}
+
+	buf.Reset()
+	tw = tar.NewWriter(&buf)
+	write(&tar.Header{Name: "logs/../../evil", Typeflag: tar.TypeReg, Mode: 0644}, "x")
+	tw.Close()
+	if err := extractTar(bytes.NewReader(buf.Bytes()), "logs", dest); err == nil {
+		t.Fatal("expected an entry escaping the destination to be rejected")
+	}
+}
+
+func TestGetPodDetail_ListsProbesAndRecentFailures(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}},
+				PeriodSeconds:    10,
+				FailureThreshold: 3,
+			},
+		}, {Name: "sidecar"}}},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "pod-a.1", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "pod-a", Namespace: "default"},
+		Reason:         "Unhealthy",
+		Message:        "Liveness probe failed: HTTP probe failed with statuscode: 500",
+		Count:          4,
+	}
+	c, _ := newTestClient("ctx1", pod, event)
+
+	d, err := c.GetPodDetail(context.Background(), "ctx1", "default", "pod-a")
+	if err != nil {
+		t.Fatalf("GetPodDetail returned error: %v", err)
+	}
+	status := strings.Join(d.Status, "\n")
+	for _, want := range []string{
+		"app liveness: http-get :8080/healthz",
+		"#failure=3",
+		"sidecar: no probes",
+		"(x4): Liveness probe failed",
+	} {
+		if !strings.Contains(status, want) {
+			t.Fatalf("expected %q in status, got:\n%s", want, status)
+		}
+	}
+}
+
+func TestWorkloadStatus_RollUp(t *testing.T) {
+	replicas := int32(3)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Generation: 2},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 3,
+			CurrentRevision: "db-1", UpdateRevision: "db-1",
+		},
+	}
+	if got := StatefulSetToStatefulSetInfo(sts).Status; got != WorkloadHealthy {
+		t.Fatalf("expected %s, got %s", WorkloadHealthy, got)
+	}
+	sts.Status.UpdateRevision = "db-2"
+	if got := StatefulSetToStatefulSetInfo(sts).Status; got != WorkloadUpdating {
+		t.Fatalf("expected %s mid-rollout, got %s", WorkloadUpdating, got)
+	}
+	sts.Status.UpdateRevision = "db-1"
+	sts.Status.ReadyReplicas = 1
+	if got := StatefulSetToStatefulSetInfo(sts).Status; got != WorkloadDegraded {
+		t.Fatalf("expected %s, got %s", WorkloadDegraded, got)
+	}
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 4, NumberReady: 4, UpdatedNumberScheduled: 4, NumberMisscheduled: 1,
+		},
+	}
+	if got := DaemonSetToDaemonSetInfo(ds).Status; got != WorkloadMisscheduled {
+		t.Fatalf("expected %s, got %s", WorkloadMisscheduled, got)
+	}
+	ds.Status = appsv1.DaemonSetStatus{}
+	if got := DaemonSetToDaemonSetInfo(ds).Status; got != WorkloadNoNodes {
+		t.Fatalf("expected %s, got %s", WorkloadNoNodes, got)
+	}
+}
+
+func TestWatchStatefulSets_ReplaysExisting(t *testing.T) {
+	existing := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+	}
+	c, _ := newTestClient("ctx1", existing)
+
+	w, err := c.WatchStatefulSets(context.Background(), "ctx1", "default", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("WatchStatefulSets returned error: %v", err)
+	}
+	defer w.Stop()
+
+	select {
+	case ev := <-w.ResultChan():
+		sts, ok := ev.Object.(*appsv1.StatefulSet)
+		if ev.Type != watch.Added || !ok || sts.Name != "db" {
+			t.Fatalf("expected replayed Added db, got %v %+v", ev.Type, ev.Object)
+		}
+	default:
+		t.Fatal("expected a buffered initial replay event, got none")
+	}
+}
+
+func TestLoadKubeconfigs_RenamesConflictsByFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, server string) string {
+		path := filepath.Join(dir, name)
+		body := `apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: prod
+  cluster: {server: ` + server + `}
+users:
+- name: admin
+  user: {token: abc}
+contexts:
+- name: prod
+  context: {cluster: prod, user: admin}
+`
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	eu := write("config-eu", "https://eu.example.com")
+	us := write("config-us", "https://us.example.com")
+	same := write("config-copy", "https://eu.example.com")
+
+	cfg, conflicts, err := loadKubeconfigs(kubeconfigPaths(strings.Join([]string{eu, filepath.Join(dir, "missing"), us, same}, string(filepath.ListSeparator))))
+	if err != nil {
+		t.Fatalf("loadKubeconfigs: %v", err)
+	}
+
+	if got := cfg.Clusters[cfg.Contexts["prod"].Cluster].Server; got != "https://eu.example.com" {
+		t.Fatalf("expected the first file to keep prod, got server %s", got)
+	}
+	renamed, ok := cfg.Contexts["prod (config-us)"]
+	if !ok {
+		t.Fatalf("expected prod from config-us to be renamed, got contexts %v", cfg.Contexts)
+	}
+	if got := cfg.Clusters[renamed.Cluster].Server; got != "https://us.example.com" {
+		t.Fatalf("expected the renamed context to point at its own cluster, got %s", got)
+	}
+	if renamed.AuthInfo != "admin" {
+		t.Fatalf("expected the identical admin user to be shared, got %s", renamed.AuthInfo)
+	}
+	if len(cfg.Contexts) != 2 {
+		t.Fatalf("expected the identical copy not to add a context, got %v", cfg.Contexts)
+	}
+
+	var kinds []string
+	for _, c := range conflicts {
+		if c.Origin != eu || c.File != us {
+			t.Fatalf("unexpected conflict origin: %+v", c)
+		}
+		kinds = append(kinds, c.Kind)
+	}
+	if strings.Join(kinds, ",") != "cluster,context" {
+		t.Fatalf("expected cluster and context conflicts, got %+v", conflicts)
+	}
+}
+
+func TestListContexts_RecordsEachContextsFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, context, server string) string {
+		path := filepath.Join(dir, name)
+		body := `apiVersion: v1
+kind: Config
+current-context: ` + context + `
+clusters:
+- name: ` + context + `
+  cluster: {server: ` + server + `}
+contexts:
+- name: ` + context + `
+  context: {cluster: ` + context + `}
+`
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	eu := write("config-eu", "prod", "https://eu.example.com")
+	us := write("config-us", "prod", "https://us.example.com")
+	dev := write("config-dev", "dev", "https://dev.example.com")
+
+	cfg, _, err := loadKubeconfigs([]string{eu, us, dev})
+	if err != nil {
+		t.Fatalf("loadKubeconfigs: %v", err)
+	}
+	c := &Client{rawConfig: cfg, kubeconfigPaths: []string{eu, us, dev}}
+	contexts, err := c.ListContexts()
+	if err != nil {
+		t.Fatalf("ListContexts: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, ctx := range contexts {
+		got[ctx.Name] = ctx.File
+	}
+	want := map[string]string{"prod": eu, "prod (config-us)": us, "dev": dev}
+	if !maps.Equal(got, want) {
+		t.Fatalf("expected files %v, got %v", want, got)
+	}
+}
+
+func TestReloadKubeconfig_ReportsChangesAndDropsStaleClients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	write := func(contexts map[string]string) {
+		body := "apiVersion: v1\nkind: Config\ncurrent-context: prod\nclusters:\n"
+		for _, name := range slices.Sorted(maps.Keys(contexts)) {
+			body += "- name: " + name + "\n  cluster: {server: " + contexts[name] + "}\n"
+		}
+		body += "contexts:\n"
+		for _, name := range slices.Sorted(maps.Keys(contexts)) {
+			body += "- name: " + name + "\n  context: {cluster: " + name + "}\n"
+		}
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(map[string]string{"prod": "https://prod.example.com", "stage": "https://stage.example.com", "dev": "https://dev.example.com"})
+	cfg, _, err := loadKubeconfigs([]string{path})
+	if err != nil {
+		t.Fatalf("loadKubeconfigs: %v", err)
+	}
+	c := &Client{
+		rawConfig:            cfg,
+		kubeconfigPaths:      []string{path},
+		currentContext:       "stage",
+		clientsByContext:     map[string]kubernetes.Interface{"prod": fake.NewClientset(), "stage": fake.NewClientset(), "dev": fake.NewClientset()},
+		restConfigsByContext: map[string]*rest.Config{},
+	}
+
+	write(map[string]string{"prod": "https://prod.example.com", "dev": "https://dev-2.example.com", "qa": "https://qa.example.com"})
+	change, err := c.ReloadKubeconfig()
+	if err != nil {
+		t.Fatalf("ReloadKubeconfig: %v", err)
+	}
+	if !slices.Equal(change.Added, []string{"qa"}) || !slices.Equal(change.Removed, []string{"stage"}) || !slices.Equal(change.Changed, []string{"dev"}) {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+	if _, ok := c.clientsByContext["prod"]; !ok {
+		t.Fatal("expected the unchanged context's client to stay cached")
+	}
+	if len(c.clientsByContext) != 1 {
+		t.Fatalf("expected the removed and changed contexts' clients dropped, got %v", slices.Collect(maps.Keys(c.clientsByContext)))
+	}
+	if c.GetCurrentContext() != "prod" {
+		t.Fatalf("expected the removed current context to fall back to the file's, got %s", c.GetCurrentContext())
+	}
+
+	if change, err := c.ReloadKubeconfig(); err != nil || !change.Empty() {
+		t.Fatalf("expected an unchanged reload to report nothing, got %+v, %v", change, err)
+	}
+}
+
+func TestSwitchKubeconfig_StartsAfreshOnTheNewFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, current string, contexts ...string) string {
+		path := filepath.Join(dir, name)
+		body := "apiVersion: v1\nkind: Config\ncurrent-context: " + current + "\nclusters:\n"
+		for _, c := range contexts {
+			body += "- name: " + c + "\n  cluster: {server: https://" + name + "." + c + ".example.com}\n"
+		}
+		body += "contexts:\n"
+		for _, c := range contexts {
+			body += "- name: " + c + "\n  context: {cluster: " + c + "}\n"
+		}
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	staging := write("staging", "stage", "stage", "dev")
+	prod := write("prod", "prod-eu", "prod-eu", "prod-us")
+
+	cfg, _, err := loadKubeconfigs([]string{staging})
+	if err != nil {
+		t.Fatalf("loadKubeconfigs: %v", err)
+	}
+	c := &Client{
+		rawConfig:            cfg,
+		kubeconfigPaths:      []string{staging},
+		currentContext:       "dev",
+		clientsByContext:     map[string]kubernetes.Interface{"stage": fake.NewClientset()},
+		restConfigsByContext: map[string]*rest.Config{},
+		access:               &accessCache{answers: map[accessKey]accessAnswer{{context: "stage"}: {allowed: true, at: time.Now()}}},
+	}
+
+	if err := c.SwitchKubeconfig([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Fatal("expected a switch to files that don't exist to fail")
+	}
+	if c.GetCurrentContext() != "dev" || len(c.clientsByContext) != 1 {
+		t.Fatal("expected a failed switch to leave the client as it was")
+	}
+
+	if err := c.SwitchKubeconfig([]string{prod}); err != nil {
+		t.Fatalf("SwitchKubeconfig: %v", err)
+	}
+	if c.GetCurrentContext() != "prod-eu" {
+		t.Fatalf("expected the new files' current context, got %s", c.GetCurrentContext())
+	}
+	if len(c.clientsByContext) != 0 || len(c.access.answers) != 0 {
+		t.Fatal("expected the old files' clients and access answers dropped")
+	}
+	if got := c.KubeconfigFiles(); !slices.Equal(got, []string{prod}) {
+		t.Fatalf("KubeconfigFiles = %v", got)
+	}
+	if _, ok := c.rawConfig.Contexts["stage"]; ok {
+		t.Fatal("expected the old files' contexts gone")
+	}
+}
+
+func TestReauthTransport_RetriesOnceWithFreshCredentials(t *testing.T) {
+	status := func(code int) *http.Response {
+		return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(""))}
+	}
+	stale := roundTripFunc(func(*http.Request) (*http.Response, error) { return status(http.StatusUnauthorized), nil })
+
+	refreshes := 0
+	var retried []string
+	armed := new(atomic.Bool)
+	rt := &reauthTransport{next: stale, armed: armed, refresh: func() (http.RoundTripper, error) {
+		refreshes++
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			retried = append(retried, req.Header.Get("Authorization")+"|"+string(body))
+			if refreshes > 1 {
+				// The fresh transport still failing must not loop.
+				return stale.RoundTrip(req)
+			}
+			return status(http.StatusOK), nil
+		}), nil
+	}}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/api", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Authorization", "Bearer old")
+	if resp, _ := rt.RoundTrip(req); resp.StatusCode != http.StatusUnauthorized || refreshes != 0 {
+		t.Fatalf("expected no retry before the client is armed, got %d after %d refreshes", resp.StatusCode, refreshes)
+	}
+
+	armed.Store(true)
+	if resp, _ := rt.RoundTrip(req); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the retry to succeed, got %d", resp.StatusCode)
+	}
+	if !slices.Equal(retried, []string{`|{"a":1}`}) {
+		t.Fatalf("expected one retry without the stale token and with the body resent, got %q", retried)
+	}
+
+	if resp, _ := rt.RoundTrip(req); resp.StatusCode != http.StatusUnauthorized || refreshes != 2 {
+		t.Fatalf("expected a second 401 to stand after one retry, got %d after %d refreshes", resp.StatusCode, refreshes)
+	}
+}
+
+func TestAnnotatePodAndSetConfigMapKey_MergeIntoExisting(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default", Annotations: map[string]string{"keep": "1"}}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-logging", Namespace: "default"}, Data: map[string]string{"other": "x"}}
+	c, cs := newTestClient("ctx1", pod, cm)
+
+	if err := c.AnnotatePod(context.Background(), "ctx1", "default", "pod-a", "log-level", "debug"); err != nil {
+		t.Fatalf("AnnotatePod: %v", err)
+	}
+	if err := c.SetConfigMapKey(context.Background(), "ctx1", "default", "app-logging", "level", "warn"); err != nil {
+		t.Fatalf("SetConfigMapKey: %v", err)
+	}
+
+	gotPod, _ := cs.CoreV1().Pods("default").Get(context.Background(), "pod-a", metav1.GetOptions{})
+	if gotPod.Annotations["log-level"] != "debug" || gotPod.Annotations["keep"] != "1" {
+		t.Fatalf("unexpected annotations: %v", gotPod.Annotations)
+	}
+	gotCM, _ := cs.CoreV1().ConfigMaps("default").Get(context.Background(), "app-logging", metav1.GetOptions{})
+	if gotCM.Data["level"] != "warn" || gotCM.Data["other"] != "x" {
+		t.Fatalf("unexpected configmap data: %v", gotCM.Data)
+	}
+
+	if err := c.SetConfigMapKey(context.Background(), "ctx1", "default", "missing", "level", "warn"); err == nil {
+		t.Fatal("expected an error for a missing configmap")
+	}
+}
+
+func TestSetReadOnly_RefusesEveryWrite(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default"}}
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	c, cs := newTestClient("ctx1", pod, deploy)
+	c.SetReadOnly()
+	ctx := context.Background()
+
+	for name, err := range map[string]error{
+		"DeletePod":         c.DeletePod(ctx, "ctx1", "default", "pod-a"),
+		"RestartDeployment": c.RestartDeployment(ctx, "ctx1", "default", "api"),
+		"AnnotatePod":       c.AnnotatePod(ctx, "ctx1", "default", "pod-a", "log-level", "debug"),
+		"SetConfigMapKey":   c.SetConfigMapKey(ctx, "ctx1", "default", "app-logging", "level", "warn"),
+		"CordonNode":        c.CordonNode(ctx, "ctx1", "node-1", true),
+		"Exec":              c.Exec(ctx, "ctx1", "default", "pod-a", ExecOptions{Command: []string{"true"}}),
+	} {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: got %v, want ErrReadOnly", name, err)
+		}
+	}
+	if _, err := c.UndoRollout(ctx, "ctx1", "default", "api", 0); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("UndoRollout: got %v, want ErrReadOnly", err)
+	}
+
+	got, err := cs.CoreV1().Pods("default").Get(ctx, "pod-a", metav1.GetOptions{})
+	if err != nil || len(got.Annotations) != 0 {
+		t.Fatalf("expected the pod untouched, got %+v, %v", got, err)
+	}
+	if !c.ReadOnly() {
+		t.Fatal("ReadOnly = false")
+	}
+}
+
+func TestSetAuditLog_RecordsEveryWriteWithItsOutcome(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default"}}
+	c, _ := newTestClient("ctx1", pod)
+	c.rawConfig = &api.Config{Contexts: map[string]*api.Context{"ctx1": {AuthInfo: "admin"}}}
+	log, err := audit.New(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetAuditLog(log)
+	ctx := context.Background()
+
+	if err := c.AnnotatePod(ctx, "ctx1", "default", "pod-a", "log-level", "debug"); err != nil {
+		t.Fatalf("AnnotatePod: %v", err)
+	}
+	if err := c.DeletePod(ctx, "ctx1", "default", "pod-b"); err == nil {
+		t.Fatal("expected deleting a missing pod to fail")
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("recorded %+v, want the annotate and the delete", entries)
+	}
+	if e := entries[0]; e.Action != "annotate" || e.Resource() != "pod/default/pod-a" || e.User != "admin" || e.Detail != "log-level=debug" || e.Error != "" {
+		t.Errorf("annotate recorded as %+v", e)
+	}
+	if e := entries[1]; e.Action != "delete" || e.Name != "pod-b" || !strings.Contains(e.Error, "not found") {
+		t.Errorf("failed delete recorded as %+v", e)
+	}
+
+	c.SetReadOnly()
+	c.DeletePod(ctx, "ctx1", "default", "pod-a")
+	if entries, _ := log.Entries(); len(entries) != 2 {
+		t.Errorf("a refused call was recorded: %+v", entries[2:])
+	}
+}
+
+func TestResolveServiceForward_PicksReadyPodAndNamedTargetPort(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	_, cs := newTestClient("ctx1", svc, pod("web-a", corev1.ConditionFalse), pod("web-b", corev1.ConditionTrue))
+
+	name, port, err := resolveServiceForward(context.Background(), cs, "default", "web", 80)
+	if err != nil {
+		t.Fatalf("resolveServiceForward: %v", err)
+	}
+	if name != "web-b" || port != 8080 {
+		t.Fatalf("expected web-b:8080, got %s:%d", name, port)
+	}
+	if _, _, err := resolveServiceForward(context.Background(), cs, "default", "web", 443); err == nil {
+		t.Fatal("expected an error for a port the service doesn't expose")
+	}
+}
+
+func TestForwardPod_GivesUpWithItsContext(t *testing.T) {
+	// An API server that never answers the port-forward upgrade.
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	path := filepath.Join(t.TempDir(), "config")
+	body := `apiVersion: v1
+kind: Config
+current-context: hung
+clusters:
+- name: hung
+  cluster: {server: ` + server.URL + `}
+contexts:
+- name: hung
+  context: {cluster: hung}
+`
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stop := make(chan struct{})
+	returned := make(chan error, 1)
+	go func() {
+		_, _, err := c.forwardPod(ctx, "hung", "default", "web-0", 0, 8080, stop)
+		returned <- err
+	}()
+	select {
+	case err := <-returned:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("forwardPod = %v, want the context's deadline", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("forwardPod kept waiting for the listener after its context ended")
+	}
+	select {
+	case <-stop:
+	default:
+		t.Error("stop wasn't closed, so the forward's goroutine is left running")
+	}
+}
+
+func TestParsePodMetrics_SumsContainersAndParsesQuantities(t *testing.T) {
+	data := []byte(`{
+		"kind": "PodMetricsList",
+		"items": [{
+			"metadata": {"name": "web-1", "namespace": "default"},
+			"containers": [
+				{"name": "sidecar", "usage": {"cpu": "1500000n", "memory": "16Mi"}},
+				{"name": "app", "usage": {"cpu": "250m", "memory": "131072Ki"}}
+			]
+		}]
+	}`)
+
+	pods, err := parsePodMetrics(data, "ctx-a")
+	if err != nil {
+		t.Fatalf("parsePodMetrics: %v", err)
+	}
+	if len(pods) != 1 {
+		t.Fatalf("expected 1 pod, got %d", len(pods))
+	}
+	p := pods[0]
+	if p.Name != "web-1" || p.Namespace != "default" || p.Context != "ctx-a" {
+		t.Errorf("unexpected pod identity: %+v", p)
+	}
+	// 1500000n rounds up to 2m.
+	if p.CPUMilli != 252 {
+		t.Errorf("CPUMilli = %d, want 252", p.CPUMilli)
+	}
+	if want := int64(144 * 1024 * 1024); p.MemoryBytes != want {
+		t.Errorf("MemoryBytes = %d, want %d", p.MemoryBytes, want)
+	}
+	if len(p.Containers) != 2 || p.Containers[0].Name != "app" || p.Containers[1].Name != "sidecar" {
+		t.Errorf("containers not sorted by name: %+v", p.Containers)
+	}
+}
+
+func TestRestartPodDeployment_FollowsOwnersAndStampsTemplate(t *testing.T) {
+	isController := true
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &isController}}},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f-abcde", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &isController}}},
+	}
+	bare := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"}}
+	c, clientset := newTestClient("ctx1", deploy, rs, pod, bare)
+
+	name, err := c.GetPodDeployment(context.Background(), "ctx1", "default", "web-7d9f-abcde")
+	if err != nil || name != "web" {
+		t.Fatalf("GetPodDeployment = %q, %v; want web", name, err)
+	}
+	if name, err := c.GetPodDeployment(context.Background(), "ctx1", "default", "debug"); err != nil || name != "" {
+		t.Fatalf("GetPodDeployment(bare pod) = %q, %v; want no deployment", name, err)
+	}
+
+	if err := c.RestartDeployment(context.Background(), "ctx1", "default", "web"); err != nil {
+		t.Fatalf("RestartDeployment: %v", err)
+	}
+	got, err := clientset.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	if got.Spec.Template.Annotations[restartedAtAnnotation] == "" {
+		t.Errorf("expected %s on the pod template, got %v", restartedAtAnnotation, got.Spec.Template.Annotations)
+	}
+
+	if err := c.DeletePod(context.Background(), "ctx1", "default", "debug"); err != nil {
+		t.Fatalf("DeletePod: %v", err)
+	}
+	if _, err := clientset.CoreV1().Pods("default").Get(context.Background(), "debug", metav1.GetOptions{}); err == nil {
+		t.Error("expected pod debug to be gone")
+	}
+}
+
+func TestPlanDrain_SkipsDaemonSetsAndSpendsPDBBudgets(t *testing.T) {
+	isController := true
+	owned := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	pods := []runtime.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default", Labels: map[string]string{"app": "api"}, OwnerReferences: owned("ReplicaSet", "api-x")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-2", Namespace: "default", Labels: map[string]string{"app": "api"}, OwnerReferences: owned("ReplicaSet", "api-x")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "fluentd-abc", Namespace: "kube-system", OwnerReferences: owned("DaemonSet", "fluentd")}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "scratch", Namespace: "default"},
+			Spec:       corev1.PodSpec{Volumes: []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}},
+		},
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-pdb", Namespace: "default"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}
+	c, clientset := newTestClient("ctx1", append(pods, node, pdb)...)
+
+	plan, err := c.PlanDrain(context.Background(), "ctx1", "node-1")
+	if err != nil {
+		t.Fatalf("PlanDrain: %v", err)
+	}
+	got := make(map[string]DrainPod)
+	for _, p := range plan.Pods {
+		got[p.Name] = p
+	}
+	if got["api-1"].BlockedBy != "" || got["api-2"].BlockedBy != "api-pdb" {
+		t.Errorf("expected the PDB to allow api-1 and block api-2, got %+v / %+v", got["api-1"], got["api-2"])
+	}
+	if got["fluentd-abc"].Skip != "DaemonSet" {
+		t.Errorf("expected the DaemonSet pod to be skipped, got %+v", got["fluentd-abc"])
+	}
+	if s := got["scratch"]; !s.Unmanaged || !s.LocalData || s.Skip != "" {
+		t.Errorf("expected scratch to be evicted, flagged unmanaged with local data, got %+v", s)
+	}
+	if plan.Blocked() != 1 {
+		t.Errorf("Blocked() = %d, want 1", plan.Blocked())
+	}
+
+	if err := c.CordonNode(context.Background(), "ctx1", "node-1", true); err != nil {
+		t.Fatalf("CordonNode: %v", err)
+	}
+	n, err := clientset.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	if err != nil || !n.Spec.Unschedulable {
+		t.Errorf("expected node-1 unschedulable, got %v (err %v)", n.Spec.Unschedulable, err)
+	}
+}
+
+func TestParseSelectors_SplitsLabelAndFieldRequirements(t *testing.T) {
+	opts, err := ParseSelectors("app=api, env in (prod,staging), status.phase=Running,app.kubernetes.io/part-of=shop")
+	if err != nil {
+		t.Fatalf("ParseSelectors: %v", err)
+	}
+	if opts.FieldSelector != "status.phase=Running" {
+		t.Errorf("FieldSelector = %q", opts.FieldSelector)
+	}
+	want := "app=api,app.kubernetes.io/part-of=shop,env in (prod,staging)"
+	if opts.LabelSelector != want {
+		t.Errorf("LabelSelector = %q, want %q", opts.LabelSelector, want)
+	}
+
+	if opts, err := ParseSelectors(""); err != nil || opts.LabelSelector != "" || opts.FieldSelector != "" {
+		t.Errorf("empty expression: got %+v, %v", opts, err)
+	}
+	if _, err := ParseSelectors("app in prod"); err == nil {
+		t.Error("expected an invalid label selector to fail")
+	}
+}
+
+func TestListPodInfo_NarrowsBySelectors(t *testing.T) {
+	c, _ := newTestClient("ctx1",
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"app": "api"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	)
+	opts, err := ParseSelectors("app=api")
+	if err != nil {
+		t.Fatalf("ParseSelectors: %v", err)
+	}
+	pods, err := c.ListPodInfo(context.Background(), "ctx1", "default", opts)
+	if err != nil {
+		t.Fatalf("ListPodInfo: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "api" {
+		t.Errorf("expected only the api pod, got %+v", pods)
+	}
+}
+
+func TestParseOrdinals_ExpandsRangesAndPodNames(t *testing.T) {
+	got, err := ParseOrdinals("web", "web-0..web-2, 5, web-1, 7..8")
+	if err != nil {
+		t.Fatalf("ParseOrdinals: %v", err)
+	}
+	want := []string{"web-0", "web-1", "web-2", "web-5", "web-7", "web-8"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, expr := range []string{"", "4..2", "db-0", "web-x", "0..5000"} {
+		if _, err := ParseOrdinals("web", expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+
+	if _, ok := StatefulSetOrdinal("web", "web-api-0"); ok {
+		t.Error("web-api-0 is not an ordinal pod of web")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestTelemetry_CountsInFlightAndRate(t *testing.T) {
+	tel := NewTelemetry()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tel.now = func() time.Time { return now }
+
+	var during RequestUsage
+	rt := tel.wrap("prod")(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		during = tel.Usage("prod")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "https://example.invalid/api/v1/pods", nil)
+	for range 3 {
+		if _, err := rt.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+	}
+
+	if during.InFlight != 1 {
+		t.Errorf("in flight during a request = %d, want 1", during.InFlight)
+	}
+	if got := tel.Usage("prod"); got != (RequestUsage{InFlight: 0, PerMinute: 3}) {
+		t.Errorf("usage after = %+v", got)
+	}
+	if got := tel.Usage("prod").Load(RequestBudget{MaxPerMinute: 4}); got != 0.75 {
+		t.Errorf("load = %v, want 0.75", got)
+	}
+
+	now = now.Add(61 * time.Second)
+	if got := tel.Usage("prod"); got.PerMinute != 0 {
+		t.Errorf("requests older than a minute still counted: %+v", got)
+	}
+	if got := tel.Usage("dev"); got != (RequestUsage{}) {
+		t.Errorf("untouched context has usage %+v", got)
+	}
+}
+
+func TestLoadLimiter_QueuesPastParallelismAndTimesOutSlowContexts(t *testing.T) {
+	limiter := &loadLimiter{}
+	limiter.set(LoadLimits{Parallelism: 1, Timeout: 50 * time.Millisecond})
+
+	release := make(chan struct{})
+	var running atomic.Int32
+	fast := limiter.wrap("dev")(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		running.Add(1)
+		defer running.Add(-1)
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "https://example.invalid/api/v1/pods", nil)
+	done := make(chan error, 2)
+	for range 2 {
+		go func() {
+			resp, err := fast.RoundTrip(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			done <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := running.Load(); n != 1 {
+		t.Fatalf("%d requests running past a parallelism of 1", n)
+	}
+	close(release)
+	for range 2 {
+		if err := <-done; err != nil {
+			t.Errorf("RoundTrip: %v", err)
+		}
+	}
+
+	hung := limiter.wrap("prod")(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}))
+	_, err := hung.RoundTrip(req)
+	var slow *SlowResponseError
+	if !errors.As(err, &slow) || slow.Context != "prod" {
+		t.Fatalf("err = %v, want a SlowResponseError for prod", err)
+	}
+
+	// A caller cancelling isn't the context being slow.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := hung.RoundTrip(req.WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled request: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestErrorKindOf_SeparatesTransientFromAuthAndRBAC(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	for _, tc := range []struct {
+		err  error
+		want ErrorKind
+	}{
+		{apierrors.NewTooManyRequests("slow down", 1), ErrorTransient},
+		{apierrors.NewServerTimeout(pods, "list", 1), ErrorTransient},
+		{fmt.Errorf("list: %w", &SlowResponseError{Context: "prod", Timeout: time.Second}), ErrorTransient},
+		{fmt.Errorf("list: %w", syscall.ECONNRESET), ErrorTransient},
+		{apierrors.NewUnauthorized("expired"), ErrorUnauthorized},
+		{apierrors.NewForbidden(pods, "", errors.New("no list")), ErrorForbidden},
+		{apierrors.NewNotFound(pods, "api-0"), ErrorPermanent},
+	} {
+		if got := ErrorKindOf(tc.err); got != tc.want {
+			t.Errorf("ErrorKindOf(%v) = %d, want %d", tc.err, got, tc.want)
+		}
+	}
+	if got := ExplainError(apierrors.NewForbidden(pods, "", errors.New("no list"))); !strings.HasPrefix(got, "forbidden by RBAC: ") {
+		t.Errorf("ExplainError = %q", got)
+	}
+}
+
+func TestWithRetry_BacksOffOnTransientFailuresOnly(t *testing.T) {
+	defer func(base time.Duration) { retryBase = base }(retryBase)
+	retryBase = time.Millisecond
+	c := &Client{retries: newRetryTracker()}
+
+	calls := 0
+	var during map[string]RetryState
+	got, err := withRetry(context.Background(), c, "prod", func() (string, error) {
+		calls++
+		if calls == 2 {
+			during = c.Retries()
+		}
+		if calls < 3 {
+			return "", apierrors.NewTooManyRequests("slow down", 1)
+		}
+		return "pods", nil
+	})
+	if err != nil || got != "pods" || calls != 3 {
+		t.Fatalf("withRetry = %q, %v after %d calls, want pods after 3", got, err, calls)
+	}
+	if len(during) != 0 {
+		t.Errorf("a retry still listed once it was sent: %+v", during)
+	}
+	if len(c.Retries()) != 0 {
+		t.Errorf("retries left behind: %+v", c.Retries())
+	}
+	select {
+	case <-c.RetriesChanged():
+	default:
+		t.Error("RetriesChanged never signalled")
+	}
+
+	calls = 0
+	if _, err := withRetry(context.Background(), c, "prod", func() (string, error) {
+		calls++
+		return "", apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no list"))
+	}); err == nil || calls != 1 {
+		t.Errorf("forbidden: err = %v after %d calls, want it returned after 1", err, calls)
+	}
+
+	calls = 0
+	if _, err := withRetry(context.Background(), c, "prod", func() (string, error) {
+		calls++
+		return "", apierrors.NewTooManyRequests("slow down", 1)
+	}); err == nil || calls != maxRetries+1 {
+		t.Errorf("throttled: err = %v after %d calls, want it returned after %d", err, calls, maxRetries+1)
+	}
+}
+
+func TestContainerTerminations_ReportsCurrentAndLastState(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "default"},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:                 "app",
+				RestartCount:         3,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled", FinishedAt: finished}},
+			},
+			{Name: "sidecar", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		}},
+	}
+
+	got := ContainerTerminations(pod, "prod")
+	if len(got) != 1 {
+		t.Fatalf("expected one termination, got %+v", got)
+	}
+	e := got[0]
+	if e.Context != "prod" || e.Pod != "api-0" || e.Container != "app" || e.ExitCode != 137 || e.Reason != "OOMKilled" || e.Restarts != 3 || !e.FinishedAt.Equal(finished.Time) {
+		t.Errorf("unexpected termination %+v", e)
+	}
+}
+
+func TestLogOptions_MapsOntoPodLogOptions(t *testing.T) {
+	opts := LogOptions{Container: "app", Follow: true, TailLines: 50, Previous: true}.podLogOptions()
+	if opts.Container != "app" || !opts.Follow || !opts.Previous || opts.TailLines == nil || *opts.TailLines != 50 {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts.SinceSeconds != nil || opts.SinceTime != nil {
+		t.Errorf("expected no since limit, got %+v", opts)
+	}
+
+	opts = LogOptions{Since: 5 * time.Minute}.podLogOptions()
+	if opts.TailLines != nil || opts.SinceSeconds == nil || *opts.SinceSeconds != 300 {
+		t.Errorf("expected SinceSeconds 300 and no tail, got %+v", opts)
+	}
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	opts = LogOptions{Since: time.Hour, SinceTime: at}.podLogOptions()
+	if opts.SinceSeconds != nil || opts.SinceTime == nil || !opts.SinceTime.Time.Equal(at) {
+		t.Errorf("expected SinceTime to win over Since, got %+v", opts)
+	}
+}
+
+func TestNamespaceExists(t *testing.T) {
+	c, _ := newTestClient("ctx1", &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}})
+
+	if ok, err := c.NamespaceExists(context.Background(), "ctx1", "prod"); err != nil || !ok {
+		t.Errorf("prod: got %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := c.NamespaceExists(context.Background(), "ctx1", "staging"); err != nil || ok {
+		t.Errorf("staging: got %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestGetWorkloadEvents_MatchesObjectsNamedAfterIt(t *testing.T) {
+	now := time.Now()
+	event := func(name, kind, object string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object},
+			Reason:         "Test",
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	c, _ := newTestClient("ctx1",
+		event("e1", "Pod", "api-7d9f8-x2k4q", now.Add(-time.Minute)),
+		event("e2", "Deployment", "api", now.Add(-time.Hour)),
+		event("e3", "ReplicaSet", "api-7d9f8", now.Add(-30*time.Minute)),
+		event("e4", "Deployment", "apiserver", now),
+	)
+
+	events, err := c.GetWorkloadEvents(context.Background(), "ctx1", "default", "api")
+	if err != nil {
+		t.Fatalf("GetWorkloadEvents returned error: %v", err)
+	}
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.Object)
+	}
+	want := []string{"Deployment/api", "ReplicaSet/api-7d9f8", "Pod/api-7d9f8-x2k4q"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v (oldest first, apiserver left out)", got, want)
+	}
+}
+
+func TestCompletionOf_OnlyForFinishedPods(t *testing.T) {
+	started := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate-x2k4q", Namespace: "default"},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &started,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1, Reason: "Error", FinishedAt: metav1.NewTime(started.Add(90 * time.Second)),
+				}}},
+			},
+		},
+	}
+	if _, ok := CompletionOf(pod, "prod"); ok {
+		t.Fatal("a running pod has no completion")
+	}
+
+	pod.Status.Phase = corev1.PodFailed
+	c, ok := CompletionOf(pod, "prod")
+	if !ok || c.Phase != "Failed" || c.Duration != 90*time.Second {
+		t.Fatalf("got %+v, %v; want Failed after 1m30s", c, ok)
+	}
+	if len(c.Exits) != 1 || c.Exits[0].Container != "app" || c.Exits[0].ExitCode != 1 {
+		t.Errorf("unexpected exits %+v", c.Exits)
+	}
+}
+
+func TestDeploymentOf_StripsTheTemplateHash(t *testing.T) {
+	controller := true
+	pod := func(kind, owner, hash string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-7f9d8c-x2k4q",
+			Labels:          map[string]string{"pod-template-hash": hash},
+			OwnerReferences: []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}},
+		}}
+	}
+	for _, tc := range []struct {
+		pod  *corev1.Pod
+		want string
+	}{
+		{pod("ReplicaSet", "api-7f9d8c", "7f9d8c"), "api"},
+		{pod("ReplicaSet", "payments-api-7f9d8c", "7f9d8c"), "payments-api"},
+		{pod("ReplicaSet", "hand-made", "7f9d8c"), ""},
+		{pod("ReplicaSet", "api-7f9d8c", ""), ""},
+		{pod("StatefulSet", "db", "7f9d8c"), ""},
+		{&corev1.Pod{}, ""},
+	} {
+		if got := DeploymentOf(tc.pod); got != tc.want {
+			t.Errorf("DeploymentOf(%s owned by %v) = %q, want %q", tc.pod.Name, tc.pod.OwnerReferences, got, tc.want)
+		}
+	}
+}
+
+func TestPodStatus_ReportsWhatKubectlWould(t *testing.T) {
+	ready := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}
+	}
+	pod := func(phase corev1.PodPhase, conditions []corev1.PodCondition, initStatuses []corev1.ContainerStatus, statuses ...corev1.ContainerStatus) *corev1.Pod {
+		p := &corev1.Pod{Status: corev1.PodStatus{Phase: phase, Conditions: conditions, InitContainerStatuses: initStatuses, ContainerStatuses: statuses}}
+		for _, cs := range initStatuses {
+			p.Spec.InitContainers = append(p.Spec.InitContainers, corev1.Container{Name: cs.Name})
+		}
+		return p
+	}
+	terminating := pod(corev1.PodRunning, ready, nil, corev1.ContainerStatus{Name: "app", Ready: true, State: running})
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	for _, tc := range []struct {
+		name string
+		pod  *corev1.Pod
+		want string
+	}{
+		{"healthy", pod(corev1.PodRunning, ready, nil, corev1.ContainerStatus{Name: "app", Ready: true, State: running}), "Running"},
+		{"crash loop", pod(corev1.PodRunning, nil, nil, corev1.ContainerStatus{Name: "app", State: waiting("CrashLoopBackOff")}), "CrashLoopBackOff"},
+		{"image pull", pod(corev1.PodPending, nil, nil, corev1.ContainerStatus{Name: "app", State: waiting("ImagePullBackOff")}), "ImagePullBackOff"},
+		{"not ready", pod(corev1.PodRunning, nil, nil, corev1.ContainerStatus{Name: "app", State: running}), "NotReady"},
+		{"oom killed", pod(corev1.PodRunning, nil, nil, corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}}), "OOMKilled"},
+		{"exit code", pod(corev1.PodFailed, nil, nil, corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 2}}}), "ExitCode:2"},
+		{"init running", pod(corev1.PodPending, nil, []corev1.ContainerStatus{{Name: "migrate", State: running}, {Name: "seed"}}), "Init:0/2"},
+		{"init crash loop", pod(corev1.PodPending, nil, []corev1.ContainerStatus{{Name: "migrate", State: waiting("CrashLoopBackOff")}}), "Init:CrashLoopBackOff"},
+		{"terminating", terminating, "Terminating"},
+	} {
+		if got := PodStatus(tc.pod); got != tc.want {
+			t.Errorf("%s: PodStatus = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	for status, failing := range map[string]bool{
+		"CrashLoopBackOff": true, "Init:ImagePullBackOff": true, "ExitCode:2": true, "OOMKilled": true,
+		"Running": false, "NotReady": false, "Init:0/2": false, "ContainerCreating": false, "Completed": false,
+	} {
+		if got := PodStatusFailing(status); got != failing {
+			t.Errorf("PodStatusFailing(%q) = %t, want %t", status, got, failing)
+		}
+	}
+}
+
+func TestPodToPodInfo_CountsReadyAgainstTheSpec(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "proxy"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	if got := PodToPodInfo(pod, "ctx").ReadyContainers; got != "0/2" {
+		t.Errorf("pending pod: ReadyContainers = %q, want 0/2", got)
+	}
+
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", Ready: true}, {Name: "proxy"}}
+	if got := PodToPodInfo(pod, "ctx").ReadyContainers; got != "1/2" {
+		t.Errorf("half-ready pod: ReadyContainers = %q, want 1/2", got)
+	}
+}
+
+func TestListNodeInfo_ReportsStatusRolesAndPressure(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, labels map[string]string, extra ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{
+				Conditions: append([]corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}, extra...),
+				NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.31.2"},
+			},
+		}
+	}
+	cordoned := node("worker-b", corev1.ConditionTrue, nil)
+	cordoned.Spec.Unschedulable = true
+	c, _ := newTestClient("ctx",
+		node("cp", corev1.ConditionTrue, map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+		node("worker-a", corev1.ConditionFalse, map[string]string{"kubernetes.io/role": "worker"},
+			corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+			corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse}),
+		cordoned,
+		node("worker-c", corev1.ConditionUnknown, nil),
+	)
+
+	nodes, err := c.ListNodeInfo(context.Background(), "ctx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type row struct {
+		name, status, roles, conditions string
+	}
+	var got []row
+	for _, n := range nodes {
+		got = append(got, row{n.Name, n.Status, strings.Join(n.Roles, ","), strings.Join(n.Conditions, ",")})
+	}
+	want := []row{
+		{"cp", "Ready", "control-plane", ""},
+		{"worker-a", "NotReady", "worker", "MemoryPressure"},
+		{"worker-b", "Ready,SchedulingDisabled", "", ""},
+		{"worker-c", "Unknown", "", ""},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ListNodeInfo =\n%v\nwant\n%v", got, want)
+	}
+	if nodes[0].Version != "v1.31.2" {
+		t.Errorf("Version = %q, want v1.31.2", nodes[0].Version)
+	}
+
+	for status, failing := range map[string]bool{
+		"Ready": false, "Ready,SchedulingDisabled": false, "NotReady": true, "Unknown": true,
+	} {
+		if got := NodeStatusFailing(status); got != failing {
+			t.Errorf("NodeStatusFailing(%q) = %t, want %t", status, got, failing)
+		}
+	}
+}
+
+func TestRollout_HistoryAndUndo(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	template := func(image, hash string) corev1.PodTemplateSpec {
+		labels := map[string]string{"app": "web"}
+		if hash != "" {
+			labels["pod-template-hash"] = hash
+		}
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+		}
+	}
+	replicas := int32(2)
+	isController := true
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-uid", Annotations: map[string]string{revisionAnnotation: "3"}},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Selector: selector, Template: template("web:3", "")},
+		Status:     appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 1},
+	}
+	rs := func(rev, image, hash string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-" + hash,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "web"},
+				Annotations:     map[string]string{revisionAnnotation: rev, changeCauseAnnotation: "set image " + image},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "web-uid", Controller: &isController}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Selector: selector, Template: template(image, hash)},
+		}
+	}
+	c, clientset := newTestClient("ctx", deployment, rs("1", "web:1", "aaa"), rs("2", "web:2", "bbb"), rs("3", "web:3", "ccc"))
+
+	r, err := c.GetRollout(context.Background(), "ctx", "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var revs []int64
+	for _, rev := range r.Revisions {
+		revs = append(revs, rev.Revision)
+	}
+	if !slices.Equal(revs, []int64{3, 2, 1}) || !r.Revisions[0].Current || r.Revisions[1].Current {
+		t.Fatalf("revisions = %v (current %t), want 3, 2, 1 with 3 current", revs, r.Revisions[0].Current)
+	}
+	if r.Done || !strings.Contains(r.Status, "1 of 2 updated replicas are available") {
+		t.Errorf("Status = %q (done %t), want waiting on availability", r.Status, r.Done)
+	}
+	if r.Revisions[1].ChangeCause != "set image web:2" {
+		t.Errorf("ChangeCause = %q", r.Revisions[1].ChangeCause)
+	}
+
+	rev, err := c.UndoRollout(context.Background(), "ctx", "default", "web", 0)
+	if err != nil || rev != 2 {
+		t.Fatalf("UndoRollout = %d, %v; want revision 2", rev, err)
+	}
+	got, _ := clientset.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if image := got.Spec.Template.Spec.Containers[0].Image; image != "web:2" {
+		t.Errorf("template image = %s, want web:2", image)
+	}
+	if _, ok := got.Spec.Template.Labels["pod-template-hash"]; ok {
+		t.Error("the ReplicaSet's hash label was copied into the template")
+	}
+
+	if _, err := c.UndoRollout(context.Background(), "ctx", "default", "web", 7); err == nil {
+		t.Error("undo to a missing revision succeeded")
+	}
+	got.Spec.Paused = true
+	if _, err := clientset.AppsV1().Deployments("default").Update(context.Background(), got, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UndoRollout(context.Background(), "ctx", "default", "web", 1); !errors.Is(err, ErrRolloutPaused) {
+		t.Errorf("undo of a paused deployment: err = %v, want ErrRolloutPaused", err)
+	}
+}
+
+func TestListIngressRoutes_FlattensRulesAndMatchesTLS(t *testing.T) {
+	class := "nginx"
+	prefix := networkingv1.PathTypePrefix
+	backend := func(svc string, port int32) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: svc, Port: networkingv1.ServiceBackendPort{Number: port}}}
+	}
+	rule := func(host string, paths ...string) networkingv1.IngressRule {
+		r := networkingv1.IngressRule{Host: host, IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{}}}
+		for _, p := range paths {
+			r.HTTP.Paths = append(r.HTTP.Paths, networkingv1.HTTPIngressPath{Path: p, PathType: &prefix, Backend: backend("web", 80)})
+		}
+		return r
+	}
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &class,
+			DefaultBackend:   &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "fallback", Port: networkingv1.ServiceBackendPort{Name: "http"}}},
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"*.example.com"}, SecretName: "wildcard"}},
+			Rules:            []networkingv1.IngressRule{rule("shop.example.com", "/", "/api"), rule("example.org", "/")},
+		},
+		Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "203.0.113.7"}}}},
+	}
+	c, _ := newTestClient("ctx", ing)
+
+	routes, err := c.ListIngressRoutes(context.Background(), "ctx", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range routes {
+		got = append(got, strings.Join([]string{r.Host, r.Path, r.Backend, r.TLSSecret}, " "))
+	}
+	want := []string{
+		"*  fallback:http ",
+		"shop.example.com / web:80 wildcard",
+		"shop.example.com /api web:80 wildcard",
+		"example.org / web:80 ",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("routes =\n%q\nwant\n%q", got, want)
+	}
+	if routes[0].Class != "nginx" || routes[0].Address != "203.0.113.7" {
+		t.Errorf("class/address = %q/%q", routes[0].Class, routes[0].Address)
+	}
+}
+
+func TestGetServiceBackends_NamesPodsAndReadiness(t *testing.T) {
+	ready, notReady := true, false
+	port, proto, name := int32(8080), corev1.ProtocolTCP, "http"
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+		Ports:      []discoveryv1.EndpointPort{{Name: &name, Port: &port, Protocol: &proto}},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+			{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+		},
+	}
+	other := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "api"}},
+		Endpoints:  []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.9"}}},
+	}
+	c, _ := newTestClient("ctx", slice, other)
+
+	backends, err := c.GetServiceBackends(context.Background(), "ctx", "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backends) != 2 || backends[0].Pod != "web-1" || !backends[0].Ready || backends[1].Ready {
+		t.Fatalf("backends = %+v, want web-1 ready then web-2 not", backends)
+	}
+	if !slices.Equal(backends[0].Ports, []string{"http 8080/TCP"}) {
+		t.Errorf("ports = %v", backends[0].Ports)
+	}
+}
+
+func TestNewClient_DefersTheConnectionCheck(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, `{"gitVersion":"v1.29.3"}`)
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	path := filepath.Join(t.TempDir(), "config")
+	body := `apiVersion: v1
+kind: Config
+current-context: down
+clusters:
+- name: up
+  cluster: {server: ` + up.URL + `}
+- name: down
+  cluster: {server: ` + down.URL + `}
+contexts:
+- name: up
+  context: {cluster: up}
+- name: down
+  context: {cluster: down}
+`
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(path)
+	if err != nil {
+		t.Fatalf("an unreachable current context must not fail NewClient: %v", err)
+	}
+	if _, err := c.CheckConnection(context.Background(), "down"); err == nil {
+		t.Error("expected the closed server to be reported unreachable")
+	}
+	version, err := c.CheckConnection(context.Background(), "up")
+	if err != nil || version != "v1.29.3" {
+		t.Fatalf("got %q, %v; want v1.29.3", version, err)
+	}
+}
+
+func TestCustomResources_DiscoverResolveAndPrinterColumns(t *testing.T) {
+	c, clientset := newTestClient("ctx1")
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "watch"}},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+		}},
+		{GroupVersion: "cert-manager.io/v1", APIResources: []metav1.APIResource{
+			{Name: "certificates", SingularName: "certificate", ShortNames: []string{"cert"}, Kind: "Certificate", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		}},
+		{GroupVersion: "cert-manager.io/v1alpha2", APIResources: []metav1.APIResource{
+			{Name: "certificates", SingularName: "certificate", Kind: "Certificate", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		}},
+	}
+
+	resources, err := c.ListAPIResources("ctx1")
+	if err != nil {
+		t.Fatalf("ListAPIResources returned error: %v", err)
+	}
+	var names []string
+	for _, r := range resources {
+		names = append(names, r.String())
+	}
+	if want := []string{"v1/pods", "cert-manager.io/v1/certificates", "cert-manager.io/v1alpha2/certificates"}; !slices.Equal(names, want) {
+		t.Fatalf("resources = %v, want %v (subresources dropped)", names, want)
+	}
+	for _, query := range []string{"cert", "Certificate", "certificates.cert-manager.io", "cert-manager.io/v1/certificates"} {
+		if r, ok := ResolveAPIResource(resources, query); !ok || r.Version != "v1" {
+			t.Errorf("ResolveAPIResource(%q) = %v, %v; want the preferred v1", query, r, ok)
+		}
+	}
+	if r, ok := ResolveAPIResource(resources, "cert-manager.io/v1alpha2/certificates"); !ok || r.Version != "v1alpha2" {
+		t.Errorf("an explicit version should resolve to it, got %v, %v", r, ok)
+	}
+	if _, ok := ResolveAPIResource(resources, "widgets"); ok {
+		t.Error("an unknown type should not resolve")
+	}
+
+	cert, _ := ResolveAPIResource(resources, "cert")
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "certificates.cert-manager.io"},
+		"spec": map[string]any{"versions": []any{
+			map[string]any{"name": "v1", "additionalPrinterColumns": []any{
+				map[string]any{"name": "Ready", "type": "string", "jsonPath": `.status.conditions[?(@.type=="Ready")].status`},
+				map[string]any{"name": "Secret", "type": "string", "jsonPath": ".spec.secretName"},
+				map[string]any{"name": "Issuer", "type": "string", "jsonPath": ".spec.issuerRef.name", "priority": int64(1)},
+				map[string]any{"name": "Age", "type": "date", "jsonPath": ".metadata.creationTimestamp"},
+			}},
+		}},
+	}}
+	certObj := func(name, namespace string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata":   map[string]any{"name": name, "namespace": namespace},
+			"spec":       map[string]any{"secretName": name + "-tls"},
+			"status": map[string]any{"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True"},
+			}},
+		}}
+	}
+	c.dynamicByContext = map[string]dynamic.Interface{
+		"ctx1": dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{
+				cert.GVR():  "CertificateList",
+				crdResource: "CustomResourceDefinitionList",
+			},
+			crd, certObj("web", "shop"), certObj("api", "shop"), certObj("other", "elsewhere")),
+	}
+
+	list, err := c.ListCustomResources(context.Background(), "ctx1", "shop", cert)
+	if err != nil {
+		t.Fatalf("ListCustomResources returned error: %v", err)
+	}
+	var cols []string
+	for _, col := range list.Columns {
+		cols = append(cols, col.Name)
+	}
+	if want := []string{"Ready", "Secret"}; !slices.Equal(cols, want) {
+		t.Fatalf("columns = %v, want %v (priority and Age columns dropped)", cols, want)
+	}
+	if len(list.Items) != 2 || list.Items[0].Name != "api" || list.Items[1].Name != "web" {
+		t.Fatalf("items = %+v, want api and web from shop only", list.Items)
+	}
+	if got := list.Items[1].Values; got["Ready"] != "True" || got["Secret"] != "web-tls" || list.Items[1].Context != "ctx1" {
+		t.Errorf("web = %+v, want Ready True, Secret web-tls in ctx1", list.Items[1])
+	}
+}
+
+func TestAccessChecks_ReviewCacheAndRefuseForbiddenLists(t *testing.T) {
+	c, clientset := newTestClient("ctx1")
+	c.access = &accessCache{answers: make(map[accessKey]accessAnswer)}
+	var reviews atomic.Int32
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews.Add(1)
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Resource == "pods" && attrs.Verb == "list" && attrs.Namespace == "default"
+		return true, review, nil
+	})
+	ctx := context.Background()
+
+	if allowed, err := c.CanI(ctx, "ctx1", "default", ListPods); err != nil || !allowed {
+		t.Fatalf("CanI(list pods in default) = %v, %v; want allowed", allowed, err)
+	}
+	if allowed, err := c.CanI(ctx, "ctx1", "default", ListPods); err != nil || !allowed || reviews.Load() != 1 {
+		t.Fatalf("second CanI = %v, %v after %d reviews; want the cached answer", allowed, err, reviews.Load())
+	}
+	if allowed, known := c.KnownAccess("ctx1", "default", ExecPods); known {
+		t.Fatalf("KnownAccess(exec) = %v, known; want unreviewed", allowed)
+	}
+	if _, err := c.CanI(ctx, "ctx1", "", ExecPods); err != nil {
+		t.Fatal(err)
+	}
+	if allowed, known := c.KnownAccess("ctx1", "default", ExecPods); !known || allowed {
+		t.Fatalf("KnownAccess(exec in default) = %v, %v; want the all-namespaces denial", allowed, known)
+	}
+
+	// Checks off: the list goes ahead without a review.
+	w, err := c.WatchPods(ctx, "ctx1", "kube-system", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("WatchPods with checks off: %v", err)
+	}
+	w.Stop()
+	c.SetAccessChecks(true)
+	_, err = c.WatchPods(ctx, "ctx1", "kube-system", metav1.ListOptions{})
+	var denied *PermissionError
+	if !errors.As(err, &denied) || ErrorKindOf(err) != ErrorForbidden {
+		t.Fatalf("WatchPods in kube-system = %v; want a PermissionError", err)
+	}
+	if got, want := ExplainError(err), "no permission (pods/list in ns kube-system)"; got != want {
+		t.Errorf("ExplainError = %q, want %q", got, want)
+	}
+	w, err = c.WatchPods(ctx, "ctx1", "default", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("WatchPods in default: %v", err)
+	}
+	w.Stop()
+}
+
+func TestScrapePort_AnnotationThenMetricsPortThenOnlyPort(t *testing.T) {
+	pod := func(annotations map[string]string, ports ...corev1.ContainerPort) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Ports: ports}}},
+		}
+	}
+	web := corev1.ContainerPort{Name: "http", ContainerPort: 8080}
+	prom := corev1.ContainerPort{Name: "http-metrics", ContainerPort: 9102}
+
+	for _, tc := range []struct {
+		pod  *corev1.Pod
+		want int
+	}{
+		{pod(map[string]string{"prometheus.io/port": "9090"}, web, prom), 9090},
+		{pod(nil, web, prom), 9102},
+		{pod(nil, web), 8080},
+	} {
+		if got, err := scrapePort(tc.pod); err != nil || got != tc.want {
+			t.Errorf("scrapePort = %d, %v; want %d", got, err, tc.want)
+		}
+	}
+	if _, err := scrapePort(pod(nil, web, corev1.ContainerPort{Name: "grpc", ContainerPort: 9000})); err == nil {
+		t.Error("expected an error with two ports and no annotation")
+	}
+	if _, err := scrapePort(pod(map[string]string{"prometheus.io/port": "http"})); err == nil {
+		t.Error("expected a named port annotation rejected")
+	}
+}
@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SpecField is one compared setting of a Deployment, named so the same
+// setting lines up across contexts: "replicas", "<container> image",
+// "<container> env LOG_LEVEL", "<container> limits.cpu".
+type SpecField struct {
+	Name  string
+	Value string
+}
+
+// DeploymentSpec is what a Deployment compare lines up: the desired
+// replicas and, per container, its image, env and resources, in spec
+// order. Missing is set when the context has no such Deployment.
+type DeploymentSpec struct {
+	Context string
+	Missing bool
+	Fields  []SpecField
+}
+
+// GetDeploymentSpec returns the settings of a Deployment that matter when
+// comparing it across contexts. Env values are taken as the spec has them:
+// a valueFrom is described ("configmap app-config:LOG_LEVEL"), not
+// resolved, so two clusters reading the same key compare equal.
+func (c *Client) GetDeploymentSpec(ctx context.Context, kubeContext, namespace, name string) (DeploymentSpec, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return DeploymentSpec{}, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return DeploymentSpec{Context: kubeContext, Missing: true}, nil
+	}
+	if err != nil {
+		return DeploymentSpec{}, fmt.Errorf("failed to get deployment %s in namespace %s (context %s): %w", name, namespace, kubeContext, err)
+	}
+	spec := deploymentSpec(deployment)
+	spec.Context = kubeContext
+	return spec, nil
+}
+
+func deploymentSpec(d *appsv1.Deployment) DeploymentSpec {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	spec := DeploymentSpec{Fields: []SpecField{{Name: "replicas", Value: strconv.Itoa(int(replicas))}}}
+	add := func(name, value string) {
+		spec.Fields = append(spec.Fields, SpecField{Name: name, Value: value})
+	}
+
+	for _, ctr := range d.Spec.Template.Spec.Containers {
+		add(ctr.Name+" image", ctr.Image)
+		for _, from := range ctr.EnvFrom {
+			switch {
+			case from.ConfigMapRef != nil:
+				add(ctr.Name+" envFrom "+from.Prefix+"*", "configmap "+from.ConfigMapRef.Name)
+			case from.SecretRef != nil:
+				add(ctr.Name+" envFrom "+from.Prefix+"*", "secret "+from.SecretRef.Name)
+			}
+		}
+		for _, env := range ctr.Env {
+			add(ctr.Name+" env "+env.Name, envSpecValue(env))
+		}
+		for _, kind := range []struct {
+			name string
+			list corev1.ResourceList
+		}{{"requests", ctr.Resources.Requests}, {"limits", ctr.Resources.Limits}} {
+			resources := make([]string, 0, len(kind.list))
+			for resource := range kind.list {
+				resources = append(resources, string(resource))
+			}
+			sort.Strings(resources)
+			for _, resource := range resources {
+				q := kind.list[corev1.ResourceName(resource)]
+				add(ctr.Name+" "+kind.name+"."+resource, q.String())
+			}
+		}
+	}
+	return spec
+}
+
+// envSpecValue is an env var's value as its spec has it: the literal, or
+// where a valueFrom reads it from. Secret values are never fetched.
+func envSpecValue(env corev1.EnvVar) string {
+	from := env.ValueFrom
+	switch {
+	case from == nil:
+		return env.Value
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("configmap %s:%s", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("secret %s:%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+	case from.FieldRef != nil:
+		return "fieldRef " + from.FieldRef.FieldPath
+	case from.ResourceFieldRef != nil:
+		return "resourceFieldRef " + from.ResourceFieldRef.Resource
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// connectTimeout bounds CheckConnection: a cluster that hasn't answered by
+// then is reported unreachable.
+const connectTimeout = 10 * time.Second
+
+// CheckConnection asks the context's API server for its version, the way
+// `kubectl version` does, and returns it (e.g. "v1.29.3"). It blocks for up
+// to connectTimeout, so callers run it off the UI's goroutine; clients are
+// created without it (see createClientForContext), letting one unreachable
+// cluster be reported on its own instead of stalling everything else.
+func (c *Client) CheckConnection(ctx context.Context, kubeContext string) (string, error) {
+	cfg, err := c.restConfigForContext(kubeContext)
+	if err != nil {
+		return "", fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	cfg = rest.CopyConfig(cfg)
+	cfg.Timeout = connectTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create discovery client for context %s: %w", kubeContext, err)
+	}
+	// dc.ServerVersion, but cancellable.
+	body, err := dc.RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to cluster in context %s: %w", kubeContext, err)
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("failed to read the server version (context %s): %w", kubeContext, err)
+	}
+	return info.GitVersion, nil
+}
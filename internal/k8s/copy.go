@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// CopyFromPod copies a file or directory out of a container to dest on the
+// local filesystem — the `kubectl cp pod:src dest` equivalent. It streams
+// `tar cf -` over exec, so the image needs a tar binary, and extracts as it
+// reads: src itself lands at dest (a directory's contents go under it).
+// progress, if set, is called with the running count of tar bytes received.
+// Symlinks and special files in the archive are skipped, and any entry that
+// would land outside dest is rejected.
+func (c *Client) CopyFromPod(ctx context.Context, kubeContext, namespace, podName, container, src, dest string, progress func(int64)) (int64, error) {
+	src = path.Clean(src)
+	if src == "/" {
+		return 0, fmt.Errorf("refusing to copy the container's root directory")
+	}
+
+	pr, pw := io.Pipe()
+	var stderr bytes.Buffer
+	go func() {
+		err := c.Exec(ctx, kubeContext, namespace, podName, ExecOptions{
+			Container: container,
+			Command:   []string{"tar", "cf", "-", "-C", path.Dir(src), path.Base(src)},
+			Stdout:    pw,
+			Stderr:    &stderr,
+		})
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+
+	cr := &countingReader{r: pr, progress: progress}
+	err := extractTar(cr, path.Base(src), dest)
+	// Unblock the exec goroutine if extraction stopped early.
+	pr.CloseWithError(err)
+	if err != nil {
+		return cr.n, fmt.Errorf("failed to copy %s from pod %s/%s (context %s): %w", src, namespace, podName, kubeContext, err)
+	}
+	return cr.n, nil
+}
+
+// countingReader reports the running total of bytes read through it.
+type countingReader struct {
+	r        io.Reader
+	n        int64
+	progress func(int64)
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if n > 0 && c.progress != nil {
+		c.progress(c.n)
+	}
+	return n, err
+}
+
+// extractTar writes the archive rooted at base (the basename tar was given)
+// to dest, renaming base to dest.
+func extractTar(r io.Reader, base, dest string) error {
+	tr := tar.NewReader(r)
+	dest = filepath.Clean(dest)
+	sawEntry := false
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(hdr.Name)
+		var target string
+		switch {
+		case name == base:
+			target = dest
+		case strings.HasPrefix(name, base+"/"):
+			target = filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(name, base+"/")))
+		default:
+			return fmt.Errorf("unexpected archive entry %q", hdr.Name)
+		}
+		if target != dest && !strings.HasPrefix(target, dest+string(filepath.Separator)) {
+			return fmt.Errorf("archive entry %q escapes the destination", hdr.Name)
+		}
+		sawEntry = true
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		default:
+			log.Printf("copy: skipping %s (not a regular file or directory)", hdr.Name)
+		}
+	}
+
+	if !sawEntry {
+		return fmt.Errorf("nothing received (is tar available in the container?)")
+	}
+	return nil
+}
+
+func writeFile(target string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,297 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// crdResource is where a CustomResourceDefinition is read from for its
+// printer columns: through the dynamic client, so no apiextensions client
+// is needed.
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// APIResource is a listable resource type the API server serves, as
+// discovery reports it.
+type APIResource struct {
+	Group    string
+	Version  string
+	Resource string // plural, e.g. "certificates"
+	Kind     string
+	// Singular and ShortNames are what else it can be typed as, e.g.
+	// "certificate" and "cert".
+	Singular   string
+	ShortNames []string
+	Namespaced bool
+	// Preferred is whether Version is the group's preferred version, the
+	// one a bare name resolves to.
+	Preferred bool
+}
+
+// String is the type's group/version/resource, written like an apiVersion
+// and a resource: "v1/pods", "cert-manager.io/v1/certificates".
+func (r APIResource) String() string {
+	return schema.GroupVersion{Group: r.Group, Version: r.Version}.String() + "/" + r.Resource
+}
+
+// GVR is the type as the dynamic client addresses it.
+func (r APIResource) GVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// dynamicClientForContext returns the context's dynamic client, creating
+// and caching it if needed.
+func (c *Client) dynamicClientForContext(kubeContext string) (dynamic.Interface, error) {
+	c.mu.RLock()
+	client, ok := c.dynamicByContext[kubeContext]
+	c.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	cfg, err := c.restConfigForContext(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	client, err = dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client for context %s: %w", kubeContext, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dynamicByContext == nil {
+		c.dynamicByContext = make(map[string]dynamic.Interface)
+	}
+	c.dynamicByContext[kubeContext] = client
+	return client, nil
+}
+
+// ListAPIResources discovers the resource types a context can list, every
+// served version of each, sorted by group, resource and version. Groups
+// whose discovery fails (an aggregated API that's down, say) are left out
+// rather than failing the rest.
+func (c *Client) ListAPIResources(kubeContext string) ([]APIResource, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	groups, lists, err := clientset.Discovery().ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover resource types (context %s): %w", kubeContext, err)
+	}
+	preferred := map[string]string{"": "v1"}
+	for _, g := range groups {
+		preferred[g.Name] = g.PreferredVersion.Version
+	}
+
+	var resources []APIResource
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			// Subresources (pods/log, deployments/scale) can't be listed.
+			if strings.Contains(r.Name, "/") || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			resources = append(resources, APIResource{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Resource:   r.Name,
+				Kind:       r.Kind,
+				Singular:   r.SingularName,
+				ShortNames: r.ShortNames,
+				Namespaced: r.Namespaced,
+				Preferred:  preferred[gv.Group] == gv.Version,
+			})
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.Version < b.Version
+	})
+	return resources, nil
+}
+
+// ResolveAPIResource finds the type query names among resources: its
+// group/version/resource, or — in the preferred version — its plural,
+// singular, kind or a short name, optionally qualified by group as kubectl
+// takes them ("certificates.cert-manager.io", "cert"). Case doesn't matter.
+func ResolveAPIResource(resources []APIResource, query string) (APIResource, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	for _, r := range resources {
+		if strings.ToLower(r.String()) == query {
+			return r, true
+		}
+	}
+	for _, r := range resources {
+		if !r.Preferred {
+			continue
+		}
+		names := append([]string{r.Resource, r.Singular, strings.ToLower(r.Kind)}, r.ShortNames...)
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if query == name || (r.Group != "" && query == name+"."+r.Group) {
+				return r, true
+			}
+		}
+	}
+	return APIResource{}, false
+}
+
+// PrinterColumn is one of a CRD's additionalPrinterColumns: what `kubectl
+// get` shows for its resources besides the name and age.
+type PrinterColumn struct {
+	Name     string
+	Type     string // "string", "integer", "date", ...
+	JSONPath string
+}
+
+// CustomResource is a row of the custom resource viewer.
+type CustomResource struct {
+	Name      string
+	Namespace string
+	Context   string
+	Age       string
+	// Values holds each printer column's value, by column name.
+	Values map[string]string
+}
+
+// CustomResourceList is a type's resources in one context with the
+// printer columns they were read with.
+type CustomResourceList struct {
+	Columns []PrinterColumn
+	Items   []CustomResource
+}
+
+// ListCustomResources lists any type's resources in a namespace ("" for
+// all; ignored for a cluster-scoped type) through the dynamic client,
+// sorted by namespace and name. For a CRD-defined type each resource
+// carries the values of the CRD's printer columns — those kubectl shows
+// without -o wide — read with their JSONPaths; a built-in type, or a CRD
+// that can't be read, gets none.
+func (c *Client) ListCustomResources(ctx context.Context, kubeContext, namespace string, resource APIResource) (CustomResourceList, error) {
+	client, err := c.dynamicClientForContext(kubeContext)
+	if err != nil {
+		return CustomResourceList{}, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	var ri dynamic.ResourceInterface = client.Resource(resource.GVR())
+	if resource.Namespaced {
+		ri = client.Resource(resource.GVR()).Namespace(namespace)
+	}
+	list, err := withRetry(ctx, c, kubeContext, func() (*unstructured.UnstructuredList, error) {
+		return ri.List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return CustomResourceList{}, fmt.Errorf("failed to list %s in namespace %s (context %s): %w", resource, namespace, kubeContext, err)
+	}
+
+	out := CustomResourceList{Columns: printerColumns(ctx, client, resource)}
+	parsers := make([]*jsonpath.JSONPath, len(out.Columns))
+	for i, col := range out.Columns {
+		jp := jsonpath.New(col.Name).AllowMissingKeys(true)
+		if err := jp.Parse("{" + col.JSONPath + "}"); err == nil {
+			parsers[i] = jp
+		}
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		cr := CustomResource{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+			Context:   kubeContext,
+			Age:       formatDuration(time.Since(item.GetCreationTimestamp().Time)),
+			Values:    make(map[string]string, len(out.Columns)),
+		}
+		for j, col := range out.Columns {
+			if parsers[j] != nil {
+				cr.Values[col.Name] = printerValue(parsers[j], col, item)
+			}
+		}
+		out.Items = append(out.Items, cr)
+	}
+	sort.Slice(out.Items, func(i, j int) bool {
+		a, b := out.Items[i], out.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return out, nil
+}
+
+// printerColumns reads the printer columns of a CRD-defined type's version,
+// leaving out priority ones (kubectl's -o wide) and Age, which every type
+// gets anyway. nil for a built-in type or when the CRD can't be read.
+func printerColumns(ctx context.Context, client dynamic.Interface, resource APIResource) []PrinterColumn {
+	if resource.Group == "" || !strings.Contains(resource.Group, ".") {
+		return nil
+	}
+	crd, err := client.Resource(crdResource).Get(ctx, resource.Resource+"."+resource.Group, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]any)
+		if !ok || version["name"] != resource.Version {
+			continue
+		}
+		cols, _, _ := unstructured.NestedSlice(version, "additionalPrinterColumns")
+		var out []PrinterColumn
+		for _, c := range cols {
+			col, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := col["name"].(string)
+			path, _ := col["jsonPath"].(string)
+			priority, _, _ := unstructured.NestedInt64(col, "priority")
+			if name == "" || path == "" || priority > 0 || strings.EqualFold(name, "age") {
+				continue
+			}
+			typ, _ := col["type"].(string)
+			out = append(out, PrinterColumn{Name: name, Type: typ, JSONPath: path})
+		}
+		return out
+	}
+	return nil
+}
+
+// printerValue evaluates a printer column against a resource; a date
+// column reads as an age, as kubectl shows it.
+func printerValue(jp *jsonpath.JSONPath, col PrinterColumn, item *unstructured.Unstructured) string {
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, item.Object); err != nil {
+		return ""
+	}
+	value := buf.String()
+	if col.Type == "date" && value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return formatDuration(time.Since(t))
+		}
+	}
+	return value
+}
@@ -6,25 +6,30 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
+// DeploymentInfo is a deployment's table row. The JSON names are what
+// `ktails get deployments` prints.
 type DeploymentInfo struct {
-	Name              string
-	Namespace         string
-	Age               string
-	ReadyReplicas     int32
-	DesiredReplicas   int32
-	AvailableReplicas int32
-	UpdatedReplicas   int32
-	Strategy          string
-	Selector          string
-	Status            []string
+	Name              string   `json:"name"`
+	Namespace         string   `json:"namespace"`
+	Age               string   `json:"age"`
+	ReadyReplicas     int32    `json:"readyReplicas"`
+	DesiredReplicas   int32    `json:"desiredReplicas"`
+	AvailableReplicas int32    `json:"availableReplicas"`
+	UpdatedReplicas   int32    `json:"updatedReplicas"`
+	Strategy          string   `json:"strategy"`
+	Selector          string   `json:"selector"`
+	Images            []string `json:"images"` // the pod template's containers'
+	Status            []string `json:"status,omitempty"`
 }
 
-// GetDeploymentInfo retrieves deployment information for a specific context and namespace
-func (c *Client) GetDeploymentInfo(kubeContextName, namespace string) ([]DeploymentInfo, error) {
+// GetDeploymentInfo retrieves deployment information for a specific context and namespace,
+// narrowed by opts' label and field selectors
+func (c *Client) GetDeploymentInfo(ctx context.Context, kubeContextName, namespace string, opts v1.ListOptions) ([]DeploymentInfo, error) {
 	// Get the appropriate client for this context
 	clientset, err := c.GetClientForContext(kubeContextName)
 	if err != nil {
@@ -33,8 +38,8 @@ func (c *Client) GetDeploymentInfo(kubeContextName, namespace string) ([]Deploym
 
 	// List deployments
 	deploymentList, err := clientset.AppsV1().Deployments(namespace).List(
-		context.Background(),
-		v1.ListOptions{},
+		ctx,
+		opts,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployments in namespace %s (context %s): %w",
@@ -50,6 +55,24 @@ func (c *Client) GetDeploymentInfo(kubeContextName, namespace string) ([]Deploym
 	return deploymentInfoList, nil
 }
 
+// ResyncDeployments mirrors ResyncPods for Deployments.
+func (c *Client) ResyncDeployments(ctx context.Context, kubeContextName, namespace string, opts v1.ListOptions) (*appsv1.DeploymentList, error) {
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	opts.ResourceVersion = "0"
+	deploymentList, err := withRetry(ctx, c, kubeContextName, func() (*appsv1.DeploymentList, error) {
+		return clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments in namespace %s (context %s): %w",
+			namespace, kubeContextName, err)
+	}
+	return deploymentList, nil
+}
+
 // DeploymentToDeploymentInfo converts a deployment object to DeploymentInfo.
 func DeploymentToDeploymentInfo(deployment *appsv1.Deployment) DeploymentInfo {
 	age := formatDuration(time.Since(deployment.CreationTimestamp.Time))
@@ -60,6 +83,11 @@ func DeploymentToDeploymentInfo(deployment *appsv1.Deployment) DeploymentInfo {
 		desiredReplicas = *deployment.Spec.Replicas
 	}
 
+	images := make([]string, 0, len(deployment.Spec.Template.Spec.Containers))
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+
 	return DeploymentInfo{
 		Name:              deployment.Name,
 		Namespace:         deployment.Namespace,
@@ -70,19 +98,20 @@ func DeploymentToDeploymentInfo(deployment *appsv1.Deployment) DeploymentInfo {
 		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
 		Strategy:          string(deployment.Spec.Strategy.Type),
 		Selector:          v1.FormatLabelSelector(deployment.Spec.Selector),
+		Images:            images,
 		Status:            []string{}, // You can add status conditions here if needed
 	}
 }
 
 // GetDeploymentDetail fetches a single deployment's status, rendered YAML, and recent events.
-func (c *Client) GetDeploymentDetail(kubeContextName, namespace, deploymentName string) (ResourceDetail, error) {
+func (c *Client) GetDeploymentDetail(ctx context.Context, kubeContextName, namespace, deploymentName string) (ResourceDetail, error) {
 	d := ResourceDetail{Kind: "Deployment"}
 	clientset, err := c.GetClientForContext(kubeContextName)
 	if err != nil {
 		return d, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
 	}
 
-	deployment, err := clientset.AppsV1().Deployments(namespace).Get(context.Background(), deploymentName, v1.GetOptions{})
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, v1.GetOptions{})
 	if err != nil {
 		return d, fmt.Errorf("failed to get deployment %s in namespace %s (context %s): %w",
 			deploymentName, namespace, kubeContextName, err)
@@ -99,15 +128,41 @@ func (c *Client) GetDeploymentDetail(kubeContextName, namespace, deploymentName
 	// Render clean YAML the way `kubectl get -o yaml` would, minus noisy managed fields.
 	deployment.ManagedFields = nil
 	deployment.TypeMeta = v1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	c.applyHealth(&d, deployment)
 	if yamlBytes, yamlErr := yaml.Marshal(deployment); yamlErr == nil {
 		d.YAML = string(yamlBytes)
 	} else {
 		d.YAML = fmt.Sprintf("failed to render YAML: %v", yamlErr)
 	}
 
-	if events, err := c.getEvents(kubeContextName, namespace, "Deployment", deploymentName); err == nil {
+	if events, err := c.getEvents(ctx, kubeContextName, namespace, "Deployment", deploymentName); err == nil {
 		d.Events = events
 	}
 
 	return d, nil
 }
+
+// GetDeploymentPods returns a deployment's labels and the pods its selector
+// currently matches.
+func (c *Client) GetDeploymentPods(ctx context.Context, kubeContextName, namespace, deploymentName string) (map[string]string, []corev1.Pod, error) {
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, v1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get deployment %s in namespace %s (context %s): %w",
+			deploymentName, namespace, kubeContextName, err)
+	}
+	selector, err := v1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid selector on deployment %s: %w", deploymentName, err)
+	}
+
+	pods, err := c.ListPods(ctx, kubeContextName, namespace, v1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployment.Labels, pods, nil
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ktails/ktails/internal/health"
 )
 
 // EventInfo is a condensed view of a Kubernetes event relevant to a resource.
@@ -16,6 +20,11 @@ type EventInfo struct {
 	Message string
 	Age     string
 	Count   int32
+
+	// Object ("Pod/api-7d9f8-x2k4q") and Time are set by
+	// GetWorkloadEvents, whose events span several objects.
+	Object string
+	Time   time.Time
 }
 
 // ResourceDetail is a kind-agnostic bundle of everything the Detail tab
@@ -27,13 +36,41 @@ type ResourceDetail struct {
 	Namespace string
 	Age       string
 	Summary   string // e.g. "Ready Replicas: 2" or "Phase: Running  Restarts: 3"
-	Status    []string
-	Events    []EventInfo
-	YAML      string
+	// State is a pod's kubectl-style status (see PodStatus), shown ahead
+	// of the summary; "" for other kinds.
+	State string
+	// Health is set when a config health rule matched the resource (see
+	// Client.SetHealthRules); HealthReason is what the rule saw.
+	Health       string
+	HealthReason string
+	Status       []string
+	Events       []EventInfo
+	YAML         string
+}
+
+// SetHealthRules installs config-defined health rules, applied to every
+// resource detail fetched afterwards.
+func (c *Client) SetHealthRules(rules *health.Evaluator) {
+	c.healthRules = rules
+}
+
+// applyHealth evaluates the health rules against obj, which must have its
+// TypeMeta (apiVersion/kind) filled in — rules match on it.
+func (c *Client) applyHealth(d *ResourceDetail, obj runtime.Object) {
+	if c.healthRules == nil {
+		return
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return
+	}
+	if res, ok := c.healthRules.Evaluate(u); ok {
+		d.Health, d.HealthReason = res.Status, res.Reason
+	}
 }
 
 // getEvents fetches events for a specific object, newest first.
-func (c *Client) getEvents(kubeContextName, namespace, kind, name string) ([]EventInfo, error) {
+func (c *Client) getEvents(ctx context.Context, kubeContextName, namespace, kind, name string) ([]EventInfo, error) {
 	clientset, err := c.GetClientForContext(kubeContextName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
@@ -41,7 +78,7 @@ func (c *Client) getEvents(kubeContextName, namespace, kind, name string) ([]Eve
 
 	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.kind=%s",
 		name, namespace, kind)
-	eventList, err := clientset.CoreV1().Events(namespace).List(context.Background(), v1.ListOptions{FieldSelector: fieldSelector})
+	eventList, err := clientset.CoreV1().Events(namespace).List(ctx, v1.ListOptions{FieldSelector: fieldSelector})
 	if err != nil {
 		return nil, err
 	}
@@ -63,3 +100,42 @@ func (c *Client) getEvents(kubeContextName, namespace, kind, name string) ([]Eve
 
 	return events, nil
 }
+
+// GetWorkloadEvents fetches the events of the object called name and of
+// everything named after it — a Deployment's ReplicaSets and their pods —
+// oldest first. Events are matched by name prefix client-side, since field
+// selectors can't express one.
+func (c *Client) GetWorkloadEvents(ctx context.Context, kubeContextName, namespace, name string) ([]EventInfo, error) {
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	eventList, err := clientset.CoreV1().Events(namespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events in namespace %s (context %s): %w", namespace, kubeContextName, err)
+	}
+
+	var events []EventInfo
+	for _, ev := range eventList.Items {
+		obj := ev.InvolvedObject.Name
+		if obj != name && !strings.HasPrefix(obj, name+"-") {
+			continue
+		}
+		ts := ev.LastTimestamp.Time
+		if ts.IsZero() {
+			ts = ev.EventTime.Time
+		}
+		events = append(events, EventInfo{
+			Type:    ev.Type,
+			Reason:  ev.Reason,
+			Message: ev.Message,
+			Age:     formatDuration(time.Since(ts)),
+			Count:   ev.Count,
+			Object:  ev.InvolvedObject.Kind + "/" + obj,
+			Time:    ts,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events, nil
+}
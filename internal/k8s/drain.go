@@ -0,0 +1,215 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// mirrorPodAnnotation marks a static pod's API mirror; deleting it does
+// nothing, so drain leaves it alone, as kubectl does.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// DrainPod is one pod on a node being drained, and what draining will do
+// to it.
+type DrainPod struct {
+	Namespace string
+	Name      string
+	// Skip says why drain leaves the pod running ("DaemonSet", "mirror
+	// pod"); empty if it will be evicted.
+	Skip string
+	// BlockedBy names the PodDisruptionBudget that allows no more
+	// disruptions by the time this pod's eviction comes up; its eviction
+	// would be refused.
+	BlockedBy string
+	// Unmanaged pods have no controller to replace them once evicted.
+	Unmanaged bool
+	// LocalData pods use emptyDir volumes, lost on eviction.
+	LocalData bool
+}
+
+// DrainPlan previews a drain: every pod on the node, in eviction order.
+type DrainPlan struct {
+	Context string
+	Node    string
+	Pods    []DrainPod
+}
+
+// Blocked reports how many evictions the plan expects a PDB to refuse.
+func (p DrainPlan) Blocked() int {
+	n := 0
+	for _, pod := range p.Pods {
+		if pod.BlockedBy != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// CordonNode marks a node unschedulable (or schedulable again, with
+// cordon false).
+func (c *Client) CordonNode(ctx context.Context, kubeContext, node string, cordon bool) (err error) {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	action := "cordon"
+	if !cordon {
+		action = "uncordon"
+	}
+	defer c.record(c.auditEntry(kubeContext, action, "node", "", node, ""), &err)
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	return cordonNode(ctx, clientset, node, cordon)
+}
+
+func cordonNode(ctx context.Context, clientset kubernetes.Interface, node string, cordon bool) error {
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"unschedulable": cordon},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build cordon patch: %w", err)
+	}
+	if _, err := clientset.CoreV1().Nodes().Patch(ctx, node, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to update node %s: %w", node, err)
+	}
+	return nil
+}
+
+// PlanDrain previews draining a node: which of its pods would be evicted,
+// which skipped, and which evictions its PodDisruptionBudgets would refuse
+// given their currently allowed disruptions.
+func (c *Client) PlanDrain(ctx context.Context, kubeContext, node string) (DrainPlan, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return DrainPlan{}, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	plan, err := planDrain(ctx, clientset, node)
+	if err != nil {
+		return DrainPlan{}, fmt.Errorf("failed to plan drain of node %s (context %s): %w", node, kubeContext, err)
+	}
+	plan.Context = kubeContext
+	return plan, nil
+}
+
+func planDrain(ctx context.Context, clientset kubernetes.Interface, node string) (DrainPlan, error) {
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return DrainPlan{}, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pdbs, err := clientset.PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return DrainPlan{}, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	// Each budget's allowed disruptions are spent in eviction order.
+	allowed := make(map[string]int32, len(pdbs.Items))
+	for _, pdb := range pdbs.Items {
+		allowed[pdb.Namespace+"/"+pdb.Name] = pdb.Status.DisruptionsAllowed
+	}
+
+	items := pods.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	plan := DrainPlan{Node: node}
+	for i := range items {
+		p := &items[i]
+		dp := DrainPod{Namespace: p.Namespace, Name: p.Name}
+		owner := metav1.GetControllerOf(p)
+		switch {
+		case p.Annotations[mirrorPodAnnotation] != "":
+			dp.Skip = "mirror pod"
+		case owner != nil && owner.Kind == "DaemonSet":
+			dp.Skip = "DaemonSet"
+		case p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed:
+			// Finished pods don't count against a budget.
+		default:
+			for _, pdb := range pdbs.Items {
+				if pdb.Namespace != p.Namespace || !pdbSelects(&pdb, p) {
+					continue
+				}
+				key := pdb.Namespace + "/" + pdb.Name
+				if allowed[key] <= 0 {
+					dp.BlockedBy = pdb.Name
+					break
+				}
+				allowed[key]--
+			}
+		}
+		dp.Unmanaged = owner == nil
+		for _, v := range p.Spec.Volumes {
+			if v.EmptyDir != nil {
+				dp.LocalData = true
+				break
+			}
+		}
+		plan.Pods = append(plan.Pods, dp)
+	}
+	return plan, nil
+}
+
+// pdbSelects reports whether a PodDisruptionBudget's selector matches a pod.
+// A budget with an invalid or empty selector matches nothing.
+func pdbSelects(pdb *policyv1.PodDisruptionBudget, p *corev1.Pod) bool {
+	if pdb.Spec.Selector == nil {
+		return false
+	}
+	sel, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil || sel.Empty() {
+		return false
+	}
+	return sel.Matches(labels.Set(p.Labels))
+}
+
+// DrainNode cordons the plan's node and evicts every pod the plan doesn't
+// skip, PDB-blocked ones included: a budget may have freed up since the
+// preview, and the API server enforces it either way. Evictions continue
+// past failures; the error joins every one.
+func (c *Client) DrainNode(ctx context.Context, plan DrainPlan) (err error) {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	evictions := 0
+	for _, p := range plan.Pods {
+		if p.Skip == "" {
+			evictions++
+		}
+	}
+	defer c.record(c.auditEntry(plan.Context, "drain", "node", "", plan.Node, fmt.Sprintf("%d evictions", evictions)), &err)
+	clientset, err := c.GetClientForContext(plan.Context)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", plan.Context, err)
+	}
+	if err := cordonNode(ctx, clientset, plan.Node, true); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range plan.Pods {
+		if p.Skip != "" {
+			continue
+		}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: p.Namespace}}
+		if err := clientset.CoreV1().Pods(p.Namespace).EvictV1(ctx, eviction); err != nil {
+			errs = append(errs, fmt.Errorf("failed to evict pod %s/%s: %w", p.Namespace, p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
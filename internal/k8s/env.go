@@ -0,0 +1,251 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maskedValue stands in for every secret-sourced value. Secret values are
+// never fetched for single-key refs, and never kept for envFrom refs —
+// only which keys a secret contributes (see envResolver.secretKeys).
+const maskedValue = "••••••"
+
+// EnvVar is one resolved environment variable. Source describes where the
+// value came from ("" for a literal in the pod spec, otherwise e.g.
+// "configmap app-config:LOG_LEVEL" or "fieldRef status.podIP"); Masked is
+// set when Value is a placeholder rather than the real value.
+type EnvVar struct {
+	Name   string
+	Value  string
+	Source string
+	Masked bool
+}
+
+// ContainerEnv is the resolved environment of one container, in the order
+// the kubelet applies it: envFrom sources first, then env entries (which
+// override envFrom on name clashes).
+type ContainerEnv struct {
+	Container string
+	Init      bool
+	Vars      []EnvVar
+}
+
+// GetPodEnv resolves the environment every container of a pod actually gets,
+// following valueFrom references: configmap keys are read, fieldRef and
+// resourceFieldRef are resolved against the pod itself, and secret values
+// are masked. A reference that can't be resolved (missing configmap, no
+// permission) is reported inline in the var's value rather than failing the
+// whole pod.
+func (c *Client) GetPodEnv(ctx context.Context, kubeContext, namespace, podName string) ([]ContainerEnv, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
+	}
+
+	r := &envResolver{ctx: ctx, clientset: clientset, pod: pod, configMaps: make(map[string]*corev1.ConfigMap), secrets: make(map[string][]string), errs: make(map[string]error)}
+
+	var out []ContainerEnv
+	for _, ctr := range pod.Spec.InitContainers {
+		out = append(out, ContainerEnv{Container: ctr.Name, Init: true, Vars: r.resolve(ctr)})
+	}
+	for _, ctr := range pod.Spec.Containers {
+		out = append(out, ContainerEnv{Container: ctr.Name, Vars: r.resolve(ctr)})
+	}
+	return out, nil
+}
+
+// envResolver caches configmap and secret lookups across a pod's
+// containers, which commonly share the same ones. Of a secret, only its
+// keys are cached.
+type envResolver struct {
+	ctx        context.Context
+	clientset  kubernetes.Interface
+	pod        *corev1.Pod
+	configMaps map[string]*corev1.ConfigMap
+	secrets    map[string][]string
+	errs       map[string]error // by "configmap <name>" or "secret <name>"
+}
+
+func (r *envResolver) configMap(name string) (*corev1.ConfigMap, error) {
+	if cm, ok := r.configMaps[name]; ok {
+		return cm, nil
+	}
+	if err, ok := r.errs["configmap "+name]; ok {
+		return nil, err
+	}
+	cm, err := r.clientset.CoreV1().ConfigMaps(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.errs["configmap "+name] = err
+		return nil, err
+	}
+	r.configMaps[name] = cm
+	return cm, nil
+}
+
+// secretKeys lists the keys of an envFrom secret. The API has no way to
+// read a secret's keys without its values (a metadata-only read leaves out
+// Data), so the values are fetched along with them — and wiped as soon as
+// the keys are read off, before anything else sees the secret.
+func (r *envResolver) secretKeys(name string) ([]string, error) {
+	if keys, ok := r.secrets[name]; ok {
+		return keys, nil
+	}
+	if err, ok := r.errs["secret "+name]; ok {
+		return nil, err
+	}
+	secret, err := r.clientset.CoreV1().Secrets(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.errs["secret "+name] = err
+		return nil, err
+	}
+	keys := make([]string, 0, len(secret.Data))
+	for k, v := range secret.Data {
+		keys = append(keys, k)
+		clear(v)
+	}
+	secret.Data, secret.StringData = nil, nil
+	sort.Strings(keys)
+	r.secrets[name] = keys
+	return keys, nil
+}
+
+func (r *envResolver) resolve(ctr corev1.Container) []EnvVar {
+	var vars []EnvVar
+
+	for _, from := range ctr.EnvFrom {
+		switch {
+		case from.ConfigMapRef != nil:
+			src := fmt.Sprintf("configmap %s (envFrom)", from.ConfigMapRef.Name)
+			cm, err := r.configMap(from.ConfigMapRef.Name)
+			if err != nil {
+				vars = append(vars, EnvVar{Name: from.Prefix + "*", Value: fmt.Sprintf("<unresolved: %v>", err), Source: src})
+				continue
+			}
+			for _, k := range sortedKeys(cm.Data) {
+				vars = append(vars, EnvVar{Name: from.Prefix + k, Value: cm.Data[k], Source: src})
+			}
+		case from.SecretRef != nil:
+			src := fmt.Sprintf("secret %s (envFrom)", from.SecretRef.Name)
+			keys, err := r.secretKeys(from.SecretRef.Name)
+			if err != nil {
+				vars = append(vars, EnvVar{Name: from.Prefix + "*", Value: maskedValue, Source: src + ", keys unavailable", Masked: true})
+				continue
+			}
+			for _, k := range keys {
+				vars = append(vars, EnvVar{Name: from.Prefix + k, Value: maskedValue, Source: src, Masked: true})
+			}
+		}
+	}
+
+	for _, env := range ctr.Env {
+		vars = append(vars, r.resolveVar(ctr, env))
+	}
+	return vars
+}
+
+func (r *envResolver) resolveVar(ctr corev1.Container, env corev1.EnvVar) EnvVar {
+	v := EnvVar{Name: env.Name, Value: env.Value}
+	from := env.ValueFrom
+	if from == nil {
+		return v
+	}
+
+	switch {
+	case from.ConfigMapKeyRef != nil:
+		ref := from.ConfigMapKeyRef
+		v.Source = fmt.Sprintf("configmap %s:%s", ref.Name, ref.Key)
+		cm, err := r.configMap(ref.Name)
+		if err != nil {
+			v.Value = fmt.Sprintf("<unresolved: %v>", err)
+		} else if val, ok := cm.Data[ref.Key]; ok {
+			v.Value = val
+		} else {
+			v.Value = "<missing key>"
+		}
+	case from.SecretKeyRef != nil:
+		v.Source = fmt.Sprintf("secret %s:%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+		v.Value = maskedValue
+		v.Masked = true
+	case from.FieldRef != nil:
+		v.Source = "fieldRef " + from.FieldRef.FieldPath
+		v.Value = podFieldValue(r.pod, from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		v.Source = "resourceFieldRef " + from.ResourceFieldRef.Resource
+		v.Value = containerResourceValue(ctr, from.ResourceFieldRef.Resource)
+	}
+	return v
+}
+
+// podFieldValue resolves the downward API field paths the kubelet supports
+// for env vars.
+func podFieldValue(pod *corev1.Pod, path string) string {
+	if key, ok := strings.CutPrefix(path, "metadata.labels['"); ok {
+		return pod.Labels[strings.TrimSuffix(key, "']")]
+	}
+	if key, ok := strings.CutPrefix(path, "metadata.annotations['"); ok {
+		return pod.Annotations[strings.TrimSuffix(key, "']")]
+	}
+	switch path {
+	case "metadata.name":
+		return pod.Name
+	case "metadata.namespace":
+		return pod.Namespace
+	case "metadata.uid":
+		return string(pod.UID)
+	case "spec.nodeName":
+		return pod.Spec.NodeName
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName
+	case "status.hostIP":
+		return pod.Status.HostIP
+	case "status.podIP":
+		return pod.Status.PodIP
+	case "status.podIPs":
+		ips := make([]string, 0, len(pod.Status.PodIPs))
+		for _, ip := range pod.Status.PodIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ",")
+	}
+	return "<unsupported field>"
+}
+
+// containerResourceValue resolves a resourceFieldRef ("limits.cpu",
+// "requests.memory", ...) against the container's own resources. An unset
+// limit shows as such rather than the node-allocatable value the kubelet
+// would substitute, which isn't knowable from the pod alone.
+func containerResourceValue(ctr corev1.Container, resource string) string {
+	kind, name, ok := strings.Cut(resource, ".")
+	if !ok {
+		return "<unsupported resource>"
+	}
+	list := ctr.Resources.Requests
+	if kind == "limits" {
+		list = ctr.Resources.Limits
+	}
+	q, ok := list[corev1.ResourceName(name)]
+	if !ok {
+		return fmt.Sprintf("<%s unset>", resource)
+	}
+	return q.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// ExecOptions describes one command run inside a container. Stdin may be
+// nil; Stdout/Stderr may be nil to discard. TTY allocates a terminal on the
+// remote side (stderr is then merged into stdout, as with kubectl exec -t).
+type ExecOptions struct {
+	Container string
+	Command   []string
+	Stdin     io.Reader
+	Stdout    io.Writer
+	Stderr    io.Writer
+	TTY       bool
+
+	// TerminalSizes, when TTY is set, feeds remote terminal resizes.
+	TerminalSizes remotecommand.TerminalSizeQueue
+}
+
+// Exec runs a command inside a pod's container, streaming its I/O until it
+// exits or ctx is cancelled. Like kubectl, it prefers the websocket exec
+// protocol and falls back to SPDY when the server can't upgrade to it.
+func (c *Client) Exec(ctx context.Context, kubeContext, namespace, podName string, opts ExecOptions) error {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	restConfig, err := c.restConfigForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			Stderr:    opts.Stderr != nil && !opts.TTY,
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec)
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create exec for pod %s in context %s: %w", podName, kubeContext, err)
+	}
+	wsExec, err := remotecommand.NewWebSocketExecutor(restConfig, "GET", req.URL().String())
+	if err != nil {
+		return fmt.Errorf("failed to create exec for pod %s in context %s: %w", podName, kubeContext, err)
+	}
+	exec, err := remotecommand.NewFallbackExecutor(wsExec, spdyExec, httpstream.IsUpgradeFailure)
+	if err != nil {
+		return fmt.Errorf("failed to create exec for pod %s in context %s: %w", podName, kubeContext, err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Stderr:            opts.Stderr,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.TerminalSizes,
+	}
+	if opts.TTY {
+		streamOpts.Stderr = nil
+	}
+	if err := exec.StreamWithContext(ctx, streamOpts); err != nil {
+		return fmt.Errorf("exec %v in pod %s/%s failed: %w", opts.Command, namespace, podName, err)
+	}
+	return nil
+}
+
+// defaultShell is what ExecInPod runs when given no command: bash if the
+// image has it, sh otherwise.
+var defaultShell = []string{"sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+
+// ExecInPod attaches an interactive terminal session to a container: command
+// (a shell if empty) runs on a remote TTY wired to stdin/stdout, which the
+// caller is expected to have put in raw mode. sizes feeds the local
+// terminal's size and its later changes; it may be nil. The session's exit
+// status is the last command's, so a non-zero one isn't reported as an error.
+// The session is audited (see SetAuditLog) as of when it opened.
+func (c *Client) ExecInPod(ctx context.Context, kubeContext, namespace, podName, container string, command []string, stdin io.Reader, stdout io.Writer, sizes remotecommand.TerminalSizeQueue) (err error) {
+	detail := strings.Join(command, " ")
+	if len(command) == 0 {
+		command, detail = defaultShell, "shell"
+	}
+	if container != "" {
+		detail += " in " + container
+	}
+	if !c.ReadOnly() {
+		defer c.record(c.auditEntry(kubeContext, "exec", "pod", namespace, podName, detail), &err)
+	}
+	err = c.Exec(ctx, kubeContext, namespace, podName, ExecOptions{
+		Container:     container,
+		Command:       command,
+		Stdin:         stdin,
+		Stdout:        stdout,
+		TTY:           true,
+		TerminalSizes: sizes,
+	})
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,161 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxFileReadBytes caps how much of a remote file ReadPodFile returns, so
+// opening a multi-gigabyte log by accident doesn't drag it all over the
+// exec stream into memory.
+const maxFileReadBytes = 1 << 20
+
+// fileExecTimeout bounds a single ls/cat/tail exec.
+const fileExecTimeout = 20 * time.Second
+
+// FileEntry is one row of a remote directory listing.
+type FileEntry struct {
+	Name       string
+	Mode       string // e.g. "drwxr-xr-x"
+	Size       int64
+	Modified   string // as ls printed it, e.g. "Jul 19 12:00"
+	LinkTarget string // for symlinks
+}
+
+// IsDir reports whether the entry is a directory (symlinks aren't followed —
+// a link to a directory is opened as a directory by path anyway).
+func (f FileEntry) IsDir() bool {
+	return strings.HasPrefix(f.Mode, "d")
+}
+
+// IsLink reports whether the entry is a symlink.
+func (f FileEntry) IsLink() bool {
+	return strings.HasPrefix(f.Mode, "l")
+}
+
+// ListPodDir runs `ls -la` in the container and parses its output. It needs
+// an ls binary in the image (coreutils or busybox) — distroless images
+// don't have one, which surfaces as the exec's error.
+func (c *Client) ListPodDir(ctx context.Context, kubeContext, namespace, podName, container, dir string) ([]FileEntry, error) {
+	out, err := c.execOutput(ctx, kubeContext, namespace, podName, container, []string{"ls", "-la", "--", dir}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseLsOutput(out), nil
+}
+
+// ReadPodFile returns a file's contents via `cat`, or its last tailLines
+// lines via `tail -n` when tailLines > 0. Output beyond maxFileReadBytes is
+// dropped and truncated is set.
+func (c *Client) ReadPodFile(ctx context.Context, kubeContext, namespace, podName, container, file string, tailLines int) (content string, truncated bool, err error) {
+	cmd := []string{"cat", "--", file}
+	if tailLines > 0 {
+		cmd = []string{"tail", "-n", strconv.Itoa(tailLines), "--", file}
+	}
+	out, err := c.execOutput(ctx, kubeContext, namespace, podName, container, cmd, maxFileReadBytes)
+	if errors.Is(err, errOutputLimit) {
+		return out, true, nil
+	}
+	return out, false, err
+}
+
+// errOutputLimit aborts an exec whose stdout outgrew its cap.
+var errOutputLimit = errors.New("output limit reached")
+
+// limitedBuffer is a bytes.Buffer that refuses writes past max bytes (0 =
+// unlimited), which makes the exec stream copy fail and the exec end early.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.max > 0 && b.Len()+len(p) > b.max {
+		n, _ := b.Buffer.Write(p[:b.max-b.Len()])
+		return n, errOutputLimit
+	}
+	return b.Buffer.Write(p)
+}
+
+// execOutput runs a non-interactive command and returns its stdout. A
+// non-zero exit is reported with the command's stderr, which is where
+// ls/cat explain themselves ("No such file or directory").
+func (c *Client) execOutput(ctx context.Context, kubeContext, namespace, podName, container string, command []string, maxBytes int) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, fileExecTimeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{max: maxBytes}
+	var stderr bytes.Buffer
+	err := c.Exec(ctx, kubeContext, namespace, podName, ExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    stdout,
+		Stderr:    &stderr,
+	})
+	if maxBytes > 0 && stdout.Len() >= maxBytes {
+		return stdout.String(), errOutputLimit
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", strings.Join(command, " "), msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// parseLsOutput parses `ls -la` lines in both the GNU coreutils and busybox
+// layouts: mode, links, owner, group, size, three date fields, name. The
+// "total" header, "." and unparseable or truncated lines are skipped; ".." is kept so the
+// listing always offers a way up.
+func parseLsOutput(out string) []FileEntry {
+	var entries []FileEntry
+lines:
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 9 || len(fields[0]) < 10 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			// Device files print "major, minor" in place of a size.
+			continue
+		}
+
+		// The name is everything after the 8th field, spaces included. A
+		// line whose fields aren't separated by spaces isn't ls's.
+		rest := line
+		for i := 0; i < 8; i++ {
+			rest = strings.TrimLeft(rest, " ")
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				continue lines
+			}
+			rest = rest[end:]
+		}
+		name := strings.TrimLeft(rest, " ")
+
+		e := FileEntry{
+			Mode:     fields[0],
+			Size:     size,
+			Modified: strings.Join(fields[5:8], " "),
+		}
+		if e.IsLink() {
+			if n, target, ok := strings.Cut(name, " -> "); ok {
+				name, e.LinkTarget = n, target
+			}
+		}
+		if name == "." {
+			continue
+		}
+		e.Name = path.Base(name)
+		entries = append(entries, e)
+	}
+	return entries
+}
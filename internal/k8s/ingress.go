@@ -0,0 +1,236 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressRoute is one path an Ingress routes, the ingress tab's row: an
+// Ingress with three hosts of two paths each is six routes.
+type IngressRoute struct {
+	Ingress   string
+	Namespace string
+	Context   string
+	Class     string
+	// Host is "*" for a rule matching any host.
+	Host string
+	// Path is "" for the Ingress's default backend.
+	Path    string
+	Backend string // "service:port", or "Kind/name" for a resource backend
+	// TLSSecret is the secret terminating TLS for Host, "" if it's served
+	// over plain HTTP.
+	TLSSecret string
+	Address   string // the load balancer's hostnames/IPs, once assigned
+	Age       string
+}
+
+// ListIngressRoutes lists the routes of every Ingress in a namespace (""
+// for all), sorted by namespace and Ingress, each Ingress's in its order.
+func (c *Client) ListIngressRoutes(ctx context.Context, kubeContext, namespace string) ([]IngressRoute, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	if err := c.checkAccess(ctx, kubeContext, namespace, ListIngresses); err != nil {
+		return nil, err
+	}
+	list, err := withRetry(ctx, c, kubeContext, func() (*networkingv1.IngressList, error) {
+		return clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses in namespace %s (context %s): %w", namespace, kubeContext, err)
+	}
+
+	var routes []IngressRoute
+	for i := range list.Items {
+		routes = append(routes, IngressToRoutes(&list.Items[i], kubeContext)...)
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i], routes[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Ingress < b.Ingress
+	})
+	return routes, nil
+}
+
+// IngressToRoutes flattens an Ingress into its routes: the default backend
+// first, if any, then each rule's paths in order.
+func IngressToRoutes(ing *networkingv1.Ingress, kubeContext string) []IngressRoute {
+	base := IngressRoute{
+		Ingress:   ing.Name,
+		Namespace: ing.Namespace,
+		Context:   kubeContext,
+		Address:   formatIngressAddress(ing.Status.LoadBalancer.Ingress),
+		Age:       formatDuration(time.Since(ing.CreationTimestamp.Time)),
+	}
+	if ing.Spec.IngressClassName != nil {
+		base.Class = *ing.Spec.IngressClassName
+	}
+
+	var routes []IngressRoute
+	if ing.Spec.DefaultBackend != nil {
+		r := base
+		r.Host = "*"
+		r.Backend = formatIngressBackend(*ing.Spec.DefaultBackend)
+		routes = append(routes, r)
+	}
+	for _, rule := range ing.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			r := base
+			r.Host = host
+			r.Path = path.Path
+			if r.Path == "" {
+				r.Path = "/"
+			}
+			r.Backend = formatIngressBackend(path.Backend)
+			r.TLSSecret = tlsSecretFor(ing.Spec.TLS, rule.Host)
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+// tlsSecretFor returns the secret of the TLS entry covering host — listing
+// it, or a wildcard matching it — "" if none does. A TLS entry without
+// hosts covers the rules without one.
+func tlsSecretFor(tls []networkingv1.IngressTLS, host string) string {
+	for _, t := range tls {
+		if len(t.Hosts) == 0 && host == "" {
+			return tlsSecretName(t)
+		}
+		for _, h := range t.Hosts {
+			if h == host {
+				return tlsSecretName(t)
+			}
+			if suffix, ok := strings.CutPrefix(h, "*."); ok && host != "" {
+				if _, rest, found := strings.Cut(host, "."); found && rest == suffix {
+					return tlsSecretName(t)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// tlsSecretName names a TLS entry's secret; an entry without one relies on
+// the controller's default certificate.
+func tlsSecretName(t networkingv1.IngressTLS) string {
+	if t.SecretName == "" {
+		return "(default)"
+	}
+	return t.SecretName
+}
+
+func formatIngressBackend(b networkingv1.IngressBackend) string {
+	switch {
+	case b.Service != nil:
+		port := b.Service.Port.Name
+		if port == "" {
+			port = strconv.Itoa(int(b.Service.Port.Number))
+		}
+		return b.Service.Name + ":" + port
+	case b.Resource != nil:
+		return b.Resource.Kind + "/" + b.Resource.Name
+	}
+	return ""
+}
+
+func formatIngressAddress(ingress []networkingv1.IngressLoadBalancerIngress) string {
+	addrs := make([]string, 0, len(ingress))
+	for _, in := range ingress {
+		if in.Hostname != "" {
+			addrs = append(addrs, in.Hostname)
+		} else if in.IP != "" {
+			addrs = append(addrs, in.IP)
+		}
+	}
+	return strings.Join(addrs, ",")
+}
+
+// ServiceBackend is one endpoint behind a Service: usually a pod.
+type ServiceBackend struct {
+	Address string
+	// Pod is the endpoint's pod, "" for one that isn't (e.g. an address
+	// added by hand).
+	Pod         string
+	Node        string
+	Ready       bool
+	Terminating bool
+	Ports       []string // e.g. "http 8080/TCP"
+}
+
+// GetServiceBackends lists what's behind a Service from its
+// EndpointSlices: each endpoint's address, the pod it belongs to, and
+// whether it's ready to take traffic. Sorted by pod, then address.
+func (c *Client) GetServiceBackends(ctx context.Context, kubeContext, namespace, service string) ([]ServiceBackend, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	sliceList, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoint slices of service %s in namespace %s (context %s): %w", service, namespace, kubeContext, err)
+	}
+
+	var backends []ServiceBackend
+	for _, slice := range sliceList.Items {
+		var ports []string
+		for _, p := range slice.Ports {
+			port := ""
+			if p.Port != nil {
+				port = strconv.Itoa(int(*p.Port))
+			}
+			if p.Protocol != nil {
+				port += "/" + string(*p.Protocol)
+			}
+			if p.Name != nil && *p.Name != "" {
+				port = *p.Name + " " + port
+			}
+			ports = append(ports, port)
+		}
+		for _, ep := range slice.Endpoints {
+			b := ServiceBackend{
+				Address: strings.Join(ep.Addresses, ","),
+				// A nil Ready means ready, per the EndpointSlice API.
+				Ready:       ep.Conditions.Ready == nil || *ep.Conditions.Ready,
+				Terminating: ep.Conditions.Terminating != nil && *ep.Conditions.Terminating,
+				Ports:       ports,
+			}
+			if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" {
+				b.Pod = ep.TargetRef.Name
+			}
+			if ep.NodeName != nil {
+				b.Node = *ep.NodeName
+			}
+			backends = append(backends, b)
+		}
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		if backends[i].Pod != backends[j].Pod {
+			return backends[i].Pod < backends[j].Pod
+		}
+		return backends[i].Address < backends[j].Address
+	})
+	return backends, nil
+}
@@ -0,0 +1,191 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Kinds of kubeconfig entry a ContextConflict can be about.
+const (
+	ConflictContext = "context"
+	ConflictCluster = "cluster"
+	ConflictUser    = "user"
+)
+
+// ContextConflict records a kubeconfig entry name defined differently by
+// more than one file. kubectl lets the first file win and silently drops the
+// rest; ktails keeps the first under its own name too, but loads each later
+// definition under Renamed instead of shadowing it.
+type ContextConflict struct {
+	Kind    string // ConflictContext, ConflictCluster or ConflictUser
+	Name    string
+	Origin  string // file that kept Name
+	File    string // file whose definition was renamed
+	Renamed string
+}
+
+// kubeconfigPaths splits a KUBECONFIG-style list ("a:b" on Unix) into its
+// files, dropping empty entries and repeats.
+func kubeconfigPaths(list string) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, p := range filepath.SplitList(list) {
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// loadKubeconfigs merges paths in order, like clientcmd's loading rules, but
+// renames clashing entries by file of origin rather than shadowing them.
+// Missing files are skipped as kubectl does; it's an error if none exist.
+func loadKubeconfigs(paths []string) (*api.Config, []ContextConflict, error) {
+	merged := api.NewConfig()
+	var conflicts []ContextConflict
+	contextOrigin := make(map[string]string)
+	clusterOrigin := make(map[string]string)
+	userOrigin := make(map[string]string)
+	loaded := 0
+
+	for _, path := range paths {
+		cfg, err := clientcmd.LoadFromFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+		}
+		if err := clientcmd.ResolveLocalPaths(cfg); err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve paths in kubeconfig %s: %w", path, err)
+		}
+		loaded++
+		if merged.CurrentContext == "" {
+			merged.CurrentContext = cfg.CurrentContext
+		}
+
+		// Clusters and users first: a renamed one must be followed by the
+		// contexts of this file that reference it.
+		clusterNames := make(map[string]string)
+		for _, name := range slices.Sorted(maps.Keys(cfg.Clusters)) {
+			cluster := cfg.Clusters[name]
+			prev, exists := merged.Clusters[name]
+			switch {
+			case !exists:
+				merged.Clusters[name] = cluster
+				clusterOrigin[name] = path
+			case !sameCluster(prev, cluster):
+				renamed := conflictName(name, path, merged.Clusters)
+				merged.Clusters[renamed] = cluster
+				clusterNames[name] = renamed
+				conflicts = append(conflicts, ContextConflict{Kind: ConflictCluster, Name: name, Origin: clusterOrigin[name], File: path, Renamed: renamed})
+			}
+		}
+		userNames := make(map[string]string)
+		for _, name := range slices.Sorted(maps.Keys(cfg.AuthInfos)) {
+			user := cfg.AuthInfos[name]
+			prev, exists := merged.AuthInfos[name]
+			switch {
+			case !exists:
+				merged.AuthInfos[name] = user
+				userOrigin[name] = path
+			case !sameAuthInfo(prev, user):
+				renamed := conflictName(name, path, merged.AuthInfos)
+				merged.AuthInfos[renamed] = user
+				userNames[name] = renamed
+				conflicts = append(conflicts, ContextConflict{Kind: ConflictUser, Name: name, Origin: userOrigin[name], File: path, Renamed: renamed})
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(cfg.Contexts)) {
+			ctx := cfg.Contexts[name]
+			if renamed, ok := clusterNames[ctx.Cluster]; ok {
+				ctx.Cluster = renamed
+			}
+			if renamed, ok := userNames[ctx.AuthInfo]; ok {
+				ctx.AuthInfo = renamed
+			}
+			prev, exists := merged.Contexts[name]
+			switch {
+			case !exists:
+				merged.Contexts[name] = ctx
+				contextOrigin[name] = path
+			case !sameContext(prev, ctx):
+				renamed := conflictName(name, path, merged.Contexts)
+				merged.Contexts[renamed] = ctx
+				conflicts = append(conflicts, ContextConflict{Kind: ConflictContext, Name: name, Origin: contextOrigin[name], File: path, Renamed: renamed})
+			}
+		}
+	}
+
+	if loaded == 0 {
+		return nil, nil, fmt.Errorf("no kubeconfig found at %s: %w", strings.Join(paths, string(filepath.ListSeparator)), fs.ErrNotExist)
+	}
+	return merged, conflicts, nil
+}
+
+// conflictName suffixes name with the base name of the file it came from —
+// "prod (config-eu)" — adding a counter if even that is taken.
+func conflictName[V any](name, path string, taken map[string]V) string {
+	candidate := fmt.Sprintf("%s (%s)", name, filepath.Base(path))
+	for i := 2; ; i++ {
+		if _, exists := taken[candidate]; !exists {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s (%s #%d)", name, filepath.Base(path), i)
+	}
+}
+
+// sameCluster, sameAuthInfo and sameContext compare entries ignoring
+// LocationOfOrigin, so the same entry copied into two files isn't a conflict.
+func sameCluster(a, b *api.Cluster) bool {
+	x, y := *a, *b
+	x.LocationOfOrigin, y.LocationOfOrigin = "", ""
+	return reflect.DeepEqual(x, y)
+}
+
+func sameAuthInfo(a, b *api.AuthInfo) bool {
+	x, y := *a, *b
+	x.LocationOfOrigin, y.LocationOfOrigin = "", ""
+	return reflect.DeepEqual(x, y)
+}
+
+func sameContext(a, b *api.Context) bool {
+	x, y := *a, *b
+	x.LocationOfOrigin, y.LocationOfOrigin = "", ""
+	return reflect.DeepEqual(x, y)
+}
+
+// KubeconfigFiles returns the kubeconfig files the client merged, in load
+// order (missing ones included).
+func (c *Client) KubeconfigFiles() []string {
+	return c.kubeconfigPaths
+}
+
+// ContextConflicts returns the entries renamed while merging kubeconfig
+// files, in load order. Empty for a single file.
+func (c *Client) ContextConflicts() []ContextConflict {
+	return c.conflicts
+}
+
+// isRenamed reports whether name is the renamed copy of a conflicting entry
+// of the given kind.
+func (c *Client) isRenamed(kind, name string) bool {
+	for _, cf := range c.conflicts {
+		if cf.Kind == kind && cf.Renamed == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// kubeconfigSettle is how long a kubeconfig file must go unwritten before a
+// change is reported, so an editor's or kubectl's write-rename burst reads
+// as one change (and never as a half-written file).
+const kubeconfigSettle = 250 * time.Millisecond
+
+// KubeconfigChange is what a kubeconfig reload changed, by context name.
+// Changed contexts kept their name but now point at a different cluster or
+// credentials (a rotated token, say); their cached clients are dropped.
+type KubeconfigChange struct {
+	Added, Removed, Changed []string
+}
+
+// Empty reports whether the reload changed nothing.
+func (ch KubeconfigChange) Empty() bool {
+	return len(ch.Added) == 0 && len(ch.Removed) == 0 && len(ch.Changed) == 0
+}
+
+// ReloadKubeconfig re-reads and re-merges the client's kubeconfig files,
+// dropping the cached clients of every removed or changed context so their
+// next request connects with the new settings. A current context that's
+// gone falls back to the files' own.
+func (c *Client) ReloadKubeconfig() (KubeconfigChange, error) {
+	rawConfig, conflicts, err := loadKubeconfigs(c.kubeconfigPaths)
+	if err != nil {
+		return KubeconfigChange{}, fmt.Errorf("failed to reload kubeconfig: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var change KubeconfigChange
+	for _, name := range slices.Sorted(maps.Keys(rawConfig.Contexts)) {
+		if _, exists := c.rawConfig.Contexts[name]; !exists {
+			change.Added = append(change.Added, name)
+		} else if contextChanged(c.rawConfig, rawConfig, name) {
+			change.Changed = append(change.Changed, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.rawConfig.Contexts)) {
+		if _, exists := rawConfig.Contexts[name]; !exists {
+			change.Removed = append(change.Removed, name)
+		}
+	}
+	for _, name := range slices.Concat(change.Removed, change.Changed) {
+		delete(c.clientsByContext, name)
+		delete(c.restConfigsByContext, name)
+		delete(c.dynamicByContext, name)
+	}
+
+	c.rawConfig = rawConfig
+	c.conflicts = conflicts
+	if _, exists := rawConfig.Contexts[c.currentContext]; !exists {
+		c.currentContext = rawConfig.CurrentContext
+	}
+	return change, nil
+}
+
+// SwitchKubeconfig points the client at another set of kubeconfig files —
+// a profile's — as though it had been created from them: every cached
+// client and access answer is dropped and the current context becomes the
+// files' own. The client keeps its telemetry, limits and health rules, so
+// whatever holds it carries on with the new contexts. On error nothing
+// changes.
+func (c *Client) SwitchKubeconfig(paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no kubeconfig paths given")
+	}
+	rawConfig, conflicts, err := loadKubeconfigs(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if rawConfig.CurrentContext == "" {
+		return fmt.Errorf("no current context set in kubeconfig")
+	}
+
+	c.mu.Lock()
+	c.rawConfig = rawConfig
+	c.kubeconfigPaths = slices.Clone(paths)
+	c.conflicts = conflicts
+	c.currentContext = rawConfig.CurrentContext
+	c.clientsByContext = make(map[string]kubernetes.Interface)
+	c.restConfigsByContext = make(map[string]*rest.Config)
+	c.dynamicByContext = nil
+	c.mu.Unlock()
+
+	// A context of the same name in the new files may well be another
+	// cluster, or another user.
+	if c.access != nil {
+		c.access.mu.Lock()
+		c.access.answers = make(map[accessKey]accessAnswer)
+		c.access.mu.Unlock()
+	}
+	return nil
+}
+
+// contextChanged reports whether name, present in both configs, resolves
+// to a different context, cluster or user in next.
+func contextChanged(prev, next *api.Config, name string) bool {
+	a, b := prev.Contexts[name], next.Contexts[name]
+	if !sameContext(a, b) {
+		return true
+	}
+	ca, cb := prev.Clusters[a.Cluster], next.Clusters[b.Cluster]
+	if (ca == nil) != (cb == nil) || (ca != nil && !sameCluster(ca, cb)) {
+		return true
+	}
+	ua, ub := prev.AuthInfos[a.AuthInfo], next.AuthInfos[b.AuthInfo]
+	return (ua == nil) != (ub == nil) || (ua != nil && !sameAuthInfo(ua, ub))
+}
+
+// KubeconfigWatcher reports writes to a client's kubeconfig files. It
+// watches their directories rather than the files themselves: editors and
+// kubectl replace a file by renaming a new one over it, which a watch on
+// the old file would never see.
+type KubeconfigWatcher struct {
+	watcher *fsnotify.Watcher
+	files   map[string]bool
+}
+
+// WatchKubeconfig starts watching the client's kubeconfig files. Files
+// whose directory doesn't exist are skipped.
+func (c *Client) WatchKubeconfig() (*KubeconfigWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch kubeconfig: %w", err)
+	}
+	w := &KubeconfigWatcher{watcher: watcher, files: make(map[string]bool)}
+	dirs := make(map[string]bool)
+	for _, path := range c.kubeconfigPaths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		w.files[abs] = true
+		dir := filepath.Dir(abs)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		if err := watcher.Add(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			watcher.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+	return w, nil
+}
+
+// Wait blocks until one of the files has changed and then gone
+// kubeconfigSettle without another write. It returns fs.ErrClosed once
+// the watcher is closed.
+func (w *KubeconfigWatcher) Wait() error {
+	var settle <-chan time.Time
+	for {
+		select {
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return fs.ErrClosed
+			}
+			if w.files[filepath.Clean(ev.Name)] && !ev.Has(fsnotify.Chmod) {
+				settle = time.After(kubeconfigSettle)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return fs.ErrClosed
+			}
+			return fmt.Errorf("kubeconfig watch failed: %w", err)
+		case <-settle:
+			return nil
+		}
+	}
+}
+
+// Close stops the watch, ending a pending Wait.
+func (w *KubeconfigWatcher) Close() error {
+	return w.watcher.Close()
+}
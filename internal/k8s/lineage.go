@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"maps"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// perPodLabels are labels a controller sets differently on every pod (or
+// every revision), so they're left out when matching a replacement.
+var perPodLabels = []string{
+	"pod-template-hash",
+	"controller-revision-hash",
+	"pod-template-generation",
+	"statefulset.kubernetes.io/pod-name",
+	"apps.kubernetes.io/pod-index",
+}
+
+// PodLineage is what identifies the pods a controller creates in one
+// pod's place: the controller (a Deployment rather than its ReplicaSet,
+// which a rollout replaces) and the labels its template sets.
+type PodLineage struct {
+	UID        types.UID
+	Namespace  string
+	Name       string
+	Controller string // kind/name, e.g. "Deployment/web", "StatefulSet/db"
+	Labels     map[string]string
+	Created    time.Time
+}
+
+// LineageOf returns pod's lineage; ok is false for a pod no controller
+// owns, which nothing recreates.
+func LineageOf(pod *v1.Pod) (l PodLineage, ok bool) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return l, false
+	}
+	controller := ref.Kind + "/" + ref.Name
+	if d := DeploymentOf(pod); d != "" {
+		controller = "Deployment/" + d
+	}
+	labels := maps.Clone(pod.Labels)
+	for _, k := range perPodLabels {
+		delete(labels, k)
+	}
+	return PodLineage{
+		UID:        pod.UID,
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		Controller: controller,
+		Labels:     labels,
+		Created:    pod.CreationTimestamp.Time,
+	}, true
+}
+
+// IsReplacedBy reports whether pod is a replacement for the lineage's pod
+// whose container can be tailed: another pod (a StatefulSet's keeps its
+// name) from the same controller and labels, created no earlier, not being
+// deleted, with container started.
+func (l PodLineage) IsReplacedBy(pod *v1.Pod, container string) bool {
+	if pod.UID == l.UID || pod.Namespace != l.Namespace || pod.DeletionTimestamp != nil {
+		return false
+	}
+	if pod.CreationTimestamp.Time.Before(l.Created) {
+		return false
+	}
+	other, ok := LineageOf(pod)
+	if !ok || other.Controller != l.Controller || !maps.Equal(other.Labels, l.Labels) {
+		return false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == container {
+			return cs.State.Running != nil || cs.State.Terminated != nil
+		}
+	}
+	return false
+}
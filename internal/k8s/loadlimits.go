@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// LoadLimits bound the API requests ktails has out at once across every
+// context, and how long each may wait for its response, so many selected
+// contexts load a few at a time and one that doesn't answer fails on its
+// own instead of holding up the rest. A zero limit is unlimited.
+type LoadLimits struct {
+	Parallelism int
+	Timeout     time.Duration
+}
+
+// SlowResponseError is a request to Context that got no response within
+// LoadLimits.Timeout.
+type SlowResponseError struct {
+	Context string
+	Timeout time.Duration
+}
+
+func (e *SlowResponseError) Error() string {
+	return fmt.Sprintf("context %s sent no response within %s", e.Context, e.Timeout)
+}
+
+// loadLimiter enforces the LoadLimits every context's clientset shares:
+// slots holds a token per request awaiting its response.
+type loadLimiter struct {
+	mu     sync.Mutex
+	limits LoadLimits
+	slots  chan struct{}
+}
+
+func (l *loadLimiter) set(limits LoadLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = limits
+	l.slots = nil
+	if limits.Parallelism > 0 {
+		l.slots = make(chan struct{}, limits.Parallelism)
+	}
+}
+
+func (l *loadLimiter) current() (LoadLimits, chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limits, l.slots
+}
+
+// wrap returns a rest.Config WrapTransport that holds kubeContext's
+// requests to the limits. Like Telemetry, it counts a request until its
+// response headers arrive, so a watch or a following log stream takes a
+// slot only while it opens, and only its opening is timed.
+func (l *loadLimiter) wrap(kubeContext string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &limitedTransport{next: rt, limiter: l, context: kubeContext}
+	}
+}
+
+type limitedTransport struct {
+	next    http.RoundTripper
+	limiter *loadLimiter
+	context string
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Exec and port-forward sessions upgrade the connection; they're
+	// interactive, not part of a load.
+	if httpstream.IsUpgradeRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+	limits, slots := t.limiter.current()
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	if limits.Timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(limits.Timeout, cancel)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() {
+		// Timed out, if only just: the response can't be read past the
+		// cancellation either way.
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if req.Context().Err() != nil {
+			return nil, req.Context().Err()
+		}
+		return nil, &SlowResponseError{Context: t.context, Timeout: limits.Timeout}
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a timed request's context along with its body.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodeRoleLabelPrefix is the prefix of the labels naming a node's roles,
+// e.g. node-role.kubernetes.io/control-plane.
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// NodeInfo is a node as the nodes tab shows it.
+type NodeInfo struct {
+	Name    string
+	Context string
+	// Status is what kubectl get nodes shows: Ready, NotReady or Unknown,
+	// with ",SchedulingDisabled" for a cordoned node.
+	Status  string
+	Roles   []string
+	Version string // kubelet version
+	// CPUMilli, MemoryBytes and Pods are the node's capacity.
+	CPUMilli    int64
+	MemoryBytes int64
+	Pods        int64
+	// Conditions lists the node's problem conditions that are on, e.g.
+	// MemoryPressure or DiskPressure; empty for a healthy node.
+	Conditions []string
+	Age        string
+}
+
+// ListNodeInfo lists a context's nodes, sorted by name.
+func (c *Client) ListNodeInfo(ctx context.Context, kubeContext string) ([]NodeInfo, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	if err := c.checkAccess(ctx, kubeContext, "", ListNodes); err != nil {
+		return nil, err
+	}
+	list, err := withRetry(ctx, c, kubeContext, func() (*v1.NodeList, error) {
+		return clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes in context %s: %w", kubeContext, err)
+	}
+
+	nodes := make([]NodeInfo, 0, len(list.Items))
+	for i := range list.Items {
+		nodes = append(nodes, NodeToNodeInfo(&list.Items[i], kubeContext))
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
+
+// NodeToNodeInfo converts a node to its tab row.
+func NodeToNodeInfo(node *v1.Node, kubeContext string) NodeInfo {
+	info := NodeInfo{
+		Name:        node.Name,
+		Context:     kubeContext,
+		Status:      nodeStatus(node),
+		Roles:       nodeRoles(node),
+		Version:     node.Status.NodeInfo.KubeletVersion,
+		CPUMilli:    node.Status.Capacity.Cpu().MilliValue(),
+		MemoryBytes: node.Status.Capacity.Memory().Value(),
+		Pods:        node.Status.Capacity.Pods().Value(),
+		Age:         formatDuration(time.Since(node.CreationTimestamp.Time)),
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != v1.NodeReady && cond.Status == v1.ConditionTrue {
+			info.Conditions = append(info.Conditions, string(cond.Type))
+		}
+	}
+	return info
+}
+
+// NodeStatusFailing reports whether status (see NodeInfo.Status) means the
+// node isn't running pods: NotReady, or Unknown because its kubelet stopped
+// reporting.
+func NodeStatusFailing(status string) bool {
+	status, _, _ = strings.Cut(status, ",")
+	return status != string(v1.NodeReady)
+}
+
+// nodeStatus is the node's Ready condition as kubectl shows it.
+func nodeStatus(node *v1.Node) string {
+	status := "Unknown"
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != v1.NodeReady {
+			continue
+		}
+		switch cond.Status {
+		case v1.ConditionTrue:
+			status = string(v1.NodeReady)
+		case v1.ConditionFalse:
+			status = "NotReady"
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// nodeRoles reads a node's roles from its node-role.kubernetes.io/<role>
+// labels, and the older kubernetes.io/role one.
+func nodeRoles(node *v1.Node) []string {
+	var roles []string
+	for label, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(label, nodeRoleLabelPrefix):
+			if role := strings.TrimPrefix(label, nodeRoleLabelPrefix); role != "" {
+				roles = append(roles, role)
+			}
+		case label == "kubernetes.io/role" && value != "":
+			roles = append(roles, value)
+		}
+	}
+	slices.Sort(roles)
+	return slices.Compact(roles)
+}
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxOrdinalRange bounds a single "a..b" range so a typo like 0..100000
+// doesn't expand into that many pod names.
+const maxOrdinalRange = 1000
+
+// ParseOrdinals expands an ordinal pattern into the names of StatefulSet
+// statefulSet's pods. The pattern is a comma-separated list of ordinals
+// and inclusive ranges, each end optionally written as the pod name: "0..4",
+// "0,2,5", "web-0..web-4" and "web-1..3" all work. Names come back in the
+// order given, without duplicates.
+func ParseOrdinals(statefulSet, expr string) ([]string, error) {
+	var names []string
+	seen := make(map[int]bool)
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(term, "..")
+		lo, err := parseOrdinal(statefulSet, from)
+		if err != nil {
+			return nil, err
+		}
+		hi := lo
+		if isRange {
+			if hi, err = parseOrdinal(statefulSet, to); err != nil {
+				return nil, err
+			}
+		}
+		if hi < lo {
+			return nil, fmt.Errorf("range %q runs backwards", term)
+		}
+		if hi-lo >= maxOrdinalRange {
+			return nil, fmt.Errorf("range %q spans more than %d ordinals", term, maxOrdinalRange)
+		}
+		for n := lo; n <= hi; n++ {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, fmt.Sprintf("%s-%d", statefulSet, n))
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no ordinals in %q", expr)
+	}
+	return names, nil
+}
+
+// parseOrdinal reads one end of an ordinal term: a bare ordinal, or the
+// StatefulSet's pod name for it.
+func parseOrdinal(statefulSet, s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if n, ok := StatefulSetOrdinal(statefulSet, s); ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is neither an ordinal nor a %s-N pod name", s, statefulSet)
+	}
+	return n, nil
+}
+
+// StatefulSetOrdinal reports the ordinal of pod if it's named the way
+// StatefulSet statefulSet names its pods ("<statefulSet>-<ordinal>").
+func StatefulSetOrdinal(statefulSet, pod string) (int, bool) {
+	suffix, ok := strings.CutPrefix(pod, statefulSet+"-")
+	if !ok || suffix == "" {
+		return 0, false
+	}
+	for _, r := range suffix {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
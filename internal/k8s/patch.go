@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetPodLabels returns a pod's labels.
+func (c *Client) GetPodLabels(ctx context.Context, kubeContext, namespace, podName string) (map[string]string, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
+	}
+	return pod.Labels, nil
+}
+
+// AnnotatePod sets one annotation on a pod, leaving the others alone.
+func (c *Client) AnnotatePod(ctx context.Context, kubeContext, namespace, podName, key, value string) (err error) {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	defer c.record(c.auditEntry(kubeContext, "annotate", "pod", namespace, podName, key+"="+value), &err)
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]string{key: value}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build annotation patch: %w", err)
+	}
+	if _, err := clientset.CoreV1().Pods(namespace).Patch(ctx, podName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to annotate pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
+	}
+	return nil
+}
+
+// SetConfigMapKey sets one data key of an existing ConfigMap, leaving the
+// other keys alone.
+func (c *Client) SetConfigMapKey(ctx context.Context, kubeContext, namespace, name, key, value string) (err error) {
+	if err := c.refuseWrite(); err != nil {
+		return err
+	}
+	defer c.record(c.auditEntry(kubeContext, "set key", "configmap", namespace, name, key+"="+value), &err)
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"data": map[string]string{key: value},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build configmap patch: %w", err)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch configmap %s in namespace %s (context %s): %w", name, namespace, kubeContext, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodStatus is the status kubectl get pods shows for pod: the phase, unless
+// something more telling is going on — an init container still running or
+// failing ("Init:1/2", "Init:CrashLoopBackOff"), a container waiting or
+// stopped ("CrashLoopBackOff", "ImagePullBackOff", "OOMKilled"), a pod
+// shutting down ("Terminating") or one whose containers run but aren't all
+// ready ("NotReady"). A crash-looping pod's phase stays Running.
+func PodStatus(pod *v1.Pod) string {
+	status := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		status = pod.Status.Reason
+	}
+
+	initializing := false
+	for i, cs := range pod.Status.InitContainerStatuses {
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+			continue
+		case isSidecar(pod, cs.Name) && cs.Started != nil && *cs.Started:
+			continue
+		case cs.State.Terminated != nil:
+			status = "Init:" + terminatedReason(cs.State.Terminated)
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing":
+			status = "Init:" + cs.State.Waiting.Reason
+		default:
+			status = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing {
+		running := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			cs := pod.Status.ContainerStatuses[i]
+			switch {
+			case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+				status = cs.State.Waiting.Reason
+			case cs.State.Terminated != nil:
+				status = terminatedReason(cs.State.Terminated)
+			case cs.Ready && cs.State.Running != nil:
+				running = true
+			}
+		}
+		// A pod with one container done and another still up.
+		if status == "Completed" && running {
+			status = "NotReady"
+			if isPodReady(pod) {
+				status = string(v1.PodRunning)
+			}
+		}
+		if status == string(v1.PodRunning) && !isPodReady(pod) && len(pod.Status.ContainerStatuses) > 0 {
+			status = "NotReady"
+		}
+	}
+
+	if pod.DeletionTimestamp != nil {
+		if pod.Status.Reason == "NodeLost" {
+			return string(v1.PodUnknown)
+		}
+		return "Terminating"
+	}
+	return status
+}
+
+// PodStatusFailing reports whether status (see PodStatus) means the pod is
+// broken rather than on its way up or down: a failed phase, a container
+// crash-looping, failing to pull its image or start, or exiting in error.
+func PodStatusFailing(status string) bool {
+	status = strings.TrimPrefix(status, "Init:")
+	switch status {
+	case string(v1.PodFailed), string(v1.PodUnknown), "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull",
+		"InvalidImageName", "CreateContainerConfigError", "CreateContainerError", "RunContainerError",
+		"Error", "OOMKilled", "ContainerStatusUnknown", "Evicted", "NodeLost", "DeadlineExceeded":
+		return true
+	}
+	return strings.HasPrefix(status, "ExitCode:") || strings.HasPrefix(status, "Signal:")
+}
+
+// terminatedReason names a stopped container's exit: its reason, else its
+// signal or exit code.
+func terminatedReason(t *v1.ContainerStateTerminated) string {
+	switch {
+	case t.Reason != "":
+		return t.Reason
+	case t.Signal != 0:
+		return fmt.Sprintf("Signal:%d", t.Signal)
+	}
+	return fmt.Sprintf("ExitCode:%d", t.ExitCode)
+}
+
+// isSidecar reports whether pod's init container name is a sidecar — one
+// that keeps running alongside the app (restartPolicy: Always).
+func isSidecar(pod *v1.Pod, name string) bool {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == name {
+			return c.RestartPolicy != nil && *c.RestartPolicy == v1.ContainerRestartPolicyAlways
+		}
+	}
+	return false
+}
@@ -0,0 +1,302 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// Kinds of object a port-forward can target. A service forward goes to one
+// ready pod behind it, picked when the forward starts — like kubectl
+// port-forward svc/..., it doesn't follow the service to another pod.
+const (
+	ForwardPod     = "pod"
+	ForwardService = "svc"
+)
+
+// PortForwardInfo is a snapshot of one forward.
+type PortForwardInfo struct {
+	ID        int
+	Context   string
+	Namespace string
+	Kind      string // ForwardPod or ForwardService
+	Name      string
+	// Pod is the pod actually forwarded to — Name itself for a pod forward.
+	Pod        string
+	LocalPort  int
+	RemotePort int // as requested: the service's port for a svc forward
+	Started    time.Time
+	Active     bool
+	Err        string // why an inactive forward ended, if it failed
+}
+
+// Uptime renders how long the forward has been running.
+func (f PortForwardInfo) Uptime() string {
+	return formatDuration(time.Since(f.Started))
+}
+
+// PortForwardManager runs port-forwards in the background, independent of
+// whatever the TUI is showing, until they're stopped or their connection
+// drops. Ended forwards stay listed (with their error) until stopped.
+type PortForwardManager struct {
+	client *Client
+
+	mu       sync.Mutex
+	nextID   int
+	forwards []*portForward
+}
+
+type portForward struct {
+	info PortForwardInfo
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewPortForwardManager creates a manager forwarding through client.
+func NewPortForwardManager(client *Client) *PortForwardManager {
+	return &PortForwardManager{client: client}
+}
+
+// Start opens a forward from localhost:localPort (0 picks a free port) to
+// remotePort of a pod, or of a service's backing pod. It returns once the
+// local listener is up; done is closed when the forward ends.
+func (m *PortForwardManager) Start(ctx context.Context, kubeContext, namespace, kind, name string, localPort, remotePort int) (info PortForwardInfo, done <-chan struct{}, err error) {
+	clientset, err := m.client.GetClientForContext(kubeContext)
+	if err != nil {
+		return PortForwardInfo{}, nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	pod, podPort := name, remotePort
+	if kind == ForwardService {
+		pod, podPort, err = resolveServiceForward(ctx, clientset, namespace, name, remotePort)
+		if err != nil {
+			return PortForwardInfo{}, nil, err
+		}
+	}
+
+	stop := make(chan struct{})
+	localPort, failed, err := m.client.forwardPod(ctx, kubeContext, namespace, pod, localPort, podPort, stop)
+	if err != nil {
+		return PortForwardInfo{}, nil, err
+	}
+
+	m.mu.Lock()
+	m.nextID++
+	pf := &portForward{
+		info: PortForwardInfo{
+			ID:         m.nextID,
+			Context:    kubeContext,
+			Namespace:  namespace,
+			Kind:       kind,
+			Name:       name,
+			Pod:        pod,
+			LocalPort:  localPort,
+			RemotePort: remotePort,
+			Started:    time.Now(),
+			Active:     true,
+		},
+		stop: stop,
+		done: make(chan struct{}),
+	}
+	m.forwards = append(m.forwards, pf)
+	m.mu.Unlock()
+
+	go func() {
+		err := <-failed
+		m.mu.Lock()
+		pf.info.Active = false
+		if err != nil {
+			pf.info.Err = err.Error()
+		}
+		m.mu.Unlock()
+		close(pf.done)
+	}()
+
+	return pf.info, pf.done, nil
+}
+
+// forwardPod opens a forward from localhost:localPort (0 picks a free
+// port) to podPort of a pod, returning once the local listener is up with
+// the port it got. failed yields the forward's end — nil once stop is
+// closed. If ctx ends while the listener is coming up, forwardPod closes
+// stop itself and returns ctx's error; the caller closes it otherwise.
+func (c *Client) forwardPod(ctx context.Context, kubeContext, namespace, pod string, localPort, podPort int, stop chan struct{}) (port int, failed <-chan error, err error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	restConfig, err := c.restConfigForContext(kubeContext)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward")
+
+	// Like kubectl, prefer tunneling over websockets and fall back to SPDY.
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create port-forward to pod %s in context %s: %w", pod, kubeContext, err)
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	wsDialer, err := portforward.NewSPDYOverWebsocketDialer(req.URL(), restConfig)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create port-forward to pod %s in context %s: %w", pod, kubeContext, err)
+	}
+	dialer := portforward.NewFallbackDialer(wsDialer, spdyDialer, httpstream.IsUpgradeFailure)
+
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"localhost"},
+		[]string{fmt.Sprintf("%d:%d", localPort, podPort)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create port-forward to pod %s in context %s: %w", pod, kubeContext, err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- fw.ForwardPorts() }()
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		close(stop)
+		return 0, nil, ctx.Err()
+	case err := <-done:
+		if err == nil {
+			err = fmt.Errorf("forward ended before it was ready")
+		}
+		return 0, nil, fmt.Errorf("port-forward to pod %s/%s failed: %w", namespace, pod, err)
+	}
+
+	if ports, err := fw.GetPorts(); err == nil && len(ports) > 0 {
+		localPort = int(ports[0].Local)
+	}
+	return localPort, done, nil
+}
+
+// List returns every forward, active or ended, oldest first.
+func (m *PortForwardManager) List() []PortForwardInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]PortForwardInfo, len(m.forwards))
+	for i, pf := range m.forwards {
+		out[i] = pf.info
+	}
+	return out
+}
+
+// Stop tears down a forward (if still active) and forgets it. It reports
+// whether id was known.
+func (m *PortForwardManager) Stop(id int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, pf := range m.forwards {
+		if pf.info.ID == id {
+			if pf.info.Active {
+				close(pf.stop)
+				pf.info.Active = false
+			}
+			m.forwards = append(m.forwards[:i], m.forwards[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// StopAll tears down every forward and waits for them to close their
+// listeners — called on quit, so no local port outlives the program.
+func (m *PortForwardManager) StopAll() {
+	m.mu.Lock()
+	forwards := m.forwards
+	m.forwards = nil
+	for _, pf := range forwards {
+		if pf.info.Active {
+			close(pf.stop)
+			pf.info.Active = false
+		}
+	}
+	m.mu.Unlock()
+
+	for _, pf := range forwards {
+		<-pf.done
+	}
+}
+
+// resolveServiceForward maps a service port to one ready backing pod and the
+// container port it targets (resolving a named targetPort against the pod's
+// container ports).
+func resolveServiceForward(ctx context.Context, clientset kubernetes.Interface, namespace, svcName string, port int) (pod string, podPort int, err error) {
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, svcName, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get service %s in namespace %s: %w", svcName, namespace, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s has no selector, so no pods to forward to", svcName)
+	}
+
+	var svcPort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == port {
+			svcPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if svcPort == nil {
+		return "", 0, fmt.Errorf("service %s has no port %d", svcName, port)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list pods of service %s: %w", svcName, err)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.Status.Phase != corev1.PodRunning || !isPodReady(p) {
+			continue
+		}
+		target := svcPort.TargetPort
+		if target.StrVal == "" {
+			if target.IntVal == 0 {
+				return p.Name, port, nil
+			}
+			return p.Name, int(target.IntVal), nil
+		}
+		for _, c := range p.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == target.StrVal {
+					return p.Name, int(cp.ContainerPort), nil
+				}
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("service %s has no ready pod serving port %d", svcName, port)
+}
+
+// isPodReady reports whether a pod's Ready condition is true.
+func isPodReady(p *corev1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// maxProbeFailures bounds how many recent probe failure events the pod
+// detail lists — enough to see a pattern without burying the rest.
+const maxProbeFailures = 5
+
+// probeLines renders each container's liveness/readiness/startup probe
+// configuration, one probe per line, for the pod detail's Status section.
+func probeLines(containers []v1.Container) []string {
+	var lines []string
+	for _, ctr := range containers {
+		probes := []struct {
+			kind  string
+			probe *v1.Probe
+		}{
+			{"startup", ctr.StartupProbe},
+			{"liveness", ctr.LivenessProbe},
+			{"readiness", ctr.ReadinessProbe},
+		}
+		hasProbe := false
+		for _, p := range probes {
+			if p.probe == nil {
+				continue
+			}
+			hasProbe = true
+			lines = append(lines, fmt.Sprintf("  %s %s: %s", ctr.Name, p.kind, formatProbe(p.probe)))
+		}
+		if !hasProbe {
+			lines = append(lines, fmt.Sprintf("  %s: no probes", ctr.Name))
+		}
+	}
+	return lines
+}
+
+// formatProbe summarizes a probe's handler and timing the way kubectl
+// describe does, e.g.
+// "http-get :8080/healthz delay=10s timeout=1s period=10s #success=1 #failure=3".
+func formatProbe(p *v1.Probe) string {
+	var handler string
+	switch {
+	case p.HTTPGet != nil:
+		scheme := strings.ToLower(string(p.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		handler = fmt.Sprintf("%s-get %s:%s%s", scheme, p.HTTPGet.Host, p.HTTPGet.Port.String(), p.HTTPGet.Path)
+	case p.TCPSocket != nil:
+		handler = fmt.Sprintf("tcp-socket %s:%s", p.TCPSocket.Host, p.TCPSocket.Port.String())
+	case p.GRPC != nil:
+		handler = fmt.Sprintf("grpc :%d", p.GRPC.Port)
+		if p.GRPC.Service != nil && *p.GRPC.Service != "" {
+			handler += " " + *p.GRPC.Service
+		}
+	case p.Exec != nil:
+		handler = "exec [" + strings.Join(p.Exec.Command, " ") + "]"
+	default:
+		handler = "unknown"
+	}
+
+	// Zero values mean "server default" in a spec that wasn't defaulted
+	// (it always is once admitted, but be honest about what's there).
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		handler, p.InitialDelaySeconds, p.TimeoutSeconds, p.PeriodSeconds, p.SuccessThreshold, p.FailureThreshold)
+}
+
+// probeFailureLines picks the most recent probe failure events (the kubelet
+// reports them with reason "Unhealthy"; events are already newest first).
+func probeFailureLines(events []EventInfo) []string {
+	var lines []string
+	for _, ev := range events {
+		if ev.Reason != "Unhealthy" {
+			continue
+		}
+		count := ""
+		if ev.Count > 1 {
+			count = fmt.Sprintf(" (x%d)", ev.Count)
+		}
+		lines = append(lines, fmt.Sprintf("  %s ago%s: %s", ev.Age, count, ev.Message))
+		if len(lines) == maxProbeFailures {
+			break
+		}
+	}
+	return lines
+}
@@ -0,0 +1,29 @@
+package k8s
+
+import "errors"
+
+// ErrReadOnly is what every call that would change something in a cluster,
+// or run a process in a container, returns from a read-only client.
+var ErrReadOnly = errors.New("read-only mode: nothing in the cluster is changed")
+
+// SetReadOnly has the client refuse, with ErrReadOnly, every call that
+// writes — deleting a pod, restarting or rolling back a Deployment,
+// patching a pod or ConfigMap, cordoning or draining a node — and every
+// exec, whatever the UI lets through. There's no turning it back off.
+func (c *Client) SetReadOnly() {
+	c.readOnly.Store(true)
+}
+
+// ReadOnly reports whether SetReadOnly was called.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly.Load()
+}
+
+// refuseWrite is ErrReadOnly on a read-only client, else nil; every
+// mutating call checks it first.
+func (c *Client) refuseWrite() error {
+	if c.readOnly.Load() {
+		return ErrReadOnly
+	}
+	return nil
+}
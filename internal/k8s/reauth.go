@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"sync/atomic"
+
+	"k8s.io/client-go/rest"
+)
+
+// errReauthInProgress is reauthenticate's answer while the context is
+// already being re-authenticated: the 401 that triggered it stands.
+var errReauthInProgress = errors.New("re-authentication already in progress")
+
+// reauthRetryKey marks a request as the retry after a re-authentication,
+// so a second 401 is returned rather than re-authenticating again.
+type reauthRetryKey struct{}
+
+// reauthWrap returns a rest.Config WrapTransport that recovers kubeContext
+// from expired credentials. Cached clients live for the whole session, so
+// a token from an exec plugin (aws eks get-token, gcloud) or an OIDC
+// provider eventually expires under them; on a 401 the context's client is
+// rebuilt from the kubeconfig — which runs the plugin again — and the
+// request retried once with the new credentials. Nothing is retried until
+// armed is set, once the client is built: re-authenticating rebuilds it,
+// which mustn't start from inside the build (under Client.mu).
+func (c *Client) reauthWrap(kubeContext string, armed *atomic.Bool) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &reauthTransport{next: rt, armed: armed, refresh: func() (http.RoundTripper, error) {
+			return c.reauthenticate(kubeContext)
+		}}
+	}
+}
+
+type reauthTransport struct {
+	next  http.RoundTripper
+	armed *atomic.Bool
+	// refresh rebuilds the context's credentials, returning a transport
+	// that authenticates with them.
+	refresh func() (http.RoundTripper, error)
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !t.armed.Load() || req.Context().Value(reauthRetryKey{}) != nil {
+		return resp, err
+	}
+	// A body already sent can only be resent if it can be had again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	fresh, rerr := t.refresh()
+	if rerr != nil {
+		return resp, nil
+	}
+
+	retry := req.Clone(context.WithValue(req.Context(), reauthRetryKey{}, true))
+	if req.GetBody != nil {
+		if retry.Body, rerr = req.GetBody(); rerr != nil {
+			return resp, nil
+		}
+	}
+	// The auth round trippers leave a request that already carries
+	// credentials alone, so the stale ones have to go.
+	retry.Header.Del("Authorization")
+	resp.Body.Close()
+	return fresh.RoundTrip(retry)
+}
+
+// reauthenticate drops kubeContext's cached client and builds a new one
+// from the kubeconfig, returning a transport for its rest config. Requests
+// failing with a 401 meanwhile get errReauthInProgress, rather than
+// queueing up re-authentications of their own.
+func (c *Client) reauthenticate(kubeContext string) (http.RoundTripper, error) {
+	c.reauthMu.Lock()
+	if c.reauthing[kubeContext] {
+		c.reauthMu.Unlock()
+		return nil, errReauthInProgress
+	}
+	if c.reauthing == nil {
+		c.reauthing = make(map[string]bool)
+	}
+	c.reauthing[kubeContext] = true
+	c.reauthMu.Unlock()
+	defer func() {
+		c.reauthMu.Lock()
+		delete(c.reauthing, kubeContext)
+		c.reauthMu.Unlock()
+	}()
+
+	c.ClearClientCache(kubeContext)
+	cfg, err := c.restConfigForContext(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	return rest.TransportFor(cfg)
+}
+
+// Reauthenticating returns the contexts whose credentials are being
+// rebuilt after a 401, sorted.
+func (c *Client) Reauthenticating() []string {
+	c.reauthMu.Lock()
+	defer c.reauthMu.Unlock()
+	return slices.Sorted(maps.Keys(c.reauthing))
+}
@@ -0,0 +1,204 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// List calls and watch opens that fail transiently are retried up to
+// maxRetries times, the nth retry after a jittered retryBase<<(n-1),
+// capped at maxRetryDelay.
+const (
+	maxRetries    = 3
+	maxRetryDelay = 8 * time.Second
+)
+
+// retryBase is a var so tests can shorten the backoff.
+var retryBase = time.Second
+
+// ErrorKind is what a failed call's error says about calling again.
+type ErrorKind int
+
+const (
+	// ErrorPermanent is any failure retrying won't fix: a bad request, a
+	// missing resource, a broken kubeconfig entry.
+	ErrorPermanent ErrorKind = iota
+	// ErrorTransient is a timeout, throttling or a dropped connection:
+	// the same call may well succeed in a moment, and is retried.
+	ErrorTransient
+	// ErrorUnauthorized is a 401: the context's credentials were rejected.
+	ErrorUnauthorized
+	// ErrorForbidden is a 403: RBAC doesn't allow the call.
+	ErrorForbidden
+)
+
+// ErrorKindOf classifies err.
+func ErrorKindOf(err error) ErrorKind {
+	var slow *SlowResponseError
+	var denied *PermissionError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ErrorPermanent
+	case apierrors.IsUnauthorized(err):
+		return ErrorUnauthorized
+	case apierrors.IsForbidden(err), errors.As(err, &denied):
+		return ErrorForbidden
+	case errors.As(err, &slow),
+		apierrors.IsTimeout(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTooManyRequests(err),
+		apierrors.IsServiceUnavailable(err),
+		utilnet.IsConnectionReset(err),
+		utilnet.IsProbableEOF(err),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTransient
+	}
+	return ErrorPermanent
+}
+
+// ExplainError is err's message led by what kind of failure it is, so a
+// cluster that's briefly unreachable reads differently from credentials or
+// RBAC that will keep failing until someone fixes them. A
+// *PermissionError already says which, and reads as just that. "" for nil.
+func ExplainError(err error) string {
+	var denied *PermissionError
+	if err == nil {
+		return ""
+	}
+	if errors.As(err, &denied) {
+		return denied.Error()
+	}
+	switch ErrorKindOf(err) {
+	case ErrorTransient:
+		return "temporary failure, retried: " + err.Error()
+	case ErrorUnauthorized:
+		return "not authenticated, check the context's credentials: " + err.Error()
+	case ErrorForbidden:
+		return "forbidden by RBAC: " + err.Error()
+	}
+	return err.Error()
+}
+
+// retryDelay is the backoff before the nth retry: half of
+// retryBase<<(n-1) (capped at maxRetryDelay) plus up to as much again at
+// random, so contexts that failed together don't retry in lockstep.
+func retryDelay(n int) time.Duration {
+	d := retryBase << uint(n-1)
+	if d <= 0 || d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// withRetry runs call, retrying it while it fails transiently, up to
+// maxRetries times; the backoff between tries is reported through the
+// client's Retries. It gives up early once ctx is done.
+func withRetry[T any](ctx context.Context, c *Client, kubeContext string, call func() (T, error)) (T, error) {
+	for n := 1; ; n++ {
+		v, err := call()
+		if err == nil || n > maxRetries || ErrorKindOf(err) != ErrorTransient || ctx.Err() != nil {
+			return v, err
+		}
+		delay := retryDelay(n)
+		if !c.retries.wait(ctx, kubeContext, RetryState{Attempt: n, At: time.Now().Add(delay), Err: err}, delay) {
+			return v, err
+		}
+	}
+}
+
+// RetryState is a context's call waiting out its backoff before it's
+// retried.
+type RetryState struct {
+	Attempt int       // the retry coming up, from 1
+	At      time.Time // when it's sent
+	Err     error     // the failure being retried
+}
+
+// retryTracker records which contexts have calls backing off, for the
+// contexts list to show. changed is signalled (without blocking) whenever
+// that changes.
+type retryTracker struct {
+	mu      sync.Mutex
+	waiting map[string]int // backing-off calls, by context
+	latest  map[string]RetryState
+	changed chan struct{}
+}
+
+func newRetryTracker() *retryTracker {
+	return &retryTracker{
+		waiting: make(map[string]int),
+		latest:  make(map[string]RetryState),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// wait sleeps out delay as kubeContext's state, reporting false if ctx
+// was done first. A nil tracker (a client built in tests) just sleeps.
+func (t *retryTracker) wait(ctx context.Context, kubeContext string, state RetryState, delay time.Duration) bool {
+	if t != nil {
+		t.update(func() {
+			t.waiting[kubeContext]++
+			t.latest[kubeContext] = state
+		})
+		defer t.update(func() {
+			if t.waiting[kubeContext]--; t.waiting[kubeContext] <= 0 {
+				delete(t.waiting, kubeContext)
+				delete(t.latest, kubeContext)
+			}
+		})
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (t *retryTracker) update(f func()) {
+	t.mu.Lock()
+	f()
+	t.mu.Unlock()
+	select {
+	case t.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Retries returns, by context, the latest of its calls backing off before
+// a retry; contexts with none are left out.
+func (c *Client) Retries() map[string]RetryState {
+	out := make(map[string]RetryState)
+	if c.retries == nil {
+		return out
+	}
+	c.retries.mu.Lock()
+	defer c.retries.mu.Unlock()
+	maps.Copy(out, c.retries.latest)
+	return out
+}
+
+// RetriesChanged is signalled whenever Retries changes. Only one signal is
+// buffered, so a reader sees the latest state, not every step. nil (never
+// signalled) for a client built in tests.
+func (c *Client) RetriesChanged() <-chan struct{} {
+	if c.retries == nil {
+		return nil
+	}
+	return c.retries.changed
+}
@@ -0,0 +1,253 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The annotations a Deployment's ReplicaSets carry: the rollout revision
+// each one is, and the change cause recorded for it (kubectl's --record,
+// or set by hand).
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// ErrRolloutPaused is returned when undoing the rollout of a paused
+// Deployment, which kubectl refuses too: it would be rolled back only once
+// resumed.
+var ErrRolloutPaused = errors.New("the deployment is paused; resume it first")
+
+// RolloutRevision is one revision in a Deployment's history: the
+// ReplicaSet it rolled out.
+type RolloutRevision struct {
+	Revision    int64
+	ReplicaSet  string
+	ChangeCause string
+	Images      []string
+	Replicas    int32
+	Created     time.Time
+	// Current is the revision the Deployment runs.
+	Current bool
+}
+
+// Rollout is a Deployment's rollout as `kubectl rollout status` and
+// `kubectl rollout history` show it.
+type Rollout struct {
+	Name      string
+	Namespace string
+	Context   string
+
+	Desired   int32
+	Current   int32
+	Updated   int32
+	Ready     int32
+	Available int32
+	Paused    bool
+
+	// Status is what kubectl rollout status would print, and Done whether
+	// the rollout has finished.
+	Status string
+	Done   bool
+
+	Conditions []string
+	// Revisions is the history, newest first.
+	Revisions []RolloutRevision
+}
+
+// GetRollout returns a Deployment's rollout status and revision history.
+func (c *Client) GetRollout(ctx context.Context, kubeContext, namespace, name string) (Rollout, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return Rollout{}, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	r, err := getRollout(ctx, clientset, namespace, name)
+	if err != nil {
+		return Rollout{}, fmt.Errorf("failed to get rollout of deployment %s in namespace %s (context %s): %w", name, namespace, kubeContext, err)
+	}
+	r.Context = kubeContext
+	return r, nil
+}
+
+func getRollout(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (Rollout, error) {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return Rollout{}, err
+	}
+	replicaSets, err := deploymentReplicaSets(ctx, clientset, deployment)
+	if err != nil {
+		return Rollout{}, err
+	}
+
+	r := Rollout{
+		Name:      deployment.Name,
+		Namespace: deployment.Namespace,
+		Desired:   1,
+		Current:   deployment.Status.Replicas,
+		Updated:   deployment.Status.UpdatedReplicas,
+		Ready:     deployment.Status.ReadyReplicas,
+		Available: deployment.Status.AvailableReplicas,
+		Paused:    deployment.Spec.Paused,
+	}
+	if deployment.Spec.Replicas != nil {
+		r.Desired = *deployment.Spec.Replicas
+	}
+	r.Status, r.Done = rolloutStatus(deployment)
+	for _, cond := range deployment.Status.Conditions {
+		r.Conditions = append(r.Conditions, formatCondition(string(cond.Type), string(cond.Status), cond.Reason, cond.Message))
+	}
+
+	current := revisionOf(&deployment.ObjectMeta)
+	for _, rs := range replicaSets {
+		rev := RolloutRevision{
+			Revision:    revisionOf(&rs.ObjectMeta),
+			ReplicaSet:  rs.Name,
+			ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Replicas:    rs.Status.Replicas,
+			Created:     rs.CreationTimestamp.Time,
+		}
+		rev.Current = rev.Revision == current
+		for _, ctr := range rs.Spec.Template.Spec.Containers {
+			rev.Images = append(rev.Images, ctr.Image)
+		}
+		r.Revisions = append(r.Revisions, rev)
+	}
+	sort.Slice(r.Revisions, func(i, j int) bool { return r.Revisions[i].Revision > r.Revisions[j].Revision })
+	return r, nil
+}
+
+// rolloutStatus words a Deployment's rollout as kubectl rollout status
+// does, and reports whether it's done.
+func rolloutStatus(d *appsv1.Deployment) (string, bool) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "Waiting for deployment spec update to be observed...", false
+	}
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name), false
+		}
+	}
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	switch {
+	case d.Status.UpdatedReplicas < desired:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", d.Name, d.Status.UpdatedReplicas, desired), false
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", d.Name, d.Status.Replicas-d.Status.UpdatedReplicas), false
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", d.Name, d.Status.AvailableReplicas, d.Status.UpdatedReplicas), false
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out", d.Name), true
+}
+
+// deploymentReplicaSets lists the ReplicaSets a Deployment controls.
+func deploymentReplicaSets(ctx context.Context, clientset kubernetes.Interface, d *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	list, err := clientset.AppsV1().ReplicaSets(d.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(d.Spec.Selector),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.UID == d.UID {
+			owned = append(owned, rs)
+		}
+	}
+	return owned, nil
+}
+
+// revisionOf reads an object's rollout revision annotation, 0 if unset.
+func revisionOf(meta *metav1.ObjectMeta) int64 {
+	rev, _ := strconv.ParseInt(meta.Annotations[revisionAnnotation], 10, 64)
+	return rev
+}
+
+// UndoRollout rolls a Deployment back to revision, or to the one before
+// the current with revision 0, the way `kubectl rollout undo` does: by
+// putting that revision's pod template back, which rolls it out again as
+// the newest revision. It returns the revision rolled back to.
+func (c *Client) UndoRollout(ctx context.Context, kubeContext, namespace, name string, revision int64) (rev int64, err error) {
+	if err := c.refuseWrite(); err != nil {
+		return 0, err
+	}
+	to := "to the previous revision"
+	if revision != 0 {
+		to = fmt.Sprintf("to revision %d", revision)
+	}
+	defer c.record(c.auditEntry(kubeContext, "rollback", "deployment", namespace, name, to), &err)
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	rev, err = undoRollout(ctx, clientset, namespace, name, revision)
+	if err != nil {
+		return 0, fmt.Errorf("failed to undo rollout of deployment %s in namespace %s (context %s): %w", name, namespace, kubeContext, err)
+	}
+	return rev, nil
+}
+
+func undoRollout(ctx context.Context, clientset kubernetes.Interface, namespace, name string, revision int64) (int64, error) {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	if deployment.Spec.Paused {
+		return 0, ErrRolloutPaused
+	}
+	replicaSets, err := deploymentReplicaSets(ctx, clientset, deployment)
+	if err != nil {
+		return 0, err
+	}
+
+	current := revisionOf(&deployment.ObjectMeta)
+	var target *appsv1.ReplicaSet
+	for i := range replicaSets {
+		rev := revisionOf(&replicaSets[i].ObjectMeta)
+		switch {
+		case revision != 0 && rev == revision:
+			target = &replicaSets[i]
+		case revision == 0 && rev < current && (target == nil || rev > revisionOf(&target.ObjectMeta)):
+			target = &replicaSets[i]
+		}
+	}
+	switch {
+	case target == nil && revision == 0:
+		return 0, errors.New("no previous revision to roll back to")
+	case target == nil:
+		return 0, fmt.Errorf("revision %d not found", revision)
+	}
+
+	// The ReplicaSet's template carries the hash label the Deployment adds
+	// to its pods; the Deployment's own template doesn't.
+	template := *target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	rev := revisionOf(&target.ObjectMeta)
+	if apiequality.Semantic.DeepEqual(template, deployment.Spec.Template) {
+		return rev, fmt.Errorf("revision %d is the current template already", rev)
+	}
+
+	patch, err := json.Marshal([]map[string]any{
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to build undo patch: %w", err)
+	}
+	if _, err := clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{}); err != nil {
+		return 0, err
+	}
+	return rev, nil
+}
@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The annotations Prometheus' kubernetes-pods scrape job (as the community
+// charts configure it) reads to find a pod's metrics.
+const (
+	scrapeAnnotation       = "prometheus.io/scrape"
+	scrapePortAnnotation   = "prometheus.io/port"
+	scrapePathAnnotation   = "prometheus.io/path"
+	scrapeSchemeAnnotation = "prometheus.io/scheme"
+)
+
+// maxScrape bounds how much of a /metrics answer is read; a busy app's
+// exposition is a few hundred KiB.
+const maxScrape = 8 << 20
+
+// scrapeTimeout bounds one scrape, forward included.
+const scrapeTimeout = 10 * time.Second
+
+// ErrNotScraped is returned for a pod without prometheus.io/scrape: "true".
+var ErrNotScraped = errors.New("pod isn't annotated prometheus.io/scrape: \"true\"")
+
+// MetricsScrape is one read of a pod's metrics endpoint.
+type MetricsScrape struct {
+	Target   string           // where it was read, e.g. "http :9090/metrics"
+	Restarts map[string]int32 // each container's restarts, from the pod's status
+	Text     []byte
+	At       time.Time
+}
+
+// ScrapePodMetrics reads the metrics endpoint a pod's prometheus.io
+// annotations point at, through a port-forward opened for the one request
+// — so it works from outside the cluster network, with the same access a
+// port-forward needs.
+func (c *Client) ScrapePodMetrics(ctx context.Context, kubeContext, namespace, podName string) (MetricsScrape, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return MetricsScrape{}, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return MetricsScrape{}, fmt.Errorf("failed to get pod %s in namespace %s (context %s): %w", podName, namespace, kubeContext, err)
+	}
+	if pod.Annotations[scrapeAnnotation] != "true" {
+		return MetricsScrape{}, ErrNotScraped
+	}
+	port, err := scrapePort(pod)
+	if err != nil {
+		return MetricsScrape{}, err
+	}
+	path := pod.Annotations[scrapePathAnnotation]
+	if path == "" {
+		path = "/metrics"
+	}
+	scheme := pod.Annotations[scrapeSchemeAnnotation]
+	if scheme == "" {
+		scheme = "http"
+	}
+	if scheme != "http" && scheme != "https" {
+		return MetricsScrape{}, fmt.Errorf("invalid %s annotation %q", scrapeSchemeAnnotation, scheme)
+	}
+
+	out := MetricsScrape{
+		Target:   fmt.Sprintf("%s :%d%s", scheme, port, path),
+		Restarts: make(map[string]int32),
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		out.Restarts[cs.Name] = cs.RestartCount
+	}
+
+	stop := make(chan struct{})
+	local, _, err := c.forwardPod(ctx, kubeContext, namespace, podName, 0, port, stop)
+	if err != nil {
+		return MetricsScrape{}, err
+	}
+	defer close(stop)
+
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://localhost:%d%s", scheme, local, path), nil)
+	if err != nil {
+		return MetricsScrape{}, fmt.Errorf("failed to build scrape request: %w", err)
+	}
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+	// The forward ends at localhost, which no serving certificate names;
+	// the tunnel itself is the API server's, authenticated as any call.
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return MetricsScrape{}, fmt.Errorf("failed to scrape %s/%s at %s: %w", namespace, podName, out.Target, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return MetricsScrape{}, fmt.Errorf("scraping %s/%s at %s: %s", namespace, podName, out.Target, resp.Status)
+	}
+	if out.Text, err = io.ReadAll(io.LimitReader(resp.Body, maxScrape)); err != nil {
+		return MetricsScrape{}, fmt.Errorf("failed to read metrics of %s/%s: %w", namespace, podName, err)
+	}
+	out.At = time.Now()
+	return out, nil
+}
+
+// scrapePort is the port prometheus.io/port names or, without it, the
+// container port named like a metrics one, else the pod's only declared
+// port.
+func scrapePort(pod *corev1.Pod) (int, error) {
+	if v := pod.Annotations[scrapePortAnnotation]; v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil || port <= 0 || port > 65535 {
+			return 0, fmt.Errorf("invalid %s annotation %q", scrapePortAnnotation, v)
+		}
+		return port, nil
+	}
+	var ports []corev1.ContainerPort
+	for _, ctr := range pod.Spec.Containers {
+		ports = append(ports, ctr.Ports...)
+	}
+	for _, p := range ports {
+		if strings.Contains(p.Name, "metrics") {
+			return int(p.ContainerPort), nil
+		}
+	}
+	if len(ports) == 1 {
+		return int(ports[0].ContainerPort), nil
+	}
+	return 0, fmt.Errorf("no %s annotation, and no single container port to scrape", scrapePortAnnotation)
+}
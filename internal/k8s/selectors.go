@@ -0,0 +1,86 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// fieldPathPrefixes mark a selector requirement as a field selector; no
+// label key starts with one (prefixed label keys are DNS names, e.g.
+// app.kubernetes.io/name).
+var fieldPathPrefixes = []string{"metadata.", "spec.", "status."}
+
+// ParseSelectors turns a selector expression into list options. The
+// expression is label selector syntax ("app=api,tier!=frontend,env in
+// (prod)"); comma-separated requirements on a field path such as
+// status.phase=Running or spec.nodeName=node-1 become the field selector
+// instead. Each half is validated the way the API server would parse it. An
+// empty expression selects everything.
+func ParseSelectors(expr string) (metav1.ListOptions, error) {
+	var labelReqs, fieldReqs []string
+	for _, req := range splitRequirements(expr) {
+		if isFieldRequirement(req) {
+			fieldReqs = append(fieldReqs, req)
+		} else {
+			labelReqs = append(labelReqs, req)
+		}
+	}
+
+	var opts metav1.ListOptions
+	if len(labelReqs) > 0 {
+		sel, err := labels.Parse(strings.Join(labelReqs, ","))
+		if err != nil {
+			return metav1.ListOptions{}, fmt.Errorf("invalid label selector: %w", err)
+		}
+		opts.LabelSelector = sel.String()
+	}
+	if len(fieldReqs) > 0 {
+		sel, err := fields.ParseSelector(strings.Join(fieldReqs, ","))
+		if err != nil {
+			return metav1.ListOptions{}, fmt.Errorf("invalid field selector: %w", err)
+		}
+		opts.FieldSelector = sel.String()
+	}
+	return opts, nil
+}
+
+// splitRequirements splits expr on the commas between requirements, keeping
+// those inside a set's parentheses ("env in (prod,staging)"), and trims
+// each one.
+func splitRequirements(expr string) []string {
+	var reqs []string
+	depth, start := 0, 0
+	flush := func(end int) {
+		if req := strings.TrimSpace(expr[start:end]); req != "" {
+			reqs = append(reqs, req)
+		}
+	}
+	for i, r := range expr {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				flush(i)
+				start = i + 1
+			}
+		}
+	}
+	flush(len(expr))
+	return reqs
+}
+
+func isFieldRequirement(req string) bool {
+	for _, prefix := range fieldPathPrefixes {
+		if strings.HasPrefix(req, prefix) {
+			return true
+		}
+	}
+	return false
+}
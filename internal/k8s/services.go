@@ -27,13 +27,15 @@ type ServiceInfo struct {
 }
 
 // GetServiceInfo retrieves service information for a specific context and namespace
-func (c *Client) GetServiceInfo(kubeContextName, namespace string) ([]ServiceInfo, error) {
+func (c *Client) GetServiceInfo(ctx context.Context, kubeContextName, namespace string) ([]ServiceInfo, error) {
 	clientset, err := c.GetClientForContext(kubeContextName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
 	}
 
-	serviceList, err := clientset.CoreV1().Services(namespace).List(context.Background(), v1.ListOptions{})
+	serviceList, err := withRetry(ctx, c, kubeContextName, func() (*corev1.ServiceList, error) {
+		return clientset.CoreV1().Services(namespace).List(ctx, v1.ListOptions{})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list services in namespace %s (context %s): %w",
 			namespace, kubeContextName, err)
@@ -65,13 +67,15 @@ func ServiceToServiceInfo(svc *corev1.Service) ServiceInfo {
 // call and groups their addresses by owning service name (via the
 // kubernetes.io/service-name label EndpointSlices carry), so callers don't
 // need one API round trip per service.
-func (c *Client) GetServiceEndpoints(kubeContextName, namespace string) (map[string][]string, error) {
+func (c *Client) GetServiceEndpoints(ctx context.Context, kubeContextName, namespace string) (map[string][]string, error) {
 	clientset, err := c.GetClientForContext(kubeContextName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
 	}
 
-	sliceList, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), v1.ListOptions{})
+	sliceList, err := withRetry(ctx, c, kubeContextName, func() (*discoveryv1.EndpointSliceList, error) {
+		return clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, v1.ListOptions{})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list endpoint slices in namespace %s (context %s): %w",
 			namespace, kubeContextName, err)
@@ -92,14 +96,14 @@ func (c *Client) GetServiceEndpoints(kubeContextName, namespace string) (map[str
 }
 
 // GetServiceDetail fetches a single service's status, rendered YAML, and recent events.
-func (c *Client) GetServiceDetail(kubeContextName, namespace, serviceName string) (ResourceDetail, error) {
+func (c *Client) GetServiceDetail(ctx context.Context, kubeContextName, namespace, serviceName string) (ResourceDetail, error) {
 	d := ResourceDetail{Kind: "Service"}
 	clientset, err := c.GetClientForContext(kubeContextName)
 	if err != nil {
 		return d, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
 	}
 
-	svc, err := clientset.CoreV1().Services(namespace).Get(context.Background(), serviceName, v1.GetOptions{})
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, serviceName, v1.GetOptions{})
 	if err != nil {
 		return d, fmt.Errorf("failed to get service %s in namespace %s (context %s): %w",
 			serviceName, namespace, kubeContextName, err)
@@ -119,13 +123,14 @@ func (c *Client) GetServiceDetail(kubeContextName, namespace, serviceName string
 
 	svc.ManagedFields = nil
 	svc.TypeMeta = v1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+	c.applyHealth(&d, svc)
 	if yamlBytes, yamlErr := yaml.Marshal(svc); yamlErr == nil {
 		d.YAML = string(yamlBytes)
 	} else {
 		d.YAML = fmt.Sprintf("failed to render YAML: %v", yamlErr)
 	}
 
-	if events, err := c.getEvents(kubeContextName, namespace, "Service", serviceName); err == nil {
+	if events, err := c.getEvents(ctx, kubeContextName, namespace, "Service", serviceName); err == nil {
 		d.Events = events
 	}
 
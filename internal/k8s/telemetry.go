@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestRateWindow is the sliding window Telemetry reports request rate
+// over.
+const requestRateWindow = time.Minute
+
+// Telemetry counts the API requests each context's clientset makes: how
+// many are outstanding right now and how many were sent in the last
+// requestRateWindow. Every clientset (and exec/port-forward connection)
+// built from a context's rest config reports here.
+type Telemetry struct {
+	mu       sync.Mutex
+	contexts map[string]*contextRequests
+	now      func() time.Time
+}
+
+// contextRequests is one context's counters. sent holds the send time of
+// each request within the rate window, oldest first.
+type contextRequests struct {
+	inFlight int
+	sent     []time.Time
+}
+
+// RequestUsage is one context's request load at a point in time.
+type RequestUsage struct {
+	InFlight  int // requests sent and not yet answered
+	PerMinute int // requests sent in the last minute
+}
+
+// RequestBudget is the load a context is allowed before ktails holds back;
+// a zero limit is unlimited.
+type RequestBudget struct {
+	MaxInFlight  int
+	MaxPerMinute int
+}
+
+// Load returns how much of budget usage spends, as the larger of its two
+// ratios (1 is at the limit).
+func (u RequestUsage) Load(budget RequestBudget) float64 {
+	var load float64
+	if budget.MaxInFlight > 0 {
+		load = float64(u.InFlight) / float64(budget.MaxInFlight)
+	}
+	if budget.MaxPerMinute > 0 {
+		load = max(load, float64(u.PerMinute)/float64(budget.MaxPerMinute))
+	}
+	return load
+}
+
+// NewTelemetry returns an empty collector.
+func NewTelemetry() *Telemetry {
+	return &Telemetry{contexts: make(map[string]*contextRequests), now: time.Now}
+}
+
+// Usage returns kubeContext's current request load.
+func (t *Telemetry) Usage(kubeContext string) RequestUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cr, ok := t.contexts[kubeContext]
+	if !ok {
+		return RequestUsage{}
+	}
+	cr.prune(t.now())
+	return RequestUsage{InFlight: cr.inFlight, PerMinute: len(cr.sent)}
+}
+
+// begin records a request to kubeContext going out.
+func (t *Telemetry) begin(kubeContext string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cr, ok := t.contexts[kubeContext]
+	if !ok {
+		cr = &contextRequests{}
+		t.contexts[kubeContext] = cr
+	}
+	now := t.now()
+	cr.prune(now)
+	cr.inFlight++
+	cr.sent = append(cr.sent, now)
+}
+
+// end records a response (or failure) for a request begin counted.
+func (t *Telemetry) end(kubeContext string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cr, ok := t.contexts[kubeContext]; ok && cr.inFlight > 0 {
+		cr.inFlight--
+	}
+}
+
+// prune drops send times older than the rate window.
+func (cr *contextRequests) prune(now time.Time) {
+	cutoff := now.Add(-requestRateWindow)
+	i := 0
+	for i < len(cr.sent) && !cr.sent[i].After(cutoff) {
+		i++
+	}
+	cr.sent = cr.sent[i:]
+}
+
+// wrap returns a rest.Config WrapTransport that counts kubeContext's
+// requests. A request is outstanding until its response headers arrive, so
+// a watch or a following log stream counts once, not for as long as it
+// stays open.
+func (t *Telemetry) wrap(kubeContext string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &countingTransport{next: rt, telemetry: t, context: kubeContext}
+	}
+}
+
+type countingTransport struct {
+	next      http.RoundTripper
+	telemetry *Telemetry
+	context   string
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.telemetry.begin(c.context)
+	defer c.telemetry.end(c.context)
+	return c.next.RoundTrip(req)
+}
@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ContainerTermination is one container exit a pod's status reports: the
+// current state of a container that has stopped, or the last state of one
+// that has restarted since.
+type ContainerTermination struct {
+	Context    string
+	Namespace  string
+	Pod        string
+	Container  string
+	ExitCode   int32
+	Signal     int32
+	Reason     string // e.g. Error, OOMKilled, Completed
+	Message    string
+	FinishedAt time.Time
+	// Restarts is the container's restart count when the exit was seen.
+	Restarts int32
+}
+
+// ContainerTerminations returns the exits pod's container statuses
+// currently report, at most two per container (its current and last
+// state). A pod's status only ever remembers the latest exit, so a history
+// has to be built by whoever watches it.
+func ContainerTerminations(pod *v1.Pod, kubeContext string) []ContainerTermination {
+	var terms []ContainerTermination
+	for _, cs := range pod.Status.ContainerStatuses {
+		for _, t := range []*v1.ContainerStateTerminated{cs.LastTerminationState.Terminated, cs.State.Terminated} {
+			if t == nil {
+				continue
+			}
+			terms = append(terms, ContainerTermination{
+				Context:    kubeContext,
+				Namespace:  pod.Namespace,
+				Pod:        pod.Name,
+				Container:  cs.Name,
+				ExitCode:   t.ExitCode,
+				Signal:     t.Signal,
+				Reason:     t.Reason,
+				Message:    t.Message,
+				FinishedAt: t.FinishedAt.Time,
+				Restarts:   cs.RestartCount,
+			})
+		}
+	}
+	return terms
+}
+
+// PodCompletion is how a pod that ran to completion ended.
+type PodCompletion struct {
+	Phase string // Succeeded or Failed
+	// Duration runs from the pod's start to its last container's exit.
+	Duration time.Duration
+	// Exits is each container's final exit.
+	Exits []ContainerTermination
+}
+
+// CompletionOf returns how pod completed, ok false while it's still
+// pending or running.
+func CompletionOf(pod *v1.Pod, kubeContext string) (c PodCompletion, ok bool) {
+	if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		return c, false
+	}
+	c.Phase = string(pod.Status.Phase)
+	var finished time.Time
+	for _, cs := range pod.Status.ContainerStatuses {
+		t := cs.State.Terminated
+		if t == nil {
+			continue
+		}
+		c.Exits = append(c.Exits, ContainerTermination{
+			Context:    kubeContext,
+			Namespace:  pod.Namespace,
+			Pod:        pod.Name,
+			Container:  cs.Name,
+			ExitCode:   t.ExitCode,
+			Signal:     t.Signal,
+			Reason:     t.Reason,
+			Message:    t.Message,
+			FinishedAt: t.FinishedAt.Time,
+			Restarts:   cs.RestartCount,
+		})
+		if t.FinishedAt.After(finished) {
+			finished = t.FinishedAt.Time
+		}
+	}
+	if pod.Status.StartTime != nil && !finished.IsZero() {
+		c.Duration = finished.Sub(pod.Status.StartTime.Time)
+	}
+	return c, true
+}
@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ErrMetricsUnavailable is returned when a cluster doesn't serve the
+// metrics API — usually because metrics-server isn't installed.
+var ErrMetricsUnavailable = errors.New("metrics API not available (is metrics-server installed?)")
+
+// ContainerUsage is one container's current resource usage.
+type ContainerUsage struct {
+	Name        string
+	CPUMilli    int64
+	MemoryBytes int64
+}
+
+// PodUsage is a pod's current resource usage, summed over its containers,
+// as `kubectl top pod` reports it.
+type PodUsage struct {
+	Name        string
+	Namespace   string
+	Context     string
+	CPUMilli    int64
+	MemoryBytes int64
+	Containers  []ContainerUsage
+}
+
+// podMetricsList mirrors the parts of metrics.k8s.io/v1beta1 PodMetricsList
+// ktails reads. It's decoded by hand rather than through k8s.io/metrics to
+// keep the dependency for two fields.
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Containers []struct {
+			Name  string `json:"name"`
+			Usage struct {
+				CPU    resource.Quantity `json:"cpu"`
+				Memory resource.Quantity `json:"memory"`
+			} `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// GetPodUsage returns the current usage of every pod in a namespace ("" for
+// all namespaces) from the metrics API, containers sorted by name.
+func (c *Client) GetPodUsage(ctx context.Context, kubeContext, namespace string) ([]PodUsage, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	path := "/apis/metrics.k8s.io/v1beta1/pods"
+	if namespace != "" {
+		path = "/apis/metrics.k8s.io/v1beta1/namespaces/" + namespace + "/pods"
+	}
+	data, err := clientset.CoreV1().RESTClient().Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("context %s: %w", kubeContext, ErrMetricsUnavailable)
+		}
+		return nil, fmt.Errorf("failed to get pod metrics in namespace %s (context %s): %w", namespace, kubeContext, err)
+	}
+
+	usage, err := parsePodMetrics(data, kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pod metrics (context %s): %w", kubeContext, err)
+	}
+	return usage, nil
+}
+
+// parsePodMetrics decodes a PodMetricsList body into PodUsage.
+func parsePodMetrics(data []byte, kubeContext string) ([]PodUsage, error) {
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	out := make([]PodUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		pu := PodUsage{Name: item.Metadata.Name, Namespace: item.Metadata.Namespace, Context: kubeContext}
+		for _, ctr := range item.Containers {
+			cu := ContainerUsage{
+				Name:        ctr.Name,
+				CPUMilli:    ctr.Usage.CPU.MilliValue(),
+				MemoryBytes: ctr.Usage.Memory.Value(),
+			}
+			pu.CPUMilli += cu.CPUMilli
+			pu.MemoryBytes += cu.MemoryBytes
+			pu.Containers = append(pu.Containers, cu)
+		}
+		sort.Slice(pu.Containers, func(i, j int) bool { return pu.Containers[i].Name < pu.Containers[j].Name })
+		out = append(out, pu)
+	}
+	return out, nil
+}
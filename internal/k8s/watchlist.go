@@ -0,0 +1,168 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The kinds of resource the watchlist holds.
+const (
+	WatchPod        = "pod"
+	WatchDeployment = "deployment"
+)
+
+// WatchHealth sums a watched resource's status up, for coloring it.
+type WatchHealth int
+
+const (
+	WatchPending WatchHealth = iota // on its way up or down, or rolling out
+	WatchHealthy
+	WatchFailing
+	WatchMissing // not found in its context
+)
+
+// WatchStatus is the live status of a pod or deployment on the watchlist.
+type WatchStatus struct {
+	Kind      string // WatchPod or WatchDeployment
+	Context   string
+	Namespace string
+	Name      string
+
+	// Status is the pod's as kubectl shows it, or the deployment's
+	// "Available", "Progressing", "Rollout stalled" or "Unavailable"; "Not
+	// found" once it's gone.
+	Status string
+	Health WatchHealth
+	Ready  string // containers ready, "1/2", or a deployment's replicas
+	// Restarts are the pod's containers', or those of all the deployment's
+	// pods.
+	Restarts int32
+	Age      string
+
+	// Pods are what attaching to its logs tails: the pod itself, or the
+	// deployment's pods.
+	Pods []WatchedPod
+}
+
+// WatchedPod is a pod a watched resource's logs come from.
+type WatchedPod struct {
+	Name       string
+	Containers []string
+}
+
+// GetWatchStatus returns the live status of a watched pod or deployment.
+// One that doesn't exist (any more) isn't an error: its status says so.
+func (c *Client) GetWatchStatus(ctx context.Context, kubeContext, kind, namespace, name string) (WatchStatus, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return WatchStatus{}, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	w := WatchStatus{Kind: kind, Context: kubeContext, Namespace: namespace, Name: name}
+	switch kind {
+	case WatchPod:
+		err = podWatchStatus(ctx, clientset, &w)
+	case WatchDeployment:
+		err = deploymentWatchStatus(ctx, clientset, &w)
+	default:
+		return WatchStatus{}, fmt.Errorf("unknown watchlist kind %q", kind)
+	}
+	if apierrors.IsNotFound(err) {
+		w.Status, w.Health = "Not found", WatchMissing
+		return w, nil
+	}
+	if err != nil {
+		return WatchStatus{}, fmt.Errorf("failed to get %s %s in namespace %s (context %s): %w", kind, name, namespace, kubeContext, err)
+	}
+	return w, nil
+}
+
+func podWatchStatus(ctx context.Context, clientset kubernetes.Interface, w *WatchStatus) error {
+	pod, err := clientset.CoreV1().Pods(w.Namespace).Get(ctx, w.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	info := PodToPodInfo(pod, w.Context)
+	w.Status, w.Ready, w.Restarts, w.Age = info.Status, info.ReadyContainers, info.Restarts, info.Age
+	w.Pods = []WatchedPod{{Name: pod.Name, Containers: info.Containers}}
+	w.Health = podHealth(pod, info.Status)
+	return nil
+}
+
+// podHealth is healthy for a running pod with every container ready, or
+// one that has completed.
+func podHealth(pod *v1.Pod, status string) WatchHealth {
+	switch {
+	case PodStatusFailing(status):
+		return WatchFailing
+	case pod.Status.Phase == v1.PodSucceeded:
+		return WatchHealthy
+	case pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil:
+		return WatchPending
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return WatchPending
+		}
+	}
+	return WatchHealthy
+}
+
+func deploymentWatchStatus(ctx context.Context, clientset kubernetes.Interface, w *WatchStatus) error {
+	deployment, err := clientset.AppsV1().Deployments(w.Namespace).Get(ctx, w.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	info := DeploymentToDeploymentInfo(deployment)
+	w.Ready = fmt.Sprintf("%d/%d", info.ReadyReplicas, info.DesiredReplicas)
+	w.Age = info.Age
+	w.Status, w.Health = deploymentHealth(deployment, info)
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector on deployment %s: %w", w.Name, err)
+	}
+	pods, err := clientset.CoreV1().Pods(w.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := PodToPodInfo(&pods.Items[i], w.Context)
+		w.Restarts += pod.Restarts
+		w.Pods = append(w.Pods, WatchedPod{Name: pod.Name, Containers: pod.Containers})
+	}
+	return nil
+}
+
+// deploymentHealth words a deployment's state: available once every
+// desired replica is updated and ready, progressing while a rollout is
+// still replacing them, else unavailable.
+func deploymentHealth(d *appsv1.Deployment, info DeploymentInfo) (string, WatchHealth) {
+	switch {
+	case info.UpdatedReplicas >= info.DesiredReplicas && info.ReadyReplicas >= info.DesiredReplicas:
+		return "Available", WatchHealthy
+	case d.Generation > d.Status.ObservedGeneration || info.UpdatedReplicas < info.DesiredReplicas:
+		if rolloutStalled(d) {
+			return "Rollout stalled", WatchFailing
+		}
+		return "Progressing", WatchPending
+	}
+	return "Unavailable", WatchFailing
+}
+
+// rolloutStalled reports whether a deployment's rollout passed its
+// progress deadline.
+func rolloutStalled(d *appsv1.Deployment) bool {
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/yaml"
+)
+
+// Roll-up statuses for StatefulSets and DaemonSets — one word summarizing
+// where the controller is relative to its spec, for the Status column.
+const (
+	WorkloadHealthy      = "Healthy"
+	WorkloadUpdating     = "Updating"
+	WorkloadDegraded     = "Degraded"
+	WorkloadScaledToZero = "ScaledToZero"
+	WorkloadNoNodes      = "NoNodes"
+	WorkloadMisscheduled = "Misscheduled"
+)
+
+type StatefulSetInfo struct {
+	Name            string
+	Namespace       string
+	Age             string
+	ReadyReplicas   int32
+	DesiredReplicas int32
+	CurrentReplicas int32
+	UpdatedReplicas int32
+	UpdateStrategy  string
+	ServiceName     string
+	Selector        string
+	Status          string
+}
+
+type DaemonSetInfo struct {
+	Name           string
+	Namespace      string
+	Age            string
+	Ready          int32
+	Desired        int32
+	Available      int32
+	Updated        int32
+	Misscheduled   int32
+	UpdateStrategy string
+	NodeSelector   string
+	Selector       string
+	Status         string
+}
+
+// GetStatefulSetInfo retrieves statefulset information for a specific context and namespace
+func (c *Client) GetStatefulSetInfo(ctx context.Context, kubeContextName, namespace string) ([]StatefulSetInfo, error) {
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	list, err := withRetry(ctx, c, kubeContextName, func() (*appsv1.StatefulSetList, error) {
+		return clientset.AppsV1().StatefulSets(namespace).List(ctx, v1.ListOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets in namespace %s (context %s): %w",
+			namespace, kubeContextName, err)
+	}
+
+	infos := make([]StatefulSetInfo, 0, len(list.Items))
+	for i := range list.Items {
+		infos = append(infos, StatefulSetToStatefulSetInfo(&list.Items[i]))
+	}
+	return infos, nil
+}
+
+// StatefulSetToStatefulSetInfo converts a statefulset object to StatefulSetInfo.
+func StatefulSetToStatefulSetInfo(sts *appsv1.StatefulSet) StatefulSetInfo {
+	// Spec.Replicas is nil when unset, which the Kubernetes API defaults to 1.
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+
+	return StatefulSetInfo{
+		Name:            sts.Name,
+		Namespace:       sts.Namespace,
+		Age:             formatDuration(time.Since(sts.CreationTimestamp.Time)),
+		ReadyReplicas:   sts.Status.ReadyReplicas,
+		DesiredReplicas: desired,
+		CurrentReplicas: sts.Status.CurrentReplicas,
+		UpdatedReplicas: sts.Status.UpdatedReplicas,
+		UpdateStrategy:  string(sts.Spec.UpdateStrategy.Type),
+		ServiceName:     sts.Spec.ServiceName,
+		Selector:        v1.FormatLabelSelector(sts.Spec.Selector),
+		Status:          statefulSetStatus(sts, desired),
+	}
+}
+
+// statefulSetStatus rolls a statefulset's status up into one word. A
+// rollout in progress (spec not yet observed, or pods still on the old
+// revision) reads as Updating rather than Degraded, since not-ready pods are
+// expected mid-rollout.
+func statefulSetStatus(sts *appsv1.StatefulSet, desired int32) string {
+	switch {
+	case desired == 0:
+		return WorkloadScaledToZero
+	case sts.Status.ObservedGeneration < sts.Generation,
+		sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision,
+		sts.Status.UpdatedReplicas < desired:
+		return WorkloadUpdating
+	case sts.Status.ReadyReplicas < desired:
+		return WorkloadDegraded
+	default:
+		return WorkloadHealthy
+	}
+}
+
+// GetDaemonSetInfo retrieves daemonset information for a specific context and namespace
+func (c *Client) GetDaemonSetInfo(ctx context.Context, kubeContextName, namespace string) ([]DaemonSetInfo, error) {
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	list, err := withRetry(ctx, c, kubeContextName, func() (*appsv1.DaemonSetList, error) {
+		return clientset.AppsV1().DaemonSets(namespace).List(ctx, v1.ListOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets in namespace %s (context %s): %w",
+			namespace, kubeContextName, err)
+	}
+
+	infos := make([]DaemonSetInfo, 0, len(list.Items))
+	for i := range list.Items {
+		infos = append(infos, DaemonSetToDaemonSetInfo(&list.Items[i]))
+	}
+	return infos, nil
+}
+
+// DaemonSetToDaemonSetInfo converts a daemonset object to DaemonSetInfo.
+func DaemonSetToDaemonSetInfo(ds *appsv1.DaemonSet) DaemonSetInfo {
+	var nodeSelector string
+	if len(ds.Spec.Template.Spec.NodeSelector) > 0 {
+		nodeSelector = v1.FormatLabelSelector(&v1.LabelSelector{MatchLabels: ds.Spec.Template.Spec.NodeSelector})
+	}
+
+	return DaemonSetInfo{
+		Name:           ds.Name,
+		Namespace:      ds.Namespace,
+		Age:            formatDuration(time.Since(ds.CreationTimestamp.Time)),
+		Ready:          ds.Status.NumberReady,
+		Desired:        ds.Status.DesiredNumberScheduled,
+		Available:      ds.Status.NumberAvailable,
+		Updated:        ds.Status.UpdatedNumberScheduled,
+		Misscheduled:   ds.Status.NumberMisscheduled,
+		UpdateStrategy: string(ds.Spec.UpdateStrategy.Type),
+		NodeSelector:   nodeSelector,
+		Selector:       v1.FormatLabelSelector(ds.Spec.Selector),
+		Status:         daemonSetStatus(ds),
+	}
+}
+
+// daemonSetStatus rolls a daemonset's status up into one word; see
+// statefulSetStatus. Desired is however many nodes the scheduler matched,
+// so zero means no node fits rather than a deliberate scale-down.
+func daemonSetStatus(ds *appsv1.DaemonSet) string {
+	st := ds.Status
+	switch {
+	case st.DesiredNumberScheduled == 0:
+		return WorkloadNoNodes
+	case st.ObservedGeneration < ds.Generation, st.UpdatedNumberScheduled < st.DesiredNumberScheduled:
+		return WorkloadUpdating
+	case st.NumberReady < st.DesiredNumberScheduled:
+		return WorkloadDegraded
+	case st.NumberMisscheduled > 0:
+		return WorkloadMisscheduled
+	default:
+		return WorkloadHealthy
+	}
+}
+
+// WatchStatefulSets opens a watch on statefulsets in the given namespace.
+// See WatchPods for the implicit list-then-watch behavior.
+func (c *Client) WatchStatefulSets(ctx context.Context, kubeContext, namespace string, opts v1.ListOptions) (watch.Interface, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	if err := c.checkAccess(ctx, kubeContext, namespace, ListStatefulSets); err != nil {
+		return nil, err
+	}
+	w, err := withRetry(ctx, c, kubeContext, func() (watch.Interface, error) {
+		return clientset.AppsV1().StatefulSets(namespace).Watch(ctx, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch statefulsets in namespace %s (context %s): %w", namespace, kubeContext, err)
+	}
+	return w, nil
+}
+
+// WatchDaemonSets opens a watch on daemonsets in the given namespace. See
+// WatchPods for the implicit list-then-watch behavior.
+func (c *Client) WatchDaemonSets(ctx context.Context, kubeContext, namespace string, opts v1.ListOptions) (watch.Interface, error) {
+	clientset, err := c.GetClientForContext(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context %s: %w", kubeContext, err)
+	}
+
+	if err := c.checkAccess(ctx, kubeContext, namespace, ListDaemonSets); err != nil {
+		return nil, err
+	}
+	w, err := withRetry(ctx, c, kubeContext, func() (watch.Interface, error) {
+		return clientset.AppsV1().DaemonSets(namespace).Watch(ctx, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch daemonsets in namespace %s (context %s): %w", namespace, kubeContext, err)
+	}
+	return w, nil
+}
+
+// GetStatefulSetDetail fetches a single statefulset's status, rendered YAML, and recent events.
+func (c *Client) GetStatefulSetDetail(ctx context.Context, kubeContextName, namespace, name string) (ResourceDetail, error) {
+	d := ResourceDetail{Kind: "StatefulSet"}
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return d, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	sts, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return d, fmt.Errorf("failed to get statefulset %s in namespace %s (context %s): %w",
+			name, namespace, kubeContextName, err)
+	}
+
+	info := StatefulSetToStatefulSetInfo(sts)
+	d.Name = sts.Name
+	d.Namespace = sts.Namespace
+	d.Age = info.Age
+	d.Summary = fmt.Sprintf("Ready: %d/%d  Status: %s", info.ReadyReplicas, info.DesiredReplicas, info.Status)
+	d.Status = append(d.Status,
+		fmt.Sprintf("Replicas: %d desired | %d ready | %d current | %d updated",
+			info.DesiredReplicas, info.ReadyReplicas, info.CurrentReplicas, info.UpdatedReplicas),
+		fmt.Sprintf("Revision: current %s, update %s", sts.Status.CurrentRevision, sts.Status.UpdateRevision),
+	)
+	for _, condition := range sts.Status.Conditions {
+		d.Status = append(d.Status, formatCondition(string(condition.Type), string(condition.Status), condition.Reason, condition.Message))
+	}
+
+	sts.ManagedFields = nil
+	sts.TypeMeta = v1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"}
+	c.applyHealth(&d, sts)
+	if yamlBytes, yamlErr := yaml.Marshal(sts); yamlErr == nil {
+		d.YAML = string(yamlBytes)
+	} else {
+		d.YAML = fmt.Sprintf("failed to render YAML: %v", yamlErr)
+	}
+
+	if events, err := c.getEvents(ctx, kubeContextName, namespace, "StatefulSet", name); err == nil {
+		d.Events = events
+	}
+
+	return d, nil
+}
+
+// GetDaemonSetDetail fetches a single daemonset's status, rendered YAML, and recent events.
+func (c *Client) GetDaemonSetDetail(ctx context.Context, kubeContextName, namespace, name string) (ResourceDetail, error) {
+	d := ResourceDetail{Kind: "DaemonSet"}
+	clientset, err := c.GetClientForContext(kubeContextName)
+	if err != nil {
+		return d, fmt.Errorf("failed to get client for context %s: %w", kubeContextName, err)
+	}
+
+	ds, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return d, fmt.Errorf("failed to get daemonset %s in namespace %s (context %s): %w",
+			name, namespace, kubeContextName, err)
+	}
+
+	info := DaemonSetToDaemonSetInfo(ds)
+	d.Name = ds.Name
+	d.Namespace = ds.Namespace
+	d.Age = info.Age
+	d.Summary = fmt.Sprintf("Ready: %d/%d  Status: %s", info.Ready, info.Desired, info.Status)
+	d.Status = append(d.Status,
+		fmt.Sprintf("Nodes: %d desired | %d ready | %d available | %d updated | %d misscheduled",
+			info.Desired, info.Ready, info.Available, info.Updated, info.Misscheduled),
+	)
+	if info.NodeSelector != "" {
+		d.Status = append(d.Status, "Node selector: "+info.NodeSelector)
+	}
+	for _, condition := range ds.Status.Conditions {
+		d.Status = append(d.Status, formatCondition(string(condition.Type), string(condition.Status), condition.Reason, condition.Message))
+	}
+
+	ds.ManagedFields = nil
+	ds.TypeMeta = v1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"}
+	c.applyHealth(&d, ds)
+	if yamlBytes, yamlErr := yaml.Marshal(ds); yamlErr == nil {
+		d.YAML = string(yamlBytes)
+	} else {
+		d.YAML = fmt.Sprintf("failed to render YAML: %v", yamlErr)
+	}
+
+	if events, err := c.getEvents(ctx, kubeContextName, namespace, "DaemonSet", name); err == nil {
+		d.Events = events
+	}
+
+	return d, nil
+}
@@ -0,0 +1,115 @@
+package logfmt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Level is a log line's severity. LevelUnknown sorts below every real level,
+// so "at or above" comparisons treat unleveled lines as the least severe.
+type Level int
+
+const (
+	LevelUnknown Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel maps a level value as loggers spell it ("WARNING", "err",
+// "Fatal", "trace", ...) onto a Level.
+func ParseLevel(s string) Level {
+	switch strings.ToLower(strings.Trim(s, "[]():")) {
+	case "trace", "debug", "dbg":
+		return LevelDebug
+	case "info", "inf", "notice":
+		return LevelInfo
+	case "warn", "warning", "wrn":
+		return LevelWarn
+	case "error", "err", "fatal", "panic", "crit", "critical", "alert", "emerg":
+		return LevelError
+	}
+	return LevelUnknown
+}
+
+// DetectLevel finds a line's severity. Structured lines use their level
+// field. Plain lines are scanned for the first upper-case level word
+// ("ERROR", "[WARN]", "INFO:") or a klog header ("E0719 12:00:00.000 ...");
+// start/end is that token's byte span, for highlighting it in place, and
+// -1/-1 when the level came from a structured field.
+func DetectLevel(line string) (level Level, start, end int) {
+	if e, ok := Parse(line); ok {
+		if v, ok := e.Lookup("level"); ok {
+			return ParseLevel(v), -1, -1
+		}
+		return LevelUnknown, -1, -1
+	}
+
+	if lvl := klogLevel(line); lvl != LevelUnknown {
+		return lvl, 0, 1
+	}
+
+	i := 0
+	for i < len(line) {
+		// Advance to the start of the next word.
+		for i < len(line) && !isWordByte(line[i]) {
+			i++
+		}
+		j := i
+		for j < len(line) && isWordByte(line[j]) {
+			j++
+		}
+		if j > i {
+			word := line[i:j]
+			if word == strings.ToUpper(word) {
+				if lvl := ParseLevel(word); lvl != LevelUnknown {
+					return lvl, i, j
+				}
+			}
+		}
+		i = j
+	}
+	return LevelUnknown, -1, -1
+}
+
+// klogLevel recognizes the Kubernetes klog header "Lmmdd hh:mm:ss.uuuuuu",
+// whose leading letter is the severity.
+func klogLevel(line string) Level {
+	if len(line) < 6 || line[5] != ' ' {
+		return LevelUnknown
+	}
+	for _, r := range line[1:5] {
+		if !unicode.IsDigit(r) {
+			return LevelUnknown
+		}
+	}
+	switch line[0] {
+	case 'I':
+		return LevelInfo
+	case 'W':
+		return LevelWarn
+	case 'E', 'F':
+		return LevelError
+	}
+	return LevelUnknown
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,207 @@
+// Package logfmt detects structured log lines — JSON objects and
+// logfmt-style key=value pairs — and parses them into an ordered field list
+// the Log pane can render as columns or expand into a full payload view.
+package logfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Format is the structured encoding a line was recognized as.
+type Format int
+
+const (
+	FormatPlain Format = iota
+	FormatJSON
+	FormatLogfmt
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatLogfmt:
+		return "logfmt"
+	default:
+		return "plain"
+	}
+}
+
+// Field is one key/value pair, in the order it appeared on the line. Nested
+// JSON values are kept as their compact JSON text.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// Entry is a parsed structured line. Prefix holds any unstructured text
+// ahead of an embedded JSON object (e.g. a "2026-07-19 INFO " prefix).
+type Entry struct {
+	Format Format
+	Prefix string
+	Fields []Field
+}
+
+// aliases maps the canonical field names used in config (level, msg,
+// timestamp) to the spellings common logging libraries actually emit.
+var aliases = map[string][]string{
+	"level":     {"level", "lvl", "severity", "log.level", "loglevel"},
+	"msg":       {"msg", "message", "log", "text"},
+	"timestamp": {"timestamp", "ts", "time", "@timestamp", "t"},
+}
+
+// DefaultFields is the column set shown when config doesn't choose one.
+var DefaultFields = []string{"timestamp", "level", "msg"}
+
+// Parse recognizes line as JSON or logfmt. ok is false for plain text.
+func Parse(line string) (Entry, bool) {
+	if e, ok := parseJSON(line); ok {
+		return e, true
+	}
+	if e, ok := parseLogfmt(line); ok {
+		return e, true
+	}
+	return Entry{}, false
+}
+
+// Lookup returns the value of a canonical field (see aliases) or any
+// literal key, matched case-insensitively.
+func (e Entry) Lookup(name string) (string, bool) {
+	candidates := aliases[strings.ToLower(name)]
+	if len(candidates) == 0 {
+		candidates = []string{name}
+	}
+	for _, c := range candidates {
+		for _, f := range e.Fields {
+			if strings.EqualFold(f.Key, c) {
+				return f.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseJSON accepts a line whose text from the first '{' onward is a single
+// valid JSON object, decoding it token by token so key order survives.
+func parseJSON(line string) (Entry, bool) {
+	idx := strings.IndexByte(line, '{')
+	if idx < 0 {
+		return Entry{}, false
+	}
+	body := strings.TrimSpace(line[idx:])
+	if !json.Valid([]byte(body)) {
+		return Entry{}, false
+	}
+
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return Entry{}, false
+	}
+
+	e := Entry{Format: FormatJSON, Prefix: line[:idx]}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return Entry{}, false
+		}
+		k, ok := tok.(string)
+		if !ok {
+			return Entry{}, false
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return Entry{}, false
+		}
+		e.Fields = append(e.Fields, Field{Key: k, Value: jsonValueString(raw)})
+	}
+	return e, true
+}
+
+// jsonValueString renders a raw JSON value for display: strings unquoted,
+// everything else as compact JSON text.
+func jsonValueString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err == nil {
+		return buf.String()
+	}
+	return string(raw)
+}
+
+// parseLogfmt accepts a line made up entirely of key=value pairs (values
+// optionally double-quoted), requiring at least two pairs so ordinary prose
+// containing a lone "=" isn't misdetected.
+func parseLogfmt(line string) (Entry, bool) {
+	s := strings.TrimSpace(line)
+	e := Entry{Format: FormatLogfmt}
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return Entry{}, false
+		}
+		k := s[:eq]
+		if !validKey(k) {
+			return Entry{}, false
+		}
+		s = s[eq+1:]
+
+		var v string
+		if strings.HasPrefix(s, `"`) {
+			end := closingQuote(s)
+			if end < 0 {
+				return Entry{}, false
+			}
+			unq, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return Entry{}, false
+			}
+			v, s = unq, s[end+1:]
+			if s != "" && s[0] != ' ' {
+				return Entry{}, false
+			}
+		} else if sp := strings.IndexByte(s, ' '); sp >= 0 {
+			v, s = s[:sp], s[sp:]
+		} else {
+			v, s = s, ""
+		}
+		e.Fields = append(e.Fields, Field{Key: k, Value: v})
+		s = strings.TrimLeft(s, " ")
+	}
+	if len(e.Fields) < 2 {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// closingQuote returns the index of the quote closing the string opening at
+// s[0], honoring backslash escapes, or -1 if unterminated.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func validKey(k string) bool {
+	for _, r := range k {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_' || r == '.' || r == '-' || r == '@' || r == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
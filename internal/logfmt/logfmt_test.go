@@ -0,0 +1,84 @@
+package logfmt
+
+import "testing"
+
+func TestParse_JSONPreservesKeyOrderAndPrefix(t *testing.T) {
+	e, ok := Parse(`2026-07-19 INFO {"ts":"2026-07-19T00:00:00Z","level":"info","msg":"hello","attrs":{"a":1}}`)
+	if !ok || e.Format != FormatJSON {
+		t.Fatalf("expected JSON entry, got ok=%v format=%v", ok, e.Format)
+	}
+	if e.Prefix != "2026-07-19 INFO " {
+		t.Fatalf("unexpected prefix %q", e.Prefix)
+	}
+	wantKeys := []string{"ts", "level", "msg", "attrs"}
+	if len(e.Fields) != len(wantKeys) {
+		t.Fatalf("expected %d fields, got %+v", len(wantKeys), e.Fields)
+	}
+	for i, k := range wantKeys {
+		if e.Fields[i].Key != k {
+			t.Fatalf("field %d: want key %q, got %q", i, k, e.Fields[i].Key)
+		}
+	}
+	if v, _ := e.Lookup("attrs"); v != `{"a":1}` {
+		t.Fatalf("nested value should stay compact JSON, got %q", v)
+	}
+}
+
+func TestParse_Logfmt(t *testing.T) {
+	e, ok := Parse(`time=2026-07-19T00:00:00Z lvl=warn msg="disk almost \"full\"" pct=91`)
+	if !ok || e.Format != FormatLogfmt {
+		t.Fatalf("expected logfmt entry, got ok=%v format=%v", ok, e.Format)
+	}
+	if v, _ := e.Lookup("level"); v != "warn" {
+		t.Fatalf("level alias lookup: got %q", v)
+	}
+	if v, _ := e.Lookup("msg"); v != `disk almost "full"` {
+		t.Fatalf("quoted value: got %q", v)
+	}
+	if v, _ := e.Lookup("timestamp"); v != "2026-07-19T00:00:00Z" {
+		t.Fatalf("timestamp alias lookup: got %q", v)
+	}
+}
+
+func TestParse_PlainTextIsNotStructured(t *testing.T) {
+	for _, line := range []string{
+		"GET /healthz 200",
+		"retrying with x=1",
+		`{"broken": `,
+		"",
+	} {
+		if _, ok := Parse(line); ok {
+			t.Fatalf("expected %q to stay plain", line)
+		}
+	}
+}
+
+func TestDetectLevel(t *testing.T) {
+	cases := []struct {
+		line  string
+		want  Level
+		token string
+	}{
+		{"2026-07-19 12:00:00 ERROR failed to connect", LevelError, "ERROR"},
+		{"[WARN] disk almost full", LevelWarn, "WARN"},
+		{"E0719 12:00:00.000000       1 reflector.go:123] watch failed", LevelError, "E"},
+		{`{"level":"debug","msg":"tick"}`, LevelDebug, ""},
+		{"time=now lvl=info msg=ok", LevelInfo, ""},
+		{"an error happened in lowercase prose", LevelUnknown, ""},
+	}
+	for _, c := range cases {
+		got, start, end := DetectLevel(c.line)
+		if got != c.want {
+			t.Fatalf("%q: want level %v, got %v", c.line, c.want, got)
+		}
+		if c.token == "" {
+			if start != -1 {
+				t.Fatalf("%q: expected no token span, got %d:%d", c.line, start, end)
+			}
+			continue
+		}
+		if c.line[start:end] != c.token {
+			t.Fatalf("%q: want token %q, got %q", c.line, c.token, c.line[start:end])
+		}
+	}
+}
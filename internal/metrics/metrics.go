@@ -0,0 +1,247 @@
+// Package metrics reads a pod's Prometheus text exposition — what its
+// /metrics endpoint answers — and boils it down to the few series worth a
+// glance from the Pods tab: how many HTTP requests it served and how many
+// failed, and its process's CPU, memory, goroutines and file descriptors.
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sample is one series' value in a scrape.
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Parse reads the text exposition format: "name{label="value",...} value
+// [timestamp]" lines, "#" lines (HELP, TYPE) skipped. A malformed line is
+// an error naming its line number.
+func Parse(text []byte) ([]Sample, error) {
+	var samples []Sample
+	sc := bufio.NewScanner(bytes.NewReader(text))
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		s, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		samples = append(samples, s)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+	return samples, nil
+}
+
+func parseLine(line string) (Sample, error) {
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return Sample{}, fmt.Errorf("no value after %q", line)
+	}
+	s := Sample{Name: line[:end]}
+	rest := line[end:]
+	if rest[0] == '{' {
+		labels, after, err := parseLabels(rest[1:])
+		if err != nil {
+			return Sample{}, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		s.Labels, rest = labels, after
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Sample{}, fmt.Errorf("%s: want a value and an optional timestamp, got %q", s.Name, strings.TrimSpace(rest))
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("%s: invalid value %q", s.Name, fields[0])
+	}
+	s.Value = v
+	return s, nil
+}
+
+// parseLabels reads `name="value",...}` — the part of a series after its
+// "{" — returning the labels and what follows the "}".
+func parseLabels(text string) (map[string]string, string, error) {
+	labels := make(map[string]string)
+	for {
+		text = strings.TrimLeft(text, " \t")
+		if strings.HasPrefix(text, "}") {
+			return labels, text[1:], nil
+		}
+		name, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, "", fmt.Errorf("unterminated labels")
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimLeft(value, " \t")
+		if !strings.HasPrefix(value, `"`) {
+			return nil, "", fmt.Errorf("label %s: value isn't quoted", name)
+		}
+		var b strings.Builder
+		i := 1
+		for ; i < len(value) && value[i] != '"'; i++ {
+			if value[i] == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				default:
+					b.WriteByte(value[i])
+				}
+				continue
+			}
+			b.WriteByte(value[i])
+		}
+		if i >= len(value) {
+			return nil, "", fmt.Errorf("label %s: unterminated value", name)
+		}
+		labels[name] = b.String()
+		text = strings.TrimLeft(value[i+1:], " \t")
+		text = strings.TrimPrefix(text, ",")
+	}
+}
+
+// statusLabels are the labels request counters carry the response status
+// in, across client libraries: Prometheus' own, Micrometer's and
+// OpenTelemetry's.
+var statusLabels = []string{"code", "status", "status_code", "response_code", "http_status_code", "http_response_status_code"}
+
+// Peek is what a scrape says at a glance. The process figures are NaN when
+// the app doesn't export them; HTTP is "" when it has no request counter
+// with a status label.
+type Peek struct {
+	At     time.Time
+	Series int
+
+	// HTTP is the request counter read, e.g. "http_requests_total";
+	// Requests counts every request in it, Errors4xx and Errors5xx those
+	// answered 4xx and 5xx.
+	HTTP      string
+	Requests  float64
+	Errors4xx float64
+	Errors5xx float64
+
+	CPUSeconds    float64 // process_cpu_seconds_total
+	ResidentBytes float64 // process_resident_memory_bytes
+	Goroutines    float64 // go_goroutines
+	OpenFDs       float64 // process_open_fds
+	StartTime     float64 // process_start_time_seconds, Unix seconds
+}
+
+// Summarize picks a Peek out of a scrape taken at at. Of several request
+// counters, a server's is preferred over a client's, then the one that
+// counted the most.
+func Summarize(samples []Sample, at time.Time) Peek {
+	p := Peek{
+		At:            at,
+		Series:        len(samples),
+		CPUSeconds:    math.NaN(),
+		ResidentBytes: math.NaN(),
+		Goroutines:    math.NaN(),
+		OpenFDs:       math.NaN(),
+		StartTime:     math.NaN(),
+	}
+	type counter struct{ total, e4, e5 float64 }
+	counters := make(map[string]*counter)
+	for _, s := range samples {
+		switch s.Name {
+		case "process_cpu_seconds_total":
+			p.CPUSeconds = s.Value
+		case "process_resident_memory_bytes":
+			p.ResidentBytes = s.Value
+		case "go_goroutines":
+			p.Goroutines = s.Value
+		case "process_open_fds":
+			p.OpenFDs = s.Value
+		case "process_start_time_seconds":
+			p.StartTime = s.Value
+		}
+		if !isRequestCounter(s.Name) {
+			continue
+		}
+		status, ok := statusOf(s.Labels)
+		if !ok {
+			continue
+		}
+		c := counters[s.Name]
+		if c == nil {
+			c = &counter{}
+			counters[s.Name] = c
+		}
+		c.total += s.Value
+		switch {
+		case strings.HasPrefix(status, "4"):
+			c.e4 += s.Value
+		case strings.HasPrefix(status, "5"):
+			c.e5 += s.Value
+		}
+	}
+	for name, c := range counters {
+		better := p.HTTP == "" ||
+			isClient(p.HTTP) && !isClient(name) ||
+			isClient(p.HTTP) == isClient(name) && (c.total > p.Requests || c.total == p.Requests && name < p.HTTP)
+		if better {
+			p.HTTP, p.Requests, p.Errors4xx, p.Errors5xx = name, c.total, c.e4, c.e5
+		}
+	}
+	return p
+}
+
+// isRequestCounter reports whether name looks like a count of HTTP
+// requests: "http" in it, and a counter's or a histogram's count suffix.
+func isRequestCounter(name string) bool {
+	return strings.Contains(name, "http") && (strings.HasSuffix(name, "_total") || strings.HasSuffix(name, "_count"))
+}
+
+func isClient(name string) bool {
+	return strings.Contains(name, "client")
+}
+
+// statusOf is a series' response status, from the first of statusLabels
+// it carries.
+func statusOf(labels map[string]string) (string, bool) {
+	for _, l := range statusLabels {
+		if v, ok := labels[l]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Rates are per-second rates between two scrapes of the same pod.
+type Rates struct {
+	Requests  float64
+	Errors5xx float64
+	CPU       float64 // cores busy
+}
+
+// RatesSince works out p's rates since prev. ok is false without an
+// earlier scrape to compare with, or when a counter went backwards — the
+// process restarted in between.
+func (p Peek) RatesSince(prev Peek) (r Rates, ok bool) {
+	elapsed := p.At.Sub(prev.At).Seconds()
+	if prev.At.IsZero() || elapsed <= 0 || p.Requests < prev.Requests || p.CPUSeconds < prev.CPUSeconds {
+		return Rates{}, false
+	}
+	r.CPU = math.NaN()
+	if !math.IsNaN(p.CPUSeconds) && !math.IsNaN(prev.CPUSeconds) {
+		r.CPU = (p.CPUSeconds - prev.CPUSeconds) / elapsed
+	}
+	if p.HTTP != "" && p.HTTP == prev.HTTP {
+		r.Requests = (p.Requests - prev.Requests) / elapsed
+		r.Errors5xx = (p.Errors5xx - prev.Errors5xx) / elapsed
+	}
+	return r, true
+}
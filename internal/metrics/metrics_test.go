@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const scrape = `# HELP http_requests_total Requests served.
+# TYPE http_requests_total counter
+http_requests_total{code="200",path="/api"} 90
+http_requests_total{code="404",path="/api"} 6
+http_requests_total{code="503",path="/a\"b,c}"} 4 1700000000000
+http_client_requests_total{code="200"} 5000
+process_cpu_seconds_total 12.5
+process_resident_memory_bytes 5.24288e+07
+go_goroutines 42
+`
+
+func TestSummarize_PrefersTheServerCounter(t *testing.T) {
+	samples, err := Parse([]byte(scrape))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := samples[2].Labels["path"]; got != `/a"b,c}` {
+		t.Errorf("escaped label = %q", got)
+	}
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := Summarize(samples, at)
+	if p.HTTP != "http_requests_total" || p.Requests != 100 || p.Errors4xx != 6 || p.Errors5xx != 4 {
+		t.Errorf("HTTP = %s %v/%v/%v, want http_requests_total 100/6/4", p.HTTP, p.Requests, p.Errors4xx, p.Errors5xx)
+	}
+	if p.Series != 7 || p.Goroutines != 42 || p.ResidentBytes != 52428800 || !math.IsNaN(p.OpenFDs) {
+		t.Errorf("unexpected peek %+v", p)
+	}
+
+	later, _ := Parse([]byte(strings.NewReplacer(`"503",path="/a\"b,c}"} 4`, `"503",path="/a\"b,c}"} 14`, "12.5", "17.5").Replace(scrape)))
+	r, ok := Summarize(later, at.Add(10*time.Second)).RatesSince(p)
+	if !ok || r.Requests != 1 || r.Errors5xx != 1 || r.CPU != 0.5 {
+		t.Errorf("RatesSince = %+v (ok=%v), want 1 req/s, 1 5xx/s, 0.5 cores", r, ok)
+	}
+	if _, ok := p.RatesSince(Peek{}); ok {
+		t.Error("expected no rates without an earlier scrape")
+	}
+}
+
+func TestParse_ReportsTheBadLine(t *testing.T) {
+	_, err := Parse([]byte("up 1\nhttp_requests_total{code=200} 3\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2: http_requests_total: label code: value isn't quoted") {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package pages
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// syncAccents gives each selected context its accent (see
+// styles.ContextAccents) and hands them to every view that shows one:
+// the contexts pane and its status-bar health summary, and the tables'
+// row bars. Views are only touched when the selection changed.
+func (m *MainPage) syncAccents(snapshot state.Snapshot) {
+	contexts := slices.Sorted(maps.Keys(snapshot.SelectedContexts))
+	key := strings.Join(contexts, "\x00")
+	if key == m.accentsFor {
+		return
+	}
+	m.accentsFor = key
+	m.accents = styles.ContextAccents(contexts)
+	m.contextList.SetAccents(m.accents)
+	m.podList.SetAccents(m.accents)
+	m.deploymentList.SetAccents(m.accents)
+	m.svcList.SetAccents(m.accents)
+	m.stsList.SetAccents(m.accents)
+	m.dsList.SetAccents(m.accents)
+}
+
+// paneBorderStyle is the bottom pane's divider style: in the accent of the
+// context it shows, when that's a single one, else the plain border color.
+func (m *MainPage) paneBorderStyle() lipgloss.Style {
+	if contexts := m.bottomPaneContexts(); len(contexts) == 1 {
+		if accent, ok := m.accents[contexts[0]]; ok {
+			return lipgloss.NewStyle().Foreground(accent)
+		}
+	}
+	return m.theme.Overlay0
+}
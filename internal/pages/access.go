@@ -0,0 +1,92 @@
+package pages
+
+import (
+	"errors"
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/keys"
+)
+
+// podActionAccess is what each pod action needs RBAC to allow.
+var podActionAccess = []k8s.Access{k8s.DeletePods, k8s.ExecPods, k8s.ForwardPods, k8s.RestartDeployments}
+
+// SetAccessChecks turns on access reviews (access_checks in the config):
+// listing is checked first (see k8s.Client.SetAccessChecks), and each
+// selected context's pod actions are reviewed up front so the ones RBAC
+// forbids can be disabled.
+func (m *MainPage) SetAccessChecks(enabled bool) {
+	m.accessChecks = enabled
+	m.Client.SetAccessChecks(enabled)
+}
+
+// checkAccessCmd reviews the pod actions for a newly loaded context's
+// namespace; nil with access checks off.
+func (m *MainPage) checkAccessCmd(context, namespace string) tea.Cmd {
+	if !m.accessChecks {
+		return nil
+	}
+	return cmds.CheckAccessCmd(m.callCtx(context), m.Client, context, namespace, podActionAccess)
+}
+
+// denied reports whether the last review of access in a context's
+// namespace refused it, with access checks on; an unreviewed action is
+// let through.
+func (m *MainPage) denied(context, namespace string, access k8s.Access) bool {
+	if !m.accessChecks {
+		return false
+	}
+	allowed, known := m.Client.KnownAccess(context, namespace, access)
+	return known && !allowed
+}
+
+// refuseSelectedPod reports whether the Pods row under the cursor's
+// context denies access, saying so in the error bar if it does.
+func (m *MainPage) refuseSelectedPod(access k8s.Access) bool {
+	ctxName, namespace, _, ok := m.selectedPod()
+	if !ok || !m.denied(ctxName, namespace, access) {
+		return false
+	}
+	m.reportError(ctxName, fmt.Sprintf("%s: %v", ctxName, &k8s.PermissionError{Access: access, Namespace: namespace}))
+	return true
+}
+
+// actionHints is the keymap the status bar advertises from: the pod
+// actions the selected row's context denies are dropped, as they'd only
+// be refused.
+func (m *MainPage) actionHints() keys.KeyMap {
+	km := m.keys
+	ctxName, namespace, _, ok := m.selectedPod()
+	if !ok || m.tabs[m.activeTab] != "Pods" {
+		return km
+	}
+	for _, action := range []struct {
+		binding *key.Binding
+		access  k8s.Access
+	}{
+		{&km.Delete, k8s.DeletePods},
+		{&km.Shell, k8s.ExecPods},
+		{&km.Forward, k8s.ForwardPods},
+		{&km.Metrics, k8s.ForwardPods},
+		{&km.Restart, k8s.RestartDeployments},
+	} {
+		if m.denied(ctxName, namespace, action.access) {
+			action.binding.SetEnabled(false)
+		}
+	}
+	return km
+}
+
+// watchFailure is the error a watch given up on leaves: a missing
+// permission as just that, anything else with how often it was tried.
+func watchFailure(resource, context string, attempts int, err error) string {
+	var denied *k8s.PermissionError
+	if errors.As(err, &denied) {
+		return fmt.Sprintf("%s: %v", context, denied)
+	}
+	return fmt.Sprintf("Failed to watch %s for context '%s' after %d attempts: %s", resource, context, attempts, k8s.ExplainError(err))
+}
@@ -0,0 +1,132 @@
+package pages
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ktails/ktails/internal/alerts"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// alertFlashDuration is how long the status bar flashes when an alert
+// fires.
+const alertFlashDuration = 1500 * time.Millisecond
+
+// SetAlertRules installs the compiled alert rules; with none, the alert
+// panel's "A" is disabled and nothing is checked.
+func (m *MainPage) SetAlertRules(engine *alerts.Engine) {
+	m.alerts = engine
+	m.keys.Alerts.SetEnabled(!engine.Empty())
+}
+
+// checkLogAlerts runs a log line from source st against the log rules.
+// Backfill — lines stamped before the stream opened — isn't checked:
+// an old panic isn't news.
+func (m *MainPage) checkLogAlerts(st *logStreamState, line msgs.LogLineMsg) tea.Cmd {
+	if m.alerts.Empty() || (!line.Time.IsZero() && line.Time.Before(st.openedAt)) {
+		return nil
+	}
+	t := st.target
+	return m.onAlerts(m.alerts.Line(t.context, t.namespace, t.pod, line.Line, time.Now()))
+}
+
+// checkPodAlerts runs context's freshly watched pods against the restart
+// and status rules — as they arrive, even while the terminal is
+// unfocused and the tables hold them back.
+func (m *MainPage) checkPodAlerts(context string, rows []msgs.RowData) tea.Cmd {
+	if m.alerts.Empty() {
+		return nil
+	}
+	pods := make([]alerts.Pod, 0, len(rows))
+	for _, row := range rows {
+		p := alerts.Pod{}
+		p.Namespace, _ = row[msgs.PodKeyNamespace].(string)
+		p.Name, _ = row[msgs.PodKeyName].(string)
+		p.Status, _ = row[msgs.PodKeyStatus].(string)
+		restarts, _ := row[msgs.PodKeyRestarts].(string)
+		if n, err := strconv.ParseInt(restarts, 10, 32); err == nil {
+			p.Restarts = int32(n)
+		}
+		pods = append(pods, p)
+	}
+	return m.onAlerts(m.alerts.Pods(context, pods, time.Now()))
+}
+
+// onAlerts surfaces fired alerts: the status bar flashes for
+// alertFlashDuration and its badge counts them, and a rule asking for it
+// rings the terminal bell or sends a desktop notification (OSC 9, which
+// terminals without support ignore).
+func (m *MainPage) onAlerts(fired []alerts.Alert) tea.Cmd {
+	if len(fired) == 0 {
+		return nil
+	}
+	if m.showAlerts {
+		m.alertPanel.SetAlerts(m.alerts.Rules(), m.alerts.Alerts())
+	}
+	m.alertGen++
+	m.alertFlash = true
+	gen := m.alertGen
+	cmdSequence := []tea.Cmd{tea.Tick(alertFlashDuration, func(time.Time) tea.Msg {
+		return msgs.AlertFlashEndMsg{Generation: gen}
+	})}
+	bell, notified := false, false
+	for _, a := range fired {
+		if a.Bell && !bell {
+			bell = true
+			cmdSequence = append(cmdSequence, tea.Raw("\a"))
+		}
+		if a.Notify && !notified {
+			notified = true
+			body := fmt.Sprintf("ktails: %s · %s/%s (%s): %s", a.Rule, a.Namespace, a.Pod, a.Context, a.Text)
+			cmdSequence = append(cmdSequence, tea.Raw(ansi.Notify(textwidth.Sanitize(textwidth.Fit(body, 200)))))
+		}
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// openAlerts shows the alert panel, clearing the status bar's badge.
+func (m *MainPage) openAlerts() {
+	m.alerts.MarkSeen()
+	m.alertPanel.SetAlerts(m.alerts.Rules(), m.alerts.Alerts())
+	m.showAlerts = true
+}
+
+// handleAlertPanelKey routes keys while the alert panel is open: Esc (or
+// A) closes it; m mutes or unmutes the rule under the cursor, c clears
+// the alerts fired.
+func (m *MainPage) handleAlertPanelKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Alerts):
+		m.showAlerts = false
+		m.alerts.MarkSeen()
+		return nil
+	case key.Matches(msg, m.keys.Mute):
+		if r, ok := m.alertPanel.Selected(); ok {
+			m.alerts.SetMuted(r.Name, !r.Muted)
+			m.alertPanel.SetAlerts(m.alerts.Rules(), m.alerts.Alerts())
+		}
+		return nil
+	case key.Matches(msg, m.keys.ClearAll):
+		m.alerts.Clear()
+		m.alertPanel.SetAlerts(m.alerts.Rules(), nil)
+		return nil
+	}
+	return m.alertPanel.Update(msg)
+}
+
+// alertStatus is the status bar's badge counting the alerts fired since
+// the alert panel was last open, with the newest; "" if there are none.
+func (m *MainPage) alertStatus() string {
+	n := m.alerts.Unseen()
+	if n == 0 {
+		return ""
+	}
+	newest := m.alerts.Alerts()[0]
+	return fmt.Sprintf("🔔 %d · %s: %s/%s · A: alerts", n, newest.Rule, newest.Namespace, newest.Pod)
+}
@@ -0,0 +1,45 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/audit"
+)
+
+// SetAuditLog has the client record every mutating action to l (see
+// k8s.Client.SetAuditLog) and turns on ctrl+a, which reviews it; nil
+// leaves actions unrecorded.
+func (m *MainPage) SetAuditLog(l *audit.Log) {
+	m.Client.SetAuditLog(l)
+	m.keys.Audit.SetEnabled(l != nil)
+}
+
+// openAudit reads the audit log afresh and shows it, newest first.
+func (m *MainPage) openAudit() {
+	l := m.Client.AuditLog()
+	if l == nil {
+		return
+	}
+	entries, err := l.Entries()
+	if err != nil {
+		m.reportError("", fmt.Sprintf("Audit log: %v", err))
+		return
+	}
+	slices.Reverse(entries)
+	m.auditPanel.SetEntries(entries, l.Path())
+	m.showAudit = true
+}
+
+// handleAuditPanelKey routes keys while the audit log is open: Esc (or
+// ctrl+a) closes it.
+func (m *MainPage) handleAuditPanelKey(msg tea.KeyPressMsg) tea.Cmd {
+	if key.Matches(msg, m.keys.Back, m.keys.Audit) {
+		m.showAudit = false
+		return nil
+	}
+	return m.auditPanel.Update(msg)
+}
@@ -0,0 +1,46 @@
+package pages
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// backendsPanelKey is the info panel's panelKey while it shows what's
+// behind a Service.
+func backendsPanelKey(ctxName, namespace, name string) string {
+	return "backends/" + ctxName + "/" + namespace + "/" + name
+}
+
+// openServiceBackends (b) opens the info panel on the endpoints behind the
+// svc row under the cursor: which pods actually take its traffic.
+func (m *MainPage) openServiceBackends() tea.Cmd {
+	row := m.svcList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.SvcKeyName].(string)
+	namespace, _ := row[msgs.SvcKeyNamespace].(string)
+	ctxName, _ := row[msgs.SvcKeyContext].(string)
+	m.panelKey = backendsPanelKey(ctxName, namespace, name)
+	m.showPanel = true
+	m.infoPanel.StartLoading(fmt.Sprintf("Backends: %s/%s (%s)", namespace, name, ctxName))
+	return cmds.LoadServiceBackendsCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+}
+
+// onServiceBackends fills the backends panel, unless it was closed (or
+// moved on to another Service) while the fetch was in flight.
+func (m *MainPage) onServiceBackends(msg msgs.ServiceBackendsMsg) {
+	if !m.showPanel || m.panelKey != backendsPanelKey(msg.Context, msg.Namespace, msg.Service) {
+		return
+	}
+	if msg.Err != nil {
+		m.infoPanel.SetError(msg.Err.Error())
+		return
+	}
+	m.infoPanel.SetContent(m.infoPanel.Title(), models.ServiceBackendLines(msg.Backends))
+}
@@ -0,0 +1,172 @@
+package pages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// backlogMode is how a log source's backfill is loaded once it turns out
+// longer than backlog_warn_lines: undecided (the source waits, unread,
+// for the user to pick), the newest lines only, every Nth line until the
+// stream catches up with the present, or all of it.
+type backlogMode int
+
+const (
+	backlogUndecided backlogMode = iota
+	backlogTail
+	backlogSample
+	backlogFull
+)
+
+// backlogVerdict is what becomes of one backfilled line.
+type backlogVerdict int
+
+const (
+	keepLine  backlogVerdict = iota
+	dropLine                 // sampled out
+	pauseLine                // over the threshold, mode undecided
+)
+
+// backlogVerdict counts ts's line against the source's backfill — the
+// lines stamped before its stream was opened — and judges it. A stream
+// that has reached the present, or a pane with no threshold, keeps
+// everything.
+func (m *MainPage) backlogVerdict(st *logStreamState, ts time.Time) backlogVerdict {
+	if st.live || st.openedAt.IsZero() || m.logTail.warnLines <= 0 {
+		return keepLine
+	}
+	if !ts.IsZero() && !ts.Before(st.openedAt) {
+		st.live = true
+		return keepLine
+	}
+	st.backlog++
+	over := st.backlog - m.logTail.warnLines
+	switch {
+	case over <= 0 || st.backlogMode == backlogFull:
+		return keepLine
+	case st.backlogMode == backlogSample:
+		if over%st.sampleEvery != 1 {
+			return dropLine
+		}
+		return keepLine
+	}
+	return pauseLine
+}
+
+// pauseForBacklog stops reading the source at the line that took its
+// backfill over the threshold — the kubelet won't send more than the
+// connection buffers meanwhile — and asks how to go on, unless the pane
+// has already been told.
+func (m *MainPage) pauseForBacklog(st *logStreamState, line msgs.LogLineMsg) tea.Cmd {
+	st.pending = &line
+	if m.logTail.backlog != backlogUndecided {
+		return m.applyBacklogMode()
+	}
+	if m.backlogPrompting {
+		return nil
+	}
+	m.backlogPrompting = true
+	return m.promptBacklog("")
+}
+
+// promptBacklog asks how the paused sources' backfill is loaded; Esc
+// settles on the newest lines only, the choice least likely to pull
+// hundreds of megabytes over a slow link.
+func (m *MainPage) promptBacklog(complaint string) tea.Cmd {
+	var paused []string
+	for _, key := range m.logPanes.Keys() {
+		if st, ok := m.logStreams[key]; ok && st.pending != nil {
+			paused = append(paused, st.target.pod+"/"+st.target.cntnr)
+		}
+	}
+	label := fmt.Sprintf("%s%s has over %d lines of backlog. Load: tail (the newest %d), a number N (every Nth line until caught up), or full:",
+		complaint, strings.Join(paused, ", "), m.logTail.warnLines, m.backlogTailLines())
+	cmd := m.openPrompt("Large log backlog", label, "tail", func(answer string) tea.Cmd {
+		return m.chooseBacklog(answer)
+	})
+	m.promptCancel = func() tea.Cmd {
+		return m.chooseBacklog("tail")
+	}
+	return cmd
+}
+
+// chooseBacklog records the prompt's answer as the pane's backlog mode and
+// applies it; an answer it can't read asks again.
+func (m *MainPage) chooseBacklog(answer string) tea.Cmd {
+	switch answer = strings.ToLower(answer); {
+	case answer == "tail" || answer == "t":
+		m.logTail.backlog = backlogTail
+	case answer == "full" || answer == "f":
+		m.logTail.backlog = backlogFull
+	default:
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 2 {
+			return m.promptBacklog(fmt.Sprintf("%q isn't tail, full or a number of at least 2. ", answer))
+		}
+		m.logTail.backlog, m.logTail.sampleEvery = backlogSample, n
+	}
+	m.backlogPrompting = false
+	return m.applyBacklogMode()
+}
+
+// applyBacklogMode goes on with every paused source the pane's chosen
+// way: reopened on the newest lines only, or read on from the line it
+// stopped at, every line or every Nth.
+func (m *MainPage) applyBacklogMode() tea.Cmd {
+	var resumed []tea.Cmd
+	for _, key := range m.logPanes.Keys() {
+		st, ok := m.logStreams[key]
+		if !ok || st.pending == nil {
+			continue
+		}
+		line := *st.pending
+		st.pending = nil
+		switch m.logTail.backlog {
+		case backlogTail:
+			resumed = append(resumed, m.reopenOnTail(key, st))
+			continue
+		case backlogSample:
+			st.backlogMode, st.sampleEvery = backlogSample, m.logTail.sampleEvery
+			m.logPanes.AddNotice(key, fmt.Sprintf("backlog over %d lines · keeping 1 line in %d until caught up", m.logTail.warnLines, st.sampleEvery))
+		default:
+			st.backlogMode = backlogFull
+		}
+		st.record(line.Time)
+		m.logPanes.AppendLineAt(key, line.Line, line.Time)
+		resumed = append(resumed, cmds.WaitForLogLineCmd(key, st.generation, st.scanner))
+	}
+	return tea.Batch(resumed...)
+}
+
+// reopenOnTail replaces source key's stream with one starting at its
+// newest backlogTailLines lines.
+func (m *MainPage) reopenOnTail(key string, st *logStreamState) tea.Cmd {
+	if st.stream != nil {
+		st.stream.Close()
+	}
+	st.endStream()
+	*st = logStreamState{target: st.target, generation: st.generation + 1}
+	m.logPanes.RestartSource(key, fmt.Sprintf("Backlog over %d lines · showing the newest %d", m.logTail.warnLines, m.backlogTailLines()))
+	t := st.target
+	opts := m.logTail.options(t.cntnr)
+	opts.Since, opts.TailLines = 0, int64(m.backlogTailLines())
+	return cmds.OpenPodLogStreamCmd(m.streamCtx(st), m.Client, t.context, t.namespace, t.pod, key, st.generation, opts)
+}
+
+// backlogTailLines is how many lines "tail" keeps: tail_lines, unless that
+// is 0 (everything) or no smaller than the threshold itself.
+func (m *MainPage) backlogTailLines() int {
+	n := int(m.logTail.tailLines)
+	if n <= 0 || n >= m.logTail.warnLines {
+		return config.DefaultTailLines
+	}
+	return n
+}
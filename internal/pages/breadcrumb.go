@@ -0,0 +1,107 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// breadcrumbSep separates the breadcrumb's steps.
+const breadcrumbSep = " ▸ "
+
+// breadcrumb is the path to what has the focus, as the line above the tabs
+// shows it: the context under the cursor and its namespaces in the
+// contexts pane; else the context and namespace of the row under the
+// table's cursor, the tab and the row's name, or, with the detail or log
+// pane focused, what that shows. Where several contexts are in play and
+// nothing narrows them, the first step counts them.
+func (m *MainPage) breadcrumb(snapshot state.Snapshot) []string {
+	if m.focus == focusLeftPane {
+		name, namespaces, ok := m.contextList.CursorContext()
+		if !ok {
+			return []string{"contexts"}
+		}
+		return append([]string{name}, strings.Join(namespaces, ","))
+	}
+
+	tab := strings.ToLower(m.tabs[m.activeTab])
+	switch {
+	case m.showDetail && m.detailFocused:
+		kind, name := m.deploymentDetail.Resource()
+		return []string{m.deploymentDetail.Context(), strings.ToLower(kind), name}
+	case m.showLogs && m.logsFocused:
+		return m.logsBreadcrumb()
+	}
+
+	if row := m.selectedRow(); row != nil {
+		context, _ := row[msgs.PodKeyContext].(string)
+		namespace, _ := row[msgs.PodKeyNamespace].(string)
+		name, _ := row[msgs.PodKeyName].(string)
+		return []string{context, namespace, tab, name}
+	}
+	contexts := tableContexts(snapshot.SelectedContexts)
+	switch len(contexts) {
+	case 0:
+		return []string{tab}
+	case 1:
+		return []string{contexts[0], snapshot.SelectedContexts[contexts[0]], tab}
+	}
+	return []string{fmt.Sprintf("%d contexts", len(contexts)), tab}
+}
+
+// logsBreadcrumb is the breadcrumb of a focused log pane: the one pod it
+// tails, or how many pods across how many contexts.
+func (m *MainPage) logsBreadcrumb() []string {
+	var contexts, pods []string
+	var last podLogTarget
+	for _, st := range m.logStreams {
+		last = st.target
+		if !slices.Contains(contexts, st.target.context) {
+			contexts = append(contexts, st.target.context)
+		}
+		pod := st.target.context + "/" + st.target.namespace + "/" + st.target.pod
+		if !slices.Contains(pods, pod) {
+			pods = append(pods, pod)
+		}
+	}
+	switch {
+	case len(pods) == 1:
+		return []string{last.context, last.namespace, "logs", last.pod}
+	case len(contexts) == 1:
+		return []string{contexts[0], "logs", fmt.Sprintf("%d pods", len(pods))}
+	case len(contexts) > 1:
+		return []string{fmt.Sprintf("%d contexts", len(contexts)), "logs", fmt.Sprintf("%d pods", len(pods))}
+	}
+	return []string{"logs"}
+}
+
+// renderBreadcrumb renders the breadcrumb on one line, width cells wide:
+// the context in its accent, the last step highlighted, empty steps
+// dropped, cut short with "…" if it doesn't fit.
+func (m *MainPage) renderBreadcrumb(snapshot state.Snapshot, width int) string {
+	var steps []string
+	for _, step := range m.breadcrumb(snapshot) {
+		if step != "" {
+			steps = append(steps, step)
+		}
+	}
+
+	styled := make([]string, len(steps))
+	for i, step := range steps {
+		switch {
+		case i == len(steps)-1:
+			styled[i] = m.theme.Text.Bold(true).Render(step)
+		case i == 0 && m.accents[step] != nil:
+			styled[i] = m.theme.Text.Foreground(m.accents[step]).Render(step)
+		default:
+			styled[i] = m.theme.Subtext0.Render(step)
+		}
+	}
+	line := " " + strings.Join(styled, m.theme.Overlay0.Render(breadcrumbSep))
+	return ansi.Truncate(line, width, "…")
+}
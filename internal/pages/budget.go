@@ -0,0 +1,79 @@
+package pages
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/k8s"
+)
+
+// budgetWarnLoad is the share of a context's request budget past which
+// ktails warns and holds back the requests it can defer: top reloads and
+// manual refreshes for that context, and the refresh tick as a whole.
+const budgetWarnLoad = 0.8
+
+// budgetRefreshFactor is how much slower the refresh tick runs while any
+// selected context is near its budget.
+const budgetRefreshFactor = 3
+
+// SetRequestBudget installs the configured per-context request budget.
+func (m *MainPage) SetRequestBudget(budget config.RequestBudget) {
+	limits := budget.Limits()
+	m.requestBudget = k8s.RequestBudget{MaxInFlight: limits.MaxInFlight, MaxPerMinute: limits.MaxPerMinute}
+}
+
+// requestLoad returns how much of its budget a context is using.
+func (m *MainPage) requestLoad(context string) float64 {
+	return m.Client.RequestUsage(context).Load(m.requestBudget)
+}
+
+// nearBudget reports whether a context's requests should be held back.
+func (m *MainPage) nearBudget(context string) bool {
+	return m.requestLoad(context) >= budgetWarnLoad
+}
+
+// anyNearBudget reports whether any selected context is near its budget.
+func (m *MainPage) anyNearBudget() bool {
+	for context := range m.appState.Snapshot().SelectedContexts {
+		if m.nearBudget(context) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinBudget splits contexts into those not near their budget and the
+// names of the held back ones.
+func (m *MainPage) withinBudget(contexts map[string]string) (allowed map[string]string, held []string) {
+	allowed = make(map[string]string, len(contexts))
+	for context, namespace := range contexts {
+		if m.nearBudget(context) {
+			held = append(held, context)
+			continue
+		}
+		allowed[context] = namespace
+	}
+	sort.Strings(held)
+	return allowed, held
+}
+
+// budgetStatus is the status bar's request budget indicator: the busiest
+// selected context's share of its budget, with a warning once it's near.
+func (m *MainPage) budgetStatus() string {
+	var busiest string
+	var load float64
+	for context := range m.appState.Snapshot().SelectedContexts {
+		if l := m.requestLoad(context); busiest == "" || l > load || (l == load && context < busiest) {
+			busiest, load = context, l
+		}
+	}
+	if busiest == "" {
+		return ""
+	}
+	if load >= budgetWarnLoad {
+		usage := m.Client.RequestUsage(busiest)
+		return fmt.Sprintf("⚠ API %s %d%% (%d in flight, %d/min) · refreshes slowed", busiest, int(load*100), usage.InFlight, usage.PerMinute)
+	}
+	return fmt.Sprintf("API %d%%", int(load*100))
+}
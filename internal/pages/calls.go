@@ -0,0 +1,60 @@
+package pages
+
+import (
+	"context"
+)
+
+// SetContext ties every API call and stream MainPage starts to ctx: they
+// are cancelled when it is, as they are when the program quits.
+func (m *MainPage) SetContext(ctx context.Context) {
+	m.cancel()
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	clear(m.kubeCalls)
+}
+
+// callCtx returns the context kubeContext's API calls run in. It is
+// cancelled, ending the calls still in flight, when kubeContext is
+// unloaded (see cancelCalls) or the program quits.
+func (m *MainPage) callCtx(kubeContext string) context.Context {
+	if call, ok := m.kubeCalls[kubeContext]; ok {
+		return call.ctx
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.kubeCalls[kubeContext] = kubeCall{ctx: ctx, cancel: cancel}
+	return ctx
+}
+
+// kubeCall is one kube context's cancellable share of MainPage's context.
+type kubeCall struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// cancelCalls cancels kubeContext's calls still in flight, its watches'
+// included; the next call gets a fresh context.
+func (m *MainPage) cancelCalls(kubeContext string) {
+	if call, ok := m.kubeCalls[kubeContext]; ok {
+		call.cancel()
+		delete(m.kubeCalls, kubeContext)
+	}
+}
+
+// streamCtx returns the context a log source's stream opens in, one per
+// source for as long as it's open: closing the source (see
+// closeLogSource) cancels an open still in flight. Sources belong to their
+// pane rather than their kube context, so unloading the context leaves
+// them be.
+func (m *MainPage) streamCtx(st *logStreamState) context.Context {
+	if st.ctx == nil {
+		st.ctx, st.cancel = context.WithCancel(m.ctx)
+	}
+	return st.ctx
+}
+
+// endStream cancels a log source's stream context, if it has one.
+func (st *logStreamState) endStream() {
+	if st.cancel != nil {
+		st.cancel()
+		st.ctx, st.cancel = nil, nil
+	}
+}
@@ -0,0 +1,123 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// maxFilterChips caps the chips shown above a table: they're dismissed with
+// the digit keys, so only 1-9 can be addressed.
+const maxFilterChips = 9
+
+// filterChip is one active filter narrowing the active tab, shown above the
+// table so it isn't forgotten, and dismissed with its digit key.
+type filterChip struct {
+	label  string
+	remove func() tea.Cmd
+}
+
+// filterChips lists the filters narrowing the active tab: namespaces picked
+// per context, the Deployment the Pods tab is browsing, the tab's selector,
+// then each term of its "/" filter (so a qos:/priority: status term can be
+// dropped on its own).
+func (m *MainPage) filterChips() []filterChip {
+	tab := m.tabs[m.activeTab]
+	if !m.appStateLoaded || !isResourceTab(tab) {
+		return nil
+	}
+
+	var chips []filterChip
+	for _, selection := range m.contextList.Confirmed() {
+		if len(selection.Namespaces) == 0 {
+			continue
+		}
+		context := selection.ContextName
+		chips = append(chips, filterChip{
+			label: fmt.Sprintf("ns %s: %s", context, strings.Join(selection.Namespaces, ",")),
+			remove: func() tea.Cmd {
+				return m.applyNamespaces(context, nil)
+			},
+		})
+	}
+	if tab == "Pods" && m.podList.Group() != "" {
+		chips = append(chips, filterChip{
+			label: "▸ " + m.podList.Group(),
+			remove: func() tea.Cmd {
+				m.podList.CloseGroup()
+				return nil
+			},
+		})
+	}
+	if expr := m.selectors[tab]; expr != "" {
+		chips = append(chips, filterChip{
+			label: "⊂ " + expr,
+			remove: func() tea.Cmd {
+				return m.setSelector(tab, "")
+			},
+		})
+	}
+	if t := m.activeResourceTable(); t != nil {
+		if query, _, typing, ok := t.FilterStatus(); ok && !typing {
+			terms := strings.Fields(query)
+			for i, term := range terms {
+				chips = append(chips, filterChip{
+					label: "/" + term,
+					remove: func() tea.Cmd {
+						rest := append(append([]string{}, terms[:i]...), terms[i+1:]...)
+						t.SetFilter(strings.Join(rest, " "))
+						return nil
+					},
+				})
+			}
+		}
+	}
+	if len(chips) > maxFilterChips {
+		chips = chips[:maxFilterChips]
+	}
+	return chips
+}
+
+// removeFilterChip dismisses the n-th chip (from 0), the one the n-th
+// drop_chip key names, reporting whether there was one.
+func (m *MainPage) removeFilterChip(n int) (tea.Cmd, bool) {
+	chips := m.filterChips()
+	if n < 0 || n >= len(chips) {
+		return nil, false
+	}
+	m.actionStatus = "Removed filter " + chips[n].label
+	return chips[n].remove(), true
+}
+
+// renderFilterChips draws the chip line shown above the active table, ""
+// when nothing narrows it.
+func (m *MainPage) renderFilterChips(chips []filterChip, width int) string {
+	if len(chips) == 0 {
+		return ""
+	}
+	p := m.theme.Palette
+	chipStyle := lipgloss.NewStyle().Foreground(p.Text).Background(p.Surface0).Padding(0, 1)
+	keyStyle := lipgloss.NewStyle().Foreground(p.Mauve).Background(p.Surface0).Bold(true)
+
+	parts := make([]string, 0, len(chips))
+	for i, chip := range chips {
+		parts = append(parts, chipStyle.Render(keyStyle.Render(fmt.Sprint(i+1))+" "+chip.label+" ✕"))
+	}
+	line := strings.Join(parts, " ")
+	if lipgloss.Width(line) > width {
+		line = lipgloss.NewStyle().MaxWidth(width).Render(line)
+	}
+	return line
+}
+
+// syncChipRow resizes the tables when the chip line appears or goes away,
+// so it takes a row from the table rather than pushing the layout down.
+func (m *MainPage) syncChipRow(shown bool) {
+	if shown == m.chipRowShown {
+		return
+	}
+	m.chipRowShown = shown
+	m.applyContentSizes()
+}
@@ -0,0 +1,74 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// rowCopyKeys are the fields "Y" copies from a row of each tab, in order —
+// what identifies the object plus what its table shows, so the copy reads
+// on its own in a ticket.
+var rowCopyKeys = map[string][]string{
+	"Deployments": {msgs.DeployKeyContext, msgs.DeployKeyNamespace, msgs.DeployKeyName, msgs.DeployKeyReplicas, msgs.DeployKeyAge},
+	"Pods":        {msgs.PodKeyContext, msgs.PodKeyNamespace, msgs.PodKeyName, msgs.PodKeyStatus, msgs.PodKeyRestarts, msgs.PodKeyAge, msgs.PodKeyNode, msgs.PodKeyPodIP},
+	"svc":         {msgs.SvcKeyContext, msgs.SvcKeyNamespace, msgs.SvcKeyName, msgs.SvcKeyType, msgs.SvcKeyClusterIP, msgs.SvcKeyPorts, msgs.SvcKeyAge},
+	"sts":         {msgs.WorkloadKeyContext, msgs.WorkloadKeyNamespace, msgs.WorkloadKeyName, msgs.WorkloadKeyReady, msgs.WorkloadKeyStatus, msgs.WorkloadKeyAge},
+	"ds":          {msgs.WorkloadKeyContext, msgs.WorkloadKeyNamespace, msgs.WorkloadKeyName, msgs.WorkloadKeyReady, msgs.WorkloadKeyStatus, msgs.WorkloadKeyAge},
+}
+
+// selectedRow returns the row under the active tab's cursor, nil on a tab
+// without one.
+func (m *MainPage) selectedRow() msgs.RowData {
+	switch m.tabs[m.activeTab] {
+	case "Deployments":
+		return m.deploymentList.SelectedRow()
+	case "Pods":
+		return m.podList.SelectedRow()
+	case "svc":
+		return m.svcList.SelectedRow()
+	case "sts":
+		return m.stsList.SelectedRow()
+	case "ds":
+		return m.dsList.SelectedRow()
+	}
+	return nil
+}
+
+// copyRow puts the row under the cursor on the system clipboard: just its
+// name, ready to paste into a kubectl command, or with full set the whole
+// row, tab-separated.
+func (m *MainPage) copyRow(full bool) tea.Cmd {
+	row := m.selectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.PodKeyName].(string)
+	if !full {
+		m.actionStatus = fmt.Sprintf("Copied %s", name)
+		return m.setClipboard(name)
+	}
+	var fields []string
+	for _, key := range rowCopyKeys[m.tabs[m.activeTab]] {
+		if v, ok := row[key].(string); ok && v != "" {
+			fields = append(fields, v)
+		}
+	}
+	m.actionStatus = fmt.Sprintf("Copied the %s row", name)
+	return m.setClipboard(strings.Join(fields, "\t"))
+}
+
+// yankLogLines copies the log pane's selected lines (or, in structured
+// mode, its cursor line) to the system clipboard.
+func (m *MainPage) yankLogLines() tea.Cmd {
+	text, n, ok := m.logPanes.Active().Yank()
+	if !ok {
+		m.reportError("", "Nothing to copy: press v to select lines first")
+		return nil
+	}
+	m.actionStatus = fmt.Sprintf("Copied %d log line(s)", n)
+	return m.setClipboard(text)
+}
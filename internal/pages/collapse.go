@@ -0,0 +1,82 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// stripWidth is the collapsed contexts pane's content width: room for a
+// two-letter badge with a cell either side.
+const stripWidth = 4
+
+// toggleContextsPane (Ctrl+B) collapses the contexts pane to a strip of the
+// loaded contexts' badges, handing the tab area the width, or expands it
+// back. Collapsed, it still opens whole while it has the focus, so Tab
+// gets back to the contexts. The choice is kept across runs (see State).
+func (m *MainPage) toggleContextsPane() {
+	m.contextsCollapsed = !m.contextsCollapsed
+	if m.contextsCollapsed && m.focus == focusLeftPane {
+		m.focus = focusTabs
+	}
+	m.updateFocusStates()
+}
+
+// leftPaneWidth is the contexts pane's content width as laid out now.
+func (m *MainPage) leftPaneWidth() int {
+	if m.stripShown {
+		return stripWidth
+	}
+	return leftPaneWidthFor(m.width)
+}
+
+// sizeTabArea sizes the tables and the detail/log pane to what the
+// contexts pane leaves of the terminal.
+func (m *MainPage) sizeTabArea() {
+	if m.width == 0 {
+		return
+	}
+	m.tableW = m.width - m.leftPaneWidth() - 12
+	m.tableH = max(1, m.height-17)
+	m.applyContentSizes()
+}
+
+// contextStrip is the collapsed contexts pane: a badge per loaded context
+// in its accent, labelled with the first letters of its name, as many as
+// fit and then a count of the rest.
+func (m *MainPage) contextStrip(snapshot state.Snapshot) string {
+	contexts := tableContexts(snapshot.SelectedContexts)
+	if len(contexts) == 0 {
+		return m.theme.Overlay0.Width(stripWidth).Align(lipgloss.Center).Render("»")
+	}
+	slices.Sort(contexts)
+
+	room := max(1, m.height-6-styles.LeftPane.GetVerticalFrameSize())
+	var lines []string
+	for i, context := range contexts {
+		if len(lines) == room-1 && i < len(contexts)-1 {
+			lines = append(lines, m.theme.Overlay1.Width(stripWidth).Align(lipgloss.Center).
+				Render(fmt.Sprintf("+%d", len(contexts)-i)))
+			break
+		}
+		bg := m.theme.Palette.Blue
+		if accent, ok := m.accents[context]; ok {
+			bg = accent
+		}
+		label := []rune(context)
+		label = label[:min(2, len(label))]
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(m.theme.Palette.Base).
+			Background(bg).
+			Bold(true).
+			Width(stripWidth).
+			Align(lipgloss.Center).
+			Render(string(label)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,53 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+// SetColumns applies the config's column choices to the Pods and
+// Deployments tables.
+func (m *MainPage) SetColumns(cols config.Columns) {
+	m.podList.SetColumns(cols.Pods)
+	m.deploymentList.SetColumns(cols.Deployments)
+}
+
+// openColumnChooser opens the column chooser on tab's table (C).
+func (m *MainPage) openColumnChooser(tab string) {
+	current := m.podList.Columns()
+	if tab == "Deployments" {
+		current = m.deploymentList.Columns()
+	}
+	m.colChooser.Open(tab, current)
+	m.showColumns = true
+}
+
+// handleColumnChooserKey routes keys while the column chooser is open.
+// What Enter applies lasts for the session; the config's columns setting
+// is what's used at startup.
+func (m *MainPage) handleColumnChooserKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.showColumns = false
+		return nil
+	case key.Matches(msg, m.keys.Accept):
+		m.showColumns = false
+		tab, chosen := m.colChooser.Tab(), m.colChooser.Chosen()
+		switch tab {
+		case "Pods":
+			m.podList.SetColumns(chosen)
+			chosen = m.podList.Columns()
+		case "Deployments":
+			m.deploymentList.SetColumns(chosen)
+			chosen = m.deploymentList.Columns()
+		}
+		m.actionStatus = fmt.Sprintf("%s columns: name, %s", tab, strings.Join(chosen, ", "))
+		return nil
+	}
+	return m.colChooser.Update(msg)
+}
@@ -0,0 +1,171 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/logfmt"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// levelFilter prefixes the ":filter" argument that sets the log pane's
+// level filter rather than the active table's.
+const levelFilter = "level>="
+
+// openCommandLine opens the ":" command line, completing contexts from the
+// kubeconfig and namespaces and pods from what's loaded.
+func (m *MainPage) openCommandLine() tea.Cmd {
+	snapshot := m.appState.Snapshot()
+
+	var namespaces, pods []string
+	for _, selection := range m.contextList.Confirmed() {
+		namespaces = append(namespaces, selectionNamespaces(selection)...)
+	}
+	for _, row := range snapshot.Pods {
+		if ns, _ := row[msgs.PodKeyNamespace].(string); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+		if name, _ := row[msgs.PodKeyName].(string); name != "" {
+			pods = append(pods, name)
+		}
+	}
+	slices.Sort(namespaces)
+	slices.Sort(pods)
+
+	levels := []string{levelFilter + "debug", levelFilter + "info", levelFilter + "warn", levelFilter + "error"}
+	m.showCommand = true
+	return m.commandLine.Open(map[string][]string{
+		"ctx":     m.contextList.Names(),
+		"ns":      slices.Compact(namespaces),
+		"logs":    slices.Compact(pods),
+		"filter":  levels,
+		"profile": m.profileNames(),
+		"q":       nil,
+	})
+}
+
+// handleCommandKey routes keys while the command line is open: Esc closes
+// it, Enter closes it and runs what was typed.
+func (m *MainPage) handleCommandKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.showCommand = false
+		return nil
+	case key.Matches(msg, m.keys.Accept):
+		m.showCommand = false
+		return m.runCommand(m.commandLine.Value())
+	}
+	return m.commandLine.Update(msg)
+}
+
+// runCommand runs one command line: ctx, ns, logs, profile, filter or q.
+func (m *MainPage) runCommand(line string) tea.Cmd {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "":
+		return nil
+	case "q", "quit":
+		return m.quit()
+	case "filter":
+		return m.filterCommand(arg)
+	}
+	if arg == "" {
+		m.reportError("", fmt.Sprintf(":%s needs an argument", name))
+		return nil
+	}
+	switch name {
+	case "ctx":
+		return m.contextCommand(arg)
+	case "ns":
+		return m.alignNamespace(arg)
+	case "logs":
+		return m.logsCommand(arg)
+	case "profile":
+		return m.profileCommand(arg)
+	}
+	m.reportError("", fmt.Sprintf("Unknown command :%s (ctx, ns, logs, profile, filter, q)", name))
+	return nil
+}
+
+// contextCommand moves the contexts pane's cursor to a context, named in
+// full or by a prefix only it has, and loads it alongside those already
+// loaded.
+func (m *MainPage) contextCommand(arg string) tea.Cmd {
+	names := m.contextList.Names()
+	name := arg
+	if !slices.Contains(names, arg) {
+		var matches []string
+		for _, n := range names {
+			if strings.HasPrefix(n, arg) {
+				matches = append(matches, n)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			m.reportError("", fmt.Sprintf(":ctx: no context %s", arg))
+			return nil
+		case 1:
+			name = matches[0]
+		default:
+			m.reportError("", fmt.Sprintf(":ctx: %s could be %s", arg, strings.Join(matches, ", ")))
+			return nil
+		}
+	}
+
+	m.contextList.SelectContext(name)
+	for _, selection := range m.contextList.Confirmed() {
+		if selection.ContextName == name {
+			m.actionStatus = "Context " + name + " is already loaded"
+			return nil
+		}
+	}
+	return m.contextList.Reselect([]string{name})
+}
+
+// logsCommand tails every loaded pod whose name starts with arg in the
+// active log pane, as l does for the checked rows.
+func (m *MainPage) logsCommand(arg string) tea.Cmd {
+	var rows []msgs.RowData
+	for _, row := range m.appState.Snapshot().Pods {
+		if name, _ := row[msgs.PodKeyName].(string); strings.HasPrefix(name, arg) {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		m.reportError("", fmt.Sprintf(":logs: no loaded pod named %s…", arg))
+		return nil
+	}
+	m.focus = focusTabs
+	return m.reconcilePodLogs(rows)
+}
+
+// filterCommand sets the active log pane's level filter (level>=warn;
+// level>= alone clears it), else the active table's filter, as / would
+// (an empty one clears it).
+func (m *MainPage) filterCommand(arg string) tea.Cmd {
+	if rest, ok := strings.CutPrefix(arg, levelFilter); ok {
+		if !m.showLogs {
+			m.reportError("", ":filter: open a log pane first")
+			return nil
+		}
+		level := logfmt.ParseLevel(rest)
+		if level == logfmt.LevelUnknown && rest != "" {
+			m.reportError("", fmt.Sprintf(":filter: unknown level %s (debug, info, warn, error)", rest))
+			return nil
+		}
+		m.logPanes.SetMinLevel(level)
+		return nil
+	}
+	t := m.activeResourceTable()
+	if t == nil {
+		m.reportError("", ":filter: the active tab has no table to filter")
+		return nil
+	}
+	t.SetFilter(arg)
+	return nil
+}
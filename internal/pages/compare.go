@@ -0,0 +1,89 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// comparePanelKey is the info panel's panelKey while it compares a
+// Deployment across two contexts.
+func comparePanelKey(contextA, contextB, namespace, name string) string {
+	return "compare/" + contextA + "/" + contextB + "/" + namespace + "/" + name
+}
+
+// openCompare (d) compares the Deployments row under the cursor with the
+// Deployment of the same namespace and name in another selected context:
+// the only other one, or else the one asked for, offering the first that
+// lists it.
+func (m *MainPage) openCompare() tea.Cmd {
+	row := m.deploymentList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.DeployKeyName].(string)
+	namespace, _ := row[msgs.DeployKeyNamespace].(string)
+	ctxName, _ := row[msgs.DeployKeyContext].(string)
+
+	snapshot := m.appState.Snapshot()
+	var others []string
+	for _, context := range tableContexts(snapshot.SelectedContexts) {
+		if context != ctxName {
+			others = append(others, context)
+		}
+	}
+	slices.Sort(others)
+	switch len(others) {
+	case 0:
+		m.reportError("", "Compare: select a second context to compare the deployment with")
+		return nil
+	case 1:
+		return m.loadCompare(ctxName, others[0], namespace, name)
+	}
+
+	initial := others[0]
+	for _, deployment := range snapshot.Deployments {
+		context, _ := deployment[msgs.DeployKeyContext].(string)
+		if context != ctxName && slices.Contains(others, context) &&
+			deployment[msgs.DeployKeyNamespace] == namespace && deployment[msgs.DeployKeyName] == name {
+			initial = context
+			break
+		}
+	}
+	label := fmt.Sprintf("Compare deployment %s/%s (%s) with context:", namespace, name, ctxName)
+	return m.openPrompt("Compare", label, initial, func(value string) tea.Cmd {
+		if !slices.Contains(others, value) {
+			m.reportError("", fmt.Sprintf("Compare: %q isn't another selected context (%s)", value, strings.Join(others, ", ")))
+			return nil
+		}
+		return m.loadCompare(ctxName, value, namespace, name)
+	})
+}
+
+// loadCompare fetches a Deployment's settings in two contexts into the
+// info panel.
+func (m *MainPage) loadCompare(contextA, contextB, namespace, name string) tea.Cmd {
+	m.panelKey = comparePanelKey(contextA, contextB, namespace, name)
+	m.showPanel = true
+	m.infoPanel.StartLoading(fmt.Sprintf("Compare: %s/%s (%s ↔ %s)", namespace, name, contextA, contextB))
+	return cmds.CompareDeploymentCmd(m.callCtx(contextA), m.callCtx(contextB), m.Client, contextA, contextB, namespace, name)
+}
+
+// onCompare fills the compare panel, unless it was closed (or moved on)
+// while the fetch was in flight.
+func (m *MainPage) onCompare(msg msgs.DeploymentCompareMsg) {
+	if !m.showPanel || m.panelKey != comparePanelKey(msg.A.Context, msg.B.Context, msg.Namespace, msg.Name) {
+		return
+	}
+	if msg.Err != nil {
+		m.infoPanel.SetError(msg.Err.Error())
+		return
+	}
+	m.infoPanel.SetContent(m.infoPanel.Title(), models.CompareLines(msg.A, msg.B, m.infoPanel.ContentWidth()))
+}
@@ -0,0 +1,87 @@
+package pages
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/startup"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// heartbeatInterval is how often the selected contexts' clusters are
+// checked again — one /version call each, a far lighter request than any
+// list — so one that turns slow or unreachable shows in its badge.
+const heartbeatInterval = 30 * time.Second
+
+// checkConnectionCmd checks in the background whether context's cluster
+// answers, badging it in the contexts pane meanwhile — clients are created
+// without a round trip, so an unreachable cluster shows up here rather than
+// stalling startup or the load of every other context.
+func (m *MainPage) checkConnectionCmd(context string) tea.Cmd {
+	m.contextList.SetChecking(context)
+	m.checking[context] = true
+	return cmds.CheckConnectionCmd(m.callCtx(context), m.Client, context)
+}
+
+// onContextHealth badges the context with its check's outcome.
+func (m *MainPage) onContextHealth(msg msgs.ContextHealthMsg) {
+	delete(m.checking, msg.Context)
+	if msg.Err != nil {
+		log.Printf("context %s unreachable: %v", msg.Context, msg.Err)
+		m.trace.MarkContext(msg.Context, startup.StepUnreachable)
+	} else {
+		m.trace.MarkContext(msg.Context, startup.StepConnected)
+	}
+	m.contextList.SetHealth(msg.Context, msg.Version, msg.RTT, msg.Err)
+}
+
+// onRetries notes in the contexts pane each context with a call backing
+// off before a retry, and how long until it's sent.
+func (m *MainPage) onRetries(msg msgs.RetriesMsg) tea.Cmd {
+	notes := make(map[string]string, len(msg.Retries))
+	for context, retry := range msg.Retries {
+		wait := max(time.Until(retry.At).Round(time.Second), time.Second)
+		notes[context] = fmt.Sprintf("retrying in %s…", wait)
+	}
+	m.contextList.SetRetries(notes)
+	return cmds.WaitForRetriesCmd(m.ctx, m.Client)
+}
+
+// heartbeatTickCmd schedules the next heartbeat, slowed down like the
+// refresh tick while the terminal is unfocused.
+func (m *MainPage) heartbeatTickCmd() tea.Cmd {
+	interval := heartbeatInterval
+	if m.unfocused {
+		interval *= unfocusedRefreshFactor
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return msgs.HeartbeatTickMsg{}
+	})
+}
+
+// heartbeat checks every selected context again, keeping each badge as it
+// is until the answer lands; a context whose last check is still out
+// (a cluster slower than the interval) isn't asked twice. Nothing is
+// checked while the streams are parked for idleness.
+func (m *MainPage) heartbeat() tea.Cmd {
+	next := m.heartbeatTickCmd()
+	if m.idle.parked {
+		return next
+	}
+	batch := []tea.Cmd{next}
+	contexts := slices.Sorted(maps.Keys(m.appState.Snapshot().SelectedContexts))
+	for _, context := range contexts {
+		if m.checking[context] {
+			continue
+		}
+		m.checking[context] = true
+		batch = append(batch, cmds.CheckConnectionCmd(m.callCtx(context), m.Client, context))
+	}
+	return tea.Batch(batch...)
+}
@@ -0,0 +1,82 @@
+package pages
+
+import (
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/state"
+)
+
+// contextScopes are the sub-tabs of the Pods and Deployments tables with
+// several contexts selected: every context's rows (""), then each
+// context's own, by name. None with fewer than two.
+func contextScopes(snapshot state.Snapshot) []string {
+	contexts := tableContexts(snapshot.SelectedContexts)
+	if len(contexts) < 2 {
+		return nil
+	}
+	slices.Sort(contexts)
+	return append([]string{""}, contexts...)
+}
+
+// cycleContextScope ({ and }) moves the Pods and Deployments tables to the
+// previous or next context sub-tab, wrapping around.
+func (m *MainPage) cycleContextScope(delta int) {
+	scopes := contextScopes(m.appState.Snapshot())
+	if len(scopes) == 0 {
+		m.actionStatus = "Each context gets its own sub-tab once several are loaded"
+		return
+	}
+	i := max(0, slices.Index(scopes, m.contextScope))
+	m.setContextScope(scopes[(i+delta+len(scopes))%len(scopes)])
+}
+
+// setContextScope shows context's sub-tab on the Pods and Deployments
+// tables, "" every context's.
+func (m *MainPage) setContextScope(context string) {
+	m.contextScope = context
+	m.podList.SetContextScope(context)
+	m.deploymentList.SetContextScope(context)
+}
+
+// syncContextScope goes back to every context's rows once the sub-tab's
+// context is deselected, or is the only one left.
+func (m *MainPage) syncContextScope(snapshot state.Snapshot) {
+	if m.contextScope != "" && !slices.Contains(contextScopes(snapshot), m.contextScope) {
+		m.setContextScope("")
+	}
+}
+
+// renderContextTabs draws the Pods and Deployments tables' context
+// sub-tabs, the shown one in its context's accent; "" on other tabs or
+// with fewer than two contexts.
+func (m *MainPage) renderContextTabs(snapshot state.Snapshot) string {
+	if tab := m.tabs[m.activeTab]; tab != "Pods" && tab != "Deployments" {
+		return ""
+	}
+	scopes := contextScopes(snapshot)
+	if len(scopes) == 0 {
+		return ""
+	}
+
+	p := m.theme.Palette
+	parts := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		label := scope
+		if label == "" {
+			label = "all"
+		}
+		if scope != m.contextScope {
+			parts = append(parts, m.theme.Subtext0.Padding(0, 1).Render(label))
+			continue
+		}
+		fg, bg := p.Text, p.Surface1
+		if accent, ok := m.accents[scope]; ok {
+			fg, bg = p.Base, accent
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(fg).Background(bg).Bold(true).Padding(0, 1).Render(label))
+	}
+	return strings.Join(parts, m.theme.Overlay0.Render("│"))
+}
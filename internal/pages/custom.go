@@ -0,0 +1,79 @@
+package pages
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// promptResourceType (t, on the cr tab) discovers the resource types the
+// selected contexts serve, then asks which to show (see onAPIResources).
+func (m *MainPage) promptResourceType() tea.Cmd {
+	contexts := slices.Sorted(maps.Keys(m.appState.Snapshot().SelectedContexts))
+	if len(contexts) == 0 {
+		return nil
+	}
+	m.actionStatus = "Discovering resource types..."
+	return cmds.LoadAPIResourcesCmd(m.ctx, m.Client, contexts)
+}
+
+// onAPIResources opens the type prompt, completing from the discovered
+// types, with the one shown as its initial text. Anything
+// ResolveAPIResource takes is accepted: "cert-manager.io/v1/certificates",
+// "certificates.cert-manager.io", "cert".
+func (m *MainPage) onAPIResources(msg msgs.APIResourcesMsg) tea.Cmd {
+	m.actionStatus = ""
+	if msg.Err != nil {
+		m.reportError("", fmt.Sprintf("Resource types: %v", msg.Err))
+		return nil
+	}
+	var initial string
+	if current, ok := m.crList.Resource(); ok {
+		initial = current.String()
+	}
+	suggestions := make([]string, 0, len(msg.Resources))
+	for _, r := range msg.Resources {
+		suggestions = append(suggestions, r.String())
+	}
+	resources := msg.Resources
+	cmd := m.openPrompt("Resource type", "Group/version/resource, or a name, kind or short name:", initial, func(value string) tea.Cmd {
+		resource, ok := k8s.ResolveAPIResource(resources, value)
+		if !ok {
+			m.reportError("", fmt.Sprintf("Resource type: the selected contexts serve no listable type %q", value))
+			return nil
+		}
+		m.crList.SetResource(resource)
+		return m.loadCustomResourcesIfActive()
+	})
+	m.prompt.SetSuggestions(suggestions)
+	return cmd
+}
+
+// onCustomResources fills the cr tab with a context's resources, dropping
+// replies for a deselected context, a switched namespace or a type no
+// longer shown.
+func (m *MainPage) onCustomResources(msg msgs.CustomResourcesMsg) {
+	if ns, ok := m.appState.Snapshot().SelectedContexts[msg.Context]; !ok || ns != msg.Namespace {
+		return
+	}
+	if current, ok := m.crList.Resource(); !ok || current.String() != msg.Resource.String() {
+		return
+	}
+	if msg.Err != nil {
+		m.crList.SetError(msg.Context, k8s.ExplainError(msg.Err))
+		return
+	}
+	list := msg.List
+	if picked := m.appState.Snapshot().Namespaces[msg.Context]; len(picked) > 0 && msg.Resource.Namespaced {
+		list.Items = slices.DeleteFunc(slices.Clone(list.Items), func(r k8s.CustomResource) bool {
+			return !slices.Contains(picked, r.Namespace)
+		})
+	}
+	m.crList.SetList(msg.Context, list)
+}
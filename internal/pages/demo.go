@@ -0,0 +1,58 @@
+package pages
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/anonymize"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// demoNameKeys are the row fields demo mode learns names from; every
+// resource table's rows share the pod tables' keys for them.
+var demoNameKeys = []string{msgs.PodKeyName, msgs.PodKeyNamespace, msgs.PodKeyContext, msgs.PodKeyNode}
+
+// SetDemoMode turns on demo-safe rendering: contexts, namespaces, object
+// and node names, and IP addresses are shown as consistent pseudonyms (see
+// package anonymize), so the screen can be shared publicly. Only what's
+// drawn, copied to the clipboard or shared changes — every action still
+// runs against the real names.
+func (m *MainPage) SetDemoMode(on bool) {
+	if !on {
+		m.anonymizer = nil
+		return
+	}
+	if m.anonymizer == nil {
+		m.anonymizer = anonymize.New(nil)
+	}
+}
+
+// anonymized rewrites a rendered frame, or text leaving ktails, in demo
+// mode, first learning any names loaded since the last time.
+func (m *MainPage) anonymized(frame string) string {
+	if m.anonymizer == nil {
+		return frame
+	}
+	m.anonymizer.Learn(m.contextList.Names()...)
+
+	snapshot := m.appState.Snapshot()
+	for context, namespace := range snapshot.SelectedContexts {
+		m.anonymizer.Learn(context, namespace)
+		m.anonymizer.Learn(snapshot.Namespaces[context]...)
+	}
+	for _, rows := range [][]msgs.RowData{snapshot.Deployments, snapshot.Pods, snapshot.Services, snapshot.StatefulSets, snapshot.DaemonSets} {
+		for _, row := range rows {
+			for _, key := range demoNameKeys {
+				if v, ok := row[key].(string); ok {
+					m.anonymizer.Learn(v)
+				}
+			}
+		}
+	}
+	return m.anonymizer.Apply(frame)
+}
+
+// setClipboard puts text on the system clipboard, anonymized in demo mode
+// like the screen it was copied from.
+func (m *MainPage) setClipboard(text string) tea.Cmd {
+	return tea.SetClipboard(m.anonymized(text))
+}
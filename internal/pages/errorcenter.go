@@ -0,0 +1,94 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/textwidth"
+)
+
+// reportError logs an error to the error center (see state.AppState's
+// LogError) for context, "" for one not tied to a context. The newest
+// unseen one shows in the status bar until Esc dismisses it; all of them
+// stay listed in the error center (!).
+func (m *MainPage) reportError(context, text string) {
+	m.appState.LogError(context, text)
+	if m.showErrors {
+		m.errorPanel.SetEntries(m.appState.ErrorLog())
+	}
+}
+
+// openErrors shows the error center.
+func (m *MainPage) openErrors() {
+	m.errorPanel.SetEntries(m.appState.ErrorLog())
+	m.showErrors = true
+}
+
+// handleErrorPanelKey routes keys while the error center is open: Esc (or
+// !) closes it, marking everything listed seen; r retries the selected
+// error's context, x dismisses it, c clears the log.
+func (m *MainPage) handleErrorPanelKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Errors):
+		m.showErrors = false
+		m.appState.MarkErrorsSeen()
+		return nil
+	case key.Matches(msg, m.keys.Retry):
+		e, ok := m.errorPanel.Selected()
+		if !ok {
+			return nil
+		}
+		return m.retryContext(e.Context)
+	case key.Matches(msg, m.keys.Dismiss):
+		if e, ok := m.errorPanel.Selected(); ok {
+			m.appState.DismissError(e.ID)
+			m.errorPanel.SetEntries(m.appState.ErrorLog())
+		}
+		return nil
+	case key.Matches(msg, m.keys.ClearAll):
+		m.appState.ClearErrorLog()
+		m.errorPanel.SetEntries(nil)
+		return nil
+	}
+	return m.errorPanel.Update(msg)
+}
+
+// retryContext reopens every watch of a selected context, clearing the
+// error it was given up with — what r in the error center does for an
+// error tied to a context.
+func (m *MainPage) retryContext(context string) tea.Cmd {
+	if context == "" {
+		m.actionStatus = "Retry: that error isn't tied to a context"
+		return nil
+	}
+	namespace, ok := m.appState.Snapshot().SelectedContexts[context]
+	if !ok {
+		m.actionStatus = fmt.Sprintf("Retry: %s isn't loaded any more", context)
+		return nil
+	}
+	m.appState.ClearError(context)
+	s := m.appState.Snapshot()
+	m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
+	m.actionStatus = "Retrying " + context
+	return tea.Batch(
+		m.restartPodWatch(context, namespace),
+		m.restartDeploymentWatch(context, namespace),
+		m.restartServiceWatch(context, namespace),
+		m.restartStatefulSetWatch(context, namespace),
+		m.restartDaemonSetWatch(context, namespace),
+	)
+}
+
+// errorStatus is the status bar's note of the newest unseen error, its
+// first line only; "" if there's none.
+func (m *MainPage) errorStatus() string {
+	e, ok := m.appState.LatestUnseenError()
+	if !ok {
+		return ""
+	}
+	line, _, _ := strings.Cut(e.Text, "\n")
+	return "⚠ " + textwidth.Sanitize(line) + " · !: errors"
+}
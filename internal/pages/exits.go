@@ -0,0 +1,95 @@
+package pages
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/models"
+)
+
+// maxExitHistory bounds how many exits are kept per log source.
+const maxExitHistory = 50
+
+// exitHistory is the container exits seen for one log source this session.
+// A pod's status only keeps its latest exit, so an intermittent crash is
+// only on record here once the next one has replaced it.
+type exitHistory struct {
+	exits []k8s.ContainerTermination
+	seen  map[exitKey]bool
+}
+
+// exitKey identifies one exit across repeated sightings in pod status —
+// first as the container's current state, then as its last state once it
+// restarts.
+type exitKey struct {
+	finishedAt int64
+	exitCode   int32
+}
+
+// recordExits adds the exits the Pods watch cache now reports for every
+// open log source in context. A source's first sighting (when it opens)
+// records without comment; exits seen after that are also marked in the
+// source's scrollback, and hold the source when the pane holds restarts.
+func (m *MainPage) recordExits(context string) {
+	st, ok := m.podWatchers[context]
+	if !ok || st.cache == nil {
+		return
+	}
+	for key, ls := range m.logStreams {
+		t := ls.target
+		if t.context != context {
+			continue
+		}
+		h, known := m.exitHistories[key]
+		if !known {
+			h = &exitHistory{seen: make(map[exitKey]bool)}
+			m.exitHistories[key] = h
+		}
+		for _, e := range st.cache.Terminations(t.context, t.namespace, t.pod) {
+			if e.Container != t.cntnr {
+				continue
+			}
+			k := exitKey{finishedAt: e.FinishedAt.UnixNano(), exitCode: e.ExitCode}
+			if h.seen[k] {
+				continue
+			}
+			h.seen[k] = true
+			h.exits = append(h.exits, e)
+			if len(h.exits) > maxExitHistory {
+				h.exits = h.exits[len(h.exits)-maxExitHistory:]
+			}
+			if known {
+				m.logPanes.AddNotice(key, fmt.Sprintf("container %s exited %d (%s)", e.Container, e.ExitCode, e.Reason))
+				if m.logTail.holdRestarts {
+					m.holdSource(key)
+				}
+			}
+		}
+	}
+}
+
+// openExitHistory opens the info panel on the exits recorded for the log
+// pane's active source, or for every source in a merge.
+func (m *MainPage) openExitHistory() {
+	keys := m.logPanes.Active().Keys()
+	title := fmt.Sprintf("Exit history: %d source(s)", len(keys))
+	if target, ok := m.logPanes.Active().ActiveSource(); ok {
+		keys = []string{target.SourceKey}
+		title = fmt.Sprintf("Exit history: %s/%s", target.Pod, target.Container)
+	}
+
+	var exits []k8s.ContainerTermination
+	for _, key := range keys {
+		if h, ok := m.exitHistories[key]; ok {
+			exits = append(exits, h.exits...)
+		}
+	}
+	sort.SliceStable(exits, func(i, j int) bool {
+		return exits[i].FinishedAt.After(exits[j].FinishedAt)
+	})
+
+	m.panelKey = "exits"
+	m.showPanel = true
+	m.infoPanel.SetContent(title, models.ExitHistoryLines(exits))
+}
@@ -0,0 +1,135 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// idlePause parks a forgotten session: after the configured idle period
+// with no key pressed, every watch and log stream is closed — freeing their
+// connections on the API servers — until the next key resumes them.
+type idlePause struct {
+	after     time.Duration // 0 disables it
+	lastInput time.Time
+	parked    bool
+	parkedAt  time.Time
+}
+
+// SetIdlePause sets how long ktails may sit without input before its
+// streams are parked; 0 never parks them.
+func (m *MainPage) SetIdlePause(after time.Duration) {
+	m.idle.after = after
+}
+
+// noteInput records user activity, restarting the idle clock.
+func (m *MainPage) noteInput() {
+	m.idle.lastInput = time.Now()
+}
+
+// parkIfIdle parks the streams once the idle period has run out.
+func (m *MainPage) parkIfIdle() {
+	if m.idle.after <= 0 || m.idle.parked || time.Since(m.idle.lastInput) < m.idle.after {
+		return
+	}
+	m.idle.parked = true
+	m.idle.parkedAt = time.Now()
+
+	parkWatches(m.podWatchers)
+	parkWatches(m.deploymentWatchers)
+	parkWatches(m.serviceWatchers)
+	parkWatches(m.stsWatchers)
+	parkWatches(m.dsWatchers)
+
+	// A previous instance's logs aren't followed; they end on their own.
+	if m.logTail.previous {
+		return
+	}
+	notice := fmt.Sprintf("paused after %s idle", shortDuration(m.idle.after))
+	for key, st := range m.logStreams {
+		st.generation++
+		if st.stream != nil {
+			st.stream.Close()
+			st.stream, st.scanner = nil, nil
+		}
+		m.logPanes.AddNotice(key, notice)
+	}
+}
+
+// parkWatches stops every watch in watchers without forgetting it, so
+// resumeFromIdle can reopen it the way "r" does; bumping the generation
+// drops anything the old watch still has in flight.
+func parkWatches[C any](watchers map[string]*resourceWatchState[C]) {
+	for _, st := range watchers {
+		st.generation++
+		if st.watcher != nil {
+			st.watcher.Stop()
+			st.watcher = nil
+		}
+	}
+}
+
+// resumeFromIdle reopens everything parkIfIdle closed: each context's
+// watches (their caches replay against a fresh list), and each log source
+// from its last line's timestamp, so nothing logged in between is lost.
+func (m *MainPage) resumeFromIdle() tea.Cmd {
+	m.idle.parked = false
+	m.noteInput()
+
+	var batch []tea.Cmd
+	for context, namespace := range m.appState.Snapshot().SelectedContexts {
+		batch = append(batch,
+			m.restartPodWatch(context, namespace),
+			m.restartDeploymentWatch(context, namespace),
+			m.restartServiceWatch(context, namespace),
+			m.restartStatefulSetWatch(context, namespace),
+			m.restartDaemonSetWatch(context, namespace),
+		)
+	}
+	if !m.logTail.previous {
+		for key, st := range m.logStreams {
+			if st.stream != nil {
+				continue
+			}
+			st.generation++
+			st.failures = 0
+			if st.lastTime.IsZero() {
+				batch = append(batch, m.openLogSourceCmd(key, st))
+				continue
+			}
+			st.resuming = true
+			st.skipAtLastTime = st.atLastTime
+			t := st.target
+			batch = append(batch, cmds.ResumePodLogStreamCmd(m.streamCtx(st), m.Client, t.context, t.namespace, t.pod, key, st.generation, m.logTail.options(t.cntnr), st.lastTime, 0))
+		}
+	}
+	m.actionStatus = fmt.Sprintf("Resumed streams paused at %s", m.idle.parkedAt.Format("15:04"))
+	return tea.Batch(batch...)
+}
+
+// renderIdleOverlay is the resume banner shown over the screen while the
+// streams are parked.
+func (m *MainPage) renderIdleOverlay() string {
+	p := styles.CatppuccinMocha()
+	box := lipgloss.NewStyle().
+		Foreground(p.Text).
+		Background(p.Surface0).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(p.Yellow).
+		Padding(1, 3).
+		Align(lipgloss.Center)
+
+	title := lipgloss.NewStyle().Foreground(p.Yellow).Bold(true).Render("⏸  Paused")
+	body := fmt.Sprintf("No input for %s, so watches and log streams were closed\nat %s to spare the clusters.",
+		shortDuration(m.idle.after), m.idle.parkedAt.Format("15:04"))
+	hint := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true).Render("Press any key to resume")
+
+	content := strings.Join([]string{title, "", body, "", hint}, "\n")
+	return lipgloss.Place(m.width, m.height-2, lipgloss.Center, lipgloss.Center, box.Render(content))
+}
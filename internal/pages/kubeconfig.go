@@ -0,0 +1,96 @@
+package pages
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// watchKubeconfigCmd starts watching the kubeconfig files, so contexts
+// added, removed or re-credentialed in them show up without a restart.
+func (m *MainPage) watchKubeconfigCmd() tea.Cmd {
+	w, err := m.Client.WatchKubeconfig()
+	if err != nil {
+		log.Printf("not reloading kubeconfig changes: %v", err)
+		return nil
+	}
+	m.kubeconfigWatcher = w
+	return cmds.WaitForKubeconfigChangeCmd(m.Client, w)
+}
+
+// onKubeconfigChanged brings the contexts pane in line with the reloaded
+// kubeconfig: removed contexts that were loaded are unloaded (with an
+// error overlay saying so), and loaded contexts whose cluster or
+// credentials changed are checked again and reopen their watches on fresh
+// clients.
+func (m *MainPage) onKubeconfigChanged(msg msgs.KubeconfigChangedMsg) tea.Cmd {
+	batch := []tea.Cmd{cmds.WaitForKubeconfigChangeCmd(m.Client, m.kubeconfigWatcher)}
+	if msg.Err != nil {
+		m.actionStatus = fmt.Sprintf("Kubeconfig not reloaded: %v", msg.Err)
+		return tea.Batch(batch...)
+	}
+	ch := msg.Change
+	if ch.Empty() {
+		return tea.Batch(batch...)
+	}
+
+	m.contextList.Reload()
+	selected := m.appState.Snapshot().SelectedContexts
+	var gone []string
+	for _, context := range ch.Removed {
+		if _, ok := selected[context]; ok {
+			m.removeContext(context)
+			gone = append(gone, context)
+		}
+	}
+	for _, context := range ch.Changed {
+		if namespace, ok := selected[context]; ok && !m.idle.parked {
+			batch = append(batch,
+				m.checkConnectionCmd(context),
+				m.restartPodWatch(context, namespace),
+				m.restartDeploymentWatch(context, namespace),
+				m.restartServiceWatch(context, namespace),
+				m.restartStatefulSetWatch(context, namespace),
+				m.restartDaemonSetWatch(context, namespace),
+			)
+		}
+	}
+
+	snapshot := m.appState.Snapshot()
+	m.deploymentList.SetRows(snapshot.Deployments)
+	m.podList.SetRows(snapshot.Pods)
+	m.svcList.SetRows(snapshot.Services)
+	m.stsList.SetRows(snapshot.StatefulSets)
+	m.dsList.SetRows(snapshot.DaemonSets)
+	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+	if len(snapshot.SelectedContexts) == 0 {
+		m.appStateLoaded = false
+		m.updateFocusStates()
+	}
+
+	if len(gone) > 0 {
+		m.reportError("", fmt.Sprintf("Removed from the kubeconfig, so unloaded: %s", strings.Join(gone, ", ")))
+	}
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		verb string
+	}{{len(ch.Added), "added"}, {len(ch.Removed), "removed"}, {len(ch.Changed), "changed"}} {
+		if p.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", p.n, p.verb))
+		}
+	}
+	m.actionGen++
+	m.actionStatus = "↻ kubeconfig reloaded: " + strings.Join(parts, ", ")
+	gen := m.actionGen
+	batch = append(batch, tea.Tick(actionNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodActionClearMsg{Generation: gen}
+	}))
+	return tea.Batch(batch...)
+}
@@ -0,0 +1,51 @@
+package pages
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// compareLogs (d, on the Pods tab) tails the two checked pods in a pair of
+// log panes side by side, in place of what the Log area showed: canary
+// against stable, say, or one service in two clusters. The pair scroll
+// together to the same time and share a search and level filter (see
+// models.LogPanes.StartCompare); N or X on the panes ends the pairing.
+func (m *MainPage) compareLogs() tea.Cmd {
+	var rows []msgs.RowData
+	for _, key := range m.podList.CheckedKeys() {
+		if row := m.podList.CheckedRow(key); row != nil {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) != 2 {
+		m.reportError("", "Compare logs: check two pods (space), then d")
+		return nil
+	}
+
+	m.closeLogs()
+	first := m.reconcilePodLogs(rows[:1])
+	m.logPanes.Add()
+	second := m.reconcilePodLogs(rows[1:])
+	if !m.logPanes.StartCompare() {
+		return tea.Batch(first, second)
+	}
+	m.applyContentSizes()
+	m.updateFocusStates()
+	m.actionStatus = "Comparing logs · / searches both panes, scrolling one scrolls the other"
+	return tea.Batch(first, second)
+}
+
+// promptLogSearch (/, in the log pane) asks for text to narrow the active
+// log pane to the lines containing it, or both panes while comparing; an
+// empty answer clears it.
+func (m *MainPage) promptLogSearch() tea.Cmd {
+	label := "Show only lines containing (any case; empty for all):"
+	if m.logPanes.Comparing() {
+		label = "Show only lines containing, in both panes (any case; empty for all):"
+	}
+	return m.openOptionalPrompt("Search logs", label, m.logPanes.Active().Search(), func(text string) tea.Cmd {
+		m.logPanes.SetSearch(text)
+		return nil
+	})
+}
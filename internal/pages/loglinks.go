@@ -0,0 +1,106 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// SetLogLinks installs the configured log store links; with none, "H" is
+// disabled.
+func (m *MainPage) SetLogLinks(links []config.LogLink) {
+	m.logLinks = links
+	m.keys.History.SetEnabled(len(links) > 0)
+}
+
+// copyPodHistoryLink copies the link to the Pods row under the cursor's
+// logs in the log store, over the link's window up to now.
+func (m *MainPage) copyPodHistoryLink() tea.Cmd {
+	row := m.podList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	var data config.LogLinkData
+	data.Pod, _ = row[msgs.PodKeyName].(string)
+	data.Namespace, _ = row[msgs.PodKeyNamespace].(string)
+	data.Context, _ = row[msgs.PodKeyContext].(string)
+	link, ok := m.logLink(data.Context)
+	if !ok {
+		return nil
+	}
+	data.To = time.Now()
+	data.From = data.To.Add(-link.Span())
+	return m.copyHistoryLink(link, data)
+}
+
+// copyLogHistoryLink copies the link to the log pane's pod in the log
+// store, over the time its selected lines span (every shown line's without
+// a selection). A merge of several pods has to be narrowed to one first;
+// several containers of one pod link the pod.
+func (m *MainPage) copyLogHistoryLink() tea.Cmd {
+	pane := m.logPanes.Active()
+	target, ok := pane.ActiveSource()
+	if !ok {
+		for i, key := range pane.Keys() {
+			src, _ := pane.Source(key)
+			if i == 0 {
+				target, ok = src, true
+				target.Container = ""
+			} else if src.Context != target.Context || src.Namespace != target.Namespace || src.Pod != target.Pod {
+				m.reportError("", "Several pods in this pane: press c to isolate one first")
+				return nil
+			}
+		}
+	}
+	if !ok {
+		return nil
+	}
+	link, ok := m.logLink(target.Context)
+	if !ok {
+		return nil
+	}
+	from, to, ok := pane.TimeSpan()
+	if !ok {
+		m.reportError("", "No timestamped lines to take the time range from")
+		return nil
+	}
+	data := config.LogLinkData{
+		Context:   target.Context,
+		Namespace: target.Namespace,
+		Pod:       target.Pod,
+		Container: target.Container,
+		From:      from,
+		// A query's end is exclusive in Loki; a second past the last line
+		// keeps it in.
+		To: to.Add(time.Second),
+	}
+	return m.copyHistoryLink(link, data)
+}
+
+// logLink is the first log link matching context, reporting when there's
+// none.
+func (m *MainPage) logLink(context string) (config.LogLink, bool) {
+	for _, link := range m.logLinks {
+		if link.Matches(context) {
+			return link, true
+		}
+	}
+	m.reportError(context, fmt.Sprintf("No log_links entry matches context %s", context))
+	return config.LogLink{}, false
+}
+
+// copyHistoryLink renders link for data and puts it on the clipboard.
+func (m *MainPage) copyHistoryLink(link config.LogLink, data config.LogLinkData) tea.Cmd {
+	url, err := link.Render(data)
+	if err != nil {
+		m.reportError(data.Context, fmt.Sprintf("Log link for %s: %v", data.Pod, err))
+		return nil
+	}
+	m.actionStatus = fmt.Sprintf("Copied the log history link for %s (%s – %s)", data.Pod,
+		data.From.Local().Format("15:04:05"), data.To.Local().Format("15:04:05"))
+	return m.setClipboard(url)
+}
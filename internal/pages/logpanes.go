@@ -0,0 +1,58 @@
+package pages
+
+import (
+	"fmt"
+
+	"github.com/ktails/ktails/internal/tui/models"
+)
+
+// addLogPane (N, in the log pane) splits off a new, empty log pane and
+// makes it the active one: Esc back to the Pods tab, then l tails the
+// rows into it, leaving the other panes as they are.
+func (m *MainPage) addLogPane() {
+	if !m.logPanes.Add() {
+		m.reportError("", fmt.Sprintf("Log panes: %d is the most; X closes the active one", models.MaxLogPanes))
+		return
+	}
+	m.actionStatus = "New log pane · Esc, then l on a Pods row tails into it"
+	m.applyContentSizes()
+	m.updateFocusStates()
+}
+
+// closeLogPane (X, in the log pane) closes the active log pane, stopping
+// its sources' streams. Closing the only one closes the Log area, as Esc
+// does.
+func (m *MainPage) closeLogPane() {
+	keys, ok := m.logPanes.CloseActive()
+	if !ok {
+		m.closeLogs()
+		m.applyContentSizes()
+		m.updateFocusStates()
+		return
+	}
+	for _, key := range keys {
+		m.forgetLogSource(key)
+	}
+	m.applyContentSizes()
+	m.updateFocusStates()
+}
+
+// emptyLogPane closes every source in the active log pane, leaving the
+// others; with a single pane it's closeLogs.
+func (m *MainPage) emptyLogPane() {
+	if m.logPanes.Len() == 1 {
+		m.closeLogs()
+		return
+	}
+	for _, key := range m.logPanes.Active().Keys() {
+		m.forgetLogSource(key)
+	}
+}
+
+// forgetLogSource closes a source (see closeLogSource) and drops the
+// event and ended bookkeeping MainPage keeps for it.
+func (m *MainPage) forgetLogSource(key string) {
+	m.closeLogSource(key)
+	delete(m.eventSources, key)
+	delete(m.endedSources, key)
+}
@@ -0,0 +1,143 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+)
+
+// sincePresets are the windows the log pane's "T" steps through. The first,
+// 0, is the configured default: log_since if set, else the last tail_lines
+// lines.
+var sincePresets = []time.Duration{0, 5 * time.Minute, 15 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// logTail is how the log pane's streams are opened: the configured
+// tail_lines/log_since, overridden by the pane's since preset and its
+// previous-instance toggle. holdRestarts is the pane's choice of what a
+// container restart does to its sources (see restarts.go); warnLines is
+// backlog_warn_lines, and backlog and sampleEvery the pane's answer once a
+// source's backfill passed it (see backlog.go).
+type logTail struct {
+	tailLines    int64
+	since        time.Duration
+	preset       int // index into sincePresets
+	previous     bool
+	holdRestarts bool
+	warnLines    int
+	backlog      backlogMode
+	sampleEvery  int
+}
+
+// options returns the stream options for one of the pane's containers.
+func (t logTail) options(container string) k8s.LogOptions {
+	opts := k8s.LogOptions{Container: container, Previous: t.previous}
+	switch {
+	case sincePresets[t.preset] > 0:
+		opts.Since = sincePresets[t.preset]
+	case t.since > 0:
+		opts.Since = t.since
+	default:
+		opts.TailLines = t.tailLines
+	}
+	return opts
+}
+
+// mode labels the overrides in effect for the pane's header, "" for none.
+func (t logTail) mode() string {
+	mode := ""
+	if d := sincePresets[t.preset]; d > 0 {
+		mode = "since " + shortDuration(d)
+	}
+	if t.previous {
+		if mode != "" {
+			mode += ", "
+		}
+		mode += "previous"
+	}
+	if t.holdRestarts {
+		if mode != "" {
+			mode += ", "
+		}
+		mode += "hold on restart"
+	}
+	return mode
+}
+
+// shortDuration formats a preset as "5m", "1h" rather than "5m0s".
+func shortDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+// openLogSourceCmd opens source key's stream with the pane's current
+// options.
+func (m *MainPage) openLogSourceCmd(key string, st *logStreamState) tea.Cmd {
+	t := st.target
+	return cmds.OpenPodLogStreamCmd(m.streamCtx(st), m.Client, t.context, t.namespace, t.pod, key, st.generation, m.logTail.options(t.cntnr))
+}
+
+// togglePreviousLogs switches the pane between the containers' current
+// and previous (last terminated) instances.
+func (m *MainPage) togglePreviousLogs() tea.Cmd {
+	m.logTail.previous = !m.logTail.previous
+	return m.reopenLogSources()
+}
+
+// cycleLogSince steps the pane to the next since preset.
+func (m *MainPage) cycleLogSince() tea.Cmd {
+	m.logTail.preset = (m.logTail.preset + 1) % len(sincePresets)
+	return m.reopenLogSources()
+}
+
+// reopenLogSources restarts every source in the pane — including ones
+// whose stream already ended — with the current options, each on a new
+// generation so lines still in flight from the old stream are dropped.
+// A pane template's events source has no stream, and is left as is.
+func (m *MainPage) reopenLogSources() tea.Cmd {
+	mode := m.logTail.mode()
+	m.logPanes.SetMode(mode)
+	notice := "Reopening with the default tail..."
+	if mode != "" {
+		notice = fmt.Sprintf("Reopening (%s)...", mode)
+	}
+
+	var openCmds []tea.Cmd
+	for _, key := range m.logPanes.Keys() {
+		if _, events := m.eventSources[key]; events {
+			continue
+		}
+		st, ok := m.logStreams[key]
+		if ok {
+			if st.stream != nil {
+				st.stream.Close()
+			}
+			st.endStream()
+			*st = logStreamState{target: st.target, generation: st.generation + 1}
+		} else {
+			src, ok := m.logPanes.Source(key)
+			if !ok {
+				continue
+			}
+			st = &logStreamState{
+				target: podLogTarget{
+					key:       key,
+					context:   src.Context,
+					namespace: src.Namespace,
+					pod:       src.Pod,
+					cntnr:     src.Container,
+				},
+				generation: 1,
+			}
+			m.logStreams[key] = st
+		}
+		m.logPanes.RestartSource(key, notice)
+		openCmds = append(openCmds, m.openLogSourceCmd(key, st))
+	}
+	return tea.Batch(openCmds...)
+}
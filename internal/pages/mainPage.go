@@ -3,26 +3,43 @@ package pages
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"image/color"
 	"io"
 	"log"
+	"maps"
+	"math"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/term"
 	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/ktails/ktails/internal/alerts"
+	"github.com/ktails/ktails/internal/anonymize"
+	"github.com/ktails/ktails/internal/config"
 	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/share"
+	"github.com/ktails/ktails/internal/startup"
 	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/textwidth"
 	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/keys"
 	"github.com/ktails/ktails/internal/tui/models"
 	"github.com/ktails/ktails/internal/tui/msgs"
 	"github.com/ktails/ktails/internal/tui/styles"
 	"github.com/ktails/ktails/internal/tui/views"
+	"github.com/ktails/ktails/internal/version"
 )
 
 type focusTarget int
@@ -51,24 +68,257 @@ type MainPage struct {
 	deploymentList   *models.DeploymentPage
 	podList          *models.PodPage
 	svcList          *models.ServicePage
+	stsList          *models.WorkloadPage
+	dsList           *models.WorkloadPage
+	topList          *models.TopPage
+	nodeList         *models.NodePage
+	ingList          *models.IngressPage
+	crList           *models.CustomResourcePage
+	watchList        *models.WatchlistPage
 	deploymentDetail *models.ResourceDetailPage
 	focus            focusTarget
 
 	// k8s client
 	Client *k8s.Client
 
+	// accessChecks is whether access reviews are on (see access.go).
+	accessChecks bool
+
+	// slow holds the contexts reportSlow marked, until one of their
+	// watches opens.
+	slow map[string]bool
+
+	// ctx is what every API call and stream runs under, cancelled on quit;
+	// kubeCalls are its per-kube-context children (see calls.go).
+	ctx       context.Context
+	cancel    context.CancelFunc
+	kubeCalls map[string]kubeCall
+
+	// keys is the keymap registry the status bar hints are generated from,
+	// and hints renders them (see renderHints).
+	keys  keys.KeyMap
+	hints help.Model
+
 	// UI overlays
-	errorMessage string
-	showHelp     bool
-
-	// Auto-refresh — a self-rescheduling tick. Table data itself is now kept
-	// current by the watch streams below; the tick's only remaining job is to
-	// re-render Age text from the local watch caches (no API calls). Paused
-	// (tick still reschedules, but the re-render is skipped) while the Detail
-	// or Log pane is open, since a background reorder under a pinned pane is
-	// more disruptive than helpful.
+	showHelp   bool
+	helpOffset int
+
+	// build identifies the running binary, for the status bar and the
+	// build info overlay (I).
+	build version.Info
+
+	// accents are the selected contexts' colors (see syncAccents), and
+	// accentsFor the selection they were assigned for.
+	accents    map[string]color.Color
+	accentsFor string
+
+	// trace times the startup for --startup-trace; nil when it's off.
+	trace *startup.Trace
+
+	// Deselected contexts waiting out their undo grace period (see
+	// parkContext), each with the generation of its park; lastParked is the
+	// batch U restores, and undoStatus the notice offering it.
+	parked     map[string]int
+	parkGen    int
+	lastParked []string
+	undoStatus string
+
+	// Info panel — a modal scrollable overlay for one-off inspection views
+	// (a pod's resolved env, ...). panelKey identifies what it was opened
+	// for, so a late reply for a since-closed or replaced panel is dropped.
+	infoPanel *models.InfoPanel
+	showPanel bool
+	panelKey  string
+	// rollout is the rollout the panel last showed, for u to undo.
+	rollout msgs.RolloutMsg
+	// podMetrics is the scrape the panel last showed, for r to scrape
+	// again and work out rates since.
+	podMetrics msgs.PodMetricsMsg
+
+	// File browser — a modal overlay listing/reading a container's files
+	// over exec. filesGen guards its listing/read replies against a browser
+	// since closed or navigated elsewhere.
+	fileBrowser *models.FileBrowserPage
+	showFiles   bool
+	filesGen    int
+
+	// Prompt — a modal one-line text input; Enter hands its value to
+	// promptAction (e.g. starting a copy to the entered path). A blank
+	// value is ignored unless promptAllowBlank (see openOptionalPrompt).
+	// promptCancel, when set, runs on Esc — for a question that can't be
+	// left unanswered.
+	prompt           *models.PromptDialog
+	showPrompt       bool
+	promptAction     func(value string) tea.Cmd
+	promptAllowBlank bool
+	promptCancel     func() tea.Cmd
+
+	// screen is where the last frame drew what the mouse can click on.
+	screen screenLayout
+	// mouse turns on mouse reporting (see mouse.go); without it the
+	// terminal keeps its own text selection.
+	mouse bool
+
+	// commandLine is the ":" command line, shown in place of the status
+	// bar while showCommand (see commands.go).
+	commandLine *models.CommandLine
+	showCommand bool
+
+	// backlogPrompting is set while the large-backlog question is open
+	// (see backlog.go), so sources passing the threshold meanwhile wait on
+	// the same answer.
+	backlogPrompting bool
+
+	// Confirmation modal — guards the Pods tab's destructive actions (delete
+	// pod, restart its deployment). actionStatus is the last finished
+	// action's status bar notice; actionGen guards its timed clear.
+	confirm       *models.ConfirmDialog
+	showConfirm   bool
+	confirmAction func() tea.Cmd
+	actionGen     int
+	actionStatus  string
+
+	// Session restore (see session.go): savedSession is the one offered at
+	// startup; restoreTab and restorePods are what's left to apply of the
+	// one being restored, restoredLogRows the pods reopened so far.
+	// quitSession is captured on quit for main to save. startSelection is
+	// the context (and namespace) to load instead, when one was given.
+	savedSession    *config.Session
+	startSelection  map[string][]string
+	restoreTab      string
+	restorePods     map[string][]config.SessionPod
+	restoredLogRows []msgs.RowData
+	quitSession     *config.Session
+
+	// state is the state file's contents (see SetState), saved back on
+	// quit with the contexts pane's order.
+	state *config.State
+
+	// watchlist is what's pinned (w) to the watch tab, in the order it was
+	// pinned; saved with the state.
+	watchlist []config.WatchedResource
+
+	// requestBudget is the per-context API load past which deferrable
+	// requests are held back (see budget.go).
+	requestBudget k8s.RequestBudget
+
+	// Pod copy — one background copy out of a container at a time, started
+	// from the file browser. copyGen guards its updates the same way
+	// filesGen does for listings; copyStatus is its status bar notice.
+	copyGen     int
+	copyCancel  context.CancelFunc
+	copyUpdates <-chan msgs.PodCopyProgress
+	copyLabel   string
+	copyStatus  string
+
+	// Port-forwards — run by forwards in the background, surviving tab
+	// switches and overlays alike, until stopped from the panel or torn down
+	// on quit.
+	forwards     *k8s.PortForwardManager
+	forwardPanel *models.PortForwardPanel
+	showForwards bool
+
+	// Error center (see errorcenter.go)
+	errorPanel *models.ErrorPanel
+	showErrors bool
+
+	// Alerts (see alerts.go): the configured rules, nil without any, and
+	// their panel. alertFlash is set while the status bar flashes for the
+	// latest, until the AlertFlashEndMsg of generation alertGen.
+	alerts     *alerts.Engine
+	alertPanel *models.AlertPanel
+	showAlerts bool
+	alertFlash bool
+	alertGen   int
+
+	// Audit log panel (see audit.go), reviewing what the client recorded.
+	auditPanel *models.AuditPanel
+	showAudit  bool
+
+	// Namespace picker — "n" on a context chooses the namespaces it loads
+	// from (see applyNamespaces).
+	nsPicker     *models.NamespacePicker
+	showNSPicker bool
+
+	// lockedKeys are the actions read-only mode turned off (see
+	// SetReadOnly), which help still lists, greyed out.
+	lockedKeys []key.Binding
+
+	// Profile picker — "p" in the contexts pane switches the client to
+	// another profile's kubeconfig (see switchProfile). profiles is empty
+	// without any configured; defaultKubeconfig is the files ktails started
+	// on, for the profiles that don't name their own.
+	profilePicker     *models.ProfilePicker
+	showProfiles      bool
+	profiles          []config.Profile
+	activeProfile     string
+	defaultKubeconfig []string
+
+	// Column chooser — "C" on the Pods or Deployments tab picks the
+	// columns its table shows (see handleColumnChooserKey).
+	colChooser  *models.ColumnChooser
+	showColumns bool
+
+	// selectors narrows each resource tab's watches by a label/field
+	// selector expression (see k8s.ParseSelectors), keyed by tab name and
+	// set with ":"; absent means everything.
+	selectors map[string]string
+
+	// theme styles the chrome MainPage renders itself every frame (status
+	// bar, pane divider, loading badge).
+	theme *styles.Theme
+
+	// logLevelSwitches are the configured log level actions (see
+	// config.LogLevelSwitch), offered with "V" in the log pane.
+	logLevelSwitches []config.LogLevelSwitch
+	// sharer posts log lines to the configured paste service ("S" in the
+	// log pane); nil when sharing is off.
+	sharer *share.Sharer
+	// logLinks are the configured log store links "H" copies, from the
+	// Pods tab or the log pane (see config.LogLink).
+	logLinks []config.LogLink
+	// paneTemplates are the configured layouts "o" opens for a deployment
+	// (see config.PaneTemplate).
+	paneTemplates []config.PaneTemplate
+	// watermarks badge the panes of matching contexts (see watermark.go).
+	watermarks []config.Watermark
+
+	// Auto-refresh — a self-rescheduling tick. Table data itself is kept
+	// current by the watch streams below; the tick re-renders Age text from
+	// the local watch caches and resyncs the Pods and Deployments caches
+	// against a fresh list (see resyncTables). Paused (tick still
+	// reschedules, but both are skipped) while the Detail or Log pane is
+	// open, since a background reorder under a pinned pane is more
+	// disruptive than helpful, and switched off altogether with R.
+	// resyncing counts each context's resyncs still out; syncSpinner spins
+	// in the status bar while any are, spinning tracking whether its tick
+	// loop is running.
 	autoRefresh     bool
 	refreshInterval time.Duration
+	refreshGen      int
+	resyncing       map[string]int
+	syncSpinner     spinner.Model
+	spinning        bool
+
+	// splitPercent is the detail/log pane's share of the tab content area
+	// (see resizeSplit).
+	splitPercent int
+
+	// contextsCollapsed is whether the contexts pane is collapsed to a
+	// strip of badges when unfocused; stripShown whether the tab area is
+	// laid out around the strip (see collapse.go).
+	contextsCollapsed bool
+	stripShown        bool
+
+	// checking holds the contexts whose connectivity check is out (see
+	// connectivity.go), so the heartbeat doesn't stack a second on one.
+	checking map[string]bool
+
+	// unfocused is set while the terminal reports it has lost focus: the
+	// tick slows down by unfocusedRefreshFactor, and watch events only update
+	// their caches, their rows applied in one go on the next tick or on
+	// refocus (see flushDeferredWatchRows).
+	unfocused bool
 
 	// Watch streams — one per resource type per selected context, replacing
 	// the old poll-on-a-timer refresh with a k9s-style Watch()-backed local
@@ -79,6 +329,8 @@ type MainPage struct {
 	podWatchers        map[string]*resourceWatchState[*cmds.PodWatchCache]
 	deploymentWatchers map[string]*resourceWatchState[*cmds.DeploymentWatchCache]
 	serviceWatchers    map[string]*resourceWatchState[*cmds.ServiceWatchCache]
+	stsWatchers        map[string]*resourceWatchState[*cmds.StatefulSetWatchCache]
+	dsWatchers         map[string]*resourceWatchState[*cmds.DaemonSetWatchCache]
 
 	// Detail pane — a cross-cutting bottom split opened by Enter from
 	// Deployments or Pods. It's not a peer tab: it stays put, splitting
@@ -90,28 +342,96 @@ type MainPage struct {
 	// one or more checked Pods rows (or the row under the cursor, if none
 	// are checked). Mutually exclusive with the Detail pane: opening one
 	// closes the other. Every checked pod's containers become one source
-	// each, merged into a single scrollback; logStreams holds the live
-	// stream/scanner/generation per source, keyed the same way as
-	// podLogs' sources. Generation guards against messages from a
+	// each, merged into a single scrollback in the active one of logPanes'
+	// panes (N splits off another, see addLogPane); logStreams holds the
+	// live stream/scanner/generation per source, keyed the same way as
+	// logPanes' sources. Generation guards against messages from a
 	// since-superseded stream for that specific source (old pod switched
 	// out, or the whole pane closed) without affecting other open sources.
-	podLogs     *models.LogPage
+	logPanes    *models.LogPanes
 	showLogs    bool
 	logsFocused bool
 	logStreams  map[string]*logStreamState
+	// logTail is what the pane's streams open with (see logtail.go).
+	logTail logTail
+	// idle parks every stream after a stretch without input (see idle.go).
+	idle idlePause
+	// endedSources are sources whose stream ended, awaiting their pod's
+	// completion summary (see summary.go).
+	endedSources map[string]podLogTarget
+	// eventSources are the pane's event sources opened by a pane template
+	// (see templates.go), keyed like logPanes' sources.
+	eventSources map[string]*eventSource
+	// exitHistories records the container exits seen per log source, kept
+	// for the session even once the source is closed (see exits.go).
+	exitHistories map[string]*exitHistory
+	// lineages are the pods of the pane's sources as the Pods watch last
+	// saw them, for following a source into the pod that replaces its own
+	// (see recreate.go).
+	lineages map[string]k8s.PodLineage
+
+	// kubeconfigWatcher reports writes to the kubeconfig files (see
+	// kubeconfig.go).
+	kubeconfigWatcher *k8s.KubeconfigWatcher
+
+	// anonymizer, set in demo mode, rewrites names and IPs in every frame
+	// (see demo.go).
+	anonymizer *anonymize.Anonymizer
 
 	tableW, tableH int
+	// chipRowShown is whether the filter chip line currently takes a row
+	// above the tables (see chips.go).
+	chipRowShown bool
+
+	// contextScope is the context sub-tab the Pods and Deployments tables
+	// show, "" for all of them (see contexttabs.go).
+	contextScope string
 }
 
 // logStreamState is the live stream-plumbing state for one open log
 // source, keyed by the same context/namespace/pod/container key used in
-// models.LogPage.
+// models.LogPage. lastTime and atLastTime record where the source got to
+// (the last line's timestamp, and how many lines carried exactly that
+// timestamp) so a dropped stream can resume from there; while resuming,
+// skipAtLastTime counts down the already-seen lines the reopened stream
+// replays from that second.
 type logStreamState struct {
+	target     podLogTarget
 	stream     io.ReadCloser
 	scanner    *bufio.Scanner
 	generation int
+	// ctx/cancel are the source's stream context (see streamCtx).
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lastTime       time.Time
+	atLastTime     int
+	resuming       bool
+	skipAtLastTime int
+	failures       int
+
+	// held is set once the container restarted while the pane holds
+	// restarts: the stream isn't reopened on the new instance until F.
+	held bool
+
+	// The backfill guard (see backlog.go): openedAt is when the stream
+	// first opened, lines stamped before it being backlog, counted in
+	// backlog until one from the present sets live. pending is the line
+	// the source paused at, unread past it, while its backlogMode is
+	// undecided; sampleEvery is N when sampling.
+	openedAt    time.Time
+	live        bool
+	backlog     int
+	backlogMode backlogMode
+	sampleEvery int
+	pending     *msgs.LogLineMsg
 }
 
+// maxLogReconnects bounds consecutive reconnects of a dropped log stream
+// that deliver no new lines — a container that has exited closes every
+// reopened stream straight away.
+const maxLogReconnects = 3
+
 // resourceWatchState is the live watch-plumbing state for one resource
 // type's Watch() stream against one context: the current generation (bumped
 // on every restart, guarding against stale in-flight messages), the open
@@ -123,8 +443,32 @@ type resourceWatchState[C any] struct {
 	watcher    watch.Interface
 	cache      C
 	failures   int
+
+	// deferred holds the latest rows received while the terminal was
+	// unfocused, not yet applied; hasDeferred tells an empty row set apart
+	// from none.
+	deferred    []msgs.RowData
+	hasDeferred bool
+}
+
+// deferRows keeps rows to apply later, replacing any kept before: each
+// event carries the context's full row set.
+func (st *resourceWatchState[C]) deferRows(rows []msgs.RowData) {
+	st.deferred = rows
+	st.hasDeferred = true
+}
+
+// takeDeferred returns and clears the kept rows, ok false if there are none.
+func (st *resourceWatchState[C]) takeDeferred() (rows []msgs.RowData, ok bool) {
+	rows, ok = st.deferred, st.hasDeferred
+	st.deferred, st.hasDeferred = nil, false
+	return rows, ok
 }
 
+// unfocusedRefreshFactor is how much slower the refresh tick runs while the
+// terminal is unfocused.
+const unfocusedRefreshFactor = 6
+
 // maxWatchReconnectFailures is how many consecutive reconnect failures a
 // context+resource watch tolerates before giving up and surfacing an error,
 // rather than backing off forever against a genuinely broken context (bad
@@ -158,9 +502,8 @@ func NewMainPageModel(c *k8s.Client, refreshIntervalSeconds int) *MainPage {
 	pList := models.NewPodPageModel(c)
 	svcList := models.NewServicePageModel(c)
 	detailPage := models.NewResourceDetailPage()
-	logPage := models.NewLogPage()
 	tabs := styles.DefaultTabs
-	tabs = append(tabs, "svc")
+	tabs = append(tabs, "svc", "sts", "ds", "top", "nodes", "ing", "cr", "watch")
 
 	if refreshIntervalSeconds < 1 {
 		refreshIntervalSeconds = 5
@@ -168,6 +511,8 @@ func NewMainPageModel(c *k8s.Client, refreshIntervalSeconds int) *MainPage {
 
 	m := &MainPage{
 		Client:             c,
+		keys:               keys.Default(),
+		hints:              help.New(),
 		appState:           state.NewAppState(),
 		tabs:               tabs,
 		tabContent:         "",
@@ -175,27 +520,123 @@ func NewMainPageModel(c *k8s.Client, refreshIntervalSeconds int) *MainPage {
 		deploymentList:     depList,
 		podList:            pList,
 		svcList:            svcList,
+		stsList:            models.NewStatefulSetPage(c),
+		dsList:             models.NewDaemonSetPage(c),
+		topList:            models.NewTopPage(),
+		nodeList:           models.NewNodePage(),
+		ingList:            models.NewIngressPage(),
+		crList:             models.NewCustomResourcePage(),
+		watchList:          models.NewWatchlistPage(),
 		deploymentDetail:   detailPage,
-		podLogs:            logPage,
+		logPanes:           models.NewLogPanes(),
+		infoPanel:          models.NewInfoPanel(),
+		fileBrowser:        models.NewFileBrowserPage(),
+		prompt:             models.NewPromptDialog(),
+		commandLine:        models.NewCommandLine(),
+		confirm:            models.NewConfirmDialog(),
+		forwards:           k8s.NewPortForwardManager(c),
+		forwardPanel:       models.NewPortForwardPanel(),
+		errorPanel:         models.NewErrorPanel(),
+		alertPanel:         models.NewAlertPanel(),
+		auditPanel:         models.NewAuditPanel(),
+		nsPicker:           models.NewNamespacePicker(),
+		profilePicker:      models.NewProfilePicker(),
+		colChooser:         models.NewColumnChooser(),
+		theme:              styles.Mocha(),
+		selectors:          make(map[string]string),
 		logStreams:         make(map[string]*logStreamState),
+		eventSources:       make(map[string]*eventSource),
+		endedSources:       make(map[string]podLogTarget),
+		logTail:            logTail{tailLines: config.DefaultTailLines},
+		exitHistories:      make(map[string]*exitHistory),
+		lineages:           make(map[string]k8s.PodLineage),
 		podWatchers:        make(map[string]*resourceWatchState[*cmds.PodWatchCache]),
+		parked:             make(map[string]int),
+		slow:               make(map[string]bool),
 		deploymentWatchers: make(map[string]*resourceWatchState[*cmds.DeploymentWatchCache]),
 		serviceWatchers:    make(map[string]*resourceWatchState[*cmds.ServiceWatchCache]),
+		stsWatchers:        make(map[string]*resourceWatchState[*cmds.StatefulSetWatchCache]),
+		dsWatchers:         make(map[string]*resourceWatchState[*cmds.DaemonSetWatchCache]),
 		appStateLoaded:     false,
 		focus:              focusLeftPane,
-		errorMessage:       "",
 		showHelp:           false,
+		build:              version.Get(),
 		autoRefresh:        true,
+		splitPercent:       defaultSplitPercent,
+		resyncing:          make(map[string]int),
+		checking:           make(map[string]bool),
+		syncSpinner:        spinner.New(spinner.WithSpinner(spinner.MiniDot)),
 		refreshInterval:    time.Duration(refreshIntervalSeconds) * time.Second,
+		idle:               idlePause{lastInput: time.Now()},
+	}
+	m.hints.Styles = m.theme.KeyHints
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.kubeCalls = make(map[string]kubeCall)
+	m.SetRequestBudget(config.RequestBudget{})
+
+	if len(c.ContextConflicts()) == 0 {
+		m.keys.Conflicts.SetEnabled(false)
+	}
+	// The lists, panes and panels below match their own keys against
+	// m.keys, so SetKeyBindings remaps them along with MainPage's.
+	for _, keyed := range []interface{ SetKeyMap(*keys.KeyMap) }{
+		m.contextList, m.deploymentList, m.podList, m.svcList, m.stsList, m.dsList,
+		m.topList, m.nodeList, m.ingList, m.crList, m.watchList,
+		m.deploymentDetail, m.logPanes, m.infoPanel, m.fileBrowser, m.confirm,
+		m.forwardPanel, m.errorPanel, m.alertPanel, m.auditPanel,
+		m.nsPicker, m.profilePicker, m.colChooser,
+	} {
+		keyed.SetKeyMap(&m.keys)
 	}
-
 	m.updateFocusStates()
 	return m
 }
 
+// SetLogPreferences applies the Log pane's config.Preferences: the
+// structured view's columns (LogFields; empty keeps the defaults), level
+// highlighting (ColorCodeLogs), per-source scrollback (MaxLogLines),
+// whether a new pane follows its tail (FollowByDefault), shows timestamps
+// (ShowTimestamps) and folds repeated lines (CollapseRepeats), whether split panes scroll together (SyncScroll), how
+// far back its streams start (TailLines, LogSince), and past how many
+// backfilled lines it asks before reading on (BacklogWarnLines).
+func (m *MainPage) SetLogPreferences(prefs config.Preferences) {
+	m.logPanes.SetFields(prefs.LogFields)
+	m.logPanes.SetColorCodeLevels(prefs.ColorCodeLogs)
+	m.logPanes.SetMaxLines(prefs.MaxLogLines)
+	m.logPanes.SetFollowByDefault(prefs.FollowByDefault)
+	m.logPanes.SetTimestamps(prefs.ShowTimestamps)
+	m.logPanes.SetCollapseRepeats(prefs.CollapseRepeats)
+	m.logPanes.SetSyncScroll(prefs.SyncScroll)
+	m.logTail.tailLines = int64(prefs.TailLines)
+	m.logTail.since = prefs.Since()
+	m.logTail.warnLines = prefs.BacklogWarnLines
+}
+
+// SetKeyBindings applies the config's keys section to the keymap MainPage
+// and the models under it match key presses against, and show in hints
+// and help. A key given to two actions live at once is refused (see
+// keys.KeyMap.Rebind).
+func (m *MainPage) SetKeyBindings(bindings map[string][]string) error {
+	return m.keys.Rebind(bindings)
+}
+
+// SetLogLevelSwitches installs the configured log level switches; with
+// none, the log pane's "V" action is disabled.
+func (m *MainPage) SetLogLevelSwitches(switches []config.LogLevelSwitch) {
+	m.logLevelSwitches = switches
+	m.keys.LogLevel.SetEnabled(len(switches) > 0 && !m.Client.ReadOnly())
+}
+
+// SetStartupTrace installs the --startup-trace timer, marked as the first
+// frame renders and as each context connects and first has data.
+func (m *MainPage) SetStartupTrace(trace *startup.Trace) {
+	m.trace = trace
+}
+
 func (m *MainPage) Init() tea.Cmd {
 	m.contextList.Init()
-	return tea.Batch(m.refreshTickCmd(), recheckStartupSizeCmd())
+	m.offerSessionRestore()
+	return tea.Batch(m.loadStartContext(), m.refreshTickCmd(), recheckStartupSizeCmd(), m.watchKubeconfigCmd(), m.checkConnectionCmd(m.Client.GetCurrentContext()), m.heartbeatTickCmd(), cmds.WaitForRetriesCmd(m.ctx, m.Client))
 }
 
 // refreshTickCmd schedules the next RefreshTickMsg one refreshInterval from
@@ -203,8 +644,16 @@ func (m *MainPage) Init() tea.Cmd {
 // unconditionally, even while auto-refresh is paused or toggled off, so it's
 // always running in the background and ready to pick refreshing back up.
 func (m *MainPage) refreshTickCmd() tea.Cmd {
-	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
-		return msgs.RefreshTickMsg{}
+	interval := m.refreshInterval
+	if m.unfocused {
+		interval *= unfocusedRefreshFactor
+	}
+	if m.anyNearBudget() {
+		interval *= budgetRefreshFactor
+	}
+	gen := m.refreshGen
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return msgs.RefreshTickMsg{Generation: gen}
 	})
 }
 
@@ -239,52 +688,134 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	defer m.logSlowUpdate(start)
 
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m, m.handleMouse(msg)
+
 	case tea.KeyPressMsg:
-		keypress := msg.String()
+		// While parked, any key only resumes the streams.
+		if m.idle.parked {
+			return m, m.resumeFromIdle()
+		}
+		m.noteInput()
 
-		// Help overlay is modal — only ? and esc pass through
+		// Help overlay is modal — ? and esc close it, the rest scroll it
 		if m.showHelp {
-			if keypress == "?" || keypress == "esc" {
+			if key.Matches(msg, m.keys.Help, m.keys.Back) {
 				m.showHelp = false
+			} else {
+				m.scrollHelp(msg)
 			}
 			return m, nil
 		}
 
+		// The prompt sits above every other overlay and takes all keys.
+		if m.showPrompt {
+			return m, m.handlePromptKey(msg)
+		}
+
+		if m.showConfirm {
+			return m, m.handleConfirmKey(msg)
+		}
+
+		if m.showCommand {
+			return m, m.handleCommandKey(msg)
+		}
+
+		// Info panel is modal too — Esc closes it, everything else scrolls it.
+		if m.showPanel {
+			if key.Matches(msg, m.keys.Back) {
+				m.showPanel = false
+				m.panelKey = ""
+				return m, nil
+			}
+			if key.Matches(msg, m.keys.UndoRollout) && strings.HasPrefix(m.panelKey, "rollout/") {
+				return m, m.promptUndoRollout()
+			}
+			if key.Matches(msg, m.keys.Rescrape) && strings.HasPrefix(m.panelKey, "metrics/") {
+				return m, m.rescrapePodMetrics()
+			}
+			return m, m.infoPanel.Update(msg)
+		}
+
+		if m.showFiles {
+			return m, m.handleFileBrowserKey(msg)
+		}
+
+		if m.showForwards {
+			return m, m.handlePortForwardKey(msg)
+		}
+
+		if m.showErrors {
+			return m, m.handleErrorPanelKey(msg)
+		}
+
+		if m.showAlerts {
+			return m, m.handleAlertPanelKey(msg)
+		}
+
+		if m.showAudit {
+			return m, m.handleAuditPanelKey(msg)
+		}
+
+		if m.showNSPicker {
+			return m, m.handleNamespacePickerKey(msg)
+		}
+
+		if m.showProfiles {
+			return m, m.handleProfilePickerKey(msg)
+		}
+
+		if m.showColumns {
+			return m, m.handleColumnChooserKey(msg)
+		}
+
 		// While a resource table is actively capturing filter text (see
 		// rowFilter in models/table.go), every keypress must reach it
 		// untouched — otherwise single-letter global shortcuts like "r"
 		// (refresh) or "l" (open logs) below would get swallowed into a
 		// command instead of becoming part of the filter query.
-		if m.focus == focusTabs && !m.detailFocused && !m.logsFocused {
-			if t := m.activeResourceTable(); t != nil {
-				if _, _, typing, ok := t.FilterStatus(); ok && typing {
-					switch m.tabs[m.activeTab] {
-					case "Deployments":
-						return m, m.deploymentList.Update(msg)
-					case "Pods":
-						return m, m.podList.Update(msg)
-					case "svc":
-						return m, m.svcList.Update(msg)
-					}
-				}
+		if m.typingFilter() {
+			switch m.tabs[m.activeTab] {
+			case "Deployments":
+				return m, m.deploymentList.Update(msg)
+			case "Pods":
+				return m, m.podList.Update(msg)
+			case "svc":
+				return m, m.svcList.Update(msg)
+			case "sts":
+				return m, m.stsList.Update(msg)
+			case "ds":
+				return m, m.dsList.Update(msg)
+			case "top":
+				return m, m.topList.Update(msg)
+			case "nodes":
+				return m, m.nodeList.Update(msg)
+			case "ing":
+				return m, m.ingList.Update(msg)
+			case "cr":
+				return m, m.crList.Update(msg)
+			case "watch":
+				return m, m.watchList.Update(msg)
 			}
 		}
 
 		// Global keys
-		switch keypress {
-		case "ctrl+c", "q":
-			m.stopLogStream()
-			return m, tea.Quit
-		case "tab", "shift+tab":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, m.quit()
+		case key.Matches(msg, m.keys.FocusNext):
 			m.toggleFocus()
 			return m, nil
-		case "esc":
+		case key.Matches(msg, m.keys.Back):
 			// Peel dismissals one at a time: unfocus the detail/log pane, then
-			// close it, then inline error, then context errors. Detail and Logs
+			// close it, then leave the Deployment the Pods tab is browsing,
+			// then inline error, then context errors. Detail and Logs
 			// are mutually exclusive, so only one of their branches is ever live.
 			if m.detailFocused {
 				m.detailFocused = false
 				m.updateFocusStates()
+			} else if m.logsFocused && m.logPanes.Active().Selecting() {
+				m.logPanes.Active().CancelSelection()
 			} else if m.logsFocused {
 				m.logsFocused = false
 				m.updateFocusStates()
@@ -294,22 +825,75 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if m.showLogs {
 				m.closeLogs()
 				m.applyContentSizes()
-			} else if m.errorMessage != "" {
-				m.errorMessage = ""
+			} else if m.focus == focusTabs && m.tabs[m.activeTab] == "Pods" && m.podList.Group() != "" {
+				m.podList.CloseGroup()
+			} else if e, ok := m.appState.LatestUnseenError(); ok {
+				m.appState.MarkErrorSeen(e.ID)
 			} else {
 				m.appState.ClearErrors()
 			}
 			return m, nil
-		case "?":
+		case key.Matches(msg, m.keys.Help):
 			m.showHelp = true
+			m.helpOffset = 0
 			return m, nil
-		case "R":
+		case key.Matches(msg, m.keys.Command):
+			return m, m.openCommandLine()
+		case key.Matches(msg, m.keys.AutoRefresh):
 			m.autoRefresh = !m.autoRefresh
 			return m, nil
+		case key.Matches(msg, m.keys.BuildInfo):
+			m.openBuildInfo()
+			return m, nil
+		case key.Matches(msg, m.keys.Errors):
+			m.openErrors()
+			return m, nil
+		case key.Matches(msg, m.keys.Alerts):
+			m.openAlerts()
+			return m, nil
+		case key.Matches(msg, m.keys.Audit):
+			m.openAudit()
+			return m, nil
+		case key.Matches(msg, m.keys.Resize):
+			// The first key grows the pane, the second shrinks it.
+			if keys.Index(msg, m.keys.Resize) == 0 {
+				m.resizeSplit(splitStepPercent)
+			} else {
+				m.resizeSplit(-splitStepPercent)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Collapse):
+			m.toggleContextsPane()
+			return m, nil
+		case key.Matches(msg, m.keys.Undo):
+			return m, m.undoDeselect()
 		}
 
 		// Context list keys
 		if m.focus == focusLeftPane {
+			switch {
+			case key.Matches(msg, m.keys.Conflicts):
+				m.openContextConflicts()
+				return m, nil
+			case key.Matches(msg, m.keys.Namespaces):
+				return m, m.openNamespacePicker()
+			case key.Matches(msg, m.keys.AlignNS):
+				return m, m.promptAlignNamespace()
+			case key.Matches(msg, m.keys.Profiles):
+				m.openProfilePicker()
+				return m, nil
+			case key.Matches(msg, m.keys.SortCtx):
+				m.actionStatus = "Contexts sorted by " + m.contextList.CycleSort()
+				return m, nil
+			case key.Matches(msg, m.keys.MoveCtx):
+				// The first key moves the context up, the second down.
+				if keys.Index(msg, m.keys.MoveCtx) == 0 {
+					m.contextList.MoveCursorContext(-1)
+				} else {
+					m.contextList.MoveCursorContext(1)
+				}
+				return m, nil
+			}
 			cmd := m.contextList.Update(msg)
 			return m, cmd
 		}
@@ -322,25 +906,101 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// While the log pane has keyboard focus, it captures everything except
-		// 'c' and 'w', which MainPage intercepts directly — both are pure view
-		// toggles with no stream side effects (isolate/return-to-merged a
-		// single source, and soft-wrap on/off).
+		// 'c', 'w', 's', 'x', 'L', 't', 'u' and 'P', which MainPage intercepts
+		// directly — all pure view toggles with no stream side effects
+		// (isolate/return-to-merged a single source, soft-wrap on/off,
+		// structured columns on/off, expanding the cursor line's payload, the
+		// minimum-level filter, timestamps, collapsing repeated lines, and
+		// pausing the view while the streams buffer on) — and 'V', which
+		// switches the app's own log level (see findLogLevelSwitch),
+		// 'v'/'y'/'S', which select, copy and share lines (see clipboard.go,
+		// share.go), 'H', which copies a link to the lines' history in the
+		// log store (see loglinks.go), 'E', which lists container exits (see
+		// openExitHistory), 'p'/'T', which reopen the streams from the
+		// previous instance or a since preset (see logtail.go), 'Z'/'D',
+		// which ask for the time zone timestamps are shown in and cycle their
+		// format (see timezone.go), 'a'/'F', which hold a restarted
+		// container's old output and follow it on (see restarts.go),
+		// 'N'/'X'/'O', which add, close and cycle through log panes (see
+		// logpanes.go), and '/', which searches them (see logcompare.go).
 		if m.logsFocused {
-			switch keypress {
-			case "c":
-				m.podLogs.CycleIsolation()
+			switch {
+			case key.Matches(msg, m.keys.Isolate):
+				m.logPanes.Active().CycleIsolation()
+				return m, nil
+			case key.Matches(msg, m.keys.Pause):
+				m.logPanes.Active().TogglePause()
+				return m, nil
+			case key.Matches(msg, m.keys.Wrap):
+				m.logPanes.ToggleWrap()
+				return m, nil
+			case key.Matches(msg, m.keys.Structured):
+				m.logPanes.Active().ToggleStructured()
+				return m, nil
+			case key.Matches(msg, m.keys.Expand):
+				m.logPanes.Active().ToggleExpand()
+				return m, nil
+			case key.Matches(msg, m.keys.MinLevel):
+				m.logPanes.CycleMinLevel()
 				return m, nil
-			case "w":
-				m.podLogs.ToggleWrap()
+			case key.Matches(msg, m.keys.Search):
+				return m, m.promptLogSearch()
+			case key.Matches(msg, m.keys.LogLevel):
+				return m, m.findLogLevelSwitch()
+			case key.Matches(msg, m.keys.Select):
+				if !m.logPanes.Active().Selecting() {
+					m.logPanes.Active().StartSelection()
+				}
+				return m, nil
+			case key.Matches(msg, m.keys.Yank):
+				return m, m.yankLogLines()
+			case key.Matches(msg, m.keys.Share):
+				m.shareLogLines()
+				return m, nil
+			case key.Matches(msg, m.keys.History):
+				return m, m.copyLogHistoryLink()
+			case key.Matches(msg, m.keys.Exits):
+				m.openExitHistory()
+				return m, nil
+			case key.Matches(msg, m.keys.Timestamps):
+				m.logPanes.Active().ToggleTimestamps()
+				return m, nil
+			case key.Matches(msg, m.keys.Repeats):
+				m.logPanes.Active().ToggleCollapseRepeats()
+				return m, nil
+			case key.Matches(msg, m.keys.Zone):
+				return m, m.promptLogTimezone()
+			case key.Matches(msg, m.keys.TimeFormat):
+				m.cycleLogStampFormat()
+				return m, nil
+			case key.Matches(msg, m.keys.Previous):
+				return m, m.togglePreviousLogs()
+			case key.Matches(msg, m.keys.Since):
+				return m, m.cycleLogSince()
+			case key.Matches(msg, m.keys.Hold):
+				return m, m.toggleHoldRestarts()
+			case key.Matches(msg, m.keys.Follow):
+				return m, m.followRestarts()
+			case key.Matches(msg, m.keys.NewPane):
+				m.addLogPane()
+				return m, nil
+			case key.Matches(msg, m.keys.ClosePane):
+				m.closeLogPane()
+				return m, nil
+			case key.Matches(msg, m.keys.NextPane):
+				m.logPanes.Next()
+				m.updateFocusStates()
 				return m, nil
 			}
-			cmd := m.podLogs.Update(msg)
+			cmd := m.logPanes.Update(msg)
 			return m, cmd
 		}
 
 		// Ctrl+R always jumps straight back into an already-open pane — unlike
 		// Enter, it never fetches, no matter where the list cursor now sits.
-		if keypress == "ctrl+r" && m.showDetail {
+		// Except on the Pods tab, where it restarts the pod's deployment
+		// (below); Enter on the same row refocuses there just as instantly.
+		if key.Matches(msg, m.keys.ReturnPane) && m.showDetail && m.tabs[m.activeTab] != "Pods" {
 			m.detailFocused = true
 			m.applyContentSizes()
 			m.updateFocusStates()
@@ -350,37 +1010,70 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Tab navigation (tabs focused) — switching tabs while the detail pane
 		// is open (but unfocused) is allowed; the pane is cross-cutting and
 		// stays put beneath whichever tab you land on.
-		switch keypress {
-		case "right", "]":
-			next := m.activeTab + 1
-			if next >= len(m.tabs) {
+		switch {
+		case key.Matches(msg, m.keys.NextTab):
+			if m.activeTab+1 >= len(m.tabs) {
 				return m, nil
 			}
-			nextTab := m.tabs[next]
-			if nextTab == "Deployments" || nextTab == "Pods" || nextTab == "svc" {
-				snapshot := m.appState.Snapshot()
-				if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
-					return m, nil
-				}
-			}
-			m.activeTab = next
-			m.updateFocusStates()
-			return m, nil
-		case "left", "[":
+			return m, m.switchTab(m.activeTab + 1)
+		case key.Matches(msg, m.keys.PrevTab):
 			prev := m.activeTab - 1
 			if prev < 0 {
 				return m, nil
 			}
 			m.activeTab = prev
 			m.updateFocusStates()
-			return m, nil
+			return m, m.loadPolledTabIfActive()
 		}
 
-		// Enter on a selected Deployment/Pod/Service row (re)loads the detail
-		// pane for that row and gives it keyboard focus for scrolling. Detail
-		// and Logs share the same bottom slot and are mutually exclusive.
-		if m.appStateLoaded && keypress == "enter" &&
-			(m.tabs[m.activeTab] == "Deployments" || m.tabs[m.activeTab] == "Pods" || m.tabs[m.activeTab] == "svc") {
+		// On the top tab, Enter expands the pod's container breakdown instead
+		// of opening a detail pane, and o flips the sort between CPU and memory.
+		if m.appStateLoaded && m.tabs[m.activeTab] == "top" {
+			switch {
+			case key.Matches(msg, m.keys.Containers):
+				m.topList.ToggleExpand()
+				return m, nil
+			case key.Matches(msg, m.keys.UsageSort):
+				m.topList.ToggleSort()
+				return m, nil
+			}
+		}
+
+		// On the nodes tab, c cordons (or uncordons) the node under the
+		// cursor and d drains it, each after confirming with a preview of
+		// what changes. Read-only mode disables both.
+		if m.appStateLoaded && m.tabs[m.activeTab] == "nodes" {
+			switch {
+			case key.Matches(msg, m.keys.Cordon):
+				m.confirmCordon()
+				return m, nil
+			case key.Matches(msg, m.keys.Drain):
+				return m, m.planDrain()
+			}
+		}
+
+		// Browsing the Pods tab by deployment, Enter on a Deployment's row
+		// lists its pods and b switches the browse on and off; Backspace
+		// (like Esc) goes back up.
+		if m.appStateLoaded && m.tabs[m.activeTab] == "Pods" {
+			switch {
+			case key.Matches(msg, m.keys.Open):
+				if m.podList.OpenGroup() {
+					return m, nil
+				}
+			case key.Matches(msg, m.keys.Browse):
+				m.podList.ToggleByDeployment()
+				return m, nil
+			case key.Matches(msg, m.keys.GroupUp):
+				m.podList.CloseGroup()
+				return m, nil
+			}
+		}
+
+		// Enter on a selected resource row (re)loads the detail pane for that
+		// row and gives it keyboard focus for scrolling. Detail and Logs share
+		// the same bottom slot and are mutually exclusive.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Open) && isResourceTab(m.tabs[m.activeTab]) {
 			m.closeLogs()
 			if cmd := m.openResourceDetail(m.tabs[m.activeTab]); cmd != nil {
 				return m, cmd
@@ -391,43 +1084,198 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Space toggles the row under the cursor for inclusion in the next
 		// merged log stream; Ctrl+X clears all checkmarks. Pods-tab only.
 		if m.appStateLoaded && m.tabs[m.activeTab] == "Pods" {
-			switch keypress {
-			case "space":
+			switch {
+			case key.Matches(msg, m.keys.Check):
 				m.podList.ToggleChecked(models.PodRowKey(m.podList.SelectedRow()))
 				return m, nil
-			case "ctrl+x":
+			case key.Matches(msg, m.keys.ClearCheck):
 				m.podList.ClearChecked()
 				return m, nil
 			}
 		}
 
+		// S cycles the Pods or Deployments table's sort column and
+		// direction; C opens the chooser for the columns it shows; z folds
+		// the context section the cursor is in; { and } switch their
+		// context sub-tab.
+		if m.appStateLoaded && key.Matches(msg, m.keys.SortRows, m.keys.Columns, m.keys.Fold, m.keys.PrevCtxTab, m.keys.NextCtxTab) {
+			if tab := m.tabs[m.activeTab]; tab == "Pods" || tab == "Deployments" {
+				switch {
+				case key.Matches(msg, m.keys.Columns):
+					m.openColumnChooser(tab)
+				case key.Matches(msg, m.keys.Fold):
+					m.foldSection(tab)
+				case key.Matches(msg, m.keys.PrevCtxTab):
+					m.cycleContextScope(-1)
+				case key.Matches(msg, m.keys.NextCtxTab):
+					m.cycleContextScope(1)
+				default:
+					m.cycleTableSort(tab)
+				}
+				return m, nil
+			}
+		}
+
+		// e opens the env panel for the Pods row under the cursor: every
+		// container's resolved environment, valueFrom sources included.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Env) && m.tabs[m.activeTab] == "Pods" {
+			return m, m.openPodEnv()
+		}
+
+		// m peeks at the metrics the Pods row under the cursor exports, read
+		// through a port-forward.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Metrics) && m.tabs[m.activeTab] == "Pods" {
+			if m.refuseSelectedPod(k8s.ForwardPods) {
+				return m, nil
+			}
+			return m, m.openPodMetrics()
+		}
+
+		// s suspends the TUI for an interactive shell in the Pods row under
+		// the cursor; the TUI resumes untouched when the shell exits.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Shell) && m.tabs[m.activeTab] == "Pods" {
+			if m.refuseSelectedPod(k8s.ExecPods) {
+				return m, nil
+			}
+			return m, m.openPodShell()
+		}
+
+		// Ctrl+D deletes the Pods row under the cursor; Ctrl+R rollout-restarts
+		// the Deployment owning it. Both ask first.
+		if m.appStateLoaded && m.tabs[m.activeTab] == "Pods" {
+			switch {
+			case key.Matches(msg, m.keys.Delete):
+				if !m.refuseSelectedPod(k8s.DeletePods) {
+					m.confirmDeletePod()
+				}
+				return m, nil
+			case key.Matches(msg, m.keys.Restart):
+				if m.refuseSelectedPod(k8s.RestartDeployments) {
+					return m, nil
+				}
+				return m, m.findPodDeployment()
+			}
+		}
+
+		// p starts a port-forward to the Pods or svc row under the cursor;
+		// P lists the running forwards.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Forward) {
+			if tab := m.tabs[m.activeTab]; tab == "Pods" || tab == "svc" {
+				if tab == "Pods" && m.refuseSelectedPod(k8s.ForwardPods) {
+					return m, nil
+				}
+				return m, m.promptPortForward()
+			}
+		}
+		if key.Matches(msg, m.keys.Forwards) {
+			m.openPortForwards()
+			return m, nil
+		}
+
+		// f opens the file browser on the Pods row under the cursor.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Files) && m.tabs[m.activeTab] == "Pods" {
+			return m, m.openFileBrowser()
+		}
+
+		// H copies a link to the Pods row's logs in the configured log store.
+		if m.appStateLoaded && key.Matches(msg, m.keys.History) && m.tabs[m.activeTab] == "Pods" {
+			return m, m.copyPodHistoryLink()
+		}
+
+		// d tails the two checked Pods rows side by side, for comparing.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Compare) && m.tabs[m.activeTab] == "Pods" {
+			return m, m.compareLogs()
+		}
 		// l reconciles the merged log pane to whatever's currently checked in
 		// the Pods tab (or the row under the cursor, if nothing's checked).
-		if m.appStateLoaded && keypress == "l" && m.tabs[m.activeTab] == "Pods" {
+		if m.appStateLoaded && key.Matches(msg, m.keys.Logs) && m.tabs[m.activeTab] == "Pods" {
 			if cmd := m.openPodLogs(); cmd != nil {
 				return m, cmd
 			}
 			return m, nil
 		}
+		// p on the Deployments tab drills into the pods of the Deployment
+		// under the cursor, on the Pods tab.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Pods) && m.tabs[m.activeTab] == "Deployments" {
+			m.showDeploymentPods()
+			return m, nil
+		}
+		// h opens the rollout status and history of the Deployments row
+		// under the cursor.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Rollout) && m.tabs[m.activeTab] == "Deployments" {
+			return m, m.openRollout()
+		}
+		// d compares the Deployments row under the cursor with the same
+		// Deployment in another selected context.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Compare) && m.tabs[m.activeTab] == "Deployments" {
+			return m, m.openCompare()
+		}
+		// w pins the Pods or Deployments row under the cursor to the
+		// watchlist, or unpins it (or, on the watch tab, the entry under
+		// the cursor); l there tails the entry's logs.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Watch) {
+			if tab := m.tabs[m.activeTab]; tab == "Pods" || tab == "Deployments" || tab == "watch" {
+				return m, m.toggleWatch()
+			}
+		}
+		if m.appStateLoaded && key.Matches(msg, m.keys.Logs) && m.tabs[m.activeTab] == "watch" {
+			return m, m.attachWatched()
+		}
+		// t picks the type the cr tab lists.
+		if m.appStateLoaded && key.Matches(msg, m.keys.ResourceType) && m.tabs[m.activeTab] == "cr" {
+			return m, m.promptResourceType()
+		}
+		// b lists the endpoints behind the svc row under the cursor.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Backends) && m.tabs[m.activeTab] == "svc" {
+			return m, m.openServiceBackends()
+		}
+		// o opens the first pane template matching the Deployments row
+		// under the cursor.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Template) && m.tabs[m.activeTab] == "Deployments" {
+			return m, m.openPaneTemplate()
+		}
+		// On the sts tab, l asks which ordinals of the StatefulSet under the
+		// cursor to tail.
+		if m.appStateLoaded && key.Matches(msg, m.keys.OrdinalLogs) && m.tabs[m.activeTab] == "sts" {
+			return m, m.promptOrdinalLogs()
+		}
 
 		// r force-restarts the watch(es) for only the active tab's resource
 		// type, across every selected context — not all three resource
 		// types, to avoid tripling API load on tabs the user isn't even
 		// looking at. Table cursor is untouched: SetRows reuses the same
 		// table.Model, it doesn't reset it.
-		if m.appStateLoaded && keypress == "r" {
+		if m.appStateLoaded && key.Matches(msg, m.keys.Refresh) {
 			if cmd := m.restartActiveTabWatch(); cmd != nil {
 				return m, cmd
 			}
 			return m, nil
 		}
 
+		// y copies the name of the row under the cursor, Y the whole row.
+		if m.appStateLoaded && key.Matches(msg, m.keys.CopyRow) {
+			return m, m.copyRow(keys.Index(msg, m.keys.CopyRow) > 0)
+		}
+
+		// 1-9 dismiss the matching filter chip above the active table.
+		if key.Matches(msg, m.keys.DropChip) {
+			if cmd, ok := m.removeFilterChip(keys.Index(msg, m.keys.DropChip)); ok {
+				return m, cmd
+			}
+		}
+
+		// ":" narrows the active tab server-side by label/field selector,
+		// across every selected context.
+		if m.appStateLoaded && key.Matches(msg, m.keys.Selector) {
+			return m, m.promptSelector()
+		}
+
 		// Ctrl+W toggles wide mode on the active tab's table (sticky per tab,
 		// reset on resize); Shift+Left/Right scroll one column at a time while
 		// wide mode is on. Both are a no-op outside the three resource tabs.
 		if m.appStateLoaded {
-			switch keypress {
-			case "ctrl+w":
+			switch {
+			case key.Matches(msg, m.keys.WideMode):
 				if t := m.activeResourceTable(); t != nil {
 					wasWide := t.WideMode()
 					t.ToggleWideMode()
@@ -436,12 +1284,12 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 				return m, nil
-			case "shift+left":
+			case key.Matches(msg, m.keys.ColLeft):
 				if t := m.activeResourceTable(); t != nil && t.WideMode() {
 					t.ScrollLeft()
 				}
 				return m, nil
-			case "shift+right":
+			case key.Matches(msg, m.keys.ColRight):
 				if t := m.activeResourceTable(); t != nil && t.WideMode() {
 					t.ScrollRight()
 				}
@@ -461,6 +1309,27 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "svc":
 				cmd := m.svcList.Update(msg)
 				return m, cmd
+			case "sts":
+				cmd := m.stsList.Update(msg)
+				return m, cmd
+			case "ds":
+				cmd := m.dsList.Update(msg)
+				return m, cmd
+			case "top":
+				cmd := m.topList.Update(msg)
+				return m, cmd
+			case "nodes":
+				cmd := m.nodeList.Update(msg)
+				return m, cmd
+			case "ing":
+				cmd := m.ingList.Update(msg)
+				return m, cmd
+			case "cr":
+				cmd := m.crList.Update(msg)
+				return m, cmd
+			case "watch":
+				cmd := m.watchList.Update(msg)
+				return m, cmd
 			}
 		}
 
@@ -473,14 +1342,19 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		ctxW, ctxH := getContextPaneDimensions(m.width, m.height)
 		ctxMsg := tea.WindowSizeMsg{Width: ctxW, Height: ctxH}
 
-		leftW := leftPaneWidthFor(m.width)
-		tableW := m.width - leftW - 12
-		tableH := m.height - 16
-		if tableH < 1 {
-			tableH = 1
-		}
-		m.tableW, m.tableH = tableW, tableH
-		m.applyContentSizes()
+		m.sizeTabArea()
+		m.infoPanel.SetSize(m.width, m.height-2)
+		m.fileBrowser.SetSize(m.width, m.height-2)
+		m.prompt.SetSize(m.width, m.height-2)
+		m.commandLine.SetWidth(m.width - 2)
+		m.confirm.SetSize(m.width, m.height-2)
+		m.forwardPanel.SetSize(m.width, m.height-2)
+		m.errorPanel.SetSize(m.width, m.height-2)
+		m.alertPanel.SetSize(m.width, m.height-2)
+		m.auditPanel.SetSize(m.width, m.height-2)
+		m.nsPicker.SetSize(m.width, m.height-2)
+		m.profilePicker.SetSize(m.width, m.height-2)
+		m.colChooser.SetSize(m.width, m.height-2)
 
 		return m, m.contextList.Update(ctxMsg)
 
@@ -492,6 +1366,55 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.deploymentDetail.SetDetail(msg.Detail)
 		return m, nil
 
+	case msgs.PodEnvMsg:
+		if !m.showPanel || m.panelKey != podEnvPanelKey(msg.Context, msg.Namespace, msg.Pod) {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.infoPanel.SetError(msg.Err.Error())
+			return m, nil
+		}
+		m.infoPanel.SetContent(m.infoPanel.Title(), models.PodEnvLines(msg.Envs))
+		return m, nil
+
+	case msgs.PodDirListingMsg:
+		if !m.showFiles || msg.Generation != m.filesGen {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.fileBrowser.SetError(msg.Err.Error())
+			return m, nil
+		}
+		m.fileBrowser.SetListing(msg.Dir, msg.Entries)
+		return m, nil
+
+	case msgs.PodFileContentMsg:
+		if !m.showFiles || msg.Generation != m.filesGen {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.fileBrowser.SetError(msg.Err.Error())
+			return m, nil
+		}
+		m.fileBrowser.SetFile(msg.Path, msg.Content, msg.Truncated, msg.Tail)
+		return m, nil
+
+	case msgs.PodCopyStartedMsg:
+		if msg.Generation != m.copyGen {
+			return m, nil
+		}
+		m.copyUpdates = msg.Updates
+		return m, cmds.WaitForPodCopyCmd(msg.Generation, msg.Updates)
+
+	case msgs.PodCopyProgressMsg:
+		return m, m.onPodCopyProgress(msg)
+
+	case msgs.PodCopyClearMsg:
+		if msg.Generation == m.copyGen && m.copyCancel == nil {
+			m.copyStatus = ""
+		}
+		return m, nil
+
 	case msgs.LogStreamOpenedMsg:
 		// Stale — this source has since been restarted or closed. Close the
 		// stream rather than adopting it; other open sources are unaffected.
@@ -502,6 +1425,12 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		st.stream = msg.Stream
 		st.scanner = cmds.NewLogScanner(msg.Stream)
+		m.recordExits(st.target.context)
+		if st.resuming {
+			m.logPanes.AddNotice(msg.SourceKey, "reconnected · resuming after "+st.lastTime.In(m.logPanes.Location()).Format("15:04:05.000"))
+		} else if st.openedAt.IsZero() {
+			st.openedAt = time.Now()
+		}
 		return m, cmds.WaitForLogLineCmd(msg.SourceKey, msg.Generation, st.scanner)
 
 	case msgs.LogLineMsg:
@@ -509,20 +1438,21 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !ok || msg.Generation != st.generation {
 			return m, nil
 		}
-		m.podLogs.AppendLine(msg.SourceKey, msg.Line)
-		return m, cmds.WaitForLogLineCmd(msg.SourceKey, msg.Generation, st.scanner)
-
-	case msgs.LogStreamClosedMsg:
-		st, ok := m.logStreams[msg.SourceKey]
-		if !ok || msg.Generation != st.generation {
-			return m, nil
+		if st.resumeSkips(msg.Time) {
+			return m, cmds.WaitForLogLineCmd(msg.SourceKey, msg.Generation, st.scanner)
 		}
-		if st.stream != nil {
-			st.stream.Close()
+		switch m.backlogVerdict(st, msg.Time) {
+		case dropLine:
+			return m, cmds.WaitForLogLineCmd(msg.SourceKey, msg.Generation, st.scanner)
+		case pauseLine:
+			return m, m.pauseForBacklog(st, msg)
 		}
-		delete(m.logStreams, msg.SourceKey)
-		m.podLogs.SetStreamEnded(msg.SourceKey, msg.Err)
-		return m, nil
+		st.record(msg.Time)
+		m.logPanes.AppendLineAt(msg.SourceKey, msg.Line, msg.Time)
+		return m, tea.Batch(m.checkLogAlerts(st, msg), cmds.WaitForLogLineCmd(msg.SourceKey, msg.Generation, st.scanner))
+
+	case msgs.LogStreamClosedMsg:
+		return m, m.onLogStreamClosed(msg)
 
 	case msgs.PodWatchOpenedMsg:
 		st, ok := m.podWatchers[msg.Context]
@@ -531,6 +1461,7 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		st.watcher = msg.Watcher
+		m.clearSlow(msg.Context)
 		return m, cmds.WaitForPodWatchEventCmd(msg.Context, msg.Generation, msg.Watcher, st.cache)
 
 	case msgs.PodWatchEventMsg:
@@ -539,8 +1470,19 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		st.failures = 0
-		m.applyPodWatchRows(msg.Context, msg.Rows)
-		return m, cmds.WaitForPodWatchEventCmd(msg.Context, msg.Generation, st.watcher, st.cache)
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyPodWatchRows(msg.Context, msg.Rows)
+		}
+		m.recordExits(msg.Context)
+		m.summarizeCompleted(msg.Context)
+		return m, tea.Batch(
+			m.checkPodAlerts(msg.Context, msg.Rows),
+			m.followRecreatedPods(msg.Context),
+			m.resumeRestoredLogs(msg.Context, msg.Rows),
+			cmds.WaitForPodWatchEventCmd(msg.Context, msg.Generation, st.watcher, st.cache),
+		)
 
 	case msgs.PodWatchClosedMsg:
 		return m, m.onPodWatchClosed(msg)
@@ -552,6 +1494,7 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		st.watcher = msg.Watcher
+		m.clearSlow(msg.Context)
 		return m, cmds.WaitForDeploymentWatchEventCmd(msg.Context, msg.Generation, msg.Watcher, st.cache)
 
 	case msgs.DeploymentWatchEventMsg:
@@ -560,7 +1503,11 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		st.failures = 0
-		m.applyDeploymentWatchRows(msg.Context, msg.Rows)
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyDeploymentWatchRows(msg.Context, msg.Rows)
+		}
 		return m, cmds.WaitForDeploymentWatchEventCmd(msg.Context, msg.Generation, st.watcher, st.cache)
 
 	case msgs.DeploymentWatchClosedMsg:
@@ -573,6 +1520,7 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		st.watcher = msg.Watcher
+		m.clearSlow(msg.Context)
 		return m, cmds.WaitForServiceWatchEventCmd(msg.Context, msg.Generation, msg.Watcher, st.cache)
 
 	case msgs.ServiceWatchEventMsg:
@@ -581,33 +1529,121 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		st.failures = 0
-		m.applyServiceWatchRows(msg.Context, msg.Rows)
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyServiceWatchRows(msg.Context, msg.Rows)
+		}
 		return m, cmds.WaitForServiceWatchEventCmd(msg.Context, msg.Generation, st.watcher, st.cache)
 
 	case msgs.ServiceWatchClosedMsg:
 		return m, m.onServiceWatchClosed(msg)
 
-	case msgs.ContextsStateMsg:
-		m.errorMessage = ""
+	case msgs.StatefulSetWatchOpenedMsg:
+		st, ok := m.stsWatchers[msg.Context]
+		if !ok || msg.Generation != st.generation {
+			msg.Watcher.Stop()
+			return m, nil
+		}
+		st.watcher = msg.Watcher
+		m.clearSlow(msg.Context)
+		return m, cmds.WaitForStatefulSetWatchEventCmd(msg.Context, msg.Generation, msg.Watcher, st.cache)
+
+	case msgs.StatefulSetWatchEventMsg:
+		st, ok := m.stsWatchers[msg.Context]
+		if !ok || msg.Generation != st.generation {
+			return m, nil
+		}
+		st.failures = 0
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyStatefulSetWatchRows(msg.Context, msg.Rows)
+		}
+		return m, cmds.WaitForStatefulSetWatchEventCmd(msg.Context, msg.Generation, st.watcher, st.cache)
+
+	case msgs.StatefulSetWatchClosedMsg:
+		return m, m.onStatefulSetWatchClosed(msg)
+
+	case msgs.DaemonSetWatchOpenedMsg:
+		st, ok := m.dsWatchers[msg.Context]
+		if !ok || msg.Generation != st.generation {
+			msg.Watcher.Stop()
+			return m, nil
+		}
+		st.watcher = msg.Watcher
+		m.clearSlow(msg.Context)
+		return m, cmds.WaitForDaemonSetWatchEventCmd(msg.Context, msg.Generation, msg.Watcher, st.cache)
+
+	case msgs.DaemonSetWatchEventMsg:
+		st, ok := m.dsWatchers[msg.Context]
+		if !ok || msg.Generation != st.generation {
+			return m, nil
+		}
+		st.failures = 0
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyDaemonSetWatchRows(msg.Context, msg.Rows)
+		}
+		return m, cmds.WaitForDaemonSetWatchEventCmd(msg.Context, msg.Generation, st.watcher, st.cache)
+
+	case msgs.DaemonSetWatchClosedMsg:
+		return m, m.onDaemonSetWatchClosed(msg)
+
+	case msgs.KubeconfigChangedMsg:
+		return m, m.onKubeconfigChanged(msg)
+
+	case msgs.ContextHealthMsg:
+		m.onContextHealth(msg)
+		return m, nil
 
+	case msgs.RetriesMsg:
+		return m, m.onRetries(msg)
+
+	case msgs.AccessCheckedMsg:
+		// The reviews are cached in the client; the next frame's hints
+		// pick them up.
+		return m, nil
+
+	case msgs.HeartbeatTickMsg:
+		return m, m.heartbeat()
+
+	case msgs.ContextParkExpiredMsg:
+		m.onParkExpired(msg)
+		return m, nil
+
+	case msgs.ContextsStateMsg:
 		// Snapshot before mutations so we know which contexts were already present
 		prevSelected := m.appState.Snapshot().SelectedContexts
 
+		// Deselected contexts are parked rather than unloaded, for an undo;
+		// parked ones picked again come back without reloading.
+		var parkCmds []tea.Cmd
+		var parked []string
 		for _, contextName := range msg.Deselected {
-			m.appState.RemoveContext(contextName)
-			m.stopPodWatch(contextName)
-			m.stopDeploymentWatch(contextName)
-			m.stopServiceWatch(contextName)
+			if cmd := m.parkContext(contextName); cmd != nil {
+				parkCmds = append(parkCmds, cmd)
+				parked = append(parked, contextName)
+			}
 		}
+		m.noteParked(parked)
 
 		for _, ms := range msg.Selected {
-			m.appState.AddContext(ms.ContextName, ms.DefaultNamespace)
+			namespaces := selectionNamespaces(ms)
+			if m.unparkContext(ms.ContextName, state.WatchNamespace(namespaces)) {
+				prevSelected[ms.ContextName] = state.WatchNamespace(namespaces)
+			}
+			m.appState.AddContext(ms.ContextName, state.WatchNamespace(namespaces))
+			m.appState.SetNamespaces(ms.ContextName, namespaces)
 		}
 
 		snapshot := m.appState.Snapshot()
 		m.deploymentList.SetRows(snapshot.Deployments)
 		m.podList.SetRows(snapshot.Pods)
 		m.svcList.SetRows(snapshot.Services)
+		m.stsList.SetRows(snapshot.StatefulSets)
+		m.dsList.SetRows(snapshot.DaemonSets)
 		m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
 
 		if len(snapshot.SelectedContexts) == 0 {
@@ -615,9 +1651,11 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.deploymentList.SetRows([]msgs.RowData{})
 			m.podList.SetRows([]msgs.RowData{})
 			m.svcList.SetRows([]msgs.RowData{})
+			m.stsList.SetRows([]msgs.RowData{})
+			m.dsList.SetRows([]msgs.RowData{})
 			m.contextList.SetContextStates(nil, nil, nil)
 			m.updateFocusStates()
-			return m, nil
+			return m, tea.Batch(parkCmds...)
 		}
 
 		for i, t := range m.tabs {
@@ -626,28 +1664,38 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
+		m.applyRestoredTab()
 
 		// Only load contexts that are genuinely new (not previously selected).
 		// Previously selected contexts that failed stay failed until the user
 		// explicitly deselects and re-selects them — that removes them from
 		// prevSelected and they appear here as new on the next Enter press.
-		cmdSequence := []tea.Cmd{}
+		cmdSequence := parkCmds
 		for context, namespace := range snapshot.SelectedContexts {
 			if _, alreadyPresent := prevSelected[context]; alreadyPresent {
 				continue
 			}
+			m.trace.MarkContext(context, startup.StepSelected)
 			m.appState.SetLoading(context, true)
 			m.appState.SetLoadingPods(context, true)
 			m.appState.SetLoadingServices(context, true)
+			m.appState.SetLoadingStatefulSets(context, true)
+			m.appState.SetLoadingDaemonSets(context, true)
 
 			m.podWatchers[context] = &resourceWatchState[*cmds.PodWatchCache]{generation: 1, cache: cmds.NewPodWatchCache()}
 			m.deploymentWatchers[context] = &resourceWatchState[*cmds.DeploymentWatchCache]{generation: 1, cache: cmds.NewDeploymentWatchCache()}
 			m.serviceWatchers[context] = &resourceWatchState[*cmds.ServiceWatchCache]{generation: 1, cache: cmds.NewServiceWatchCache()}
+			m.stsWatchers[context] = &resourceWatchState[*cmds.StatefulSetWatchCache]{generation: 1, cache: cmds.NewStatefulSetWatchCache()}
+			m.dsWatchers[context] = &resourceWatchState[*cmds.DaemonSetWatchCache]{generation: 1, cache: cmds.NewDaemonSetWatchCache()}
 
 			cmdSequence = append(cmdSequence,
-				cmds.WatchDeploymentsCmd(m.Client, context, namespace, 1),
-				cmds.WatchPodsCmd(m.Client, context, namespace, 1),
-				cmds.WatchServicesCmd(m.Client, context, namespace, 1),
+				m.checkConnectionCmd(context),
+				cmds.WatchDeploymentsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("Deployments"), 1),
+				cmds.WatchPodsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("Pods"), 1),
+				cmds.WatchServicesCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("svc"), 1),
+				cmds.WatchStatefulSetsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("sts"), 1),
+				cmds.WatchDaemonSetsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("ds"), 1),
+				m.checkAccessCmd(context, namespace),
 			)
 		}
 
@@ -676,10 +1724,163 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.svcList.SetRows(snapshot.Services)
 		return m, nil
 
+	case msgs.NamespacesMsg:
+		m.onNamespaces(msg)
+		return m, nil
+
+	case msgs.NamespaceCheckMsg:
+		return m, m.onNamespaceCheck(msg)
+
+	case msgs.PodUsageMsg:
+		// Drop replies for a context deselected (or switched namespace)
+		// while the fetch was in flight.
+		if ns, ok := m.appState.Snapshot().SelectedContexts[msg.Context]; !ok || ns != msg.Namespace {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.topList.SetError(msg.Context, k8s.ExplainError(msg.Err))
+			return m, nil
+		}
+		pods := msg.Pods
+		if picked := m.appState.Snapshot().Namespaces[msg.Context]; len(picked) > 0 {
+			pods = slices.DeleteFunc(slices.Clone(pods), func(p k8s.PodUsage) bool {
+				return !slices.Contains(picked, p.Namespace)
+			})
+		}
+		m.topList.SetUsage(msg.Context, pods)
+		return m, nil
+
+	case msgs.NodesMsg:
+		// Nodes aren't namespaced, so only a deselect makes a reply stale.
+		if _, ok := m.appState.Snapshot().SelectedContexts[msg.Context]; !ok {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.nodeList.SetError(msg.Context, k8s.ExplainError(msg.Err))
+			return m, nil
+		}
+		m.nodeList.SetNodes(msg.Context, msg.Nodes)
+		return m, nil
+
+	case msgs.IngressesMsg:
+		// Drop replies as for PodUsageMsg.
+		if ns, ok := m.appState.Snapshot().SelectedContexts[msg.Context]; !ok || ns != msg.Namespace {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.ingList.SetError(msg.Context, k8s.ExplainError(msg.Err))
+			return m, nil
+		}
+		routes := msg.Routes
+		if picked := m.appState.Snapshot().Namespaces[msg.Context]; len(picked) > 0 {
+			routes = slices.DeleteFunc(slices.Clone(routes), func(r k8s.IngressRoute) bool {
+				return !slices.Contains(picked, r.Namespace)
+			})
+		}
+		m.ingList.SetRoutes(msg.Context, routes)
+		return m, nil
+
+	case msgs.WatchStatusMsg:
+		m.onWatchStatus(msg)
+		return m, nil
+
+	case msgs.LogLevelSwitchMsg:
+		return m, m.promptLogLevel(msg)
+
+	case msgs.PaneTemplateMsg:
+		return m, m.onPaneTemplate(msg)
+
+	case msgs.WorkloadEventsMsg:
+		m.appendEvents(msg.SourceKey, msg.Events, msg.Err)
+		return m, nil
+
+	case msgs.LogsSharedMsg:
+		return m, m.onLogsShared(msg)
+
+	case msgs.LogLevelSwitchedMsg:
+		if msg.Err != nil {
+			m.reportError("", fmt.Sprintf("Log level for %s: %v", msg.Target.Pod, msg.Err))
+			return m, nil
+		}
+		m.logPanes.AddNotice(msg.Target.SourceKey, fmt.Sprintf("log level → %s (%s)", msg.Level, msg.Where))
+		return m, nil
+
+	case msgs.PodDeploymentMsg:
+		m.confirmRestartDeployment(msg)
+		return m, nil
+
+	case msgs.PodActionMsg:
+		return m, m.onPodAction(msg)
+
+	case msgs.NodeDrainPlanMsg:
+		m.confirmDrain(msg)
+		return m, nil
+
+	case msgs.NodeActionMsg:
+		return m, m.onNodeAction(msg)
+
+	case msgs.APIResourcesMsg:
+		return m, m.onAPIResources(msg)
+
+	case msgs.CustomResourcesMsg:
+		m.onCustomResources(msg)
+		return m, nil
+
+	case msgs.ServiceBackendsMsg:
+		m.onServiceBackends(msg)
+		return m, nil
+
+	case msgs.RolloutMsg:
+		m.onRollout(msg)
+		return m, nil
+
+	case msgs.PodMetricsMsg:
+		m.onPodMetrics(msg)
+		return m, nil
+
+	case msgs.RolloutUndoneMsg:
+		return m, m.onRolloutUndone(msg)
+
+	case msgs.DeploymentCompareMsg:
+		m.onCompare(msg)
+		return m, nil
+
+	case msgs.AlertFlashEndMsg:
+		if msg.Generation == m.alertGen {
+			m.alertFlash = false
+		}
+		return m, nil
+
+	case msgs.PodActionClearMsg:
+		if msg.Generation == m.actionGen {
+			m.actionStatus = ""
+		}
+		return m, nil
+
+	case msgs.PortForwardStartedMsg:
+		if msg.Err != nil {
+			m.reportError("", fmt.Sprintf("Port-forward: %v", msg.Err))
+			return m, nil
+		}
+		m.openPortForwards()
+		return m, cmds.WaitForPortForwardCmd(msg.Info.ID, msg.Done)
+
+	case msgs.PortForwardEndedMsg:
+		m.forwardPanel.SetForwards(m.forwards.List())
+		return m, nil
+
+	case msgs.PodShellExitedMsg:
+		if msg.Err != nil {
+			m.reportError("", fmt.Sprintf("Shell in %s/%s: %v", msg.Namespace, msg.Pod, msg.Err))
+		}
+		// The terminal may have been resized while the shell had it.
+		return m, tea.RequestWindowSize
+
 	case msgs.ErrorMsg:
-		m.errorMessage = fmt.Sprintf("%s: %v", msg.Title, msg.Err)
+		errMsg := fmt.Sprintf("%s: %v", msg.Title, msg.Err)
+		m.reportError(msg.Context, errMsg)
 		if msg.Context != "" {
-			m.appState.SetError(msg.Context, m.errorMessage)
+			m.appState.SetError(msg.Context, errMsg)
 			{
 				s := m.appState.Snapshot()
 				m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
@@ -687,18 +1888,58 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case tea.BlurMsg:
+		m.unfocused = true
+		m.refreshGen++
+		return m, m.refreshTickCmd()
+
+	case tea.FocusMsg:
+		// Catch up at once rather than waiting out the slow tick: apply what
+		// the watches delivered meanwhile and restart the tick at full rate.
+		m.unfocused = false
+		m.refreshGen++
+		m.flushDeferredWatchRows()
+		return m, tea.Batch(m.refreshTickCmd(), m.loadPolledTabIfActive())
+
 	case msgs.RefreshTickMsg:
+		if msg.Generation != m.refreshGen {
+			return m, nil
+		}
 		// Always reschedule, even when auto-refresh is off or paused, so it
 		// resumes on its own the moment the pane closes / it's toggled back on.
 		// Table data itself is kept current by the watch streams; this tick
-		// just re-renders Age text from the local watch caches — purely
-		// local, zero API calls.
-		next := m.refreshTickCmd()
+		// re-renders Age text from the local watch caches and resyncs them
+		// (from the API server's watch cache, one resync per context at a
+		// time).
+		m.parkIfIdle()
+		if m.idle.parked {
+			return m, m.refreshTickCmd()
+		}
+		// Event sources aren't watched, so they're re-listed on every tick,
+		// pane open or not.
+		next := tea.Batch(m.refreshTickCmd(), m.refreshEventSources())
+		m.flushDeferredWatchRows()
+		if m.showLogs {
+			m.logPanes.RefreshStamps()
+		}
+		// Pods that didn't restart since the last tick stop standing out.
+		if m.appState.RollRestarts() {
+			m.podList.SetRows(m.appState.Snapshot().Pods)
+		}
 		if !m.autoRefresh || m.showDetail || m.showLogs || !m.appStateLoaded {
 			return m, next
 		}
 		m.reRenderAgeFromWatchCaches()
-		return m, next
+		// The top tab's usage and the nodes tab's list aren't watched, so
+		// they're re-fetched on the tick, but only while on screen.
+		return m, tea.Batch(next, m.resyncTables(), m.loadPolledTabIfActive())
+
+	case msgs.ResyncedMsg:
+		m.onResynced(msg)
+		return m, nil
+
+	case spinner.TickMsg:
+		return m, m.onSpinnerTick(msg)
 	}
 
 	// Forward non-key messages to the focused component(s)
@@ -711,12 +1952,26 @@ func (m *MainPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			forwardCmds = append(forwardCmds, m.podList.Update(msg))
 		case "svc":
 			forwardCmds = append(forwardCmds, m.svcList.Update(msg))
+		case "sts":
+			forwardCmds = append(forwardCmds, m.stsList.Update(msg))
+		case "ds":
+			forwardCmds = append(forwardCmds, m.dsList.Update(msg))
+		case "top":
+			forwardCmds = append(forwardCmds, m.topList.Update(msg))
+		case "nodes":
+			forwardCmds = append(forwardCmds, m.nodeList.Update(msg))
+		case "ing":
+			forwardCmds = append(forwardCmds, m.ingList.Update(msg))
+		case "cr":
+			forwardCmds = append(forwardCmds, m.crList.Update(msg))
+		case "watch":
+			forwardCmds = append(forwardCmds, m.watchList.Update(msg))
 		}
 		if m.showDetail {
 			forwardCmds = append(forwardCmds, m.deploymentDetail.Update(msg))
 		}
 		if m.showLogs {
-			forwardCmds = append(forwardCmds, m.podLogs.Update(msg))
+			forwardCmds = append(forwardCmds, m.logPanes.Update(msg))
 		}
 		if len(forwardCmds) > 0 {
 			return m, tea.Batch(forwardCmds...)
@@ -749,6 +2004,10 @@ func (m *MainPage) toggleFocus() {
 
 func (m *MainPage) updateFocusStates() {
 	m.contextList.SetFocused(m.focus == focusLeftPane)
+	if strip := m.contextsCollapsed && m.focus != focusLeftPane; strip != m.stripShown {
+		m.stripShown = strip
+		m.sizeTabArea()
+	}
 	listActive := m.focus == focusTabs && !m.detailFocused && !m.logsFocused
 	shouldFocusDeployments := listActive && m.tabs[m.activeTab] == "Deployments" && m.appStateLoaded
 	m.deploymentList.SetFocused(shouldFocusDeployments)
@@ -756,26 +2015,55 @@ func (m *MainPage) updateFocusStates() {
 	m.podList.SetFocused(shouldFocusPods)
 	shouldFocusSvc := listActive && m.tabs[m.activeTab] == "svc" && m.appStateLoaded
 	m.svcList.SetFocused(shouldFocusSvc)
+	m.stsList.SetFocused(listActive && m.tabs[m.activeTab] == "sts" && m.appStateLoaded)
+	m.dsList.SetFocused(listActive && m.tabs[m.activeTab] == "ds" && m.appStateLoaded)
+	m.topList.SetFocused(listActive && m.tabs[m.activeTab] == "top" && m.appStateLoaded)
+	m.nodeList.SetFocused(listActive && m.tabs[m.activeTab] == "nodes" && m.appStateLoaded)
+	m.ingList.SetFocused(listActive && m.tabs[m.activeTab] == "ing" && m.appStateLoaded)
+	m.crList.SetFocused(listActive && m.tabs[m.activeTab] == "cr" && m.appStateLoaded)
+	m.watchList.SetFocused(listActive && m.tabs[m.activeTab] == "watch" && m.appStateLoaded)
 	m.deploymentDetail.SetFocused(m.focus == focusTabs && m.detailFocused)
-	m.podLogs.SetFocused(m.focus == focusTabs && m.logsFocused)
+	m.logPanes.SetFocused(m.focus == focusTabs && m.logsFocused)
 }
 
-// detailPaneHeightPercent is the share of the tab content area given to the
-// detail pane, out of the remainder after list rows.
-const detailPaneHeightPercent = 45
+// The share of the tab content area given to the detail/log pane below the
+// list, in percent: where it starts, how far Ctrl+Up/Down move it per press
+// and the bounds it's kept in, so neither half shrinks to nothing.
+const (
+	defaultSplitPercent = 45
+	splitStepPercent    = 5
+	minSplitPercent     = 20
+	maxSplitPercent     = 80
+)
+
+// resizeSplit (Ctrl+Up/Down) moves the divider between the list and the
+// open detail/log pane by delta percent of the content area — up, giving
+// the pane more room, for a positive delta. The split is kept across runs
+// (see State).
+func (m *MainPage) resizeSplit(delta int) {
+	if !m.showDetail && !m.showLogs {
+		return
+	}
+	m.splitPercent = max(minSplitPercent, min(maxSplitPercent, m.splitPercent+delta))
+	m.applyContentSizes()
+}
 
 // applyContentSizes resizes the Deployments/Pods lists and the bottom pane
 // (Detail or Logs — mutually exclusive) to split the tab content area in two
 // whenever either is open.
 func (m *MainPage) applyContentSizes() {
-	listH := m.tableH
+	contentH := m.tableH
+	if m.chipRowShown {
+		contentH-- // the filter chip line above the table
+	}
+	listH := contentH
 	detailH := 0
 	if m.showDetail || m.showLogs {
-		detailH = m.tableH * detailPaneHeightPercent / 100
+		detailH = m.tableH * m.splitPercent / 100
 		if detailH < 6 {
 			detailH = 6
 		}
-		listH = m.tableH - detailH - 2 // 2 lines reserved: the divider and the pane header
+		listH = contentH - detailH - 2 // 2 lines reserved: the divider and the pane header
 		if listH < 3 {
 			listH = 3
 		}
@@ -783,12 +2071,19 @@ func (m *MainPage) applyContentSizes() {
 	m.deploymentList.SetSize(m.tableW, listH)
 	m.podList.SetSize(m.tableW, listH)
 	m.svcList.SetSize(m.tableW, listH)
+	m.stsList.SetSize(m.tableW, listH)
+	m.dsList.SetSize(m.tableW, listH)
+	m.topList.SetSize(m.tableW, listH)
+	m.nodeList.SetSize(m.tableW, listH)
+	m.ingList.SetSize(m.tableW, listH)
+	m.crList.SetSize(m.tableW, listH)
+	m.watchList.SetSize(m.tableW, listH)
 	m.deploymentDetail.SetSize(m.tableW, detailH)
-	m.podLogs.SetSize(m.tableW, detailH)
+	m.logPanes.SetSize(m.tableW, detailH)
 }
 
 // openResourceDetail loads detail for the currently selected row on the given
-// top tab ("Deployments", "Pods", or "svc") into the shared bottom detail pane.
+// top tab (one of the isResourceTab tabs) into the shared bottom detail pane.
 // Returns nil if there's no valid selection.
 func (m *MainPage) openResourceDetail(sourceTab string) tea.Cmd {
 	var kind, name, ctxName, namespace string
@@ -821,6 +2116,19 @@ func (m *MainPage) openResourceDetail(sourceTab string) tea.Cmd {
 		name, _ = row[msgs.SvcKeyName].(string)
 		namespace, _ = row[msgs.SvcKeyNamespace].(string)
 		ctxName, _ = row[msgs.SvcKeyContext].(string)
+	case "sts", "ds":
+		list := m.stsList
+		if sourceTab == "ds" {
+			list = m.dsList
+		}
+		row := list.SelectedRow()
+		if row == nil {
+			return nil
+		}
+		kind = list.Kind()
+		name, _ = row[msgs.WorkloadKeyName].(string)
+		namespace, _ = row[msgs.WorkloadKeyNamespace].(string)
+		ctxName, _ = row[msgs.WorkloadKeyContext].(string)
 	default:
 		return nil
 	}
@@ -843,18 +2151,209 @@ func (m *MainPage) openResourceDetail(sourceTab string) tea.Cmd {
 
 	switch kind {
 	case "Pod":
-		return cmds.LoadPodDetailCmd(m.Client, ctxName, namespace, name)
+		return cmds.LoadPodDetailCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
 	case "Service":
-		return cmds.LoadServiceDetailCmd(m.Client, ctxName, namespace, name)
+		return cmds.LoadServiceDetailCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+	case "StatefulSet":
+		return cmds.LoadStatefulSetDetailCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+	case "DaemonSet":
+		return cmds.LoadDaemonSetDetailCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
 	default:
-		return cmds.LoadDeploymentDetailCmd(m.Client, ctxName, namespace, name)
+		return cmds.LoadDeploymentDetailCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
 	}
 }
 
+// podEnvPanelKey identifies the env panel opened for one pod.
+func podEnvPanelKey(ctxName, namespace, pod string) string {
+	return "env/" + ctxName + "/" + namespace + "/" + pod
+}
+
+// openContextConflicts opens the info panel on the kubeconfig entries
+// renamed at load time. Its content is already in hand, so no fetch.
+func (m *MainPage) openContextConflicts() {
+	conflicts := m.Client.ContextConflicts()
+	m.panelKey = "conflicts"
+	m.showPanel = true
+	m.infoPanel.SetContent(fmt.Sprintf("Kubeconfig conflicts: %d", len(conflicts)), models.ContextConflictLines(conflicts))
+}
+
+// openBuildInfo opens the info panel on the running build's version,
+// commit, build date and Go version — what a bug report needs to name it.
+func (m *MainPage) openBuildInfo() {
+	m.panelKey = "build"
+	m.showPanel = true
+	lines := append(m.build.Lines(), "", "Paste these lines into bug reports; `ktails version` prints them too.")
+	m.infoPanel.SetContent("Build info", lines)
+}
+
+// findLogLevelSwitch starts looking up which configured log level switch
+// applies to the log pane's active source (see LogPage.ActiveSource).
+func (m *MainPage) findLogLevelSwitch() tea.Cmd {
+	target, ok := m.logPanes.Active().ActiveSource()
+	if !ok {
+		m.reportError("", "Log level: isolate one source first (c)")
+		return nil
+	}
+	return cmds.FindLogLevelSwitchCmd(m.ctx, m.Client, m.logLevelSwitches, target)
+}
+
+// promptLogLevel asks for the level to switch msg's target to, once the
+// switch that applies to it is known.
+func (m *MainPage) promptLogLevel(msg msgs.LogLevelSwitchMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.reportError("", fmt.Sprintf("Log level for %s: %v", msg.Target.Pod, msg.Err))
+		return nil
+	}
+	if msg.Switch == nil {
+		m.reportError("", fmt.Sprintf("Log level: no log_level_switches entry matches pod %s", msg.Target.Pod))
+		return nil
+	}
+	sw := *msg.Switch
+	title := fmt.Sprintf("Log level: %s/%s", msg.Target.Pod, msg.Target.Container)
+	label := fmt.Sprintf("%s — one of %s", sw.Name, strings.Join(sw.Levels, ", "))
+	return m.openPrompt(title, label, "", func(level string) tea.Cmd {
+		if !slices.Contains(sw.Levels, level) {
+			m.reportError("", fmt.Sprintf("Log level: %q is not one of %s", level, strings.Join(sw.Levels, ", ")))
+			return nil
+		}
+		return cmds.SwitchLogLevelCmd(m.ctx, m.Client, sw, msg.Target, msg.Labels, level)
+	})
+}
+
+// openPodShell starts a shell in a container of the Pods row under the
+// cursor, asking which first if the pod has several — as the file browser
+// does. Returns nil if there's no selection.
+func (m *MainPage) openPodShell() tea.Cmd {
+	row := m.podList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.PodKeyName].(string)
+	namespace, _ := row[msgs.PodKeyNamespace].(string)
+	ctxName, _ := row[msgs.PodKeyContext].(string)
+	containersCSV, _ := row[msgs.PodKeyContainers].(string)
+	containers := strings.Split(containersCSV, ",")
+	if len(containers) < 2 {
+		return cmds.ExecPodShellCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name, containers[0])
+	}
+
+	label := fmt.Sprintf("Shell into which container of %s/%s (%s)? %s", namespace, name, ctxName, containersCSV)
+	cmd := m.openPrompt("Shell", label, containers[0], func(container string) tea.Cmd {
+		if !slices.Contains(containers, container) {
+			m.reportError("", fmt.Sprintf("Shell: %s/%s has no container %s", namespace, name, container))
+			return nil
+		}
+		return cmds.ExecPodShellCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name, container)
+	})
+	m.prompt.SetSuggestions(containers)
+	return cmd
+}
+
+// openPodEnv opens the info panel on the Pods row under the cursor and
+// starts resolving its environment. Returns nil if there's no selection.
+func (m *MainPage) openPodEnv() tea.Cmd {
+	row := m.podList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.PodKeyName].(string)
+	namespace, _ := row[msgs.PodKeyNamespace].(string)
+	ctxName, _ := row[msgs.PodKeyContext].(string)
+
+	m.panelKey = podEnvPanelKey(ctxName, namespace, name)
+	m.showPanel = true
+	m.infoPanel.StartLoading(fmt.Sprintf("Env: %s/%s (%s)", namespace, name, ctxName))
+	return cmds.LoadPodEnvCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+}
+
+// showDeploymentPods switches to the Pods tab, browsing by deployment with
+// the pods of the Deployments row under the cursor listed — from "1/3"
+// ready to the pods that aren't in one keystroke. Backspace goes up to
+// every Deployment's pods, b back to the flat list.
+func (m *MainPage) showDeploymentPods() {
+	row := m.deploymentList.SelectedRow()
+	if row == nil {
+		return
+	}
+	name, _ := row[msgs.DeployKeyName].(string)
+	namespace, _ := row[msgs.DeployKeyNamespace].(string)
+	ctxName, _ := row[msgs.DeployKeyContext].(string)
+	m.podList.OpenDeployment(ctxName, namespace, name)
+	m.activeTab = slices.Index(m.tabs, "Pods")
+	m.updateFocusStates()
+}
+
+// loadTopIfActive fetches pod usage for every selected context when the top
+// tab is the one showing — on entering it, on "r" and on each refresh tick.
+// Contexts near their request budget are skipped. Returns nil on any other
+// tab.
+func (m *MainPage) loadTopIfActive() tea.Cmd {
+	if m.tabs[m.activeTab] != "top" || !m.appStateLoaded {
+		return nil
+	}
+	var cmdSequence []tea.Cmd
+	contexts, _ := m.withinBudget(m.appState.Snapshot().SelectedContexts)
+	for context, namespace := range contexts {
+		cmdSequence = append(cmdSequence, cmds.LoadPodUsageCmd(m.callCtx(context), m.Client, context, namespace))
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// loadNodesIfActive lists the nodes of every selected context when the
+// nodes tab is the one showing, as loadTopIfActive does for usage.
+func (m *MainPage) loadNodesIfActive() tea.Cmd {
+	if m.tabs[m.activeTab] != "nodes" || !m.appStateLoaded {
+		return nil
+	}
+	var cmdSequence []tea.Cmd
+	contexts, _ := m.withinBudget(m.appState.Snapshot().SelectedContexts)
+	for context := range contexts {
+		cmdSequence = append(cmdSequence, cmds.LoadNodesCmd(m.callCtx(context), m.Client, context))
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// loadIngressesIfActive lists the Ingresses of every selected context when
+// the ing tab is the one showing, as loadTopIfActive does for usage.
+func (m *MainPage) loadIngressesIfActive() tea.Cmd {
+	if m.tabs[m.activeTab] != "ing" || !m.appStateLoaded {
+		return nil
+	}
+	var cmdSequence []tea.Cmd
+	contexts, _ := m.withinBudget(m.appState.Snapshot().SelectedContexts)
+	for context, namespace := range contexts {
+		cmdSequence = append(cmdSequence, cmds.LoadIngressesCmd(m.callCtx(context), m.Client, context, namespace))
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// loadCustomResourcesIfActive lists the picked type's resources in every
+// selected context when the cr tab is the one showing, as loadTopIfActive
+// does for usage. nil until a type has been picked.
+func (m *MainPage) loadCustomResourcesIfActive() tea.Cmd {
+	resource, ok := m.crList.Resource()
+	if m.tabs[m.activeTab] != "cr" || !m.appStateLoaded || !ok {
+		return nil
+	}
+	var cmdSequence []tea.Cmd
+	contexts, _ := m.withinBudget(m.appState.Snapshot().SelectedContexts)
+	for context, namespace := range contexts {
+		cmdSequence = append(cmdSequence, cmds.LoadCustomResourcesCmd(m.callCtx(context), m.Client, context, namespace, resource))
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// loadPolledTabIfActive fetches the active tab's data if it's one of the
+// tabs that aren't watch-backed (top, nodes, ing, cr, watch); nil on any
+// other tab.
+func (m *MainPage) loadPolledTabIfActive() tea.Cmd {
+	return tea.Batch(m.loadTopIfActive(), m.loadNodesIfActive(), m.loadIngressesIfActive(), m.loadCustomResourcesIfActive(), m.loadWatchlistIfActive())
+}
+
 // wideModeTable is implemented identically by DeploymentPage/PodPage/
-// ServicePage — the Ctrl+W wide-mode toggle, Shift+Left/Right column scroll,
-// and the "/" filter status all operate on whichever of the three is the
-// active tab.
+// ServicePage/WorkloadPage (and, trivially, TopPage, NodePage, IngressPage, CustomResourcePage and WatchlistPage) — the Ctrl+W wide-mode toggle, Shift+Left/Right
+// column scroll, the "/" filter status and mouse clicks and scrolling all
+// operate on whichever of them is the active tab.
 type wideModeTable interface {
 	ToggleWideMode()
 	WideMode() bool
@@ -862,10 +2361,24 @@ type wideModeTable interface {
 	ScrollRight()
 	ScrollStatus() (offset, total int, ok bool)
 	FilterStatus() (query string, matches int, typing bool, ok bool)
+	SetFilter(query string)
+	ClickRow(y int) bool
+	ScrollRows(delta int)
+}
+
+// switchTab makes tab i the active one — a resource tab only once
+// contexts are loaded — loading it if it's polled.
+func (m *MainPage) switchTab(i int) tea.Cmd {
+	if isResourceTab(m.tabs[i]) && (!m.appStateLoaded || len(m.appState.Snapshot().SelectedContexts) == 0) {
+		return nil
+	}
+	m.activeTab = i
+	m.updateFocusStates()
+	return m.loadPolledTabIfActive()
 }
 
 // activeResourceTable returns the active tab's table as a wideModeTable, or
-// nil if the active tab isn't one of the three resource tables.
+// nil if the active tab isn't one of the resource tables.
 func (m *MainPage) activeResourceTable() wideModeTable {
 	switch m.tabs[m.activeTab] {
 	case "Deployments":
@@ -874,15 +2387,54 @@ func (m *MainPage) activeResourceTable() wideModeTable {
 		return m.podList
 	case "svc":
 		return m.svcList
+	case "sts":
+		return m.stsList
+	case "ds":
+		return m.dsList
+	case "top":
+		return m.topList
+	case "nodes":
+		return m.nodeList
+	case "ing":
+		return m.ingList
+	case "cr":
+		return m.crList
+	case "watch":
+		return m.watchList
 	}
 	return nil
 }
 
+// foldSection folds or unfolds the context section the cursor is in on
+// tab's table, Pods or Deployments (z).
+func (m *MainPage) foldSection(tab string) {
+	folded := false
+	if tab == "Pods" {
+		folded = m.podList.FoldSection()
+	} else {
+		folded = m.deploymentList.FoldSection()
+	}
+	if !folded {
+		m.actionStatus = "Rows are grouped by context only with several contexts loaded"
+	}
+}
+
+// isResourceTab reports whether tab is one of the per-context resource
+// tables (as opposed to a tab that works without any context selected).
+func isResourceTab(tab string) bool {
+	switch tab {
+	case "Deployments", "Pods", "svc", "sts", "ds", "top", "nodes", "ing", "cr":
+		return true
+	}
+	return false
+}
+
 // applyPodWatchRows applies a freshly rebuilt Pods row set for one context
 // to AppState and the render models — the watch equivalent of the old
 // PodTableMsg success path.
 func (m *MainPage) applyPodWatchRows(context string, rows []msgs.RowData) {
 	m.appState.SetPods(context, rows)
+	m.trace.MarkContext(context, startup.StepFirstData)
 	snapshot := m.appState.Snapshot()
 	m.podList.SetRows(snapshot.Pods)
 	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
@@ -891,100 +2443,228 @@ func (m *MainPage) applyPodWatchRows(context string, rows []msgs.RowData) {
 // applyDeploymentWatchRows mirrors applyPodWatchRows for Deployments.
 func (m *MainPage) applyDeploymentWatchRows(context string, rows []msgs.RowData) {
 	m.appState.SetDeployments(context, rows)
+	m.trace.MarkContext(context, startup.StepFirstData)
 	snapshot := m.appState.Snapshot()
 	m.deploymentList.SetRows(snapshot.Deployments)
 	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
 	m.updateFocusStates()
 }
 
-// applyServiceWatchRows mirrors applyPodWatchRows for Services.
-func (m *MainPage) applyServiceWatchRows(context string, rows []msgs.RowData) {
-	m.appState.SetServices(context, rows)
-	snapshot := m.appState.Snapshot()
-	m.svcList.SetRows(snapshot.Services)
-	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+// applyServiceWatchRows mirrors applyPodWatchRows for Services.
+func (m *MainPage) applyServiceWatchRows(context string, rows []msgs.RowData) {
+	m.appState.SetServices(context, rows)
+	m.trace.MarkContext(context, startup.StepFirstData)
+	snapshot := m.appState.Snapshot()
+	m.svcList.SetRows(snapshot.Services)
+	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+}
+
+// applyStatefulSetWatchRows mirrors applyPodWatchRows for StatefulSets.
+func (m *MainPage) applyStatefulSetWatchRows(context string, rows []msgs.RowData) {
+	m.appState.SetStatefulSets(context, rows)
+	m.trace.MarkContext(context, startup.StepFirstData)
+	snapshot := m.appState.Snapshot()
+	m.stsList.SetRows(snapshot.StatefulSets)
+	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+}
+
+// applyDaemonSetWatchRows mirrors applyPodWatchRows for DaemonSets.
+func (m *MainPage) applyDaemonSetWatchRows(context string, rows []msgs.RowData) {
+	m.appState.SetDaemonSets(context, rows)
+	m.trace.MarkContext(context, startup.StepFirstData)
+	snapshot := m.appState.Snapshot()
+	m.dsList.SetRows(snapshot.DaemonSets)
+	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+}
+
+// needsFixing reports whether a watch failed for a reason reconnecting
+// won't fix — credentials or RBAC — so it's given up on at once.
+func needsFixing(err error) bool {
+	kind := k8s.ErrorKindOf(err)
+	return kind == k8s.ErrorUnauthorized || kind == k8s.ErrorForbidden
+}
+
+// reportSlow marks a context whose watch failed to open for want of a
+// response in time (see k8s.LoadLimits) as slow in the contexts list, so it
+// stops counting as loading while the others finish; the watch keeps
+// retrying, and its first rows clear the mark.
+func (m *MainPage) reportSlow(context string, err error) {
+	var slow *k8s.SlowResponseError
+	if !errors.As(err, &slow) {
+		return
+	}
+	m.slow[context] = true
+	m.appState.SetError(context, fmt.Sprintf("slow: no response within %s · retrying", slow.Timeout))
+	s := m.appState.Snapshot()
+	m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
+}
+
+// clearSlow lifts reportSlow's mark once one of context's watches opens.
+func (m *MainPage) clearSlow(context string) {
+	if !m.slow[context] {
+		return
+	}
+	delete(m.slow, context)
+	m.appState.ClearError(context)
+	s := m.appState.Snapshot()
+	m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
+}
+
+// onPodWatchClosed handles a stopped/failed Pods watch for one context:
+// dropped if stale or the context is no longer selected, otherwise
+// reconnected with exponential backoff, or — past
+// maxWatchReconnectFailures consecutive failures — given up on with the
+// error surfaced the same way a List-failure error would be.
+func (m *MainPage) onPodWatchClosed(msg msgs.PodWatchClosedMsg) tea.Cmd {
+	st, ok := m.podWatchers[msg.Context]
+	if !ok || msg.Generation != st.generation {
+		return nil
+	}
+	st.watcher = nil
+	st.failures++
+
+	namespace, stillWatched := m.appState.WatchedNamespace(msg.Context)
+	if !stillWatched {
+		return nil
+	}
+	m.reportSlow(msg.Context, msg.Err)
+
+	if st.failures > maxWatchReconnectFailures || needsFixing(msg.Err) {
+		errMsg := watchFailure("pods", msg.Context, st.failures, msg.Err)
+		m.appState.SetError(msg.Context, errMsg)
+		m.reportError(msg.Context, errMsg)
+		s := m.appState.Snapshot()
+		m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
+		return nil
+	}
+
+	return cmds.ReconnectPodsCmd(m.callCtx(msg.Context), m.Client, msg.Context, namespace, m.listOptions("Pods"), st.generation, watchBackoffDelay(st.failures))
+}
+
+// onDeploymentWatchClosed mirrors onPodWatchClosed for Deployments.
+func (m *MainPage) onDeploymentWatchClosed(msg msgs.DeploymentWatchClosedMsg) tea.Cmd {
+	st, ok := m.deploymentWatchers[msg.Context]
+	if !ok || msg.Generation != st.generation {
+		return nil
+	}
+	st.watcher = nil
+	st.failures++
+
+	namespace, stillWatched := m.appState.WatchedNamespace(msg.Context)
+	if !stillWatched {
+		return nil
+	}
+	m.reportSlow(msg.Context, msg.Err)
+
+	if st.failures > maxWatchReconnectFailures || needsFixing(msg.Err) {
+		errMsg := watchFailure("deployments", msg.Context, st.failures, msg.Err)
+		m.appState.SetError(msg.Context, errMsg)
+		m.reportError(msg.Context, errMsg)
+		s := m.appState.Snapshot()
+		m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
+		return nil
+	}
+
+	return cmds.ReconnectDeploymentsCmd(m.callCtx(msg.Context), m.Client, msg.Context, namespace, m.listOptions("Deployments"), st.generation, watchBackoffDelay(st.failures))
 }
 
-// onPodWatchClosed handles a stopped/failed Pods watch for one context:
-// dropped if stale or the context is no longer selected, otherwise
-// reconnected with exponential backoff, or — past
-// maxWatchReconnectFailures consecutive failures — given up on with the
-// error surfaced the same way a List-failure error would be.
-func (m *MainPage) onPodWatchClosed(msg msgs.PodWatchClosedMsg) tea.Cmd {
-	st, ok := m.podWatchers[msg.Context]
+// onServiceWatchClosed mirrors onPodWatchClosed for Services.
+func (m *MainPage) onServiceWatchClosed(msg msgs.ServiceWatchClosedMsg) tea.Cmd {
+	st, ok := m.serviceWatchers[msg.Context]
 	if !ok || msg.Generation != st.generation {
 		return nil
 	}
 	st.watcher = nil
 	st.failures++
 
-	namespace, stillSelected := m.appState.Snapshot().SelectedContexts[msg.Context]
-	if !stillSelected {
+	namespace, stillWatched := m.appState.WatchedNamespace(msg.Context)
+	if !stillWatched {
 		return nil
 	}
+	m.reportSlow(msg.Context, msg.Err)
 
-	if st.failures > maxWatchReconnectFailures {
-		errMsg := fmt.Sprintf("Failed to watch pods for context '%s' after %d attempts: %v", msg.Context, st.failures, msg.Err)
+	if st.failures > maxWatchReconnectFailures || needsFixing(msg.Err) {
+		errMsg := watchFailure("services", msg.Context, st.failures, msg.Err)
 		m.appState.SetError(msg.Context, errMsg)
-		m.errorMessage = errMsg
+		m.reportError(msg.Context, errMsg)
 		s := m.appState.Snapshot()
 		m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
 		return nil
 	}
 
-	return cmds.ReconnectPodsCmd(m.Client, msg.Context, namespace, st.generation, watchBackoffDelay(st.failures))
+	return cmds.ReconnectServicesCmd(m.callCtx(msg.Context), m.Client, msg.Context, namespace, m.listOptions("svc"), st.generation, watchBackoffDelay(st.failures))
 }
 
-// onDeploymentWatchClosed mirrors onPodWatchClosed for Deployments.
-func (m *MainPage) onDeploymentWatchClosed(msg msgs.DeploymentWatchClosedMsg) tea.Cmd {
-	st, ok := m.deploymentWatchers[msg.Context]
+// onStatefulSetWatchClosed mirrors onPodWatchClosed for StatefulSets.
+func (m *MainPage) onStatefulSetWatchClosed(msg msgs.StatefulSetWatchClosedMsg) tea.Cmd {
+	st, ok := m.stsWatchers[msg.Context]
 	if !ok || msg.Generation != st.generation {
 		return nil
 	}
 	st.watcher = nil
 	st.failures++
 
-	namespace, stillSelected := m.appState.Snapshot().SelectedContexts[msg.Context]
-	if !stillSelected {
+	namespace, stillWatched := m.appState.WatchedNamespace(msg.Context)
+	if !stillWatched {
 		return nil
 	}
+	m.reportSlow(msg.Context, msg.Err)
 
-	if st.failures > maxWatchReconnectFailures {
-		errMsg := fmt.Sprintf("Failed to watch deployments for context '%s' after %d attempts: %v", msg.Context, st.failures, msg.Err)
+	if st.failures > maxWatchReconnectFailures || needsFixing(msg.Err) {
+		errMsg := watchFailure("statefulsets", msg.Context, st.failures, msg.Err)
 		m.appState.SetError(msg.Context, errMsg)
-		m.errorMessage = errMsg
+		m.reportError(msg.Context, errMsg)
 		s := m.appState.Snapshot()
 		m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
 		return nil
 	}
 
-	return cmds.ReconnectDeploymentsCmd(m.Client, msg.Context, namespace, st.generation, watchBackoffDelay(st.failures))
+	return cmds.ReconnectStatefulSetsCmd(m.callCtx(msg.Context), m.Client, msg.Context, namespace, m.listOptions("sts"), st.generation, watchBackoffDelay(st.failures))
 }
 
-// onServiceWatchClosed mirrors onPodWatchClosed for Services.
-func (m *MainPage) onServiceWatchClosed(msg msgs.ServiceWatchClosedMsg) tea.Cmd {
-	st, ok := m.serviceWatchers[msg.Context]
+// onDaemonSetWatchClosed mirrors onPodWatchClosed for DaemonSets.
+func (m *MainPage) onDaemonSetWatchClosed(msg msgs.DaemonSetWatchClosedMsg) tea.Cmd {
+	st, ok := m.dsWatchers[msg.Context]
 	if !ok || msg.Generation != st.generation {
 		return nil
 	}
 	st.watcher = nil
 	st.failures++
 
-	namespace, stillSelected := m.appState.Snapshot().SelectedContexts[msg.Context]
-	if !stillSelected {
+	namespace, stillWatched := m.appState.WatchedNamespace(msg.Context)
+	if !stillWatched {
 		return nil
 	}
+	m.reportSlow(msg.Context, msg.Err)
 
-	if st.failures > maxWatchReconnectFailures {
-		errMsg := fmt.Sprintf("Failed to watch services for context '%s' after %d attempts: %v", msg.Context, st.failures, msg.Err)
+	if st.failures > maxWatchReconnectFailures || needsFixing(msg.Err) {
+		errMsg := watchFailure("daemonsets", msg.Context, st.failures, msg.Err)
 		m.appState.SetError(msg.Context, errMsg)
-		m.errorMessage = errMsg
+		m.reportError(msg.Context, errMsg)
 		s := m.appState.Snapshot()
 		m.contextList.SetContextStates(s.LoadingStates, s.Errors, s.LoadedContexts)
 		return nil
 	}
 
-	return cmds.ReconnectServicesCmd(m.Client, msg.Context, namespace, st.generation, watchBackoffDelay(st.failures))
+	return cmds.ReconnectDaemonSetsCmd(m.callCtx(msg.Context), m.Client, msg.Context, namespace, m.listOptions("ds"), st.generation, watchBackoffDelay(st.failures))
+}
+
+// removeContext unloads a context: drops its rows and stops its watches.
+func (m *MainPage) removeContext(context string) {
+	m.forgetParked(context)
+	m.appState.RemoveContext(context)
+	m.stopPodWatch(context)
+	m.stopDeploymentWatch(context)
+	m.stopServiceWatch(context)
+	m.stopStatefulSetWatch(context)
+	m.stopDaemonSetWatch(context)
+	m.topList.RemoveContext(context)
+	m.nodeList.RemoveContext(context)
+	m.ingList.RemoveContext(context)
+	m.crList.RemoveContext(context)
+	m.alerts.ForgetContext(context)
+	m.cancelCalls(context)
+	delete(m.slow, context)
 }
 
 // stopPodWatch stops (if open) and forgets a context's Pods watch — called
@@ -1031,36 +2711,90 @@ func (m *MainPage) stopServiceWatch(context string) {
 	delete(m.serviceWatchers, context)
 }
 
+// stopStatefulSetWatch mirrors stopPodWatch for StatefulSets.
+func (m *MainPage) stopStatefulSetWatch(context string) {
+	st, ok := m.stsWatchers[context]
+	if !ok {
+		return
+	}
+	st.generation++
+	if st.watcher != nil {
+		st.watcher.Stop()
+	}
+	delete(m.stsWatchers, context)
+}
+
+// stopDaemonSetWatch mirrors stopPodWatch for DaemonSets.
+func (m *MainPage) stopDaemonSetWatch(context string) {
+	st, ok := m.dsWatchers[context]
+	if !ok {
+		return
+	}
+	st.generation++
+	if st.watcher != nil {
+		st.watcher.Stop()
+	}
+	delete(m.dsWatchers, context)
+}
+
 // restartActiveTabWatch force-restarts the watch(es) for only the active
 // tab's resource type, across every selected context — the "r" key. The
 // existing watch cache for each context is reused as-is: a fresh watch's
-// Added replay is idempotent against the upsert-based cache.apply.
+// Added replay is idempotent against the upsert-based cache.apply. Contexts
+// near their request budget are skipped.
 func (m *MainPage) restartActiveTabWatch() tea.Cmd {
 	snapshot := m.appState.Snapshot()
 	if len(snapshot.SelectedContexts) == 0 {
 		return nil
 	}
 
+	contexts, held := m.withinBudget(snapshot.SelectedContexts)
+	if len(held) > 0 {
+		m.reportError("", fmt.Sprintf("API budget: refresh held back for %s", strings.Join(held, ", ")))
+	}
+
 	var cmdSequence []tea.Cmd
 	switch m.tabs[m.activeTab] {
 	case "Deployments":
-		for context, namespace := range snapshot.SelectedContexts {
+		for context, namespace := range contexts {
 			if cmd := m.restartDeploymentWatch(context, namespace); cmd != nil {
 				cmdSequence = append(cmdSequence, cmd)
 			}
 		}
 	case "Pods":
-		for context, namespace := range snapshot.SelectedContexts {
+		for context, namespace := range contexts {
 			if cmd := m.restartPodWatch(context, namespace); cmd != nil {
 				cmdSequence = append(cmdSequence, cmd)
 			}
 		}
 	case "svc":
-		for context, namespace := range snapshot.SelectedContexts {
+		for context, namespace := range contexts {
 			if cmd := m.restartServiceWatch(context, namespace); cmd != nil {
 				cmdSequence = append(cmdSequence, cmd)
 			}
 		}
+	case "sts":
+		for context, namespace := range contexts {
+			if cmd := m.restartStatefulSetWatch(context, namespace); cmd != nil {
+				cmdSequence = append(cmdSequence, cmd)
+			}
+		}
+	case "ds":
+		for context, namespace := range contexts {
+			if cmd := m.restartDaemonSetWatch(context, namespace); cmd != nil {
+				cmdSequence = append(cmdSequence, cmd)
+			}
+		}
+	case "top":
+		return m.loadTopIfActive()
+	case "nodes":
+		return m.loadNodesIfActive()
+	case "ing":
+		return m.loadIngressesIfActive()
+	case "cr":
+		return m.loadCustomResourcesIfActive()
+	case "watch":
+		return m.loadWatchlistIfActive()
 	}
 
 	if len(cmdSequence) == 0 {
@@ -1089,7 +2823,7 @@ func (m *MainPage) restartPodWatch(context, namespace string) tea.Cmd {
 	st.generation++
 	st.failures = 0
 	m.appState.SetLoadingPods(context, true)
-	return cmds.WatchPodsCmd(m.Client, context, namespace, st.generation)
+	return cmds.WatchPodsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("Pods"), st.generation)
 }
 
 // restartDeploymentWatch mirrors restartPodWatch for Deployments.
@@ -1105,7 +2839,7 @@ func (m *MainPage) restartDeploymentWatch(context, namespace string) tea.Cmd {
 	st.generation++
 	st.failures = 0
 	m.appState.SetLoading(context, true)
-	return cmds.WatchDeploymentsCmd(m.Client, context, namespace, st.generation)
+	return cmds.WatchDeploymentsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("Deployments"), st.generation)
 }
 
 // restartServiceWatch mirrors restartPodWatch for Services.
@@ -1121,7 +2855,69 @@ func (m *MainPage) restartServiceWatch(context, namespace string) tea.Cmd {
 	st.generation++
 	st.failures = 0
 	m.appState.SetLoadingServices(context, true)
-	return cmds.WatchServicesCmd(m.Client, context, namespace, st.generation)
+	return cmds.WatchServicesCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("svc"), st.generation)
+}
+
+// restartStatefulSetWatch mirrors restartPodWatch for StatefulSets.
+func (m *MainPage) restartStatefulSetWatch(context, namespace string) tea.Cmd {
+	st, ok := m.stsWatchers[context]
+	if !ok {
+		return nil
+	}
+	if st.watcher != nil {
+		st.watcher.Stop()
+		st.watcher = nil
+	}
+	st.generation++
+	st.failures = 0
+	m.appState.SetLoadingStatefulSets(context, true)
+	return cmds.WatchStatefulSetsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("sts"), st.generation)
+}
+
+// restartDaemonSetWatch mirrors restartPodWatch for DaemonSets.
+func (m *MainPage) restartDaemonSetWatch(context, namespace string) tea.Cmd {
+	st, ok := m.dsWatchers[context]
+	if !ok {
+		return nil
+	}
+	if st.watcher != nil {
+		st.watcher.Stop()
+		st.watcher = nil
+	}
+	st.generation++
+	st.failures = 0
+	m.appState.SetLoadingDaemonSets(context, true)
+	return cmds.WatchDaemonSetsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("ds"), st.generation)
+}
+
+// flushDeferredWatchRows applies the rows each watch deferred while the
+// terminal was unfocused.
+func (m *MainPage) flushDeferredWatchRows() {
+	for context, st := range m.podWatchers {
+		if rows, ok := st.takeDeferred(); ok {
+			m.applyPodWatchRows(context, rows)
+		}
+	}
+	for context, st := range m.deploymentWatchers {
+		if rows, ok := st.takeDeferred(); ok {
+			m.applyDeploymentWatchRows(context, rows)
+		}
+	}
+	for context, st := range m.serviceWatchers {
+		if rows, ok := st.takeDeferred(); ok {
+			m.applyServiceWatchRows(context, rows)
+		}
+	}
+	for context, st := range m.stsWatchers {
+		if rows, ok := st.takeDeferred(); ok {
+			m.applyStatefulSetWatchRows(context, rows)
+		}
+	}
+	for context, st := range m.dsWatchers {
+		if rows, ok := st.takeDeferred(); ok {
+			m.applyDaemonSetWatchRows(context, rows)
+		}
+	}
 }
 
 // reRenderAgeFromWatchCaches recomputes every selected context's rows from
@@ -1141,6 +2937,12 @@ func (m *MainPage) reRenderAgeFromWatchCaches() {
 		if st, ok := m.serviceWatchers[context]; ok {
 			m.applyServiceWatchRows(context, st.cache.Rows(context))
 		}
+		if st, ok := m.stsWatchers[context]; ok {
+			m.applyStatefulSetWatchRows(context, st.cache.Rows(context))
+		}
+		if st, ok := m.dsWatchers[context]; ok {
+			m.applyDaemonSetWatchRows(context, st.cache.Rows(context))
+		}
 	}
 }
 
@@ -1162,7 +2964,7 @@ func (m *MainPage) fetchServiceEndpointsIfNeeded() tea.Cmd {
 			continue
 		}
 		m.appState.MarkServiceEndpointsRequested(context, namespace)
-		cmdSequence = append(cmdSequence, cmds.LoadServiceEndpointsCmd(m.Client, context, namespace))
+		cmdSequence = append(cmdSequence, cmds.LoadServiceEndpointsCmd(m.callCtx(context), m.Client, context, namespace))
 	}
 
 	if len(cmdSequence) == 0 {
@@ -1191,7 +2993,7 @@ func podLogTargets(rows []msgs.RowData) []podLogTarget {
 		ctxName, _ := row[msgs.PodKeyContext].(string)
 		for _, container := range strings.Split(containers, ",") {
 			targets = append(targets, podLogTarget{
-				key:       ctxName + "/" + namespace + "/" + name + "/" + container,
+				key:       podSourceKey(ctxName, namespace, name, container),
 				context:   ctxName,
 				namespace: namespace,
 				pod:       name,
@@ -1202,6 +3004,11 @@ func podLogTargets(rows []msgs.RowData) []podLogTarget {
 	return targets
 }
 
+// podSourceKey is a pod container's log source key.
+func podSourceKey(context, namespace, pod, container string) string {
+	return context + "/" + namespace + "/" + pod + "/" + container
+}
+
 // openPodLogs reconciles the merged log pane to whatever's currently checked
 // in the Pods tab — or, if nothing's checked, the single row under the
 // cursor (preserving the original single-pod behavior). Sources newly
@@ -1218,7 +3025,13 @@ func (m *MainPage) openPodLogs() tea.Cmd {
 	} else if row := m.podList.SelectedRow(); row != nil {
 		rows = append(rows, row)
 	}
+	return m.reconcilePodLogs(rows)
+}
 
+// reconcilePodLogs points the active log pane at every container of the
+// given Pods rows, the way openPodLogs describes. A container already
+// tailing in another pane moves to this one, its stream left running.
+func (m *MainPage) reconcilePodLogs(rows []msgs.RowData) tea.Cmd {
 	targets := podLogTargets(rows)
 	if len(targets) == 0 {
 		m.closeLogs()
@@ -1231,8 +3044,10 @@ func (m *MainPage) openPodLogs() tea.Cmd {
 		targetSet[t.key] = t
 	}
 
-	// Close sources no longer targeted.
-	for key := range m.logStreams {
+	// Close sources no longer targeted, along with any pane template's
+	// events.
+	m.closeEventSources()
+	for _, key := range m.logPanes.Active().Keys() {
 		if _, wanted := targetSet[key]; !wanted {
 			m.closeLogSource(key)
 		}
@@ -1241,12 +3056,16 @@ func (m *MainPage) openPodLogs() tea.Cmd {
 	// Open sources newly targeted; unchanged ones are left running.
 	var openCmds []tea.Cmd
 	for key, t := range targetSet {
+		moved := m.logPanes.AddSource(key, t.pod, t.namespace, t.context, t.cntnr)
 		if _, exists := m.logStreams[key]; exists {
+			if moved {
+				m.logPanes.AddNotice(key, "moved from another pane · still following")
+			}
 			continue
 		}
-		m.podLogs.AddSource(key, t.pod, t.namespace, t.context, t.cntnr)
-		m.logStreams[key] = &logStreamState{generation: 1}
-		openCmds = append(openCmds, cmds.OpenPodLogStreamCmd(m.Client, t.context, t.namespace, t.pod, t.cntnr, key, 1))
+		st := &logStreamState{target: t, generation: 1}
+		m.logStreams[key] = st
+		openCmds = append(openCmds, m.openLogSourceCmd(key, st))
 	}
 
 	m.closeDetail()
@@ -1261,16 +3080,93 @@ func (m *MainPage) openPodLogs() tea.Cmd {
 	return tea.Batch(openCmds...)
 }
 
-// closeLogSource stops one source's stream (if any) and removes it from
-// both the stream registry and the render model.
+// onLogStreamClosed handles one source's stream ending. A source that has
+// delivered timestamped lines is reopened from the last one's timestamp
+// with backoff, so a dropped connection neither loses nor repeats lines;
+// past maxLogReconnects attempts without a new line (or with nothing to
+// resume from) the source is marked ended. A source held after a restart
+// (see holdSource) stops where the old instance did. A previous instance's logs
+// aren't followed, so their end is just the end; nor is a completed pod's,
+// whose end gets a summary once its final status is in (summarizeCompleted).
+func (m *MainPage) onLogStreamClosed(msg msgs.LogStreamClosedMsg) tea.Cmd {
+	st, ok := m.logStreams[msg.SourceKey]
+	if !ok || msg.Generation != st.generation {
+		return nil
+	}
+	if st.stream != nil {
+		st.stream.Close()
+		st.stream, st.scanner = nil, nil
+	}
+
+	_, completed := m.podCompletion(st.target)
+	if st.held && !completed && !m.logTail.previous {
+		m.logPanes.AddNotice(msg.SourceKey, heldNotice)
+		return nil
+	}
+	if completed || m.logTail.previous || st.lastTime.IsZero() || st.failures >= maxLogReconnects {
+		st.endStream()
+		delete(m.logStreams, msg.SourceKey)
+		m.logPanes.SetStreamEnded(msg.SourceKey, msg.Err)
+		if !m.logTail.previous {
+			m.endedSources[msg.SourceKey] = st.target
+			m.summarizeCompleted(st.target.context)
+		}
+		return nil
+	}
+
+	st.failures++
+	st.generation++
+	st.resuming = true
+	st.skipAtLastTime = st.atLastTime
+	t := st.target
+	return cmds.ResumePodLogStreamCmd(m.streamCtx(st), m.Client, t.context, t.namespace, t.pod, msg.SourceKey, st.generation, m.logTail.options(t.cntnr), st.lastTime, watchBackoffDelay(st.failures))
+}
+
+// resumeSkips reports whether a line with timestamp ts, read from a
+// resumed stream, is one the source already showed before the drop: the
+// reopened stream starts at the top of lastTime's second. The first line
+// past lastTime ends the resume. Untimestamped lines can't be placed and
+// are let through.
+func (st *logStreamState) resumeSkips(ts time.Time) bool {
+	if !st.resuming || ts.IsZero() {
+		return false
+	}
+	if ts.Before(st.lastTime) {
+		return true
+	}
+	if ts.Equal(st.lastTime) && st.skipAtLastTime > 0 {
+		st.skipAtLastTime--
+		return true
+	}
+	st.resuming = false
+	return false
+}
+
+// record advances the source's resume point past a newly shown line.
+func (st *logStreamState) record(ts time.Time) {
+	st.failures = 0
+	switch {
+	case ts.IsZero():
+	case ts.Equal(st.lastTime):
+		st.atLastTime++
+	default:
+		st.lastTime = ts
+		st.atLastTime = 1
+	}
+}
+
+// closeLogSource stops one source's stream (if any), cancelling it if it's
+// still opening, and removes it from both the stream registry and the
+// render model.
 func (m *MainPage) closeLogSource(key string) {
 	if st, ok := m.logStreams[key]; ok {
 		if st.stream != nil {
 			st.stream.Close()
 		}
+		st.endStream()
 		delete(m.logStreams, key)
 	}
-	m.podLogs.RemoveSource(key)
+	m.logPanes.RemoveSource(key)
 }
 
 // closeDetail closes the Detail pane, if open. It holds no external
@@ -1281,30 +3177,45 @@ func (m *MainPage) closeDetail() {
 }
 
 // closeLogs closes the Log pane, if open, stopping every open source's
-// underlying stream.
+// underlying stream. The pane's since preset and previous toggle go with
+// it, as does its choice to hold restarts.
 func (m *MainPage) closeLogs() {
 	m.stopLogStream()
-	m.podLogs.Clear()
+	clear(m.eventSources)
+	clear(m.endedSources)
+	m.logPanes.Clear()
+	m.logTail.preset, m.logTail.previous, m.logTail.holdRestarts = 0, false, false
+	m.logTail.backlog, m.backlogPrompting = backlogUndecided, false
+	m.logPanes.SetMode("")
 	m.showLogs = false
 	m.logsFocused = false
 }
 
-// stopLogStream closes every currently open log source's stream. Safe to
-// call when nothing is streaming.
+// stopLogStream closes every currently open log source's stream,
+// cancelling those still opening. Safe to call when nothing is streaming.
 func (m *MainPage) stopLogStream() {
 	for key, st := range m.logStreams {
 		if st.stream != nil {
 			st.stream.Close()
 		}
+		st.endStream()
 		delete(m.logStreams, key)
 	}
 }
 
 func (m *MainPage) View() tea.View {
-	return tea.View{
-		Content:   m.renderView(),
+	content := m.anonymized(m.renderView())
+	m.trace.Mark(startup.StepFirstFrame)
+	v := tea.View{
+		Content:   content,
 		AltScreen: true,
+		// Focus reports drive the unfocused backoff (see m.unfocused).
+		ReportFocus: true,
 	}
+	if m.mouse {
+		v.MouseMode = tea.MouseModeCellMotion
+	}
+	return v
 }
 
 func (m *MainPage) renderView() string {
@@ -1313,24 +3224,30 @@ func (m *MainPage) renderView() string {
 	}
 
 	snapshot := m.appState.Snapshot()
+	m.syncAccents(snapshot)
+	m.syncContextScope(snapshot)
 
-	leftPaneWidth := leftPaneWidthFor(m.width)
+	leftPaneWidth := m.leftPaneWidth()
+	leftContent := m.contextList.View()
+	if m.stripShown {
+		leftContent = m.contextStrip(snapshot)
+	}
 	leftPane := ""
 	tabBlur := false
 	tabBottom := styles.WindowStyle
 
 	// Starting guess for the left pane's height; reconciled exactly against
 	// the right side's actual rendered height below (see rightLines/leftLines).
-	leftPaneHeight := m.height - 5
+	leftPaneHeight := m.height - 6
 
 	switch m.focus {
 	case focusLeftPane:
-		leftPane = views.RenderLeftPane(m.contextList.View(), leftPaneWidth, leftPaneHeight)
+		leftPane = views.RenderLeftPane(leftContent, leftPaneWidth, leftPaneHeight)
 		tabBlur = true
 		tabBottom = styles.WindowBlurStyle
 
 	case focusTabs:
-		leftPane = views.RenderLeftPaneBlur(m.contextList.View(), leftPaneWidth, leftPaneHeight)
+		leftPane = views.RenderLeftPaneBlur(leftContent, leftPaneWidth, leftPaneHeight)
 		tabBlur = false
 		tabBottom = styles.WindowStyle
 	}
@@ -1339,7 +3256,16 @@ func (m *MainPage) renderView() string {
 	tabs := strings.Builder{}
 	tabWidth := m.width - leftPaneWidth - 8
 
+	// Sized before the table renders, so the chip line takes a table row
+	// in this same frame.
+	chips := m.filterChips()
+	ctxTabs := m.renderContextTabs(snapshot)
+	m.syncChipRow((len(chips) > 0 || ctxTabs != "") && len(snapshot.SelectedContexts) > 0)
+
 	emptyMsg := "No contexts selected\n\nPress Tab to focus contexts\nSpace to select • Enter to load"
+	// tableAbove counts the lines put above the active table's own view,
+	// for mouse clicks (see screenLayout).
+	tableAbove := 0
 	switch m.tabs[m.activeTab] {
 	case "Deployments":
 		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
@@ -1359,9 +3285,68 @@ func (m *MainPage) renderView() string {
 		} else {
 			m.tabContent = m.svcList.View()
 		}
+	case "sts":
+		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else {
+			m.tabContent = m.stsList.View()
+		}
+	case "ds":
+		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else {
+			m.tabContent = m.dsList.View()
+		}
+	case "top":
+		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else {
+			m.tabContent = m.topList.View()
+		}
+	case "nodes":
+		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else {
+			m.tabContent = m.nodeList.View()
+		}
+	case "ing":
+		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else {
+			m.tabContent = m.ingList.View()
+		}
+	case "cr":
+		if !m.appStateLoaded || len(snapshot.SelectedContexts) == 0 {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else if _, ok := m.crList.Resource(); !ok {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(
+				"Press t to pick a resource type: any group/version/resource the selected contexts serve, e.g. cert-manager.io/v1/certificates")
+		} else {
+			m.tabContent = m.crList.View()
+		}
+	case "watch":
+		// The watchlist spans contexts whether they're selected or not.
+		if !m.appStateLoaded {
+			m.tabContent = styles.HelpBoxStyle().Align(lipgloss.Center).Render(emptyMsg)
+		} else {
+			m.tabContent = m.watchList.View()
+		}
 	default:
 		m.tabContent = styles.HelpBoxStyle().Render(emptyMsg)
 	}
+	tableShown := m.appStateLoaded && (len(snapshot.SelectedContexts) > 0 || m.tabs[m.activeTab] == "watch")
+	// The context sub-tabs lead the chip line.
+	line := ctxTabs
+	if chipLine := m.renderFilterChips(chips, max(1, m.tableW-lipgloss.Width(ctxTabs)-2)); chipLine != "" {
+		if line != "" {
+			line += "  "
+		}
+		line += chipLine
+	}
+	if line != "" && len(snapshot.SelectedContexts) > 0 {
+		m.tabContent = line + "\n" + m.tabContent
+		tableAbove++
+	}
 
 	// Loading indicator (inline — it's brief and doesn't break layout). Only
 	// shown on the active tab's first load (no rows yet) — a background
@@ -1375,16 +3360,33 @@ func (m *MainPage) renderView() string {
 		activeTabHasRows = len(snapshot.Pods) > 0
 	case "svc":
 		activeTabHasRows = len(snapshot.Services) > 0
+	case "sts":
+		activeTabHasRows = len(snapshot.StatefulSets) > 0
+	case "ds":
+		activeTabHasRows = len(snapshot.DaemonSets) > 0
+	case "top":
+		activeTabHasRows = m.topList.Len() > 0
+	case "nodes":
+		activeTabHasRows = m.nodeList.Len() > 0
+	case "ing":
+		activeTabHasRows = m.ingList.Len() > 0
+	case "cr":
+		activeTabHasRows = m.crList.Len() > 0
+	case "watch":
+		activeTabHasRows = m.watchList.Len() > 0
 	}
 	if !activeTabHasRows && hasLoading(snapshot.LoadingStates) {
-		m.tabContent = m.renderLoadingIndicator(snapshot.LoadingStates) + "\n\n" + m.tabContent
+		indicator := m.renderLoadingIndicator(snapshot.LoadingStates)
+		m.tabContent = indicator + "\n\n" + m.tabContent
+		tableAbove += lipgloss.Height(indicator) + 1
 	}
+	tableLines := lipgloss.Height(m.tabContent)
+	paneAbove := 0
 
 	// The bottom pane (Detail or Logs — mutually exclusive) is cross-cutting:
 	// it splits whichever top tab's content area is active in two, rather
 	// than being a peer tab of its own.
 	if m.showDetail || m.showLogs {
-		p := styles.CatppuccinMocha()
 		// RenderTabHeaders divides tabWidth by len(tabs) with integer
 		// division, so the box's real rendered width can be up to
 		// len(tabs)-1 characters narrower than tableW depending on the exact
@@ -1392,20 +3394,21 @@ func (m *MainPage) renderView() string {
 		// never gets word-wrapped by the outer container — a wrap here (not
 		// just a truncation) adds a physical line, which is what threw the
 		// left/right pane heights out of sync at some window widths.
-		const tabRoundingSafetyMargin = 2 // len(m.tabs)-1, the max rounding loss from tabWidth/len(tabs)
+		tabRoundingSafetyMargin := len(m.tabs) - 1 // the max rounding loss from tabWidth/len(tabs)
 		dividerW := m.tableW - tabRoundingSafetyMargin
 		if dividerW < 1 {
 			dividerW = 1
 		}
-		divider := lipgloss.NewStyle().Foreground(p.Overlay0).Render(strings.Repeat("─", dividerW))
+		divider := m.paneBorderStyle().Render(strings.Repeat("─", dividerW))
 
 		var header, body string
+		badge := m.watermarkBadges(m.bottomPaneContexts())
 		if m.showDetail {
-			header = m.deploymentDetail.Header(dividerW)
+			header = withWatermark(m.deploymentDetail.Header, dividerW, badge)
 			body = m.deploymentDetail.View()
 		} else {
-			header = m.podLogs.Header(dividerW)
-			body = m.podLogs.View()
+			header = withWatermark(m.logPanes.Header, dividerW, badge)
+			body = m.logPanes.View()
 		}
 
 		joined := lipgloss.JoinVertical(lipgloss.Left,
@@ -1414,6 +3417,7 @@ func (m *MainPage) renderView() string {
 			header,
 			body,
 		)
+		paneAbove = tableLines + 1 + lipgloss.Height(header)
 		// Right-pad every line up to a uniform minimum width so the outer
 		// container's Align(Center) shifts the whole block by one constant
 		// amount instead of centering each line individually — the latter is
@@ -1441,7 +3445,7 @@ func (m *MainPage) renderView() string {
 	// to truncate-rather-than-wrap for the detail/log split case below; apply
 	// it here too so the plain table case gets the same guarantee.
 	m.tabContent = padLinesToMinWidth(m.tabContent, boxWidth-tabBottom.GetHorizontalFrameSize())
-	tabs.WriteString(tabBottom.Width(boxWidth).Height(m.height - 8).Align(lipgloss.Center).Render(m.tabContent))
+	tabs.WriteString(tabBottom.Width(boxWidth).Height(m.height - 9).Align(lipgloss.Center).Render(m.tabContent))
 
 	// lipgloss's Height()+Border()+Padding() frame math doesn't add up to a
 	// fixed constant across every width/content combination — real content
@@ -1457,41 +3461,100 @@ func (m *MainPage) renderView() string {
 		leftPaneHeight += gap
 		switch m.focus {
 		case focusLeftPane:
-			leftPane = views.RenderLeftPane(m.contextList.View(), leftPaneWidth, leftPaneHeight)
+			leftPane = views.RenderLeftPane(leftContent, leftPaneWidth, leftPaneHeight)
 		case focusTabs:
-			leftPane = views.RenderLeftPaneBlur(m.contextList.View(), leftPaneWidth, leftPaneHeight)
+			leftPane = views.RenderLeftPaneBlur(leftContent, leftPaneWidth, leftPaneHeight)
 		}
 	} else if gap < 0 {
 		tabs.Reset()
 		tabs.WriteString(tabHeaders)
 		tabs.WriteString("\n")
-		tabs.WriteString(tabBottom.Width(lipgloss.Width(tabHeaders)).Height(m.height - 8 - gap).Align(lipgloss.Center).Render(m.tabContent))
+		tabs.WriteString(tabBottom.Width(lipgloss.Width(tabHeaders)).Height(m.height - 9 - gap).Align(lipgloss.Center).Render(m.tabContent))
+	}
+
+	crumbs := m.renderBreadcrumb(snapshot, m.width)
+	contentTop := lipgloss.Height(tabHeaders) + tabBottom.GetPaddingTop()
+	contentLeft := lipgloss.Width(leftPane) + tabBottom.GetBorderLeftSize() +
+		max(0, (boxWidth-tabBottom.GetHorizontalFrameSize()-lipgloss.Width(m.tabContent))/2)
+	m.screen = screenLayout{
+		top:         lipgloss.Height(crumbs),
+		leftWidth:   lipgloss.Width(leftPane),
+		contextsTop: styles.LeftPane.GetBorderTopSize() + styles.LeftPane.GetPaddingTop(),
+		tabsHeight:  lipgloss.Height(tabHeaders),
+		tabsWidth:   tabWidth,
+		tableTop:    contentTop + tableAbove,
+		tableEnd:    contentTop + tableLines,
+		tableShown:  tableShown,
+		paneTop:     contentTop + paneAbove,
+		paneLeft:    contentLeft,
 	}
 
 	fullView := lipgloss.JoinVertical(lipgloss.Left,
+		crumbs,
 		lipgloss.JoinHorizontal(lipgloss.Top, leftPane, tabs.String()),
 		m.renderStatusBar(snapshot),
 	)
+	// The tables' watermark sits in the top-right corner of their box, on
+	// the blank padding row under the tab headers.
+	if badge := m.watermarkBadges(tableContexts(snapshot.SelectedContexts)); badge != "" {
+		x := lipgloss.Width(leftPane) + boxWidth - tabBottom.GetBorderRightSize() - 1 - lipgloss.Width(badge)
+		if x > lipgloss.Width(leftPane) {
+			fullView = stampWatermark(fullView, x, m.screen.top+lipgloss.Height(tabHeaders), badge)
+		}
+	}
 
-	// Overlays rendered on top of the full view (help > error)
+	// Overlays rendered on top of the full view (idle > help > error)
+	if m.idle.parked {
+		return m.renderIdleOverlay()
+	}
 	if m.showHelp {
 		return m.renderHelpOverlay()
 	}
-	if m.errorMessage != "" {
-		return m.renderErrorOverlay(m.errorMessage)
+	if m.showPrompt {
+		return m.prompt.View()
+	}
+	if m.showConfirm {
+		return m.confirm.View()
+	}
+	if m.showPanel {
+		return m.infoPanel.View()
 	}
-	if len(snapshot.Errors) > 0 {
-		return m.renderErrorSummaryOverlay(snapshot.Errors)
+	if m.showFiles {
+		return m.fileBrowser.View()
+	}
+	if m.showForwards {
+		return m.forwardPanel.View()
+	}
+	if m.showErrors {
+		return m.errorPanel.View()
+	}
+	if m.showAlerts {
+		return m.alertPanel.View()
+	}
+	if m.showAudit {
+		return m.auditPanel.View()
+	}
+	if m.showNSPicker {
+		return m.nsPicker.View()
+	}
+	if m.showProfiles {
+		return m.profilePicker.View()
+	}
+	if m.showColumns {
+		return m.colChooser.View()
 	}
 
 	return fullView
 }
 
 func (m *MainPage) renderStatusBar(snapshot state.Snapshot) string {
-	p := styles.CatppuccinMocha()
-	leftStyle := lipgloss.NewStyle().Foreground(p.Rosewater).Padding(0, 1)
-	midStyle := lipgloss.NewStyle().Foreground(p.Sapphire).Bold(true)
-	rightStyle := lipgloss.NewStyle().Foreground(p.Green).Padding(0, 1)
+	if m.showCommand {
+		return styles.StatusBar.Width(m.width - 2).Render(m.commandLine.View())
+	}
+
+	leftStyle := m.theme.StatusLeft
+	midStyle := m.theme.StatusMid
+	rightStyle := m.theme.StatusRight
 
 	selectedCtx := len(snapshot.SelectedContexts)
 	errCount := len(snapshot.Errors)
@@ -1510,15 +3573,31 @@ func (m *MainPage) renderStatusBar(snapshot state.Snapshot) string {
 		activeCount = len(snapshot.Pods)
 	case "svc":
 		activeCount = len(snapshot.Services)
-	}
-
-	focusStr := "Left Pane"
-	if m.focus == focusTabs {
-		focusStr = "Tabs"
+	case "sts":
+		activeCount = len(snapshot.StatefulSets)
+	case "ds":
+		activeCount = len(snapshot.DaemonSets)
+	case "top":
+		activeCount = m.topList.Len()
+	case "nodes":
+		activeCount = m.nodeList.Len()
+	case "ing":
+		activeCount = m.ingList.Len()
+	case "cr":
+		activeCount = m.crList.Len()
+	case "watch":
+		activeCount = m.watchList.Len()
 	}
 
 	left := leftStyle.Render(fmt.Sprintf("Contexts: %d", selectedCtx))
-	mid := midStyle.Render(fmt.Sprintf("Tab: %s | Focus: %s", activeTabName, focusStr))
+	if selectedCtx > 0 {
+		left = leftStyle.Render(fmt.Sprintf("Contexts: %d (%s)", selectedCtx, namespaceSummary(snapshot)))
+		// Each selected cluster's heartbeat: dot and round trip.
+		if health := m.contextList.HealthSummary(slices.Sorted(maps.Keys(snapshot.SelectedContexts))); health != "" {
+			left += health + " "
+		}
+	}
+	mid := midStyle.Render(m.build.Version)
 
 	// Dynamic status bits (loading / count / errors) — count reflects
 	// whichever tab currently has focus, not always Deployments.
@@ -1531,6 +3610,21 @@ func (m *MainPage) renderStatusBar(snapshot state.Snapshot) string {
 	if errCount > 0 {
 		statusBits = append(statusBits, fmt.Sprintf("⚠ %d error(s)", errCount))
 	}
+	if latest := m.errorStatus(); latest != "" {
+		statusBits = append(statusBits, latest)
+	}
+	if alert := m.alertStatus(); alert != "" {
+		statusBits = append(statusBits, alert)
+	}
+	if n := state.RestartSpikes(snapshot.Pods); n > 0 {
+		statusBits = append(statusBits, fmt.Sprintf("⟳ %d pod(s) restarting", n))
+	}
+	if n := m.activeForwardCount(); n > 0 {
+		statusBits = append(statusBits, fmt.Sprintf("⇄ %d forward(s) · P: list", n))
+	}
+	if n := len(m.Client.ContextConflicts()); n > 0 && m.focus == focusLeftPane {
+		statusBits = append(statusBits, fmt.Sprintf("⚠ %d kubeconfig conflict(s) · K: show", n))
+	}
 	if activeTabName == "Pods" {
 		if checkedCount := len(m.podList.CheckedKeys()); checkedCount > 0 {
 			statusBits = append(statusBits, fmt.Sprintf("☑ %d checked · l: open merged · Ctrl+X: clear", checkedCount))
@@ -1554,20 +3648,46 @@ func (m *MainPage) renderStatusBar(snapshot state.Snapshot) string {
 		}
 	}
 	if m.showLogs {
-		if percent, ok := m.podLogs.ScrollStatus(); ok {
+		if percent, ok := m.logPanes.ScrollStatus(); ok {
 			statusBits = append(statusBits, fmt.Sprintf("◂ %d%% ▸", percent))
 		}
 	}
+	if m.copyStatus != "" {
+		statusBits = append(statusBits, m.copyStatus)
+	}
+	if m.actionStatus != "" {
+		statusBits = append(statusBits, m.actionStatus)
+	}
+	if refresh := m.refreshStatus(); refresh != "" {
+		statusBits = append(statusBits, refresh)
+	}
+	if budget := m.budgetStatus(); budget != "" {
+		statusBits = append(statusBits, budget)
+	}
+	if reauth := m.Client.Reauthenticating(); len(reauth) > 0 {
+		statusBits = append(statusBits, fmt.Sprintf("🔑 re-authenticating %s…", strings.Join(reauth, ", ")))
+	}
+	if m.anonymizer != nil {
+		statusBits = append(statusBits, "🕶 demo")
+	}
+	if m.Client.ReadOnly() {
+		statusBits = append(statusBits, "🔒 read-only")
+	}
 	if len(statusBits) == 0 {
 		statusBits = append(statusBits, "Ready")
 	}
-	status := rightStyle.Render(strings.Join(statusBits, "  |  "))
-
-	// Hints are a fixed, separate element anchored to the far right
-	hints := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true).Render("Tab:focus  [ ]:tabs  ?:help  q:quit")
-
 	barWidth := m.width - 2
 	leftMid := lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", mid)
+
+	// Status bits carry text from the clusters (errors, pod names), which
+	// must neither break the bar onto a second line nor push it past the
+	// terminal's edge.
+	status := rightStyle.Render(textwidth.Fit(strings.Join(statusBits, "  |  "), barWidth-lipgloss.Width(leftMid)-4))
+
+	// Hints are anchored to the far right and get whatever width the rest of
+	// the bar leaves over — dropped from the end, never wrapped.
+	hintsWidth := barWidth - lipgloss.Width(leftMid) - lipgloss.Width(status) - 4
+	hints := m.renderHints(hintsWidth)
 	rightSection := lipgloss.JoinHorizontal(lipgloss.Top, status, "   ", hints)
 	spacerWidth := barWidth - lipgloss.Width(leftMid) - lipgloss.Width(rightSection)
 	if spacerWidth < 1 {
@@ -1575,59 +3695,186 @@ func (m *MainPage) renderStatusBar(snapshot state.Snapshot) string {
 	}
 	line := leftMid + strings.Repeat(" ", spacerWidth) + rightSection
 
+	if m.alertFlash {
+		// An alert just fired: the whole bar flashes red for a moment.
+		return styles.StatusBar.Foreground(m.theme.Palette.Base).Background(m.theme.Palette.Red).Bold(true).Width(barWidth).Render(ansi.Strip(line))
+	}
 	return styles.StatusBar.Width(barWidth).Render(line)
 }
 
+// hintScope maps the current focus (and any in-progress filter typing) to
+// the keymap scope whose bindings the status bar should advertise.
+func (m *MainPage) hintScope() keys.Scope {
+	switch {
+	case m.focus == focusLeftPane:
+		return keys.ScopeContexts
+	case m.detailFocused:
+		return keys.ScopeDetail
+	case m.logsFocused:
+		return keys.ScopeLogs
+	}
+	if t := m.activeResourceTable(); t != nil {
+		if _, _, typing, ok := t.FilterStatus(); ok && typing {
+			return keys.ScopeFilter
+		}
+	}
+	switch m.tabs[m.activeTab] {
+	case "Deployments":
+		return keys.ScopeDeployments
+	case "Pods":
+		return keys.ScopePods
+	case "svc":
+		return keys.ScopeServices
+	case "cr":
+		return keys.ScopeCustom
+	case "sts":
+		return keys.ScopeStatefulSets
+	case "top":
+		return keys.ScopeTop
+	case "nodes":
+		return keys.ScopeNodes
+	case "watch":
+		return keys.ScopeWatch
+	}
+	return keys.ScopeTable
+}
+
+// renderHints is the focused scope's bindings as help's short view, "key
+// desc • key desc", the trailing ones dropped for an ellipsis past
+// maxWidth. Disabled bindings (an action RBAC forbids, an unconfigured
+// template) are left out. Returns "" if not even the first hint fits.
+func (m *MainPage) renderHints(maxWidth int) string {
+	if maxWidth <= 0 {
+		return ""
+	}
+	m.hints.SetWidth(maxWidth)
+	// help only drops a hint when there's room for the ellipsis instead,
+	// so a nearly-full bar can still overflow; trim until it doesn't.
+	bindings := m.actionHints().Hints(m.hintScope())
+	for len(bindings) > 0 {
+		if line := m.hints.ShortHelpView(bindings); lipgloss.Width(line) <= maxWidth {
+			return line
+		}
+		bindings = bindings[:len(bindings)-1]
+	}
+	return ""
+}
+
+// helpKeyWidth is the help overlay's key column.
+const helpKeyWidth = 22
+
+// renderHelpOverlay lists every binding the keymap registry holds (see
+// keys.KeyMap.Sections), so it can't drift from the keys themselves,
+// scrolled to helpOffset when it's taller than the screen.
 func (m *MainPage) renderHelpOverlay() string {
 	p := styles.CatppuccinMocha()
 
 	titleStyle := lipgloss.NewStyle().Foreground(p.Mauve).Bold(true)
-	keyStyle := lipgloss.NewStyle().Foreground(p.Blue).Bold(true).Width(22)
-	descStyle := lipgloss.NewStyle().Foreground(p.Text)
+	sectionStyle := lipgloss.NewStyle().Foreground(p.Lavender).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(p.Blue).Bold(true).Width(helpKeyWidth)
 	sepStyle := lipgloss.NewStyle().Foreground(p.Overlay0)
+	hintStyle := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true)
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(p.Mauve).
 		Background(p.Mantle).
 		Padding(1, 3)
 
-	type binding struct{ key, desc string }
-	bindings := []binding{
-		{"Tab / Shift+Tab", "Switch pane focus"},
-		{"[ / ]", "Navigate tabs"},
-		{"← / →", "Navigate tabs (alias)"},
-		{"↑ / ↓   j / k", "Move up / down"},
-		{"g / Home   G / End", "Jump to first / last row (Deployments, Pods, svc tabs)"},
-		{"/", "Filter the active table by name across all rows, not just the visible ones; Enter to keep it, Esc to clear"},
-		{"Space", "Toggle context selection / check a Pods row for log tailing"},
-		{"Enter", "Confirm selection & load / open + focus detail pane (refocuses instantly if already loaded)"},
-		{"l (Pods tab)", "Open/reconcile the merged log pane for checked rows (or the row under the cursor)"},
-		{"Ctrl+X (Pods tab)", "Clear all checked rows"},
-		{"r", "Refresh the active tab's resource list across all selected contexts"},
-		{"c (log pane focused)", "Isolate one source's view, or return to the full merge"},
-		{"Ctrl+R", "Jump back into an open detail pane without changing its resource"},
-		{"R", "Toggle auto-refresh on/off"},
-		{"↑/↓ j/k PgUp/PgDn", "Scroll detail/log pane (while it has focus)"},
-		{"Home / End", "Jump to top / bottom of detail/log pane"},
-		{"Esc", "Unfocus detail/log pane, then close it / overlay / dismiss error"},
-		{"?", "Toggle this help"},
-		{"q / Ctrl+C", "Quit"},
-	}
-
-	var lines []string
-	lines = append(lines, titleStyle.Render("Keybindings"))
-	lines = append(lines, sepStyle.Render(strings.Repeat("─", 38)))
-	for _, b := range bindings {
-		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
-			keyStyle.Render(b.key),
-			descStyle.Render(b.desc),
-		))
+	innerW := max(40, m.width-12)
+	descStyle := lipgloss.NewStyle().Foreground(p.Text).Width(innerW - helpKeyWidth)
+	var body []string
+	lockedStyle := lipgloss.NewStyle().Foreground(p.Overlay0).Width(helpKeyWidth)
+	for i, section := range m.keys.Sections(m.lockedKeys...) {
+		if i > 0 {
+			body = append(body, "")
+		}
+		body = append(body, sectionStyle.Render(section.Title))
+		for _, e := range section.Entries {
+			about := e.About
+			if about == "" {
+				about = e.Binding.Help().Desc
+			}
+			keys, desc := keyStyle, descStyle
+			if !e.Binding.Enabled() {
+				// Only the keys read-only mode locked are listed disabled.
+				keys, desc = lockedStyle, descStyle.Foreground(p.Overlay0)
+				about += " (off: read-only)"
+			}
+			row := lipgloss.JoinHorizontal(lipgloss.Top, keys.Render(e.Binding.Help().Key), desc.Render(about))
+			body = append(body, strings.Split(row, "\n")...)
+		}
+	}
+
+	// Border, padding, title, rule and footer take 7 of the rows.
+	visible := max(1, m.height-2-7)
+	m.helpOffset = max(0, min(m.helpOffset, len(body)-visible))
+	end := min(len(body), m.helpOffset+visible)
+	footer := "? / esc close"
+	if len(body) > visible {
+		footer = fmt.Sprintf("↑/↓ PgUp/PgDn scroll (%d–%d of %d) • %s", m.helpOffset+1, end, len(body), footer)
 	}
 
+	lines := []string{titleStyle.Render("Keybindings"), sepStyle.Render(strings.Repeat("─", innerW))}
+	lines = append(lines, body[m.helpOffset:end]...)
+	lines = append(lines, hintStyle.Render(footer))
 	box := boxStyle.Render(strings.Join(lines, "\n"))
 	return lipgloss.Place(m.width, m.height-2, lipgloss.Center, lipgloss.Center, box)
 }
 
+// quit saves the session and stops every stream, copy and forward before
+// quitting.
+func (m *MainPage) quit() tea.Cmd {
+	m.captureSession()
+	m.stopLogStream()
+	m.cancelPodCopy()
+	m.forwards.StopAll()
+	m.cancel()
+	return tea.Quit
+}
+
+// typingFilter reports whether the focused resource table is capturing
+// filter text, which every key press then goes to as typed.
+func (m *MainPage) typingFilter() bool {
+	if m.focus != focusTabs || m.detailFocused || m.logsFocused {
+		return false
+	}
+	t := m.activeResourceTable()
+	if t == nil {
+		return false
+	}
+	_, _, typing, ok := t.FilterStatus()
+	return ok && typing
+}
+
+// scrollHelp moves the help overlay by a key press; renderHelpOverlay
+// clamps the offset to the content.
+func (m *MainPage) scrollHelp(msg tea.KeyPressMsg) {
+	page := max(1, m.height-2-7)
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.helpOffset--
+	case key.Matches(msg, m.keys.Down):
+		m.helpOffset++
+	case key.Matches(msg, m.keys.Scroll):
+		// up, down, pgup, pgdown as in Scroll's defaults
+		switch keys.Index(msg, m.keys.Scroll) {
+		case 0:
+			m.helpOffset--
+		case 1:
+			m.helpOffset++
+		case 2:
+			m.helpOffset -= page
+		case 3:
+			m.helpOffset += page
+		}
+	case key.Matches(msg, m.keys.Top):
+		m.helpOffset = 0
+	case key.Matches(msg, m.keys.Bottom):
+		m.helpOffset = math.MaxInt / 2
+	}
+	m.helpOffset = max(0, m.helpOffset)
+}
+
 // renderTooSmallOverlay replaces the whole TUI with a plain message when the
 // terminal is below views.MinContentWidth x views.MinHeight — below that, the
 // real layout doesn't have room to render without breaking, so we don't try.
@@ -1657,65 +3904,7 @@ func (m *MainPage) renderTooSmallOverlay() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
 }
 
-func (m *MainPage) renderErrorOverlay(msg string) string {
-	p := styles.CatppuccinMocha()
-	maxW := m.width - 16
-	if maxW < 40 {
-		maxW = 40
-	}
-	box := lipgloss.NewStyle().
-		Foreground(p.Text).
-		Background(p.Surface0).
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(p.Red).
-		Padding(1, 3).
-		Width(maxW).
-		Align(lipgloss.Center)
-
-	title := lipgloss.NewStyle().Foreground(p.Red).Bold(true).Render("⚠  Error")
-	sep := lipgloss.NewStyle().Foreground(p.Overlay0).Render(strings.Repeat("─", maxW-2))
-	body := lipgloss.NewStyle().Foreground(p.Text).Render(msg)
-	hint := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true).Render("Esc to dismiss")
-
-	content := strings.Join([]string{title, sep, body, "", hint}, "\n")
-	return lipgloss.Place(m.width, m.height-2, lipgloss.Center, lipgloss.Center, box.Render(content))
-}
-
-func (m *MainPage) renderErrorSummaryOverlay(errors map[string]string) string {
-	p := styles.CatppuccinMocha()
-	maxW := m.width - 16
-	if maxW < 40 {
-		maxW = 40
-	}
-	box := lipgloss.NewStyle().
-		Foreground(p.Text).
-		Background(p.Surface0).
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(p.Red).
-		Padding(1, 3).
-		Width(maxW).
-		Align(lipgloss.Center)
-
-	title := lipgloss.NewStyle().Foreground(p.Red).Bold(true).Render("⚠  Errors encountered")
-	sep := lipgloss.NewStyle().Foreground(p.Overlay0).Render(strings.Repeat("─", maxW-2))
-	var bodyLines []string
-	for ctx, err := range errors {
-		bodyLines = append(bodyLines, fmt.Sprintf("• %s: %s", ctx, err))
-	}
-	body := lipgloss.NewStyle().Foreground(p.Text).Render(strings.Join(bodyLines, "\n"))
-	hint := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true).Render("Esc to dismiss")
-
-	content := strings.Join([]string{title, sep, body, "", hint}, "\n")
-	return lipgloss.Place(m.width, m.height-2, lipgloss.Center, lipgloss.Center, box.Render(content))
-}
-
 func (m *MainPage) renderLoadingIndicator(loading map[string]bool) string {
-	p := styles.CatppuccinMocha()
-	loadingStyle := lipgloss.NewStyle().
-		Foreground(p.Blue).
-		Background(p.Surface0).
-		Padding(0, 1)
-
 	var loadingContexts []string
 	for ctx, isLoading := range loading {
 		if isLoading {
@@ -1727,7 +3916,7 @@ func (m *MainPage) renderLoadingIndicator(loading map[string]bool) string {
 		return ""
 	}
 
-	return loadingStyle.Render(fmt.Sprintf("⏳ Loading: %s...", strings.Join(loadingContexts, ", ")))
+	return m.theme.Loading.Render(fmt.Sprintf("⏳ Loading: %s...", strings.Join(loadingContexts, ", ")))
 }
 
 // padLinesToMinWidth right-pads every line of content with spaces so it is at
@@ -1765,7 +3954,7 @@ func hasLoading(loading map[string]bool) bool {
 
 func getContextPaneDimensions(w, h int) (cW, cH int) {
 	cW = leftPaneWidthFor(w)
-	cH = h - 10
+	cH = h - 11
 	return cW, cH
 }
 
@@ -0,0 +1,68 @@
+package pages
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/metrics"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// metricsPanelKey is the info panel's panelKey while it peeks at a pod's
+// metrics.
+func metricsPanelKey(ctxName, namespace, name string) string {
+	return "metrics/" + ctxName + "/" + namespace + "/" + name
+}
+
+// openPodMetrics (m) opens the info panel on a scrape of the metrics
+// endpoint the Pods row under the cursor is annotated with.
+func (m *MainPage) openPodMetrics() tea.Cmd {
+	row := m.podList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.PodKeyName].(string)
+	namespace, _ := row[msgs.PodKeyNamespace].(string)
+	ctxName, _ := row[msgs.PodKeyContext].(string)
+	m.podMetrics = msgs.PodMetricsMsg{}
+	return m.loadPodMetrics(ctxName, namespace, name)
+}
+
+// rescrapePodMetrics (r, in the metrics panel) scrapes the panel's pod
+// again; the panel then shows rates since the scrape before.
+func (m *MainPage) rescrapePodMetrics() tea.Cmd {
+	r := m.podMetrics
+	if m.panelKey != metricsPanelKey(r.Context, r.Namespace, r.Pod) {
+		return nil
+	}
+	return m.loadPodMetrics(r.Context, r.Namespace, r.Pod)
+}
+
+func (m *MainPage) loadPodMetrics(ctxName, namespace, name string) tea.Cmd {
+	m.panelKey = metricsPanelKey(ctxName, namespace, name)
+	m.showPanel = true
+	m.infoPanel.StartLoading(fmt.Sprintf("Metrics: %s/%s (%s)", namespace, name, ctxName))
+	return cmds.LoadPodMetricsCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+}
+
+// onPodMetrics fills the metrics panel, unless it was closed (or moved on
+// to another pod) while the scrape was in flight.
+func (m *MainPage) onPodMetrics(msg msgs.PodMetricsMsg) {
+	if !m.showPanel || m.panelKey != metricsPanelKey(msg.Context, msg.Namespace, msg.Pod) {
+		return
+	}
+	if msg.Err != nil {
+		m.infoPanel.SetError(msg.Err.Error())
+		return
+	}
+	var prev metrics.Peek
+	if m.podMetrics.Context == msg.Context && m.podMetrics.Namespace == msg.Namespace && m.podMetrics.Pod == msg.Pod {
+		prev = m.podMetrics.Peek
+	}
+	m.podMetrics = msg
+	m.infoPanel.SetContent(m.infoPanel.Title(), models.PodMetricsLines(msg.Target, msg.Restarts, msg.Peek, prev))
+	m.infoPanel.SetActions("r scrape again")
+}
@@ -0,0 +1,164 @@
+package pages
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/views"
+)
+
+// wheelRows is how many rows one notch of the mouse wheel moves a table's
+// cursor, as many as it scrolls the panes' viewports.
+const wheelRows = 3
+
+// screenLayout is where renderView last drew what a click can land on, in
+// screen cells: the contexts pane on the left, the tab headers along the
+// top of the rest, the active table under them and, when one is open, the
+// detail or log pane below that. Rows count from under the breadcrumb.
+type screenLayout struct {
+	top         int // the breadcrumb's lines, above everything else
+	leftWidth   int // the contexts pane, borders included
+	contextsTop int // the row ContextsInfo.View starts on
+	tabsHeight  int
+	tabsWidth   int  // the width RenderTabHeaders was given
+	tableTop    int  // the row the active table's view starts on
+	tableEnd    int  // one past the table area's last row
+	tableShown  bool // false while the tab shows a placeholder instead
+	paneTop     int  // the row the detail/log pane's body starts on
+	paneLeft    int  // the column it starts at
+}
+
+// SetMouse turns on mouse support (mouse in the config): clicks focus the
+// pane, table row, context or tab under the pointer, and the wheel scrolls
+// what's under it.
+func (m *MainPage) SetMouse(on bool) {
+	m.mouse = on
+}
+
+// handleMouse routes a click or wheel notch by where the last frame drew
+// things; other mouse events are ignored. While an overlay is open only
+// the help and info panels scroll, and nothing takes clicks.
+func (m *MainPage) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	click, isClick := msg.(tea.MouseClickMsg)
+	wheel, isWheel := msg.(tea.MouseWheelMsg)
+	if !isClick && !isWheel {
+		return nil
+	}
+	if m.idle.parked {
+		return m.resumeFromIdle()
+	}
+	m.noteInput()
+
+	switch {
+	case m.showHelp:
+		if isWheel {
+			m.helpOffset = max(0, m.helpOffset+wheelStep(wheel))
+		}
+		return nil
+	case m.showPanel:
+		if isWheel {
+			return m.infoPanel.Update(wheel)
+		}
+		return nil
+	case m.showPrompt || m.showConfirm || m.showCommand || m.showFiles || m.showForwards ||
+		m.showErrors || m.showAudit || m.showNSPicker || m.showProfiles || m.showColumns:
+		return nil
+	}
+
+	if isClick {
+		if click.Button != tea.MouseLeft {
+			return nil
+		}
+		return m.clickAt(click.X, click.Y-m.screen.top)
+	}
+	wheel.Y -= m.screen.top
+	return m.wheelAt(wheel)
+}
+
+// clickAt focuses what's drawn at x, y: a context (clicking the one under
+// the cursor toggles it) or the collapsed contexts strip, a tab header, a table row, or the detail/log
+// pane (and, in a grid of log panes, the one clicked).
+func (m *MainPage) clickAt(x, y int) tea.Cmd {
+	s := m.screen
+	if y < 0 { // the breadcrumb
+		return nil
+	}
+	if x < s.leftWidth {
+		if !m.stripShown { // a click on the collapsed strip only opens it
+			m.contextList.ClickItem(y - s.contextsTop)
+		}
+		m.focus = focusLeftPane
+		m.updateFocusStates()
+		return nil
+	}
+	if y < s.tabsHeight {
+		if i, ok := views.TabAt(x-s.leftWidth, m.tabs, s.tabsWidth); ok {
+			return m.switchTab(i)
+		}
+		return nil
+	}
+
+	m.focus = focusTabs
+	switch {
+	case m.showDetail || m.showLogs:
+		if y < s.paneTop-1 { // the pane's header line is the pane's too
+			break
+		}
+		if m.showDetail {
+			m.detailFocused = true
+		} else {
+			m.logsFocused = true
+			m.logPanes.ActivateAt(x-s.paneLeft, y-s.paneTop)
+		}
+		m.updateFocusStates()
+		return nil
+	}
+	m.detailFocused, m.logsFocused = false, false
+	if t := m.activeResourceTable(); t != nil && s.tableShown && y >= s.tableTop && y < s.tableEnd {
+		t.ClickRow(y - s.tableTop)
+	}
+	m.updateFocusStates()
+	return nil
+}
+
+// wheelAt scrolls what's under the pointer: the contexts, the active
+// table's cursor, or the detail/log pane, without moving the focus.
+func (m *MainPage) wheelAt(msg tea.MouseWheelMsg) tea.Cmd {
+	s := m.screen
+	delta := 0
+	switch msg.Button {
+	case tea.MouseWheelUp:
+		delta = -1
+	case tea.MouseWheelDown:
+		delta = 1
+	default:
+		return nil
+	}
+
+	switch {
+	case msg.X < s.leftWidth:
+		if !m.stripShown {
+			m.contextList.Scroll(delta)
+		}
+	case (m.showDetail || m.showLogs) && msg.Y >= s.paneTop:
+		if m.showDetail {
+			return m.deploymentDetail.Update(msg)
+		}
+		if m.logPanes.ActivateAt(msg.X-s.paneLeft, msg.Y-s.paneTop) {
+			m.updateFocusStates()
+		}
+		return m.logPanes.Update(msg)
+	case s.tableShown && msg.Y >= s.tableTop && msg.Y < s.tableEnd:
+		if t := m.activeResourceTable(); t != nil {
+			t.ScrollRows(delta * wheelRows)
+		}
+	}
+	return nil
+}
+
+// wheelStep is how far a wheel notch scrolls the help overlay.
+func wheelStep(msg tea.MouseWheelMsg) int {
+	if msg.Button == tea.MouseWheelUp {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,222 @@
+package pages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// openNamespacePicker opens the namespace picker for the context under the
+// left pane's cursor and starts listing its namespaces.
+func (m *MainPage) openNamespacePicker() tea.Cmd {
+	context, current, ok := m.contextList.CursorContext()
+	if !ok {
+		return nil
+	}
+	m.nsPicker.Open(context, current)
+	m.showNSPicker = true
+	return cmds.LoadNamespacesCmd(m.callCtx(context), m.Client, context)
+}
+
+// handleNamespacePickerKey routes keys while the namespace picker is open.
+func (m *MainPage) handleNamespacePickerKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.showNSPicker = false
+		return nil
+	case key.Matches(msg, m.keys.Accept):
+		if !m.nsPicker.Ready() {
+			return nil
+		}
+		m.showNSPicker = false
+		return m.applyNamespaces(m.nsPicker.Context(), m.nsPicker.Picked())
+	}
+	return m.nsPicker.Update(msg)
+}
+
+// applyNamespaces records a context's picked namespaces. A context not yet
+// loaded just remembers them for the next Enter; a loaded one has its
+// watches moved over right away, each on a fresh cache (and with any rows
+// deferred while unfocused dropped) so nothing from the old namespaces
+// lingers.
+func (m *MainPage) applyNamespaces(context string, namespaces []string) tea.Cmd {
+	selection, ok := m.contextList.SetNamespaces(context, namespaces)
+	if !ok {
+		return nil
+	}
+	if _, ok := m.appState.Snapshot().SelectedContexts[context]; !ok {
+		return nil
+	}
+	watchNS := m.appState.SetNamespaces(context, selectionNamespaces(selection))
+
+	m.appState.SetDeployments(context, nil)
+	m.appState.SetPods(context, nil)
+	m.appState.SetServices(context, nil)
+	m.appState.SetStatefulSets(context, nil)
+	m.appState.SetDaemonSets(context, nil)
+	if st, ok := m.podWatchers[context]; ok {
+		st.cache = cmds.NewPodWatchCache()
+		st.takeDeferred()
+	}
+	if st, ok := m.deploymentWatchers[context]; ok {
+		st.cache = cmds.NewDeploymentWatchCache()
+		st.takeDeferred()
+	}
+	if st, ok := m.serviceWatchers[context]; ok {
+		st.cache = cmds.NewServiceWatchCache()
+		st.takeDeferred()
+	}
+	if st, ok := m.stsWatchers[context]; ok {
+		st.cache = cmds.NewStatefulSetWatchCache()
+		st.takeDeferred()
+	}
+	if st, ok := m.dsWatchers[context]; ok {
+		st.cache = cmds.NewDaemonSetWatchCache()
+		st.takeDeferred()
+	}
+	m.topList.RemoveContext(context)
+	m.ingList.RemoveContext(context)
+	m.crList.RemoveContext(context)
+	m.alerts.ForgetContext(context)
+
+	cmdSequence := []tea.Cmd{
+		m.restartDeploymentWatch(context, watchNS),
+		m.restartPodWatch(context, watchNS),
+		m.restartServiceWatch(context, watchNS),
+		m.restartStatefulSetWatch(context, watchNS),
+		m.restartDaemonSetWatch(context, watchNS),
+		m.loadTopIfActive(),
+		m.loadIngressesIfActive(),
+		m.loadCustomResourcesIfActive(),
+	}
+
+	snapshot := m.appState.Snapshot()
+	m.deploymentList.SetRows(snapshot.Deployments)
+	m.podList.SetRows(snapshot.Pods)
+	m.svcList.SetRows(snapshot.Services)
+	m.stsList.SetRows(snapshot.StatefulSets)
+	m.dsList.SetRows(snapshot.DaemonSets)
+	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+	return tea.Batch(cmdSequence...)
+}
+
+// promptAlignNamespace asks for one namespace to switch every loaded
+// context to at once, prefilled with the cursor context's, and checks it
+// exists in each before moving anything.
+func (m *MainPage) promptAlignNamespace() tea.Cmd {
+	confirmed := m.contextList.Confirmed()
+	if len(confirmed) == 0 {
+		m.reportError("", "Align namespace: load one or more contexts first")
+		return nil
+	}
+	initial := ""
+	if _, current, ok := m.contextList.CursorContext(); ok && len(current) == 1 {
+		initial = current[0]
+	}
+	label := fmt.Sprintf("Namespace for all %d loaded context(s):", len(confirmed))
+	return m.openPrompt("Align namespace", label, initial, m.alignNamespace)
+}
+
+// alignNamespace checks namespace exists in every loaded context, for
+// onNamespaceCheck to move them over to it — what the align namespace
+// prompt and ":ns" do.
+func (m *MainPage) alignNamespace(namespace string) tea.Cmd {
+	confirmed := m.contextList.Confirmed()
+	if len(confirmed) == 0 {
+		m.reportError("", "Align namespace: load one or more contexts first")
+		return nil
+	}
+	contexts := make([]string, len(confirmed))
+	for i, selection := range confirmed {
+		contexts[i] = selection.ContextName
+	}
+	m.actionStatus = fmt.Sprintf("Checking namespace %s in %d context(s)...", namespace, len(contexts))
+	return cmds.CheckNamespaceCmd(m.ctx, m.Client, contexts, namespace)
+}
+
+// onNamespaceCheck moves every loaded context the namespace exists in over
+// to it — skipping any already on just that namespace — and reports the
+// contexts it's missing from, or couldn't be checked in, as an error.
+func (m *MainPage) onNamespaceCheck(msg msgs.NamespaceCheckMsg) tea.Cmd {
+	current := make(map[string][]string)
+	for _, selection := range m.contextList.Confirmed() {
+		current[selection.ContextName] = selectionNamespaces(selection)
+	}
+
+	var cmdSequence []tea.Cmd
+	for _, context := range msg.Found {
+		namespaces, ok := current[context]
+		if !ok || len(namespaces) == 1 && namespaces[0] == msg.Namespace {
+			continue
+		}
+		cmdSequence = append(cmdSequence, m.applyNamespaces(context, []string{msg.Namespace}))
+	}
+	m.actionStatus = fmt.Sprintf("Namespace %s in %d of %d context(s)", msg.Namespace, len(msg.Found), len(msg.Found)+len(msg.Missing)+len(msg.Errs))
+
+	var problems []string
+	if len(msg.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("not found in %s", strings.Join(msg.Missing, ", ")))
+	}
+	failed := make([]string, 0, len(msg.Errs))
+	for context := range msg.Errs {
+		failed = append(failed, context)
+	}
+	sort.Strings(failed)
+	for _, context := range failed {
+		problems = append(problems, fmt.Sprintf("%s: %v", context, msg.Errs[context]))
+	}
+	if len(problems) > 0 {
+		m.reportError("", fmt.Sprintf("Align namespace %s: %s", msg.Namespace, strings.Join(problems, "; ")))
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// selectionNamespaces returns the namespaces a selected context loads from:
+// the picked ones, else its default namespace.
+func selectionNamespaces(selection msgs.ContextsSelectedMsg) []string {
+	if len(selection.Namespaces) > 0 {
+		return selection.Namespaces
+	}
+	return []string{selection.DefaultNamespace}
+}
+
+// onNamespaces fills the picker with a fetched namespace list, dropping one
+// for a context the picker has since been closed or reopened for.
+func (m *MainPage) onNamespaces(msg msgs.NamespacesMsg) {
+	if !m.showNSPicker || m.nsPicker.Context() != msg.Context {
+		return
+	}
+	m.nsPicker.SetNamespaces(msg.Namespaces, msg.Err)
+}
+
+// namespaceSummary lists each selected context's namespaces for the status
+// bar ("prod: api,web · dev: default"), "all" for a context watched across
+// every namespace.
+func namespaceSummary(snapshot state.Snapshot) string {
+	contexts := make([]string, 0, len(snapshot.SelectedContexts))
+	for context := range snapshot.SelectedContexts {
+		contexts = append(contexts, context)
+	}
+	sort.Strings(contexts)
+
+	parts := make([]string, 0, len(contexts))
+	for _, context := range contexts {
+		namespaces := snapshot.Namespaces[context]
+		if len(namespaces) == 0 {
+			namespaces = []string{snapshot.SelectedContexts[context]}
+		}
+		list := strings.Join(namespaces, ",")
+		if list == "" {
+			list = "all"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", context, list))
+	}
+	return strings.Join(parts, " · ")
+}
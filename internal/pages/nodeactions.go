@@ -0,0 +1,93 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// confirmCordon asks before cordoning the nodes row under the cursor, or
+// uncordoning it if it's cordoned already, previewing the status change.
+// Either is undone by the other, so the confirmation doesn't warn.
+func (m *MainPage) confirmCordon() {
+	node, ok := m.nodeList.Selected()
+	if !ok {
+		return
+	}
+	cordon := !models.Cordoned(node)
+	title, message := "Cordon node", fmt.Sprintf("Cordon node %s in %s? No new pods will be scheduled onto it; those running stay.", node.Name, node.Context)
+	if !cordon {
+		title, message = "Uncordon node", fmt.Sprintf("Uncordon node %s in %s? Pods can be scheduled onto it again.", node.Name, node.Context)
+	}
+	m.openConfirm(title, message, func() tea.Cmd {
+		return cmds.CordonNodeCmd(m.callCtx(node.Context), m.Client, node.Context, node.Name, cordon)
+	})
+	m.confirm.SetPreview(models.CordonPreview(node, cordon))
+	m.confirm.Reversible()
+}
+
+// planDrain plans draining the nodes row under the cursor; the
+// confirmation follows once the plan's in (see confirmDrain).
+func (m *MainPage) planDrain() tea.Cmd {
+	node, ok := m.nodeList.Selected()
+	if !ok {
+		return nil
+	}
+	return cmds.PlanDrainCmd(m.callCtx(node.Context), m.Client, node.Context, node.Name)
+}
+
+// confirmDrain asks before draining a node, previewing the cordon and which
+// of its pods are evicted, skipped or blocked by a PodDisruptionBudget; or
+// reports why the node's pods couldn't be planned for.
+func (m *MainPage) confirmDrain(msg msgs.NodeDrainPlanMsg) {
+	if msg.Err != nil {
+		m.reportError(msg.Context, fmt.Sprintf("Drain: %v", msg.Err))
+		return
+	}
+	node, ok := m.nodeList.Find(msg.Context, msg.Node)
+	if !ok {
+		node = k8s.NodeInfo{Name: msg.Node, Context: msg.Context}
+	}
+	plan := msg.Plan
+	m.openConfirm("Drain node",
+		fmt.Sprintf("Drain node %s in %s? It's cordoned, then every pod not skipped is evicted.", msg.Node, msg.Context),
+		func() tea.Cmd {
+			return cmds.DrainNodeCmd(m.callCtx(plan.Context), m.Client, plan)
+		})
+	m.confirm.SetPreview(models.DrainPreview(node, plan))
+}
+
+// onNodeAction surfaces a finished node action like onPodAction does a pod
+// one, then re-lists the node's context so its status shows the change.
+func (m *MainPage) onNodeAction(msg msgs.NodeActionMsg) tea.Cmd {
+	reload := m.reloadNodes(msg.Context)
+	if msg.Err != nil {
+		m.reportError(msg.Context, msg.Err.Error())
+		return reload
+	}
+	verb := map[string]string{
+		msgs.ActionCordonNode:   "cordoned",
+		msgs.ActionUncordonNode: "uncordoned",
+		msgs.ActionDrainNode:    "drained",
+	}[msg.Action]
+	m.actionGen++
+	m.actionStatus = fmt.Sprintf("✓ %s node %s (%s)", verb, msg.Node, msg.Context)
+	gen := m.actionGen
+	return tea.Batch(reload, tea.Tick(actionNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodActionClearMsg{Generation: gen}
+	}))
+}
+
+// reloadNodes re-lists one context's nodes if the nodes tab is showing.
+func (m *MainPage) reloadNodes(context string) tea.Cmd {
+	if m.tabs[m.activeTab] != "nodes" || !m.appStateLoaded {
+		return nil
+	}
+	return cmds.LoadNodesCmd(m.callCtx(context), m.Client, context)
+}
@@ -0,0 +1,147 @@
+package pages
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// A kubeconfig whose cluster is never dialled: the tests below stop at the
+// commands MainPage returns, without running them.
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+users:
+- name: admin
+  user:
+    token: test
+current-context: prod
+`
+
+// newNodesTabPage returns a MainPage showing the nodes tab, focused, with
+// one Ready node and one cordoned one listed.
+func newNodesTabPage(t *testing.T) *MainPage {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := k8s.NewClient(path)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	m := NewMainPageModel(c, 5)
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 48})
+	m.appStateLoaded = true
+	m.activeTab = slices.Index(m.tabs, "nodes")
+	m.focus = focusTabs
+	m.updateFocusStates()
+	m.nodeList.SetNodes("prod", []k8s.NodeInfo{
+		{Name: "node-a", Context: "prod", Status: "Ready"},
+		{Name: "node-b", Context: "prod", Status: "Ready,SchedulingDisabled"},
+	})
+	return m
+}
+
+func press(m *MainPage, text string) tea.Cmd {
+	_, cmd := m.Update(tea.KeyPressMsg{Code: rune(text[0]), Text: text})
+	return cmd
+}
+
+func TestNodesTab_CordonAndUncordonPreviewTheStatusChange(t *testing.T) {
+	m := newNodesTabPage(t)
+
+	press(m, "c")
+	view := m.confirm.View()
+	if !m.showConfirm || !strings.Contains(view, "Cordon node node-a") || !strings.Contains(view, "Ready → Ready,SchedulingDisabled") {
+		t.Fatalf("c on a Ready node: showConfirm = %v, want the cordon question with its status change", m.showConfirm)
+	}
+	if strings.Contains(view, "can't be undone") {
+		t.Error("a cordon is undone by uncordoning, yet the confirmation warns it can't be")
+	}
+	press(m, "n")
+	if m.showConfirm {
+		t.Fatal("n left the confirmation open")
+	}
+
+	press(m, "j")
+	press(m, "c")
+	view = m.confirm.View()
+	if !m.showConfirm || !strings.Contains(view, "Uncordon node node-b") || !strings.Contains(view, "Ready,SchedulingDisabled → Ready") {
+		t.Fatal("c on a cordoned node didn't ask to uncordon it with its status change")
+	}
+	if cmd := press(m, "y"); cmd == nil || m.showConfirm {
+		t.Fatalf("y: cmd = %v, showConfirm = %v; want the uncordon run", cmd, m.showConfirm)
+	}
+}
+
+func TestNodesTab_DrainPreviewsThePlanBeforeDraining(t *testing.T) {
+	m := newNodesTabPage(t)
+
+	if cmd := press(m, "d"); cmd == nil {
+		t.Fatal("d didn't plan the drain")
+	}
+	if m.showConfirm {
+		t.Fatal("the confirmation opened before the plan was in")
+	}
+	m.Update(msgs.NodeDrainPlanMsg{Context: "prod", Node: "node-a", Plan: k8s.DrainPlan{
+		Context: "prod",
+		Node:    "node-a",
+		Pods: []k8s.DrainPod{
+			{Namespace: "kube-system", Name: "proxy-x", Skip: "DaemonSet"},
+			{Namespace: "shop", Name: "api-0", BlockedBy: "api-pdb"},
+			{Namespace: "shop", Name: "web-1"},
+		},
+	}})
+	if !m.showConfirm {
+		t.Fatal("the plan didn't open the drain confirmation")
+	}
+	view := m.View().Content
+	for _, want := range []string{"Drain node node-a", "Ready → Ready,SchedulingDisabled", "evicts 2 of its 3 pods", "blocked by PDB api-pdb", "(DaemonSet)", "can't be undone"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("drain confirmation is missing %q", want)
+		}
+	}
+
+	// Nothing but y/n gets past the confirmation to the tab underneath.
+	if press(m, "c"); !m.showConfirm || !strings.Contains(m.confirm.View(), "Drain node") {
+		t.Fatal("c reached the nodes tab through the drain confirmation")
+	}
+	if cmd := press(m, "y"); cmd == nil || m.showConfirm {
+		t.Fatalf("y: cmd = %v, showConfirm = %v; want the drain run", cmd, m.showConfirm)
+	}
+
+	press(m, "d")
+	m.Update(msgs.NodeDrainPlanMsg{Context: "prod", Node: "node-a", Plan: k8s.DrainPlan{Context: "prod", Node: "node-a"}})
+	if cmd := press(m, "n"); cmd != nil || m.showConfirm {
+		t.Fatalf("n: cmd = %v, showConfirm = %v; want the drain dropped", cmd, m.showConfirm)
+	}
+}
+
+func TestNodesTab_ReadOnlyLocksCordonAndDrain(t *testing.T) {
+	m := newNodesTabPage(t)
+	m.SetReadOnly(true)
+
+	press(m, "c")
+	if m.showConfirm {
+		t.Error("c asked to cordon in read-only mode")
+	}
+	if cmd := press(m, "d"); cmd != nil {
+		t.Error("d planned a drain in read-only mode")
+	}
+}
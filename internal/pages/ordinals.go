@@ -0,0 +1,82 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// promptOrdinalLogs asks which ordinals of the StatefulSet under the sts
+// tab's cursor to tail.
+func (m *MainPage) promptOrdinalLogs() tea.Cmd {
+	row := m.stsList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.WorkloadKeyName].(string)
+	namespace, _ := row[msgs.WorkloadKeyNamespace].(string)
+	context, _ := row[msgs.WorkloadKeyContext].(string)
+	label := fmt.Sprintf("Ordinals of %s to tail, e.g. 0..4, 0,2,5 or %s-0..%s-4 (empty tails all):", name, name, name)
+	return m.openOptionalPrompt("Tail ordinals", label, "", func(expr string) tea.Cmd {
+		return m.openOrdinalLogs(context, namespace, name, expr)
+	})
+}
+
+// openOrdinalLogs points the merged log pane at the StatefulSet's pods with
+// the ordinals expr names, found among the Pods tab's rows — every ordinal
+// pod when expr is empty. Ordinals with no pod (scaled down, or hidden by
+// the Pods tab's selector) are reported rather than silently skipped.
+func (m *MainPage) openOrdinalLogs(context, namespace, statefulSet, expr string) tea.Cmd {
+	var wanted []string
+	if expr != "" {
+		names, err := k8s.ParseOrdinals(statefulSet, expr)
+		if err != nil {
+			m.reportError("", fmt.Sprintf("Tail ordinals: %v", err))
+			return nil
+		}
+		wanted = names
+	}
+
+	byName := make(map[string]msgs.RowData)
+	for _, row := range m.appState.Snapshot().Pods {
+		if row[msgs.PodKeyContext] != context || row[msgs.PodKeyNamespace] != namespace {
+			continue
+		}
+		name, _ := row[msgs.PodKeyName].(string)
+		if _, ok := k8s.StatefulSetOrdinal(statefulSet, name); ok {
+			byName[name] = row
+		}
+	}
+	if wanted == nil {
+		for name := range byName {
+			wanted = append(wanted, name)
+		}
+		slices.SortFunc(wanted, func(a, b string) int {
+			x, _ := k8s.StatefulSetOrdinal(statefulSet, a)
+			y, _ := k8s.StatefulSetOrdinal(statefulSet, b)
+			return x - y
+		})
+	}
+
+	var rows []msgs.RowData
+	var missing []string
+	for _, name := range wanted {
+		if row, ok := byName[name]; ok {
+			rows = append(rows, row)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(rows) == 0 {
+		m.reportError("", fmt.Sprintf("Tail ordinals: no pods of %s found in %s/%s", statefulSet, context, namespace))
+		return nil
+	}
+	if len(missing) > 0 {
+		m.reportError("", fmt.Sprintf("Tail ordinals: no pod for %v", missing))
+	}
+	return m.reconcilePodLogs(rows)
+}
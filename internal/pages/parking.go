@@ -0,0 +1,99 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// deselectGrace is how long a deselected context stays parked, ready for
+// an undo, before it's unloaded for good.
+const deselectGrace = 30 * time.Second
+
+// parkContext deselects context without unloading it: its rows leave the
+// tables, but its watches keep running and its state stays (see
+// state.AppState.ParkContext), so undo (U) or selecting it again within
+// deselectGrace brings it straight back. The returned tick unloads it
+// after that.
+func (m *MainPage) parkContext(context string) tea.Cmd {
+	if !m.appState.ParkContext(context) {
+		return nil
+	}
+	m.parkGen++
+	gen := m.parkGen
+	m.parked[context] = gen
+	m.keys.Undo.SetEnabled(true)
+	return tea.Tick(deselectGrace, func(time.Time) tea.Msg {
+		return msgs.ContextParkExpiredMsg{Context: context, Generation: gen}
+	})
+}
+
+// noteParked records the contexts one confirmed deselection parked as
+// the batch U restores, and says so in the status bar.
+func (m *MainPage) noteParked(contexts []string) {
+	if len(contexts) == 0 {
+		return
+	}
+	slices.Sort(contexts)
+	m.lastParked = contexts
+	m.undoStatus = fmt.Sprintf("Deselected %s — U to undo (%s)", strings.Join(contexts, ", "), deselectGrace)
+	m.actionStatus = m.undoStatus
+}
+
+// unparkContext takes a parked context being selected again back as it
+// was, reporting whether it did. A context picked with other namespaces
+// than it was watching is unloaded instead, to be loaded afresh.
+func (m *MainPage) unparkContext(context, namespace string) bool {
+	if _, ok := m.parked[context]; !ok {
+		return false
+	}
+	m.forgetParked(context)
+	if watched, _ := m.appState.WatchedNamespace(context); watched != namespace {
+		m.removeContext(context)
+		return false
+	}
+	return m.appState.UnparkContext(context)
+}
+
+// onParkExpired unloads a context whose undo grace period is up, unless it
+// was taken back (or parked again) since.
+func (m *MainPage) onParkExpired(msg msgs.ContextParkExpiredMsg) {
+	if gen, ok := m.parked[msg.Context]; !ok || gen != msg.Generation {
+		return
+	}
+	m.forgetParked(msg.Context)
+	m.removeContext(msg.Context)
+}
+
+// undoDeselect (U) selects the last deselected batch of contexts again.
+func (m *MainPage) undoDeselect() tea.Cmd {
+	var names []string
+	for _, context := range m.lastParked {
+		if _, ok := m.parked[context]; ok {
+			names = append(names, context)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	m.actionStatus = "Restored " + strings.Join(names, ", ")
+	return m.contextList.Reselect(names)
+}
+
+// forgetParked drops context from the parked set, clearing the undo
+// notice once nothing's left to undo.
+func (m *MainPage) forgetParked(context string) {
+	delete(m.parked, context)
+	m.lastParked = slices.DeleteFunc(m.lastParked, func(c string) bool { return c == context })
+	if len(m.parked) == 0 {
+		m.keys.Undo.SetEnabled(false)
+	}
+	if len(m.lastParked) == 0 && m.actionStatus == m.undoStatus {
+		m.actionStatus = ""
+	}
+}
@@ -0,0 +1,120 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// actionNoticeDuration is how long a finished pod action's status bar
+// notice stays up.
+const actionNoticeDuration = 5 * time.Second
+
+// openConfirm shows the confirmation modal; y or Enter runs action, n or Esc
+// drops it.
+func (m *MainPage) openConfirm(title, message string, action func() tea.Cmd) {
+	m.confirm.Open(title, message)
+	m.confirmAction = action
+	m.showConfirm = true
+}
+
+// handleConfirmKey routes keys while the confirmation modal is open. Any
+// other key scrolls its preview, if it has one, and is otherwise swallowed,
+// so a stray keypress can neither confirm nor leak through to the table
+// underneath.
+func (m *MainPage) handleConfirmKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Yes):
+		action := m.confirmAction
+		m.showConfirm = false
+		m.confirmAction = nil
+		if action == nil {
+			return nil
+		}
+		return action()
+	case key.Matches(msg, m.keys.No):
+		m.showConfirm = false
+		m.confirmAction = nil
+		return nil
+	}
+	return m.confirm.Update(msg)
+}
+
+// selectedPod returns the Pods row under the cursor, ok false if none.
+func (m *MainPage) selectedPod() (ctxName, namespace, name string, ok bool) {
+	row := m.podList.SelectedRow()
+	if row == nil {
+		return "", "", "", false
+	}
+	name, _ = row[msgs.PodKeyName].(string)
+	namespace, _ = row[msgs.PodKeyNamespace].(string)
+	ctxName, _ = row[msgs.PodKeyContext].(string)
+	return ctxName, namespace, name, name != ""
+}
+
+// confirmDeletePod asks before deleting the Pods row under the cursor.
+func (m *MainPage) confirmDeletePod() {
+	ctxName, namespace, name, ok := m.selectedPod()
+	if !ok {
+		return
+	}
+	m.openConfirm("Delete pod",
+		fmt.Sprintf("Delete pod %s/%s in %s? Its controller, if any, will replace it.", namespace, name, ctxName),
+		func() tea.Cmd {
+			return cmds.DeletePodCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+		})
+}
+
+// findPodDeployment looks up the Deployment owning the Pods row under the
+// cursor; the confirmation follows once it's known (see
+// confirmRestartDeployment).
+func (m *MainPage) findPodDeployment() tea.Cmd {
+	ctxName, namespace, name, ok := m.selectedPod()
+	if !ok {
+		return nil
+	}
+	return cmds.FindPodDeploymentCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+}
+
+// confirmRestartDeployment asks before rollout-restarting the Deployment
+// found for a pod.
+func (m *MainPage) confirmRestartDeployment(msg msgs.PodDeploymentMsg) {
+	if msg.Err != nil {
+		m.reportError("", fmt.Sprintf("Restart: %v", msg.Err))
+		return
+	}
+	if msg.Deployment == "" {
+		m.reportError("", fmt.Sprintf("Restart: pod %s/%s isn't managed by a Deployment", msg.Namespace, msg.Pod))
+		return
+	}
+	m.openConfirm("Restart deployment",
+		fmt.Sprintf("Rollout-restart deployment %s/%s in %s (owner of pod %s)? Every pod it runs will be replaced.", msg.Namespace, msg.Deployment, msg.Context, msg.Pod),
+		func() tea.Cmd {
+			return cmds.RestartDeploymentCmd(m.callCtx(msg.Context), m.Client, msg.Context, msg.Namespace, msg.Deployment)
+		})
+}
+
+// onPodAction surfaces a finished action: failures through the error
+// overlay, success as a status bar notice that clears itself after
+// actionNoticeDuration. The watches pick up the change itself.
+func (m *MainPage) onPodAction(msg msgs.PodActionMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.reportError("", msg.Err.Error())
+		return nil
+	}
+	verb := "deleted pod"
+	if msg.Action == msgs.ActionRestartDeployment {
+		verb = "restarted deployment"
+	}
+	m.actionGen++
+	m.actionStatus = fmt.Sprintf("✓ %s %s/%s (%s)", verb, msg.Namespace, msg.Name, msg.Context)
+	gen := m.actionGen
+	return tea.Tick(actionNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodActionClearMsg{Generation: gen}
+	})
+}
@@ -0,0 +1,221 @@
+package pages
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// copyNoticeDuration is how long a finished copy's "✓ copied" notice stays
+// in the status bar.
+const copyNoticeDuration = 5 * time.Second
+
+// openFileBrowser opens the file browser on the Pods row under the cursor,
+// starting at "/" — first asking which container, the first offered, when
+// the pod has more than one. Returns nil if there's no selection.
+func (m *MainPage) openFileBrowser() tea.Cmd {
+	row := m.podList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.PodKeyName].(string)
+	namespace, _ := row[msgs.PodKeyNamespace].(string)
+	ctxName, _ := row[msgs.PodKeyContext].(string)
+	containersCSV, _ := row[msgs.PodKeyContainers].(string)
+	containers := strings.Split(containersCSV, ",")
+	if len(containers) < 2 {
+		return m.browsePodFiles(ctxName, namespace, name, containers[0])
+	}
+
+	label := fmt.Sprintf("Browse which container of %s/%s (%s)? %s", namespace, name, ctxName, containersCSV)
+	cmd := m.openPrompt("Files", label, containers[0], func(container string) tea.Cmd {
+		if !slices.Contains(containers, container) {
+			m.reportError("", fmt.Sprintf("Files: %s/%s has no container %s", namespace, name, container))
+			return nil
+		}
+		return m.browsePodFiles(ctxName, namespace, name, container)
+	})
+	m.prompt.SetSuggestions(containers)
+	return cmd
+}
+
+// browsePodFiles opens the file browser on a pod's container at "/".
+func (m *MainPage) browsePodFiles(ctxName, namespace, name, container string) tea.Cmd {
+	m.filesGen++
+	m.showFiles = true
+	m.fileBrowser.Open(ctxName, namespace, name, container, "/")
+	return cmds.ListPodDirCmd(m.callCtx(ctxName), m.Client, m.filesGen, ctxName, namespace, name, container, "/")
+}
+
+// handleFileBrowserKey routes keys while the file browser is open. Keys
+// that trigger an exec (open, view, tail, up a directory, copy out) are
+// handled here; cursor and viewer scrolling go to the browser itself.
+func (m *MainPage) handleFileBrowserKey(msg tea.KeyPressMsg) tea.Cmd {
+	fb := m.fileBrowser
+	ctxName, namespace, pod, container := fb.Target()
+
+	listDir := func(dir string) tea.Cmd {
+		m.filesGen++
+		fb.StartListing(dir)
+		return cmds.ListPodDirCmd(m.callCtx(ctxName), m.Client, m.filesGen, ctxName, namespace, pod, container, dir)
+	}
+	readFile := func(file string, tail bool) tea.Cmd {
+		m.filesGen++
+		fb.StartReading(file)
+		return cmds.ReadPodFileCmd(m.callCtx(ctxName), m.Client, m.filesGen, ctxName, namespace, pod, container, file, tail)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		if fb.Viewing() {
+			m.filesGen++
+			fb.CloseFile()
+			return nil
+		}
+		m.filesGen++
+		m.showFiles = false
+		return nil
+	case key.Matches(msg, m.keys.ParentDir):
+		if !fb.Viewing() {
+			return listDir(fb.ParentDir())
+		}
+	case key.Matches(msg, m.keys.Accept):
+		if entry, full, ok := fb.Selected(); ok {
+			if entry.IsDir() || entry.IsLink() {
+				// A symlink is listed through to its target; if that's a
+				// file, ls says so and v/t still read it.
+				return listDir(full)
+			}
+			return readFile(full, false)
+		}
+		return nil
+	case key.Matches(msg, m.keys.ViewFile, m.keys.TailFile):
+		if _, full, ok := fb.Selected(); ok {
+			return readFile(full, key.Matches(msg, m.keys.TailFile))
+		}
+		return nil
+	case key.Matches(msg, m.keys.CopyOut):
+		if entry, full, ok := fb.Selected(); ok && entry.Name != ".." {
+			return m.promptPodCopy(ctxName, namespace, pod, container, full)
+		}
+		return nil
+	}
+	return fb.Update(msg)
+}
+
+// openPrompt shows the text prompt; Enter hands its value to action.
+func (m *MainPage) openPrompt(title, label, initial string, action func(value string) tea.Cmd) tea.Cmd {
+	m.showPrompt = true
+	m.promptAction = action
+	m.promptAllowBlank = false
+	m.promptCancel = nil
+	return m.prompt.Open(title, label, initial)
+}
+
+// openOptionalPrompt is openPrompt for a value that may be left blank, e.g.
+// to clear a setting.
+func (m *MainPage) openOptionalPrompt(title, label, initial string, action func(value string) tea.Cmd) tea.Cmd {
+	cmd := m.openPrompt(title, label, initial, action)
+	m.promptAllowBlank = true
+	return cmd
+}
+
+// handlePromptKey routes keys while the prompt is open: Esc cancels (running
+// promptCancel, if set), Enter runs the pending action with the entered
+// text (ignored when blank, unless opened with openOptionalPrompt).
+func (m *MainPage) handlePromptKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		cancel := m.promptCancel
+		m.showPrompt = false
+		m.promptAction, m.promptCancel = nil, nil
+		if cancel != nil {
+			return cancel()
+		}
+		return nil
+	case key.Matches(msg, m.keys.Accept):
+		value := strings.TrimSpace(m.prompt.Value())
+		if value == "" && !m.promptAllowBlank {
+			return nil
+		}
+		action := m.promptAction
+		m.showPrompt = false
+		m.promptAction, m.promptCancel = nil, nil
+		if action == nil {
+			return nil
+		}
+		return action(value)
+	}
+	return m.prompt.Update(msg)
+}
+
+// promptPodCopy asks where to copy a container path to, defaulting to its
+// basename in the working directory, then starts the copy.
+func (m *MainPage) promptPodCopy(ctxName, namespace, pod, container, src string) tea.Cmd {
+	label := fmt.Sprintf("Copy %s from %s/%s [%s] to local path:", src, namespace, pod, container)
+	return m.openPrompt("Copy from pod", label, "./"+path.Base(src), func(dest string) tea.Cmd {
+		return m.startPodCopy(ctxName, namespace, pod, container, src, dest)
+	})
+}
+
+// startPodCopy cancels any copy still running — only one runs at a time —
+// and starts copying src to dest in the background.
+func (m *MainPage) startPodCopy(ctxName, namespace, pod, container, src, dest string) tea.Cmd {
+	m.cancelPodCopy()
+	if abs, err := filepath.Abs(dest); err == nil {
+		dest = abs
+	}
+
+	ctx, cancel := context.WithCancel(m.callCtx(ctxName))
+	m.copyGen++
+	m.copyCancel = cancel
+	m.copyLabel = fmt.Sprintf("%s → %s", path.Base(src), dest)
+	m.copyStatus = fmt.Sprintf("⇣ copying %s…", m.copyLabel)
+	return cmds.StartPodCopyCmd(ctx, m.Client, m.copyGen, ctxName, namespace, pod, container, src, dest)
+}
+
+// cancelPodCopy aborts the running copy, if any.
+func (m *MainPage) cancelPodCopy() {
+	if m.copyCancel != nil {
+		m.copyCancel()
+		m.copyCancel = nil
+	}
+	m.copyStatus = ""
+}
+
+// onPodCopyProgress updates the status bar notice from one copy update.
+// Failures surface through the error overlay; success leaves a notice that
+// clears itself after copyNoticeDuration.
+func (m *MainPage) onPodCopyProgress(msg msgs.PodCopyProgressMsg) tea.Cmd {
+	if msg.Generation != m.copyGen {
+		return nil
+	}
+	if !msg.Done {
+		m.copyStatus = fmt.Sprintf("⇣ copying %s (%s)", m.copyLabel, models.HumanBytes(msg.Bytes))
+		return cmds.WaitForPodCopyCmd(msg.Generation, m.copyUpdates)
+	}
+
+	m.copyCancel = nil
+	m.copyUpdates = nil
+	if msg.Err != nil {
+		m.copyStatus = ""
+		m.reportError("", msg.Err.Error())
+		return nil
+	}
+	m.copyStatus = fmt.Sprintf("✓ copied %s (%s)", m.copyLabel, models.HumanBytes(msg.Bytes))
+	gen := msg.Generation
+	return tea.Tick(copyNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodCopyClearMsg{Generation: gen}
+	})
+}
@@ -0,0 +1,118 @@
+package pages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// promptPortForward asks for the ports to forward to the Pods or svc row
+// under the cursor, then starts the forward. A svc prompt is prefilled with
+// the service's first port. Returns nil if there's no selection.
+func (m *MainPage) promptPortForward() tea.Cmd {
+	var kind, name, namespace, ctxName, initial string
+	switch m.tabs[m.activeTab] {
+	case "Pods":
+		row := m.podList.SelectedRow()
+		if row == nil {
+			return nil
+		}
+		kind = k8s.ForwardPod
+		name, _ = row[msgs.PodKeyName].(string)
+		namespace, _ = row[msgs.PodKeyNamespace].(string)
+		ctxName, _ = row[msgs.PodKeyContext].(string)
+	case "svc":
+		row := m.svcList.SelectedRow()
+		if row == nil {
+			return nil
+		}
+		kind = k8s.ForwardService
+		name, _ = row[msgs.SvcKeyName].(string)
+		namespace, _ = row[msgs.SvcKeyNamespace].(string)
+		ctxName, _ = row[msgs.SvcKeyContext].(string)
+		// Ports render as "80:30080/TCP,443/TCP" — the first port is what
+		// precedes any ":" or "/".
+		ports, _ := row[msgs.SvcKeyPorts].(string)
+		first, _, _ := strings.Cut(ports, ",")
+		first, _, _ = strings.Cut(first, "/")
+		first, _, _ = strings.Cut(first, ":")
+		if _, err := strconv.Atoi(first); err == nil {
+			initial = ":" + first
+		}
+	default:
+		return nil
+	}
+
+	label := fmt.Sprintf("Forward to %s/%s in %s (%s) — local:remote, or :remote for a free local port:", kind, name, namespace, ctxName)
+	return m.openPrompt("Port-forward", label, initial, func(spec string) tea.Cmd {
+		local, remote, err := parsePortSpec(spec)
+		if err != nil {
+			m.reportError("", fmt.Sprintf("Port-forward: %v", err))
+			return nil
+		}
+		return cmds.StartPortForwardCmd(m.callCtx(ctxName), m.forwards, ctxName, namespace, kind, name, local, remote)
+	})
+}
+
+// parsePortSpec parses kubectl port-forward's port syntax: "8080:80",
+// ":80" (any free local port) or "80" (the same port locally).
+func parsePortSpec(spec string) (local, remote int, err error) {
+	localStr, remoteStr, found := strings.Cut(spec, ":")
+	if !found {
+		remoteStr = localStr
+	}
+	remote, err = strconv.Atoi(remoteStr)
+	if err != nil || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port %q", remoteStr)
+	}
+	if localStr == "" {
+		return 0, remote, nil
+	}
+	local, err = strconv.Atoi(localStr)
+	if err != nil || local < 0 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", localStr)
+	}
+	return local, remote, nil
+}
+
+// openPortForwards shows the port-forward panel.
+func (m *MainPage) openPortForwards() {
+	m.forwardPanel.SetForwards(m.forwards.List())
+	m.showForwards = true
+}
+
+// handlePortForwardKey routes keys while the port-forward panel is open:
+// Esc closes it (forwards keep running), x stops the one under the cursor.
+func (m *MainPage) handlePortForwardKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.showForwards = false
+		return nil
+	case key.Matches(msg, m.keys.StopForward):
+		if f, ok := m.forwardPanel.Selected(); ok {
+			m.forwards.Stop(f.ID)
+			m.forwardPanel.SetForwards(m.forwards.List())
+		}
+		return nil
+	}
+	return m.forwardPanel.Update(msg)
+}
+
+// activeForwardCount is how many forwards are still running, for the
+// status bar.
+func (m *MainPage) activeForwardCount() int {
+	n := 0
+	for _, f := range m.forwards.List() {
+		if f.Active {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,168 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// SetProfiles installs the configured profiles; with none, 'p' in the
+// contexts pane is disabled. ktails starts in config.DefaultProfile, the
+// client's own kubeconfig files, listed first unless the config defines
+// it.
+func (m *MainPage) SetProfiles(profiles []config.Profile) {
+	m.profiles = nil
+	m.keys.Profiles.SetEnabled(len(profiles) > 0)
+	if len(profiles) == 0 {
+		return
+	}
+	if !slices.ContainsFunc(profiles, func(p config.Profile) bool { return p.Name == config.DefaultProfile }) {
+		m.profiles = append(m.profiles, config.Profile{Name: config.DefaultProfile})
+	}
+	m.profiles = append(m.profiles, profiles...)
+	m.defaultKubeconfig = slices.Clone(m.Client.KubeconfigFiles())
+	m.activeProfile = config.DefaultProfile
+	m.contextList.SetProfile(m.activeProfile)
+}
+
+// openProfilePicker opens the profile picker (p), on the active profile.
+func (m *MainPage) openProfilePicker() {
+	items := make([]models.ProfileItem, 0, len(m.profiles))
+	for _, p := range m.profiles {
+		kubeconfig := "top-level kubeconfig"
+		if len(p.KubeconfigPaths) > 0 {
+			kubeconfig = strings.Join(p.KubeconfigPaths, ", ")
+		}
+		loads := "loads nothing"
+		if len(p.Contexts) > 0 {
+			loads = "loads " + strings.Join(p.Contexts, ", ")
+			if len(p.Namespaces) > 0 {
+				loads += " in " + strings.Join(p.Namespaces, ", ")
+			}
+		}
+		items = append(items, models.ProfileItem{Name: p.Name, Detail: kubeconfig + " · " + loads})
+	}
+	m.profilePicker.Open(items, m.activeProfile)
+	m.showProfiles = true
+}
+
+// handleProfilePickerKey routes keys while the profile picker is open.
+func (m *MainPage) handleProfilePickerKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.showProfiles = false
+		return nil
+	case key.Matches(msg, m.keys.Accept):
+		m.showProfiles = false
+		name, ok := m.profilePicker.Picked()
+		if !ok {
+			return nil
+		}
+		return m.switchProfile(name)
+	}
+	return m.profilePicker.Update(msg)
+}
+
+// profileCommand switches to the profile named by :profile.
+func (m *MainPage) profileCommand(arg string) tea.Cmd {
+	if len(m.profiles) == 0 {
+		m.reportError("", ":profile: no profiles configured")
+		return nil
+	}
+	if !slices.ContainsFunc(m.profiles, func(p config.Profile) bool { return p.Name == arg }) {
+		m.reportError("", fmt.Sprintf(":profile: no profile %s", arg))
+		return nil
+	}
+	return m.switchProfile(arg)
+}
+
+// profileNames lists the profiles for :profile's completion.
+func (m *MainPage) profileNames() []string {
+	names := make([]string, 0, len(m.profiles))
+	for _, p := range m.profiles {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// switchProfile points the client at the named profile's kubeconfig files
+// and starts over on them: every loaded context is unloaded — its watches
+// stopped, its log sources and port-forwards closed — the contexts pane is
+// re-listed, and the profile's contexts are loaded. If the files can't be
+// loaded nothing changes.
+func (m *MainPage) switchProfile(name string) tea.Cmd {
+	i := slices.IndexFunc(m.profiles, func(p config.Profile) bool { return p.Name == name })
+	if i < 0 {
+		return nil
+	}
+	profile := m.profiles[i]
+	paths := profile.KubeconfigPaths
+	if len(paths) == 0 {
+		paths = m.defaultKubeconfig
+	}
+	if err := m.Client.SwitchKubeconfig(paths); err != nil {
+		m.reportError("", fmt.Sprintf("Profile %s: %v", name, err))
+		return nil
+	}
+
+	loaded := make(map[string]bool)
+	for context := range m.appState.Snapshot().SelectedContexts {
+		loaded[context] = true
+	}
+	for context := range m.parked {
+		loaded[context] = true
+	}
+	for context := range loaded {
+		m.removeContext(context)
+	}
+	m.closeLogs()
+	m.closeDetail()
+	m.showPanel = false
+	m.forwards.StopAll()
+	m.forwardPanel.SetForwards(m.forwards.List())
+
+	m.activeProfile = name
+	m.contextList.SetProfile(name)
+	m.contextList.Reset()
+	m.keys.Conflicts.SetEnabled(len(m.Client.ContextConflicts()) > 0)
+
+	m.appStateLoaded = false
+	m.deploymentList.SetRows([]msgs.RowData{})
+	m.podList.SetRows([]msgs.RowData{})
+	m.svcList.SetRows([]msgs.RowData{})
+	m.stsList.SetRows([]msgs.RowData{})
+	m.dsList.SetRows([]msgs.RowData{})
+	m.contextList.SetContextStates(nil, nil, nil)
+	if len(profile.Contexts) == 0 {
+		// Nothing's coming to the tabs: the contexts are the thing to pick.
+		m.focus = focusLeftPane
+	}
+	m.updateFocusStates()
+
+	// The watch follows the new files; the old one's pending wait ends
+	// quietly as it's closed.
+	if m.kubeconfigWatcher != nil {
+		m.kubeconfigWatcher.Close()
+		m.kubeconfigWatcher = nil
+	}
+	batch := []tea.Cmd{m.watchKubeconfigCmd(), m.checkConnectionCmd(m.Client.GetCurrentContext())}
+	if len(profile.Contexts) > 0 {
+		batch = append(batch, m.contextList.Restore(profile.Selection()))
+	}
+
+	m.actionGen++
+	m.actionStatus = "Switched to profile " + name
+	gen := m.actionGen
+	batch = append(batch, tea.Tick(actionNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodActionClearMsg{Generation: gen}
+	}))
+	return tea.Batch(batch...)
+}
@@ -0,0 +1,14 @@
+package pages
+
+// SetReadOnly turns on read-only mode (read_only in the config, or
+// --read-only): the client refuses every write and exec whatever asks for
+// it (see k8s.Client.SetReadOnly), and the keys for them are locked —
+// dropped from the hints, greyed out in help. It's called after the
+// setters that enable keys, and there's no turning it back off.
+func (m *MainPage) SetReadOnly(on bool) {
+	if !on {
+		return
+	}
+	m.Client.SetReadOnly()
+	m.lockedKeys = m.keys.LockMutating()
+}
@@ -0,0 +1,94 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// followRecreatedPods follows every source in context whose pod is gone
+// — deleted, or recreated under its own name by a StatefulSet — into the
+// pod its controller created in its place, once that pod's container has
+// started: the source keeps its scrollback, gains a divider line, and
+// tails the new pod from the start. Sources whose stream has ended are
+// followed too, so a pane doesn't go dead when the replacement takes
+// longer than the reconnects. Previous-instance logs aren't followed.
+func (m *MainPage) followRecreatedPods(context string) tea.Cmd {
+	st, ok := m.podWatchers[context]
+	if !ok || st.cache == nil || m.logTail.previous {
+		return nil
+	}
+
+	targets := make(map[string]podLogTarget)
+	for key, ls := range m.logStreams {
+		targets[key] = ls.target
+	}
+	for key, t := range m.endedSources {
+		targets[key] = t
+	}
+
+	var openCmds []tea.Cmd
+	for key, t := range targets {
+		if t.context != context || !m.logPanes.HasSource(key) {
+			continue
+		}
+		if current, ok := st.cache.Lineage(t.namespace, t.pod); ok {
+			if prev, seen := m.lineages[key]; !seen || prev.UID == current.UID {
+				m.lineages[key] = current
+				continue
+			}
+		}
+		prev, seen := m.lineages[key]
+		if !seen {
+			continue
+		}
+		name, ok := st.cache.Replacement(prev, t.cntnr, func(name string) bool {
+			_, taken := m.logStreams[podSourceKey(t.context, t.namespace, name, t.cntnr)]
+			return taken && name != t.pod
+		})
+		if !ok {
+			continue
+		}
+		openCmds = append(openCmds, m.followReplacement(key, t, name))
+	}
+	for key := range m.lineages {
+		if !m.logPanes.HasSource(key) {
+			delete(m.lineages, key)
+		}
+	}
+	return tea.Batch(openCmds...)
+}
+
+// followReplacement moves source key onto the pod name that replaced its
+// pod and opens the new stream.
+func (m *MainPage) followReplacement(key string, t podLogTarget, name string) tea.Cmd {
+	controller := m.lineages[key].Controller
+	generation := 1
+	if old, ok := m.logStreams[key]; ok {
+		if old.stream != nil {
+			old.stream.Close()
+		}
+		old.endStream()
+		generation = old.generation + 1
+		delete(m.logStreams, key)
+	}
+	delete(m.endedSources, key)
+	delete(m.lineages, key)
+
+	newKey := podSourceKey(t.context, t.namespace, name, t.cntnr)
+	divider := fmt.Sprintf("%s recreated by %s as %s · following", t.pod, strings.ToLower(controller), name)
+	if name == t.pod {
+		divider = fmt.Sprintf("%s recreated by %s · following", t.pod, strings.ToLower(controller))
+	}
+	m.logPanes.ReplaceSource(key, newKey, name, divider)
+	if h, ok := m.exitHistories[key]; ok && newKey != key {
+		m.exitHistories[newKey] = h
+		delete(m.exitHistories, key)
+	}
+
+	t.key, t.pod = newKey, name
+	st := &logStreamState{target: t, generation: generation}
+	m.logStreams[newKey] = st
+	return m.openLogSourceCmd(newKey, st)
+}
@@ -0,0 +1,82 @@
+package pages
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+)
+
+// heldNotice marks where a held source's output stops.
+const heldNotice = "container restarted · holding this instance's output, F follows the new one"
+
+// toggleHoldRestarts switches the pane between following a container into
+// its next instance when it restarts (the default) and holding the old
+// instance's output on screen until F — for reading a crash's last lines
+// without the new instance's startup scrolling them away. Switching back
+// to following follows every source held meanwhile.
+func (m *MainPage) toggleHoldRestarts() tea.Cmd {
+	m.logTail.holdRestarts = !m.logTail.holdRestarts
+	m.logPanes.SetMode(m.logTail.mode())
+	if m.logTail.holdRestarts {
+		m.actionStatus = "Log pane: holding output when a container restarts (F follows)"
+		return nil
+	}
+	m.actionStatus = "Log pane: following containers when they restart"
+	return tea.Batch(m.followHeldSources()...)
+}
+
+// holdSource holds source key on its current instance after recordExits
+// sees the container exit. A stream still open is left to deliver the old
+// instance's last lines, and onLogStreamClosed then stops instead of
+// reconnecting; a reconnect already under way is called off.
+func (m *MainPage) holdSource(key string) {
+	st, ok := m.logStreams[key]
+	if !ok || st.held {
+		return
+	}
+	st.held = true
+	if st.stream == nil {
+		st.generation++
+		st.resuming = false
+		m.logPanes.AddNotice(key, heldNotice)
+	}
+}
+
+// followRestarts reopens every held source on its container's new
+// instance (F in the log pane).
+func (m *MainPage) followRestarts() tea.Cmd {
+	openCmds := m.followHeldSources()
+	if len(openCmds) == 0 {
+		m.reportError("", "Nothing to follow: no container in the pane is held after a restart")
+		return nil
+	}
+	m.actionStatus = fmt.Sprintf("Following %d restarted container(s)", len(openCmds))
+	return tea.Batch(openCmds...)
+}
+
+// followHeldSources reopens each held source from just after the old
+// instance's last line, returning the commands opening them.
+func (m *MainPage) followHeldSources() []tea.Cmd {
+	var openCmds []tea.Cmd
+	for _, key := range m.logPanes.Keys() {
+		st, ok := m.logStreams[key]
+		if !ok || !st.held {
+			continue
+		}
+		st.held = false
+		st.failures = 0
+		st.generation++
+		m.logPanes.AddNotice(key, "following the restarted container")
+		if st.lastTime.IsZero() {
+			openCmds = append(openCmds, m.openLogSourceCmd(key, st))
+			continue
+		}
+		st.resuming = true
+		st.skipAtLastTime = st.atLastTime
+		t := st.target
+		openCmds = append(openCmds, cmds.ResumePodLogStreamCmd(m.streamCtx(st), m.Client, t.context, t.namespace, t.pod, key, st.generation, m.logTail.options(t.cntnr), st.lastTime, 0))
+	}
+	return openCmds
+}
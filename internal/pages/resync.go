@@ -0,0 +1,102 @@
+package pages
+
+import (
+	"log"
+
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// resyncTables reconciles each selected context's Pods and Deployments
+// watch caches with a fresh list — the auto-refresh tick's reload, a safety
+// net under watches that can go quiet without closing. A context whose
+// previous resync hasn't come back yet is skipped rather than stacked, as
+// is one near its request budget or without a live watch.
+func (m *MainPage) resyncTables() tea.Cmd {
+	contexts, _ := m.withinBudget(m.appState.Snapshot().SelectedContexts)
+	var cmdSequence []tea.Cmd
+	for context, namespace := range contexts {
+		if m.resyncing[context] > 0 {
+			continue
+		}
+		if st, ok := m.podWatchers[context]; ok && st.watcher != nil {
+			cmdSequence = append(cmdSequence, cmds.ResyncPodsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("Pods"), st.generation, st.cache))
+			m.resyncing[context]++
+		}
+		if st, ok := m.deploymentWatchers[context]; ok && st.watcher != nil {
+			cmdSequence = append(cmdSequence, cmds.ResyncDeploymentsCmd(m.callCtx(context), m.Client, context, namespace, m.listOptions("Deployments"), st.generation, st.cache))
+			m.resyncing[context]++
+		}
+	}
+	if len(cmdSequence) == 0 {
+		return nil
+	}
+	if !m.spinning {
+		m.spinning = true
+		cmdSequence = append(cmdSequence, m.syncSpinner.Tick)
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// onResynced applies a resync's rows, unless the watch it was issued for
+// has since been restarted (its fresh cache is then the newer truth). A
+// failed resync is only logged: the watch is still the primary source, and
+// its own failures surface on the context.
+func (m *MainPage) onResynced(msg msgs.ResyncedMsg) {
+	if m.resyncing[msg.Context]--; m.resyncing[msg.Context] <= 0 {
+		delete(m.resyncing, msg.Context)
+	}
+	if msg.Err != nil {
+		log.Printf("resync of %s in %s failed: %v", msg.Resource, msg.Context, msg.Err)
+		return
+	}
+	switch msg.Resource {
+	case "Pods":
+		st, ok := m.podWatchers[msg.Context]
+		if !ok || msg.Generation != st.generation {
+			return
+		}
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyPodWatchRows(msg.Context, msg.Rows)
+		}
+	case "Deployments":
+		st, ok := m.deploymentWatchers[msg.Context]
+		if !ok || msg.Generation != st.generation {
+			return
+		}
+		if m.unfocused {
+			st.deferRows(msg.Rows)
+		} else {
+			m.applyDeploymentWatchRows(msg.Context, msg.Rows)
+		}
+	}
+}
+
+// onSpinnerTick advances the status bar's sync spinner, letting it stop
+// once no resync is outstanding.
+func (m *MainPage) onSpinnerTick(msg spinner.TickMsg) tea.Cmd {
+	if len(m.resyncing) == 0 {
+		m.spinning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.syncSpinner, cmd = m.syncSpinner.Update(msg)
+	return cmd
+}
+
+// refreshStatus is the status bar's auto-refresh indicator: a spinner while
+// a resync is out, and a note while auto-refresh is switched off.
+func (m *MainPage) refreshStatus() string {
+	switch {
+	case !m.autoRefresh:
+		return "⏸ auto-refresh off · R: resume"
+	case len(m.resyncing) > 0:
+		return m.syncSpinner.View() + " syncing"
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package pages
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// rolloutPanelKey is the info panel's panelKey while it shows a
+// Deployment's rollout.
+func rolloutPanelKey(ctxName, namespace, name string) string {
+	return "rollout/" + ctxName + "/" + namespace + "/" + name
+}
+
+// openRollout (h) opens the info panel on the rollout status and revision
+// history of the Deployments row under the cursor.
+func (m *MainPage) openRollout() tea.Cmd {
+	row := m.deploymentList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.DeployKeyName].(string)
+	namespace, _ := row[msgs.DeployKeyNamespace].(string)
+	ctxName, _ := row[msgs.DeployKeyContext].(string)
+	return m.loadRollout(ctxName, namespace, name)
+}
+
+// loadRollout (re)fetches a Deployment's rollout into the info panel.
+func (m *MainPage) loadRollout(ctxName, namespace, name string) tea.Cmd {
+	m.panelKey = rolloutPanelKey(ctxName, namespace, name)
+	m.showPanel = true
+	m.infoPanel.StartLoading(fmt.Sprintf("Rollout: %s/%s (%s)", namespace, name, ctxName))
+	return cmds.LoadRolloutCmd(m.callCtx(ctxName), m.Client, ctxName, namespace, name)
+}
+
+// onRollout fills the rollout panel, unless it was closed (or moved on to
+// another Deployment) while the fetch was in flight.
+func (m *MainPage) onRollout(msg msgs.RolloutMsg) {
+	if !m.showPanel || m.panelKey != rolloutPanelKey(msg.Context, msg.Namespace, msg.Name) {
+		return
+	}
+	if msg.Err != nil {
+		m.infoPanel.SetError(msg.Err.Error())
+		return
+	}
+	m.rollout = msg
+	m.infoPanel.SetContent(m.infoPanel.Title(), models.RolloutLines(msg.Rollout))
+	if len(msg.Rollout.Revisions) > 1 && m.keys.UndoRollout.Enabled() {
+		m.infoPanel.SetActions("u undo")
+	}
+}
+
+// promptUndoRollout (u, in the rollout panel) asks which revision to roll
+// the Deployment back to, the one before the current by default, then
+// confirms.
+func (m *MainPage) promptUndoRollout() tea.Cmd {
+	r := m.rollout
+	if m.panelKey != rolloutPanelKey(r.Context, r.Namespace, r.Name) || len(r.Rollout.Revisions) < 2 {
+		return nil
+	}
+	var initial string
+	for _, rev := range r.Rollout.Revisions {
+		if !rev.Current {
+			initial = strconv.FormatInt(rev.Revision, 10)
+			break
+		}
+	}
+	label := fmt.Sprintf("Roll deployment %s/%s (%s) back to revision:", r.Namespace, r.Name, r.Context)
+	return m.openPrompt("Undo rollout", label, initial, func(value string) tea.Cmd {
+		revision, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || revision < 1 {
+			m.reportError("", fmt.Sprintf("Undo rollout: %q isn't a revision number", value))
+			return nil
+		}
+		m.openConfirm("Undo rollout",
+			fmt.Sprintf("Roll deployment %s/%s in %s back to revision %d? Its pods will be replaced with that revision's.", r.Namespace, r.Name, r.Context, revision),
+			func() tea.Cmd {
+				return cmds.UndoRolloutCmd(m.callCtx(r.Context), m.Client, r.Context, r.Namespace, r.Name, revision)
+			})
+		return nil
+	})
+}
+
+// onRolloutUndone reports a rollback like a pod action (see onPodAction)
+// and refreshes the rollout panel if it's still showing that Deployment.
+func (m *MainPage) onRolloutUndone(msg msgs.RolloutUndoneMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.reportError("", msg.Err.Error())
+		return nil
+	}
+	m.actionGen++
+	m.actionStatus = fmt.Sprintf("✓ rolled back deployment %s/%s (%s) to revision %d", msg.Namespace, msg.Name, msg.Context, msg.Revision)
+	gen := m.actionGen
+	clearNotice := tea.Tick(actionNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodActionClearMsg{Generation: gen}
+	})
+	if m.showPanel && m.panelKey == rolloutPanelKey(msg.Context, msg.Namespace, msg.Name) {
+		return tea.Batch(clearNotice, m.loadRollout(msg.Context, msg.Namespace, msg.Name))
+	}
+	return clearNotice
+}
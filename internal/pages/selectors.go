@@ -0,0 +1,111 @@
+package pages
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+)
+
+// listOptions returns the list options a resource tab's watches open with.
+// Expressions are validated before they're stored, so parsing can't fail
+// here.
+func (m *MainPage) listOptions(tab string) metav1.ListOptions {
+	opts, _ := k8s.ParseSelectors(m.selectors[tab])
+	return opts
+}
+
+// promptSelector asks for the active resource tab's selector expression,
+// prefilled with the current one; an empty answer clears it.
+func (m *MainPage) promptSelector() tea.Cmd {
+	tab := m.tabs[m.activeTab]
+	if !isSelectorTab(tab) {
+		return nil
+	}
+	label := fmt.Sprintf("Selector for %s in every context, e.g. app=api,tier=backend or status.phase=Running (empty clears):", tab)
+	return m.openOptionalPrompt("Selector", label, m.selectors[tab], func(expr string) tea.Cmd {
+		return m.setSelector(tab, expr)
+	})
+}
+
+// isSelectorTab reports whether a tab's rows come from a watch a selector
+// can narrow.
+func isSelectorTab(tab string) bool {
+	switch tab {
+	case "Deployments", "Pods", "svc", "sts", "ds":
+		return true
+	}
+	return false
+}
+
+// setSelector validates and stores a tab's selector expression, then
+// reopens that tab's watches in every selected context on fresh caches: an
+// object the old watch delivered may not match the new selector, and a
+// watch only reports what does.
+func (m *MainPage) setSelector(tab, expr string) tea.Cmd {
+	if _, err := k8s.ParseSelectors(expr); err != nil {
+		m.reportError("", fmt.Sprintf("Selector: %v", err))
+		return nil
+	}
+	if expr == m.selectors[tab] {
+		return nil
+	}
+	if expr == "" {
+		delete(m.selectors, tab)
+	} else {
+		m.selectors[tab] = expr
+	}
+
+	var cmdSequence []tea.Cmd
+	for context, namespace := range m.appState.Snapshot().SelectedContexts {
+		switch tab {
+		case "Deployments":
+			if st, ok := m.deploymentWatchers[context]; ok {
+				st.cache = cmds.NewDeploymentWatchCache()
+				st.takeDeferred()
+			}
+			m.appState.SetDeployments(context, nil)
+			cmdSequence = append(cmdSequence, m.restartDeploymentWatch(context, namespace))
+		case "Pods":
+			if st, ok := m.podWatchers[context]; ok {
+				st.cache = cmds.NewPodWatchCache()
+				st.takeDeferred()
+			}
+			m.appState.SetPods(context, nil)
+			cmdSequence = append(cmdSequence, m.restartPodWatch(context, namespace))
+		case "svc":
+			if st, ok := m.serviceWatchers[context]; ok {
+				st.cache = cmds.NewServiceWatchCache()
+				st.takeDeferred()
+			}
+			m.appState.SetServices(context, nil)
+			cmdSequence = append(cmdSequence, m.restartServiceWatch(context, namespace))
+		case "sts":
+			if st, ok := m.stsWatchers[context]; ok {
+				st.cache = cmds.NewStatefulSetWatchCache()
+				st.takeDeferred()
+			}
+			m.appState.SetStatefulSets(context, nil)
+			cmdSequence = append(cmdSequence, m.restartStatefulSetWatch(context, namespace))
+		case "ds":
+			if st, ok := m.dsWatchers[context]; ok {
+				st.cache = cmds.NewDaemonSetWatchCache()
+				st.takeDeferred()
+			}
+			m.appState.SetDaemonSets(context, nil)
+			cmdSequence = append(cmdSequence, m.restartDaemonSetWatch(context, namespace))
+		}
+	}
+
+	snapshot := m.appState.Snapshot()
+	m.deploymentList.SetRows(snapshot.Deployments)
+	m.podList.SetRows(snapshot.Pods)
+	m.svcList.SetRows(snapshot.Services)
+	m.stsList.SetRows(snapshot.StatefulSets)
+	m.dsList.SetRows(snapshot.DaemonSets)
+	m.contextList.SetContextStates(snapshot.LoadingStates, snapshot.Errors, snapshot.LoadedContexts)
+	return tea.Batch(cmdSequence...)
+}
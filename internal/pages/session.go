@@ -0,0 +1,214 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// SetSession hands over the session saved on the last quit; Init offers to
+// restore it.
+func (m *MainPage) SetSession(session *config.Session) {
+	if session.Empty() {
+		return
+	}
+	m.savedSession = session
+}
+
+// SetStartContext has the page load context straight away, in namespace
+// (empty: the context's own), rather than offer the saved session — for
+// starting as kubectl would, on the context and namespace it resolves.
+func (m *MainPage) SetStartContext(context, namespace string) {
+	m.savedSession = nil
+	var namespaces []string
+	if namespace != "" {
+		namespaces = []string{namespace}
+	}
+	m.startSelection = map[string][]string{context: namespaces}
+}
+
+// Session returns the layout captured when the user quit, nil if they
+// haven't.
+func (m *MainPage) Session() *config.Session {
+	return m.quitSession
+}
+
+// SetState hands the page what the state file recorded: the contexts
+// pane's order and whether it's collapsed, the list/pane split, the log
+// panes' wrapping, the watchlist and the muted alert rules. The rest of it
+// is saved back untouched.
+func (m *MainPage) SetState(state *config.State) {
+	m.state = state
+	m.contextList.SetOrdering(state.ContextSort, state.ContextOrder, state.ContextsUsed)
+	if state.SplitPercent != 0 {
+		m.splitPercent = max(minSplitPercent, min(maxSplitPercent, state.SplitPercent))
+	}
+	m.contextsCollapsed = state.ContextsCollapsed
+	m.logPanes.SetWrap(state.LogWrap)
+	m.watchlist = slices.Clone(state.Watchlist)
+	m.syncWatchlist()
+	for _, name := range state.MutedAlerts {
+		m.alerts.SetMuted(name, true)
+	}
+	m.updateFocusStates()
+}
+
+// State returns the state to save on quit, with the contexts pane's order
+// and collapse, the split, the log panes' wrapping, the watchlist and the
+// muted alert rules as they are now.
+func (m *MainPage) State() *config.State {
+	if m.state == nil {
+		m.state = &config.State{}
+	}
+	m.state.ContextSort, m.state.ContextOrder, m.state.ContextsUsed = m.contextList.Ordering()
+	m.state.ContextsCollapsed = m.contextsCollapsed
+	m.state.LogWrap = m.logPanes.Wrap()
+	m.state.Watchlist = m.watchlist
+	m.state.MutedAlerts = m.alerts.Muted()
+	m.state.SplitPercent = 0
+	if m.splitPercent != defaultSplitPercent {
+		m.state.SplitPercent = m.splitPercent
+	}
+	return m.state
+}
+
+// loadStartContext selects the context SetStartContext named, once, and
+// has the Pods tab focused as the selection lands.
+func (m *MainPage) loadStartContext() tea.Cmd {
+	selection := m.startSelection
+	m.startSelection = nil
+	if selection == nil {
+		return nil
+	}
+	m.restoreTab = "Pods"
+	return m.contextList.Restore(selection)
+}
+
+// offerSessionRestore asks whether to restore the saved session.
+func (m *MainPage) offerSessionRestore() {
+	s := m.savedSession
+	if s == nil {
+		return
+	}
+	names := make([]string, 0, len(s.Contexts))
+	for _, c := range s.Contexts {
+		names = append(names, c.Name)
+	}
+	message := fmt.Sprintf("Restore the previous session? Contexts: %s", strings.Join(names, ", "))
+	if s.ActiveTab != "" {
+		message += fmt.Sprintf(" · tab: %s", s.ActiveTab)
+	}
+	if len(s.Pods) > 0 {
+		message += fmt.Sprintf(" · tailing %d pod(s)", len(s.Pods))
+	}
+	if !s.SavedAt.IsZero() {
+		message += fmt.Sprintf(" (saved %s)", s.SavedAt.Local().Format("Jan 2 15:04"))
+	}
+	m.confirm.Ask("Restore session", message)
+	m.confirmAction = m.restoreSession
+	m.showConfirm = true
+}
+
+// restoreSession selects the saved contexts; the active tab is switched to
+// once the selection lands (see applyRestoredTab), and the tailed pods are
+// reopened as each context's pods arrive (see resumeRestoredLogs).
+func (m *MainPage) restoreSession() tea.Cmd {
+	s := m.savedSession
+	m.savedSession = nil
+	if s == nil {
+		return nil
+	}
+	selections := make(map[string][]string, len(s.Contexts))
+	for _, c := range s.Contexts {
+		selections[c.Name] = c.Namespaces
+	}
+	m.restoreTab = s.ActiveTab
+	m.restorePods = make(map[string][]config.SessionPod)
+	for _, p := range s.Pods {
+		if _, ok := selections[p.Context]; ok {
+			m.restorePods[p.Context] = append(m.restorePods[p.Context], p)
+		}
+	}
+	m.restoredLogRows = nil
+	return m.contextList.Restore(selections)
+}
+
+// applyRestoredTab switches to a restored session's tab, once.
+func (m *MainPage) applyRestoredTab() {
+	if m.restoreTab == "" {
+		return
+	}
+	for i, t := range m.tabs {
+		if t == m.restoreTab {
+			m.activeTab = i
+			m.focus = focusTabs
+			m.updateFocusStates()
+			break
+		}
+	}
+	m.restoreTab = ""
+}
+
+// resumeRestoredLogs reopens the log pane on a restored session's pods in
+// context, now that its pods have arrived. Pods that are gone are skipped.
+func (m *MainPage) resumeRestoredLogs(context string, rows []msgs.RowData) tea.Cmd {
+	pending, ok := m.restorePods[context]
+	if !ok {
+		return nil
+	}
+	delete(m.restorePods, context)
+	for _, p := range pending {
+		for _, row := range rows {
+			if row[msgs.PodKeyNamespace] == p.Namespace && row[msgs.PodKeyName] == p.Pod {
+				m.restoredLogRows = append(m.restoredLogRows, row)
+				break
+			}
+		}
+	}
+	rows = m.restoredLogRows
+	if len(m.restorePods) == 0 {
+		m.restoredLogRows = nil
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return m.reconcilePodLogs(rows)
+}
+
+// captureSession records the current layout for Session.
+func (m *MainPage) captureSession() {
+	s := &config.Session{SavedAt: time.Now()}
+	for _, sel := range m.contextList.Confirmed() {
+		s.Contexts = append(s.Contexts, config.SessionContext{Name: sel.ContextName, Namespaces: sel.Namespaces})
+	}
+	if len(s.Contexts) > 0 {
+		s.ActiveTab = m.tabs[m.activeTab]
+	}
+
+	seen := make(map[config.SessionPod]bool)
+	for _, st := range m.logStreams {
+		p := config.SessionPod{Context: st.target.context, Namespace: st.target.namespace, Pod: st.target.pod}
+		if !seen[p] {
+			seen[p] = true
+			s.Pods = append(s.Pods, p)
+		}
+	}
+	sort.Slice(s.Pods, func(i, j int) bool {
+		a, b := s.Pods[i], s.Pods[j]
+		if a.Context != b.Context {
+			return a.Context < b.Context
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Pod < b.Pod
+	})
+	m.quitSession = s
+}
@@ -0,0 +1,110 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/share"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// SetSharer installs the configured paste service; with none, the log
+// pane's "S" action is disabled.
+func (m *MainPage) SetSharer(sharer *share.Sharer) {
+	m.sharer = sharer
+	m.keys.Share.SetEnabled(sharer != nil)
+}
+
+// shareLogLines asks before posting the log pane's selected lines (or, in
+// structured mode, its cursor line) to the paste service, headed by which
+// pods and contexts they came from: once posted, anyone with the link can
+// read them.
+func (m *MainPage) shareLogLines() {
+	if m.sharer == nil {
+		return
+	}
+	pane := m.logPanes.Active()
+	text, n, ok := pane.Yank()
+	if !ok {
+		m.reportError("", "Nothing to share: press v to select lines first")
+		return
+	}
+	var sources []msgs.LogLevelTarget
+	if target, ok := pane.ActiveSource(); ok {
+		sources = append(sources, target)
+	} else {
+		for _, key := range pane.Keys() {
+			if target, ok := pane.Source(key); ok {
+				sources = append(sources, target)
+			}
+		}
+	}
+	title, header := shareHeader(sources, n, time.Now())
+	message := fmt.Sprintf("Post %d log line(s) to %s? Anyone with the link will be able to read them.", n, m.sharer.Host())
+	m.openConfirm("Share logs", message, func() tea.Cmd {
+		m.actionStatus = fmt.Sprintf("Sharing %d log line(s) to %s…", n, m.sharer.Host())
+		// The upload is cancelled with its lines' context when that's
+		// unloaded, or with every other call on quit.
+		ctx := m.ctx
+		if kubeContext, ok := shareContext(sources); ok {
+			ctx = m.callCtx(kubeContext)
+		}
+		// What's posted is what was on screen: in demo mode, pseudonyms.
+		return cmds.ShareLogsCmd(ctx, m.sharer, m.anonymized(title), m.anonymized(header+text), n)
+	})
+}
+
+// shareContext is the one kube context every source is in, if there's one.
+func shareContext(sources []msgs.LogLevelTarget) (string, bool) {
+	if len(sources) == 0 {
+		return "", false
+	}
+	for _, s := range sources[1:] {
+		if s.Context != sources[0].Context {
+			return "", false
+		}
+	}
+	return sources[0].Context, true
+}
+
+// shareHeader is a shared excerpt's title and the header it starts with:
+// when it was shared and each source's pod, container, namespace and
+// context.
+func shareHeader(sources []msgs.LogLevelTarget, lines int, at time.Time) (title, header string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# ktails log excerpt: %d line(s), shared %s\n", lines, at.UTC().Format(time.RFC3339))
+	for _, s := range sources {
+		fmt.Fprintf(&b, "# %s/%s  namespace %s  context %s\n", s.Pod, s.Container, s.Namespace, s.Context)
+	}
+	b.WriteString("\n")
+
+	title = "ktails logs"
+	switch len(sources) {
+	case 0:
+	case 1:
+		title = fmt.Sprintf("ktails logs: %s/%s (%s, %s)", sources[0].Pod, sources[0].Container, sources[0].Namespace, sources[0].Context)
+	default:
+		title = fmt.Sprintf("ktails logs: %s/%s and %d more", sources[0].Pod, sources[0].Container, len(sources)-1)
+	}
+	return title, b.String()
+}
+
+// onLogsShared reports a finished share: the link goes on the clipboard,
+// and in the status bar until actionNoticeDuration passes.
+func (m *MainPage) onLogsShared(msg msgs.LogsSharedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.actionStatus = ""
+		m.reportError("", fmt.Sprintf("Sharing logs: %v", msg.Err))
+		return nil
+	}
+	m.actionGen++
+	m.actionStatus = fmt.Sprintf("✓ shared %d log line(s): %s (link copied)", msg.Lines, msg.URL)
+	gen := m.actionGen
+	return tea.Batch(tea.SetClipboard(msg.URL), tea.Tick(actionNoticeDuration, func(time.Time) tea.Msg {
+		return msgs.PodActionClearMsg{Generation: gen}
+	}))
+}
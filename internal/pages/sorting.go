@@ -0,0 +1,70 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// sortColumn is a column S can sort a table by, with the title the
+// status line names it by.
+type sortColumn struct{ key, title string }
+
+// sortColumns are the columns S sorts each table by, in the order it
+// cycles through them.
+var sortColumns = map[string][]sortColumn{
+	"Pods": {
+		{msgs.PodKeyName, "name"},
+		{msgs.PodKeyStatus, "status"},
+		{msgs.PodKeyRestarts, "restarts"},
+		{msgs.PodKeyAge, "age"},
+	},
+	"Deployments": {
+		{msgs.DeployKeyName, "name"},
+		{msgs.DeployKeyReplicas, "ready replicas"},
+		{msgs.DeployKeyAge, "age"},
+		{msgs.DeployKeyContext, "context"},
+	},
+}
+
+// cycleTableSort moves tab's table to its next sort (S): each column
+// ascending then descending, and after the last back to the default
+// order. The sort lives in AppState, so refreshes keep it.
+func (m *MainPage) cycleTableSort(tab string) {
+	cols := sortColumns[tab]
+	cur := m.appState.Sort(tab)
+	i := slices.IndexFunc(cols, func(c sortColumn) bool { return c.key == cur.Column })
+	var next state.SortSpec
+	switch {
+	case i < 0:
+		next = state.SortSpec{Column: cols[0].key}
+	case !cur.Desc:
+		next = state.SortSpec{Column: cur.Column, Desc: true}
+	case i+1 < len(cols):
+		next = state.SortSpec{Column: cols[i+1].key}
+	}
+	m.appState.SetSort(tab, next)
+
+	snapshot := m.appState.Snapshot()
+	switch tab {
+	case "Pods":
+		m.podList.SetSort(next.Column, next.Desc)
+		m.podList.SetRows(snapshot.Pods)
+	case "Deployments":
+		m.deploymentList.SetSort(next.Column, next.Desc)
+		m.deploymentList.SetRows(snapshot.Deployments)
+	}
+
+	if next.Column == "" {
+		m.actionStatus = fmt.Sprintf("%s: default order", tab)
+		return
+	}
+	order := "ascending"
+	if next.Desc {
+		order = "descending"
+	}
+	title := cols[slices.IndexFunc(cols, func(c sortColumn) bool { return c.key == next.Column })].title
+	m.actionStatus = fmt.Sprintf("%s sorted by %s, %s", tab, title, order)
+}
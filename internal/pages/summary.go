@@ -0,0 +1,73 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ktails/ktails/internal/k8s"
+)
+
+// maxSummaryErrors is how many of its last error lines a completed pod's
+// summary quotes.
+const maxSummaryErrors = 5
+
+// podCompletion returns how t's pod completed, per the Pods watch cache;
+// ok is false while it runs, or if it isn't cached.
+func (m *MainPage) podCompletion(t podLogTarget) (k8s.PodCompletion, bool) {
+	st, ok := m.podWatchers[t.context]
+	if !ok || st.cache == nil {
+		return k8s.PodCompletion{}, false
+	}
+	return st.cache.Completion(t.context, t.namespace, t.pod)
+}
+
+// summarizeCompleted closes off every ended source in context whose pod
+// has since reached Succeeded or Failed with a summary block: how long it
+// ran, its containers' exit codes, and its error lines. A stream usually
+// ends a moment before the pod's status catches up, so sources wait in
+// endedSources for the Pods watch to report the final phase.
+func (m *MainPage) summarizeCompleted(context string) {
+	for key, t := range m.endedSources {
+		if !m.logPanes.HasSource(key) {
+			delete(m.endedSources, key)
+			continue
+		}
+		if t.context != context {
+			continue
+		}
+		c, ok := m.podCompletion(t)
+		if !ok {
+			continue
+		}
+		delete(m.endedSources, key)
+		head, lines := m.completionSummary(key, t, c)
+		m.logPanes.AddSummary(key, head, lines)
+	}
+}
+
+// completionSummary renders source key's summary block.
+func (m *MainPage) completionSummary(key string, t podLogTarget, c k8s.PodCompletion) (head string, lines []string) {
+	head = fmt.Sprintf("%s completed: %s", t.pod, c.Phase)
+	if c.Duration > 0 {
+		head += " after " + c.Duration.Round(time.Second).String()
+	}
+
+	var exits []string
+	for _, e := range c.Exits {
+		exits = append(exits, fmt.Sprintf("%s %d (%s)", e.Container, e.ExitCode, e.Reason))
+	}
+	if len(exits) > 0 {
+		lines = append(lines, "exit codes: "+strings.Join(exits, ", "))
+	}
+
+	count, last := m.logPanes.ErrorLines(key, maxSummaryErrors)
+	if count == 0 {
+		return head, append(lines, "errors: none")
+	}
+	lines = append(lines, fmt.Sprintf("errors: %d line(s), the last %d:", count, len(last)))
+	for _, text := range last {
+		lines = append(lines, "  "+text)
+	}
+	return head, lines
+}
@@ -0,0 +1,141 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// eventSource is a log pane source a pane template opened for a
+// workload's events rather than a container's logs. It's re-listed on
+// every refresh tick; seen keeps the events already shown from being
+// repeated.
+type eventSource struct {
+	context, namespace, name string
+	seen                     map[string]bool
+}
+
+// SetPaneTemplates installs the configured pane templates; with none, the
+// Deployments tab's "o" action is disabled.
+func (m *MainPage) SetPaneTemplates(templates []config.PaneTemplate) {
+	m.paneTemplates = templates
+	m.keys.Template.SetEnabled(len(templates) > 0)
+}
+
+// openPaneTemplate opens the first pane template matching the Deployments
+// row under the cursor.
+func (m *MainPage) openPaneTemplate() tea.Cmd {
+	if len(m.paneTemplates) == 0 {
+		return nil
+	}
+	row := m.deploymentList.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	name, _ := row[msgs.DeployKeyName].(string)
+	namespace, _ := row[msgs.DeployKeyNamespace].(string)
+	ctxName, _ := row[msgs.DeployKeyContext].(string)
+	m.actionStatus = fmt.Sprintf("Opening the pane template for %s...", name)
+	return cmds.OpenPaneTemplateCmd(m.callCtx(ctxName), m.Client, m.paneTemplates, ctxName, namespace, name)
+}
+
+// onPaneTemplate points the log pane at what the template gathered: the
+// chosen containers of every pod, then the events source, replacing
+// whatever the pane showed.
+func (m *MainPage) onPaneTemplate(msg msgs.PaneTemplateMsg) tea.Cmd {
+	m.actionStatus = ""
+	if msg.Err != nil && msg.Template == nil {
+		m.reportError("", fmt.Sprintf("Pane template for %s: %v", msg.Deployment, msg.Err))
+		return nil
+	}
+	if msg.Template == nil {
+		m.reportError("", fmt.Sprintf("Pane template: no pane_templates entry matches deployment %s", msg.Deployment))
+		return nil
+	}
+	t := msg.Template
+	if len(msg.Pods) == 0 && !t.Events {
+		if len(t.Containers) == 0 {
+			m.reportError("", fmt.Sprintf("Pane template %s: deployment %s has no pods", t.Name, msg.Deployment))
+		} else {
+			m.reportError("", fmt.Sprintf("Pane template %s: no pod of %s runs %s", t.Name, msg.Deployment, strings.Join(t.Containers, ", ")))
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if len(msg.Pods) > 0 {
+		cmd = m.reconcilePodLogs(msg.Pods)
+	} else {
+		m.emptyLogPane()
+	}
+	if t.Events {
+		key := "events/" + msg.Context + "/" + msg.Namespace + "/" + msg.Deployment
+		m.logPanes.AddSource(key, msg.Deployment, msg.Namespace, msg.Context, "events")
+		m.eventSources[key] = &eventSource{
+			context:   msg.Context,
+			namespace: msg.Namespace,
+			name:      msg.Deployment,
+			seen:      make(map[string]bool),
+		}
+		m.appendEvents(key, msg.Events, msg.Err)
+
+		m.closeDetail()
+		m.showLogs = true
+		m.logsFocused = true
+		m.applyContentSizes()
+		m.updateFocusStates()
+	}
+	m.actionStatus = fmt.Sprintf("Opened pane template %s for %s", t.Name, msg.Deployment)
+	return cmd
+}
+
+// refreshEventSources re-lists the events of every open events source.
+func (m *MainPage) refreshEventSources() tea.Cmd {
+	var batch []tea.Cmd
+	for key, src := range m.eventSources {
+		batch = append(batch, cmds.LoadWorkloadEventsCmd(m.callCtx(src.context), m.Client, key, src.context, src.namespace, src.name))
+	}
+	return tea.Batch(batch...)
+}
+
+// appendEvents adds the events source key hasn't shown yet, as one line
+// each: "Warning BackOff Pod/api-7d9f8-x2k4q: Back-off restarting… (x4)".
+func (m *MainPage) appendEvents(key string, events []k8s.EventInfo, err error) {
+	src, ok := m.eventSources[key]
+	if !ok {
+		return
+	}
+	if err != nil {
+		m.logPanes.AddNotice(key, fmt.Sprintf("failed to list events: %v", err))
+		return
+	}
+	for _, ev := range events {
+		id := fmt.Sprintf("%s|%s|%s|%d|%d", ev.Object, ev.Reason, ev.Message, ev.Count, ev.Time.Unix())
+		if src.seen[id] {
+			continue
+		}
+		src.seen[id] = true
+		line := fmt.Sprintf("%s %s %s: %s", ev.Type, ev.Reason, ev.Object, ev.Message)
+		if ev.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", ev.Count)
+		}
+		m.logPanes.AppendLineAt(key, line, ev.Time)
+	}
+}
+
+// closeEventSources removes every events source from the active log pane.
+func (m *MainPage) closeEventSources() {
+	for key := range m.eventSources {
+		if !m.logPanes.Active().HasSource(key) {
+			continue
+		}
+		m.logPanes.RemoveSource(key)
+		delete(m.eventSources, key)
+	}
+}
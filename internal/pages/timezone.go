@@ -0,0 +1,47 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// promptLogTimezone asks which time zone the log pane shows its timestamps
+// in — a cluster's own, say, while the rest of the screen stays local.
+func (m *MainPage) promptLogTimezone() tea.Cmd {
+	initial := ""
+	if loc := m.logPanes.Location(); loc != time.Local {
+		initial = loc.String()
+	}
+	label := "IANA time zone for the pane's timestamps, e.g. Asia/Tokyo or UTC (empty for local time):"
+	return m.openOptionalPrompt("Log time zone", label, initial, func(name string) tea.Cmd {
+		m.setLogTimezone(name)
+		return nil
+	})
+}
+
+// setLogTimezone shows the log pane's timestamps in the zone called name,
+// or in local time when name is empty. Picking a zone turns timestamps on,
+// since that's what it's for.
+func (m *MainPage) setLogTimezone(name string) {
+	if name == "" {
+		m.logPanes.SetLocation(nil)
+		return
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		m.reportError("", fmt.Sprintf("Log time zone: unknown zone %q", name))
+		return
+	}
+	m.logPanes.SetLocation(loc)
+	m.logPanes.SetTimestamps(true)
+}
+
+// cycleLogStampFormat steps the log pane's timestamps between local time,
+// UTC and relative, turning them on if they're off.
+func (m *MainPage) cycleLogStampFormat() {
+	format := m.logPanes.CycleStampFormat()
+	m.logPanes.SetTimestamps(true)
+	m.actionStatus = "Log timestamps: " + format.String()
+}
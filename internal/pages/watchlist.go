@@ -0,0 +1,126 @@
+package pages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// toggleWatch (w) pins the Pods or Deployments row under the cursor to the
+// watchlist, or unpins it if it's already there; on the watch tab it unpins
+// the entry under the cursor. The watchlist is saved with the rest of the
+// state on quit.
+func (m *MainPage) toggleWatch() tea.Cmd {
+	var item config.WatchedResource
+	switch m.tabs[m.activeTab] {
+	case "Pods":
+		row := m.podList.SelectedRow()
+		if row == nil {
+			return nil
+		}
+		item.Kind = k8s.WatchPod
+		item.Name, _ = row[msgs.PodKeyName].(string)
+		item.Namespace, _ = row[msgs.PodKeyNamespace].(string)
+		item.Context, _ = row[msgs.PodKeyContext].(string)
+	case "Deployments":
+		row := m.deploymentList.SelectedRow()
+		if row == nil {
+			return nil
+		}
+		item.Kind = k8s.WatchDeployment
+		item.Name, _ = row[msgs.DeployKeyName].(string)
+		item.Namespace, _ = row[msgs.DeployKeyNamespace].(string)
+		item.Context, _ = row[msgs.DeployKeyContext].(string)
+	case "watch":
+		e, ok := m.watchList.Selected()
+		if !ok {
+			return nil
+		}
+		item = config.WatchedResource{Kind: e.Kind, Context: e.Context, Namespace: e.Namespace, Name: e.Name}
+	default:
+		return nil
+	}
+
+	label := fmt.Sprintf("%s %s/%s (%s)", item.Kind, item.Namespace, item.Name, item.Context)
+	if i := slices.Index(m.watchlist, item); i >= 0 {
+		m.watchlist = slices.Delete(m.watchlist, i, i+1)
+		m.syncWatchlist()
+		m.actionStatus = "Unwatched " + label
+		return nil
+	}
+	m.watchlist = append(m.watchlist, item)
+	m.syncWatchlist()
+	m.actionStatus = fmt.Sprintf("Watching %s · %d on the watch tab", label, len(m.watchlist))
+	return nil
+}
+
+// syncWatchlist hands the watchlist to the watch tab's table.
+func (m *MainPage) syncWatchlist() {
+	items := make([]k8s.WatchStatus, 0, len(m.watchlist))
+	for _, w := range m.watchlist {
+		items = append(items, k8s.WatchStatus{Kind: w.Kind, Context: w.Context, Namespace: w.Namespace, Name: w.Name})
+	}
+	m.watchList.SetWatchlist(items)
+}
+
+// loadWatchlistIfActive fetches the status of everything watched when the
+// watch tab is the one showing, as loadTopIfActive does for usage — in
+// whichever context each is, selected or not. Entries in a context near
+// its request budget keep their last status.
+func (m *MainPage) loadWatchlistIfActive() tea.Cmd {
+	if m.tabs[m.activeTab] != "watch" || !m.appStateLoaded {
+		return nil
+	}
+	contexts := make(map[string]string)
+	for _, w := range m.watchlist {
+		contexts[w.Context] = ""
+	}
+	allowed, _ := m.withinBudget(contexts)
+	var cmdSequence []tea.Cmd
+	for _, w := range m.watchlist {
+		if _, ok := allowed[w.Context]; ok {
+			cmdSequence = append(cmdSequence, cmds.LoadWatchStatusCmd(m.callCtx(w.Context), m.Client, w.Context, w.Kind, w.Namespace, w.Name))
+		}
+	}
+	return tea.Batch(cmdSequence...)
+}
+
+// onWatchStatus records a watched resource's fetched status.
+func (m *MainPage) onWatchStatus(msg msgs.WatchStatusMsg) {
+	if msg.Err != nil {
+		m.watchList.SetError(msg.Kind, msg.Context, msg.Namespace, msg.Name, k8s.ExplainError(msg.Err))
+		return
+	}
+	m.watchList.SetStatus(msg.Status)
+}
+
+// attachWatched (l, on the watch tab) tails the logs of the entry under the
+// cursor — the pod, or every pod of the deployment — in the Log area, as l
+// does for checked Pods rows, whether or not its context is selected.
+func (m *MainPage) attachWatched() tea.Cmd {
+	e, ok := m.watchList.Selected()
+	if !ok {
+		return nil
+	}
+	if len(e.Pods) == 0 {
+		m.reportError(e.Context, fmt.Sprintf("Logs: %s %s/%s has no pods to tail (%s)", e.Kind, e.Namespace, e.Name, e.Status))
+		return nil
+	}
+	rows := make([]msgs.RowData, 0, len(e.Pods))
+	for _, pod := range e.Pods {
+		rows = append(rows, msgs.RowData{
+			msgs.PodKeyName:       pod.Name,
+			msgs.PodKeyNamespace:  e.Namespace,
+			msgs.PodKeyContext:    e.Context,
+			msgs.PodKeyContainers: strings.Join(pod.Containers, ","),
+		})
+	}
+	return m.reconcilePodLogs(rows)
+}
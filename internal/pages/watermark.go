@@ -0,0 +1,106 @@
+package pages
+
+import (
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+// SetWatermarks installs the configured context watermarks; panes bound
+// to a matching context carry its badge in their top-right corner.
+func (m *MainPage) SetWatermarks(watermarks []config.Watermark) {
+	m.watermarks = watermarks
+}
+
+// watermarkBadges renders the badges of the watermarks matching contexts,
+// each distinct label once, in context order — "" when none match.
+func (m *MainPage) watermarkBadges(contexts []string) string {
+	if len(m.watermarks) == 0 {
+		return ""
+	}
+	slices.Sort(contexts)
+	var badges []string
+	seen := make(map[string]bool)
+	for _, context := range contexts {
+		for _, w := range m.watermarks {
+			if !w.Matches(context) {
+				continue
+			}
+			label := w.Label(context)
+			if !seen[label] {
+				seen[label] = true
+				bg := m.theme.Palette.Red
+				if w.Color != "" {
+					bg = lipgloss.Color(w.Color)
+				}
+				badges = append(badges, lipgloss.NewStyle().
+					Foreground(m.theme.Palette.Base).
+					Background(bg).
+					Bold(true).
+					Padding(0, 1).
+					Render(label))
+			}
+			break
+		}
+	}
+	return strings.Join(badges, " ")
+}
+
+// tableContexts are the contexts the tables show: the selected ones.
+func tableContexts(selected map[string]string) []string {
+	contexts := make([]string, 0, len(selected))
+	for context := range selected {
+		contexts = append(contexts, context)
+	}
+	return contexts
+}
+
+// bottomPaneContexts are the contexts the Detail or Logs pane is showing.
+func (m *MainPage) bottomPaneContexts() []string {
+	if m.showDetail {
+		return []string{m.deploymentDetail.Context()}
+	}
+	var contexts []string
+	for _, st := range m.logStreams {
+		if !slices.Contains(contexts, st.target.context) {
+			contexts = append(contexts, st.target.context)
+		}
+	}
+	for _, src := range m.eventSources {
+		if !slices.Contains(contexts, src.context) {
+			contexts = append(contexts, src.context)
+		}
+	}
+	return contexts
+}
+
+// withWatermark renders a pane header width cells wide with badge at its
+// right end, giving the header what's left.
+func withWatermark(header func(width int) string, width int, badge string) string {
+	bw := lipgloss.Width(badge)
+	if badge == "" || bw+1 >= width {
+		return header(width)
+	}
+	h := header(width - bw - 1)
+	return h + strings.Repeat(" ", width-bw-lipgloss.Width(h)) + badge
+}
+
+// stampWatermark overwrites view's line y from column x with badge,
+// keeping the rest of the line (and its styling) intact.
+func stampWatermark(view string, x, y int, badge string) string {
+	lines := strings.Split(view, "\n")
+	if badge == "" || x < 0 || y >= len(lines) {
+		return view
+	}
+	line := lines[y]
+	bw := lipgloss.Width(badge)
+	if pad := x + bw - ansi.StringWidth(line); pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	lines[y] = ansi.Truncate(line, x, "") + badge + ansi.TruncateLeft(line, x+bw, "")
+	return strings.Join(lines, "\n")
+}
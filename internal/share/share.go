@@ -0,0 +1,168 @@
+// Package share posts log excerpts to the paste service the config names
+// (see config.Share) — a GitHub gist or any endpoint taking an HTTP POST —
+// and returns the paste's URL, for pasting into an incident channel.
+package share
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+// maxResponse bounds how much of a paste service's answer is read: a URL,
+// or a gist's JSON, is far less.
+const maxResponse = 1 << 20
+
+// requestTimeout bounds one paste, on top of the caller's context.
+const requestTimeout = 30 * time.Second
+
+// Sharer posts to one configured paste service. It's safe for concurrent
+// use.
+type Sharer struct {
+	cfg    config.Share
+	url    string
+	token  string
+	client *http.Client
+}
+
+// New returns a Sharer for cfg, nil when sharing is off. A gist needs its
+// token set in the environment; an error says which variable is missing,
+// so it can be reported as a config problem at startup.
+func New(cfg config.Share) (*Sharer, error) {
+	s := &Sharer{cfg: cfg, url: cfg.URL, client: &http.Client{Timeout: requestTimeout}}
+	switch cfg.Kind {
+	case "":
+		return nil, nil
+	case "gist":
+		if s.url == "" {
+			s.url = config.DefaultGistURL
+		}
+		env := cfg.TokenEnv
+		if env == "" {
+			env = "GITHUB_TOKEN"
+		}
+		s.token = os.Getenv(env)
+		if s.token == "" {
+			return nil, fmt.Errorf("share: gist needs a token in $%s", env)
+		}
+	case "http":
+	default:
+		return nil, fmt.Errorf("share: invalid kind %q", cfg.Kind)
+	}
+	return s, nil
+}
+
+// Host is where pastes go, for asking before sending anything there.
+func (s *Sharer) Host() string {
+	if u, err := url.Parse(s.url); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return s.url
+}
+
+// Share posts text as a paste and returns its URL. title is a gist's
+// description; an http paste is text alone.
+func (s *Sharer) Share(ctx context.Context, title, text string) (string, error) {
+	if s.cfg.Kind == "gist" {
+		return s.gist(ctx, title, text)
+	}
+	return s.post(ctx, text)
+}
+
+func (s *Sharer) gist(ctx context.Context, title, text string) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"description": title,
+		"public":      s.cfg.Public,
+		"files": map[string]any{
+			"ktails.log": map[string]string{"content": text},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode gist: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build gist request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, data, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	var created struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(data, &created); err != nil || created.HTMLURL == "" {
+		return "", fmt.Errorf("gist created (%s) but its URL is missing from the response", resp.Status)
+	}
+	return created.HTMLURL, nil
+}
+
+func (s *Sharer) post(ctx context.Context, text string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, strings.NewReader(text))
+	if err != nil {
+		return "", fmt.Errorf("failed to build paste request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	for name, value := range s.cfg.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	resp, data, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	var pasted string
+	switch {
+	case s.cfg.URLField != "":
+		var fields map[string]any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return "", fmt.Errorf("paste response isn't JSON: %w", err)
+		}
+		pasted, _ = fields[s.cfg.URLField].(string)
+	case resp.Header.Get("Location") != "":
+		pasted = resp.Header.Get("Location")
+	default:
+		pasted = strings.TrimSpace(string(data))
+	}
+	if u, err := url.Parse(pasted); err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("paste response has no URL: %q", truncate(pasted, 80))
+	}
+	return pasted, nil
+}
+
+// do sends req and reads the answer, an error for anything but a 2xx.
+func (s *Sharer) do(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reach %s: %w", s.Host(), err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponse))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the answer from %s: %w", s.Host(), err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, nil, fmt.Errorf("%s answered %s: %s", s.Host(), resp.Status, truncate(strings.TrimSpace(string(data)), 200))
+	}
+	return resp, data, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,86 @@
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ktails/ktails/internal/config"
+)
+
+func TestShare_GistPostsTheLinesWithTheToken(t *testing.T) {
+	var got struct {
+		Description string `json:"description"`
+		Public      bool   `json:"public"`
+		Files       map[string]struct {
+			Content string `json:"content"`
+		} `json:"files"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding the gist: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"html_url": "https://gist.example/abc"}`)
+	}))
+	defer srv.Close()
+	t.Setenv("PASTE_TOKEN", "s3cret")
+
+	s, err := New(config.Share{Kind: "gist", URL: srv.URL, TokenEnv: "PASTE_TOKEN"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	link, err := s.Share(context.Background(), "api-0 errors", "ERROR boom")
+	if err != nil || link != "https://gist.example/abc" {
+		t.Fatalf("Share = %q, %v", link, err)
+	}
+	if got.Description != "api-0 errors" || got.Public || got.Files["ktails.log"].Content != "ERROR boom" {
+		t.Errorf("gist posted = %+v", got)
+	}
+}
+
+func TestShare_HTTPTakesTheURLFromTheAnswer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Header.Get("X-Api-Key") != "k3y":
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		case r.URL.Path == "/json":
+			io.WriteString(w, `{"link": "https://paste.example/`+strings.ToLower(string(body))+`"}`)
+		default:
+			io.WriteString(w, "https://paste.example/plain\n")
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("PASTE_KEY", "k3y")
+
+	s, _ := New(config.Share{Kind: "http", URL: srv.URL + "/json", Headers: map[string]string{"X-Api-Key": "${PASTE_KEY}"}, URLField: "link"})
+	if link, err := s.Share(context.Background(), "", "ABC"); err != nil || link != "https://paste.example/abc" {
+		t.Errorf("JSON answer: Share = %q, %v", link, err)
+	}
+	s, _ = New(config.Share{Kind: "http", URL: srv.URL, Headers: map[string]string{"X-Api-Key": "${PASTE_KEY}"}})
+	if link, err := s.Share(context.Background(), "", "x"); err != nil || link != "https://paste.example/plain" {
+		t.Errorf("plain answer: Share = %q, %v", link, err)
+	}
+	s, _ = New(config.Share{Kind: "http", URL: srv.URL})
+	if _, err := s.Share(context.Background(), "", "x"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected the 401 reported, got %v", err)
+	}
+}
+
+func TestNew_GistWithoutTokenFails(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	if _, err := New(config.Share{Kind: "gist"}); err == nil || !strings.Contains(err.Error(), "$GITHUB_TOKEN") {
+		t.Fatalf("expected the missing variable named, got %v", err)
+	}
+	if s, err := New(config.Share{}); s != nil || err != nil {
+		t.Errorf("New with sharing off = %v, %v; want nil, nil", s, err)
+	}
+}
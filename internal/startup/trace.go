@@ -0,0 +1,113 @@
+// Package startup times ktails' way up for --startup-trace: loading the
+// config, parsing the kubeconfigs, the first frame, and for each context
+// when it was selected, reached and first had data. Each step is logged as
+// it happens and the whole trace summarized on exit, so a slow start can be
+// reported with numbers rather than "it hangs for a while".
+//
+// A nil *Trace is a valid, disabled trace: every method is a no-op, so
+// callers don't need to check whether tracing is on.
+package startup
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Trace records the first time each step happened.
+type Trace struct {
+	mu    sync.Mutex
+	start time.Time
+	steps []step
+	now   func() time.Time
+}
+
+// step is one recorded step; context is "" for the app-wide ones.
+type step struct {
+	name    string
+	context string
+	at      time.Duration
+}
+
+// New starts a trace, timing every step from now.
+func New() *Trace {
+	return &Trace{start: time.Now(), now: time.Now}
+}
+
+// Mark records an app-wide step, the first time it happens.
+func (t *Trace) Mark(name string) {
+	t.MarkContext("", name)
+}
+
+// MarkContext records a step for context, the first time it happens.
+func (t *Trace) MarkContext(context, name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slices.ContainsFunc(t.steps, func(s step) bool { return s.name == name && s.context == context }) {
+		return
+	}
+	s := step{name: name, context: context, at: t.now().Sub(t.start)}
+	t.steps = append(t.steps, s)
+	log.Printf("startup: %s at %s", s.label(), s.at.Round(time.Millisecond))
+}
+
+// Summary renders the trace for printing on exit: app-wide steps, then
+// each context's, each with its time since launch and a context's also
+// since it was selected.
+func (t *Trace) Summary() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	steps := slices.Clone(t.steps)
+	slices.SortStableFunc(steps, func(a, b step) int {
+		if a.context != b.context {
+			return strings.Compare(a.context, b.context)
+		}
+		return int(a.at - b.at)
+	})
+	selected := make(map[string]time.Duration)
+	for _, s := range steps {
+		if s.context != "" && s.name == StepSelected {
+			selected[s.context] = s.at
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "Startup trace (time since launch):")
+	for _, s := range steps {
+		fmt.Fprintf(&b, "  %-40s %8s", s.label(), s.at.Round(time.Millisecond))
+		if at, ok := selected[s.context]; ok && s.name != StepSelected {
+			fmt.Fprintf(&b, "  (+%s after selected)", (s.at - at).Round(time.Millisecond))
+		}
+		fmt.Fprintln(&b)
+	}
+	return b.String()
+}
+
+func (s step) label() string {
+	if s.context == "" {
+		return s.name
+	}
+	return s.context + ": " + s.name
+}
+
+// The steps traced.
+const (
+	StepConfig      = "config loaded"
+	StepKubeconfig  = "kubeconfig parsed"
+	StepFirstFrame  = "first frame"
+	StepSelected    = "selected"
+	StepConnected   = "connected"
+	StepUnreachable = "unreachable"
+	StepFirstData   = "first data"
+)
@@ -0,0 +1,46 @@
+package startup
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrace_KeepsFirstMarkAndSummarizesPerContext(t *testing.T) {
+	clock := time.Unix(0, 0)
+	tr := &Trace{start: clock, now: func() time.Time { return clock }}
+	advance := func(d time.Duration) { clock = clock.Add(d) }
+
+	advance(5 * time.Millisecond)
+	tr.Mark(StepConfig)
+	advance(40 * time.Millisecond)
+	tr.Mark(StepKubeconfig)
+	tr.MarkContext("prod", StepSelected)
+	advance(300 * time.Millisecond)
+	tr.MarkContext("prod", StepConnected)
+	advance(200 * time.Millisecond)
+	tr.MarkContext("prod", StepFirstData)
+	advance(time.Second)
+	tr.MarkContext("prod", StepFirstData) // a later load doesn't count
+
+	got := tr.Summary()
+	for _, want := range []string{
+		"config loaded", "5ms",
+		"kubeconfig parsed", "45ms",
+		"prod: connected", "345ms  (+300ms after selected)",
+		"prod: first data", "545ms  (+500ms after selected)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "first data") != 1 {
+		t.Errorf("first data recorded more than once:\n%s", got)
+	}
+
+	var off *Trace
+	off.Mark(StepFirstFrame)
+	if s := off.Summary(); s != "" {
+		t.Errorf("nil trace summary = %q, want empty", s)
+	}
+}
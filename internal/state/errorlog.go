@@ -0,0 +1,105 @@
+package state
+
+import (
+	"slices"
+	"time"
+)
+
+// maxErrorLog bounds the error log; past it the oldest entries drop off.
+const maxErrorLog = 200
+
+// ErrorEntry is one error reported this session, as the error center lists
+// it. Context is "" for an error not tied to one (a bad key, a failed
+// port-forward).
+type ErrorEntry struct {
+	ID      int
+	At      time.Time
+	Context string
+	Text    string
+	// Seen is set once the error has been dismissed from the status bar
+	// or looked at in the error center; it stays in the log either way.
+	Seen bool
+}
+
+// LogError appends an error to the log, dropping the oldest past
+// maxErrorLog, and returns it.
+func (a *AppState) LogError(context, text string) ErrorEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.nextErrorID++
+	entry := ErrorEntry{ID: a.nextErrorID, At: time.Now(), Context: context, Text: text}
+	a.errorLog = append(a.errorLog, entry)
+	if n := len(a.errorLog) - maxErrorLog; n > 0 {
+		a.errorLog = slices.Delete(a.errorLog, 0, n)
+	}
+	return entry
+}
+
+// ErrorLog returns a copy of the logged errors, newest first.
+func (a *AppState) ErrorLog() []ErrorEntry {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	out := slices.Clone(a.errorLog)
+	slices.Reverse(out)
+	return out
+}
+
+// LatestUnseenError returns the newest error not yet seen, if any.
+func (a *AppState) LatestUnseenError() (ErrorEntry, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if n := len(a.errorLog); n > 0 && !a.errorLog[n-1].Seen {
+		return a.errorLog[n-1], true
+	}
+	return ErrorEntry{}, false
+}
+
+// MarkErrorSeen marks one logged error seen; MarkErrorsSeen marks them all.
+func (a *AppState) MarkErrorSeen(id int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if i := a.errorIndex(id); i >= 0 {
+		a.errorLog[i].Seen = true
+	}
+}
+
+func (a *AppState) MarkErrorsSeen() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.errorLog {
+		a.errorLog[i].Seen = true
+	}
+}
+
+// DismissError removes one error from the log, reporting whether it was
+// there.
+func (a *AppState) DismissError(id int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i := a.errorIndex(id)
+	if i < 0 {
+		return false
+	}
+	a.errorLog = slices.Delete(a.errorLog, i, i+1)
+	return true
+}
+
+// ClearErrorLog empties the log.
+func (a *AppState) ClearErrorLog() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.errorLog = nil
+}
+
+// errorIndex finds a logged error by ID, -1 if it's gone.
+// Must be called with lock held
+func (a *AppState) errorIndex(id int) int {
+	return slices.IndexFunc(a.errorLog, func(e ErrorEntry) bool { return e.ID == id })
+}
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestErrorLog_BoundedNewestFirstWithDismissal(t *testing.T) {
+	a := NewAppState()
+	for i := range maxErrorLog + 5 {
+		a.LogError("prod", fmt.Sprintf("error %d", i))
+	}
+	log := a.ErrorLog()
+	if len(log) != maxErrorLog {
+		t.Fatalf("log holds %d entries, want %d", len(log), maxErrorLog)
+	}
+	if got, want := log[0].Text, fmt.Sprintf("error %d", maxErrorLog+4); got != want {
+		t.Errorf("newest entry = %q, want %q", got, want)
+	}
+	if got, want := log[len(log)-1].Text, "error 5"; got != want {
+		t.Errorf("oldest entry = %q, want %q (the first five dropped)", got, want)
+	}
+
+	latest, ok := a.LatestUnseenError()
+	if !ok || latest.ID != log[0].ID {
+		t.Fatalf("LatestUnseenError = %+v, %v; want the newest entry", latest, ok)
+	}
+	a.MarkErrorSeen(latest.ID)
+	if _, ok := a.LatestUnseenError(); ok {
+		t.Error("LatestUnseenError still reports the entry just seen")
+	}
+	if got := a.ErrorLog(); len(got) != maxErrorLog || !got[0].Seen {
+		t.Error("seeing an entry should mark it, not drop it")
+	}
+
+	if !a.DismissError(latest.ID) {
+		t.Fatal("DismissError = false for a logged entry")
+	}
+	if a.DismissError(latest.ID) {
+		t.Error("DismissError = true for an entry already dismissed")
+	}
+	if got := a.ErrorLog(); len(got) != maxErrorLog-1 || got[0].ID == latest.ID {
+		t.Errorf("log after dismissal: %d entries, newest %d", len(got), got[0].ID)
+	}
+
+	a.ClearErrorLog()
+	if got := a.ErrorLog(); len(got) != 0 {
+		t.Errorf("log after ClearErrorLog holds %d entries", len(got))
+	}
+}
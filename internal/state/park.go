@@ -0,0 +1,61 @@
+package state
+
+// ParkContext deselects context without unloading it: it drops out of
+// SelectedContexts, and so out of every snapshot, but its rows, loading
+// state and errors stay and keep being updated, so UnparkContext can bring
+// it back as it was. RemoveContext unloads it for good. It reports whether
+// context was selected.
+func (a *AppState) ParkContext(context string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	namespace, ok := a.SelectedContexts[context]
+	if !ok {
+		return false
+	}
+	delete(a.SelectedContexts, context)
+	a.parked[context] = namespace
+	a.markAllDirty()
+	return true
+}
+
+// UnparkContext selects a parked context again, watching namespace as
+// before; it reports false if context isn't parked.
+func (a *AppState) UnparkContext(context string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	namespace, ok := a.parked[context]
+	if !ok {
+		return false
+	}
+	delete(a.parked, context)
+	a.SelectedContexts[context] = namespace
+	a.markAllDirty()
+	return true
+}
+
+// WatchedNamespace returns the namespace context is watched in ("" for
+// all), whether it's selected or parked; ok is false for neither.
+func (a *AppState) WatchedNamespace(context string) (namespace string, ok bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if namespace, ok = a.SelectedContexts[context]; ok {
+		return namespace, true
+	}
+	namespace, ok = a.parked[context]
+	return namespace, ok
+}
+
+// withoutParked copies m without the parked contexts' entries.
+// Must be called with lock held
+func withoutParked[V any](m map[string]V, parked map[string]string) map[string]V {
+	out := make(map[string]V, len(m))
+	for context, v := range m {
+		if _, ok := parked[context]; !ok {
+			out[context] = v
+		}
+	}
+	return out
+}
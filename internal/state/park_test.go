@@ -0,0 +1,54 @@
+package state
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+func TestParkContext_HidesAndRestoresState(t *testing.T) {
+	a := NewAppState()
+	a.AddContext("prod", "")
+	a.AddContext("dev", "team-a")
+	a.SetPods("prod", []msgs.RowData{podRow("prod", "api", "0", "1h")})
+	a.SetPods("dev", []msgs.RowData{podRow("dev", "db", "0", "1h")})
+	a.SetError("dev", "boom")
+
+	if !a.ParkContext("dev") {
+		t.Fatal("ParkContext(dev) = false for a selected context")
+	}
+	s := a.Snapshot()
+	if _, ok := s.SelectedContexts["dev"]; ok {
+		t.Error("parked context still selected")
+	}
+	if _, ok := s.Errors["dev"]; ok {
+		t.Error("parked context's error still in the snapshot")
+	}
+	if got, want := podNames(s.Pods), []string{"api"}; !slices.Equal(got, want) {
+		t.Fatalf("pods while parked = %v, want %v", got, want)
+	}
+	if ns, ok := a.WatchedNamespace("dev"); !ok || ns != "team-a" {
+		t.Errorf("WatchedNamespace(dev) = %q, %v; want team-a, true", ns, ok)
+	}
+
+	// Its watches keep running while it's parked.
+	a.SetPods("dev", []msgs.RowData{podRow("dev", "db", "0", "1h"), podRow("dev", "cache", "0", "2m")})
+
+	if !a.UnparkContext("dev") {
+		t.Fatal("UnparkContext(dev) = false for a parked context")
+	}
+	s = a.Snapshot()
+	if got, want := podNames(s.Pods), []string{"db", "cache", "api"}; !slices.Equal(got, want) {
+		t.Fatalf("pods after unparking = %v, want %v", got, want)
+	}
+	if s.SelectedContexts["dev"] != "team-a" || s.Errors["dev"] != "boom" {
+		t.Errorf("unparked context lost its namespace or error: %v %v", s.SelectedContexts, s.Errors)
+	}
+
+	a.ParkContext("dev")
+	a.RemoveContext("dev")
+	if a.UnparkContext("dev") {
+		t.Error("UnparkContext brought back a removed context")
+	}
+}
@@ -0,0 +1,114 @@
+package state
+
+import (
+	"strconv"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// restartTrack is one pod's restart count across refreshes: base is the
+// count as of the last refresh, seen the latest, and rise the restarts
+// counted over the run of refreshes that each saw it climb — a crash loop
+// keeps adding to it, a pod left alone for a whole refresh drops it.
+type restartTrack struct {
+	base int32
+	seen int32
+	rise int32
+}
+
+// spike is the pod's restarts since before its current run of climbing.
+func (t restartTrack) spike() int32 {
+	return t.rise + max(0, t.seen-t.base)
+}
+
+// trackRestarts records context's pods' restart counts (must be called
+// with lock held) and marks each row with its spike, if any, under
+// msgs.PodKeyRestartRise. A pod seen for the first time has none.
+func (a *AppState) trackRestarts(context string, rows []msgs.RowData) {
+	before := a.restarts[context]
+	tracks := make(map[string]restartTrack, len(rows))
+	for _, row := range rows {
+		key := restartKey(row)
+		n := rowRestarts(row)
+		t, ok := before[key]
+		switch {
+		case !ok:
+			t = restartTrack{base: n}
+		case n < t.base:
+			// Recreated under the same name: a fresh count.
+			t = restartTrack{base: n}
+		}
+		t.seen = n
+		tracks[key] = t
+		if spike := t.spike(); spike > 0 {
+			row[msgs.PodKeyRestartRise] = spike
+		} else {
+			delete(row, msgs.PodKeyRestartRise)
+		}
+	}
+	a.restarts[context] = tracks
+}
+
+// RollRestarts moves every pod's restart baseline on to its current count
+// — what the refresh tick does — ending the spike of each pod that didn't
+// restart since the previous one. It reports whether any row's spike
+// changed, so the Pods table needs the new rows.
+func (a *AppState) RollRestarts() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	changed := false
+	for context, tracks := range a.restarts {
+		before := make(map[string]int32, len(tracks))
+		for key, t := range tracks {
+			before[key] = t.spike()
+			if t.seen > t.base {
+				t.rise += t.seen - t.base
+			} else {
+				t.rise = 0
+			}
+			t.base = t.seen
+			tracks[key] = t
+		}
+		for _, row := range a.Pods[context] {
+			key := restartKey(row)
+			if spike := tracks[key].spike(); spike != before[key] {
+				changed = true
+				if spike > 0 {
+					row[msgs.PodKeyRestartRise] = spike
+				} else {
+					delete(row, msgs.PodKeyRestartRise)
+				}
+			}
+		}
+	}
+	if changed {
+		a.podsDirty = true
+		a.cachedAllPods = nil
+	}
+	return changed
+}
+
+// RestartSpikes counts the pods among rows whose restarts are climbing,
+// for the status bar.
+func RestartSpikes(rows []msgs.RowData) int {
+	n := 0
+	for _, row := range rows {
+		if spike, _ := row[msgs.PodKeyRestartRise].(int32); spike > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func restartKey(row msgs.RowData) string {
+	namespace, _ := row[msgs.PodKeyNamespace].(string)
+	name, _ := row[msgs.PodKeyName].(string)
+	return namespace + "/" + name
+}
+
+func rowRestarts(row msgs.RowData) int32 {
+	s, _ := row[msgs.PodKeyRestarts].(string)
+	n, _ := strconv.ParseInt(s, 10, 32)
+	return int32(n)
+}
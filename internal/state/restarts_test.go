@@ -0,0 +1,69 @@
+package state
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+func TestRestarts_SpikeUntilAQuietRefresh(t *testing.T) {
+	a := NewAppState()
+	a.AddContext("prod", "")
+	pods := func(restarts int) []msgs.RowData {
+		return []msgs.RowData{
+			{msgs.PodKeyNamespace: "shop", msgs.PodKeyName: "api-0", msgs.PodKeyRestarts: strconv.Itoa(restarts)},
+			{msgs.PodKeyNamespace: "shop", msgs.PodKeyName: "cart-0", msgs.PodKeyRestarts: "0"},
+		}
+	}
+	rise := func() int32 {
+		for _, row := range a.Snapshot().Pods {
+			if row[msgs.PodKeyName] == "api-0" {
+				n, _ := row[msgs.PodKeyRestartRise].(int32)
+				return n
+			}
+		}
+		t.Fatal("api-0 missing from the snapshot")
+		return 0
+	}
+
+	a.SetPods("prod", pods(7))
+	if got := rise(); got != 0 {
+		t.Errorf("rise on first sight = %d, want none", got)
+	}
+
+	a.SetPods("prod", pods(8))
+	if got := rise(); got != 1 {
+		t.Errorf("rise after one restart = %d, want 1", got)
+	}
+	if a.RollRestarts() || rise() != 1 {
+		t.Error("the spike should survive the refresh it climbed in unchanged")
+	}
+
+	// Climbing again the next interval adds up.
+	a.SetPods("prod", pods(9))
+	a.RollRestarts()
+	if got := rise(); got != 2 {
+		t.Errorf("rise over two climbing refreshes = %d, want 2", got)
+	}
+	if n := RestartSpikes(a.Snapshot().Pods); n != 1 {
+		t.Errorf("RestartSpikes = %d, want only api-0", n)
+	}
+
+	// A whole interval without a restart clears it.
+	if !a.RollRestarts() {
+		t.Error("RollRestarts = false when api-0's spike ended")
+	}
+	if got := rise(); got != 0 {
+		t.Errorf("rise after a quiet refresh = %d, want 0", got)
+	}
+	if a.RollRestarts() {
+		t.Error("RollRestarts = true with nothing changing")
+	}
+
+	// A pod recreated under the same name starts afresh.
+	a.SetPods("prod", pods(0))
+	if got := rise(); got != 0 {
+		t.Errorf("rise after the count reset = %d, want 0", got)
+	}
+}
@@ -0,0 +1,124 @@
+package state
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// SortSpec is the order a table's rows are listed in: by Column's value
+// (a row key), descending when Desc. The zero SortSpec keeps the default
+// order — context by context, each in the order its watch lists them.
+type SortSpec struct {
+	Column string
+	Desc   bool
+}
+
+// SetSort changes how the flattened rows of resource ("Pods",
+// "Deployments") are ordered. The order is applied whenever the rows are
+// flattened, so it holds across refreshes and watch updates.
+func (a *AppState) SetSort(resource string, spec SortSpec) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if spec.Column == "" {
+		delete(a.sorts, resource)
+	} else {
+		a.sorts[resource] = spec
+	}
+	switch resource {
+	case "Pods":
+		a.podsDirty = true
+		a.cachedAllPods = nil
+	case "Deployments":
+		a.deploymentsDirty = true
+		a.cachedAllDeployments = nil
+	}
+}
+
+// Sort returns how resource's rows are ordered.
+func (a *AppState) Sort(resource string) SortSpec {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.sorts[resource]
+}
+
+// sortRows orders rows by spec in place. The sort is stable, so rows
+// with equal values keep the default order among themselves.
+func sortRows(rows []msgs.RowData, spec SortSpec) {
+	if spec.Column == "" {
+		return
+	}
+	slices.SortStableFunc(rows, func(a, b msgs.RowData) int {
+		x, _ := a[spec.Column].(string)
+		y, _ := b[spec.Column].(string)
+		d := compareCells(spec.Column, x, y)
+		if spec.Desc {
+			return -d
+		}
+		return d
+	})
+}
+
+// compareCells compares two cells of column: restarts as numbers, ages as
+// the durations they spell, ready/desired replicas by how many are ready,
+// anything else as text. A value that doesn't parse sorts after those
+// that do.
+func compareCells(column, x, y string) int {
+	switch column {
+	case msgs.PodKeyRestarts:
+		return compareParsed(x, y, func(s string) (int, bool) {
+			n, err := strconv.Atoi(s)
+			return n, err == nil
+		})
+	case msgs.PodKeyAge:
+		return compareParsed(x, y, parseAge)
+	case msgs.DeployKeyReplicas:
+		return compareParsed(x, y, func(s string) (int, bool) {
+			ready, _, _ := strings.Cut(s, "/")
+			n, err := strconv.Atoi(ready)
+			return n, err == nil
+		})
+	}
+	return strings.Compare(x, y)
+}
+
+func compareParsed[T cmp.Ordered](x, y string, parse func(string) (T, bool)) int {
+	px, okx := parse(x)
+	py, oky := parse(y)
+	switch {
+	case okx && oky:
+		return cmp.Compare(px, py)
+	case okx:
+		return -1
+	case oky:
+		return 1
+	}
+	return strings.Compare(x, y)
+}
+
+// parseAge reads an age as k8s formats it ("45s", "5m3s", "2h10m",
+// "3d4h"); time.ParseDuration knows everything but the days.
+func parseAge(s string) (time.Duration, bool) {
+	var days time.Duration
+	if d, rest, ok := strings.Cut(s, "d"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, false
+		}
+		days, s = time.Duration(n)*24*time.Hour, rest
+		if s == "" {
+			return days, true
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, false
+	}
+	return days + d, true
+}
@@ -0,0 +1,49 @@
+package state
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+func podRow(context, name, restarts, age string) msgs.RowData {
+	return msgs.RowData{
+		msgs.PodKeyContext:  context,
+		msgs.PodKeyName:     name,
+		msgs.PodKeyRestarts: restarts,
+		msgs.PodKeyAge:      age,
+	}
+}
+
+func podNames(rows []msgs.RowData) []string {
+	var names []string
+	for _, row := range rows {
+		name, _ := row[msgs.PodKeyName].(string)
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestSnapshot_SortsPodsAcrossRefreshes(t *testing.T) {
+	a := NewAppState()
+	a.AddContext("prod", "")
+	a.AddContext("dev", "")
+	a.SetPods("prod", []msgs.RowData{podRow("prod", "api", "3", "2d1h"), podRow("prod", "web", "10", "45s")})
+	a.SetPods("dev", []msgs.RowData{podRow("dev", "db", "0", "5m3s")})
+
+	if got, want := podNames(a.Snapshot().Pods), []string{"db", "api", "web"}; !slices.Equal(got, want) {
+		t.Fatalf("default order = %v, want %v (contexts by name)", got, want)
+	}
+
+	a.SetSort("Pods", SortSpec{Column: msgs.PodKeyRestarts, Desc: true})
+	if got, want := podNames(a.Snapshot().Pods), []string{"web", "api", "db"}; !slices.Equal(got, want) {
+		t.Fatalf("restarts descending = %v, want %v (numeric, not text)", got, want)
+	}
+
+	a.SetSort("Pods", SortSpec{Column: msgs.PodKeyAge})
+	a.SetPods("dev", []msgs.RowData{podRow("dev", "db", "0", "5m3s"), podRow("dev", "cache", "1", "1h")})
+	if got, want := podNames(a.Snapshot().Pods), []string{"web", "db", "cache", "api"}; !slices.Equal(got, want) {
+		t.Fatalf("age ascending after a refresh = %v, want %v", got, want)
+	}
+}
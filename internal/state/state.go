@@ -2,6 +2,8 @@
 package state
 
 import (
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -13,6 +15,11 @@ type AppState struct {
 	// Selected contexts and their namespaces
 	SelectedContexts map[string]string // context -> namespace
 
+	// Namespaces picked per context when more than one was chosen. Those
+	// contexts are watched across all namespaces (SelectedContexts holds
+	// "") and their rows narrowed to the picked ones when flattened.
+	Namespaces map[string][]string // context -> namespaces
+
 	// Deployment data per context
 	Deployments map[string][]msgs.RowData // context -> rows
 
@@ -22,10 +29,16 @@ type AppState struct {
 	// Service data per context
 	Services map[string][]msgs.RowData // context -> rows
 
+	// StatefulSet and DaemonSet data per context
+	StatefulSets map[string][]msgs.RowData // context -> rows
+	DaemonSets   map[string][]msgs.RowData // context -> rows
+
 	// Loading states
-	LoadingDeployments map[string]bool // context -> isLoading
-	LoadingPods        map[string]bool // context -> isLoading
-	LoadingServices    map[string]bool // context -> isLoading
+	LoadingDeployments  map[string]bool // context -> isLoading
+	LoadingPods         map[string]bool // context -> isLoading
+	LoadingServices     map[string]bool // context -> isLoading
+	LoadingStatefulSets map[string]bool // context -> isLoading
+	LoadingDaemonSets   map[string]bool // context -> isLoading
 
 	// Errors
 	Errors map[string]string // context -> error message
@@ -44,13 +57,34 @@ type AppState struct {
 	// refetching on every Ctrl+W toggle or refresh.
 	serviceEndpointsFetchedNS map[string]string
 
-	// Cache for GetAllDeployments, GetAllPods, and GetAllServices
-	cachedAllDeployments []msgs.RowData
-	cachedAllPods        []msgs.RowData
-	cachedAllServices    []msgs.RowData
-	deploymentsDirty     bool
-	podsDirty            bool
-	servicesDirty        bool
+	// Cache for GetAllDeployments, GetAllPods, GetAllServices, ...
+	cachedAllDeployments  []msgs.RowData
+	cachedAllPods         []msgs.RowData
+	cachedAllServices     []msgs.RowData
+	cachedAllStatefulSets []msgs.RowData
+	cachedAllDaemonSets   []msgs.RowData
+	deploymentsDirty      bool
+	podsDirty             bool
+	servicesDirty         bool
+	statefulSetsDirty     bool
+	daemonSetsDirty       bool
+
+	// sorts holds the order chosen for a table's rows, by resource (see
+	// SetSort); resources without one keep the default order.
+	sorts map[string]SortSpec
+
+	// parked holds deselected contexts kept whole for an undo (see
+	// ParkContext), by their watch namespace.
+	parked map[string]string
+
+	// restarts tracks each context's pods' restart counts across refreshes
+	// (see RollRestarts), by namespace/name.
+	restarts map[string]map[string]restartTrack
+
+	// errorLog holds the errors reported this session, oldest first and
+	// at most maxErrorLog of them (see LogError); nextErrorID numbers them.
+	errorLog    []ErrorEntry
+	nextErrorID int
 
 	// Mutex to protect concurrent access
 	mu sync.RWMutex
@@ -59,28 +93,41 @@ type AppState struct {
 // Snapshot captures a read-only view of application state data.
 type Snapshot struct {
 	SelectedContexts map[string]string
-	LoadingStates    map[string]bool // Combined deployment + pod + service loading
+	Namespaces       map[string][]string
+	LoadingStates    map[string]bool // Combined loading across every resource type
 	LoadedContexts   map[string]bool // Contexts with at least one successful load
 	Errors           map[string]string
 	Deployments      []msgs.RowData
 	Pods             []msgs.RowData
 	Services         []msgs.RowData
+	StatefulSets     []msgs.RowData
+	DaemonSets       []msgs.RowData
 }
 
 func NewAppState() *AppState {
 	return &AppState{
-		SelectedContexts:   make(map[string]string),
-		Deployments:        make(map[string][]msgs.RowData),
-		Pods:               make(map[string][]msgs.RowData),
-		Services:           make(map[string][]msgs.RowData),
-		LoadingDeployments: make(map[string]bool),
-		LoadingPods:        make(map[string]bool),
-		LoadingServices:    make(map[string]bool),
-		Errors:             make(map[string]string),
-		LoadedContexts:     make(map[string]bool),
-		deploymentsDirty:   true,
-		podsDirty:          true,
-		servicesDirty:      true,
+		SelectedContexts:    make(map[string]string),
+		Namespaces:          make(map[string][]string),
+		Deployments:         make(map[string][]msgs.RowData),
+		Pods:                make(map[string][]msgs.RowData),
+		Services:            make(map[string][]msgs.RowData),
+		StatefulSets:        make(map[string][]msgs.RowData),
+		DaemonSets:          make(map[string][]msgs.RowData),
+		LoadingDeployments:  make(map[string]bool),
+		LoadingPods:         make(map[string]bool),
+		LoadingServices:     make(map[string]bool),
+		LoadingStatefulSets: make(map[string]bool),
+		LoadingDaemonSets:   make(map[string]bool),
+		Errors:              make(map[string]string),
+		LoadedContexts:      make(map[string]bool),
+		deploymentsDirty:    true,
+		podsDirty:           true,
+		servicesDirty:       true,
+		statefulSetsDirty:   true,
+		daemonSetsDirty:     true,
+		sorts:               make(map[string]SortSpec),
+		parked:              make(map[string]string),
+		restarts:            make(map[string]map[string]restartTrack),
 
 		serviceEndpoints:          make(map[string]map[string][]string),
 		serviceEndpointsFetchedNS: make(map[string]string),
@@ -99,6 +146,7 @@ func (a *AppState) AddContext(context, namespace string) {
 		delete(a.serviceEndpointsFetchedNS, context)
 	}
 	a.SelectedContexts[context] = namespace
+	delete(a.Namespaces, context)
 	// Initialize deployment, pod, and service state for this context
 	if _, exists := a.Deployments[context]; !exists {
 		a.Deployments[context] = []msgs.RowData{}
@@ -109,12 +157,49 @@ func (a *AppState) AddContext(context, namespace string) {
 	if _, exists := a.Services[context]; !exists {
 		a.Services[context] = []msgs.RowData{}
 	}
-	a.deploymentsDirty = true
-	a.podsDirty = true
-	a.servicesDirty = true
-	a.cachedAllDeployments = nil
-	a.cachedAllPods = nil
-	a.cachedAllServices = nil
+	if _, exists := a.StatefulSets[context]; !exists {
+		a.StatefulSets[context] = []msgs.RowData{}
+	}
+	if _, exists := a.DaemonSets[context]; !exists {
+		a.DaemonSets[context] = []msgs.RowData{}
+	}
+	a.markAllDirty()
+}
+
+// SetNamespaces changes the namespaces an already-selected context loads
+// from and returns the namespace its watches should now use: the only one
+// picked, or "" (all namespaces) when several are, the rows then narrowed to
+// the picked ones. Rows already loaded stay until the restarted watches
+// replace them.
+func (a *AppState) SetNamespaces(context string, namespaces []string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	watchNS := WatchNamespace(namespaces)
+	if prevNS, exists := a.SelectedContexts[context]; exists && prevNS != watchNS {
+		delete(a.serviceEndpoints, context)
+		delete(a.serviceEndpointsFetchedNS, context)
+	}
+	a.SelectedContexts[context] = watchNS
+	if len(namespaces) > 1 {
+		picked := make([]string, len(namespaces))
+		copy(picked, namespaces)
+		sort.Strings(picked)
+		a.Namespaces[context] = picked
+	} else {
+		delete(a.Namespaces, context)
+	}
+	a.markAllDirty()
+	return watchNS
+}
+
+// WatchNamespace returns the namespace to watch for a set of picked
+// namespaces: the only one, or "" (all namespaces) for several or none.
+func WatchNamespace(namespaces []string) string {
+	if len(namespaces) == 1 {
+		return namespaces[0]
+	}
+	return ""
 }
 
 // SetDeployments replaces deployment rows for a context
@@ -136,6 +221,7 @@ func (a *AppState) SetPods(context string, rows []msgs.RowData) {
 	defer a.mu.Unlock()
 
 	a.Pods[context] = cloneRows(rows)
+	a.trackRestarts(context, a.Pods[context])
 	a.LoadingPods[context] = false
 	// Don't delete errors here - only clear if both deployments AND pods succeed
 	a.podsDirty = true
@@ -162,6 +248,28 @@ func (a *AppState) SetServices(context string, rows []msgs.RowData) {
 	a.cachedAllServices = nil
 }
 
+// SetStatefulSets replaces statefulset rows for a context
+func (a *AppState) SetStatefulSets(context string, rows []msgs.RowData) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.StatefulSets[context] = cloneRows(rows)
+	a.LoadingStatefulSets[context] = false
+	a.statefulSetsDirty = true
+	a.cachedAllStatefulSets = nil
+}
+
+// SetDaemonSets replaces daemonset rows for a context
+func (a *AppState) SetDaemonSets(context string, rows []msgs.RowData) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.DaemonSets[context] = cloneRows(rows)
+	a.LoadingDaemonSets[context] = false
+	a.daemonSetsDirty = true
+	a.cachedAllDaemonSets = nil
+}
+
 // NeedsServiceEndpoints reports whether Endpoint IPs still need fetching for
 // this context's given namespace — false once fetched (or requested) for
 // that exact namespace, true again if the namespace has since changed.
@@ -253,6 +361,22 @@ func (a *AppState) SetLoadingServices(context string, loading bool) {
 	a.LoadingServices[context] = loading
 }
 
+// SetLoadingStatefulSets marks a context as loading statefulsets
+func (a *AppState) SetLoadingStatefulSets(context string, loading bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.LoadingStatefulSets[context] = loading
+}
+
+// SetLoadingDaemonSets marks a context as loading daemonsets
+func (a *AppState) SetLoadingDaemonSets(context string, loading bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.LoadingDaemonSets[context] = loading
+}
+
 // SetError stores an error for a context
 func (a *AppState) SetError(context string, err string) {
 	a.mu.Lock()
@@ -262,6 +386,8 @@ func (a *AppState) SetError(context string, err string) {
 	a.LoadingDeployments[context] = false
 	a.LoadingPods[context] = false
 	a.LoadingServices[context] = false
+	a.LoadingStatefulSets[context] = false
+	a.LoadingDaemonSets[context] = false
 }
 
 // GetAllDeployments returns all deployment rows from all selected contexts
@@ -293,16 +419,21 @@ func (a *AppState) Snapshot() Snapshot {
 	deploymentsClean := !a.deploymentsDirty && a.cachedAllDeployments != nil
 	podsClean := !a.podsDirty && a.cachedAllPods != nil
 	servicesClean := !a.servicesDirty && a.cachedAllServices != nil
+	statefulSetsClean := !a.statefulSetsDirty && a.cachedAllStatefulSets != nil
+	daemonSetsClean := !a.daemonSetsDirty && a.cachedAllDaemonSets != nil
 
-	if deploymentsClean && podsClean && servicesClean {
+	if deploymentsClean && podsClean && servicesClean && statefulSetsClean && daemonSetsClean {
 		snapshot := Snapshot{
 			SelectedContexts: copyStringMap(a.SelectedContexts),
+			Namespaces:       copyNamespacesMap(a.Namespaces),
 			LoadingStates:    a.combinedLoadingStates(),
-			LoadedContexts:   copyBoolMap(a.LoadedContexts),
-			Errors:           copyStringMap(a.Errors),
+			LoadedContexts:   withoutParked(a.LoadedContexts, a.parked),
+			Errors:           withoutParked(a.Errors, a.parked),
 			Deployments:      cloneRows(a.cachedAllDeployments),
 			Pods:             cloneRows(a.cachedAllPods),
 			Services:         cloneRows(a.cachedAllServices),
+			StatefulSets:     cloneRows(a.cachedAllStatefulSets),
+			DaemonSets:       cloneRows(a.cachedAllDaemonSets),
 		}
 		a.mu.RUnlock()
 		return snapshot
@@ -314,28 +445,43 @@ func (a *AppState) Snapshot() Snapshot {
 	defer a.mu.Unlock()
 
 	if a.deploymentsDirty || a.cachedAllDeployments == nil {
-		a.cachedAllDeployments = flattenRows(a.SelectedContexts, a.Deployments)
+		a.cachedAllDeployments = flattenRows(a.SelectedContexts, a.Namespaces, a.Deployments)
+		sortRows(a.cachedAllDeployments, a.sorts["Deployments"])
 		a.deploymentsDirty = false
 	}
 
 	if a.podsDirty || a.cachedAllPods == nil {
-		a.cachedAllPods = flattenRows(a.SelectedContexts, a.Pods)
+		a.cachedAllPods = flattenRows(a.SelectedContexts, a.Namespaces, a.Pods)
+		sortRows(a.cachedAllPods, a.sorts["Pods"])
 		a.podsDirty = false
 	}
 
 	if a.servicesDirty || a.cachedAllServices == nil {
-		a.cachedAllServices = flattenRows(a.SelectedContexts, a.Services)
+		a.cachedAllServices = flattenRows(a.SelectedContexts, a.Namespaces, a.Services)
 		a.servicesDirty = false
 	}
 
+	if a.statefulSetsDirty || a.cachedAllStatefulSets == nil {
+		a.cachedAllStatefulSets = flattenRows(a.SelectedContexts, a.Namespaces, a.StatefulSets)
+		a.statefulSetsDirty = false
+	}
+
+	if a.daemonSetsDirty || a.cachedAllDaemonSets == nil {
+		a.cachedAllDaemonSets = flattenRows(a.SelectedContexts, a.Namespaces, a.DaemonSets)
+		a.daemonSetsDirty = false
+	}
+
 	return Snapshot{
 		SelectedContexts: copyStringMap(a.SelectedContexts),
+		Namespaces:       copyNamespacesMap(a.Namespaces),
 		LoadingStates:    a.combinedLoadingStates(),
-		LoadedContexts:   copyBoolMap(a.LoadedContexts),
-		Errors:           copyStringMap(a.Errors),
+		LoadedContexts:   withoutParked(a.LoadedContexts, a.parked),
+		Errors:           withoutParked(a.Errors, a.parked),
 		Deployments:      cloneRows(a.cachedAllDeployments),
 		Pods:             cloneRows(a.cachedAllPods),
 		Services:         cloneRows(a.cachedAllServices),
+		StatefulSets:     cloneRows(a.cachedAllStatefulSets),
+		DaemonSets:       cloneRows(a.cachedAllDaemonSets),
 	}
 }
 
@@ -346,7 +492,8 @@ func (a *AppState) combinedLoadingStates() map[string]bool {
 
 	for ctx := range a.SelectedContexts {
 		// Context is loading if ANY resource type is loading
-		combined[ctx] = a.LoadingDeployments[ctx] || a.LoadingPods[ctx] || a.LoadingServices[ctx]
+		combined[ctx] = a.LoadingDeployments[ctx] || a.LoadingPods[ctx] || a.LoadingServices[ctx] ||
+			a.LoadingStatefulSets[ctx] || a.LoadingDaemonSets[ctx]
 	}
 
 	return combined
@@ -375,6 +522,18 @@ func (a *AppState) IsAnyLoading() bool {
 		}
 	}
 
+	for _, loading := range a.LoadingStatefulSets {
+		if loading {
+			return true
+		}
+	}
+
+	for _, loading := range a.LoadingDaemonSets {
+		if loading {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -392,22 +551,40 @@ func (a *AppState) RemoveContext(context string) {
 	defer a.mu.Unlock()
 
 	delete(a.SelectedContexts, context)
+	delete(a.parked, context)
+	delete(a.Namespaces, context)
 	delete(a.Deployments, context)
 	delete(a.Pods, context)
 	delete(a.Services, context)
+	delete(a.StatefulSets, context)
+	delete(a.DaemonSets, context)
 	delete(a.LoadingDeployments, context)
 	delete(a.LoadingPods, context)
 	delete(a.LoadingServices, context)
+	delete(a.LoadingStatefulSets, context)
+	delete(a.LoadingDaemonSets, context)
 	delete(a.Errors, context)
 	delete(a.LoadedContexts, context)
 	delete(a.serviceEndpoints, context)
 	delete(a.serviceEndpointsFetchedNS, context)
+	delete(a.restarts, context)
+	a.markAllDirty()
+}
+
+// markAllDirty invalidates every flattened cross-context cache — the
+// selected context set changed, so all of them are stale.
+// Must be called with lock held
+func (a *AppState) markAllDirty() {
 	a.deploymentsDirty = true
 	a.podsDirty = true
 	a.servicesDirty = true
+	a.statefulSetsDirty = true
+	a.daemonSetsDirty = true
 	a.cachedAllDeployments = nil
 	a.cachedAllPods = nil
 	a.cachedAllServices = nil
+	a.cachedAllStatefulSets = nil
+	a.cachedAllDaemonSets = nil
 }
 
 // ClearErrors removes all error messages
@@ -418,6 +595,14 @@ func (a *AppState) ClearErrors() {
 	a.Errors = make(map[string]string)
 }
 
+// ClearError removes one context's error message.
+func (a *AppState) ClearError(context string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.Errors, context)
+}
+
 // GetErrors returns a copy of all errors (safe for display)
 func (a *AppState) GetErrors() map[string]string {
 	a.mu.RLock()
@@ -441,31 +626,38 @@ func copyStringMap(src map[string]string) map[string]string {
 	return dst
 }
 
-func copyBoolMap(src map[string]bool) map[string]bool {
-	if len(src) == 0 {
-		return map[string]bool{}
-	}
-	dst := make(map[string]bool, len(src))
+func copyNamespacesMap(src map[string][]string) map[string][]string {
+	dst := make(map[string][]string, len(src))
 	for k, v := range src {
-		dst[k] = v
+		dst[k] = append([]string(nil), v...)
 	}
 	return dst
 }
 
-// flattenRows combines rows from multiple contexts (renamed from flattenDeployments for reuse)
-func flattenRows(selected map[string]string, rowsByContext map[string][]msgs.RowData) []msgs.RowData {
+// flattenRows combines rows from multiple contexts (renamed from flattenDeployments for reuse),
+// context by context in name order so the list doesn't reshuffle between calls.
+// Contexts with several picked namespaces keep only rows in one of them; every
+// row type stores its namespace under the same key.
+func flattenRows(selected map[string]string, namespaces map[string][]string, rowsByContext map[string][]msgs.RowData) []msgs.RowData {
 	if len(selected) == 0 {
 		return nil
 	}
 
 	var all []msgs.RowData
-	for context := range selected {
+	for _, context := range slices.Sorted(maps.Keys(selected)) {
 		rows, exists := rowsByContext[context]
 		if !exists {
 			continue
 		}
 
+		picked := namespaces[context]
 		for _, row := range rows {
+			if len(picked) > 0 {
+				ns, _ := row[msgs.PodKeyNamespace].(string)
+				if !slices.Contains(picked, ns) {
+					continue
+				}
+			}
 			all = append(all, cloneRow(row))
 		}
 	}
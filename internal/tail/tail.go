@@ -0,0 +1,169 @@
+// Package tail is ktails' headless mode: `ktails tail` streams the logs of
+// a pod, or of every pod a selector matches, straight to an io.Writer, each
+// line prefixed with its pod/container the way the log pane's merged view
+// prefixes it — for piping into grep or a file where a TUI is unwanted.
+package tail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/cmds"
+	"github.com/ktails/ktails/internal/tui/models"
+)
+
+// Options is what to tail and how.
+type Options struct {
+	Context   string
+	Namespace string
+	// Target is a pod name, or a selector expression (see
+	// k8s.ParseSelectors) when it contains "=" or "!".
+	Target string
+	// Container narrows each pod to that container; empty tails all of
+	// them.
+	Container string
+
+	TailLines  int64
+	Since      time.Duration
+	Follow     bool
+	Timestamps bool // prefix each line with its timestamp
+	Color      bool // color prefixes and level tokens like the log pane
+}
+
+// IsSelector reports whether target is read as a selector rather than a
+// pod name.
+func IsSelector(target string) bool {
+	return strings.ContainsAny(target, "=!")
+}
+
+// source is one pod container being tailed.
+type source struct {
+	pod, container string
+}
+
+func (s source) label() string {
+	return s.pod + "/" + s.container
+}
+
+// Run resolves opts.Target to its pods' containers and copies every one's
+// log lines to out until each stream ends (or, following, until ctx is
+// done). Lines are written whole, so sources interleave line by line.
+// Streams that fail to open or break are reported together once the rest
+// are done.
+func Run(ctx context.Context, client *k8s.Client, opts Options, out io.Writer) error {
+	sources, err := resolve(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+
+	w := &lineWriter{out: out}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for i, src := range sources {
+		prefix := src.label() + " | "
+		if opts.Color {
+			prefix = models.SourcePrefix(src.label(), models.SourceColor(i))
+		}
+		wg.Go(func() {
+			if err := stream(ctx, client, opts, src, prefix, w); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", src.label(), err))
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// resolve lists the containers opts selects, in pod order.
+func resolve(ctx context.Context, client *k8s.Client, opts Options) ([]source, error) {
+	listOpts := metav1.ListOptions{FieldSelector: "metadata.name=" + opts.Target}
+	if IsSelector(opts.Target) {
+		var err error
+		if listOpts, err = k8s.ParseSelectors(opts.Target); err != nil {
+			return nil, err
+		}
+	}
+	pods, err := client.ListPods(ctx, opts.Context, opts.Namespace, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no pod matches %q in namespace %s (context %s)", opts.Target, opts.Namespace, opts.Context)
+	}
+
+	var sources []source
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if opts.Container == "" || c.Name == opts.Container {
+				sources = append(sources, source{pod: pod.Name, container: c.Name})
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no pod matching %q runs a container named %s", opts.Target, opts.Container)
+	}
+	return sources, nil
+}
+
+// stream copies one source's lines to w. The stream is opened with ctx, so
+// it ends early once ctx is done.
+func stream(ctx context.Context, client *k8s.Client, opts Options, src source, prefix string, w *lineWriter) error {
+	rc, err := client.StreamLogs(ctx, opts.Context, opts.Namespace, src.pod, k8s.LogOptions{
+		Container:  src.container,
+		Follow:     opts.Follow,
+		TailLines:  opts.TailLines,
+		Since:      opts.Since,
+		Timestamps: opts.Timestamps,
+	})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	scanner := cmds.NewLogScanner(rc)
+	for scanner.Scan() {
+		line := scanner.Text()
+		stamp := ""
+		if opts.Timestamps {
+			if ts, rest := cmds.SplitLogTimestamp(line); !ts.IsZero() {
+				stamp, line = ts.Local().Format("15:04:05.000")+" ", rest
+			}
+		}
+		if opts.Color {
+			line = models.HighlightLine(line)
+		}
+		if err := w.write(prefix + stamp + line + "\n"); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+// lineWriter serializes whole lines from concurrent sources onto out.
+type lineWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (w *lineWriter) write(s string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := io.WriteString(w.out, s)
+	return err
+}
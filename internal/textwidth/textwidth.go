@@ -0,0 +1,99 @@
+// Package textwidth keeps text that comes from the clusters — log lines,
+// event messages, file contents, env values, error strings — from
+// distorting the layout. Widths are terminal cells measured the way
+// lipgloss and bubble-table measure them (grapheme clusters, through
+// charmbracelet/x/ansi), so CJK text and emoji count double everywhere
+// alike; a second measure such as go-runewidth would disagree with
+// lipgloss's own border math on exactly those characters. What the
+// terminal would act on rather than print is cleaned out: a tab jumps the
+// cursor, a carriage return sends it back over the line's prefix, and
+// other escape sequences can move it anywhere.
+package textwidth
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tabWidth is the terminal's tab stop interval.
+const tabWidth = 8
+
+// Width returns s's width in terminal cells, escape sequences excluded.
+func Width(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// Sanitize makes a single line of s safe to lay out: tabs are expanded to
+// spaces up to the next tab stop, SGR sequences (colors and styles, which
+// the views already use) are kept, and every other control character or
+// escape sequence — newlines included — is dropped.
+func Sanitize(s string) string {
+	if isPlain(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	col := 0
+	var state byte
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		if n == 0 {
+			break
+		}
+		s, state = s[n:], newState
+		switch {
+		case seq == "\t":
+			pad := tabWidth - col%tabWidth
+			b.WriteString(strings.Repeat(" ", pad))
+			col += pad
+		case width > 0:
+			b.WriteString(seq)
+			col += width
+		case isSGR(seq):
+			b.WriteString(seq)
+		}
+	}
+	return b.String()
+}
+
+// SanitizeLines is Sanitize for multi-line text, line by line; "\r\n"
+// line endings count as "\n".
+func SanitizeLines(s string) string {
+	if isPlain(strings.ReplaceAll(s, "\n", "")) {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = Sanitize(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Fit squeezes s onto one line of at most width cells: line breaks become
+// spaces, s is sanitized, and what doesn't fit is cut with an ellipsis.
+func Fit(s string, width int) string {
+	s = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(s)
+	return ansi.Truncate(Sanitize(s), max(width, 0), "…")
+}
+
+// PadRight pads s with spaces to width cells; s already that wide or wider
+// is returned as is.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-Width(s)))
+}
+
+// isPlain reports whether s is printable ASCII only, with nothing to clean.
+func isPlain(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// isSGR reports whether seq is a Select Graphic Rendition sequence.
+func isSGR(seq string) bool {
+	return ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m")
+}
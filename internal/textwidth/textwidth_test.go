@@ -0,0 +1,42 @@
+package textwidth
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"plain ascii", "plain ascii"},
+		{"a\tb", "a       b"},
+		{"日本\tx", "日本    x"},
+		{"level=info\r", "level=info"},
+		{"\x1b[31mred\x1b[0m", "\x1b[31mred\x1b[0m"},
+		{"up\x1b[2Aand\x1b]0;title\x07away", "upandaway"},
+		{"bell\x07 and\bback", "bell andback"},
+		{"emoji 🚀 ok", "emoji 🚀 ok"},
+	} {
+		if got := Sanitize(tc.in); got != tc.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSanitizeLines_KeepsLineBreaks(t *testing.T) {
+	got := SanitizeLines("key:\tvalue\r\nnext\tline")
+	want := "key:    value\nnext    line"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestFitAndPadRight_CountCells(t *testing.T) {
+	if got := Width("日本語"); got != 6 {
+		t.Fatalf("Width of three CJK characters = %d, want 6", got)
+	}
+	if got := Fit("failed:\n日本語のエラー", 12); Width(got) > 12 || got != "failed: 日…" {
+		t.Errorf("Fit = %q (%d cells), want %q", got, Width(got), "failed: 日…")
+	}
+	if got := PadRight("日本", 6); got != "日本  " {
+		t.Errorf("PadRight = %q, want %q", got, "日本  ")
+	}
+}
@@ -3,28 +3,30 @@ package cmds
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
-	v1 "k8s.io/api/core/v1"
 
+	"github.com/ktails/ktails/internal/config"
 	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/metrics"
+	"github.com/ktails/ktails/internal/share"
 	"github.com/ktails/ktails/internal/tui/msgs"
 )
 
-// logTailLines is the number of pre-existing lines backfilled when a log
-// stream opens, matching `kubectl logs -f --tail=N`.
-const logTailLines = 200
-
 // maxLogLineBytes bounds bufio.Scanner's per-line buffer so a single
 // abnormally long log line (e.g. a huge JSON blob) can't abort the scan
 // with bufio.ErrTooLong.
 const maxLogLineBytes = 1024 * 1024
 
-func int64Ptr(v int64) *int64 {
-	return &v
-}
-
 // endpointIPsPlaceholder is shown in the Endpoint IPs wide-mode column until
 // LoadServiceEndpointsCmd's lazy fetch resolves for that context+namespace.
 const endpointIPsPlaceholder = "…"
@@ -34,9 +36,9 @@ const endpointIPsPlaceholder = "…"
 // lazily (see mainPage.go's Ctrl+W handling for the svc tab), independent of
 // the Services watch, so it only ever runs once per context+namespace until
 // that namespace's selection changes.
-func LoadServiceEndpointsCmd(client *k8s.Client, kubeContext, namespace string) tea.Cmd {
+func LoadServiceEndpointsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string) tea.Cmd {
 	return func() tea.Msg {
-		endpoints, err := client.GetServiceEndpoints(kubeContext, namespace)
+		endpoints, err := client.GetServiceEndpoints(ctx, kubeContext, namespace)
 		if err != nil {
 			return msgs.ServiceEndpointsMsg{Context: kubeContext, Namespace: namespace, Err: err}
 		}
@@ -45,9 +47,9 @@ func LoadServiceEndpointsCmd(client *k8s.Client, kubeContext, namespace string)
 }
 
 // LoadDeploymentDetailCmd fetches detailed information for a single deployment
-func LoadDeploymentDetailCmd(client *k8s.Client, kubeContext, namespace, deploymentName string) tea.Cmd {
+func LoadDeploymentDetailCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, deploymentName string) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := client.GetDeploymentDetail(kubeContext, namespace, deploymentName)
+		detail, err := client.GetDeploymentDetail(ctx, kubeContext, namespace, deploymentName)
 		if err != nil {
 			return msgs.ResourceDetailMsg{Context: kubeContext, Err: err}
 		}
@@ -56,9 +58,9 @@ func LoadDeploymentDetailCmd(client *k8s.Client, kubeContext, namespace, deploym
 }
 
 // LoadPodDetailCmd fetches detailed information for a single pod
-func LoadPodDetailCmd(client *k8s.Client, kubeContext, namespace, podName string) tea.Cmd {
+func LoadPodDetailCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName string) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := client.GetPodDetail(kubeContext, namespace, podName)
+		detail, err := client.GetPodDetail(ctx, kubeContext, namespace, podName)
 		if err != nil {
 			return msgs.ResourceDetailMsg{Context: kubeContext, Err: err}
 		}
@@ -67,9 +69,31 @@ func LoadPodDetailCmd(client *k8s.Client, kubeContext, namespace, podName string
 }
 
 // LoadServiceDetailCmd fetches detailed information for a single service
-func LoadServiceDetailCmd(client *k8s.Client, kubeContext, namespace, serviceName string) tea.Cmd {
+func LoadServiceDetailCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, serviceName string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.GetServiceDetail(ctx, kubeContext, namespace, serviceName)
+		if err != nil {
+			return msgs.ResourceDetailMsg{Context: kubeContext, Err: err}
+		}
+		return msgs.ResourceDetailMsg{Context: kubeContext, Detail: detail}
+	}
+}
+
+// LoadStatefulSetDetailCmd fetches detailed information for a single statefulset
+func LoadStatefulSetDetailCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.GetStatefulSetDetail(ctx, kubeContext, namespace, name)
+		if err != nil {
+			return msgs.ResourceDetailMsg{Context: kubeContext, Err: err}
+		}
+		return msgs.ResourceDetailMsg{Context: kubeContext, Detail: detail}
+	}
+}
+
+// LoadDaemonSetDetailCmd fetches detailed information for a single daemonset
+func LoadDaemonSetDetailCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, name string) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := client.GetServiceDetail(kubeContext, namespace, serviceName)
+		detail, err := client.GetDaemonSetDetail(ctx, kubeContext, namespace, name)
 		if err != nil {
 			return msgs.ResourceDetailMsg{Context: kubeContext, Err: err}
 		}
@@ -77,21 +101,513 @@ func LoadServiceDetailCmd(client *k8s.Client, kubeContext, namespace, serviceNam
 	}
 }
 
-// OpenPodLogStreamCmd opens a following log stream for a single pod
-// container (one source in the merged Log pane), backfilled with the last
-// logTailLines lines. sourceKey identifies which source this is, and
-// generation is echoed back on the resulting message so the caller can
-// tell whether this stream is still the one it's waiting for — that
-// specific source may have been restarted or closed before this resolves,
-// independent of any other open source.
-func OpenPodLogStreamCmd(client *k8s.Client, kubeContext, namespace, podName, container, sourceKey string, generation int) tea.Cmd {
+// LoadPodMetricsCmd scrapes a pod's metrics endpoint and picks out the
+// series the metrics panel shows.
+func LoadPodMetricsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName string) tea.Cmd {
 	return func() tea.Msg {
-		opts := &v1.PodLogOptions{
-			Follow:    true,
-			TailLines: int64Ptr(logTailLines),
-			Container: container,
+		msg := msgs.PodMetricsMsg{Context: kubeContext, Namespace: namespace, Pod: podName}
+		scrape, err := client.ScrapePodMetrics(ctx, kubeContext, namespace, podName)
+		if err != nil {
+			msg.Err = err
+			return msg
 		}
-		stream, err := client.StreamLogs(kubeContext, namespace, podName, opts)
+		samples, err := metrics.Parse(scrape.Text)
+		if err != nil {
+			msg.Err = fmt.Errorf("metrics of %s/%s at %s: %w", namespace, podName, scrape.Target, err)
+			return msg
+		}
+		msg.Target, msg.Restarts, msg.Peek = scrape.Target, scrape.Restarts, metrics.Summarize(samples, scrape.At)
+		return msg
+	}
+}
+
+// LoadPodEnvCmd resolves the environment of every container in a pod
+func LoadPodEnvCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName string) tea.Cmd {
+	return func() tea.Msg {
+		envs, err := client.GetPodEnv(ctx, kubeContext, namespace, podName)
+		return msgs.PodEnvMsg{Context: kubeContext, Namespace: namespace, Pod: podName, Envs: envs, Err: err}
+	}
+}
+
+// LoadPodUsageCmd fetches current pod usage in one context's namespace
+func LoadPodUsageCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		pods, err := client.GetPodUsage(ctx, kubeContext, namespace)
+		return msgs.PodUsageMsg{Context: kubeContext, Namespace: namespace, Pods: pods, Err: err}
+	}
+}
+
+// LoadNodesCmd lists one context's nodes for the nodes tab
+func LoadNodesCmd(ctx context.Context, client *k8s.Client, kubeContext string) tea.Cmd {
+	return func() tea.Msg {
+		nodes, err := client.ListNodeInfo(ctx, kubeContext)
+		return msgs.NodesMsg{Context: kubeContext, Nodes: nodes, Err: err}
+	}
+}
+
+// LoadIngressesCmd lists the Ingress routes in one context's namespace
+func LoadIngressesCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		routes, err := client.ListIngressRoutes(ctx, kubeContext, namespace)
+		return msgs.IngressesMsg{Context: kubeContext, Namespace: namespace, Routes: routes, Err: err}
+	}
+}
+
+// LoadWatchStatusCmd fetches the live status of a pod or deployment on the
+// watchlist.
+func LoadWatchStatusCmd(ctx context.Context, client *k8s.Client, kubeContext, kind, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		status, err := client.GetWatchStatus(ctx, kubeContext, kind, namespace, name)
+		return msgs.WatchStatusMsg{Kind: kind, Context: kubeContext, Namespace: namespace, Name: name, Status: status, Err: err}
+	}
+}
+
+// LoadAPIResourcesCmd discovers the resource types the given contexts
+// serve, merged: a type is listed once however many serve it. It fails only
+// if every context does.
+func LoadAPIResourcesCmd(ctx context.Context, client *k8s.Client, kubeContexts []string) tea.Cmd {
+	return func() tea.Msg {
+		var merged []k8s.APIResource
+		seen := make(map[string]bool)
+		var firstErr error
+		ok := false
+		for _, kubeContext := range kubeContexts {
+			resources, err := client.ListAPIResources(kubeContext)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			ok = true
+			for _, r := range resources {
+				if !seen[r.String()] {
+					seen[r.String()] = true
+					merged = append(merged, r)
+				}
+			}
+		}
+		if !ok {
+			return msgs.APIResourcesMsg{Err: firstErr}
+		}
+		return msgs.APIResourcesMsg{Resources: merged}
+	}
+}
+
+// LoadCustomResourcesCmd lists a type's resources in one context's namespace
+func LoadCustomResourcesCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, resource k8s.APIResource) tea.Cmd {
+	return func() tea.Msg {
+		list, err := client.ListCustomResources(ctx, kubeContext, namespace, resource)
+		return msgs.CustomResourcesMsg{Context: kubeContext, Namespace: namespace, Resource: resource, List: list, Err: err}
+	}
+}
+
+// LoadServiceBackendsCmd lists the endpoints behind a Service
+func LoadServiceBackendsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, service string) tea.Cmd {
+	return func() tea.Msg {
+		backends, err := client.GetServiceBackends(ctx, kubeContext, namespace, service)
+		return msgs.ServiceBackendsMsg{Context: kubeContext, Namespace: namespace, Service: service, Backends: backends, Err: err}
+	}
+}
+
+// LoadNamespacesCmd lists a context's namespaces for the namespace picker
+func LoadNamespacesCmd(ctx context.Context, client *k8s.Client, kubeContext string) tea.Cmd {
+	return func() tea.Msg {
+		namespaces, err := client.ListNamespaces(ctx, kubeContext)
+		return msgs.NamespacesMsg{Context: kubeContext, Namespaces: namespaces, Err: err}
+	}
+}
+
+// CheckConnectionCmd checks, in the background, that kubeContext's
+// cluster answers, timing the round trip.
+func CheckConnectionCmd(ctx context.Context, client *k8s.Client, kubeContext string) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		version, err := client.CheckConnection(ctx, kubeContext)
+		return msgs.ContextHealthMsg{Context: kubeContext, Version: version, RTT: time.Since(start), Err: err}
+	}
+}
+
+// CheckNamespaceCmd checks whether namespace exists in each of contexts,
+// concurrently; the lists in the result keep contexts' order.
+func CheckNamespaceCmd(ctx context.Context, client *k8s.Client, contexts []string, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		found := make([]bool, len(contexts))
+		errs := make([]error, len(contexts))
+		var wg sync.WaitGroup
+		for i, kubeContext := range contexts {
+			wg.Go(func() {
+				found[i], errs[i] = client.NamespaceExists(ctx, kubeContext, namespace)
+			})
+		}
+		wg.Wait()
+
+		msg := msgs.NamespaceCheckMsg{Namespace: namespace, Errs: make(map[string]error)}
+		for i, kubeContext := range contexts {
+			switch {
+			case errs[i] != nil:
+				msg.Errs[kubeContext] = errs[i]
+			case found[i]:
+				msg.Found = append(msg.Found, kubeContext)
+			default:
+				msg.Missing = append(msg.Missing, kubeContext)
+			}
+		}
+		return msg
+	}
+}
+
+// DeletePodCmd deletes a pod
+func DeletePodCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeletePod(ctx, kubeContext, namespace, podName)
+		return msgs.PodActionMsg{Action: msgs.ActionDeletePod, Context: kubeContext, Namespace: namespace, Name: podName, Err: err}
+	}
+}
+
+// FindPodDeploymentCmd looks up the Deployment owning a pod
+func FindPodDeploymentCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName string) tea.Cmd {
+	return func() tea.Msg {
+		name, err := client.GetPodDeployment(ctx, kubeContext, namespace, podName)
+		return msgs.PodDeploymentMsg{Context: kubeContext, Namespace: namespace, Pod: podName, Deployment: name, Err: err}
+	}
+}
+
+// RestartDeploymentCmd triggers a rolling restart of a Deployment
+func RestartDeploymentCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.RestartDeployment(ctx, kubeContext, namespace, name)
+		return msgs.PodActionMsg{Action: msgs.ActionRestartDeployment, Context: kubeContext, Namespace: namespace, Name: name, Err: err}
+	}
+}
+
+// CordonNodeCmd cordons a node, or uncordons it with cordon false
+func CordonNodeCmd(ctx context.Context, client *k8s.Client, kubeContext, node string, cordon bool) tea.Cmd {
+	return func() tea.Msg {
+		action := msgs.ActionCordonNode
+		if !cordon {
+			action = msgs.ActionUncordonNode
+		}
+		err := client.CordonNode(ctx, kubeContext, node, cordon)
+		return msgs.NodeActionMsg{Action: action, Context: kubeContext, Node: node, Err: err}
+	}
+}
+
+// PlanDrainCmd previews draining a node
+func PlanDrainCmd(ctx context.Context, client *k8s.Client, kubeContext, node string) tea.Cmd {
+	return func() tea.Msg {
+		plan, err := client.PlanDrain(ctx, kubeContext, node)
+		return msgs.NodeDrainPlanMsg{Context: kubeContext, Node: node, Plan: plan, Err: err}
+	}
+}
+
+// DrainNodeCmd cordons a node and evicts the pods its plan doesn't skip
+func DrainNodeCmd(ctx context.Context, client *k8s.Client, plan k8s.DrainPlan) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DrainNode(ctx, plan)
+		return msgs.NodeActionMsg{Action: msgs.ActionDrainNode, Context: plan.Context, Node: plan.Node, Err: err}
+	}
+}
+
+// LoadRolloutCmd fetches a Deployment's rollout status and history
+func LoadRolloutCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		rollout, err := client.GetRollout(ctx, kubeContext, namespace, name)
+		return msgs.RolloutMsg{Context: kubeContext, Namespace: namespace, Name: name, Rollout: rollout, Err: err}
+	}
+}
+
+// CompareDeploymentCmd fetches a Deployment's settings in two contexts,
+// each call made under its own context's ctx
+func CompareDeploymentCmd(ctxA, ctxB context.Context, client *k8s.Client, contextA, contextB, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		msg := msgs.DeploymentCompareMsg{Namespace: namespace, Name: name}
+		msg.A, msg.Err = client.GetDeploymentSpec(ctxA, contextA, namespace, name)
+		if msg.Err == nil {
+			msg.B, msg.Err = client.GetDeploymentSpec(ctxB, contextB, namespace, name)
+		}
+		msg.A.Context, msg.B.Context = contextA, contextB
+		return msg
+	}
+}
+
+// UndoRolloutCmd rolls a Deployment back to revision, 0 for the previous one
+func UndoRolloutCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, name string, revision int64) tea.Cmd {
+	return func() tea.Msg {
+		rev, err := client.UndoRollout(ctx, kubeContext, namespace, name, revision)
+		return msgs.RolloutUndoneMsg{Context: kubeContext, Namespace: namespace, Name: name, Revision: rev, Err: err}
+	}
+}
+
+// FindLogLevelSwitchCmd looks up the target pod's labels and picks the first
+// of switches whose selector matches them.
+func FindLogLevelSwitchCmd(ctx context.Context, client *k8s.Client, switches []config.LogLevelSwitch, target msgs.LogLevelTarget) tea.Cmd {
+	return func() tea.Msg {
+		labels, err := client.GetPodLabels(ctx, target.Context, target.Namespace, target.Pod)
+		if err != nil {
+			return msgs.LogLevelSwitchMsg{Target: target, Err: err}
+		}
+		for i := range switches {
+			if switches[i].Matches(labels) {
+				return msgs.LogLevelSwitchMsg{Target: target, Switch: &switches[i], Labels: labels}
+			}
+		}
+		return msgs.LogLevelSwitchMsg{Target: target, Labels: labels}
+	}
+}
+
+// OpenPaneTemplateCmd looks up a deployment's labels, picks the first of
+// templates whose selector matches them, and gathers what it opens: the
+// deployment's pods, each narrowed to the template's containers (pods
+// running none of them are left out), and its events if the template
+// shows them.
+func OpenPaneTemplateCmd(ctx context.Context, client *k8s.Client, templates []config.PaneTemplate, kubeContext, namespace, deployment string) tea.Cmd {
+	return func() tea.Msg {
+		msg := msgs.PaneTemplateMsg{Context: kubeContext, Namespace: namespace, Deployment: deployment}
+		labels, pods, err := client.GetDeploymentPods(ctx, kubeContext, namespace, deployment)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		for i := range templates {
+			if templates[i].Matches(labels) {
+				msg.Template = &templates[i]
+				break
+			}
+		}
+		if msg.Template == nil {
+			return msg
+		}
+
+		for i := range pods {
+			var containers []string
+			for _, c := range pods[i].Spec.Containers {
+				if len(msg.Template.Containers) == 0 || slices.Contains(msg.Template.Containers, c.Name) {
+					containers = append(containers, c.Name)
+				}
+			}
+			if len(containers) == 0 {
+				continue
+			}
+			msg.Pods = append(msg.Pods, msgs.RowData{
+				msgs.PodKeyName:       pods[i].Name,
+				msgs.PodKeyNamespace:  pods[i].Namespace,
+				msgs.PodKeyContext:    kubeContext,
+				msgs.PodKeyContainers: strings.Join(containers, ","),
+			})
+		}
+		if msg.Template.Events {
+			msg.Events, msg.Err = client.GetWorkloadEvents(ctx, kubeContext, namespace, deployment)
+		}
+		return msg
+	}
+}
+
+// LoadWorkloadEventsCmd re-fetches the events a pane template's events
+// source shows (see k8s.Client.GetWorkloadEvents).
+func LoadWorkloadEventsCmd(ctx context.Context, client *k8s.Client, sourceKey, kubeContext, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := client.GetWorkloadEvents(ctx, kubeContext, namespace, name)
+		return msgs.WorkloadEventsMsg{SourceKey: sourceKey, Events: events, Err: err}
+	}
+}
+
+// WaitForKubeconfigChangeCmd blocks until watcher sees the kubeconfig
+// files change, then reloads them into client. It returns nil once the
+// watcher is closed; the caller re-issues it after each
+// KubeconfigChangedMsg.
+func WaitForKubeconfigChangeCmd(client *k8s.Client, watcher *k8s.KubeconfigWatcher) tea.Cmd {
+	return func() tea.Msg {
+		if err := watcher.Wait(); err != nil {
+			if errors.Is(err, fs.ErrClosed) {
+				return nil
+			}
+			return msgs.KubeconfigChangedMsg{Err: err}
+		}
+		change, err := client.ReloadKubeconfig()
+		return msgs.KubeconfigChangedMsg{Change: change, Err: err}
+	}
+}
+
+// CheckAccessCmd reviews each of accesses in a context's namespace,
+// caching the answers for KnownAccess. A review that fails is skipped:
+// the action it covers stays enabled, and says so itself if refused.
+func CheckAccessCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, accesses []k8s.Access) tea.Cmd {
+	return func() tea.Msg {
+		for _, access := range accesses {
+			client.CanI(ctx, kubeContext, namespace, access)
+		}
+		return msgs.AccessCheckedMsg{Context: kubeContext}
+	}
+}
+
+// WaitForRetriesCmd blocks until the client's calls backing off before a
+// retry change, then reports them. It returns nil once ctx is done; the
+// caller re-issues it after each RetriesMsg.
+func WaitForRetriesCmd(ctx context.Context, client *k8s.Client) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-client.RetriesChanged():
+			return msgs.RetriesMsg{Retries: client.Retries()}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// SwitchLogLevelCmd renders sw's templates for level and writes the result
+// to the pod annotation or ConfigMap key it names.
+func SwitchLogLevelCmd(ctx context.Context, client *k8s.Client, sw config.LogLevelSwitch, target msgs.LogLevelTarget, labels map[string]string, level string) tea.Cmd {
+	return func() tea.Msg {
+		configMap, value, err := sw.Render(config.LogLevelTemplateData{
+			Context:   target.Context,
+			Namespace: target.Namespace,
+			Pod:       target.Pod,
+			Container: target.Container,
+			Labels:    labels,
+			Level:     level,
+		})
+		if err != nil {
+			return msgs.LogLevelSwitchedMsg{Target: target, Level: level, Err: fmt.Errorf("log level switch %s: %w", sw.Name, err)}
+		}
+
+		var where string
+		if configMap != "" {
+			where = fmt.Sprintf("configmap %s key %s", configMap, sw.Key)
+			err = client.SetConfigMapKey(ctx, target.Context, target.Namespace, configMap, sw.Key, value)
+		} else {
+			where = fmt.Sprintf("annotation %s", sw.Annotation)
+			err = client.AnnotatePod(ctx, target.Context, target.Namespace, target.Pod, sw.Annotation, value)
+		}
+		return msgs.LogLevelSwitchedMsg{Target: target, Level: level, Where: where, Err: err}
+	}
+}
+
+// ShareLogsCmd posts lines log lines, text, to sharer's paste service.
+func ShareLogsCmd(ctx context.Context, sharer *share.Sharer, title, text string, lines int) tea.Cmd {
+	return func() tea.Msg {
+		url, err := sharer.Share(ctx, title, text)
+		return msgs.LogsSharedMsg{Lines: lines, URL: url, Err: err}
+	}
+}
+
+// ListPodDirCmd lists a directory inside a container via exec
+func ListPodDirCmd(ctx context.Context, client *k8s.Client, generation int, kubeContext, namespace, podName, container, dir string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := client.ListPodDir(ctx, kubeContext, namespace, podName, container, dir)
+		return msgs.PodDirListingMsg{Generation: generation, Dir: dir, Entries: entries, Err: err}
+	}
+}
+
+// PodFileTailLines is how much of a file the file browser's tail view reads.
+const PodFileTailLines = 500
+
+// ReadPodFileCmd reads a file inside a container via exec — the whole file
+// (capped) or, with tail, its last PodFileTailLines lines
+func ReadPodFileCmd(ctx context.Context, client *k8s.Client, generation int, kubeContext, namespace, podName, container, file string, tail bool) tea.Cmd {
+	return func() tea.Msg {
+		lines := 0
+		if tail {
+			lines = PodFileTailLines
+		}
+		content, truncated, err := client.ReadPodFile(ctx, kubeContext, namespace, podName, container, file, lines)
+		return msgs.PodFileContentMsg{Generation: generation, Path: file, Content: content, Truncated: truncated, Tail: tail, Err: err}
+	}
+}
+
+// StartPodCopyCmd starts copying src out of a container to the local dest
+// in the background. Progress updates are coalesced: the channel holds at
+// most one pending update, and a newer byte count replaces a stale one
+// rather than queueing behind it. The final Done update is always
+// delivered, after which the channel is closed.
+func StartPodCopyCmd(ctx context.Context, client *k8s.Client, generation int, kubeContext, namespace, podName, container, src, dest string) tea.Cmd {
+	return func() tea.Msg {
+		updates := make(chan msgs.PodCopyProgress, 1)
+		go func() {
+			defer close(updates)
+			n, err := client.CopyFromPod(ctx, kubeContext, namespace, podName, container, src, dest, func(n int64) {
+				select {
+				case <-updates:
+				default:
+				}
+				select {
+				case updates <- msgs.PodCopyProgress{Bytes: n}:
+				default:
+				}
+			})
+			select {
+			case <-updates:
+			default:
+			}
+			updates <- msgs.PodCopyProgress{Bytes: n, Done: true, Err: err}
+		}()
+		return msgs.PodCopyStartedMsg{Generation: generation, Updates: updates}
+	}
+}
+
+// WaitForPodCopyCmd reads the next update off a copy's channel. The caller
+// re-issues it after every update that isn't Done.
+func WaitForPodCopyCmd(generation int, updates <-chan msgs.PodCopyProgress) tea.Cmd {
+	return func() tea.Msg {
+		u, ok := <-updates
+		if !ok {
+			return msgs.PodCopyProgressMsg{Generation: generation, PodCopyProgress: msgs.PodCopyProgress{Done: true}}
+		}
+		return msgs.PodCopyProgressMsg{Generation: generation, PodCopyProgress: u}
+	}
+}
+
+// StartPortForwardCmd starts a port-forward to a pod or service (see
+// k8s.PortForwardManager.Start).
+func StartPortForwardCmd(ctx context.Context, forwards *k8s.PortForwardManager, kubeContext, namespace, kind, name string, localPort, remotePort int) tea.Cmd {
+	return func() tea.Msg {
+		info, done, err := forwards.Start(ctx, kubeContext, namespace, kind, name, localPort, remotePort)
+		return msgs.PortForwardStartedMsg{Info: info, Done: done, Err: err}
+	}
+}
+
+// WaitForPortForwardCmd reports when forward id ends.
+func WaitForPortForwardCmd(id int, done <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-done
+		return msgs.PortForwardEndedMsg{ID: id}
+	}
+}
+
+// OpenPodLogStreamCmd opens a log stream for a single pod container (one
+// source in the merged Log pane) with opts — following it, unless it's the
+// previous instance's logs, which have ended. sourceKey identifies which
+// source this is, and generation is echoed back on the resulting message so
+// the caller can tell whether this stream is still the one it's waiting
+// for — that specific source may have been restarted or closed before this
+// resolves, independent of any other open source. Lines come back
+// timestamped (see WaitForLogLineCmd) so a dropped stream can be resumed
+// where it left off.
+func OpenPodLogStreamCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName, sourceKey string, generation int, opts k8s.LogOptions) tea.Cmd {
+	return func() tea.Msg {
+		opts.Follow = !opts.Previous
+		opts.Timestamps = true
+		stream, err := client.StreamLogs(ctx, kubeContext, namespace, podName, opts)
+		if err != nil {
+			return msgs.LogStreamClosedMsg{SourceKey: sourceKey, Generation: generation, Err: err}
+		}
+		return msgs.LogStreamOpenedMsg{SourceKey: sourceKey, Generation: generation, Stream: stream}
+	}
+}
+
+// ResumePodLogStreamCmd sleeps for delay, then reopens a dropped source's
+// stream (opened with opts) from since, the timestamp of the last line it
+// delivered. The API only honours SinceTime to the second, so the stream
+// starts at the top of that second and replays a few lines the caller has
+// already seen; it's up to the caller to skip them by timestamp.
+func ResumePodLogStreamCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName, sourceKey string, generation int, opts k8s.LogOptions, since time.Time, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		opts.Follow = true
+		opts.Timestamps = true
+		opts.TailLines, opts.Since = 0, 0
+		opts.SinceTime = since.Truncate(time.Second)
+		stream, err := client.StreamLogs(ctx, kubeContext, namespace, podName, opts)
 		if err != nil {
 			return msgs.LogStreamClosedMsg{SourceKey: sourceKey, Generation: generation, Err: err}
 		}
@@ -102,16 +618,33 @@ func OpenPodLogStreamCmd(client *k8s.Client, kubeContext, namespace, podName, co
 // WaitForLogLineCmd reads the next line from scanner and returns it as a
 // LogLineMsg, or a LogStreamClosedMsg once the stream ends (scanner.Err()
 // is nil on a clean EOF). The caller re-issues this command after each
-// LogLineMsg to keep that source's read loop going.
+// LogLineMsg to keep that source's read loop going. The kubelet's
+// timestamp prefix is split off into LogLineMsg.Time.
 func WaitForLogLineCmd(sourceKey string, generation int, scanner *bufio.Scanner) tea.Cmd {
 	return func() tea.Msg {
 		if scanner.Scan() {
-			return msgs.LogLineMsg{SourceKey: sourceKey, Generation: generation, Line: scanner.Text()}
+			ts, line := SplitLogTimestamp(scanner.Text())
+			return msgs.LogLineMsg{SourceKey: sourceKey, Generation: generation, Line: line, Time: ts}
 		}
 		return msgs.LogStreamClosedMsg{SourceKey: sourceKey, Generation: generation, Err: scanner.Err()}
 	}
 }
 
+// SplitLogTimestamp splits the RFC 3339 timestamp a Timestamps log stream
+// prefixes each line with from the line itself. A line without one comes
+// back whole, with a zero time.
+func SplitLogTimestamp(raw string) (time.Time, string) {
+	prefix, line, ok := strings.Cut(raw, " ")
+	if !ok {
+		prefix, line = raw, ""
+	}
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, raw
+	}
+	return ts, line
+}
+
 // NewLogScanner wraps an opened log stream in a bufio.Scanner sized to
 // tolerate abnormally long individual log lines.
 func NewLogScanner(stream io.Reader) *bufio.Scanner {
@@ -0,0 +1,25 @@
+package cmds
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitLogTimestamp(t *testing.T) {
+	want := time.Date(2024, 5, 1, 12, 30, 45, 123456789, time.UTC)
+
+	ts, line := SplitLogTimestamp("2024-05-01T12:30:45.123456789Z level=info msg=ready")
+	if !ts.Equal(want) || line != "level=info msg=ready" {
+		t.Fatalf("got (%v, %q), want (%v, %q)", ts, line, want, "level=info msg=ready")
+	}
+
+	ts, line = SplitLogTimestamp("2024-05-01T12:30:45.123456789Z")
+	if !ts.Equal(want) || line != "" {
+		t.Fatalf("empty line: got (%v, %q)", ts, line)
+	}
+
+	ts, line = SplitLogTimestamp("no timestamp here")
+	if !ts.IsZero() || line != "no timestamp here" {
+		t.Fatalf("untimestamped line: got (%v, %q)", ts, line)
+	}
+}
@@ -0,0 +1,117 @@
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// terminalResizePoll is how often a shell session checks the local terminal
+// for a resize to forward. Polling, rather than SIGWINCH, works the same on
+// every platform.
+const terminalResizePoll = 250 * time.Millisecond
+
+// ExecPodShellCmd suspends the program and hands the terminal to an
+// interactive shell in a container (see k8s.Client.ExecInPod). Bubble Tea
+// restores the alt screen and input once the shell exits; MainPage's state
+// is untouched meanwhile, so the TUI comes back exactly as it was left.
+func ExecPodShellCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace, podName, container string) tea.Cmd {
+	shell := &podShell{
+		ctx:         ctx,
+		client:      client,
+		kubeContext: kubeContext,
+		namespace:   namespace,
+		pod:         podName,
+		container:   container,
+	}
+	return tea.Exec(shell, func(err error) tea.Msg {
+		return msgs.PodShellExitedMsg{Context: kubeContext, Namespace: namespace, Pod: podName, Err: err}
+	})
+}
+
+// podShell is the tea.ExecCommand behind ExecPodShellCmd.
+type podShell struct {
+	ctx                                    context.Context
+	client                                 *k8s.Client
+	kubeContext, namespace, pod, container string
+	stdin                                  io.Reader
+	stdout, stderr                         io.Writer
+}
+
+func (s *podShell) SetStdin(r io.Reader)  { s.stdin = r }
+func (s *podShell) SetStdout(w io.Writer) { s.stdout = w }
+func (s *podShell) SetStderr(w io.Writer) { s.stderr = w }
+
+// Run puts the local terminal in raw mode (as kubectl exec -it does) so
+// keystrokes like Ctrl+C reach the remote shell, then streams the session.
+func (s *podShell) Run() error {
+	if in, ok := s.stdin.(term.File); ok && term.IsTerminal(in.Fd()) {
+		state, err := term.MakeRaw(in.Fd())
+		if err != nil {
+			return fmt.Errorf("failed to put terminal in raw mode: %w", err)
+		}
+		defer term.Restore(in.Fd(), state)
+	}
+
+	fmt.Fprintf(s.stdout, "ktails: shell in %s/%s [%s] (%s) — exit it to return\r\n", s.namespace, s.pod, s.container, s.kubeContext)
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	var sizes remotecommand.TerminalSizeQueue
+	if out, ok := s.stdout.(term.File); ok && term.IsTerminal(out.Fd()) {
+		sizes = watchTerminalSize(ctx, out.Fd())
+	}
+	return s.client.ExecInPod(ctx, s.kubeContext, s.namespace, s.pod, s.container, nil, s.stdin, s.stdout, sizes)
+}
+
+// terminalSizeQueue implements remotecommand.TerminalSizeQueue over a
+// channel fed by watchTerminalSize.
+type terminalSizeQueue chan remotecommand.TerminalSize
+
+func (q terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// watchTerminalSize reports fd's current size, then every change to it,
+// until ctx is done.
+func watchTerminalSize(ctx context.Context, fd uintptr) terminalSizeQueue {
+	q := make(terminalSizeQueue, 1)
+	go func() {
+		defer close(q)
+		ticker := time.NewTicker(terminalResizePoll)
+		defer ticker.Stop()
+
+		var last remotecommand.TerminalSize
+		for {
+			if w, h, err := term.GetSize(fd); err == nil {
+				size := remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
+				if size != last {
+					last = size
+					select {
+					case q <- size:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return q
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -75,6 +76,31 @@ func (c *PodWatchCache) apply(event watch.Event) error {
 	return nil
 }
 
+// replace reconciles the cache with a full list taken at listRV: listed pods
+// are upserted (a cached copy newer than the list wins), and cached pods
+// the list lacks are dropped — unless a watch event newer than the list
+// added them.
+func (c *PodWatchCache) replace(pods []corev1.Pod, listRV string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listed := make(map[string]bool, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		key := pod.Namespace + "/" + pod.Name
+		listed[key] = true
+		if existing, ok := c.byKey[key]; ok && !resourceVersionLess(existing.resourceVersion, pod.ResourceVersion) {
+			continue
+		}
+		c.byKey[key] = podCacheEntry{pod: pod, resourceVersion: pod.ResourceVersion}
+	}
+	for key, entry := range c.byKey {
+		if !listed[key] && !resourceVersionLess(listRV, entry.resourceVersion) {
+			delete(c.byKey, key)
+		}
+	}
+}
+
 // rows rebuilds every row fresh from the stored raw objects — this is what
 // keeps the Age column accurate on every call without a second field to
 // keep in sync.
@@ -103,11 +129,72 @@ func (c *PodWatchCache) Rows(kubeContext string) []msgs.RowData {
 			msgs.PodKeyNodeIP:     pod.NodeIP,
 			msgs.PodKeyPodIP:      pod.PodIP,
 			msgs.PodKeyReady:      pod.ReadyContainers,
+			msgs.PodKeyQoS:        pod.QOSClass,
+			msgs.PodKeyPriority:   pod.PriorityClass,
+			msgs.PodKeyDeployment: pod.Deployment,
+			msgs.PodKeyImage:      pod.Image,
+			msgs.PodKeyOwner:      pod.Owner,
 		})
 	}
 	return rows
 }
 
+// Completion returns how the cached pod namespace/name completed (see
+// k8s.CompletionOf); ok is false while it's running or if it isn't cached.
+func (c *PodWatchCache) Completion(kubeContext, namespace, name string) (k8s.PodCompletion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.byKey[namespace+"/"+name]
+	if !ok {
+		return k8s.PodCompletion{}, false
+	}
+	return k8s.CompletionOf(entry.pod, kubeContext)
+}
+
+// Terminations returns the container exits the cached pod namespace/name
+// currently reports (see k8s.ContainerTerminations), nil if it isn't
+// cached.
+func (c *PodWatchCache) Terminations(kubeContext, namespace, name string) []k8s.ContainerTermination {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.byKey[namespace+"/"+name]
+	if !ok {
+		return nil
+	}
+	return k8s.ContainerTerminations(entry.pod, kubeContext)
+}
+
+// Lineage returns the lineage (see k8s.LineageOf) of the cached pod
+// namespace/name; ok is false if it isn't cached or no controller owns it.
+func (c *PodWatchCache) Lineage(namespace, name string) (k8s.PodLineage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.byKey[namespace+"/"+name]
+	if !ok {
+		return k8s.PodLineage{}, false
+	}
+	return k8s.LineageOf(entry.pod)
+}
+
+// Replacement returns the newest cached pod replacing the lineage's pod
+// with container started (see PodLineage.IsReplacedBy), skipping pods
+// taken says are spoken for; ok is false while there's none.
+func (c *PodWatchCache) Replacement(l k8s.PodLineage, container string, taken func(name string) bool) (name string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var newest time.Time
+	for _, entry := range c.byKey {
+		pod := entry.pod
+		if !l.IsReplacedBy(pod, container) || taken(pod.Name) {
+			continue
+		}
+		if created := pod.CreationTimestamp.Time; !ok || created.After(newest) || created.Equal(newest) && pod.Name < name {
+			name, newest, ok = pod.Name, created, true
+		}
+	}
+	return name, ok
+}
+
 // DeploymentWatchCache mirrors PodWatchCache for Deployments.
 type DeploymentWatchCache struct {
 	mu    sync.Mutex
@@ -123,6 +210,28 @@ func NewDeploymentWatchCache() *DeploymentWatchCache {
 	return &DeploymentWatchCache{byKey: make(map[string]deploymentCacheEntry)}
 }
 
+// replace mirrors PodWatchCache.replace for Deployments.
+func (c *DeploymentWatchCache) replace(deployments []appsv1.Deployment, listRV string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listed := make(map[string]bool, len(deployments))
+	for i := range deployments {
+		d := &deployments[i]
+		key := d.Namespace + "/" + d.Name
+		listed[key] = true
+		if existing, ok := c.byKey[key]; ok && !resourceVersionLess(existing.resourceVersion, d.ResourceVersion) {
+			continue
+		}
+		c.byKey[key] = deploymentCacheEntry{deployment: d, resourceVersion: d.ResourceVersion}
+	}
+	for key, entry := range c.byKey {
+		if !listed[key] && !resourceVersionLess(listRV, entry.resourceVersion) {
+			delete(c.byKey, key)
+		}
+	}
+}
+
 func (c *DeploymentWatchCache) apply(event watch.Event) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -173,6 +282,7 @@ func (c *DeploymentWatchCache) Rows(kubeContext string) []msgs.RowData {
 			msgs.DeployKeyAvailable: strconv.FormatInt(int64(deployment.AvailableReplicas), 10),
 			msgs.DeployKeyUpdated:   strconv.FormatInt(int64(deployment.UpdatedReplicas), 10),
 			msgs.DeployKeySelector:  deployment.Selector,
+			msgs.DeployKeyImage:     strings.Join(deployment.Images, ","),
 		})
 	}
 	return rows
@@ -248,3 +358,145 @@ func (c *ServiceWatchCache) Rows(kubeContext string) []msgs.RowData {
 	}
 	return rows
 }
+
+// StatefulSetWatchCache mirrors PodWatchCache for StatefulSets.
+type StatefulSetWatchCache struct {
+	mu    sync.Mutex
+	byKey map[string]statefulSetCacheEntry
+}
+
+type statefulSetCacheEntry struct {
+	statefulSet     *appsv1.StatefulSet
+	resourceVersion string
+}
+
+func NewStatefulSetWatchCache() *StatefulSetWatchCache {
+	return &StatefulSetWatchCache{byKey: make(map[string]statefulSetCacheEntry)}
+}
+
+func (c *StatefulSetWatchCache) apply(event watch.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch event.Type {
+	case watch.Error:
+		return fmt.Errorf("watch error: %v", event.Object)
+	case watch.Added, watch.Modified:
+		sts, ok := event.Object.(*appsv1.StatefulSet)
+		if !ok {
+			return nil
+		}
+		key := sts.Namespace + "/" + sts.Name
+		if existing, ok := c.byKey[key]; ok && !resourceVersionLess(existing.resourceVersion, sts.ResourceVersion) {
+			return nil
+		}
+		c.byKey[key] = statefulSetCacheEntry{statefulSet: sts, resourceVersion: sts.ResourceVersion}
+	case watch.Deleted:
+		sts, ok := event.Object.(*appsv1.StatefulSet)
+		if !ok {
+			return nil
+		}
+		delete(c.byKey, sts.Namespace+"/"+sts.Name)
+	}
+	return nil
+}
+
+func (c *StatefulSetWatchCache) Rows(kubeContext string) []msgs.RowData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.byKey))
+	for k := range c.byKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([]msgs.RowData, 0, len(keys))
+	for _, key := range keys {
+		sts := k8s.StatefulSetToStatefulSetInfo(c.byKey[key].statefulSet)
+		rows = append(rows, msgs.RowData{
+			msgs.WorkloadKeyName:      sts.Name,
+			msgs.WorkloadKeyNamespace: sts.Namespace,
+			msgs.WorkloadKeyReady:     strconv.Itoa(int(sts.ReadyReplicas)) + "/" + strconv.Itoa(int(sts.DesiredReplicas)),
+			msgs.WorkloadKeyStatus:    sts.Status,
+			msgs.WorkloadKeyAge:       sts.Age,
+			msgs.WorkloadKeyContext:   kubeContext,
+			msgs.WorkloadKeyUpdated:   strconv.FormatInt(int64(sts.UpdatedReplicas), 10),
+			msgs.WorkloadKeyAvailable: strconv.FormatInt(int64(sts.CurrentReplicas), 10),
+			msgs.WorkloadKeyStrategy:  sts.UpdateStrategy,
+			msgs.WorkloadKeySelector:  sts.Selector,
+		})
+	}
+	return rows
+}
+
+// DaemonSetWatchCache mirrors PodWatchCache for DaemonSets.
+type DaemonSetWatchCache struct {
+	mu    sync.Mutex
+	byKey map[string]daemonSetCacheEntry
+}
+
+type daemonSetCacheEntry struct {
+	daemonSet       *appsv1.DaemonSet
+	resourceVersion string
+}
+
+func NewDaemonSetWatchCache() *DaemonSetWatchCache {
+	return &DaemonSetWatchCache{byKey: make(map[string]daemonSetCacheEntry)}
+}
+
+func (c *DaemonSetWatchCache) apply(event watch.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch event.Type {
+	case watch.Error:
+		return fmt.Errorf("watch error: %v", event.Object)
+	case watch.Added, watch.Modified:
+		ds, ok := event.Object.(*appsv1.DaemonSet)
+		if !ok {
+			return nil
+		}
+		key := ds.Namespace + "/" + ds.Name
+		if existing, ok := c.byKey[key]; ok && !resourceVersionLess(existing.resourceVersion, ds.ResourceVersion) {
+			return nil
+		}
+		c.byKey[key] = daemonSetCacheEntry{daemonSet: ds, resourceVersion: ds.ResourceVersion}
+	case watch.Deleted:
+		ds, ok := event.Object.(*appsv1.DaemonSet)
+		if !ok {
+			return nil
+		}
+		delete(c.byKey, ds.Namespace+"/"+ds.Name)
+	}
+	return nil
+}
+
+func (c *DaemonSetWatchCache) Rows(kubeContext string) []msgs.RowData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.byKey))
+	for k := range c.byKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([]msgs.RowData, 0, len(keys))
+	for _, key := range keys {
+		ds := k8s.DaemonSetToDaemonSetInfo(c.byKey[key].daemonSet)
+		rows = append(rows, msgs.RowData{
+			msgs.WorkloadKeyName:      ds.Name,
+			msgs.WorkloadKeyNamespace: ds.Namespace,
+			msgs.WorkloadKeyReady:     strconv.Itoa(int(ds.Ready)) + "/" + strconv.Itoa(int(ds.Desired)),
+			msgs.WorkloadKeyStatus:    ds.Status,
+			msgs.WorkloadKeyAge:       ds.Age,
+			msgs.WorkloadKeyContext:   kubeContext,
+			msgs.WorkloadKeyUpdated:   strconv.FormatInt(int64(ds.Updated), 10),
+			msgs.WorkloadKeyAvailable: strconv.FormatInt(int64(ds.Available), 10),
+			msgs.WorkloadKeyStrategy:  ds.UpdateStrategy,
+			msgs.WorkloadKeySelector:  ds.Selector,
+		})
+	}
+	return rows
+}
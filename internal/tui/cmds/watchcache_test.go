@@ -2,12 +2,15 @@ package cmds
 
 import (
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/ktails/ktails/internal/k8s"
 	"github.com/ktails/ktails/internal/tui/msgs"
 )
 
@@ -153,3 +156,110 @@ func TestServiceWatchCache_RowsIncludeEndpointPlaceholder(t *testing.T) {
 		t.Fatalf("expected placeholder %q, got %v", endpointIPsPlaceholder, rows[0][msgs.SvcKeyEndpointIPs])
 	}
 }
+
+func TestDaemonSetWatchCache_RowsIncludeReadyAndStatus(t *testing.T) {
+	c := NewDaemonSetWatchCache()
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "kube-system", ResourceVersion: "1"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3, NumberReady: 2, UpdatedNumberScheduled: 3, NumberAvailable: 2,
+		},
+	}
+	if err := c.apply(watch.Event{Type: watch.Added, Object: ds}); err != nil {
+		t.Fatalf("apply Added: %v", err)
+	}
+
+	rows := c.Rows("ctx1")
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if rows[0][msgs.WorkloadKeyReady] != "2/3" {
+		t.Fatalf("expected ready 2/3, got %v", rows[0][msgs.WorkloadKeyReady])
+	}
+	if rows[0][msgs.WorkloadKeyStatus] != k8s.WorkloadDegraded {
+		t.Fatalf("expected status %s, got %v", k8s.WorkloadDegraded, rows[0][msgs.WorkloadKeyStatus])
+	}
+	if rows[0][msgs.WorkloadKeyContext] != "ctx1" {
+		t.Fatalf("expected context ctx1, got %v", rows[0][msgs.WorkloadKeyContext])
+	}
+}
+
+func TestPodWatchCache_ReplaceKeepsNewerWatchState(t *testing.T) {
+	c := NewPodWatchCache()
+	pod := func(name, rv string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: rv}}
+	}
+	for _, p := range []*corev1.Pod{pod("stale", "5"), pod("fresh", "20"), pod("updated", "30")} {
+		if err := c.apply(watch.Event{Type: watch.Added, Object: p}); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	// Listed at RV 10: "stale" is gone, "listed" is new, "updated" has a
+	// newer copy from the watch already, and "fresh" was added after the list.
+	c.replace([]corev1.Pod{*pod("listed", "8"), *pod("updated", "9")}, "10")
+
+	names := map[string]string{}
+	for _, row := range c.Rows("ctx") {
+		names[row[msgs.PodKeyName].(string)] = c.byKey["default/"+row[msgs.PodKeyName].(string)].resourceVersion
+	}
+	want := map[string]string{"listed": "8", "updated": "30", "fresh": "20"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	for name, rv := range want {
+		if names[name] != rv {
+			t.Errorf("%s: resourceVersion %q, want %q", name, names[name], rv)
+		}
+	}
+}
+
+func TestPodWatchCache_ReplacementFollowsTheController(t *testing.T) {
+	controller := true
+	base := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(name, uid, rs, hash string, created time.Time, running bool) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "default", UID: types.UID(uid), ResourceVersion: "1",
+				CreationTimestamp: metav1.NewTime(created),
+				Labels:            map[string]string{"app": "web", "pod-template-hash": hash},
+				OwnerReferences:   []metav1.OwnerReference{{Kind: "ReplicaSet", Name: rs, Controller: &controller}},
+			},
+		}
+		state := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+		if running {
+			state = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		}
+		p.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", State: state}}
+		return p
+	}
+
+	c := NewPodWatchCache()
+	old := pod("web-aaa-1", "u1", "web-aaa", "aaa", base, true)
+	_ = c.apply(watch.Event{Type: watch.Added, Object: old})
+	lineage, ok := c.Lineage("default", "web-aaa-1")
+	if !ok || lineage.Controller != "Deployment/web" {
+		t.Fatalf("Lineage = %+v, %v; want the Deployment as controller", lineage, ok)
+	}
+	none := func(string) bool { return false }
+
+	// A rollout's new ReplicaSet: same Deployment, other template hash.
+	_ = c.apply(watch.Event{Type: watch.Added, Object: pod("web-bbb-1", "u2", "web-bbb", "bbb", base.Add(time.Minute), false)})
+	_ = c.apply(watch.Event{Type: watch.Deleted, Object: old})
+	if name, ok := c.Replacement(lineage, "app", none); ok {
+		t.Fatalf("a replacement whose container hasn't started can't be tailed yet, got %s", name)
+	}
+
+	_ = c.apply(watch.Event{Type: watch.Modified, Object: func() *corev1.Pod {
+		p := pod("web-bbb-1", "u2", "web-bbb", "bbb", base.Add(time.Minute), true)
+		p.ResourceVersion = "2"
+		return p
+	}()})
+	_ = c.apply(watch.Event{Type: watch.Added, Object: pod("api-ccc-1", "u3", "api-ccc", "ccc", base.Add(2*time.Minute), true)})
+	if name, ok := c.Replacement(lineage, "app", none); !ok || name != "web-bbb-1" {
+		t.Fatalf("Replacement = %q, %v; want web-bbb-1", name, ok)
+	}
+	if _, ok := c.Replacement(lineage, "app", func(name string) bool { return name == "web-bbb-1" }); ok {
+		t.Fatal("a pod already spoken for isn't a replacement")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/ktails/ktails/internal/k8s"
@@ -15,9 +16,9 @@ import (
 // echoed back on the resulting message so the caller can tell whether this
 // watch is still the one it's waiting for (it may have been superseded by a
 // manual "r" restart or a context deselect before this resolves).
-func WatchPodsCmd(client *k8s.Client, kubeContext, namespace string, generation int) tea.Cmd {
+func WatchPodsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int) tea.Cmd {
 	return func() tea.Msg {
-		w, err := client.WatchPods(context.Background(), kubeContext, namespace)
+		w, err := client.WatchPods(ctx, kubeContext, namespace, opts)
 		if err != nil {
 			return msgs.PodWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
 		}
@@ -61,10 +62,10 @@ func WaitForPodWatchEventCmd(kubeContext string, generation int, watcher watch.I
 // reconnect attempts), then opens a fresh Pods watch exactly like
 // WatchPodsCmd. The existing cache is reused as-is — a fresh watch's Added
 // replay is idempotent against the upsert-based apply.
-func ReconnectPodsCmd(client *k8s.Client, kubeContext, namespace string, generation int, delay time.Duration) tea.Cmd {
+func ReconnectPodsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, delay time.Duration) tea.Cmd {
 	return func() tea.Msg {
 		time.Sleep(delay)
-		w, err := client.WatchPods(context.Background(), kubeContext, namespace)
+		w, err := client.WatchPods(ctx, kubeContext, namespace, opts)
 		if err != nil {
 			return msgs.PodWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
 		}
@@ -72,10 +73,25 @@ func ReconnectPodsCmd(client *k8s.Client, kubeContext, namespace string, generat
 	}
 }
 
+// ResyncPodsCmd reconciles cache with a fresh list of the context's pods —
+// the periodic safety net under the watch, catching anything a quiet watch
+// missed. generation is the watch's, so a result for a since-restarted
+// watch can be dropped.
+func ResyncPodsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, cache *PodWatchCache) tea.Cmd {
+	return func() tea.Msg {
+		list, err := client.ResyncPods(ctx, kubeContext, namespace, opts)
+		if err != nil {
+			return msgs.ResyncedMsg{Context: kubeContext, Resource: "Pods", Generation: generation, Err: err}
+		}
+		cache.replace(list.Items, list.ResourceVersion)
+		return msgs.ResyncedMsg{Context: kubeContext, Resource: "Pods", Generation: generation, Rows: cache.Rows(kubeContext)}
+	}
+}
+
 // WatchDeploymentsCmd mirrors WatchPodsCmd for Deployments.
-func WatchDeploymentsCmd(client *k8s.Client, kubeContext, namespace string, generation int) tea.Cmd {
+func WatchDeploymentsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int) tea.Cmd {
 	return func() tea.Msg {
-		w, err := client.WatchDeployments(context.Background(), kubeContext, namespace)
+		w, err := client.WatchDeployments(ctx, kubeContext, namespace, opts)
 		if err != nil {
 			return msgs.DeploymentWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
 		}
@@ -111,10 +127,10 @@ func WaitForDeploymentWatchEventCmd(kubeContext string, generation int, watcher
 }
 
 // ReconnectDeploymentsCmd mirrors ReconnectPodsCmd for Deployments.
-func ReconnectDeploymentsCmd(client *k8s.Client, kubeContext, namespace string, generation int, delay time.Duration) tea.Cmd {
+func ReconnectDeploymentsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, delay time.Duration) tea.Cmd {
 	return func() tea.Msg {
 		time.Sleep(delay)
-		w, err := client.WatchDeployments(context.Background(), kubeContext, namespace)
+		w, err := client.WatchDeployments(ctx, kubeContext, namespace, opts)
 		if err != nil {
 			return msgs.DeploymentWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
 		}
@@ -122,10 +138,22 @@ func ReconnectDeploymentsCmd(client *k8s.Client, kubeContext, namespace string,
 	}
 }
 
+// ResyncDeploymentsCmd mirrors ResyncPodsCmd for Deployments.
+func ResyncDeploymentsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, cache *DeploymentWatchCache) tea.Cmd {
+	return func() tea.Msg {
+		list, err := client.ResyncDeployments(ctx, kubeContext, namespace, opts)
+		if err != nil {
+			return msgs.ResyncedMsg{Context: kubeContext, Resource: "Deployments", Generation: generation, Err: err}
+		}
+		cache.replace(list.Items, list.ResourceVersion)
+		return msgs.ResyncedMsg{Context: kubeContext, Resource: "Deployments", Generation: generation, Rows: cache.Rows(kubeContext)}
+	}
+}
+
 // WatchServicesCmd mirrors WatchPodsCmd for Services.
-func WatchServicesCmd(client *k8s.Client, kubeContext, namespace string, generation int) tea.Cmd {
+func WatchServicesCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int) tea.Cmd {
 	return func() tea.Msg {
-		w, err := client.WatchServices(context.Background(), kubeContext, namespace)
+		w, err := client.WatchServices(ctx, kubeContext, namespace, opts)
 		if err != nil {
 			return msgs.ServiceWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
 		}
@@ -161,13 +189,113 @@ func WaitForServiceWatchEventCmd(kubeContext string, generation int, watcher wat
 }
 
 // ReconnectServicesCmd mirrors ReconnectPodsCmd for Services.
-func ReconnectServicesCmd(client *k8s.Client, kubeContext, namespace string, generation int, delay time.Duration) tea.Cmd {
+func ReconnectServicesCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, delay time.Duration) tea.Cmd {
 	return func() tea.Msg {
 		time.Sleep(delay)
-		w, err := client.WatchServices(context.Background(), kubeContext, namespace)
+		w, err := client.WatchServices(ctx, kubeContext, namespace, opts)
 		if err != nil {
 			return msgs.ServiceWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
 		}
 		return msgs.ServiceWatchOpenedMsg{Context: kubeContext, Generation: generation, Watcher: w}
 	}
 }
+
+// WatchStatefulSetsCmd mirrors WatchPodsCmd for StatefulSets.
+func WatchStatefulSetsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int) tea.Cmd {
+	return func() tea.Msg {
+		w, err := client.WatchStatefulSets(ctx, kubeContext, namespace, opts)
+		if err != nil {
+			return msgs.StatefulSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+		}
+		return msgs.StatefulSetWatchOpenedMsg{Context: kubeContext, Generation: generation, Watcher: w}
+	}
+}
+
+// WaitForStatefulSetWatchEventCmd mirrors WaitForPodWatchEventCmd for StatefulSets.
+func WaitForStatefulSetWatchEventCmd(kubeContext string, generation int, watcher watch.Interface, cache *StatefulSetWatchCache) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-watcher.ResultChan()
+		if !ok {
+			return msgs.StatefulSetWatchClosedMsg{Context: kubeContext, Generation: generation}
+		}
+		if err := cache.apply(ev); err != nil {
+			return msgs.StatefulSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+		}
+
+		for {
+			select {
+			case ev, ok := <-watcher.ResultChan():
+				if !ok {
+					return msgs.StatefulSetWatchEventMsg{Context: kubeContext, Generation: generation, Rows: cache.Rows(kubeContext)}
+				}
+				if err := cache.apply(ev); err != nil {
+					return msgs.StatefulSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+				}
+			default:
+				return msgs.StatefulSetWatchEventMsg{Context: kubeContext, Generation: generation, Rows: cache.Rows(kubeContext)}
+			}
+		}
+	}
+}
+
+// ReconnectStatefulSetsCmd mirrors ReconnectPodsCmd for StatefulSets.
+func ReconnectStatefulSetsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		w, err := client.WatchStatefulSets(ctx, kubeContext, namespace, opts)
+		if err != nil {
+			return msgs.StatefulSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+		}
+		return msgs.StatefulSetWatchOpenedMsg{Context: kubeContext, Generation: generation, Watcher: w}
+	}
+}
+
+// WatchDaemonSetsCmd mirrors WatchPodsCmd for DaemonSets.
+func WatchDaemonSetsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int) tea.Cmd {
+	return func() tea.Msg {
+		w, err := client.WatchDaemonSets(ctx, kubeContext, namespace, opts)
+		if err != nil {
+			return msgs.DaemonSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+		}
+		return msgs.DaemonSetWatchOpenedMsg{Context: kubeContext, Generation: generation, Watcher: w}
+	}
+}
+
+// WaitForDaemonSetWatchEventCmd mirrors WaitForPodWatchEventCmd for DaemonSets.
+func WaitForDaemonSetWatchEventCmd(kubeContext string, generation int, watcher watch.Interface, cache *DaemonSetWatchCache) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-watcher.ResultChan()
+		if !ok {
+			return msgs.DaemonSetWatchClosedMsg{Context: kubeContext, Generation: generation}
+		}
+		if err := cache.apply(ev); err != nil {
+			return msgs.DaemonSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+		}
+
+		for {
+			select {
+			case ev, ok := <-watcher.ResultChan():
+				if !ok {
+					return msgs.DaemonSetWatchEventMsg{Context: kubeContext, Generation: generation, Rows: cache.Rows(kubeContext)}
+				}
+				if err := cache.apply(ev); err != nil {
+					return msgs.DaemonSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+				}
+			default:
+				return msgs.DaemonSetWatchEventMsg{Context: kubeContext, Generation: generation, Rows: cache.Rows(kubeContext)}
+			}
+		}
+	}
+}
+
+// ReconnectDaemonSetsCmd mirrors ReconnectPodsCmd for DaemonSets.
+func ReconnectDaemonSetsCmd(ctx context.Context, client *k8s.Client, kubeContext, namespace string, opts metav1.ListOptions, generation int, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		w, err := client.WatchDaemonSets(ctx, kubeContext, namespace, opts)
+		if err != nil {
+			return msgs.DaemonSetWatchClosedMsg{Context: kubeContext, Generation: generation, Err: err}
+		}
+		return msgs.DaemonSetWatchOpenedMsg{Context: kubeContext, Generation: generation, Watcher: w}
+	}
+}
@@ -0,0 +1,196 @@
+package keys
+
+import (
+	"slices"
+
+	"charm.land/bubbles/v2/key"
+)
+
+// Section is one titled group of the help overlay.
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Entry is a binding as the help overlay lists it: its keys, from the
+// binding's help, and About, the longer account of what it does there. An
+// empty About falls back to the binding's short description.
+type Entry struct {
+	Binding key.Binding
+	About   string
+}
+
+// Sections returns the help overlay's content: every binding, grouped by
+// where it applies. Disabled bindings are skipped, as in Hints, unless
+// they're among locked (see LockMutating): those are kept, still disabled,
+// for the overlay to grey out. A section left empty is dropped.
+func (k KeyMap) Sections(locked ...key.Binding) []Section {
+	all := []Section{
+		{"Global", []Entry{
+			{k.FocusNext, "Switch focus between the contexts pane and the tab area"},
+			{k.PrevTab, "Previous tab (← also works)"},
+			{k.NextTab, "Next tab (→ also works)"},
+			{k.AutoRefresh, "Pause / resume auto-refresh (Age re-render and the periodic Pods/Deployments resync)"},
+			{k.Forwards, "List port-forwards (x stops the one under the cursor)"},
+			{k.Errors, "Error center: this session's errors, newest first, with the full text (r retries its context, x dismisses, c clears all)"},
+			{k.Alerts, "Alert panel: the alert rules from the config with how often each fired, and the alerts fired this session (m mutes a rule, c clears)"},
+			{k.Audit, "Audit log: every delete, restart, rollback, patch, cordon, drain and shell session ktails has run, across sessions, newest first, with who ran it and whether it went through"},
+			{k.Resize, "Move the divider above the open detail/log pane up or down, giving it more or less of the screen; kept across runs"},
+			{k.Collapse, "Collapse the contexts pane to a strip of the loaded contexts' badges, giving the tabs the full width, and expand it back; it opens whole while focused. Kept across runs"},
+			{k.ReturnPane, "Jump back into an open detail pane without changing its resource (other than on the Pods tab)"},
+			{k.BuildInfo, "Show the build's version, commit, date and Go version, for bug reports"},
+			{k.Undo, "Undo the last context deselection (within 30s; the contexts come back with their data and streams)"},
+			{k.Back, "Unfocus detail/log pane, then close it / overlay / dismiss the status bar's error"},
+			{k.Command, "Command line: ctx <context>, ns <namespace>, logs <pod>, filter <text> or filter level>=warn, q (Tab completes)"},
+			{k.Help, "Toggle this help"},
+			{k.Quit, "Quit"},
+		}},
+		{"Contexts pane", []Entry{
+			{k.Up, "Move up"},
+			{k.Down, "Move down"},
+			{k.Toggle, "Toggle the context's selection"},
+			{k.Confirm, "Load the selected contexts"},
+			{k.Namespaces, "Pick the namespaces the context under the cursor loads from (space toggles, enter applies)"},
+			{k.AlignNS, "Switch every loaded context to one namespace, after checking it exists in each"},
+			{k.SortCtx, "Sort the contexts by name → cluster → recently loaded → your own order; kept across runs"},
+			{k.MoveCtx, "Move the context under the cursor up or down, switching to your own order"},
+			{k.Conflicts, "Show kubeconfig entries renamed because several files define the same name"},
+			{k.Profiles, "Switch to another profile's kubeconfig, unloading every context and loading the profile's own"},
+		}},
+		{"Tables", []Entry{
+			{k.Open, "Open + focus the detail pane (refocuses instantly if already loaded)"},
+			{k.Top, "Jump to the first row"},
+			{k.Bottom, "Jump to the last row"},
+			{k.Filter, "Filter the active table by name across all rows, not just the visible ones"},
+			{k.Selector, "Narrow the active tab by label/field selector in every context (app=api,tier=backend, status.phase=Running); empty clears"},
+			{k.DropChip, "Remove the numbered filter chip above the table (picked namespaces, selector, or one filter term)"},
+			{k.CopyRow, "Copy the name of the row under the cursor / the whole row to the clipboard (OSC 52)"},
+			{k.Refresh, "Refresh the active tab's resource list across all selected contexts"},
+			{k.WideMode, "Show every column"},
+			{k.ColLeft, "Scroll the columns left"},
+			{k.ColRight, "Scroll the columns right"},
+			{k.PrevCtxTab, "Previous context sub-tab of Pods and Deployments: all contexts, then each one's own rows"},
+			{k.NextCtxTab, "Next context sub-tab of Pods and Deployments"},
+			{k.Fold, "With several contexts loaded, Pods and Deployments list each context's rows under a header counting them: fold the section the cursor is in down to its header, or unfold it"},
+			{k.Watch, "Pin the Pods or Deployments row under the cursor to the watch tab, or unpin it; the watchlist is kept across runs"},
+		}},
+		{"Pods tab", []Entry{
+			{k.Check, "Check the row for log tailing"},
+			{k.ClearCheck, "Clear all checked rows"},
+			{k.Logs, "Open/reconcile the merged log pane for checked rows (or the row under the cursor)"},
+			{k.Compare, "Compare the two checked pods' logs in a pair of side-by-side panes that scroll together by time and share / and L"},
+			{k.Shell, "Open an interactive shell in a container (bash, else sh), asking which with more than one; exit it to return"},
+			{k.Forward, "Port-forward to the row under the cursor (local:remote); forwards run until stopped or quit"},
+			{k.Env, "Show the resolved env of every container (configmap/fieldRef sources resolved, secrets masked)"},
+			{k.Metrics, "Peek at the metrics a pod annotated prometheus.io/scrape exports, read through a port-forward: restarts, HTTP requests and errors, process stats (r scrapes again for rates)"},
+			{k.Files, "Browse a container's files via exec, asking which with more than one (enter open, v view, t tail, c copy out, backspace up)"},
+			{k.Delete, "Delete the pod under the cursor, after confirming"},
+			{k.Restart, "Rollout-restart the Deployment owning the pod under the cursor, after confirming"},
+			{k.Browse, "Browse by deployment: a row per Deployment (running/total pods), Enter lists its pods, Esc/Backspace goes back"},
+			{k.GroupUp, "Browsing by deployment, go back up from a Deployment's pods to every Deployment"},
+			{k.History, "Copy a link to the pod's logs in the log store (log_links: Grafana, Loki…) over the last window, for looking further back"},
+			{k.Filter, "Also by QoS or priority class (e.g. /qos:besteffort /priority:high); combine terms with spaces"},
+			{k.SortRows, "Sort by the next column (name, status, restarts, age…), ascending then descending, then back to the default order"},
+			{k.Columns, "Choose the columns the table shows and their order (space toggle, ⇧↑/⇧↓ move); columns in config sets them at startup"},
+		}},
+		{"Deployments tab", []Entry{
+			{k.Pods, "List the deployment's pods on the Pods tab; Backspace goes up to every deployment's, b back to all pods"},
+			{k.Rollout, "Rollout status, conditions and revision history; u there rolls it back to a revision"},
+			{k.Compare, "Compare the deployment with its namesake in another selected context: replicas, images, env and resources side by side, differences highlighted"},
+			{k.Template, "Open the first pane_templates entry matching the deployment: its chosen containers' logs and, optionally, its events"},
+			{k.SortRows, "Sort by the next column, ascending then descending, then back to the default order"},
+			{k.Columns, "Choose the columns the table shows and their order"},
+		}},
+		{"svc tab", []Entry{
+			{k.Backends, "The service's endpoints from its EndpointSlices: each address's pod, node and readiness"},
+			{k.Forward, "Port-forward to the service (local:remote)"},
+		}},
+		{"sts tab", []Entry{
+			{k.OrdinalLogs, "Tail chosen ordinals of the StatefulSet under the cursor (0..4, 0,2,5, web-0..web-4; empty = all)"},
+		}},
+		{"cr tab", []Entry{
+			{k.ResourceType, "Pick the type the cr tab lists: any group/version/resource the contexts serve, with the CRD's printer columns"},
+		}},
+		{"top tab", []Entry{
+			{k.Containers, "Expand / collapse the pod's per-container usage"},
+			{k.UsageSort, "Sort pod usage by CPU or by memory"},
+		}},
+		{"nodes tab", []Entry{
+			{k.Cordon, "Cordon the node under the cursor, or uncordon it if it's cordoned, after confirming its status change"},
+			{k.Drain, "Drain the node: preview the cordon and which pods are evicted, skipped (DaemonSet, mirror pod) or blocked by a PodDisruptionBudget, then confirm"},
+		}},
+		{"watch tab", []Entry{
+			{k.Logs, "Tail the watched pod's logs, or all the watched deployment's pods', whether or not its context is selected"},
+			{k.Watch, "Unpin the entry under the cursor"},
+		}},
+		{"Detail / log pane", []Entry{
+			{k.Scroll, "Scroll the focused pane"},
+			{k.Pan, "Pan long lines sideways (in the log pane ←/→ pan by a few columns)"},
+			{k.Top, "Jump to the top"},
+			{k.Bottom, "Jump to the bottom (a log pane follows its tail again)"},
+		}},
+		{"Log pane", []Entry{
+			{k.Isolate, "Isolate one source's view, or return to the full merge"},
+			{k.Wrap, "Wrap this pane's long lines, or cut them off to pan sideways; new panes and the next run start the same way"},
+			{k.Structured, "Toggle structured columns for JSON/logfmt lines (fields from log_fields in config)"},
+			{k.Expand, "Expand the full payload of the structured view's highlighted line"},
+			{k.MinLevel, "Cycle the minimum log level shown: all → debug → info → warn → error"},
+			{k.Search, "Show only the lines containing some text, in any case; blank shows all again"},
+			{k.Timestamps, "Show / hide each line's timestamp (show_timestamps in config)"},
+			{k.Zone, "Show the pane's timestamps in another time zone (e.g. Asia/Tokyo or UTC); blank for local time"},
+			{k.Repeats, "Fold each source's runs of identical lines into one marked ×N; x on one (structured view) shows the run's span (collapse_repeats in config)"},
+			{k.TimeFormat, "Cycle the timestamps between local time (or the Z zone), UTC and relative (\"3s ago\")"},
+			{k.Previous, "Switch the pane to the previous container instance's logs (after a crash), and back"},
+			{k.Since, "Cycle how far back the streams start: tail_lines/log_since → 5m → 15m → 1h → 6h → 24h"},
+			{k.Hold, "Hold a restarted container's old output instead of following it into the new instance, and back"},
+			{k.Follow, "Follow the containers held after a restart into their new instances, from where the old output stopped"},
+			{k.Exits, "List the container exits (code, reason, time) seen on the tailed pods this session"},
+			{k.Select, "Select lines (↑/↓ extend, Esc cancels)"},
+			{k.Yank, "Copy the selected lines, or the structured view's cursor line, to the clipboard"},
+			{k.Share, "Post the selected lines, headed by their pods and contexts, to the share paste service and copy its link"},
+			{k.History, "Copy a link to the active source's logs in the log store over the time the selected lines span (all shown lines without a selection)"},
+			{k.LogLevel, "Switch the isolated pod's own log level via its log_level_switches entry, marking the change in the pane"},
+			{k.Pause, "Pause / resume the pane's view: new lines buffer (up to max_log_lines per source) behind a PAUSED badge"},
+			{k.NewPane, "Split off a new log pane (up to 4, laid out as a grid); l on the Pods tab tails into the active pane"},
+			{k.ClosePane, "Close the active log pane"},
+			{k.NextPane, "Cycle to the next log pane"},
+		}},
+		{"Filter input", []Entry{
+			{k.FilterKeep, "Keep the filter and return to the table"},
+			{k.FilterClear, "Clear the filter"},
+		}},
+		{"Prompts, pickers and panels", []Entry{
+			{k.Accept, "Apply the prompt, picker or column chooser"},
+			{k.Yes, "Go ahead with the action being confirmed"},
+			{k.No, "Cancel the action being confirmed"},
+			{k.UndoRollout, "In the rollout panel, roll the deployment back to a revision"},
+			{k.Rescrape, "In the metrics panel, scrape the pod again, adding rates since the previous scrape"},
+			{k.StopForward, "In the port-forward list, stop the forward under the cursor"},
+			{k.Mute, "In the alert panel, mute or unmute the rule under the cursor; kept across runs"},
+			{k.Retry, "In the error center, reopen the watches of the selected error's context"},
+			{k.Dismiss, "In the error center, dismiss the selected error"},
+			{k.ClearAll, "In the error center, clear every error; in the alert panel, every alert"},
+			{k.ParentDir, "In the file browser, go up a directory"},
+			{k.ViewFile, "In the file browser, view the file under the cursor"},
+			{k.TailFile, "In the file browser, tail the file under the cursor"},
+			{k.CopyOut, "In the file browser, copy the file or directory under the cursor out of the pod"},
+		}},
+	}
+
+	sections := all[:0]
+	for _, s := range all {
+		entries := s.Entries[:0]
+		for _, e := range s.Entries {
+			if e.Binding.Enabled() || slices.ContainsFunc(locked, func(l key.Binding) bool {
+				return slices.Equal(l.Keys(), e.Binding.Keys()) && l.Help() == e.Binding.Help()
+			}) {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) > 0 {
+			s.Entries = entries
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
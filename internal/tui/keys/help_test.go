@@ -0,0 +1,76 @@
+package keys
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+)
+
+func TestSections_ListEveryBinding(t *testing.T) {
+	k := Default()
+	// Disabled bindings are left out; enable them all to check coverage.
+	v := reflect.ValueOf(&k).Elem()
+	for i := range v.NumField() {
+		v.Field(i).Addr().Interface().(*key.Binding).SetEnabled(true)
+	}
+
+	var listed []key.Binding
+	for _, s := range k.Sections() {
+		for _, e := range s.Entries {
+			listed = append(listed, e.Binding)
+		}
+	}
+	for i := range v.NumField() {
+		b := v.Field(i).Interface().(key.Binding)
+		if !slices.ContainsFunc(listed, func(l key.Binding) bool {
+			return slices.Equal(l.Keys(), b.Keys()) && l.Help() == b.Help()
+		}) {
+			t.Errorf("KeyMap.%s (%s) isn't in the help overlay's sections", v.Type().Field(i).Name, b.Help().Key)
+		}
+	}
+}
+
+func TestSections_SkipDisabledBindings(t *testing.T) {
+	k := Default()
+	k.Undo.SetEnabled(false)
+	for _, s := range k.Sections() {
+		for _, e := range s.Entries {
+			if e.Binding.Help() == k.Undo.Help() {
+				t.Fatalf("disabled Undo listed in section %q", s.Title)
+			}
+		}
+	}
+}
+
+func TestSections_ListLockedBindingsAsLocked(t *testing.T) {
+	k := Default()
+	locked := k.LockMutating()
+	if k.Delete.Enabled() || k.Shell.Enabled() {
+		t.Fatal("expected LockMutating to turn delete and shell off")
+	}
+	if slices.ContainsFunc(locked, func(b key.Binding) bool { return b.Help() == k.LogLevel.Help() }) {
+		t.Error("the log level switch was off already, so isn't locked")
+	}
+
+	var sawDelete bool
+	for _, s := range k.Sections(locked...) {
+		for _, e := range s.Entries {
+			if e.Binding.Help() == k.Delete.Help() {
+				sawDelete = !e.Binding.Enabled()
+			}
+			if e.Binding.Help() == k.LogLevel.Help() {
+				t.Errorf("LogLevel listed in section %q", s.Title)
+			}
+		}
+	}
+	if !sawDelete {
+		t.Error("expected delete listed, locked")
+	}
+	for _, b := range k.Hints(ScopePods) {
+		if b.Help() == k.Delete.Help() {
+			t.Error("locked delete advertised in the Pods hints")
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package keys
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/help"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHints_OrderLockedAndRemapped(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, k *KeyMap)
+		scope Scope
+		// want is how the status bar starts, as help's short view renders
+		// it; absent are hints it mustn't carry at all.
+		want   []string
+		absent []string
+	}{
+		{
+			name:  "filter input",
+			scope: ScopeFilter,
+			want:  []string{"enter keep filter", "esc clear filter"},
+		},
+		{
+			name:   "nodes tab leads with its own actions",
+			scope:  ScopeNodes,
+			want:   []string{"c cordon/uncordon", "d drain", "/ filter", "1-9 drop filter", "r refresh"},
+			absent: []string{"l logs"},
+		},
+		{
+			name:   "read-only leaves cordon and drain out",
+			setup:  func(t *testing.T, k *KeyMap) { k.LockMutating() },
+			scope:  ScopeNodes,
+			want:   []string{"/ filter", "1-9 drop filter", "r refresh"},
+			absent: []string{"c cordon/uncordon", "d drain"},
+		},
+		{
+			name:   "read-only closes the gaps the pod actions leave",
+			setup:  func(t *testing.T, k *KeyMap) { k.LockMutating() },
+			scope:  ScopePods,
+			want:   []string{"enter describe", "l logs", "d compare", "p port-forward", "e env", "m metrics", "space check"},
+			absent: []string{"s shell", "f files", "ctrl+d delete", "ctrl+r restart deploy"},
+		},
+		{
+			name:   "actions off until configured are left out",
+			scope:  ScopeLogs,
+			want:   []string{"c isolate", "v select", "y copy", "w wrap"},
+			absent: []string{"S share", "H history link", "V app log level"},
+		},
+		{
+			name:  "configured actions take their place",
+			setup: func(t *testing.T, k *KeyMap) { k.Share.SetEnabled(true) },
+			scope: ScopeLogs,
+			want:  []string{"c isolate", "v select", "y copy", "S share", "w wrap"},
+		},
+		{
+			name: "remapped keys label their hints",
+			setup: func(t *testing.T, k *KeyMap) {
+				if err := k.Rebind(map[string][]string{"cordon": {"x", "ctrl+o"}, "filter": {"ctrl+f"}}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			scope: ScopeNodes,
+			want:  []string{"x/ctrl+o cordon/uncordon", "d drain", "ctrl+f filter"},
+		},
+		{
+			name: "an unbound action drops out",
+			setup: func(t *testing.T, k *KeyMap) {
+				if err := k.Rebind(map[string][]string{"drain": {}}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			scope:  ScopeNodes,
+			want:   []string{"c cordon/uncordon", "/ filter"},
+			absent: []string{"d drain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := Default()
+			if tt.setup != nil {
+				tt.setup(t, &k)
+			}
+			hints := k.Hints(tt.scope)
+			var got []string
+			for _, b := range hints {
+				got = append(got, b.Help().Key+" "+b.Help().Desc)
+			}
+			if len(got) < len(tt.want) || !slices.Equal(got[:len(tt.want)], tt.want) {
+				t.Errorf("hints = %q, want them to start %q", got, tt.want)
+			}
+			for _, h := range tt.absent {
+				if slices.Contains(got, h) {
+					t.Errorf("hints = %q, want no %q", got, h)
+				}
+			}
+
+			bar := ansi.Strip(help.New().ShortHelpView(hints))
+			if want := strings.Join(tt.want, " • "); !strings.HasPrefix(bar, want) {
+				t.Errorf("status bar = %q, want it to start %q", bar, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Package keys is the keymap registry: every keybinding MainPage responds
+// to, grouped by the focus scope it applies in, so UI that lists keys (the
+// status bar hints, the help overlay) is generated from the same bindings
+// rather than drifting hand-written strings.
+package keys
+
+import "charm.land/bubbles/v2/key"
+
+// Scope identifies which component currently receives keyboard input —
+// hints are looked up per scope, most specific first.
+type Scope int
+
+const (
+	ScopeContexts     Scope = iota // left pane focused
+	ScopeTable                     // ds/ing row list focused
+	ScopeServices                  // svc row list focused (table keys + backends)
+	ScopeDeployments               // Deployments row list focused (table keys + pane templates)
+	ScopePods                      // Pods row list focused (table keys + checks/logs)
+	ScopeStatefulSets              // sts row list focused (table keys + ordinal logs)
+	ScopeTop                       // top tab's usage list focused
+	ScopeNodes                     // nodes tab's list focused (table keys + cordon/drain)
+	ScopeCustom                    // cr tab's resource list focused
+	ScopeWatch                     // watch tab's watchlist focused
+	ScopeDetail                    // Detail pane focused
+	ScopeLogs                      // Log pane focused
+	ScopeFilter                    // a table is capturing "/" filter text
+)
+
+// KeyMap holds every named binding. Help text is what the status bar hints
+// and help overlay render — keep it short ("logs", not "open the log pane").
+type KeyMap struct {
+	// Global
+	Quit        key.Binding
+	FocusNext   key.Binding
+	Help        key.Binding
+	Command     key.Binding
+	Back        key.Binding
+	AutoRefresh key.Binding
+	Forwards    key.Binding
+	Errors      key.Binding
+	Alerts      key.Binding
+	Audit       key.Binding
+	BuildInfo   key.Binding
+	Undo        key.Binding
+	Resize      key.Binding
+	Collapse    key.Binding
+
+	// Context list
+	Up         key.Binding
+	Down       key.Binding
+	Toggle     key.Binding
+	Confirm    key.Binding
+	Conflicts  key.Binding
+	Namespaces key.Binding
+	AlignNS    key.Binding
+	SortCtx    key.Binding
+	MoveCtx    key.Binding
+	Profiles   key.Binding
+
+	// Resource tables
+	PrevTab    key.Binding
+	NextTab    key.Binding
+	Open       key.Binding
+	Filter     key.Binding
+	Selector   key.Binding
+	Refresh    key.Binding
+	WideMode   key.Binding
+	ColLeft    key.Binding
+	ColRight   key.Binding
+	ReturnPane key.Binding
+	CopyRow    key.Binding
+	DropChip   key.Binding
+	SortRows   key.Binding
+	Columns    key.Binding
+	Fold       key.Binding
+	PrevCtxTab key.Binding
+	NextCtxTab key.Binding
+	Watch      key.Binding
+
+	// Pods table
+	Check      key.Binding
+	ClearCheck key.Binding
+	Logs       key.Binding
+	Env        key.Binding
+	Metrics    key.Binding
+	Files      key.Binding
+	Shell      key.Binding
+	Forward    key.Binding
+	Delete     key.Binding
+	Restart    key.Binding
+	Browse     key.Binding
+	GroupUp    key.Binding
+	History    key.Binding
+
+	// Deployments table
+	Template key.Binding
+	Pods     key.Binding
+	Rollout  key.Binding
+	Compare  key.Binding
+
+	// Services table
+	Backends key.Binding
+
+	// StatefulSets table
+	OrdinalLogs key.Binding
+
+	// Custom resources table
+	ResourceType key.Binding
+
+	// Top table
+	Containers key.Binding
+	UsageSort  key.Binding
+
+	// Nodes table
+	Cordon key.Binding
+	Drain  key.Binding
+
+	// Detail / Log panes
+	Scroll     key.Binding
+	Pan        key.Binding
+	Top        key.Binding
+	Bottom     key.Binding
+	Isolate    key.Binding
+	Wrap       key.Binding
+	Structured key.Binding
+	Expand     key.Binding
+	MinLevel   key.Binding
+	Search     key.Binding
+	LogLevel   key.Binding
+	Exits      key.Binding
+	Timestamps key.Binding
+	Zone       key.Binding
+	TimeFormat key.Binding
+	Repeats    key.Binding
+	Previous   key.Binding
+	Since      key.Binding
+	Hold       key.Binding
+	Follow     key.Binding
+	Select     key.Binding
+	Yank       key.Binding
+	Share      key.Binding
+	Pause      key.Binding
+	NewPane    key.Binding
+	ClosePane  key.Binding
+	NextPane   key.Binding
+
+	// Filter input
+	FilterKeep  key.Binding
+	FilterClear key.Binding
+
+	// Overlays (prompts, pickers, panels); Back closes them
+	Accept      key.Binding
+	Yes         key.Binding
+	No          key.Binding
+	UndoRollout key.Binding
+	Rescrape    key.Binding
+	StopForward key.Binding
+	Mute        key.Binding
+	Retry       key.Binding
+	Dismiss     key.Binding
+	ClearAll    key.Binding
+	ParentDir   key.Binding
+	ViewFile    key.Binding
+	TailFile    key.Binding
+	CopyOut     key.Binding
+}
+
+// Default returns the built-in keymap.
+func Default() KeyMap {
+	return KeyMap{
+		Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		FocusNext:   key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab", "focus")),
+		Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Command:     key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "command")),
+		Back:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		AutoRefresh: key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "auto-refresh")),
+		Forwards:    key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "forwards")),
+		Errors:      key.NewBinding(key.WithKeys("!"), key.WithHelp("!", "errors")),
+		// Alerts is enabled by MainPage once alert rules are configured (see
+		// config.AlertRule).
+		Alerts: key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "alerts"), key.WithDisabled()),
+		// Audit is enabled by MainPage once actions are being audited (see
+		// SetAuditLog).
+		Audit:     key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "audit log"), key.WithDisabled()),
+		BuildInfo: key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "build info")),
+		Undo:      key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "undo deselect"), key.WithDisabled()),
+		Resize:    key.NewBinding(key.WithKeys("ctrl+up", "ctrl+down"), key.WithHelp("ctrl+↑/↓", "resize")),
+		Collapse:  key.NewBinding(key.WithKeys("ctrl+b"), key.WithHelp("ctrl+b", "collapse")),
+
+		Up:      key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:    key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		Toggle:  key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "select")),
+		Confirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "load")),
+		// Conflicts is disabled by MainPage unless the kubeconfig merge
+		// renamed something (see k8s.ContextConflict).
+		Conflicts:  key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "conflicts")),
+		Namespaces: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "namespaces")),
+		AlignNS:    key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "align namespace")),
+		SortCtx:    key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "sort")),
+		MoveCtx:    key.NewBinding(key.WithKeys("shift+up", "shift+down"), key.WithHelp("⇧↑/⇧↓", "move")),
+		// Profiles is enabled by MainPage once profiles are configured (see
+		// config.Profile).
+		Profiles: key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "profiles"), key.WithDisabled()),
+
+		PrevTab:    key.NewBinding(key.WithKeys("left", "["), key.WithHelp("[", "prev tab")),
+		NextTab:    key.NewBinding(key.WithKeys("right", "]"), key.WithHelp("]", "next tab")),
+		Open:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "describe")),
+		Filter:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		Selector:   key.NewBinding(key.WithKeys("="), key.WithHelp("=", "selector")),
+		Refresh:    key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		WideMode:   key.NewBinding(key.WithKeys("ctrl+w"), key.WithHelp("ctrl+w", "wide")),
+		ColLeft:    key.NewBinding(key.WithKeys("shift+left"), key.WithHelp("⇧←", "col left")),
+		ColRight:   key.NewBinding(key.WithKeys("shift+right"), key.WithHelp("⇧→", "col right")),
+		ReturnPane: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "return to pane")),
+		CopyRow:    key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y/Y", "copy name/row")),
+		DropChip:   key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "drop filter")),
+		SortRows:   key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "sort")),
+		Columns:    key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "columns")),
+		Fold:       key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "fold context")),
+		PrevCtxTab: key.NewBinding(key.WithKeys("{"), key.WithHelp("{", "prev context")),
+		NextCtxTab: key.NewBinding(key.WithKeys("}"), key.WithHelp("}", "next context")),
+		Watch:      key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "watch")),
+
+		Check:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "check")),
+		ClearCheck: key.NewBinding(key.WithKeys("ctrl+x"), key.WithHelp("ctrl+x", "clear checks")),
+		Logs:       key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "logs")),
+		Env:        key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "env")),
+		Metrics:    key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "metrics")),
+		Files:      key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "files")),
+		Shell:      key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "shell")),
+		Forward:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "port-forward")),
+		Delete:     key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "delete")),
+		Restart:    key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "restart deploy")),
+		Browse:     key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "by deployment")),
+		GroupUp:    key.NewBinding(key.WithKeys("backspace"), key.WithHelp("backspace", "all deployments")),
+
+		// History is enabled by MainPage once log links are configured (see
+		// config.LogLink); it works from the log pane too.
+		History: key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "history link"), key.WithDisabled()),
+
+		// Template is enabled by MainPage once pane templates are configured
+		// (see config.PaneTemplate).
+		Template: key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open template"), key.WithDisabled()),
+		Pods:     key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pods")),
+		Rollout:  key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "rollout")),
+		Compare:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "compare")),
+
+		Backends: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "backends")),
+
+		OrdinalLogs: key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "tail ordinals")),
+
+		ResourceType: key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "resource type")),
+
+		Containers: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "containers")),
+		UsageSort:  key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "sort cpu/mem")),
+
+		Cordon: key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "cordon/uncordon")),
+		Drain:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "drain")),
+
+		Scroll:     key.NewBinding(key.WithKeys("up", "down", "pgup", "pgdown"), key.WithHelp("↑/↓", "scroll")),
+		Pan:        key.NewBinding(key.WithKeys("shift+left", "shift+right"), key.WithHelp("⇧←/⇧→", "pan")),
+		Top:        key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g", "top")),
+		Bottom:     key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G", "bottom")),
+		Isolate:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "isolate")),
+		Wrap:       key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "wrap")),
+		Structured: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "structured")),
+		Expand:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "expand")),
+		MinLevel:   key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "min level")),
+		Search:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		// LogLevel is enabled by MainPage once log level switches are
+		// configured (see config.LogLevelSwitch).
+		LogLevel:   key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "app log level"), key.WithDisabled()),
+		Exits:      key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "exits")),
+		Timestamps: key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "timestamps")),
+		Zone:       key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "time zone")),
+		TimeFormat: key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "time format")),
+		Repeats:    key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "collapse repeats")),
+		Previous:   key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "previous")),
+		Since:      key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "since")),
+		Hold:       key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "hold restarts")),
+		Follow:     key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "follow restart")),
+		Select:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "select")),
+		Yank:       key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy")),
+		Pause:      key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "pause")),
+		NewPane:    key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "new pane")),
+		ClosePane:  key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "close pane")),
+		NextPane:   key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "next pane")),
+		// Share is enabled by MainPage once a paste service is configured
+		// (see config.Share).
+		Share: key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "share"), key.WithDisabled()),
+
+		FilterKeep:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keep filter")),
+		FilterClear: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear filter")),
+
+		Accept:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply")),
+		Yes:         key.NewBinding(key.WithKeys("y", "enter"), key.WithHelp("y", "yes")),
+		No:          key.NewBinding(key.WithKeys("n", "esc"), key.WithHelp("n", "no")),
+		UndoRollout: key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo rollout")),
+		Rescrape:    key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "scrape again")),
+		StopForward: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop forward")),
+		Mute:        key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "mute rule")),
+		Retry:       key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "retry")),
+		Dismiss:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "dismiss")),
+		ClearAll:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "clear all")),
+		ParentDir:   key.NewBinding(key.WithKeys("backspace", "-"), key.WithHelp("backspace", "up a dir")),
+		ViewFile:    key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "view")),
+		TailFile:    key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tail")),
+		CopyOut:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy out")),
+	}
+}
+
+// Mutating returns the bindings of the actions that change something in a
+// cluster or run a process in a container: what read-only mode locks.
+func (k *KeyMap) Mutating() []*key.Binding {
+	return []*key.Binding{&k.Delete, &k.Restart, &k.Shell, &k.Files, &k.UndoRollout, &k.LogLevel, &k.Cordon, &k.Drain}
+}
+
+// LockMutating turns the Mutating bindings off for read-only mode,
+// returning those that were on for the help overlay to list as locked (see
+// Sections).
+func (k *KeyMap) LockMutating() []key.Binding {
+	var locked []key.Binding
+	for _, b := range k.Mutating() {
+		if b.Enabled() {
+			locked = append(locked, *b)
+		}
+		b.SetEnabled(false)
+	}
+	return locked
+}
+
+// Hints returns the bindings worth advertising in the status bar for the
+// given scope, most useful first — callers truncate from the end when space
+// runs out, so order matters. Disabled bindings are skipped.
+func (k KeyMap) Hints(scope Scope) []key.Binding {
+	var hints []key.Binding
+	switch scope {
+	case ScopeContexts:
+		hints = []key.Binding{k.Toggle, k.Confirm, k.Undo, k.Namespaces, k.AlignNS, k.Profiles, k.SortCtx, k.MoveCtx, k.Collapse, k.Conflicts, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeTable:
+		hints = []key.Binding{k.Open, k.Filter, k.Selector, k.DropChip, k.CopyRow, k.Refresh, k.WideMode, k.NextTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeServices:
+		hints = []key.Binding{k.Open, k.Backends, k.Forward, k.Filter, k.Selector, k.DropChip, k.CopyRow, k.Refresh, k.WideMode, k.NextTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeDeployments:
+		hints = []key.Binding{k.Open, k.Pods, k.Rollout, k.Compare, k.Watch, k.Template, k.SortRows, k.Columns, k.Fold, k.NextCtxTab, k.Filter, k.Selector, k.DropChip, k.CopyRow, k.Refresh, k.WideMode, k.NextTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopePods:
+		hints = []key.Binding{k.Open, k.Logs, k.Compare, k.Shell, k.Forward, k.Env, k.Metrics, k.Files, k.History, k.Delete, k.Restart, k.Check, k.Watch, k.Browse, k.SortRows, k.Columns, k.Fold, k.NextCtxTab, k.Filter, k.Selector, k.DropChip, k.CopyRow, k.Refresh, k.WideMode, k.NextTab, k.Forwards, k.Errors, k.Alerts, k.Command, k.Help, k.Quit}
+	case ScopeStatefulSets:
+		hints = []key.Binding{k.Open, k.OrdinalLogs, k.Filter, k.Selector, k.DropChip, k.CopyRow, k.Refresh, k.WideMode, k.NextTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeTop:
+		hints = []key.Binding{k.Containers, k.UsageSort, k.Filter, k.DropChip, k.Refresh, k.PrevTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeNodes:
+		hints = []key.Binding{k.Cordon, k.Drain, k.Filter, k.DropChip, k.Refresh, k.NextTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeCustom:
+		hints = []key.Binding{k.ResourceType, k.Filter, k.DropChip, k.Refresh, k.PrevTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeWatch:
+		hints = []key.Binding{k.Logs, k.Watch, k.Filter, k.Refresh, k.PrevTab, k.Forwards, k.Errors, k.Alerts, k.FocusNext, k.Command, k.Help, k.Quit}
+	case ScopeDetail:
+		hints = []key.Binding{k.Scroll, k.Pan, k.Top, k.Bottom, k.Resize, k.Back, k.Help}
+	case ScopeLogs:
+		hints = []key.Binding{k.Isolate, k.Select, k.Yank, k.Share, k.History, k.Wrap, k.Structured, k.Expand, k.MinLevel, k.Search, k.Previous, k.Since, k.Hold, k.Follow, k.Timestamps, k.Zone, k.TimeFormat, k.Repeats, k.LogLevel, k.Exits, k.Pause, k.NewPane, k.NextPane, k.ClosePane, k.Scroll, k.Pan, k.Bottom, k.Resize, k.Back, k.Help}
+	case ScopeFilter:
+		hints = []key.Binding{k.FilterKeep, k.FilterClear}
+	}
+
+	enabled := hints[:0]
+	for _, b := range hints {
+		if b.Enabled() {
+			enabled = append(enabled, b)
+		}
+	}
+	return enabled
+}
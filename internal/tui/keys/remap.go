@@ -0,0 +1,155 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// Actions maps each action's config name — its KeyMap field in
+// snake_case, e.g. "focus_next" or "align_ns" — to its binding in k.
+func (k *KeyMap) Actions() map[string]*key.Binding {
+	v := reflect.ValueOf(k).Elem()
+	actions := make(map[string]*key.Binding, v.NumField())
+	for i := range v.NumField() {
+		actions[snakeCase(v.Type().Field(i).Name)] = v.Field(i).Addr().Interface().(*key.Binding)
+	}
+	return actions
+}
+
+// Rebind applies the config's keys section: each action named is bound to
+// the keys listed instead of its defaults, and shown in hints and help by
+// them; an empty list unbinds it. Where an action's keys do different
+// things (resize, move, copy, drop filter chip), they're taken in the
+// order of its defaults. A key two actions would take in one place (up
+// and down both on j) is refused, unless both take it by default.
+func (k *KeyMap) Rebind(bindings map[string][]string) error {
+	actions := k.Actions()
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(bindings)) {
+		b, ok := actions[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("keys.%s: unknown action", name))
+			continue
+		}
+		keys := bindings[name]
+		if slices.Contains(keys, "") {
+			errs = append(errs, fmt.Errorf("keys.%s: keys must not be empty", name))
+			continue
+		}
+		if len(keys) == 0 {
+			b.Unbind()
+			continue
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+	}
+	if len(errs) == 0 {
+		errs = k.conflicts()
+	}
+	return errors.Join(errs...)
+}
+
+// scope is where keys are taken as one: the config names of the actions
+// live at once, each of whose keys may do one thing there.
+type scope struct {
+	name    string
+	actions []string
+}
+
+var (
+	globalActions = []string{"quit", "focus_next", "help", "command", "back", "auto_refresh", "forwards", "errors", "alerts", "audit", "build_info", "undo", "resize", "collapse", "prev_tab", "next_tab", "return_pane"}
+	cursorActions = []string{"up", "down", "top", "bottom", "scroll"}
+	panelActions  = append([]string{"back"}, cursorActions...)
+	tableActions  = slices.Concat(globalActions, cursorActions, []string{"open", "filter", "selector", "refresh", "wide_mode", "col_left", "col_right", "copy_row", "drop_chip", "sort_rows", "columns", "fold", "prev_ctx_tab", "next_ctx_tab", "watch"})
+)
+
+// scopes are checked by Rebind for a key given to two actions at once.
+// The resource tables share tableActions, plus their tab's own.
+var scopes = []scope{
+	{"contexts pane", slices.Concat(globalActions, cursorActions, []string{"toggle", "confirm", "conflicts", "namespaces", "align_ns", "sort_ctx", "move_ctx", "profiles"})},
+	{"Pods tab", slices.Concat(tableActions, []string{"check", "clear_check", "logs", "env", "metrics", "files", "shell", "forward", "delete", "restart", "browse", "group_up", "history", "compare"})},
+	{"Deployments tab", slices.Concat(tableActions, []string{"template", "pods", "rollout", "compare"})},
+	{"svc tab", slices.Concat(tableActions, []string{"backends", "forward"})},
+	{"sts tab", slices.Concat(tableActions, []string{"ordinal_logs"})},
+	{"top tab", slices.Concat(tableActions, []string{"containers", "usage_sort"})},
+	{"nodes tab", slices.Concat(tableActions, []string{"cordon", "drain"})},
+	{"cr tab", slices.Concat(tableActions, []string{"resource_type"})},
+	{"watch tab", slices.Concat(tableActions, []string{"logs"})},
+	{"detail pane", slices.Concat(globalActions, cursorActions, []string{"pan"})},
+	{"log pane", slices.Concat(globalActions, cursorActions, []string{"pan", "isolate", "wrap", "structured", "expand", "min_level", "search", "log_level", "exits", "timestamps", "zone", "time_format", "repeats", "previous", "since", "hold", "follow", "select", "yank", "share", "pause", "new_pane", "close_pane", "next_pane", "history"})},
+	{"filter input", []string{"filter_keep", "filter_clear"}},
+	{"confirmation", slices.Concat(cursorActions, []string{"yes", "no"})},
+	{"file browser", slices.Concat(panelActions, []string{"parent_dir", "view_file", "tail_file", "copy_out", "confirm"})},
+	{"error center", slices.Concat(panelActions, []string{"retry", "dismiss", "clear_all"})},
+	{"alert panel", slices.Concat(panelActions, []string{"mute", "clear_all"})},
+	{"port-forward list", slices.Concat(panelActions, []string{"stop_forward"})},
+	{"namespace picker", slices.Concat(panelActions, []string{"toggle", "accept"})},
+	{"column chooser", slices.Concat(panelActions, []string{"toggle", "move_ctx", "accept"})},
+	{"profile picker", slices.Concat(panelActions, []string{"accept"})},
+	{"rollout history", slices.Concat(panelActions, []string{"undo_rollout"})},
+	{"metrics peek", slices.Concat(panelActions, []string{"rescrape"})},
+}
+
+// conflicts reports each key two actions in one scope are bound to, but
+// for those they share by default (up and scroll both take ↑; enter opens
+// a row and, on the top tab, lists its containers). It's put down to the
+// action the key isn't a default of.
+func (k *KeyMap) conflicts() []error {
+	defaults := Default()
+	actions, builtin := k.Actions(), defaults.Actions()
+	var errs []error
+	reported := make(map[string]bool)
+	for _, sc := range scopes {
+		holder := make(map[string]string)
+		for _, name := range sc.actions {
+			for _, pressed := range actions[name].Keys() {
+				other, taken := holder[pressed]
+				if !taken {
+					holder[pressed] = name
+					continue
+				}
+				nameDefault := slices.Contains(builtin[name].Keys(), pressed)
+				if other == name || nameDefault && slices.Contains(builtin[other].Keys(), pressed) {
+					continue
+				}
+				blame, by := name, other
+				if nameDefault {
+					blame, by = other, name
+				}
+				if id := blame + " " + pressed; !reported[id] {
+					reported[id] = true
+					errs = append(errs, fmt.Errorf("keys.%s: %s is taken by %s in the %s", blame, pressed, by, sc.name))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// Index is the position of msg's key among b's keys, -1 if it isn't one:
+// for the actions whose keys do different things, which one was pressed.
+func Index(msg tea.KeyPressMsg, b key.Binding) int {
+	return slices.Index(b.Keys(), msg.String())
+}
+
+// snakeCase is a KeyMap field's config name: "FocusNext" is "focus_next",
+// "AlignNS" is "align_ns".
+func snakeCase(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 && (unicode.IsLower(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestRebind_RemapsAndUnbinds(t *testing.T) {
+	k := Default()
+	err := k.Rebind(map[string][]string{
+		"quit":       {"ctrl+q", "ctrl+c"},
+		"align_ns":   {"M"},
+		"focus_next": {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Matches(tea.KeyPressMsg{Code: 'q', Mod: tea.ModCtrl}, k.Quit) || key.Matches(keyPress("q"), k.Quit) {
+		t.Errorf("quit keys = %v, want [ctrl+q ctrl+c]", k.Quit.Keys())
+	}
+	if k.Quit.Help().Key != "ctrl+q/ctrl+c" || k.Quit.Help().Desc != "quit" {
+		t.Errorf("quit help = %+v", k.Quit.Help())
+	}
+	if !key.Matches(keyPress("M"), k.AlignNS) {
+		t.Errorf("align_ns keys = %v, want [M]", k.AlignNS.Keys())
+	}
+	if k.FocusNext.Enabled() {
+		t.Error("focus_next was unbound, yet it's still enabled")
+	}
+
+	k = Default()
+	err = k.Rebind(map[string][]string{"qiut": {"x"}, "up": {""}})
+	if err == nil {
+		t.Fatal("expected the bindings to be rejected")
+	}
+	for _, want := range []string{"keys.qiut: unknown action", "keys.up: keys must not be empty"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestRebind_RefusesAKeyTwoActionsTake(t *testing.T) {
+	k := Default()
+	err := k.Rebind(map[string][]string{"up": {"up", "j"}})
+	if err == nil || !strings.Contains(err.Error(), "keys.up: j is taken by down in the contexts pane") {
+		t.Fatalf("up on j while down has it: err = %v", err)
+	}
+	k = Default()
+	if err := k.Rebind(map[string][]string{"cordon": {"d"}}); err == nil || !strings.Contains(err.Error(), "keys.cordon: d is taken by drain in the nodes tab") {
+		t.Errorf("cordon on drain's d: err = %v", err)
+	}
+
+	// Swapping j and k frees each before the other takes it.
+	k = Default()
+	if err := k.Rebind(map[string][]string{"up": {"up", "j"}, "down": {"down", "k"}}); err != nil {
+		t.Fatalf("swapping j and k: %v", err)
+	}
+	// A key an action takes elsewhere is fine: s opens a shell on the
+	// Pods tab, and would cordon on the nodes tab.
+	k = Default()
+	if err := k.Rebind(map[string][]string{"cordon": {"s"}}); err != nil {
+		t.Fatalf("cordon on s: %v", err)
+	}
+}
+
+func TestScopes_NameActionsAndStartConflictFree(t *testing.T) {
+	k := Default()
+	actions := k.Actions()
+	for _, sc := range scopes {
+		for _, name := range sc.actions {
+			if actions[name] == nil {
+				t.Errorf("the %s lists %q, which isn't an action", sc.name, name)
+			}
+		}
+	}
+	if errs := k.conflicts(); len(errs) > 0 {
+		t.Errorf("the default keymap conflicts with itself: %v", errs)
+	}
+}
+
+func TestActions_NameFieldsInSnakeCase(t *testing.T) {
+	k := Default()
+	actions := k.Actions()
+	for _, name := range []string{"quit", "focus_next", "align_ns", "col_left", "return_pane", "ordinal_logs"} {
+		if actions[name] == nil {
+			t.Errorf("no action named %q", name)
+		}
+	}
+	actions["quit"].SetKeys("x")
+	if !key.Matches(tea.KeyPressMsg{Code: 'x', Text: "x"}, k.Quit) {
+		t.Error("Actions' bindings aren't the keymap's own")
+	}
+}
+
+// keyPress builds the press of key s as the terminal reports it.
+func keyPress(s string) tea.KeyPressMsg {
+	r := []rune(s)[0]
+	if unicode.IsUpper(r) {
+		return tea.KeyPressMsg{Code: unicode.ToLower(r), ShiftedCode: r, Mod: tea.ModShift, Text: s}
+	}
+	return tea.KeyPressMsg{Code: r, Text: s}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"image/color"
+
+	"charm.land/lipgloss/v2"
+	btable "github.com/evertras/bubble-table/table"
+)
+
+// accentKey is the row key of the accent bar leading each row in a
+// multi-context session.
+const accentKey = "accent"
+
+// rowAccents styles each context's accent bar (see styles.ContextAccents).
+// It's empty with fewer than two contexts selected, and the tables then
+// leave the bar's column out altogether.
+type rowAccents map[string]lipgloss.Style
+
+func newRowAccents(accents map[string]color.Color) rowAccents {
+	if len(accents) == 0 {
+		return nil
+	}
+	r := make(rowAccents, len(accents))
+	for name, c := range accents {
+		r[name] = lipgloss.NewStyle().Foreground(c)
+	}
+	return r
+}
+
+// columns is the bar's column, to go first, or none.
+func (r rowAccents) columns() []btable.Column {
+	if len(r) == 0 {
+		return nil
+	}
+	return []btable.Column{btable.NewColumn(accentKey, "", 1)}
+}
+
+// cell is the bar for a row of context, blank for a context with no accent.
+func (r rowAccents) cell(context any) any {
+	name, _ := context.(string)
+	st, ok := r[name]
+	if !ok {
+		return " "
+	}
+	return btable.NewStyledCell("▌", st)
+}
+
+// withAccent prepends r's column, if any, to cols.
+func (r rowAccents) withAccent(cols []btable.Column) []btable.Column {
+	return append(r.columns(), cols...)
+}
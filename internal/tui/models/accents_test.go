@@ -0,0 +1,58 @@
+package models
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+func TestContextAccents_StableAndDistinct(t *testing.T) {
+	if got := styles.ContextAccents([]string{"prod"}); got != nil {
+		t.Fatalf("one context got accents %v, want none", got)
+	}
+
+	contexts := []string{"prod-eu", "prod-us", "staging", "dev", "kind-local", "minikube"}
+	accents := styles.ContextAccents(contexts)
+	seen := make(map[color.Color]string)
+	for _, name := range contexts {
+		c, ok := accents[name]
+		if !ok {
+			t.Fatalf("%s has no accent", name)
+		}
+		if other, dup := seen[c]; dup {
+			t.Fatalf("%s and %s share an accent", name, other)
+		}
+		seen[c] = name
+	}
+
+	reordered := styles.ContextAccents([]string{"minikube", "dev", "staging", "kind-local", "prod-us", "prod-eu"})
+	for _, name := range contexts {
+		if reordered[name] != accents[name] {
+			t.Errorf("%s's accent changed with the selection's order", name)
+		}
+	}
+}
+
+func TestPodPage_AccentBarLeadsRows(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(80, 10)
+	p.SetRows(samplePodRows(2))
+	if strings.Contains(p.View(), "▌") {
+		t.Fatal("accent bar shown without accents")
+	}
+
+	p.SetAccents(styles.ContextAccents([]string{"ctx-a", "ctx-b"}))
+	if !strings.Contains(p.View(), "▌") {
+		t.Fatalf("no accent bar in view:\n%s", p.View())
+	}
+	if got := p.frozenColumns(); got != 2 {
+		t.Errorf("frozenColumns = %d, want 2 (accent bar and checkbox)", got)
+	}
+
+	p.SetAccents(nil)
+	if strings.Contains(p.View(), "▌") {
+		t.Fatal("accent bar still shown after clearing accents")
+	}
+}
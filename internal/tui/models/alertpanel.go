@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/alerts"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// AlertPanel is the modal overlay listing the configured alert rules, with
+// how often each has fired and whether it's muted, above the alerts fired
+// this session, newest first. Like ErrorPanel it only holds render state —
+// the rules and alerts are alerts.Engine's, and MainPage pushes a fresh
+// copy whenever they change.
+type AlertPanel struct {
+	keyed
+	rules  []alerts.RuleStatus
+	fired  []alerts.Alert
+	cursor int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewAlertPanel() *AlertPanel {
+	return &AlertPanel{}
+}
+
+// SetAlerts replaces the listed rules and alerts, keeping the cursor (on
+// the rules) in range.
+func (p *AlertPanel) SetAlerts(rules []alerts.RuleStatus, fired []alerts.Alert) {
+	p.rules, p.fired = rules, fired
+	p.cursor = max(0, min(p.cursor, len(rules)-1))
+}
+
+// Selected returns the rule under the cursor.
+func (p *AlertPanel) Selected() (alerts.RuleStatus, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.rules) {
+		return alerts.RuleStatus{}, false
+	}
+	return p.rules[p.cursor], true
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (p *AlertPanel) SetSize(w, h int) {
+	p.width, p.height = w, h
+	p.innerW = max(20, w*4/5-6)
+	p.innerH = max(6, h*4/5-5)
+}
+
+func (p *AlertPanel) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	km := p.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		p.cursor--
+	case key.Matches(press, km.Down):
+		p.cursor++
+	case key.Matches(press, km.Top):
+		p.cursor = 0
+	case key.Matches(press, km.Bottom):
+		p.cursor = len(p.rules) - 1
+	}
+	p.cursor = max(0, min(p.cursor, len(p.rules)-1))
+	return nil
+}
+
+func (p *AlertPanel) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	firedStyle := lipgloss.NewStyle().Foreground(pal.Red)
+	mutedStyle := lipgloss.NewStyle().Foreground(pal.Overlay0).Strikethrough(true)
+	sep := lipgloss.NewStyle().Foreground(pal.Overlay0).Render(strings.Repeat("─", p.innerW))
+
+	// The rules get up to half; the fired alerts the rest.
+	rulesH := max(2, min(len(p.rules), p.innerH/2))
+	var lines []string
+	if len(p.rules) == 0 {
+		lines = append(lines, dim.Render("No alert rules. Add alert_rules to the config: a log pattern, a restart count or pod statuses."))
+	}
+	start := max(0, p.cursor-rulesH+1)
+	for i := start; i < len(p.rules) && len(lines) < rulesH; i++ {
+		r := p.rules[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		name := r.Name
+		if r.Muted {
+			name = mutedStyle.Render(name) + dim.Render(" (muted)")
+		}
+		where := "any context"
+		if r.Context != "" {
+			where = r.Context
+		}
+		count := dim.Render("never fired")
+		if r.Fired > 0 {
+			count = firedStyle.Render(fmt.Sprintf("fired %d×, last %s", r.Fired, r.LastFired.Format("15:04:05")))
+		}
+		line := fmt.Sprintf("%s%s  %s  %s  %s", marker, name, dim.Render(textwidth.Sanitize(r.Condition)), dim.Render(where), count)
+		lines = append(lines, ansi.Truncate(line, p.innerW, "…"))
+	}
+	for len(lines) < rulesH {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, sep)
+	if len(p.fired) == 0 {
+		lines = append(lines, dim.Render("No alerts this session."))
+	}
+	for _, a := range p.fired {
+		if len(lines) >= p.innerH {
+			break
+		}
+		line := fmt.Sprintf("%s  %s  %s  %s", dim.Render(a.At.Format("15:04:05")), firedStyle.Render(a.Rule),
+			dim.Render(a.Namespace+"/"+a.Pod+" ("+a.Context+")"), textwidth.Sanitize(a.Text))
+		lines = append(lines, ansi.Truncate(line, p.innerW, "…"))
+	}
+	for len(lines) < p.innerH {
+		lines = append(lines, "")
+	}
+
+	footer := "↑/↓ move • m mute/unmute rule • c clear alerts • esc close"
+	return renderOverlayBox(p.width, p.height, p.innerW, fmt.Sprintf("Alerts: %d", len(p.fired)), strings.Join(lines, "\n"), footer)
+}
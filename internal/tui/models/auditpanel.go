@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/audit"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// AuditPanel is the modal overlay reviewing the audit log: every action
+// recorded, across sessions, newest first, with the whole of the one under
+// the cursor. Failed actions are red. MainPage reads the file each time it
+// opens the panel.
+type AuditPanel struct {
+	keyed
+	entries []audit.Entry
+	path    string
+	cursor  int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewAuditPanel() *AuditPanel {
+	return &AuditPanel{}
+}
+
+// SetEntries replaces the listed entries (newest first), read from path,
+// and puts the cursor on the newest.
+func (p *AuditPanel) SetEntries(entries []audit.Entry, path string) {
+	p.entries = entries
+	p.path = path
+	p.cursor = 0
+}
+
+// Selected returns the entry under the cursor.
+func (p *AuditPanel) Selected() (audit.Entry, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.entries) {
+		return audit.Entry{}, false
+	}
+	return p.entries[p.cursor], true
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (p *AuditPanel) SetSize(w, h int) {
+	p.width, p.height = w, h
+	p.innerW = max(20, w*4/5-6)
+	p.innerH = max(6, h*4/5-5)
+}
+
+func (p *AuditPanel) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	listH := max(3, p.innerH/2)
+	km := p.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		p.cursor--
+	case key.Matches(press, km.Down):
+		p.cursor++
+	case key.Matches(press, km.Scroll):
+		p.cursor += scrollStep(km, press, listH)
+	case key.Matches(press, km.Top):
+		p.cursor = 0
+	case key.Matches(press, km.Bottom):
+		p.cursor = len(p.entries) - 1
+	}
+	p.cursor = max(0, min(p.cursor, len(p.entries)-1))
+	return nil
+}
+
+func (p *AuditPanel) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	label := lipgloss.NewStyle().Foreground(pal.Blue)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	failed := lipgloss.NewStyle().Foreground(pal.Red)
+	sep := lipgloss.NewStyle().Foreground(pal.Overlay0).Render(strings.Repeat("─", p.innerW))
+
+	// The list gets the top half; the selected entry in full the rest.
+	listH := max(3, p.innerH/2)
+	var lines []string
+	if len(p.entries) == 0 {
+		lines = append(lines, dim.Render("Nothing recorded in "+p.path+" yet."))
+	}
+	start := max(0, p.cursor-listH+1)
+	for i := start; i < len(p.entries) && len(lines) < listH; i++ {
+		e := p.entries[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		what := textwidth.Sanitize(e.Action + " " + e.Resource())
+		if e.Error != "" {
+			what = failed.Render(what + " (failed)")
+		}
+		line := fmt.Sprintf("%s%s  %s  %s", marker, dim.Render(e.Time.Local().Format("2006-01-02 15:04:05")), dim.Render(textwidth.Sanitize(e.Context)), what)
+		lines = append(lines, ansi.Truncate(line, p.innerW, "…"))
+	}
+	for len(lines) < listH {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, sep)
+	if e, ok := p.Selected(); ok {
+		row := func(name, value string) string {
+			return label.Render(fmt.Sprintf("%-9s", name)) + " " + textwidth.Sanitize(value)
+		}
+		detail := []string{
+			row("time", e.Time.Local().Format("2006-01-02 15:04:05 MST")),
+			row("context", e.Context),
+			row("user", e.User),
+			row("action", e.Action),
+			row("resource", e.Resource()),
+		}
+		if e.Detail != "" {
+			detail = append(detail, row("detail", e.Detail))
+		}
+		if e.Error != "" {
+			detail = append(detail, label.Render(fmt.Sprintf("%-9s", "error"))+" "+failed.Render(textwidth.Sanitize(e.Error)))
+		}
+		for _, l := range detail {
+			if len(lines) >= p.innerH {
+				lines[len(lines)-1] = dim.Render("…")
+				break
+			}
+			lines = append(lines, ansi.Truncate(l, p.innerW, "…"))
+		}
+	}
+	for len(lines) < p.innerH {
+		lines = append(lines, "")
+	}
+
+	footer := "↑/↓ move • esc close"
+	return renderOverlayBox(p.width, p.height, p.innerW, fmt.Sprintf("Audit log: %d", len(p.entries)), strings.Join(lines, "\n"), footer)
+}
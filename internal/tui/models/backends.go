@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ServiceBackendLines renders the info panel body for what's behind a
+// Service: a summary line, then each endpoint with the pod and node it's
+// on, ready ones green, terminating yellow, the rest red.
+func ServiceBackendLines(backends []k8s.ServiceBackend) []string {
+	p := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	readyStyle := lipgloss.NewStyle().Foreground(p.Green)
+	notReadyStyle := lipgloss.NewStyle().Foreground(p.Red)
+	terminatingStyle := lipgloss.NewStyle().Foreground(p.Yellow)
+
+	if len(backends) == 0 {
+		return []string{dim.Render("No endpoints: the selector matches no pods, or the service has none.")}
+	}
+
+	ready := 0
+	for _, b := range backends {
+		if b.Ready && !b.Terminating {
+			ready++
+		}
+	}
+	lines := []string{
+		fmt.Sprintf("%d of %d endpoints ready", ready, len(backends)),
+		"",
+		dim.Render(fmt.Sprintf("%-16s  %-40s  %-24s  %-11s  %s", "ADDRESS", "POD", "NODE", "READY", "PORTS")),
+	}
+	for _, b := range backends {
+		pod, node := b.Pod, b.Node
+		if pod == "" {
+			pod = "—"
+		}
+		if node == "" {
+			node = "—"
+		}
+		state, style := "NotReady", notReadyStyle
+		switch {
+		case b.Terminating:
+			state, style = "Terminating", terminatingStyle
+		case b.Ready:
+			state, style = "Ready", readyStyle
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%-16s  %-40s  %-24s  %-11s  %s", b.Address, pod, node, state, strings.Join(b.Ports, ", "))))
+	}
+	return lines
+}
@@ -1,10 +1,12 @@
 package models
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	btable "github.com/evertras/bubble-table/table"
 	"github.com/ktails/ktails/internal/tui/msgs"
 )
 
@@ -15,6 +17,7 @@ func samplePodRows(n int) []msgs.RowData {
 		rows[i] = msgs.RowData{
 			msgs.PodKeyName:       "pod-with-a-fairly-long-name-" + strings.Repeat("x", i%5),
 			msgs.PodKeyNamespace:  "ns",
+			msgs.PodKeyReady:      "1/1",
 			msgs.PodKeyStatus:     statuses[i%len(statuses)],
 			msgs.PodKeyRestarts:   "0",
 			msgs.PodKeyAge:        "1d",
@@ -215,6 +218,70 @@ func TestPodPageFilterSearchesFullRowSetNotJustWindow(t *testing.T) {
 	}
 }
 
+func TestPodPageFilterByQoSAndPriorityClass(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(60, 20)
+	p.SetFocused(true)
+	rows := samplePodRows(6)
+	for i, row := range rows {
+		row[msgs.PodKeyName] = fmt.Sprintf("pod-%d", i)
+		row[msgs.PodKeyQoS] = []string{"Guaranteed", "Burstable", "BestEffort"}[i%3]
+	}
+	rows[5][msgs.PodKeyPriority] = "system-node-critical"
+	p.SetRows(rows)
+
+	p.Update(tea.KeyPressMsg{Code: '/'})
+	typeText(p, "qos:best")
+	if _, matches, _, _ := p.FilterStatus(); matches != 2 {
+		t.Fatalf("expected 2 BestEffort pods, got %d", matches)
+	}
+
+	// Terms combine: BestEffort *and* named like pod-5.
+	typeText(p, " 5")
+	if _, matches, _, _ := p.FilterStatus(); matches != 1 || p.SelectedRow()[msgs.PodKeyName] != "pod-5" {
+		t.Fatalf("expected only pod-5, got %d match(es), cursor on %v", matches, p.SelectedRow()[msgs.PodKeyName])
+	}
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	p.Update(tea.KeyPressMsg{Code: '/'})
+	typeText(p, "priority:system-")
+	if _, matches, _, _ := p.FilterStatus(); matches != 1 {
+		t.Fatalf("expected 1 pod with a system- priority class, got %d", matches)
+	}
+}
+
+// Dismissing a filter chip drops one term through SetFilter, which must
+// commit the remaining query (not leave typing mode on) and re-match.
+func TestPodPageSetFilterReplacesCommittedQuery(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(60, 20)
+	p.SetFocused(true)
+	rows := samplePodRows(6)
+	for i, row := range rows {
+		row[msgs.PodKeyName] = fmt.Sprintf("pod-%d", i)
+		row[msgs.PodKeyQoS] = []string{"Guaranteed", "Burstable", "BestEffort"}[i%3]
+	}
+	p.SetRows(rows)
+
+	p.Update(tea.KeyPressMsg{Code: '/'})
+	typeText(p, "qos:best 5")
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	p.SetFilter("qos:best")
+	query, matches, typing, ok := p.FilterStatus()
+	if !ok || typing || query != "qos:best" || matches != 2 {
+		t.Fatalf("expected committed qos:best with 2 matches, got %q matches=%d typing=%v ok=%v", query, matches, typing, ok)
+	}
+
+	p.SetFilter("")
+	if _, _, _, ok := p.FilterStatus(); ok {
+		t.Fatalf("expected an empty SetFilter to clear the filter")
+	}
+	if got := p.activeLen(); got != 6 {
+		t.Fatalf("expected all 6 rows back, got %d", got)
+	}
+}
+
 func TestPodPageScrollPersistsAcrossRefreshResetsOnResize(t *testing.T) {
 	p := NewPodPageModel(nil)
 	p.SetSize(30, 20)
@@ -266,6 +333,74 @@ func TestPodRowKeyAndCheckToggle(t *testing.T) {
 	}
 }
 
+func TestPodPageBrowseByDeployment(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(60, 20)
+	rows := samplePodRows(5)
+	for i, deployment := range []string{"api", "api", "web", "api", ""} {
+		rows[i][msgs.PodKeyName] = fmt.Sprintf("pod-%d", i)
+		rows[i][msgs.PodKeyDeployment] = deployment
+		rows[i][msgs.PodKeyRestarts] = "1"
+	}
+	p.SetRows(rows)
+	p.ToggleByDeployment()
+
+	if p.SelectedRow() != nil {
+		t.Fatal("a Deployment's row is not a pod")
+	}
+	if got := p.activeLen(); got != 3 {
+		t.Fatalf("expected 3 groups (api, web, no deployment), got %d", got)
+	}
+	api := p.activeRow(0)
+	if api[msgs.PodKeyName] != "api" || api[msgs.PodKeyStatus] != "1/3" || api[msgs.PodKeyRestarts] != "3" {
+		t.Fatalf("unexpected api group row %v", api)
+	}
+
+	p.moveCursor(1)
+	if !p.OpenGroup() || p.Group() != "web" {
+		t.Fatalf("expected to open web, got %q", p.Group())
+	}
+	if row := p.SelectedRow(); row == nil || row[msgs.PodKeyName] != "pod-2" {
+		t.Fatalf("expected web's pod under the cursor, got %v", row)
+	}
+
+	if !p.CloseGroup() || p.Group() != "" {
+		t.Fatal("expected to be back at the Deployments")
+	}
+	if p.activeRow(p.cursorIdx)[msgs.PodKeyName] != "web" {
+		t.Error("expected the cursor back on web")
+	}
+
+	p.ToggleByDeployment()
+	if p.activeLen() != 5 {
+		t.Fatalf("expected the flat list back, got %d rows", p.activeLen())
+	}
+}
+
+func TestPodPageOpenDeployment(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(60, 20)
+	rows := samplePodRows(4)
+	for i, deployment := range []string{"api", "web", "api", "web"} {
+		rows[i][msgs.PodKeyName] = fmt.Sprintf("pod-%d", i)
+		rows[i][msgs.PodKeyDeployment] = deployment
+	}
+	p.SetRows(rows)
+	p.SetFilter("pod-0")
+
+	p.OpenDeployment("ctx-a", "ns", "web")
+	if p.Group() != "web" || p.activeLen() != 2 {
+		t.Fatalf("expected web's 2 pods, got %q with %d rows", p.Group(), p.activeLen())
+	}
+	if row := p.SelectedRow(); row == nil || row[msgs.PodKeyName] != "pod-1" {
+		t.Fatalf("expected web's first pod under the cursor, got %v", row)
+	}
+
+	if !p.CloseGroup() || p.activeRow(p.cursorIdx)[msgs.PodKeyName] != "web" {
+		t.Error("expected to go back up with the cursor on web")
+	}
+}
+
 func TestDeploymentReplicaColoringViaStyledCell(t *testing.T) {
 	d := NewDeploymentPage(nil)
 	d.SetSize(40, 20)
@@ -286,3 +421,22 @@ func TestDeploymentReplicaColoringViaStyledCell(t *testing.T) {
 		t.Fatalf("expected the ready/desired cell to carry ANSI color from StyledCell")
 	}
 }
+
+// The cell style funcs run for every styled cell on every frame, so they
+// hand out the theme's prebuilt styles instead of building new ones.
+func TestCellStyleFuncsDoNotAllocate(t *testing.T) {
+	inputs := []btable.StyledCellFuncInput{
+		{Data: "Running"},
+		{Data: "Succeeded"},
+		{Data: "2/3"},
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = statusCellStyle(inputs[0])
+		_ = statusCellStyle(inputs[1])
+		_ = replicaCellStyle(inputs[2])
+		_ = workloadStatusCellStyle(inputs[0])
+	})
+	if allocs != 0 {
+		t.Fatalf("cell style funcs allocated %v times per run, want 0", allocs)
+	}
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/keys"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ColumnChooser is the modal overlay for picking which columns the Pods or
+// Deployments table shows outside wide mode, and in what order: the shown
+// columns first, as they're laid out, then the rest. MainPage reads
+// Chosen() once Enter confirms.
+type ColumnChooser struct {
+	keyed
+	tab     string
+	defs    []columnDef
+	order   []string // every column's name, as listed
+	checked map[string]bool
+	cursor  int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewColumnChooser() *ColumnChooser {
+	return &ColumnChooser{checked: make(map[string]bool)}
+}
+
+// Open resets the chooser for tab ("Pods" or "Deployments") with current
+// the columns it shows now.
+func (c *ColumnChooser) Open(tab string, current []string) {
+	c.tab = tab
+	c.defs = columnDefsFor(tab)
+	c.order = slices.Clone(current)
+	c.checked = make(map[string]bool, len(current))
+	for _, name := range current {
+		c.checked[name] = true
+	}
+	for _, d := range c.defs {
+		if !c.checked[d.name] {
+			c.order = append(c.order, d.name)
+		}
+	}
+	c.cursor = 0
+}
+
+// Tab returns the tab the chooser was opened for.
+func (c *ColumnChooser) Tab() string {
+	return c.tab
+}
+
+// Chosen returns the checked columns' names in list order.
+func (c *ColumnChooser) Chosen() []string {
+	var chosen []string
+	for _, name := range c.order {
+		if c.checked[name] {
+			chosen = append(chosen, name)
+		}
+	}
+	return chosen
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (c *ColumnChooser) SetSize(w, h int) {
+	c.width, c.height = w, h
+	c.innerW = max(20, min(50, w-16))
+	c.innerH = max(3, h*4/5-5)
+}
+
+func (c *ColumnChooser) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	km := c.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		c.cursor--
+	case key.Matches(press, km.Down):
+		c.cursor++
+	case key.Matches(press, km.Toggle):
+		if c.cursor >= 0 && c.cursor < len(c.order) {
+			name := c.order[c.cursor]
+			c.checked[name] = !c.checked[name]
+		}
+	case key.Matches(press, km.MoveCtx):
+		to := c.cursor - 1
+		if keys.Index(press, km.MoveCtx) > 0 {
+			to = c.cursor + 1
+		}
+		if to >= 0 && to < len(c.order) {
+			c.order[c.cursor], c.order[to] = c.order[to], c.order[c.cursor]
+			c.cursor = to
+		}
+	}
+	c.cursor = max(0, min(c.cursor, len(c.order)-1))
+	return nil
+}
+
+func (c *ColumnChooser) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	checkStyle := lipgloss.NewStyle().Foreground(pal.Green)
+
+	lines := []string{dim.Render("    Name (always shown)")}
+	start := max(0, c.cursor-c.innerH+2)
+	for i := start; i < len(c.order) && len(lines) < c.innerH; i++ {
+		name := c.order[i]
+		title := name
+		if j := slices.IndexFunc(c.defs, func(d columnDef) bool { return d.name == name }); j >= 0 {
+			title = c.defs[j].title
+		}
+		marker := "  "
+		if i == c.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		check := dim.Render("[ ]")
+		if c.checked[name] {
+			check = checkStyle.Render("[x]")
+		}
+		lines = append(lines, ansi.Truncate(marker+check+" "+title+dim.Render("  "+name), c.innerW, "…"))
+	}
+	for len(lines) < c.innerH {
+		lines = append(lines, "")
+	}
+
+	title := fmt.Sprintf("Columns: %s (%d shown)", c.tab, len(c.Chosen()))
+	footer := "space toggle • ⇧↑/⇧↓ move • enter apply (none = defaults) • esc cancel"
+	return renderOverlayBox(c.width, c.height, c.innerW, title, strings.Join(lines, "\n"), footer)
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"slices"
+
+	btable "github.com/evertras/bubble-table/table"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// columnDef is a column a table can show: its name in the config's
+// columns lists (config.PodColumns, config.DeploymentColumns) where it has
+// one, the row key it displays and its header.
+type columnDef struct {
+	name, key, title string
+}
+
+// podColumnDefs and deploymentColumnDefs are every column past Name, in
+// wide mode's order.
+var (
+	podColumnDefs = []columnDef{
+		{"namespace", msgs.PodKeyNamespace, "Namespace"},
+		{"status", msgs.PodKeyStatus, "Status"},
+		{"ready", msgs.PodKeyReady, "Ready"},
+		{"restarts", msgs.PodKeyRestarts, "Restarts"},
+		{"age", msgs.PodKeyAge, "Age"},
+		{"node", msgs.PodKeyNode, "Node"},
+		{"node_ip", msgs.PodKeyNodeIP, "Node IP"},
+		{"pod_ip", msgs.PodKeyPodIP, "Pod IP"},
+		{"image", msgs.PodKeyImage, "Image"},
+		{"owner", msgs.PodKeyOwner, "Owner"},
+		{"qos", msgs.PodKeyQoS, "QoS"},
+		{"priority", msgs.PodKeyPriority, "Priority Class"},
+		{"context", msgs.PodKeyContext, "Context"},
+	}
+	deploymentColumnDefs = []columnDef{
+		{"namespace", msgs.DeployKeyNamespace, "Namespace"},
+		{"age", msgs.DeployKeyAge, "Age"},
+		{"replicas", msgs.DeployKeyReplicas, "ReadyReplicas"},
+		{"available", msgs.DeployKeyAvailable, "Available"},
+		{"updated", msgs.DeployKeyUpdated, "Updated"},
+		{"strategy", msgs.DeployKeyStrategy, "Strategy"},
+		{"image", msgs.DeployKeyImage, "Image"},
+		{"context", msgs.DeployKeyContext, "Context"},
+		{"selector", msgs.DeployKeySelector, "Selector"},
+	}
+)
+
+// The columns shown outside wide mode when the config doesn't pick any.
+var (
+	defaultPodColumns        = []string{"namespace", "ready", "status", "restarts", "age"}
+	defaultDeploymentColumns = []string{"age", "replicas", "context"}
+)
+
+// maxColumnWidth caps a narrow-mode column past Name, so one long image
+// name doesn't crowd out the rest; minNameWidth is the least Name is
+// squeezed to before those columns give up room to it (see fitWidths).
+const (
+	maxColumnWidth = 40
+	minNameWidth   = 12
+)
+
+// columnDefsFor returns tab's column catalog.
+func columnDefsFor(tab string) []columnDef {
+	if tab == "Deployments" {
+		return deploymentColumnDefs
+	}
+	return podColumnDefs
+}
+
+// chosenColumns keeps the names among defs, in the order given — names
+// the config has already been checked against, so an unknown one here is
+// just skipped. None left means the defaults.
+func chosenColumns(defs []columnDef, names, defaults []string) []string {
+	var chosen []string
+	for _, name := range names {
+		if slices.ContainsFunc(defs, func(d columnDef) bool { return d.name == name }) && !slices.Contains(chosen, name) {
+			chosen = append(chosen, name)
+		}
+	}
+	if len(chosen) == 0 {
+		return slices.Clone(defaults)
+	}
+	return chosen
+}
+
+// fitColumns builds narrow-mode columns for a table width cells wide:
+// lead (the checkbox, the accent bar) as given, then nameKey and the
+// chosen columns laid out by fitWidths.
+func fitColumns(lead []btable.Column, defs []columnDef, chosen []string, rows []msgs.RowData, nameKey string, width int) []btable.Column {
+	var rest []columnDef
+	for _, name := range chosen {
+		if i := slices.IndexFunc(defs, func(d columnDef) bool { return d.name == name }); i >= 0 {
+			rest = append(rest, defs[i])
+		}
+	}
+	return fitWidths(lead, columnDef{key: nameKey, title: "Name"}, rest, rows, width)
+}
+
+// allColumns builds wide-mode columns: Name then every column in defs,
+// each as wide as the widest value it holds.
+func allColumns(defs []columnDef, rows []msgs.RowData, nameKey string) []btable.Column {
+	cols := []btable.Column{paddedColumn(nameKey, "Name", widestValue(rows, nameKey, "Name"))}
+	for _, d := range defs {
+		cols = append(cols, paddedColumn(d.key, d.title, widestValue(rows, d.key, d.title)))
+	}
+	return cols
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/config"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+func TestColumnDefs_MatchConfigNames(t *testing.T) {
+	for _, tc := range []struct {
+		defs  []columnDef
+		names []string
+	}{
+		{podColumnDefs, config.PodColumns},
+		{deploymentColumnDefs, config.DeploymentColumns},
+	} {
+		var got []string
+		for _, d := range tc.defs {
+			got = append(got, d.name)
+		}
+		if !slices.Equal(got, tc.names) {
+			t.Errorf("column catalog %v doesn't match the config's %v", got, tc.names)
+		}
+	}
+}
+
+func TestColumnChooser_PicksAndOrdersPodColumns(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(120, 20)
+	rows := samplePodRows(3)
+	for _, row := range rows {
+		row["image"] = "registry.example.com/app:1.2.3"
+	}
+	p.SetRows(rows)
+
+	c := NewColumnChooser()
+	c.SetSize(120, 30)
+	c.Open("Pods", p.Columns())
+	press := func(keys ...string) {
+		for _, k := range keys {
+			var msg tea.KeyPressMsg
+			switch k {
+			case "down":
+				msg = tea.KeyPressMsg{Code: tea.KeyDown}
+			case "shift+up":
+				msg = tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModShift}
+			case "space":
+				msg = tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+			}
+			c.Update(msg)
+		}
+	}
+	// Drop namespace, then check image (the 9th listed after the five
+	// shown ones and node, node_ip, pod_ip) and move it up one.
+	press("space")
+	press(slices.Repeat([]string{"down"}, 8)...)
+	press("space", "shift+up")
+
+	want := []string{"ready", "status", "restarts", "age", "image"}
+	if got := c.Chosen(); !slices.Equal(got, want) {
+		t.Fatalf("Chosen() = %v, want %v", got, want)
+	}
+	p.SetColumns(c.Chosen())
+	view := p.View()
+	if strings.Contains(view, "Namespace") || !strings.Contains(view, "Image") {
+		t.Fatalf("expected Image shown and Namespace hidden:\n%s", view)
+	}
+
+	p.SetColumns(nil)
+	if got := p.Columns(); !slices.Equal(got, defaultPodColumns) {
+		t.Errorf("no columns should mean the defaults, got %v", got)
+	}
+}
+
+func TestFitWidths_NameTakesTheRest(t *testing.T) {
+	rows := samplePodRows(3)
+	rows[0][msgs.PodKeyName] = "checkout-api-7d9f8c6b5d-abcde-with-a-really-long-suffix"
+	for _, width := range []int{40, 60, 100, 160} {
+		p := NewPodPageModel(nil)
+		p.SetSize(width, 10)
+		p.SetRows(rows)
+
+		view := p.View()
+		for _, line := range strings.Split(view, "\n") {
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("width %d: line is %d wide: %q", width, w, line)
+			}
+		}
+		if !strings.Contains(view, "checkout-") {
+			t.Errorf("width %d: Name squeezed out:\n%s", width, view)
+		}
+		long := strings.Contains(view, rows[0][msgs.PodKeyName].(string))
+		if width == 160 && !long {
+			t.Errorf("width 160: long name truncated with room to spare:\n%s", view)
+		}
+		if width == 60 && (long || !strings.Contains(view, "…")) {
+			t.Errorf("width 60: long name not ellipsized:\n%s", view)
+		}
+	}
+}
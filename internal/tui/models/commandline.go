@@ -0,0 +1,85 @@
+package models
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// CommandLine is the ":" command line, drawn in place of the status bar.
+// Like PromptDialog it only collects the text: MainPage runs the command
+// Enter hands it.
+type CommandLine struct {
+	input textinput.Model
+	width int
+}
+
+func NewCommandLine() *CommandLine {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	return &CommandLine{input: ti}
+}
+
+// Open shows the command line, empty, completing from candidates: each
+// command mapped to the arguments it completes (see completions).
+func (c *CommandLine) Open(candidates map[string][]string) tea.Cmd {
+	c.input.SetValue("")
+	c.input.ShowSuggestions = true
+	c.input.SetSuggestions(completions(candidates))
+	return c.input.Focus()
+}
+
+// completions lists the whole lines Tab can complete to, sorted: a command
+// taking arguments as "ctx " and then once per argument ("ctx prod"), one
+// without (a nil list) on its own ("q").
+func completions(candidates map[string][]string) []string {
+	var lines []string
+	for _, name := range slices.Sorted(maps.Keys(candidates)) {
+		args := candidates[name]
+		if args == nil {
+			lines = append(lines, name)
+			continue
+		}
+		lines = append(lines, name+" ")
+		for _, arg := range args {
+			lines = append(lines, name+" "+arg)
+		}
+	}
+	return lines
+}
+
+// Value returns the entered command line, without the ":".
+func (c *CommandLine) Value() string {
+	return c.input.Value()
+}
+
+// SetWidth sizes the line to the status bar's width.
+func (c *CommandLine) SetWidth(w int) {
+	c.width = w
+	c.input.SetWidth(max(10, w-lipgloss.Width(commandFooter)-4))
+}
+
+const commandFooter = "tab complete • ↓/↑ next • enter run • esc cancel"
+
+func (c *CommandLine) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return cmd
+}
+
+func (c *CommandLine) View() string {
+	p := styles.CatppuccinMocha()
+	footer := lipgloss.NewStyle().Foreground(p.Overlay1).Render(commandFooter)
+	line := c.input.View()
+	gap := c.width - lipgloss.Width(line) - lipgloss.Width(footer)
+	if gap < 2 {
+		return ansi.Truncate(line, c.width, "…")
+	}
+	return line + strings.Repeat(" ", gap) + footer
+}
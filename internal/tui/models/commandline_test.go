@@ -0,0 +1,31 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestCommandLine_CompletesCommandsAndArguments(t *testing.T) {
+	got := completions(map[string][]string{
+		"q":    nil,
+		"ctx":  {"dev", "prod"},
+		"logs": {},
+	})
+	want := []string{"ctx ", "ctx dev", "ctx prod", "logs ", "q"}
+	if !slices.Equal(got, want) {
+		t.Errorf("completions = %q, want %q", got, want)
+	}
+
+	c := NewCommandLine()
+	c.SetWidth(80)
+	c.Open(map[string][]string{"ctx": {"dev", "prod"}})
+	for _, r := range "ctx p" {
+		c.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	c.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if c.Value() != "ctx prod" {
+		t.Errorf("after Tab, Value() = %q, want %q", c.Value(), "ctx prod")
+	}
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// compareAbsent stands in for a setting one side doesn't have.
+const compareAbsent = "—"
+
+// CompareLines renders a Deployment's settings in two contexts side by
+// side for the InfoPanel, width cells wide: a setting per row under the
+// two contexts' names, the rows that differ marked and highlighted, and a
+// count of them on top. Settings only one side has are compared against
+// compareAbsent, after the first side's own.
+func CompareLines(a, b k8s.DeploymentSpec, width int) []string {
+	p := styles.CatppuccinMocha()
+	headerStyle := lipgloss.NewStyle().Foreground(p.Peach).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	same := lipgloss.NewStyle().Foreground(p.Subtext0)
+	differs := lipgloss.NewStyle().Foreground(p.Yellow)
+	mark := lipgloss.NewStyle().Foreground(p.Red).Bold(true)
+
+	if a.Missing && b.Missing {
+		return []string{dim.Render(fmt.Sprintf("Neither %s nor %s has this deployment.", a.Context, b.Context))}
+	}
+
+	var names []string
+	valuesA := make(map[string]string)
+	valuesB := make(map[string]string)
+	for _, f := range a.Fields {
+		names = append(names, f.Name)
+		valuesA[f.Name] = f.Value
+	}
+	for _, f := range b.Fields {
+		if _, ok := valuesA[f.Name]; !ok {
+			names = append(names, f.Name)
+		}
+		valuesB[f.Name] = f.Value
+	}
+
+	nameW := len("SETTING")
+	for _, name := range names {
+		nameW = max(nameW, textwidth.Width(name))
+	}
+	nameW = min(nameW, max(10, width/3))
+	// "≠ " marker, then name, value and value with two spaces between.
+	valueW := max(8, (width-2-nameW-4)/2)
+
+	column := func(spec k8s.DeploymentSpec) string {
+		if spec.Missing {
+			return spec.Context + " (not found)"
+		}
+		return spec.Context
+	}
+	row := func(name, va, vb string) string {
+		return textwidth.PadRight(textwidth.Fit(name, nameW), nameW) + "  " +
+			textwidth.PadRight(textwidth.Fit(va, valueW), valueW) + "  " +
+			textwidth.Fit(vb, valueW)
+	}
+
+	var body []string
+	diffs := 0
+	for _, name := range names {
+		va, okA := valuesA[name]
+		vb, okB := valuesB[name]
+		if !okA {
+			va = compareAbsent
+		}
+		if !okB {
+			vb = compareAbsent
+		}
+		if okA && okB && va == vb {
+			body = append(body, "  "+same.Render(row(name, va, vb)))
+			continue
+		}
+		diffs++
+		body = append(body, mark.Render("≠ ")+differs.Render(row(name, va, vb)))
+	}
+
+	summary := dim.Render("Identical in both contexts")
+	switch {
+	case a.Missing:
+		summary = mark.Render("Only " + b.Context + " has this deployment")
+	case b.Missing:
+		summary = mark.Render("Only " + a.Context + " has this deployment")
+	case diffs > 0:
+		summary = differs.Render(fmt.Sprintf("%d of %d settings differ", diffs, len(names)))
+	}
+	lines := []string{
+		summary,
+		"",
+		"  " + headerStyle.Render(row("SETTING", column(a), column(b))),
+	}
+	return append(lines, body...)
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ktails/ktails/internal/k8s"
+)
+
+func TestCompareLines_MarksTheSettingsThatDiffer(t *testing.T) {
+	a := k8s.DeploymentSpec{Context: "prod", Fields: []k8s.SpecField{
+		{Name: "replicas", Value: "3"},
+		{Name: "app image", Value: "repo/app:1.4"},
+		{Name: "app env LOG_LEVEL", Value: "info"},
+	}}
+	b := k8s.DeploymentSpec{Context: "staging", Fields: []k8s.SpecField{
+		{Name: "replicas", Value: "3"},
+		{Name: "app image", Value: "repo/app:1.5"},
+		{Name: "app limits.memory", Value: "512Mi"},
+	}}
+
+	lines := CompareLines(a, b, 80)
+	for i := range lines {
+		lines[i] = ansi.Strip(lines[i])
+	}
+	if lines[0] != "3 of 4 settings differ" {
+		t.Errorf("summary = %q", lines[0])
+	}
+	want := map[string]bool{ // setting -> marked as differing
+		"replicas":          false,
+		"app image":         true,
+		"app env LOG_LEVEL": true,
+		"app limits.memory": true,
+	}
+	for _, line := range lines[3:] {
+		body, marked := strings.CutPrefix(line, "≠")
+		for setting, differs := range want {
+			if strings.HasPrefix(strings.TrimSpace(body), setting+" ") {
+				if marked != differs {
+					t.Errorf("%q: marked %v, want %v", line, marked, differs)
+				}
+				delete(want, setting)
+			}
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("settings missing from the compare: %v", want)
+	}
+	if !strings.Contains(lines[len(lines)-1], "—") {
+		t.Errorf("a setting only staging has should show %q on prod's side: %q", compareAbsent, lines[len(lines)-1])
+	}
+}
+
+func TestCompareLines_NamesTheOnlyContextWithTheDeployment(t *testing.T) {
+	a := k8s.DeploymentSpec{Context: "prod", Fields: []k8s.SpecField{{Name: "replicas", Value: "3"}}}
+	b := k8s.DeploymentSpec{Context: "staging", Missing: true}
+	if got := ansi.Strip(CompareLines(a, b, 80)[0]); got != "Only prod has this deployment" {
+		t.Errorf("summary = %q", got)
+	}
+}
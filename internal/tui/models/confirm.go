@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ConfirmDialog is a modal yes/no question guarding a destructive action
+// (or, opened with Ask, just asking). Like PromptDialog it only renders:
+// MainPage holds the action and decides what y/Enter and n/Esc do.
+type ConfirmDialog struct {
+	keyed
+	title   string
+	message string
+	// warn adds the "can't be undone" warning; set by Open, not Ask.
+	warn bool
+	// preview lists what the action changes under the question, scrolled
+	// by offset when it's longer than fits; see SetPreview.
+	preview []string
+	offset  int
+
+	width  int
+	height int
+}
+
+func NewConfirmDialog() *ConfirmDialog {
+	return &ConfirmDialog{}
+}
+
+// Open sets the question shown, warned as irreversible.
+func (d *ConfirmDialog) Open(title, message string) {
+	d.title, d.message, d.warn = title, message, true
+	d.preview, d.offset = nil, 0
+}
+
+// Ask sets a question with nothing irreversible riding on the answer.
+func (d *ConfirmDialog) Ask(title, message string) {
+	d.title, d.message, d.warn = title, message, false
+	d.preview, d.offset = nil, 0
+}
+
+// SetPreview lists what the action asked about changes, one line each
+// (already styled), under the question; Open and Ask clear it.
+func (d *ConfirmDialog) SetPreview(lines []string) {
+	d.preview, d.offset = lines, 0
+}
+
+// Reversible drops the warning Open adds, for an action another undoes
+// (uncordon undoes cordon).
+func (d *ConfirmDialog) Reversible() {
+	d.warn = false
+}
+
+// SetSize sizes the dialog to the space it's drawn over.
+func (d *ConfirmDialog) SetSize(w, h int) {
+	d.width, d.height = w, h
+}
+
+// previewH is how many preview lines fit.
+func (d *ConfirmDialog) previewH() int {
+	return max(3, d.height-16)
+}
+
+// Update scrolls the preview; MainPage handles y/n itself.
+func (d *ConfirmDialog) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	km := d.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		d.offset--
+	case key.Matches(press, km.Down):
+		d.offset++
+	case key.Matches(press, km.Scroll):
+		d.offset += scrollStep(km, press, d.previewH())
+	case key.Matches(press, km.Top):
+		d.offset = 0
+	case key.Matches(press, km.Bottom):
+		d.offset = len(d.preview)
+	}
+	d.offset = max(0, min(d.offset, len(d.preview)-d.previewH()))
+	return nil
+}
+
+func (d *ConfirmDialog) View() string {
+	p := styles.CatppuccinMocha()
+	innerW := max(20, min(72, d.width-16))
+	if len(d.preview) > 0 {
+		innerW = max(20, min(100, d.width-16))
+	}
+	body := lipgloss.NewStyle().Foreground(p.Text).Width(innerW).Render(d.message)
+	footer := "y/enter confirm • n/esc cancel"
+	if len(d.preview) > 0 {
+		end := min(len(d.preview), d.offset+d.previewH())
+		lines := make([]string, 0, end-d.offset)
+		for _, l := range d.preview[d.offset:end] {
+			lines = append(lines, ansi.Truncate(l, innerW, "…"))
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "", strings.Join(lines, "\n"))
+		if len(d.preview) > d.previewH() {
+			footer = "↑/↓ scroll • " + footer
+		}
+	}
+	if d.warn {
+		warn := lipgloss.NewStyle().Foreground(p.Red).Bold(true).Render(ansi.Truncate("This can't be undone.", innerW, "…"))
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "", warn)
+	}
+	return renderOverlayBox(d.width, d.height, innerW, d.title, body, footer)
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ContextConflictLines renders the kubeconfig entries renamed at load time
+// for the InfoPanel: one block per clash, the name ktails now shows it under
+// first, then which file kept the original name.
+func ContextConflictLines(conflicts []k8s.ContextConflict) []string {
+	p := styles.CatppuccinMocha()
+	headerStyle := lipgloss.NewStyle().Foreground(p.Peach).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(p.Blue)
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+
+	lines := []string{
+		dim.Render("These names are defined differently by more than one kubeconfig file."),
+		dim.Render("The first file keeps the name; later definitions are loaded renamed."),
+	}
+	for _, c := range conflicts {
+		lines = append(lines,
+			"",
+			headerStyle.Render(fmt.Sprintf("▸ %s %q", c.Kind, c.Name)),
+			fmt.Sprintf("  kept:    %s", dim.Render(c.Origin)),
+			fmt.Sprintf("  renamed: %s  %s", nameStyle.Render(c.Renamed), dim.Render("← "+c.File)),
+		)
+	}
+	return lines
+}
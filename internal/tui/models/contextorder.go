@@ -0,0 +1,130 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+)
+
+// contextSorts are the orders "o" cycles the contexts pane through, by
+// their names in the state file. "manual" is the order set with
+// shift+up/down.
+var contextSorts = []string{"name", "cluster", "recent", "manual"}
+
+// contextOrdering is how the contexts pane orders its contexts, kept
+// across runs in the state file: by sort, with order the manual order and
+// used when each context was last loaded (for "recent").
+type contextOrdering struct {
+	sort  string
+	order []string
+	used  map[string]time.Time
+}
+
+// SetOrdering restores the pane's ordering as saved: sort (one of
+// contextSorts; anything else sorts by name), the manual order and when
+// each context was last loaded.
+func (c *ContextsInfo) SetOrdering(sort string, order []string, used map[string]time.Time) {
+	if !slices.Contains(contextSorts, sort) {
+		sort = contextSorts[0]
+	}
+	if used == nil {
+		used = make(map[string]time.Time)
+	}
+	c.ordering = contextOrdering{sort: sort, order: slices.Clone(order), used: used}
+	c.sortItems()
+}
+
+// Ordering returns the pane's ordering, for saving.
+func (c *ContextsInfo) Ordering() (sort string, order []string, used map[string]time.Time) {
+	return c.ordering.sort, slices.Clone(c.ordering.order), c.ordering.used
+}
+
+// CycleSort switches the pane to the next of contextSorts and returns it.
+func (c *ContextsInfo) CycleSort() string {
+	i := slices.Index(contextSorts, c.ordering.sort)
+	c.ordering.sort = contextSorts[(i+1)%len(contextSorts)]
+	c.sortItems()
+	return c.ordering.sort
+}
+
+// MoveCursorContext moves the context under the cursor delta places up
+// (negative) or down the list, the cursor with it, switching the pane to
+// the manual order — which starts from the order on screen; false at
+// either end.
+func (c *ContextsInfo) MoveCursorContext(delta int) bool {
+	items := c.list.Items()
+	idx := c.list.Index()
+	to := idx + delta
+	if idx < 0 || idx >= len(items) || to < 0 || to >= len(items) {
+		return false
+	}
+	items[idx], items[to] = items[to], items[idx]
+	c.ordering.sort = "manual"
+	c.ordering.order = c.ordering.order[:0]
+	for _, item := range items {
+		if ctx, ok := item.(contextList); ok {
+			c.ordering.order = append(c.ordering.order, ctx.Name)
+		}
+	}
+	c.list.SetItems(items)
+	c.list.Select(to)
+	return true
+}
+
+// touch records that context was just loaded, for the "recent" order.
+func (c *ContextsInfo) touch(context string) {
+	if c.ordering.used == nil {
+		c.ordering.used = make(map[string]time.Time)
+	}
+	c.ordering.used[context] = time.Now()
+}
+
+// sortItems puts the listed contexts in the pane's order, keeping the
+// cursor on the context it was on. Ties, and contexts the manual order
+// doesn't know yet, go by name.
+func (c *ContextsInfo) sortItems() {
+	items := c.list.Items()
+	if len(items) == 0 {
+		return
+	}
+	cursor, _, _ := c.CursorContext()
+	rank := make(map[string]int, len(c.ordering.order))
+	for i, name := range c.ordering.order {
+		rank[name] = i
+	}
+	slices.SortStableFunc(items, func(a, b list.Item) int {
+		x, _ := a.(contextList)
+		y, _ := b.(contextList)
+		switch c.ordering.sort {
+		case "cluster":
+			if d := strings.Compare(x.Cluster, y.Cluster); d != 0 {
+				return d
+			}
+		case "recent":
+			if d := c.ordering.used[y.Name].Compare(c.ordering.used[x.Name]); d != 0 {
+				return d
+			}
+		case "manual":
+			rx, okx := rank[x.Name]
+			ry, oky := rank[y.Name]
+			switch {
+			case okx && oky:
+				return rx - ry
+			case okx:
+				return -1
+			case oky:
+				return 1
+			}
+		}
+		return strings.Compare(x.Name, y.Name)
+	})
+	c.list.SetItems(items)
+	for i, item := range items {
+		if ctx, ok := item.(contextList); ok && ctx.Name == cursor {
+			c.list.Select(i)
+			break
+		}
+	}
+}
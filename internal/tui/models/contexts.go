@@ -5,12 +5,17 @@ import (
 	"image/color"
 	"io"
 	"log"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/keys"
 	"github.com/ktails/ktails/internal/tui/msgs"
 	"github.com/ktails/ktails/internal/tui/styles"
 )
@@ -20,11 +25,73 @@ type contextList struct {
 	Name             string
 	Cluster          string
 	DefaultNamespace string
-	Selected         bool
-	IsCurrent        bool
-	IsLoading        bool
-	IsError          bool
-	IsLoaded         bool
+	// File is the base name of the kubeconfig file the context came from,
+	// set only when several were merged.
+	File string
+	// Namespaces picked with the namespace picker; empty means
+	// DefaultNamespace.
+	Namespaces []string
+	Selected   bool
+	IsCurrent  bool
+	IsLoading  bool
+	IsError    bool
+	IsLoaded   bool
+	// Health is what the last connectivity check found (see SetHealth);
+	// Version is the API server's version once it answered, and RTT how
+	// long that answer took.
+	Health  contextHealth
+	Version string
+	RTT     time.Duration
+	// Retry is a note that one of its calls is backing off before a retry,
+	// e.g. "retrying in 3s…" (see SetRetries); "" when none is.
+	Retry string
+}
+
+// contextHealth is whether a context's cluster answers, as its badge shows.
+type contextHealth int
+
+const (
+	healthUnknown  contextHealth = iota // not checked yet
+	healthChecking                      // check in flight
+	healthUp
+	healthDown
+)
+
+// slowRTT is the round trip past which an answering cluster's badge turns
+// from green to yellow.
+const slowRTT = 500 * time.Millisecond
+
+// badge renders the context's health next to its name; "" until checked.
+func (cl contextList) badge(t *styles.Theme) string {
+	if dot := cl.dot(t); dot != "" {
+		return " " + dot
+	}
+	return ""
+}
+
+// dot is the health as a colored dot: green when the cluster answers,
+// yellow when it answers slowly, red when it doesn't.
+func (cl contextList) dot(t *styles.Theme) string {
+	switch cl.Health {
+	case healthChecking:
+		return t.Overlay1.Render("◌")
+	case healthUp:
+		if cl.RTT >= slowRTT {
+			return t.Yellow.Render("●")
+		}
+		return t.Green.Render("●")
+	case healthDown:
+		return t.Red.Render("●")
+	}
+	return ""
+}
+
+// formatRTT formats a round trip as "42ms" or, past a second, "1.2s".
+func formatRTT(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
 }
 
 func (cl contextList) Title() string       { return cl.Name }
@@ -32,8 +99,15 @@ func (cl contextList) Description() string { return cl.DefaultNamespace }
 func (cl contextList) FilterValue() string { return cl.Name }
 
 // contextDelegate is a custom list.ItemDelegate that renders each context with
-// icon-based state indicators and per-item colour coding.
-type contextDelegate struct{}
+// icon-based state indicators and per-item colour coding. It renders every
+// visible item on every frame, so its styles come from theme rather than
+// being built per item.
+type contextDelegate struct {
+	theme *styles.Theme
+	// accents are the selected contexts' colors (see SetAccents), shown as
+	// a bar down each one's left edge.
+	accents map[string]lipgloss.Style
+}
 
 func (d contextDelegate) Height() int                             { return 2 }
 func (d contextDelegate) Spacing() int                            { return 0 }
@@ -45,7 +119,7 @@ func (d contextDelegate) Render(w io.Writer, m list.Model, index int, item list.
 		return
 	}
 
-	p := styles.CatppuccinMocha()
+	t := d.theme
 	isCursor := index == m.Index()
 
 	paneWidth := m.Width()
@@ -53,73 +127,92 @@ func (d contextDelegate) Render(w io.Writer, m list.Model, index int, item list.
 		paneWidth = 30
 	}
 
-	// State icon and colours
+	// State icon and styles
 	var icon string
-	var iconColor, nameColor color.Color
+	var iconStyle, nameStyle lipgloss.Style
 
 	switch {
 	case ctx.IsLoading:
 		icon = "⏳"
-		iconColor = p.Blue
-		nameColor = p.Blue
+		iconStyle = t.Blue
+		nameStyle = t.Blue
 	case ctx.IsError:
 		icon = "✗"
-		iconColor = p.Red
-		nameColor = p.Maroon
+		iconStyle = t.Red
+		nameStyle = t.Maroon
 	case ctx.IsLoaded:
 		icon = "✓"
-		iconColor = p.Green
-		nameColor = p.Text
+		iconStyle = t.Green
+		nameStyle = t.Text
 	case ctx.Selected:
 		icon = "◉"
-		iconColor = p.Mauve
-		nameColor = p.Lavender
+		iconStyle = t.Mauve
+		nameStyle = t.Lavender
 	default:
 		icon = "○"
-		iconColor = p.Overlay1
-		nameColor = p.Subtext0
+		iconStyle = t.Overlay1
+		nameStyle = t.Subtext0
 	}
 
 	currentMark := ""
 	if ctx.IsCurrent {
-		currentMark = " " + lipgloss.NewStyle().Foreground(p.Yellow).Render("★")
+		currentMark = " " + t.Yellow.Render("★")
 	}
 
 	ns := ctx.DefaultNamespace
 	if ns == "" {
 		ns = "default"
 	}
+	if len(ctx.Namespaces) > 0 {
+		ns = strings.Join(ctx.Namespaces, ",")
+	}
 	cluster := ctx.Cluster
 	if cluster == "" {
 		cluster = "—"
 	}
-
-	iconStr := lipgloss.NewStyle().Foreground(iconColor).Render(icon)
-	nameStr := lipgloss.NewStyle().Foreground(nameColor).Bold(ctx.IsLoaded || ctx.Selected).Render(ctx.Name)
-	descStr := lipgloss.NewStyle().Foreground(p.Overlay1).Render(ns + " · " + cluster)
-
-	titleContent := " " + iconStr + " " + nameStr + currentMark
-	descContent := "    " + descStr // indent to align under name
+	if ctx.File != "" {
+		cluster += " · " + ctx.File
+	}
+	switch ctx.Health {
+	case healthUp:
+		if ctx.Version != "" {
+			cluster += " · " + ctx.Version
+		}
+		cluster += " · " + formatRTT(ctx.RTT)
+	case healthDown:
+		cluster += " · unreachable"
+	}
 
 	if isCursor {
 		// Mauve bg + Base fg — canonical Catppuccin selection, matches the pane border accent
-		titleLine := lipgloss.NewStyle().
-			Background(p.Mauve).
-			Foreground(p.Base).
-			Bold(true).
-			Width(paneWidth).
-			Render(" " + icon + " " + ctx.Name + currentMark)
-		descLine := lipgloss.NewStyle().
-			Background(p.Mauve).
-			Foreground(p.Base).
-			Width(paneWidth).
-			Render("    " + ns + " · " + cluster)
-		fmt.Fprintf(w, "%s\n%s", titleLine, descLine)
-	} else {
-		titleLine := lipgloss.NewStyle().Width(paneWidth).Render(titleContent)
-		descLine := lipgloss.NewStyle().Foreground(p.Overlay0).Width(paneWidth).Render(descContent)
+		selected := t.Selected.Width(paneWidth)
+		title := " " + icon + " " + ctx.Name + currentMark + ctx.badge(t)
+		if ctx.Retry != "" {
+			title += " " + ctx.Retry
+		}
+		titleLine := selected.Bold(true).Render(title)
+		descLine := selected.Render("    " + ns + " · " + cluster)
 		fmt.Fprintf(w, "%s\n%s", titleLine, descLine)
+		return
+	}
+
+	iconStr := iconStyle.Render(icon)
+	nameStr := nameStyle.Bold(ctx.IsLoaded || ctx.Selected).Render(ctx.Name)
+	descStr := t.Overlay1.Render(ns + " · " + cluster)
+
+	edge := " "
+	if accent, ok := d.accents[ctx.Name]; ok {
+		edge = accent.Render("▌")
+	}
+	titleContent := edge + iconStr + " " + nameStr + currentMark + ctx.badge(t)
+	if ctx.Retry != "" {
+		titleContent += " " + t.Yellow.Render(ctx.Retry)
 	}
+	descContent := edge + "   " + descStr // indent to align under name
+
+	titleLine := t.Plain.Width(paneWidth).Render(titleContent)
+	descLine := t.Overlay0.Width(paneWidth).Render(descContent)
+	fmt.Fprintf(w, "%s\n%s", titleLine, descLine)
 }
 
 // stripANSI removes ANSI escape sequences for width calculation.
@@ -141,6 +234,7 @@ func stripANSI(s string) string {
 
 // ContextsInfo is the left-pane model for selecting Kubernetes contexts.
 type ContextsInfo struct {
+	keyed
 	Client    *k8s.Client
 	Focused   bool
 	PaneTitle string
@@ -150,6 +244,13 @@ type ContextsInfo struct {
 	isLoading bool
 	// Track what was previously confirmed/selected for diff calculation
 	previouslySelected map[string]bool
+	// ordering is the order contexts are listed in (see contextorder.go).
+	ordering contextOrdering
+	// accents color each selected context's entry and status-bar name.
+	accents map[string]lipgloss.Style
+	// profile is the profile the contexts come from, shown in the title;
+	// empty without profiles configured.
+	profile string
 }
 
 func (c *ContextsInfo) setDimensions() {
@@ -162,7 +263,7 @@ func (c *ContextsInfo) GetDimensions() (w, h int) {
 }
 
 func NewContextInfo(client *k8s.Client) *ContextsInfo {
-	newList := list.New([]list.Item{}, contextDelegate{}, 0, 0)
+	newList := list.New([]list.Item{}, contextDelegate{theme: styles.Mocha()}, 0, 0)
 	newList.SetShowStatusBar(false)
 	newList.SetShowHelp(false)
 	return &ContextsInfo{
@@ -171,6 +272,7 @@ func NewContextInfo(client *k8s.Client) *ContextsInfo {
 		list:               newList,
 		isLoading:          true,
 		previouslySelected: make(map[string]bool),
+		ordering:           contextOrdering{sort: contextSorts[0], used: make(map[string]time.Time)},
 	}
 }
 
@@ -180,7 +282,6 @@ func (c *ContextsInfo) Init() tea.Cmd {
 }
 
 func (c *ContextsInfo) Update(msg tea.Msg) tea.Cmd {
-	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		c.width = msg.Width
@@ -188,22 +289,33 @@ func (c *ContextsInfo) Update(msg tea.Msg) tea.Cmd {
 		c.setDimensions()
 		return nil
 	case tea.KeyPressMsg:
-		switch msg.String() {
-		case "up", "k":
-			c.list, cmd = c.list.Update(msg)
-			return cmd
-		case "down", "j":
-			c.list, cmd = c.list.Update(msg)
-			return cmd
-		case "space":
+		km := c.keyMap()
+		switch {
+		case key.Matches(msg, km.Up):
+			c.list.CursorUp()
+		case key.Matches(msg, km.Down):
+			c.list.CursorDown()
+		case key.Matches(msg, km.Scroll):
+			switch keys.Index(msg, km.Scroll) {
+			case scrollUp:
+				c.list.CursorUp()
+			case scrollDown:
+				c.list.CursorDown()
+			case scrollPageUp:
+				c.list.PrevPage()
+			case scrollPageDown:
+				c.list.NextPage()
+			}
+		case key.Matches(msg, km.Top):
+			c.list.GoToStart()
+		case key.Matches(msg, km.Bottom):
+			c.list.GoToEnd()
+		case key.Matches(msg, km.Toggle):
 			c.toggleSelection()
-			return nil
-		case "enter":
+		case key.Matches(msg, km.Confirm):
 			return c.confirmSelection()
-		default:
-			c.list, cmd = c.list.Update(msg)
-			return cmd
 		}
+		return nil
 	}
 	return nil
 }
@@ -225,6 +337,208 @@ func (c *ContextsInfo) toggleSelection() {
 	c.list.Select(idx)
 }
 
+// CursorContext returns the context under the cursor and its picked
+// namespaces (DefaultNamespace if none were picked).
+func (c *ContextsInfo) CursorContext() (name string, namespaces []string, ok bool) {
+	item, ok := c.list.SelectedItem().(contextList)
+	if !ok {
+		return "", nil, false
+	}
+	if len(item.Namespaces) > 0 {
+		return item.Name, item.Namespaces, true
+	}
+	if item.DefaultNamespace != "" {
+		return item.Name, []string{item.DefaultNamespace}, true
+	}
+	return item.Name, nil, true
+}
+
+// SetNamespaces records the namespaces picked for a context; nil goes back
+// to its DefaultNamespace. It returns the context's selection as it now
+// stands, ok only if the context is part of the confirmed selection, i.e.
+// its watches need moving now.
+func (c *ContextsInfo) SetNamespaces(context string, namespaces []string) (msgs.ContextsSelectedMsg, bool) {
+	items := c.list.Items()
+	for idx, item := range items {
+		ctx, ok := item.(contextList)
+		if !ok || ctx.Name != context {
+			continue
+		}
+		ctx.Namespaces = namespaces
+		items[idx] = ctx
+		c.list.SetItems(items)
+		selection := msgs.ContextsSelectedMsg{
+			ContextName:      ctx.Name,
+			DefaultNamespace: ctx.DefaultNamespace,
+			Namespaces:       ctx.Namespaces,
+		}
+		return selection, c.previouslySelected[context]
+	}
+	return msgs.ContextsSelectedMsg{}, false
+}
+
+// ClickItem moves the cursor to the context drawn on line y of View, or,
+// when the cursor is already there, toggles its selection as Space does.
+// It reports whether a context was hit.
+func (c *ContextsInfo) ClickItem(y int) bool {
+	// View's title line, then the list's empty title bar.
+	y -= 2 + c.list.Styles.TitleBar.GetVerticalFrameSize()
+	d := contextDelegate{}
+	if y < 0 || y%(d.Height()+d.Spacing()) >= d.Height() {
+		return false
+	}
+	idx := c.list.Paginator.Page*c.list.Paginator.PerPage + y/(d.Height()+d.Spacing())
+	if idx >= len(c.list.Items()) || idx >= (c.list.Paginator.Page+1)*c.list.Paginator.PerPage {
+		return false
+	}
+	if idx == c.list.Index() {
+		c.toggleSelection()
+		return true
+	}
+	c.list.Select(idx)
+	return true
+}
+
+// Scroll moves the cursor delta contexts, stopping at either end.
+func (c *ContextsInfo) Scroll(delta int) {
+	idx := max(0, min(c.list.Index()+delta, len(c.list.Items())-1))
+	c.list.Select(idx)
+}
+
+// SelectContext moves the cursor to the named context, reporting whether
+// it's in the list.
+func (c *ContextsInfo) SelectContext(name string) bool {
+	for idx, item := range c.list.Items() {
+		if ctx, ok := item.(contextList); ok && ctx.Name == name {
+			c.list.Select(idx)
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns every context in the list, in list order.
+func (c *ContextsInfo) Names() []string {
+	var names []string
+	for _, item := range c.list.Items() {
+		if ctx, ok := item.(contextList); ok {
+			names = append(names, ctx.Name)
+		}
+	}
+	return names
+}
+
+// Confirmed returns the contexts of the last confirmed selection, in list
+// order, with their picked namespaces.
+func (c *ContextsInfo) Confirmed() []msgs.ContextsSelectedMsg {
+	var confirmed []msgs.ContextsSelectedMsg
+	for _, item := range c.list.Items() {
+		if ctx, ok := item.(contextList); ok && c.previouslySelected[ctx.Name] {
+			confirmed = append(confirmed, msgs.ContextsSelectedMsg{
+				ContextName:      ctx.Name,
+				DefaultNamespace: ctx.DefaultNamespace,
+				Namespaces:       ctx.Namespaces,
+			})
+		}
+	}
+	return confirmed
+}
+
+// Restore selects the named contexts with their namespaces, skipping any no
+// longer in the kubeconfig, and confirms the selection as Enter would.
+func (c *ContextsInfo) Restore(selections map[string][]string) tea.Cmd {
+	items := c.list.Items()
+	restored := false
+	for idx, item := range items {
+		ctx, ok := item.(contextList)
+		if !ok {
+			continue
+		}
+		namespaces, wanted := selections[ctx.Name]
+		if !wanted {
+			continue
+		}
+		ctx.Selected = true
+		ctx.Namespaces = namespaces
+		items[idx] = ctx
+		restored = true
+	}
+	if !restored {
+		return nil
+	}
+	c.list.SetItems(items)
+	return c.confirmSelection()
+}
+
+// Reselect selects the named contexts again, as they were listed, and
+// confirms the selection as Enter would — undoing their deselection.
+func (c *ContextsInfo) Reselect(names []string) tea.Cmd {
+	items := c.list.Items()
+	for idx, item := range items {
+		if ctx, ok := item.(contextList); ok && slices.Contains(names, ctx.Name) {
+			ctx.Selected = true
+			items[idx] = ctx
+		}
+	}
+	c.list.SetItems(items)
+	return c.confirmSelection()
+}
+
+// Reload re-lists the contexts after the kubeconfig changed, keeping each
+// remaining context's selection, namespaces and state, and the cursor on
+// the same context. Contexts that are gone drop out of the confirmed
+// selection as well.
+func (c *ContextsInfo) Reload() {
+	prev := make(map[string]contextList)
+	for _, item := range c.list.Items() {
+		if ctx, ok := item.(contextList); ok {
+			prev[ctx.Name] = ctx
+		}
+	}
+	cursor, _, _ := c.CursorContext()
+
+	c.initContextPane()
+	items := c.list.Items()
+	kept := make(map[string]bool)
+	for idx, item := range items {
+		ctx, ok := item.(contextList)
+		if !ok {
+			continue
+		}
+		kept[ctx.Name] = true
+		if old, ok := prev[ctx.Name]; ok {
+			ctx.Selected, ctx.Namespaces = old.Selected, old.Namespaces
+			ctx.IsLoading, ctx.IsError, ctx.IsLoaded = old.IsLoading, old.IsError, old.IsLoaded
+			ctx.Health, ctx.Version, ctx.RTT = old.Health, old.Version, old.RTT
+			items[idx] = ctx
+		}
+		if ctx.Name == cursor {
+			defer c.list.Select(idx)
+		}
+	}
+	for name := range c.previouslySelected {
+		if !kept[name] {
+			delete(c.previouslySelected, name)
+		}
+	}
+	c.list.SetItems(items)
+}
+
+// SetProfile names the profile the contexts come from, in the pane's
+// title.
+func (c *ContextsInfo) SetProfile(name string) {
+	c.profile = name
+}
+
+// Reset re-lists the contexts from scratch, for a client switched to other
+// kubeconfig files: nothing is selected, loaded or remembered as selected,
+// and the cursor is back on the first context.
+func (c *ContextsInfo) Reset() {
+	clear(c.previouslySelected)
+	c.initContextPane()
+	c.list.Select(0)
+}
+
 // SetContextStates updates loading, error, and loaded state for each context in the list.
 func (c *ContextsInfo) SetContextStates(loading map[string]bool, errors map[string]string, loaded map[string]bool) {
 	items := c.list.Items()
@@ -256,6 +570,98 @@ func (c *ContextsInfo) SetContextStates(loading map[string]bool, errors map[stri
 	}
 }
 
+// SetRetries notes, by context, the calls backing off before a retry; the
+// contexts not in retries have their note cleared.
+func (c *ContextsInfo) SetRetries(retries map[string]string) {
+	items := c.list.Items()
+	updated := false
+	for idx, item := range items {
+		ctx, ok := item.(contextList)
+		if !ok || ctx.Retry == retries[ctx.Name] {
+			continue
+		}
+		ctx.Retry = retries[ctx.Name]
+		items[idx] = ctx
+		updated = true
+	}
+	if updated {
+		c.list.SetItems(items)
+	}
+}
+
+// SetChecking marks context's connectivity check as in flight.
+func (c *ContextsInfo) SetChecking(context string) {
+	c.updateItem(context, func(ctx *contextList) {
+		ctx.Health = healthChecking
+	})
+}
+
+// SetHealth records a finished connectivity check: the API server's
+// version and round trip, or err if the cluster couldn't be reached.
+func (c *ContextsInfo) SetHealth(context, version string, rtt time.Duration, err error) {
+	c.updateItem(context, func(ctx *contextList) {
+		ctx.Health, ctx.Version, ctx.RTT = healthUp, version, rtt
+		if err != nil {
+			ctx.Health, ctx.Version, ctx.RTT = healthDown, "", 0
+		}
+	})
+}
+
+// HealthSummary renders contexts' health for the status bar, each as its
+// dot, name and round trip ("● prod 42ms  ● staging —"); contexts not
+// listed or not yet checked are left out.
+func (c *ContextsInfo) HealthSummary(contexts []string) string {
+	byName := make(map[string]contextList)
+	for _, item := range c.list.Items() {
+		if ctx, ok := item.(contextList); ok {
+			byName[ctx.Name] = ctx
+		}
+	}
+	var parts []string
+	for _, name := range contexts {
+		ctx, ok := byName[name]
+		if !ok || ctx.Health == healthUnknown {
+			continue
+		}
+		rtt := "…"
+		switch ctx.Health {
+		case healthUp:
+			rtt = formatRTT(ctx.RTT)
+		case healthDown:
+			rtt = "—"
+		}
+		label := name
+		if accent, ok := c.accents[name]; ok {
+			label = accent.Render(name)
+		}
+		parts = append(parts, ctx.dot(styles.Mocha())+" "+label+" "+rtt)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// SetAccents sets each selected context's accent (see
+// styles.ContextAccents); nil clears them.
+func (c *ContextsInfo) SetAccents(accents map[string]color.Color) {
+	c.accents = make(map[string]lipgloss.Style, len(accents))
+	for name, col := range accents {
+		c.accents[name] = lipgloss.NewStyle().Foreground(col)
+	}
+	c.list.SetDelegate(contextDelegate{theme: styles.Mocha(), accents: c.accents})
+}
+
+// updateItem applies update to the named context's item, if listed.
+func (c *ContextsInfo) updateItem(context string, update func(*contextList)) {
+	items := c.list.Items()
+	for idx, item := range items {
+		if ctx, ok := item.(contextList); ok && ctx.Name == context {
+			update(&ctx)
+			items[idx] = ctx
+			c.list.SetItems(items)
+			return
+		}
+	}
+}
+
 // getAllContextStates returns currently selected contexts and contexts that were deselected.
 func (c *ContextsInfo) getAllContextStates() msgs.ContextsStateMsg {
 	selected := []msgs.ContextsSelectedMsg{}
@@ -266,6 +672,7 @@ func (c *ContextsInfo) getAllContextStates() msgs.ContextsStateMsg {
 			selected = append(selected, msgs.ContextsSelectedMsg{
 				ContextName:      ctx.Name,
 				DefaultNamespace: ctx.DefaultNamespace,
+				Namespaces:       ctx.Namespaces,
 			})
 			currentSelected[ctx.Name] = true
 		}
@@ -297,6 +704,7 @@ func (c *ContextsInfo) confirmSelection() tea.Cmd {
 				state.Selected = append(state.Selected, msgs.ContextsSelectedMsg{
 					ContextName:      item.Name,
 					DefaultNamespace: item.DefaultNamespace,
+					Namespaces:       item.Namespaces,
 				})
 				c.previouslySelected[item.Name] = true
 			}
@@ -306,6 +714,9 @@ func (c *ContextsInfo) confirmSelection() tea.Cmd {
 	if len(state.Selected) == 0 && len(state.Deselected) == 0 {
 		return nil
 	}
+	for _, sel := range state.Selected {
+		c.touch(sel.ContextName)
+	}
 
 	return func() tea.Msg { return state }
 }
@@ -314,13 +725,14 @@ func (c *ContextsInfo) View() string {
 	if c.isLoading {
 		return ""
 	}
-	p := styles.CatppuccinMocha()
-	title := lipgloss.NewStyle().
-		Foreground(p.Flamingo).
-		Bold(true).
-		Padding(0, 1).
-		Width(c.width).
-		Render("Contexts")
+	label := "Contexts"
+	if c.profile != "" {
+		label += " · " + c.profile
+	}
+	if c.ordering.sort != contextSorts[0] {
+		label += " · by " + c.ordering.sort
+	}
+	title := styles.Mocha().PaneTitle.Width(c.width).Render(label)
 	return lipgloss.JoinVertical(lipgloss.Left, title, c.list.View())
 }
 
@@ -331,19 +743,25 @@ func (c *ContextsInfo) initContextPane() {
 	}
 
 	currentCtx := c.Client.GetCurrentContext()
+	merged := len(c.Client.KubeconfigFiles()) > 1
 	itemList := make([]list.Item, 0, len(rawContextsList))
 
 	for _, ctxInfo := range rawContextsList {
-		itemList = append(itemList, contextList{
+		item := contextList{
 			Name:             ctxInfo.Name,
 			Cluster:          ctxInfo.Cluster,
 			DefaultNamespace: ctxInfo.DefaultNamespace,
 			Selected:         false,
 			IsCurrent:        ctxInfo.Name == currentCtx,
-		})
+		}
+		if merged {
+			item.File = filepath.Base(ctxInfo.File)
+		}
+		itemList = append(itemList, item)
 	}
 
 	c.list.SetItems(itemList)
+	c.sortItems()
 	c.list.Title = "" // title rendered manually in View()
 	c.isLoading = false
 }
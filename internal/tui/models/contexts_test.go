@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+func TestContextsHealthSummary_ShowsRoundTrips(t *testing.T) {
+	c := NewContextInfo(nil)
+	c.list.SetItems([]list.Item{
+		contextList{Name: "prod"},
+		contextList{Name: "staging"},
+		contextList{Name: "lab"},
+		contextList{Name: "unchecked"},
+	})
+	c.SetHealth("prod", "v1.29.3", 42*time.Millisecond, nil)
+	c.SetHealth("staging", "v1.28.0", 1200*time.Millisecond, nil)
+	c.SetHealth("lab", "", 0, errors.New("dial tcp: i/o timeout"))
+
+	got := ansi.Strip(c.HealthSummary([]string{"prod", "staging", "lab", "unchecked"}))
+	want := "● prod 42ms  ● staging 1.2s  ● lab —"
+	if got != want {
+		t.Fatalf("HealthSummary = %q, want %q", got, want)
+	}
+
+	theme := styles.Mocha()
+	if dot := (contextList{Health: healthUp, RTT: 1200 * time.Millisecond}).dot(theme); dot != theme.Yellow.Render("●") {
+		t.Errorf("a slow cluster's dot = %q, want the yellow one", dot)
+	}
+}
+
+func contextNames(c *ContextsInfo) []string {
+	var names []string
+	for _, item := range c.list.Items() {
+		names = append(names, item.(contextList).Name)
+	}
+	return names
+}
+
+func TestContextsOrdering_SortsAndMoves(t *testing.T) {
+	c := NewContextInfo(nil)
+	c.list.SetItems([]list.Item{
+		contextList{Name: "staging", Cluster: "b"},
+		contextList{Name: "dev", Cluster: "c"},
+		contextList{Name: "prod", Cluster: "a"},
+	})
+	now := time.Now()
+	c.SetOrdering("recent", nil, map[string]time.Time{"prod": now.Add(-time.Hour), "dev": now})
+	if got, want := strings.Join(contextNames(c), ","), "dev,prod,staging"; got != want {
+		t.Fatalf("by recent = %s, want %s", got, want)
+	}
+
+	if sort := c.CycleSort(); sort != "manual" {
+		t.Fatalf("after recent comes %q, want manual", sort)
+	}
+	c.list.Select(2) // staging
+	if !c.MoveCursorContext(-1) {
+		t.Fatal("staging should move up")
+	}
+	if got, want := strings.Join(contextNames(c), ","), "dev,staging,prod"; got != want {
+		t.Errorf("after moving staging up = %s, want %s", got, want)
+	}
+	if name, _, _ := c.CursorContext(); name != "staging" {
+		t.Errorf("cursor should move with the context, is on %q", name)
+	}
+	if sort, order, _ := c.Ordering(); sort != "manual" || strings.Join(order, ",") != "dev,staging,prod" {
+		t.Errorf("Ordering = %s %v, want the manual order to be kept", sort, order)
+	}
+
+	c.CycleSort() // name
+	c.CycleSort() // cluster
+	if got, want := strings.Join(contextNames(c), ","), "prod,staging,dev"; got != want {
+		t.Errorf("by cluster = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,342 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// CustomResourcePage is the cr tab: the resources of whichever type was
+// picked for it (any group/version/resource the contexts serve, custom or
+// not) in a generic table — name, namespace, the CRD's printer columns,
+// age. Like the ing tab it's re-listed rather than watched, and has no
+// wide mode.
+type CustomResourcePage struct {
+	keyed
+	table btable.Model
+
+	// resource is the type shown; its zero value until one is picked.
+	resource  k8s.APIResource
+	byContext map[string]k8s.CustomResourceList
+	errs      map[string]string // by context, e.g. forbidden
+	// columns are the printer columns of the first context (by name) that
+	// listed the type — every context's CRD is expected to agree.
+	columns []k8s.PrinterColumn
+	items   []k8s.CustomResource
+
+	cachedView string
+	viewDirty  bool
+	focused    bool
+	tableW     int
+	tableH     int
+
+	// filter is over resource names.
+	filter rowFilter
+
+	// cursorIdx/windowStart/windowSize: see the identical fields on PodPage
+	// in pods.go. cursorIdx is a position in the filter's index space.
+	cursorIdx   int
+	windowStart int
+	windowSize  int
+}
+
+func NewCustomResourcePage() *CustomResourcePage {
+	g := &CustomResourcePage{
+		byContext:  make(map[string]k8s.CustomResourceList),
+		errs:       make(map[string]string),
+		viewDirty:  true,
+		windowSize: defaultRowWindowSize,
+	}
+	g.table = newBubbleTable(g.tableColumns())
+	return g
+}
+
+func (g *CustomResourcePage) Init() tea.Cmd {
+	return nil
+}
+
+func (g *CustomResourcePage) Update(msg tea.Msg) tea.Cmd {
+	if !g.focused {
+		return nil
+	}
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	if g.filter.filtering {
+		g.filter.handleKey(g.keyMap(), press, len(g.items), g.filterMatch)
+		g.jumpTo(0)
+		return nil
+	}
+	km := g.keyMap()
+	switch {
+	case key.Matches(press, km.Down):
+		g.moveCursor(1)
+	case key.Matches(press, km.Up):
+		g.moveCursor(-1)
+	case key.Matches(press, km.Top):
+		g.jumpTo(0)
+	case key.Matches(press, km.Bottom):
+		g.jumpTo(g.filter.len(len(g.items)) - 1)
+	case key.Matches(press, km.Filter):
+		g.filter.filtering = true
+	}
+	return nil
+}
+
+func (g *CustomResourcePage) filterMatch(i int) bool {
+	return strings.Contains(strings.ToLower(g.items[i].Name), strings.ToLower(g.filter.query))
+}
+
+// FilterStatus: see PodPage.FilterStatus in pods.go.
+func (g *CustomResourcePage) FilterStatus() (query string, matches int, typing bool, ok bool) {
+	if !g.filter.filtering && g.filter.query == "" {
+		return "", 0, false, false
+	}
+	return g.filter.query, g.filter.len(len(g.items)), g.filter.filtering, true
+}
+
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (g *CustomResourcePage) SetFilter(query string) {
+	g.filter.set(query, len(g.items), g.filterMatch)
+	g.jumpTo(0)
+}
+
+// The cr tab has no wide columns; these satisfy the interface MainPage
+// drives every resource tab through.
+func (g *CustomResourcePage) ToggleWideMode()                            {}
+func (g *CustomResourcePage) WideMode() bool                             { return false }
+func (g *CustomResourcePage) ScrollLeft()                                {}
+func (g *CustomResourcePage) ScrollRight()                               {}
+func (g *CustomResourcePage) ScrollStatus() (offset, total int, ok bool) { return 0, 0, false }
+
+// Resource returns the type shown, and whether one has been picked.
+func (g *CustomResourcePage) Resource() (k8s.APIResource, bool) {
+	return g.resource, g.resource.Resource != ""
+}
+
+// SetResource switches the tab to another type, dropping every context's
+// resources of the last one.
+func (g *CustomResourcePage) SetResource(resource k8s.APIResource) {
+	g.resource = resource
+	clear(g.byContext)
+	clear(g.errs)
+	g.filter.set("", 0, g.filterMatch)
+	g.cursorIdx, g.windowStart = 0, 0
+	g.rebuild()
+	g.applySize()
+}
+
+// SetList replaces one context's resources, clearing any error it had.
+func (g *CustomResourcePage) SetList(context string, list k8s.CustomResourceList) {
+	g.byContext[context] = list
+	delete(g.errs, context)
+	g.rebuild()
+	g.applySize()
+}
+
+// SetError records why a context's resources couldn't be listed. Its last
+// good list, if any, is dropped rather than shown stale.
+func (g *CustomResourcePage) SetError(context, err string) {
+	delete(g.byContext, context)
+	g.errs[context] = err
+	g.rebuild()
+	g.applySize()
+}
+
+// RemoveContext forgets a deselected context.
+func (g *CustomResourcePage) RemoveContext(context string) {
+	delete(g.byContext, context)
+	delete(g.errs, context)
+	g.rebuild()
+	g.applySize()
+}
+
+// Len is how many resources are listed, for the status bar.
+func (g *CustomResourcePage) Len() int {
+	return len(g.items)
+}
+
+func customResourceKey(r k8s.CustomResource) string {
+	return r.Context + "/" + r.Namespace + "/" + r.Name
+}
+
+// rebuild gathers every context's resources, keeping the cursor on the
+// same resource where it can.
+func (g *CustomResourcePage) rebuild() {
+	var selected string
+	if g.cursorIdx >= 0 && g.cursorIdx < g.filter.len(len(g.items)) {
+		selected = customResourceKey(g.items[g.filter.absolute(g.cursorIdx)])
+	}
+
+	g.items = g.items[:0]
+	g.columns = nil
+	for i, ctx := range slices.Sorted(maps.Keys(g.byContext)) {
+		if i == 0 {
+			g.columns = g.byContext[ctx].Columns
+		}
+		g.items = append(g.items, g.byContext[ctx].Items...)
+	}
+	g.filter.recompute(len(g.items), g.filterMatch)
+
+	total := g.filter.len(len(g.items))
+	for pos := 0; pos < total && selected != ""; pos++ {
+		if customResourceKey(g.items[g.filter.absolute(pos)]) == selected {
+			g.cursorIdx = pos
+			break
+		}
+	}
+	if g.cursorIdx >= total {
+		g.cursorIdx = max(total-1, 0)
+	}
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, total, g.windowSize)
+	g.pushDisplayRows()
+}
+
+// moveCursor: see PodPage.moveCursor in pods.go.
+func (g *CustomResourcePage) moveCursor(delta int) {
+	total := g.filter.len(len(g.items))
+	if total == 0 {
+		return
+	}
+	g.cursorIdx += delta
+	if g.cursorIdx < 0 {
+		g.cursorIdx = total - 1
+	} else if g.cursorIdx >= total {
+		g.cursorIdx = 0
+	}
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, total, g.windowSize)
+	g.pushDisplayRows()
+}
+
+// jumpTo: see PodPage.jumpTo in pods.go.
+func (g *CustomResourcePage) jumpTo(idx int) {
+	total := g.filter.len(len(g.items))
+	if total == 0 {
+		g.cursorIdx = 0
+		g.pushDisplayRows()
+		return
+	}
+	g.cursorIdx = max(0, min(idx, total-1))
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, total, g.windowSize)
+	g.pushDisplayRows()
+}
+
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (g *CustomResourcePage) ClickRow(y int) bool {
+	y -= len(g.errs) // the error lines View puts above the table
+	pos, ok := rowAtLine(y, g.windowStart, g.filter.len(len(g.items)))
+	if ok {
+		g.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (g *CustomResourcePage) ScrollRows(delta int) {
+	g.jumpTo(g.cursorIdx + delta)
+}
+
+func (g *CustomResourcePage) pushDisplayRows() {
+	start, end := windowBounds(g.windowStart, g.filter.len(len(g.items)), g.windowSize)
+	display := make([]btable.Row, 0, end-start)
+	for pos := start; pos < end; pos++ {
+		r := g.items[g.filter.absolute(pos)]
+		data := btable.RowData{
+			msgs.CustomKeyName:      r.Name,
+			msgs.CustomKeyNamespace: r.Namespace,
+			msgs.CustomKeyAge:       r.Age,
+			msgs.CustomKeyContext:   r.Context,
+		}
+		for _, col := range g.columns {
+			data[msgs.CustomKeyColumnPrefix+col.Name] = r.Values[col.Name]
+		}
+		display = append(display, btable.NewRow(data))
+	}
+	g.table = g.table.WithRows(display).WithHighlightedRow(g.cursorIdx - start)
+	g.invalidateView()
+}
+
+func (g *CustomResourcePage) View() string {
+	if g.cachedView != "" && !g.viewDirty {
+		return g.cachedView
+	}
+
+	view := g.table.View()
+	if len(g.errs) > 0 {
+		errStyle := styles.Mocha().Red
+		var lines []string
+		for _, ctx := range slices.Sorted(maps.Keys(g.errs)) {
+			lines = append(lines, errStyle.Render(fmt.Sprintf("⚠ %s: %s", ctx, g.errs[ctx])))
+		}
+		view = strings.Join(lines, "\n") + "\n" + view
+	}
+	g.cachedView = view
+	g.viewDirty = false
+	return view
+}
+
+func (g *CustomResourcePage) SetFocused(f bool) {
+	g.focused = f
+	g.table = g.table.Focused(f)
+	g.invalidateView()
+}
+
+func (g *CustomResourcePage) SetSize(width, h int) {
+	if width < 10 || h < 1 {
+		return
+	}
+	g.tableW, g.tableH = width, h
+	g.applySize()
+}
+
+// applySize: see TopPage.applySize in top.go. The columns are rebuilt too,
+// as they're the shown type's.
+func (g *CustomResourcePage) applySize() {
+	if g.tableW == 0 {
+		return
+	}
+	h := max(3, g.tableH-len(g.errs))
+
+	st := styles.CatppuccinBubbleTableStyle()
+	g.table = newBubbleTable(g.tableColumns()).
+		WithMinimumHeight(h).
+		WithTargetWidth(g.tableW).
+		WithMaxTotalWidth(g.tableW).
+		HeaderStyle(st.Header).
+		HighlightStyle(st.Highlight).
+		WithBaseStyle(st.Base).
+		Focused(g.focused)
+	g.windowSize = rowWindowSizeFor(h)
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, g.filter.len(len(g.items)), g.windowSize)
+	g.pushDisplayRows()
+}
+
+func (g *CustomResourcePage) invalidateView() {
+	g.viewDirty = true
+	g.cachedView = ""
+}
+
+// tableColumns lays out the shown type's columns as kubectl get does:
+// name, namespace (for a namespaced type), the printer columns, age.
+func (g *CustomResourcePage) tableColumns() []btable.Column {
+	cols := []btable.Column{paddedFlexColumn(msgs.CustomKeyName, "Name", 6)}
+	if g.resource.Namespaced {
+		cols = append(cols, paddedFlexColumn(msgs.CustomKeyNamespace, "Namespace", 4))
+	}
+	for _, col := range g.columns {
+		cols = append(cols, paddedFlexColumn(msgs.CustomKeyColumnPrefix+col.Name, col.Name, 3))
+	}
+	return append(cols,
+		paddedFlexColumn(msgs.CustomKeyAge, "Age", 2),
+		paddedFlexColumn(msgs.CustomKeyContext, "Context", 3),
+	)
+}
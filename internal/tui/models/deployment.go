@@ -2,8 +2,11 @@
 package models
 
 import (
+	"image/color"
+	"slices"
 	"strings"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	btable "github.com/evertras/bubble-table/table"
 	"github.com/ktails/ktails/internal/k8s"
@@ -12,13 +15,19 @@ import (
 )
 
 type DeploymentPage struct {
+	keyed
 	Client *k8s.Client
 	table  btable.Model
 	// share contextList
 	ContextName string
 	Namespace   string
 
+	// all is every row SetRows was given; rows what the table lists, all
+	// grouped by context when there are several (see sections.go).
+	all        []msgs.RowData
 	rows       []msgs.RowData
+	sections   contextSections
+	scope      string // see PodPage.SetContextScope
 	rowsSet    bool
 	cachedView string
 	viewDirty  bool
@@ -29,6 +38,13 @@ type DeploymentPage struct {
 	tableH       int
 	wideColCount int
 	scrollable   bool
+	sort         tableSort
+
+	// columns: see the identical field on PodPage.
+	columns []string
+
+	// accents: the per-context bar leading each row (see rowAccents).
+	accents rowAccents
 
 	// filter is a k9s-style "/" filter over the Name column — see rowFilter
 	// in table.go for why this exists instead of bubble-table's own filter.
@@ -43,12 +59,15 @@ type DeploymentPage struct {
 }
 
 func NewDeploymentPage(client *k8s.Client) *DeploymentPage {
-	return &DeploymentPage{
+	d := &DeploymentPage{
 		Client:     client,
-		table:      newBubbleTable(deploymentNarrowColumns()),
 		viewDirty:  true,
 		windowSize: defaultRowWindowSize,
+		columns:    slices.Clone(defaultDeploymentColumns),
+		sections:   contextSections{noun: "deployment"},
 	}
+	d.table = newBubbleTable(d.narrowColumns())
+	return d
 }
 
 func (d *DeploymentPage) Init() tea.Cmd {
@@ -57,26 +76,27 @@ func (d *DeploymentPage) Init() tea.Cmd {
 
 func (d *DeploymentPage) Update(msg tea.Msg) tea.Cmd {
 	if d.focused {
-		if key, ok := msg.(tea.KeyPressMsg); ok {
+		if press, ok := msg.(tea.KeyPressMsg); ok {
 			if d.filter.filtering {
-				d.filter.handleKey(key, len(d.rows), d.filterMatch)
+				d.filter.handleKey(d.keyMap(), press, len(d.rows), d.filterMatch)
 				d.afterFilterChange()
 				return nil
 			}
-			switch key.String() {
-			case "down", "j":
+			km := d.keyMap()
+			switch {
+			case key.Matches(press, km.Down):
 				d.moveCursor(1)
 				return nil
-			case "up", "k":
+			case key.Matches(press, km.Up):
 				d.moveCursor(-1)
 				return nil
-			case "home", "g":
+			case key.Matches(press, km.Top):
 				d.jumpTo(0)
 				return nil
-			case "end", "G":
+			case key.Matches(press, km.Bottom):
 				d.jumpTo(d.activeLen() - 1)
 				return nil
-			case "/":
+			case key.Matches(press, km.Filter):
 				d.filter.filtering = true
 				return nil
 			}
@@ -90,8 +110,12 @@ func (d *DeploymentPage) Update(msg tea.Msg) tea.Cmd {
 }
 
 // filterMatch is the rowFilter matchFn for Deployments: a case-insensitive
-// substring match against the Name column.
+// substring match against the Name column. Context section headers never
+// match.
 func (d *DeploymentPage) filterMatch(i int) bool {
+	if isSection(d.rows[i]) {
+		return false
+	}
 	name, _ := d.rows[i][msgs.DeployKeyName].(string)
 	return strings.Contains(strings.ToLower(name), strings.ToLower(d.filter.query))
 }
@@ -121,6 +145,12 @@ func (d *DeploymentPage) FilterStatus() (query string, matches int, typing bool,
 	return d.filter.query, d.activeLen(), d.filter.filtering, true
 }
 
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (d *DeploymentPage) SetFilter(query string) {
+	d.filter.set(query, len(d.rows), d.filterMatch)
+	d.afterFilterChange()
+}
+
 // moveCursor: see PodPage.moveCursor in pods.go.
 func (d *DeploymentPage) moveCursor(delta int) {
 	total := d.activeLen()
@@ -158,13 +188,34 @@ func (d *DeploymentPage) jumpTo(idx int) {
 	d.invalidateView()
 }
 
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (d *DeploymentPage) ClickRow(y int) bool {
+	pos, ok := rowAtLine(y, d.windowStart, d.activeLen())
+	if ok {
+		d.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (d *DeploymentPage) ScrollRows(delta int) {
+	d.jumpTo(d.cursorIdx + delta)
+}
+
 func (d *DeploymentPage) SetRows(rows []msgs.RowData) {
-	if d.rowsSet && rowsEqual(rows, d.rows) {
+	if d.rowsSet && rowsEqual(rows, d.all) {
 		return
 	}
 
-	d.rows = cloneRows(rows)
+	d.all = cloneRows(rows)
 	d.rowsSet = true
+	d.applyRows()
+}
+
+// applyRows rebuilds the listed rows from d.all, keeping the cursor in
+// range.
+func (d *DeploymentPage) applyRows() {
+	d.rows = d.sections.apply(scopedRows(d.all, d.scope))
 	d.filter.recompute(len(d.rows), d.filterMatch)
 	if d.cursorIdx >= d.activeLen() {
 		d.cursorIdx = max(d.activeLen()-1, 0)
@@ -191,16 +242,22 @@ func (d *DeploymentPage) pushDisplayRows() {
 	display := make([]btable.Row, 0, end-start)
 	for i := start; i < end; i++ {
 		row := d.activeRow(i)
+		if isSection(row) {
+			display = append(display, d.sections.sectionDisplayRow(row, "", d.accents))
+			continue
+		}
 		display = append(display, btable.NewRow(btable.RowData{
 			msgs.DeployKeyName:      row[msgs.DeployKeyName],
 			msgs.DeployKeyAge:       row[msgs.DeployKeyAge],
 			msgs.DeployKeyReplicas:  btable.NewStyledCellWithStyleFunc(row[msgs.DeployKeyReplicas], replicaCellStyle),
 			msgs.DeployKeyContext:   row[msgs.DeployKeyContext],
+			accentKey:               d.accents.cell(row[msgs.DeployKeyContext]),
 			msgs.DeployKeyNamespace: row[msgs.DeployKeyNamespace],
 			msgs.DeployKeyStrategy:  row[msgs.DeployKeyStrategy],
 			msgs.DeployKeyAvailable: row[msgs.DeployKeyAvailable],
 			msgs.DeployKeyUpdated:   row[msgs.DeployKeyUpdated],
 			msgs.DeployKeySelector:  row[msgs.DeployKeySelector],
+			msgs.DeployKeyImage:     row[msgs.DeployKeyImage],
 		}))
 	}
 	d.table = d.table.WithRows(display).WithHighlightedRow(d.cursorIdx - start)
@@ -211,9 +268,9 @@ func (d *DeploymentPage) pushDisplayRows() {
 func (d *DeploymentPage) applyColumns() {
 	var cols []btable.Column
 	if d.wideMode {
-		cols = deploymentWideColumns(d.rows)
+		cols = d.accents.withAccent(withSortIndicator(allColumns(deploymentColumnDefs, d.rows, msgs.DeployKeyName), d.sort))
 	} else {
-		cols = deploymentNarrowColumns()
+		cols = d.narrowColumns()
 	}
 	d.wideColCount = len(cols)
 	d.scrollable = d.wideMode && totalColumnsWidth(cols) > d.tableW
@@ -227,6 +284,42 @@ func (d *DeploymentPage) applyColumns() {
 	}
 }
 
+// narrowColumns is Name and the chosen columns, fitted to the table's
+// width (see fitColumns).
+func (d *DeploymentPage) narrowColumns() []btable.Column {
+	return withSortIndicator(fitColumns(d.accents.columns(), deploymentColumnDefs, d.columns, d.rows, msgs.DeployKeyName, d.tableW), d.sort)
+}
+
+// SetAccents colors each row's leading bar by its context; see
+// PodPage.SetAccents.
+func (d *DeploymentPage) SetAccents(accents map[string]color.Color) {
+	d.accents = newRowAccents(accents)
+	d.applyColumns()
+	d.pushDisplayRows()
+	d.invalidateView()
+}
+
+// SetColumns picks the columns shown after Name outside wide mode; see
+// PodPage.SetColumns.
+func (d *DeploymentPage) SetColumns(names []string) {
+	d.columns = chosenColumns(deploymentColumnDefs, names, defaultDeploymentColumns)
+	d.applyColumns()
+	d.invalidateView()
+}
+
+// Columns returns the columns shown after Name outside wide mode.
+func (d *DeploymentPage) Columns() []string {
+	return slices.Clone(d.columns)
+}
+
+// SetSort marks column's header with the order the rows arrive in; see
+// PodPage.SetSort.
+func (d *DeploymentPage) SetSort(column string, desc bool) {
+	d.sort = tableSort{column: column, desc: desc}
+	d.applyColumns()
+	d.invalidateView()
+}
+
 // ToggleWideMode flips wide mode for this tab (sticky until the next
 // resize) and rebuilds columns to fit the current data.
 func (d *DeploymentPage) ToggleWideMode() {
@@ -272,12 +365,42 @@ func (d *DeploymentPage) View() string {
 }
 
 // SelectedRow returns the raw (un-prefixed) row currently under the cursor,
-// or nil if there are no rows.
+// or nil if there are no rows or it's on a context's section header.
 func (d *DeploymentPage) SelectedRow() msgs.RowData {
 	if d.cursorIdx < 0 || d.cursorIdx >= d.activeLen() {
 		return nil
 	}
-	return d.activeRow(d.cursorIdx)
+	if row := d.activeRow(d.cursorIdx); !isSection(row) {
+		return row
+	}
+	return nil
+}
+
+// SetContextScope: see PodPage.SetContextScope in pods.go.
+func (d *DeploymentPage) SetContextScope(context string) {
+	if context == d.scope {
+		return
+	}
+	d.scope = context
+	d.cursorIdx = 0
+	d.applyRows()
+}
+
+// FoldSection: see PodPage.FoldSection in pods.go.
+func (d *DeploymentPage) FoldSection() bool {
+	var cursor msgs.RowData
+	if d.cursorIdx < d.activeLen() {
+		cursor = d.activeRow(d.cursorIdx)
+	}
+	context, ok := d.sections.fold(d.rows, cursor)
+	if !ok {
+		return false
+	}
+	d.applyRows()
+	if i := sectionAt(context, d.activeLen(), d.activeRow); i >= 0 {
+		d.jumpTo(i)
+	}
+	return true
 }
 
 func (d *DeploymentPage) SetFocused(f bool) {
@@ -294,7 +417,7 @@ func (d *DeploymentPage) SetSize(w, h int) {
 	d.wideMode = false
 
 	st := styles.CatppuccinBubbleTableStyle()
-	d.table = newBubbleTable(deploymentNarrowColumns()).
+	d.table = newBubbleTable(d.narrowColumns()).
 		WithMinimumHeight(h).
 		WithTargetWidth(w).
 		WithMaxTotalWidth(w).
@@ -302,7 +425,7 @@ func (d *DeploymentPage) SetSize(w, h int) {
 		HighlightStyle(st.Highlight).
 		WithBaseStyle(st.Base).
 		Focused(d.focused)
-	d.wideColCount = len(deploymentNarrowColumns())
+	d.wideColCount = len(d.narrowColumns())
 	d.scrollable = false
 	d.windowSize = rowWindowSizeFor(h)
 	d.windowStart = computeWindowStart(d.windowStart, d.cursorIdx, d.activeLen(), d.windowSize)
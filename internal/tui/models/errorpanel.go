@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/state"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ErrorPanel is the error center: the modal overlay listing the session's
+// errors, newest first, with the full text of the one under the cursor.
+// Like PortForwardPanel it only holds render state — the log itself is
+// state.AppState's, and MainPage pushes a fresh copy whenever it changes.
+type ErrorPanel struct {
+	keyed
+	entries []state.ErrorEntry
+	cursor  int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewErrorPanel() *ErrorPanel {
+	return &ErrorPanel{}
+}
+
+// SetEntries replaces the listed errors, keeping the cursor in range.
+func (p *ErrorPanel) SetEntries(entries []state.ErrorEntry) {
+	p.entries = entries
+	p.cursor = max(0, min(p.cursor, len(entries)-1))
+}
+
+// Selected returns the error under the cursor.
+func (p *ErrorPanel) Selected() (state.ErrorEntry, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.entries) {
+		return state.ErrorEntry{}, false
+	}
+	return p.entries[p.cursor], true
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (p *ErrorPanel) SetSize(w, h int) {
+	p.width, p.height = w, h
+	p.innerW = max(20, w*4/5-6)
+	p.innerH = max(6, h*4/5-5)
+}
+
+func (p *ErrorPanel) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	km := p.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		p.cursor--
+	case key.Matches(press, km.Down):
+		p.cursor++
+	case key.Matches(press, km.Top):
+		p.cursor = 0
+	case key.Matches(press, km.Bottom):
+		p.cursor = len(p.entries) - 1
+	}
+	p.cursor = max(0, min(p.cursor, len(p.entries)-1))
+	return nil
+}
+
+func (p *ErrorPanel) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	newStyle := lipgloss.NewStyle().Foreground(pal.Red)
+	sep := lipgloss.NewStyle().Foreground(pal.Overlay0).Render(strings.Repeat("─", p.innerW))
+
+	// The list gets the top half; the selected error's full text the rest.
+	listH := max(3, p.innerH/2)
+	var lines []string
+	if len(p.entries) == 0 {
+		lines = append(lines, dim.Render("No errors this session."))
+	}
+	start := max(0, p.cursor-listH+1)
+	for i := start; i < len(p.entries) && len(lines) < listH; i++ {
+		e := p.entries[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		where := e.Context
+		if where == "" {
+			where = "-"
+		}
+		text := textwidth.Sanitize(strings.SplitN(e.Text, "\n", 2)[0])
+		if !e.Seen {
+			text = newStyle.Render(text)
+		}
+		line := fmt.Sprintf("%s%s  %s  %s", marker, dim.Render(e.At.Format("15:04:05")), dim.Render(where), text)
+		lines = append(lines, ansi.Truncate(line, p.innerW, "…"))
+	}
+	for len(lines) < listH {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, sep)
+	if e, ok := p.Selected(); ok {
+		for _, l := range strings.Split(ansi.Wrap(textwidth.SanitizeLines(e.Text), p.innerW, ""), "\n") {
+			if len(lines) >= p.innerH {
+				lines[len(lines)-1] = dim.Render("…")
+				break
+			}
+			lines = append(lines, l)
+		}
+	}
+	for len(lines) < p.innerH {
+		lines = append(lines, "")
+	}
+
+	footer := "↑/↓ move • r retry context • x dismiss • c clear all • esc close"
+	return renderOverlayBox(p.width, p.height, p.innerW, fmt.Sprintf("Errors: %d", len(p.entries)), strings.Join(lines, "\n"), footer)
+}
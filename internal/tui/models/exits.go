@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ExitHistoryLines renders the info panel body for the container exits seen
+// on tailed pods this session, newest first as given.
+func ExitHistoryLines(exits []k8s.ContainerTermination) []string {
+	p := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	okStyle := lipgloss.NewStyle().Foreground(p.Green)
+	failStyle := lipgloss.NewStyle().Foreground(p.Red).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(p.Blue)
+
+	if len(exits) == 0 {
+		return []string{dim.Render("No container exits seen since these pods were opened.")}
+	}
+
+	lines := []string{dim.Render(fmt.Sprintf("%-19s  %-4s  %-16s  %s", "FINISHED", "EXIT", "REASON", "POD/CONTAINER"))}
+	for _, e := range exits {
+		code := fmt.Sprintf("%-4d", e.ExitCode)
+		if e.ExitCode == 0 {
+			code = okStyle.Render(code)
+		} else {
+			code = failStyle.Render(code)
+		}
+		finished := "—"
+		if !e.FinishedAt.IsZero() {
+			finished = e.FinishedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		reason := e.Reason
+		if reason == "" {
+			reason = "—"
+		}
+		if e.Signal != 0 {
+			reason = fmt.Sprintf("%s (signal %d)", reason, e.Signal)
+		}
+		lines = append(lines, fmt.Sprintf("%-19s  %s  %-16s  %s  %s",
+			finished, code, reason,
+			nameStyle.Render(e.Pod+"/"+e.Container),
+			dim.Render(fmt.Sprintf("%d restart(s) · %s", e.Restarts, e.Context)),
+		))
+		if msg, _, _ := strings.Cut(e.Message, "\n"); msg != "" {
+			lines = append(lines, dim.Render("    "+msg))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,292 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// FileBrowserPage is a modal overlay for poking around a container's
+// filesystem: a directory listing with a cursor, and a scrollable viewer for
+// a file opened from it. The ls/cat/tail execs themselves are run by
+// cmds + MainPage; this only holds what's on screen and which container
+// it's pointed at.
+type FileBrowserPage struct {
+	keyed
+	context   string
+	namespace string
+	pod       string
+	container string
+
+	// dir is the directory entries were listed from; pending is one being
+	// fetched, kept apart so a failed listing leaves dir and entries in sync.
+	dir     string
+	pending string
+	entries []k8s.FileEntry
+	cursor  int
+	offset  int // first listing row shown
+
+	// viewing switches from the listing to file, shown in viewer; the
+	// listing (and its cursor) is kept underneath for Esc to return to.
+	viewing   bool
+	file      string
+	truncated bool
+	viewer    viewport.Model
+
+	loading bool
+	errMsg  string
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewFileBrowserPage() *FileBrowserPage {
+	return &FileBrowserPage{viewer: viewport.New()}
+}
+
+// Open points the browser at a container, starting from dir.
+func (f *FileBrowserPage) Open(kubeContext, namespace, pod, container, dir string) {
+	f.context, f.namespace, f.pod, f.container = kubeContext, namespace, pod, container
+	f.dir = dir
+	f.entries = nil
+	f.viewing = false
+	f.StartListing(dir)
+}
+
+// Target returns the container the browser is pointed at.
+func (f *FileBrowserPage) Target() (kubeContext, namespace, pod, container string) {
+	return f.context, f.namespace, f.pod, f.container
+}
+
+// StartListing marks a listing of dir as in flight.
+func (f *FileBrowserPage) StartListing(dir string) {
+	f.pending = dir
+	f.viewing = false
+	f.loading = true
+	f.errMsg = ""
+}
+
+// SetListing shows a fetched directory listing, directories first. The
+// cursor starts on the first real entry rather than "..".
+func (f *FileBrowserPage) SetListing(dir string, entries []k8s.FileEntry) {
+	f.dir = dir
+	f.loading = false
+	f.errMsg = ""
+
+	sorted := make([]k8s.FileEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			sorted = append(sorted, e)
+		}
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			sorted = append(sorted, e)
+		}
+	}
+	f.entries = sorted
+	f.cursor, f.offset = 0, 0
+	if len(sorted) > 1 && sorted[0].Name == ".." {
+		f.cursor = 1
+	}
+	f.clampOffset()
+}
+
+// StartReading marks a file fetch as in flight.
+func (f *FileBrowserPage) StartReading(file string) {
+	f.file = file
+	f.viewing = true
+	f.loading = true
+	f.errMsg = ""
+	f.viewer.SetContent("Loading " + file + "...")
+	f.viewer.GotoTop()
+}
+
+// SetFile shows a fetched file. Tailed reads open at the bottom, where the
+// newest lines are.
+func (f *FileBrowserPage) SetFile(file, content string, truncated, tail bool) {
+	f.file = file
+	f.viewing = true
+	f.loading = false
+	f.truncated = truncated
+	f.errMsg = ""
+	f.viewer.SetContent(textwidth.SanitizeLines(strings.TrimRight(content, "\n")))
+	if tail {
+		f.viewer.GotoBottom()
+	} else {
+		f.viewer.GotoTop()
+	}
+}
+
+// SetError records a failed listing/read; the previous listing stays put
+// underneath so the user can pick something else.
+func (f *FileBrowserPage) SetError(err string) {
+	f.loading = false
+	f.errMsg = err
+	f.viewing = false
+}
+
+// Dir returns the directory currently listed.
+func (f *FileBrowserPage) Dir() string {
+	return f.dir
+}
+
+// Viewing reports whether a file (rather than the listing) is shown.
+func (f *FileBrowserPage) Viewing() bool {
+	return f.viewing
+}
+
+// CloseFile returns from the file viewer to the listing.
+func (f *FileBrowserPage) CloseFile() {
+	f.viewing = false
+	f.loading = false
+}
+
+// Selected returns the listing entry under the cursor and its full path.
+func (f *FileBrowserPage) Selected() (k8s.FileEntry, string, bool) {
+	if f.viewing || f.cursor < 0 || f.cursor >= len(f.entries) {
+		return k8s.FileEntry{}, "", false
+	}
+	e := f.entries[f.cursor]
+	return e, path.Join(f.dir, e.Name), true
+}
+
+// ParentDir returns the listed directory's parent.
+func (f *FileBrowserPage) ParentDir() string {
+	return path.Dir(f.dir)
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (f *FileBrowserPage) SetSize(w, h int) {
+	f.width, f.height = w, h
+	f.innerW = max(20, w*4/5-6)
+	f.innerH = max(3, h*4/5-5)
+	f.viewer.SetWidth(f.innerW)
+	f.viewer.SetHeight(f.innerH)
+	f.clampOffset()
+}
+
+func (f *FileBrowserPage) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	km := f.keyMap()
+	if f.viewing {
+		if ok {
+			scrollViewport(&f.viewer, km, press)
+			return nil
+		}
+		var cmd tea.Cmd
+		f.viewer, cmd = f.viewer.Update(msg)
+		return cmd
+	}
+
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(press, km.Up):
+		f.cursor--
+	case key.Matches(press, km.Down):
+		f.cursor++
+	case key.Matches(press, km.Scroll):
+		f.cursor += scrollStep(km, press, f.innerH)
+	case key.Matches(press, km.Top):
+		f.cursor = 0
+	case key.Matches(press, km.Bottom):
+		f.cursor = len(f.entries) - 1
+	}
+	f.cursor = max(0, min(f.cursor, len(f.entries)-1))
+	f.clampOffset()
+	return nil
+}
+
+// clampOffset scrolls the listing window just enough to keep the cursor on
+// screen.
+func (f *FileBrowserPage) clampOffset() {
+	rows := f.listRows()
+	if f.cursor < f.offset {
+		f.offset = f.cursor
+	}
+	if f.cursor >= f.offset+rows {
+		f.offset = f.cursor - rows + 1
+	}
+	f.offset = max(0, f.offset)
+}
+
+// listRows is how many listing rows fit, after the error line if any.
+func (f *FileBrowserPage) listRows() int {
+	if f.errMsg != "" {
+		return max(1, f.innerH-1)
+	}
+	return max(1, f.innerH)
+}
+
+func (f *FileBrowserPage) View() string {
+	p := styles.CatppuccinMocha()
+	title := fmt.Sprintf("Files: %s/%s [%s] (%s)  %s", f.namespace, f.pod, f.container, f.context, f.dir)
+
+	if f.viewing {
+		title = fmt.Sprintf("File: %s  (%s/%s [%s])", f.file, f.namespace, f.pod, f.container)
+		if f.truncated {
+			title += "  [truncated]"
+		}
+		footer := "↑/↓ pgup/pgdn scroll • g/G top/bottom • esc back to listing"
+		return renderOverlayBox(f.width, f.height, f.innerW, title, f.viewer.View(), footer)
+	}
+
+	var lines []string
+	if f.errMsg != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(p.Red).Render(ansi.Truncate("Error: "+f.errMsg, f.innerW, "…")))
+	}
+	switch {
+	case f.loading:
+		lines = append(lines, "Loading "+f.pending+"...")
+	case len(f.entries) == 0:
+		lines = append(lines, lipgloss.NewStyle().Foreground(p.Overlay1).Render("(empty)"))
+	default:
+		lines = append(lines, f.renderListing(p)...)
+	}
+	for len(lines) < f.innerH {
+		lines = append(lines, "")
+	}
+
+	footer := "↑/↓ move • enter open • v view • t tail • c copy out • backspace up • esc close"
+	return renderOverlayBox(f.width, f.height, f.innerW, title, strings.Join(lines, "\n"), footer)
+}
+
+func (f *FileBrowserPage) renderListing(p styles.Palette) []string {
+	dirStyle := lipgloss.NewStyle().Foreground(p.Blue).Bold(true)
+	linkStyle := lipgloss.NewStyle().Foreground(p.Teal)
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(p.Mauve).Bold(true)
+
+	end := min(len(f.entries), f.offset+f.listRows())
+	lines := make([]string, 0, end-f.offset)
+	for i := f.offset; i < end; i++ {
+		e := f.entries[i]
+		name := e.Name
+		switch {
+		case e.IsDir():
+			name = dirStyle.Render(name + "/")
+		case e.IsLink():
+			name = linkStyle.Render(name) + dim.Render(" -> "+e.LinkTarget)
+		}
+		marker := "  "
+		if i == f.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		line := fmt.Sprintf("%s%s %7s  %s  %s", marker, dim.Render(e.Mode), HumanBytes(e.Size), dim.Render(fmt.Sprintf("%-12s", e.Modified)), name)
+		lines = append(lines, ansi.Truncate(line, f.innerW, "…"))
+	}
+	return lines
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// InfoPanel is a modal, scrollable read-only overlay for one-off inspection
+// views (a container's resolved env, ...) that don't warrant a pane of their
+// own. Like LogPage and ResourceDetailPage it only holds render state:
+// MainPage fetches the content and decides when it's shown.
+type InfoPanel struct {
+	keyed
+	viewport viewport.Model
+
+	title   string
+	lines   []string
+	loading bool
+	errMsg  string
+	// actions hints the keys MainPage acts on while the panel is up, e.g.
+	// "u undo" on a rollout.
+	actions string
+
+	width  int
+	height int
+}
+
+func NewInfoPanel() *InfoPanel {
+	return &InfoPanel{viewport: viewport.New()}
+}
+
+// StartLoading resets the panel to a placeholder while its content is
+// fetched.
+func (p *InfoPanel) StartLoading(title string) {
+	p.title = title
+	p.lines = nil
+	p.loading = true
+	p.errMsg = ""
+	p.actions = ""
+	p.viewport.SetContent("Loading...")
+	p.viewport.GotoTop()
+}
+
+// SetContent replaces the panel's body.
+func (p *InfoPanel) SetContent(title string, lines []string) {
+	p.title = title
+	p.lines = lines
+	p.loading = false
+	p.errMsg = ""
+	p.actions = ""
+	p.viewport.SetContent(textwidth.SanitizeLines(strings.Join(lines, "\n")))
+	p.viewport.GotoTop()
+}
+
+// SetError records a failed fetch in place of the body.
+func (p *InfoPanel) SetError(err string) {
+	p.loading = false
+	p.errMsg = err
+	pal := styles.CatppuccinMocha()
+	p.viewport.SetContent(lipgloss.NewStyle().Foreground(pal.Red).Render("Error: " + err))
+}
+
+// SetActions sets the footer's hint of the panel's own keys, until the
+// content is next replaced.
+func (p *InfoPanel) SetActions(hint string) {
+	p.actions = hint
+}
+
+// Title returns the panel's current title.
+func (p *InfoPanel) Title() string {
+	return p.title
+}
+
+// SetSize sizes the panel to the space it's overlaid on; the box itself
+// takes most of it, leaving a margin so the page behind stays recognizable.
+func (p *InfoPanel) SetSize(w, h int) {
+	p.width, p.height = w, h
+	// 2 border + 4 horizontal padding; 2 border + title + rule + footer.
+	p.viewport.SetWidth(max(10, w*4/5-6))
+	p.viewport.SetHeight(max(3, h*4/5-5))
+}
+
+func (p *InfoPanel) Update(msg tea.Msg) tea.Cmd {
+	if press, ok := msg.(tea.KeyPressMsg); ok {
+		scrollViewport(&p.viewport, p.keyMap(), press)
+		return nil
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return cmd
+}
+
+// View renders the boxed panel centered in the space given to SetSize.
+func (p *InfoPanel) View() string {
+	footer := "↑/↓ pgup/pgdn scroll • g/G top/bottom • esc close"
+	if p.actions != "" {
+		footer = p.actions + " • " + footer
+	}
+	if !p.loading && p.errMsg == "" && len(p.lines) > p.viewport.Height() {
+		footer += fmt.Sprintf("  %d%%", int(p.viewport.ScrollPercent()*100))
+	}
+	return renderOverlayBox(p.width, p.height, p.viewport.Width(), p.title, p.viewport.View(), footer)
+}
+
+// renderOverlayBox is the shared frame of the modal overlays (InfoPanel,
+// FileBrowserPage): a bordered box innerW cells wide holding a title, a
+// rule, the body and a faint key-hint footer, centered in w x h.
+func renderOverlayBox(w, h, innerW int, title, body, footer string) string {
+	t := styles.Mocha()
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		t.Title.Render(ansi.Truncate(title, innerW, "…")),
+		t.Overlay0.Render(strings.Repeat("─", innerW)),
+		body,
+		t.Hint.Render(ansi.Truncate(footer, innerW, "…")),
+	)
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, t.OverlayBox.Render(content))
+}
+
+// ContentWidth is the width, in cells, the panel's body lines get.
+func (p *InfoPanel) ContentWidth() int {
+	return p.viewport.Width()
+}
@@ -0,0 +1,325 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// IngressPage is the ing tab: every path the selected contexts' Ingresses
+// route, a row each, with the backend it goes to and whether it's served
+// over TLS. Like the top and nodes tabs it isn't watch-backed — MainPage
+// re-lists on entry, "r" and the refresh tick — and has no wide mode.
+type IngressPage struct {
+	keyed
+	table btable.Model
+
+	byContext map[string][]k8s.IngressRoute
+	errs      map[string]string // by context, e.g. forbidden
+	routes    []k8s.IngressRoute
+
+	cachedView string
+	viewDirty  bool
+	focused    bool
+	tableW     int
+	tableH     int
+
+	// filter is over Ingress names and hosts.
+	filter rowFilter
+
+	// cursorIdx/windowStart/windowSize: see the identical fields on PodPage
+	// in pods.go. cursorIdx is a position in the filter's index space.
+	cursorIdx   int
+	windowStart int
+	windowSize  int
+}
+
+func NewIngressPage() *IngressPage {
+	return &IngressPage{
+		table:      newBubbleTable(ingressColumns()),
+		byContext:  make(map[string][]k8s.IngressRoute),
+		errs:       make(map[string]string),
+		viewDirty:  true,
+		windowSize: defaultRowWindowSize,
+	}
+}
+
+func (g *IngressPage) Init() tea.Cmd {
+	return nil
+}
+
+func (g *IngressPage) Update(msg tea.Msg) tea.Cmd {
+	if !g.focused {
+		return nil
+	}
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	if g.filter.filtering {
+		g.filter.handleKey(g.keyMap(), press, len(g.routes), g.filterMatch)
+		g.jumpTo(0)
+		return nil
+	}
+	km := g.keyMap()
+	switch {
+	case key.Matches(press, km.Down):
+		g.moveCursor(1)
+	case key.Matches(press, km.Up):
+		g.moveCursor(-1)
+	case key.Matches(press, km.Top):
+		g.jumpTo(0)
+	case key.Matches(press, km.Bottom):
+		g.jumpTo(g.filter.len(len(g.routes)) - 1)
+	case key.Matches(press, km.Filter):
+		g.filter.filtering = true
+	}
+	return nil
+}
+
+func (g *IngressPage) filterMatch(i int) bool {
+	q := strings.ToLower(g.filter.query)
+	r := g.routes[i]
+	return strings.Contains(strings.ToLower(r.Ingress), q) || strings.Contains(strings.ToLower(r.Host), q)
+}
+
+// FilterStatus: see PodPage.FilterStatus in pods.go.
+func (g *IngressPage) FilterStatus() (query string, matches int, typing bool, ok bool) {
+	if !g.filter.filtering && g.filter.query == "" {
+		return "", 0, false, false
+	}
+	return g.filter.query, g.filter.len(len(g.routes)), g.filter.filtering, true
+}
+
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (g *IngressPage) SetFilter(query string) {
+	g.filter.set(query, len(g.routes), g.filterMatch)
+	g.jumpTo(0)
+}
+
+// The ing tab has no wide columns; these satisfy the interface MainPage
+// drives every resource tab through.
+func (g *IngressPage) ToggleWideMode()                            {}
+func (g *IngressPage) WideMode() bool                             { return false }
+func (g *IngressPage) ScrollLeft()                                {}
+func (g *IngressPage) ScrollRight()                               {}
+func (g *IngressPage) ScrollStatus() (offset, total int, ok bool) { return 0, 0, false }
+
+// SetRoutes replaces one context's routes, clearing any error it had.
+func (g *IngressPage) SetRoutes(context string, routes []k8s.IngressRoute) {
+	g.byContext[context] = routes
+	delete(g.errs, context)
+	g.rebuild()
+	g.applySize()
+}
+
+// SetError records why a context's Ingresses couldn't be listed. Its last
+// good list, if any, is dropped rather than shown stale.
+func (g *IngressPage) SetError(context, err string) {
+	delete(g.byContext, context)
+	g.errs[context] = err
+	g.rebuild()
+	g.applySize()
+}
+
+// RemoveContext forgets a deselected context.
+func (g *IngressPage) RemoveContext(context string) {
+	delete(g.byContext, context)
+	delete(g.errs, context)
+	g.rebuild()
+	g.applySize()
+}
+
+// Len is how many routes are listed, for the status bar.
+func (g *IngressPage) Len() int {
+	return len(g.routes)
+}
+
+func ingressRouteKey(r k8s.IngressRoute) string {
+	return r.Context + "/" + r.Namespace + "/" + r.Ingress + "/" + r.Host + r.Path
+}
+
+// rebuild gathers every context's routes, keeping the cursor on the same
+// route where it can.
+func (g *IngressPage) rebuild() {
+	var selected string
+	if g.cursorIdx >= 0 && g.cursorIdx < g.filter.len(len(g.routes)) {
+		selected = ingressRouteKey(g.routes[g.filter.absolute(g.cursorIdx)])
+	}
+
+	g.routes = g.routes[:0]
+	for _, ctx := range slices.Sorted(maps.Keys(g.byContext)) {
+		g.routes = append(g.routes, g.byContext[ctx]...)
+	}
+	g.filter.recompute(len(g.routes), g.filterMatch)
+
+	total := g.filter.len(len(g.routes))
+	for pos := 0; pos < total && selected != ""; pos++ {
+		if ingressRouteKey(g.routes[g.filter.absolute(pos)]) == selected {
+			g.cursorIdx = pos
+			break
+		}
+	}
+	if g.cursorIdx >= total {
+		g.cursorIdx = max(total-1, 0)
+	}
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, total, g.windowSize)
+	g.pushDisplayRows()
+}
+
+// moveCursor: see PodPage.moveCursor in pods.go.
+func (g *IngressPage) moveCursor(delta int) {
+	total := g.filter.len(len(g.routes))
+	if total == 0 {
+		return
+	}
+	g.cursorIdx += delta
+	if g.cursorIdx < 0 {
+		g.cursorIdx = total - 1
+	} else if g.cursorIdx >= total {
+		g.cursorIdx = 0
+	}
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, total, g.windowSize)
+	g.pushDisplayRows()
+}
+
+// jumpTo: see PodPage.jumpTo in pods.go.
+func (g *IngressPage) jumpTo(idx int) {
+	total := g.filter.len(len(g.routes))
+	if total == 0 {
+		g.cursorIdx = 0
+		g.pushDisplayRows()
+		return
+	}
+	g.cursorIdx = max(0, min(idx, total-1))
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, total, g.windowSize)
+	g.pushDisplayRows()
+}
+
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (g *IngressPage) ClickRow(y int) bool {
+	y -= len(g.errs) // the error lines View puts above the table
+	pos, ok := rowAtLine(y, g.windowStart, g.filter.len(len(g.routes)))
+	if ok {
+		g.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (g *IngressPage) ScrollRows(delta int) {
+	g.jumpTo(g.cursorIdx + delta)
+}
+
+func (g *IngressPage) pushDisplayRows() {
+	t := styles.Mocha()
+	start, end := windowBounds(g.windowStart, g.filter.len(len(g.routes)), g.windowSize)
+	display := make([]btable.Row, 0, end-start)
+	for pos := start; pos < end; pos++ {
+		r := g.routes[g.filter.absolute(pos)]
+		path := r.Path
+		if path == "" {
+			path = "(default)"
+		}
+		tls := btable.NewStyledCell("—", t.Overlay1)
+		if r.TLSSecret != "" {
+			tls = btable.NewStyledCell("🔒 "+r.TLSSecret, t.Green)
+		}
+		display = append(display, btable.NewRow(btable.RowData{
+			msgs.IngressKeyName:      r.Ingress,
+			msgs.IngressKeyNamespace: r.Namespace,
+			msgs.IngressKeyClass:     r.Class,
+			msgs.IngressKeyHost:      r.Host,
+			msgs.IngressKeyPath:      path,
+			msgs.IngressKeyBackend:   r.Backend,
+			msgs.IngressKeyTLS:       tls,
+			msgs.IngressKeyAddress:   r.Address,
+			msgs.IngressKeyAge:       r.Age,
+			msgs.IngressKeyContext:   r.Context,
+		}))
+	}
+	g.table = g.table.WithRows(display).WithHighlightedRow(g.cursorIdx - start)
+	g.invalidateView()
+}
+
+func (g *IngressPage) View() string {
+	if g.cachedView != "" && !g.viewDirty {
+		return g.cachedView
+	}
+
+	view := g.table.View()
+	if len(g.errs) > 0 {
+		errStyle := styles.Mocha().Red
+		var lines []string
+		for _, ctx := range slices.Sorted(maps.Keys(g.errs)) {
+			lines = append(lines, errStyle.Render(fmt.Sprintf("⚠ %s: %s", ctx, g.errs[ctx])))
+		}
+		view = strings.Join(lines, "\n") + "\n" + view
+	}
+	g.cachedView = view
+	g.viewDirty = false
+	return view
+}
+
+func (g *IngressPage) SetFocused(f bool) {
+	g.focused = f
+	g.table = g.table.Focused(f)
+	g.invalidateView()
+}
+
+func (g *IngressPage) SetSize(width, h int) {
+	if width < 10 || h < 1 {
+		return
+	}
+	g.tableW, g.tableH = width, h
+	g.applySize()
+}
+
+// applySize: see TopPage.applySize in top.go.
+func (g *IngressPage) applySize() {
+	if g.tableW == 0 {
+		return
+	}
+	h := max(3, g.tableH-len(g.errs))
+
+	st := styles.CatppuccinBubbleTableStyle()
+	g.table = newBubbleTable(ingressColumns()).
+		WithMinimumHeight(h).
+		WithTargetWidth(g.tableW).
+		WithMaxTotalWidth(g.tableW).
+		HeaderStyle(st.Header).
+		HighlightStyle(st.Highlight).
+		WithBaseStyle(st.Base).
+		Focused(g.focused)
+	g.windowSize = rowWindowSizeFor(h)
+	g.windowStart = computeWindowStart(g.windowStart, g.cursorIdx, g.filter.len(len(g.routes)), g.windowSize)
+	g.pushDisplayRows()
+}
+
+func (g *IngressPage) invalidateView() {
+	g.viewDirty = true
+	g.cachedView = ""
+}
+
+func ingressColumns() []btable.Column {
+	return []btable.Column{
+		paddedFlexColumn(msgs.IngressKeyName, "Name", 6),
+		paddedFlexColumn(msgs.IngressKeyNamespace, "Namespace", 5),
+		paddedFlexColumn(msgs.IngressKeyClass, "Class", 3),
+		paddedFlexColumn(msgs.IngressKeyHost, "Host", 8),
+		paddedFlexColumn(msgs.IngressKeyPath, "Path", 5),
+		paddedFlexColumn(msgs.IngressKeyBackend, "Backend", 6),
+		paddedFlexColumn(msgs.IngressKeyTLS, "TLS", 5),
+		paddedFlexColumn(msgs.IngressKeyAddress, "Address", 5),
+		paddedFlexColumn(msgs.IngressKeyAge, "Age", 3),
+		paddedFlexColumn(msgs.IngressKeyContext, "Context", 4),
+	}
+}
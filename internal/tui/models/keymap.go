@@ -0,0 +1,86 @@
+package models
+
+import (
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"github.com/ktails/ktails/internal/tui/keys"
+)
+
+// defaultKeys is what models match key presses against until MainPage
+// shares its keymap with them (see keyed.SetKeyMap).
+var defaultKeys = keys.Default()
+
+// keyed is embedded by every model that handles key presses itself: it
+// matches them against the keymap MainPage shares, so keys remapped in the
+// config's keys section move its cursor and scroll it like the defaults.
+type keyed struct {
+	shared *keys.KeyMap
+}
+
+// SetKeyMap shares MainPage's keymap, remapped keys and all.
+func (k *keyed) SetKeyMap(km *keys.KeyMap) {
+	k.shared = km
+}
+
+func (k *keyed) keyMap() *keys.KeyMap {
+	if k.shared == nil {
+		return &defaultKeys
+	}
+	return k.shared
+}
+
+// Scroll's keys, in the order of its defaults (see keys.Index).
+const (
+	scrollUp = iota
+	scrollDown
+	scrollPageUp
+	scrollPageDown
+)
+
+// scrollStep is how many rows a press of one of Scroll's keys moves a
+// cursor by, given a page of page rows.
+func scrollStep(km *keys.KeyMap, press tea.KeyPressMsg, page int) int {
+	switch keys.Index(press, km.Scroll) {
+	case scrollUp:
+		return -1
+	case scrollDown:
+		return 1
+	case scrollPageUp:
+		return -page
+	case scrollPageDown:
+		return page
+	}
+	return 0
+}
+
+// scrollViewport scrolls vp for a press of Up, Down, Scroll, Top or
+// Bottom, reporting whether it was one of them. It stands in for the
+// viewport's own keymap, which has bubbles' defaults rather than the
+// config's keys.
+func scrollViewport(vp *viewport.Model, km *keys.KeyMap, press tea.KeyPressMsg) bool {
+	switch {
+	case key.Matches(press, km.Up):
+		vp.ScrollUp(1)
+	case key.Matches(press, km.Down):
+		vp.ScrollDown(1)
+	case key.Matches(press, km.Scroll):
+		switch keys.Index(press, km.Scroll) {
+		case scrollUp:
+			vp.ScrollUp(1)
+		case scrollDown:
+			vp.ScrollDown(1)
+		case scrollPageUp:
+			vp.PageUp()
+		case scrollPageDown:
+			vp.PageDown()
+		}
+	case key.Matches(press, km.Top):
+		vp.GotoTop()
+	case key.Matches(press, km.Bottom):
+		vp.GotoBottom()
+	default:
+		return false
+	}
+	return true
+}
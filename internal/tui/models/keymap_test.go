@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/keys"
+)
+
+func TestKeyed_TablesAndPanesFollowTheSharedKeymap(t *testing.T) {
+	km := keys.Default()
+	if err := km.Rebind(map[string][]string{"up": {"up", "j"}, "down": {"down", "k"}, "top": {"home"}}); err != nil {
+		t.Fatal(err)
+	}
+	press := func(s string) tea.KeyPressMsg { return tea.KeyPressMsg{Code: rune(s[0]), Text: s} }
+
+	n := NewNodePage()
+	n.SetKeyMap(&km)
+	n.SetFocused(true)
+	n.SetNodes("prod", []k8s.NodeInfo{{Name: "a", Context: "prod"}, {Name: "b", Context: "prod"}, {Name: "c", Context: "prod"}})
+	n.Update(press("k"))
+	n.Update(press("k"))
+	if node, _ := n.Selected(); node.Name != "c" {
+		t.Fatalf("k twice, remapped to down, selected %q, want c", node.Name)
+	}
+	n.Update(press("j"))
+	if node, _ := n.Selected(); node.Name != "b" {
+		t.Fatalf("j, remapped to up, selected %q, want b", node.Name)
+	}
+	// g was top's; bound to nothing now, it leaves the cursor be.
+	n.Update(press("g"))
+	if node, _ := n.Selected(); node.Name != "b" {
+		t.Errorf("g, unbound, selected %q, want b still", node.Name)
+	}
+
+	p := NewInfoPanel()
+	p.SetKeyMap(&km)
+	p.SetSize(80, 10)
+	p.SetContent("env", strings.Split(strings.Repeat("line\n", 40), "\n"))
+	p.Update(press("k"))
+	if p.viewport.YOffset() != 1 {
+		t.Errorf("k, remapped to down, scrolled the panel to %d, want 1", p.viewport.YOffset())
+	}
+	p.Update(press("j"))
+	if p.viewport.YOffset() != 0 {
+		t.Errorf("j, remapped to up, scrolled the panel to %d, want 0", p.viewport.YOffset())
+	}
+}
@@ -0,0 +1,622 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ktails/ktails/internal/logfmt"
+	"github.com/ktails/ktails/internal/tui/keys"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// MaxLogPanes is how many log panes the Log area can be split into.
+const MaxLogPanes = 4
+
+// minLogPaneWidth is the narrowest a pane is laid out side by side with
+// another; below twice it, panes are stacked instead.
+const minLogPaneWidth = 60
+
+// LogPanes is the Log area below the tab content: one to MaxLogPanes
+// LogPages laid out in a grid, each merging its own sources. One pane is
+// active — it takes the keys, and l on the Pods tab tails into it. A
+// source (a pod container's stream) lives in one pane at a time, so
+// everything addressed to a source key is routed to the pane holding it.
+//
+// Settings that belong to the streams rather than a view (the header's
+// mode, scrollback limit, structured fields, timestamps and time zone) are
+// the Log area's: they're applied to every pane, and to panes added later.
+type LogPanes struct {
+	panes  []*LogPage
+	active int
+
+	settings logPaneSettings
+
+	width   int
+	height  int
+	focused bool
+
+	// syncScroll (config.Preferences.SyncScroll) makes scrolling the active
+	// pane scroll the others along: to the same time while it shows
+	// timestamps, else by the same number of rows.
+	syncScroll bool
+
+	// compare pairs two panes for a side-by-side comparison (see
+	// StartCompare): they stay side by side however narrow, scroll
+	// together, and a search applies to both. Adding or closing a pane,
+	// or clearing the area, ends it.
+	compare bool
+
+	theme *styles.Theme
+}
+
+// logPaneSettings are what every pane shares; see LogPanes.
+type logPaneSettings struct {
+	maxLines        int
+	followByDefault bool
+	fields          []string
+	colorLevels     bool
+	timestamps      bool
+	location        *time.Location
+	stampFormat     StampFormat
+	wrap            bool
+	collapse        bool
+	mode            string
+	keys            *keys.KeyMap
+}
+
+func NewLogPanes() *LogPanes {
+	p := &LogPanes{theme: styles.Mocha()}
+	first := NewLogPage()
+	p.settings = logPaneSettings{
+		maxLines:        first.maxLines,
+		followByDefault: first.followByDefault,
+		fields:          first.fields,
+	}
+	p.panes = []*LogPage{first}
+	return p
+}
+
+// Len is how many panes the Log area is split into.
+func (p *LogPanes) Len() int {
+	return len(p.panes)
+}
+
+// Active returns the pane keys go to and new sources open in.
+func (p *LogPanes) Active() *LogPage {
+	return p.panes[p.active]
+}
+
+// Add splits off a new, empty pane and makes it the active one. false if
+// there are MaxLogPanes already.
+func (p *LogPanes) Add() bool {
+	if len(p.panes) >= MaxLogPanes {
+		return false
+	}
+	pane := NewLogPage()
+	p.apply(pane)
+	p.compare = false
+	p.panes = append(p.panes, pane)
+	p.active = len(p.panes) - 1
+	p.layout()
+	p.SetFocused(p.focused)
+	return true
+}
+
+// CloseActive removes the active pane, returning the source keys it held
+// so MainPage can stop their streams; the pane before it becomes active.
+// The last pane can't be closed this way (ok is false): closing it is
+// closing the Log area.
+func (p *LogPanes) CloseActive() (keys []string, ok bool) {
+	if len(p.panes) == 1 {
+		return nil, false
+	}
+	keys = p.Active().Keys()
+	p.compare = false
+	p.panes = append(p.panes[:p.active], p.panes[p.active+1:]...)
+	p.active = max(0, p.active-1)
+	p.layout()
+	p.SetFocused(p.focused)
+	return keys, true
+}
+
+// Next makes the next pane, in reading order, the active one.
+func (p *LogPanes) Next() {
+	p.active = (p.active + 1) % len(p.panes)
+	p.SetFocused(p.focused)
+}
+
+// ActivateAt makes the pane drawn at x, y of View the active one,
+// reporting whether it changed.
+func (p *LogPanes) ActivateAt(x, y int) bool {
+	if len(p.panes) == 1 || p.width == 0 {
+		return false
+	}
+	rows := p.grid()
+	heights := cellSizes(p.height+len(rows)-1, len(rows))
+	first, top := 0, 0
+	for r, n := range rows {
+		if y >= top+heights[r] && r < len(rows)-1 {
+			first += n
+			top += heights[r]
+			continue
+		}
+		col, left := 0, 0
+		for _, w := range cellSizes(p.width, n)[:n-1] {
+			if x < left+w+1 { // a cell's right divider goes with it
+				break
+			}
+			left += w + 1
+			col++
+		}
+		if first+col == p.active {
+			return false
+		}
+		p.active = first + col
+		p.SetFocused(p.focused)
+		return true
+	}
+	return false
+}
+
+// paneOf returns the pane holding source key, nil if none does.
+func (p *LogPanes) paneOf(key string) *LogPage {
+	for _, pane := range p.panes {
+		if pane.HasSource(key) {
+			return pane
+		}
+	}
+	return nil
+}
+
+// AddSource opens source key in the active pane, taking it out of any other
+// pane it was in. moved reports that it was, in which case its stream is
+// already open and only its scrollback starts over.
+func (p *LogPanes) AddSource(key, podName, namespace, context, container string) (moved bool) {
+	if pane := p.paneOf(key); pane != nil {
+		if pane == p.Active() {
+			return false
+		}
+		pane.RemoveSource(key)
+		moved = true
+	}
+	p.Active().AddSource(key, podName, namespace, context, container)
+	return moved
+}
+
+// HasSource reports whether any pane holds source key.
+func (p *LogPanes) HasSource(key string) bool {
+	return p.paneOf(key) != nil
+}
+
+// Keys returns every pane's source keys, pane by pane.
+func (p *LogPanes) Keys() []string {
+	var keys []string
+	for _, pane := range p.panes {
+		keys = append(keys, pane.Keys()...)
+	}
+	return keys
+}
+
+// Source: see LogPage.Source.
+func (p *LogPanes) Source(key string) (target msgs.LogLevelTarget, ok bool) {
+	if pane := p.paneOf(key); pane != nil {
+		return pane.Source(key)
+	}
+	return msgs.LogLevelTarget{}, false
+}
+
+// AppendLineAt: see LogPage.AppendLineAt.
+func (p *LogPanes) AppendLineAt(key, line string, ts time.Time) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.AppendLineAt(key, line, ts)
+	}
+}
+
+// AddNotice: see LogPage.AddNotice.
+func (p *LogPanes) AddNotice(key, text string) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.AddNotice(key, text)
+	}
+}
+
+// AddSummary: see LogPage.AddSummary.
+func (p *LogPanes) AddSummary(key, head string, lines []string) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.AddSummary(key, head, lines)
+	}
+}
+
+// ErrorLines: see LogPage.ErrorLines.
+func (p *LogPanes) ErrorLines(key string, n int) (count int, last []string) {
+	if pane := p.paneOf(key); pane != nil {
+		return pane.ErrorLines(key, n)
+	}
+	return 0, nil
+}
+
+// RestartSource: see LogPage.RestartSource.
+func (p *LogPanes) RestartSource(key, notice string) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.RestartSource(key, notice)
+	}
+}
+
+// SetStreamEnded: see LogPage.SetStreamEnded.
+func (p *LogPanes) SetStreamEnded(key string, err error) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.SetStreamEnded(key, err)
+	}
+}
+
+// ReplaceSource: see LogPage.ReplaceSource.
+func (p *LogPanes) ReplaceSource(key, newKey, podName, divider string) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.ReplaceSource(key, newKey, podName, divider)
+	}
+}
+
+// RemoveSource: see LogPage.RemoveSource.
+func (p *LogPanes) RemoveSource(key string) {
+	if pane := p.paneOf(key); pane != nil {
+		pane.RemoveSource(key)
+	}
+}
+
+// Clear closes every pane but one and empties it.
+func (p *LogPanes) Clear() {
+	p.panes = p.panes[:1]
+	p.active = 0
+	p.compare = false
+	p.panes[0].Clear()
+	p.layout()
+	p.SetFocused(p.focused)
+}
+
+// apply gives a pane the settings every pane shares.
+func (p *LogPanes) apply(pane *LogPage) {
+	s := p.settings
+	pane.SetMaxLines(s.maxLines)
+	pane.SetFollowByDefault(s.followByDefault)
+	pane.SetFields(s.fields)
+	pane.SetColorCodeLevels(s.colorLevels)
+	pane.SetMode(s.mode)
+	pane.location = s.location
+	pane.stampFormat = s.stampFormat
+	pane.SetTimestamps(s.timestamps)
+	pane.SetWrap(s.wrap)
+	pane.SetCollapseRepeats(s.collapse)
+	pane.SetKeyMap(s.keys)
+}
+
+// SetKeyMap: see keyed.SetKeyMap; for every pane.
+func (p *LogPanes) SetKeyMap(km *keys.KeyMap) {
+	p.settings.keys = km
+	for _, pane := range p.panes {
+		pane.SetKeyMap(km)
+	}
+}
+
+// SetMaxLines: see LogPage.SetMaxLines; for every pane.
+func (p *LogPanes) SetMaxLines(n int) {
+	p.settings.maxLines = n
+	for _, pane := range p.panes {
+		pane.SetMaxLines(n)
+	}
+}
+
+// SetFollowByDefault: see LogPage.SetFollowByDefault; for every pane.
+func (p *LogPanes) SetFollowByDefault(on bool) {
+	p.settings.followByDefault = on
+	for _, pane := range p.panes {
+		pane.SetFollowByDefault(on)
+	}
+}
+
+// SetFields: see LogPage.SetFields; for every pane.
+func (p *LogPanes) SetFields(fields []string) {
+	p.settings.fields = fields
+	for _, pane := range p.panes {
+		pane.SetFields(fields)
+	}
+}
+
+// SetColorCodeLevels: see LogPage.SetColorCodeLevels; for every pane.
+func (p *LogPanes) SetColorCodeLevels(on bool) {
+	p.settings.colorLevels = on
+	for _, pane := range p.panes {
+		pane.SetColorCodeLevels(on)
+	}
+}
+
+// SetTimestamps: see LogPage.SetTimestamps; for every pane. "t" toggles
+// the active pane's alone.
+func (p *LogPanes) SetTimestamps(on bool) {
+	p.settings.timestamps = on
+	for _, pane := range p.panes {
+		pane.SetTimestamps(on)
+	}
+}
+
+// SetCollapseRepeats: see LogPage.SetCollapseRepeats; for every pane.
+// "u" toggles the active one's.
+func (p *LogPanes) SetCollapseRepeats(on bool) {
+	p.settings.collapse = on
+	for _, pane := range p.panes {
+		pane.SetCollapseRepeats(on)
+	}
+}
+
+// SetLocation: see LogPage.SetLocation; for every pane.
+func (p *LogPanes) SetLocation(loc *time.Location) {
+	p.settings.location = loc
+	for _, pane := range p.panes {
+		pane.SetLocation(loc)
+	}
+}
+
+// Location returns the time zone timestamps are shown in.
+func (p *LogPanes) Location() *time.Location {
+	return p.Active().Location()
+}
+
+// ToggleWrap flips soft-wrap on the active pane alone, and makes its
+// choice the one new panes start with.
+func (p *LogPanes) ToggleWrap() {
+	p.Active().ToggleWrap()
+	p.settings.wrap = p.Active().Wrap()
+}
+
+// SetWrap: see LogPage.SetWrap; for every pane, and the ones opened later.
+func (p *LogPanes) SetWrap(on bool) {
+	p.settings.wrap = on
+	for _, pane := range p.panes {
+		pane.SetWrap(on)
+	}
+}
+
+// Wrap is the wrap choice new panes start with: the last one made.
+func (p *LogPanes) Wrap() bool {
+	return p.settings.wrap
+}
+
+// CycleStampFormat steps every pane to the next timestamp format — local
+// (or the "Z" zone), UTC, relative — and returns it.
+func (p *LogPanes) CycleStampFormat() StampFormat {
+	p.settings.stampFormat = (p.settings.stampFormat + 1) % (StampRelative + 1)
+	for _, pane := range p.panes {
+		pane.SetStampFormat(p.settings.stampFormat)
+	}
+	return p.settings.stampFormat
+}
+
+// RefreshStamps: see LogPage.RefreshStamps; for every pane.
+func (p *LogPanes) RefreshStamps() {
+	for _, pane := range p.panes {
+		pane.RefreshStamps()
+	}
+}
+
+// SetMode: see LogPage.SetMode; for every pane, as the streams are
+// reopened alike.
+func (p *LogPanes) SetMode(mode string) {
+	p.settings.mode = mode
+	for _, pane := range p.panes {
+		pane.SetMode(mode)
+	}
+}
+
+// SetSyncScroll sets whether scrolling the active pane scrolls the others.
+func (p *LogPanes) SetSyncScroll(on bool) {
+	p.syncScroll = on
+}
+
+// StartCompare pairs the two panes for comparing their logs: side by side,
+// both showing timestamps and scrolling together to the same time, whatever
+// SetSyncScroll says, and sharing a search (see SetSearch). false unless
+// there are exactly two panes.
+func (p *LogPanes) StartCompare() bool {
+	if len(p.panes) != 2 {
+		return false
+	}
+	p.compare = true
+	for _, pane := range p.panes {
+		pane.SetTimestamps(true)
+	}
+	p.layout()
+	return true
+}
+
+// Comparing reports whether the panes are paired by StartCompare.
+func (p *LogPanes) Comparing() bool {
+	return p.compare
+}
+
+// filtered is the panes a search or level filter applies to: both while
+// comparing, else the active one.
+func (p *LogPanes) filtered() []*LogPage {
+	if p.compare {
+		return p.panes
+	}
+	return []*LogPage{p.Active()}
+}
+
+// SetSearch: see LogPage.SetSearch; for both panes while comparing, else
+// the active one.
+func (p *LogPanes) SetSearch(text string) {
+	for _, pane := range p.filtered() {
+		pane.SetSearch(text)
+	}
+}
+
+// CycleMinLevel: see LogPage.CycleMinLevel; for both panes while
+// comparing, else the active one, the others following the active one's
+// step.
+func (p *LogPanes) CycleMinLevel() {
+	p.Active().CycleMinLevel()
+	p.SetMinLevel(p.Active().MinLevel())
+}
+
+// SetMinLevel: see LogPage.SetMinLevel; for both panes while comparing,
+// else the active one.
+func (p *LogPanes) SetMinLevel(level logfmt.Level) {
+	for _, pane := range p.filtered() {
+		pane.SetMinLevel(level)
+	}
+}
+
+// Update hands msg to the active pane and, with synchronized scrolling
+// on or while comparing, brings the others to where it scrolled.
+func (p *LogPanes) Update(msg tea.Msg) tea.Cmd {
+	active := p.Active()
+	before := active.YOffset()
+	cmd := active.Update(msg)
+	if !p.syncScroll && !p.compare || len(p.panes) == 1 || active.YOffset() == before {
+		return cmd
+	}
+	t, byTime := active.TopTime()
+	byTime = byTime && active.timestamps
+	for i, pane := range p.panes {
+		switch {
+		case i == p.active:
+		case byTime:
+			pane.ScrollToTime(t)
+		default:
+			pane.SetYOffset(pane.YOffset() + active.YOffset() - before)
+		}
+	}
+	return cmd
+}
+
+// SetFocused focuses the active pane, the others never.
+func (p *LogPanes) SetFocused(f bool) {
+	p.focused = f
+	for i, pane := range p.panes {
+		pane.SetFocused(f && i == p.active)
+	}
+}
+
+// SetSize lays the panes out in w×h cells.
+func (p *LogPanes) SetSize(w, h int) {
+	if w < 10 || h < 1 {
+		return
+	}
+	p.width, p.height = w, h
+	p.layout()
+}
+
+// grid returns how the panes are arranged: the number of panes on each
+// row, in reading order. Two panes go side by side when there's room,
+// else one above the other (compared panes always side by side); three
+// are two above one, four two by two.
+func (p *LogPanes) grid() []int {
+	n := len(p.panes)
+	cols := 1
+	if n > 1 && (p.width >= 2*minLogPaneWidth || p.compare) {
+		cols = 2
+	}
+	var rows []int
+	for n > 0 {
+		rows = append(rows, min(n, cols))
+		n -= cols
+	}
+	return rows
+}
+
+// cellSizes splits total cells among n parts with a one-cell gap between
+// each, the last taking the remainder.
+func cellSizes(total, n int) []int {
+	size := (total - (n - 1)) / n
+	sizes := make([]int, n)
+	for i := range sizes {
+		sizes[i] = size
+	}
+	sizes[n-1] = total - (n-1)*(size+1)
+	return sizes
+}
+
+// layout sizes every pane for the grid. A lone pane has the whole area;
+// in a grid each pane gives its first line to a title.
+func (p *LogPanes) layout() {
+	if p.width == 0 {
+		return
+	}
+	if len(p.panes) == 1 {
+		p.panes[0].SetSize(p.width, p.height)
+		return
+	}
+	rows := p.grid()
+	heights := cellSizes(p.height+len(rows)-1, len(rows)) // no gap between rows: the titles divide them
+	i := 0
+	for r, n := range rows {
+		for _, w := range cellSizes(p.width, n) {
+			p.panes[i].SetSize(w, max(1, heights[r]-1))
+			i++
+		}
+	}
+}
+
+// Header renders the Log area's banner: the active pane's, prefixed with
+// which pane that is once there are several.
+func (p *LogPanes) Header(width int) string {
+	if len(p.panes) == 1 {
+		return p.Active().Header(width)
+	}
+	label := fmt.Sprintf("[pane %d/%d]", p.active+1, len(p.panes))
+	switch {
+	case p.compare:
+		label = fmt.Sprintf("[pane %d/%d · compare]", p.active+1, len(p.panes))
+	case p.syncScroll:
+		label = fmt.Sprintf("[pane %d/%d · sync]", p.active+1, len(p.panes))
+	}
+	prefix := p.theme.Peach.Bold(true).Render(label) + " "
+	return ansi.Truncate(prefix+p.Active().Header(0), width, "…")
+}
+
+// ScrollStatus: see LogPage.ScrollStatus; the active pane's.
+func (p *LogPanes) ScrollStatus() (percent int, ok bool) {
+	return p.Active().ScrollStatus()
+}
+
+func (p *LogPanes) View() string {
+	if len(p.panes) == 1 || p.width == 0 {
+		return p.Active().View()
+	}
+	rows := p.grid()
+	heights := cellSizes(p.height+len(rows)-1, len(rows))
+	sep := p.theme.Overlay1
+
+	var out []string
+	i := 0
+	for r, n := range rows {
+		var cells []string
+		for c, w := range cellSizes(p.width, n) {
+			cells = append(cells, p.renderCell(i, w, heights[r]))
+			if c < n-1 {
+				cells = append(cells, sep.Render(strings.TrimSuffix(strings.Repeat("│\n", heights[r]), "\n")))
+			}
+			i++
+		}
+		out = append(out, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, out...)
+}
+
+// renderCell renders pane i in a w×h cell: its title, the active pane's
+// highlighted, then its view.
+func (p *LogPanes) renderCell(i, w, h int) string {
+	titleStyle := p.theme.Overlay1
+	marker := "▹"
+	if i == p.active {
+		titleStyle = p.theme.Peach.Bold(true)
+		marker = "▸"
+	}
+	title := ansi.Truncate(p.panes[i].pausedBadge()+titleStyle.Render(fmt.Sprintf("%s %d · %s", marker, i+1, p.panes[i].Label())), w, "…")
+	return lipgloss.NewStyle().Width(w).Height(h).MaxHeight(h).MaxWidth(w).
+		Render(lipgloss.JoinVertical(lipgloss.Left, title, p.panes[i].View()))
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// collapseRepeats folds each source's runs of identical lines — a retry
+// storm logging the same error over and over — into the newest line of
+// the run, which counts them in repeats and remembers when the run began.
+// A run is per source: another pod's lines arriving in between don't break
+// it. Synthetic lines never fold.
+func collapseRepeats(lines []prefixedLine) []prefixedLine {
+	out := make([]prefixedLine, 0, len(lines))
+	dropped := make([]bool, 0, len(lines))
+	// last is the index in out of each source's latest line.
+	last := make(map[string]int)
+	for _, ln := range lines {
+		if j, ok := last[ln.prefix]; ok && !ln.synthetic && !out[j].synthetic && out[j].text == ln.text {
+			prev := out[j]
+			ln.repeats, ln.first = max(1, prev.repeats)+1, prev.first
+			if prev.repeats == 0 {
+				ln.first = prev.time
+			}
+			dropped[j] = true
+		}
+		last[ln.prefix] = len(out)
+		out = append(out, ln)
+		dropped = append(dropped, false)
+	}
+	kept := out[:0]
+	for i, ln := range out {
+		if !dropped[i] {
+			kept = append(kept, ln)
+		}
+	}
+	return kept
+}
+
+// repeatBadge is the "×N" a collapsed line renders behind, "" for a line
+// standing for itself alone.
+func repeatBadge(ln prefixedLine, p styles.Palette) string {
+	if ln.repeats < 2 {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().Foreground(p.Peach).Bold(true).Render(fmt.Sprintf("×%d", ln.repeats))
+}
+
+// repeatDetail is what expanding a collapsed line shows of its run: how
+// many lines it stands for and since when.
+func (l *LogPage) repeatDetail(ln prefixedLine, p styles.Palette) []string {
+	if ln.repeats < 2 {
+		return nil
+	}
+	text := fmt.Sprintf("  repeated %d times", ln.repeats)
+	if !ln.first.IsZero() && !ln.time.IsZero() {
+		text += fmt.Sprintf(", %s – %s", ln.first.In(l.Location()).Format(zonedStampLayout), ln.time.In(l.Location()).Format(zonedStampLayout))
+	}
+	return []string{lipgloss.NewStyle().Foreground(p.Overlay1).Render(text)}
+}
+
+// folded is how many lines collapsing hides in lines: each run's count,
+// less the one line shown for it.
+func folded(lines []prefixedLine) int {
+	n := 0
+	for _, ln := range lines {
+		n += max(0, ln.repeats-1)
+	}
+	return n
+}
+
+// SetCollapseRepeats sets whether runs of identical lines are folded into
+// one (see collapseRepeats).
+func (l *LogPage) SetCollapseRepeats(on bool) {
+	l.collapse = on
+	l.refreshContent()
+}
+
+// ToggleCollapseRepeats flips whether runs of identical lines are folded.
+func (l *LogPage) ToggleCollapseRepeats() {
+	l.SetCollapseRepeats(!l.collapse)
+}
+
+// CollapseRepeats reports whether runs of identical lines are folded.
+func (l *LogPage) CollapseRepeats() bool {
+	return l.collapse
+}
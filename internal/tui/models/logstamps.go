@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// StampFormat is how the log pane renders a line's timestamp ("D" cycles
+// them): in the pane's time zone, in UTC, or as an age.
+type StampFormat int
+
+const (
+	StampZoned    StampFormat = iota // the pane's time zone ("Z"), local unless one was picked
+	StampUTC                         // UTC, "Z"-suffixed
+	StampRelative                    // "3s ago", right-aligned
+)
+
+// The stamp column's layouts, and how wide a relative stamp is padded to
+// so the text behind it stays aligned as ages grow.
+const (
+	zonedStampLayout   = "15:04:05.000"
+	utcStampLayout     = "15:04:05.000Z"
+	relativeStampWidth = len("999d ago")
+)
+
+func (f StampFormat) String() string {
+	switch f {
+	case StampUTC:
+		return "UTC"
+	case StampRelative:
+		return "relative"
+	}
+	return "local"
+}
+
+func (f StampFormat) width() int {
+	switch f {
+	case StampUTC:
+		return len(utcStampLayout)
+	case StampRelative:
+		return relativeStampWidth
+	}
+	return len(zonedStampLayout)
+}
+
+// stamp is the timestamp column a line renders behind while timestamps are
+// on, in the pane's stamp format, blank-padded for lines without one so
+// the text stays aligned.
+func (l *LogPage) stamp(ln logLine, p styles.Palette) string {
+	if !l.timestamps {
+		return ""
+	}
+	if ln.time.IsZero() {
+		return strings.Repeat(" ", l.stampFormat.width()+1)
+	}
+	var text string
+	switch l.stampFormat {
+	case StampUTC:
+		text = ln.time.UTC().Format(utcStampLayout)
+	case StampRelative:
+		text = fmt.Sprintf("%*s", relativeStampWidth, relativeAge(l.now().Sub(ln.time)))
+	default:
+		text = ln.time.In(l.Location()).Format(zonedStampLayout)
+	}
+	return lipgloss.NewStyle().Foreground(p.Overlay1).Render(text) + " "
+}
+
+// relativeAge is d as "3s ago", "12m ago", "5h ago" or "2d ago".
+func relativeAge(d time.Duration) string {
+	d = max(0, d)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", d/time.Second)
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", d/time.Minute)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", d/time.Hour)
+	}
+	return fmt.Sprintf("%dd ago", min(999, d/(24*time.Hour)))
+}
+
+// leadingStampLayouts are the timestamps apps commonly start their own
+// lines with: RFC 3339, with or without a zone, and its space-separated
+// cousin (Python's comma before the fraction is read as a dot).
+var leadingStampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// repeatedStamp is how many bytes at the start of ln's text are the app's
+// own timestamp (bracketed or not, with the spaces after it) while the
+// stamp column already shows the kubelet's: that prefix is dropped from
+// the rendered line rather than shown twice. 0 when there's none, or no
+// stamp column to stand in for it.
+func (l *LogPage) repeatedStamp(ln logLine) int {
+	if !l.timestamps || ln.time.IsZero() || ln.synthetic {
+		return 0
+	}
+	return leadingTimestamp(ln.text)
+}
+
+// leadingTimestamp is the length of a timestamp (see leadingStampLayouts)
+// at the start of text plus the blanks after it, 0 if it doesn't start
+// with one.
+func leadingTimestamp(text string) int {
+	rest, bracketed := strings.CutPrefix(text, "[")
+	// The shortest layout is a date and a time to the second.
+	if len(rest) < len("2006-01-02T15:04:05") || rest[4] != '-' || rest[7] != '-' {
+		return 0
+	}
+	end := len("2006-01-02T15:04:05")
+	for end < len(rest) && rest[end] != ' ' && rest[end] != ']' {
+		end++
+	}
+	token := strings.Replace(rest[:end], ",", ".", 1)
+	parsed := false
+	for _, layout := range leadingStampLayouts {
+		if _, err := time.Parse(layout, token); err == nil {
+			parsed = true
+			break
+		}
+	}
+	if !parsed {
+		return 0
+	}
+	if bracketed {
+		if end >= len(rest) || rest[end] != ']' {
+			return 0
+		}
+		end++
+	}
+	n := len(text) - len(rest) + end
+	if n < len(text) && text[n] != ' ' {
+		return 0
+	}
+	for n < len(text) && text[n] == ' ' {
+		n++
+	}
+	return n
+}
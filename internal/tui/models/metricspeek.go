@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/metrics"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// PodMetricsLines renders the info panel body for a peek at a pod's
+// metrics: its containers' restarts, its HTTP requests with the share that
+// failed, and its process stats — with rates since prev, the panel's
+// previous scrape of the same pod, once there is one. Failures and
+// restarts are red, 4xx yellow.
+func PodMetricsLines(target string, restarts map[string]int32, peek, prev metrics.Peek) []string {
+	p := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	label := lipgloss.NewStyle().Foreground(p.Blue)
+	bad := lipgloss.NewStyle().Foreground(p.Red)
+	warn := lipgloss.NewStyle().Foreground(p.Yellow)
+
+	row := func(name, value string) string {
+		return fmt.Sprintf("  %s %s", label.Render(fmt.Sprintf("%-12s", name)), value)
+	}
+	lines := []string{dim.Render(fmt.Sprintf("%s · %d series · scraped %s", target, peek.Series, peek.At.Local().Format("15:04:05")))}
+
+	lines = append(lines, "", "Restarts")
+	containers := make([]string, 0, len(restarts))
+	for name := range restarts {
+		containers = append(containers, name)
+	}
+	slices.Sort(containers)
+	for _, name := range containers {
+		n := fmt.Sprint(restarts[name])
+		if restarts[name] > 0 {
+			n = bad.Render(n)
+		}
+		lines = append(lines, row(name, n))
+	}
+
+	rates, haveRates := peek.RatesSince(prev)
+	lines = append(lines, "", "HTTP")
+	if peek.HTTP == "" {
+		lines = append(lines, dim.Render("  no request counter with a status label"))
+	} else {
+		lines = append(lines, row("counter", dim.Render(peek.HTTP)), row("requests", fmt.Sprintf("%.0f", peek.Requests)))
+		e4 := fmt.Sprintf("%.0f (%s)", peek.Errors4xx, percent(peek.Errors4xx, peek.Requests))
+		if peek.Errors4xx > 0 {
+			e4 = warn.Render(e4)
+		}
+		e5 := fmt.Sprintf("%.0f (%s)", peek.Errors5xx, percent(peek.Errors5xx, peek.Requests))
+		if peek.Errors5xx > 0 {
+			e5 = bad.Render(e5)
+		}
+		lines = append(lines, row("4xx", e4), row("5xx", e5))
+		if haveRates && peek.HTTP == prev.HTTP {
+			rate := fmt.Sprintf("%.2f req/s", rates.Requests)
+			if rates.Errors5xx > 0 {
+				rate += bad.Render(fmt.Sprintf(", %.2f 5xx/s (%s)", rates.Errors5xx, percent(rates.Errors5xx, rates.Requests)))
+			}
+			lines = append(lines, row("now", rate))
+		}
+	}
+
+	lines = append(lines, "", "Process")
+	stats := 0
+	stat := func(name string, v float64, format func(float64) string) {
+		if !math.IsNaN(v) {
+			lines = append(lines, row(name, format(v)))
+			stats++
+		}
+	}
+	stat("cpu", peek.CPUSeconds, func(v float64) string {
+		s := fmt.Sprintf("%.1fs total", v)
+		if haveRates && !math.IsNaN(rates.CPU) {
+			s += fmt.Sprintf(", %.2f cores now", rates.CPU)
+		}
+		return s
+	})
+	stat("memory", peek.ResidentBytes, func(v float64) string { return fmt.Sprintf("%.1f MiB resident", v/(1<<20)) })
+	stat("goroutines", peek.Goroutines, func(v float64) string { return fmt.Sprintf("%.0f", v) })
+	stat("open fds", peek.OpenFDs, func(v float64) string { return fmt.Sprintf("%.0f", v) })
+	stat("uptime", peek.StartTime, func(v float64) string {
+		return peek.At.Sub(time.Unix(int64(v), 0)).Truncate(time.Second).String()
+	})
+	if stats == 0 {
+		lines = append(lines, dim.Render("  no process_* or go_* series"))
+	}
+
+	if !haveRates {
+		lines = append(lines, "", dim.Render("r scrapes again, for rates since this scrape"))
+	}
+	return lines
+}
+
+// percent is part as a share of whole, "–" with nothing to share.
+func percent(part, whole float64) string {
+	if whole <= 0 {
+		return "–"
+	}
+	return fmt.Sprintf("%.1f%%", 100*part/whole)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+func TestPodPage_ClickRowPicksTheRowDrawnThere(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(80, 10)
+	p.SetFocused(true)
+	rows := samplePodRows(50)
+	for i, row := range rows {
+		row[msgs.PodKeyName] = fmt.Sprintf("pod-%02d", i)
+	}
+	p.SetRows(rows)
+	p.ScrollRows(30) // move the window off the first rows
+
+	lines := strings.Split(p.View(), "\n")
+	if p.ClickRow(1) {
+		t.Error("clicking the header should hit no row")
+	}
+	for _, y := range []int{3, 6} {
+		if !p.ClickRow(y) {
+			t.Fatalf("ClickRow(%d) hit nothing", y)
+		}
+		name, _ := p.SelectedRow()[msgs.PodKeyName].(string)
+		if !strings.Contains(ansi.Strip(lines[y]), name) {
+			t.Errorf("ClickRow(%d) picked %s, but line %d shows %q", y, name, y, ansi.Strip(lines[y]))
+		}
+	}
+
+	p.ScrollRows(-100)
+	if name := p.SelectedRow()[msgs.PodKeyName]; name != "pod-00" {
+		t.Errorf("scrolling past the top should stop on the first row, got %v", name)
+	}
+}
+
+func TestLogPanes_ActivateAtPicksTheGridCell(t *testing.T) {
+	p := NewLogPanes()
+	p.Add()
+	p.Add()
+	p.SetSize(2*minLogPaneWidth+1, 20) // two above one
+
+	if !p.ActivateAt(minLogPaneWidth+5, 2) || p.active != 1 {
+		t.Errorf("top-right click: active = %d, want 1", p.active)
+	}
+	if !p.ActivateAt(3, 15) || p.active != 2 {
+		t.Errorf("bottom click: active = %d, want 2", p.active)
+	}
+	if p.ActivateAt(minLogPaneWidth+5, 15) {
+		t.Error("the lone bottom pane spans the row; clicking it again changed nothing")
+	}
+}
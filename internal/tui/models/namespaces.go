@@ -0,0 +1,160 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// NamespacePicker is the modal overlay for choosing which namespaces a
+// context loads from. It opens in a loading state while the namespace list
+// is fetched; MainPage reads Picked() once Enter confirms.
+type NamespacePicker struct {
+	keyed
+	context    string
+	namespaces []string
+	picked     map[string]bool
+	loading    bool
+	err        string
+	cursor     int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewNamespacePicker() *NamespacePicker {
+	return &NamespacePicker{picked: make(map[string]bool)}
+}
+
+// Open resets the picker for context, prechecking its current namespaces,
+// and waits for SetNamespaces.
+func (p *NamespacePicker) Open(context string, current []string) {
+	p.context = context
+	p.namespaces = nil
+	p.picked = make(map[string]bool, len(current))
+	for _, ns := range current {
+		p.picked[ns] = true
+	}
+	p.loading = true
+	p.err = ""
+	p.cursor = 0
+}
+
+// Context returns the context the picker was opened for.
+func (p *NamespacePicker) Context() string {
+	return p.context
+}
+
+// SetNamespaces fills in the fetched namespace list, or the error fetching
+// it. The cursor starts on the first prechecked namespace.
+func (p *NamespacePicker) SetNamespaces(namespaces []string, err error) {
+	p.loading = false
+	if err != nil {
+		p.err = err.Error()
+		return
+	}
+	p.namespaces = namespaces
+	for i, ns := range namespaces {
+		if p.picked[ns] {
+			p.cursor = i
+			break
+		}
+	}
+}
+
+// Picked returns the checked namespaces in list order. Namespaces
+// prechecked but no longer listed are dropped.
+func (p *NamespacePicker) Picked() []string {
+	var picked []string
+	for _, ns := range p.namespaces {
+		if p.picked[ns] {
+			picked = append(picked, ns)
+		}
+	}
+	return picked
+}
+
+// Ready reports whether the list has loaded, so Enter has something to
+// confirm.
+func (p *NamespacePicker) Ready() bool {
+	return !p.loading && p.err == ""
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (p *NamespacePicker) SetSize(w, h int) {
+	p.width, p.height = w, h
+	p.innerW = max(20, min(60, w-16))
+	p.innerH = max(3, h*4/5-5)
+}
+
+func (p *NamespacePicker) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok || !p.Ready() {
+		return nil
+	}
+	km := p.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		p.cursor--
+	case key.Matches(press, km.Down):
+		p.cursor++
+	case key.Matches(press, km.Top):
+		p.cursor = 0
+	case key.Matches(press, km.Bottom):
+		p.cursor = len(p.namespaces) - 1
+	case key.Matches(press, km.Toggle):
+		if p.cursor >= 0 && p.cursor < len(p.namespaces) {
+			ns := p.namespaces[p.cursor]
+			p.picked[ns] = !p.picked[ns]
+		}
+	}
+	p.cursor = max(0, min(p.cursor, len(p.namespaces)-1))
+	return nil
+}
+
+func (p *NamespacePicker) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	checkStyle := lipgloss.NewStyle().Foreground(pal.Green)
+
+	var lines []string
+	switch {
+	case p.loading:
+		lines = append(lines, dim.Render("Loading namespaces…"))
+	case p.err != "":
+		lines = append(lines, lipgloss.NewStyle().Foreground(pal.Red).Width(p.innerW).Render(p.err))
+	case len(p.namespaces) == 0:
+		lines = append(lines, dim.Render("No namespaces visible in this context."))
+	}
+	start := max(0, p.cursor-p.innerH+1)
+	for i := start; i < len(p.namespaces) && len(lines) < p.innerH; i++ {
+		ns := p.namespaces[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		check := dim.Render("[ ]")
+		if p.picked[ns] {
+			check = checkStyle.Render("[x]")
+		}
+		lines = append(lines, ansi.Truncate(marker+check+" "+ns, p.innerW, "…"))
+	}
+	for len(lines) < p.innerH {
+		lines = append(lines, "")
+	}
+
+	title := fmt.Sprintf("Namespaces: %s", p.context)
+	if n := len(p.Picked()); n > 0 {
+		title += fmt.Sprintf(" (%d picked)", n)
+	}
+	footer := "space toggle • enter apply (none = context default) • esc cancel"
+	return renderOverlayBox(p.width, p.height, p.innerW, title, strings.Join(lines, "\n"), footer)
+}
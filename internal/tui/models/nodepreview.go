@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// schedulingDisabled is the status a cordoned node's Ready condition is
+// suffixed with, as `kubectl get nodes` shows it.
+const schedulingDisabled = "SchedulingDisabled"
+
+// Cordoned reports whether a node's status says it's cordoned.
+func Cordoned(node k8s.NodeInfo) bool {
+	return slices.Contains(strings.Split(node.Status, ","), schedulingDisabled)
+}
+
+// CordonPreview is the confirmation preview of cordoning a node, or
+// uncordoning it with cordon false: its status before and after.
+func CordonPreview(node k8s.NodeInfo, cordon bool) []string {
+	return []string{schedulingChange(node, cordon)}
+}
+
+// DrainPreview is the confirmation preview of draining a node: the cordon
+// its drain starts with, then every pod on it and what draining does to
+// it — evicted, skipped (DaemonSet, mirror pod), or refused by a
+// PodDisruptionBudget out of disruptions — with the pods no controller
+// replaces and those losing emptyDir data called out.
+func DrainPreview(node k8s.NodeInfo, plan k8s.DrainPlan) []string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	evict := lipgloss.NewStyle().Foreground(pal.Yellow)
+	blocked := lipgloss.NewStyle().Foreground(pal.Red)
+	warn := lipgloss.NewStyle().Foreground(pal.Peach)
+
+	evictions := 0
+	for _, pod := range plan.Pods {
+		if pod.Skip == "" {
+			evictions++
+		}
+	}
+	summary := fmt.Sprintf("evicts %d of its %d pods", evictions, len(plan.Pods))
+	if n := plan.Blocked(); n > 0 {
+		summary += blocked.Render(fmt.Sprintf("; %d would be refused by a PodDisruptionBudget as things stand", n))
+	}
+	lines := []string{schedulingChange(node, true), summary, ""}
+
+	if len(plan.Pods) == 0 {
+		lines = append(lines, dim.Render("No pods on the node."))
+	}
+	for _, pod := range plan.Pods {
+		name := textwidth.Sanitize(pod.Namespace + "/" + pod.Name)
+		var line string
+		switch {
+		case pod.Skip != "":
+			line = dim.Render("  skip   " + name + " (" + pod.Skip + ")")
+		case pod.BlockedBy != "":
+			line = blocked.Render("✗ evict  " + name + " — blocked by PDB " + textwidth.Sanitize(pod.BlockedBy))
+		default:
+			line = evict.Render("• evict  ") + name
+		}
+		if pod.Skip == "" {
+			var notes []string
+			if pod.Unmanaged {
+				notes = append(notes, "no controller, not replaced")
+			}
+			if pod.LocalData {
+				notes = append(notes, "emptyDir data lost")
+			}
+			if len(notes) > 0 {
+				line += warn.Render("  ! " + strings.Join(notes, ", "))
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// schedulingChange is the line saying how a cordon (or uncordon) changes
+// a node's status, e.g. "Ready → Ready,SchedulingDisabled".
+func schedulingChange(node k8s.NodeInfo, cordon bool) string {
+	pal := styles.CatppuccinMocha()
+	label := lipgloss.NewStyle().Foreground(pal.Blue)
+	var kept []string
+	for _, s := range strings.Split(node.Status, ",") {
+		if s != "" && s != schedulingDisabled {
+			kept = append(kept, s)
+		}
+	}
+	after := kept
+	if cordon {
+		after = append(append([]string(nil), kept...), schedulingDisabled)
+	}
+	before := node.Status
+	if before == "" {
+		before = "?"
+	}
+	change := before + " → " + strings.Join(after, ",")
+	if Cordoned(node) == cordon {
+		change = before + " (unchanged)"
+	}
+	return label.Render("status") + "  " + textwidth.Sanitize(change)
+}
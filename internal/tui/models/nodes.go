@@ -0,0 +1,376 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// NodePage is the nodes tab: every selected context's nodes, the ones not
+// Ready first, like `kubectl get nodes` with capacity and pressure
+// conditions alongside. Like the top tab it isn't watch-backed — MainPage
+// re-lists on entry, "r" and the refresh tick — and has no wide mode.
+type NodePage struct {
+	keyed
+	table btable.Model
+
+	byContext map[string][]k8s.NodeInfo
+	errs      map[string]string // by context, e.g. forbidden
+	nodes     []k8s.NodeInfo    // every context's nodes, sorted
+
+	cachedView string
+	viewDirty  bool
+	focused    bool
+	tableW     int
+	tableH     int
+
+	// filter is over node names, as on the other tabs.
+	filter rowFilter
+
+	// cursorIdx/windowStart/windowSize: see the identical fields on PodPage
+	// in pods.go. cursorIdx is a position in the filter's index space.
+	cursorIdx   int
+	windowStart int
+	windowSize  int
+}
+
+func NewNodePage() *NodePage {
+	return &NodePage{
+		table:      newBubbleTable(nodeColumns()),
+		byContext:  make(map[string][]k8s.NodeInfo),
+		errs:       make(map[string]string),
+		viewDirty:  true,
+		windowSize: defaultRowWindowSize,
+	}
+}
+
+func (n *NodePage) Init() tea.Cmd {
+	return nil
+}
+
+func (n *NodePage) Update(msg tea.Msg) tea.Cmd {
+	if !n.focused {
+		return nil
+	}
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	if n.filter.filtering {
+		n.filter.handleKey(n.keyMap(), press, len(n.nodes), n.filterMatch)
+		n.jumpTo(0)
+		return nil
+	}
+	km := n.keyMap()
+	switch {
+	case key.Matches(press, km.Down):
+		n.moveCursor(1)
+	case key.Matches(press, km.Up):
+		n.moveCursor(-1)
+	case key.Matches(press, km.Top):
+		n.jumpTo(0)
+	case key.Matches(press, km.Bottom):
+		n.jumpTo(n.filter.len(len(n.nodes)) - 1)
+	case key.Matches(press, km.Filter):
+		n.filter.filtering = true
+	}
+	return nil
+}
+
+func (n *NodePage) filterMatch(i int) bool {
+	return strings.Contains(strings.ToLower(n.nodes[i].Name), strings.ToLower(n.filter.query))
+}
+
+// FilterStatus: see PodPage.FilterStatus in pods.go.
+func (n *NodePage) FilterStatus() (query string, matches int, typing bool, ok bool) {
+	if !n.filter.filtering && n.filter.query == "" {
+		return "", 0, false, false
+	}
+	return n.filter.query, n.filter.len(len(n.nodes)), n.filter.filtering, true
+}
+
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (n *NodePage) SetFilter(query string) {
+	n.filter.set(query, len(n.nodes), n.filterMatch)
+	n.jumpTo(0)
+}
+
+// The nodes tab has no wide columns; these satisfy the interface MainPage
+// drives every resource tab through.
+func (n *NodePage) ToggleWideMode()                            {}
+func (n *NodePage) WideMode() bool                             { return false }
+func (n *NodePage) ScrollLeft()                                {}
+func (n *NodePage) ScrollRight()                               {}
+func (n *NodePage) ScrollStatus() (offset, total int, ok bool) { return 0, 0, false }
+
+// SetNodes replaces one context's nodes, clearing any error it had.
+func (n *NodePage) SetNodes(context string, nodes []k8s.NodeInfo) {
+	n.byContext[context] = nodes
+	delete(n.errs, context)
+	n.rebuild()
+	n.applySize()
+}
+
+// SetError records why a context's nodes couldn't be listed. Its last good
+// list, if any, is dropped rather than shown stale.
+func (n *NodePage) SetError(context, err string) {
+	delete(n.byContext, context)
+	n.errs[context] = err
+	n.rebuild()
+	n.applySize()
+}
+
+// RemoveContext forgets a deselected context.
+func (n *NodePage) RemoveContext(context string) {
+	delete(n.byContext, context)
+	delete(n.errs, context)
+	n.rebuild()
+	n.applySize()
+}
+
+// Len is how many nodes are listed, for the status bar.
+func (n *NodePage) Len() int {
+	return len(n.nodes)
+}
+
+// Selected returns the node under the cursor.
+func (n *NodePage) Selected() (k8s.NodeInfo, bool) {
+	if n.cursorIdx < 0 || n.cursorIdx >= n.filter.len(len(n.nodes)) {
+		return k8s.NodeInfo{}, false
+	}
+	return n.nodes[n.filter.absolute(n.cursorIdx)], true
+}
+
+// Find returns a context's node by name, listed or filtered out.
+func (n *NodePage) Find(context, name string) (k8s.NodeInfo, bool) {
+	for _, node := range n.byContext[context] {
+		if node.Name == name {
+			return node, true
+		}
+	}
+	return k8s.NodeInfo{}, false
+}
+
+func nodeKey(node k8s.NodeInfo) string {
+	return node.Context + "/" + node.Name
+}
+
+// rebuild re-sorts every context's nodes, keeping the cursor on the same
+// node where it can.
+func (n *NodePage) rebuild() {
+	var selected string
+	if n.cursorIdx >= 0 && n.cursorIdx < n.filter.len(len(n.nodes)) {
+		selected = nodeKey(n.nodes[n.filter.absolute(n.cursorIdx)])
+	}
+
+	n.nodes = n.nodes[:0]
+	for _, ctx := range slices.Sorted(maps.Keys(n.byContext)) {
+		n.nodes = append(n.nodes, n.byContext[ctx]...)
+	}
+	// Contexts, then names, are already in order; only float the nodes in
+	// trouble to the top.
+	slices.SortStableFunc(n.nodes, func(a, b k8s.NodeInfo) int {
+		fa, fb := k8s.NodeStatusFailing(a.Status), k8s.NodeStatusFailing(b.Status)
+		switch {
+		case fa && !fb:
+			return -1
+		case fb && !fa:
+			return 1
+		}
+		return 0
+	})
+	n.filter.recompute(len(n.nodes), n.filterMatch)
+
+	total := n.filter.len(len(n.nodes))
+	for pos := 0; pos < total && selected != ""; pos++ {
+		if nodeKey(n.nodes[n.filter.absolute(pos)]) == selected {
+			n.cursorIdx = pos
+			break
+		}
+	}
+	if n.cursorIdx >= total {
+		n.cursorIdx = max(total-1, 0)
+	}
+	n.windowStart = computeWindowStart(n.windowStart, n.cursorIdx, total, n.windowSize)
+	n.pushDisplayRows()
+}
+
+// moveCursor: see PodPage.moveCursor in pods.go.
+func (n *NodePage) moveCursor(delta int) {
+	total := n.filter.len(len(n.nodes))
+	if total == 0 {
+		return
+	}
+	n.cursorIdx += delta
+	if n.cursorIdx < 0 {
+		n.cursorIdx = total - 1
+	} else if n.cursorIdx >= total {
+		n.cursorIdx = 0
+	}
+	n.windowStart = computeWindowStart(n.windowStart, n.cursorIdx, total, n.windowSize)
+	n.pushDisplayRows()
+}
+
+// jumpTo: see PodPage.jumpTo in pods.go.
+func (n *NodePage) jumpTo(idx int) {
+	total := n.filter.len(len(n.nodes))
+	if total == 0 {
+		n.cursorIdx = 0
+		n.pushDisplayRows()
+		return
+	}
+	n.cursorIdx = max(0, min(idx, total-1))
+	n.windowStart = computeWindowStart(n.windowStart, n.cursorIdx, total, n.windowSize)
+	n.pushDisplayRows()
+}
+
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (n *NodePage) ClickRow(y int) bool {
+	y -= len(n.errs) // the error lines View puts above the table
+	pos, ok := rowAtLine(y, n.windowStart, n.filter.len(len(n.nodes)))
+	if ok {
+		n.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (n *NodePage) ScrollRows(delta int) {
+	n.jumpTo(n.cursorIdx + delta)
+}
+
+func (n *NodePage) pushDisplayRows() {
+	start, end := windowBounds(n.windowStart, n.filter.len(len(n.nodes)), n.windowSize)
+	display := make([]btable.Row, 0, end-start)
+	for pos := start; pos < end; pos++ {
+		node := n.nodes[n.filter.absolute(pos)]
+		roles := strings.Join(node.Roles, ",")
+		if roles == "" {
+			roles = "<none>"
+		}
+		display = append(display, btable.NewRow(btable.RowData{
+			msgs.NodeKeyName:       node.Name,
+			msgs.NodeKeyStatus:     btable.NewStyledCellWithStyleFunc(node.Status, nodeStatusCellStyle),
+			msgs.NodeKeyRoles:      roles,
+			msgs.NodeKeyVersion:    node.Version,
+			msgs.NodeKeyCPU:        formatCores(node.CPUMilli),
+			msgs.NodeKeyMemory:     HumanBytes(node.MemoryBytes) + "i",
+			msgs.NodeKeyPods:       strconv.FormatInt(node.Pods, 10),
+			msgs.NodeKeyConditions: btable.NewStyledCell(strings.Join(node.Conditions, ","), styles.Mocha().Yellow),
+			msgs.NodeKeyAge:        node.Age,
+			msgs.NodeKeyContext:    node.Context,
+		}))
+	}
+	n.table = n.table.WithRows(display).WithHighlightedRow(n.cursorIdx - start)
+	n.invalidateView()
+}
+
+// formatCores renders a CPU capacity in whole cores where it is one ("8"),
+// else in millicores ("7500m").
+func formatCores(milli int64) string {
+	if milli%1000 == 0 {
+		return strconv.FormatInt(milli/1000, 10)
+	}
+	return fmt.Sprintf("%dm", milli)
+}
+
+// nodeStatusCellStyle is a btable.StyledCellFunc that colors a node's
+// status (k8s.NodeInfo.Status): green when Ready, yellow when Ready but
+// cordoned, red when NotReady or Unknown.
+func nodeStatusCellStyle(input btable.StyledCellFuncInput) lipgloss.Style {
+	t := styles.Mocha()
+	status, _ := input.Data.(string)
+	switch {
+	case status == "":
+		return t.Plain
+	case k8s.NodeStatusFailing(status):
+		return t.Red
+	case strings.Contains(status, ","):
+		return t.Yellow
+	}
+	return t.Green
+}
+
+func (n *NodePage) View() string {
+	if n.cachedView != "" && !n.viewDirty {
+		return n.cachedView
+	}
+
+	view := n.table.View()
+	if len(n.errs) > 0 {
+		errStyle := styles.Mocha().Red
+		var lines []string
+		for _, ctx := range slices.Sorted(maps.Keys(n.errs)) {
+			lines = append(lines, errStyle.Render(fmt.Sprintf("⚠ %s: %s", ctx, n.errs[ctx])))
+		}
+		view = strings.Join(lines, "\n") + "\n" + view
+	}
+	n.cachedView = view
+	n.viewDirty = false
+	return view
+}
+
+func (n *NodePage) SetFocused(f bool) {
+	n.focused = f
+	n.table = n.table.Focused(f)
+	n.invalidateView()
+}
+
+func (n *NodePage) SetSize(width, h int) {
+	if width < 10 || h < 1 {
+		return
+	}
+	n.tableW, n.tableH = width, h
+	n.applySize()
+}
+
+// applySize: see TopPage.applySize in top.go.
+func (n *NodePage) applySize() {
+	if n.tableW == 0 {
+		return
+	}
+	h := max(3, n.tableH-len(n.errs))
+
+	st := styles.CatppuccinBubbleTableStyle()
+	n.table = newBubbleTable(nodeColumns()).
+		WithMinimumHeight(h).
+		WithTargetWidth(n.tableW).
+		WithMaxTotalWidth(n.tableW).
+		HeaderStyle(st.Header).
+		HighlightStyle(st.Highlight).
+		WithBaseStyle(st.Base).
+		Focused(n.focused)
+	n.windowSize = rowWindowSizeFor(h)
+	n.windowStart = computeWindowStart(n.windowStart, n.cursorIdx, n.filter.len(len(n.nodes)), n.windowSize)
+	n.pushDisplayRows()
+}
+
+func (n *NodePage) invalidateView() {
+	n.viewDirty = true
+	n.cachedView = ""
+}
+
+func nodeColumns() []btable.Column {
+	return []btable.Column{
+		paddedFlexColumn(msgs.NodeKeyName, "Name", 10),
+		paddedFlexColumn(msgs.NodeKeyStatus, "Status", 6),
+		paddedFlexColumn(msgs.NodeKeyRoles, "Roles", 5),
+		paddedFlexColumn(msgs.NodeKeyVersion, "Version", 4),
+		paddedFlexColumn(msgs.NodeKeyCPU, "CPU", 2),
+		paddedFlexColumn(msgs.NodeKeyMemory, "Memory", 3),
+		paddedFlexColumn(msgs.NodeKeyPods, "Pods", 2),
+		paddedFlexColumn(msgs.NodeKeyConditions, "Conditions", 6),
+		paddedFlexColumn(msgs.NodeKeyAge, "Age", 3),
+		paddedFlexColumn(msgs.NodeKeyContext, "Context", 5),
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// PodEnvLines renders a pod's resolved container environments for the
+// InfoPanel: one block per container, NAME = value rows aligned on "=", with
+// each var's valueFrom source dimmed after it. Masked (secret) values are
+// rendered dimmed too, so they don't read as the real value.
+func PodEnvLines(envs []k8s.ContainerEnv) []string {
+	p := styles.CatppuccinMocha()
+	headerStyle := lipgloss.NewStyle().Foreground(p.Peach).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(p.Blue)
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+
+	var lines []string
+	for i, ce := range envs {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		header := "▸ " + ce.Container
+		if ce.Init {
+			header += " (init)"
+		}
+		lines = append(lines, headerStyle.Render(header))
+		if len(ce.Vars) == 0 {
+			lines = append(lines, dim.Render("  (no environment variables)"))
+			continue
+		}
+
+		nameW := 0
+		for _, v := range ce.Vars {
+			nameW = max(nameW, textwidth.Width(v.Name))
+		}
+		for _, v := range ce.Vars {
+			value := v.Value
+			if v.Masked {
+				value = dim.Render(value)
+			}
+			line := fmt.Sprintf("  %s = %s", textwidth.PadRight(nameStyle.Render(v.Name), nameW), value)
+			if v.Source != "" {
+				line += dim.Render("  ← " + v.Source)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+// noDeployment names the group of pods no Deployment owns — StatefulSet,
+// DaemonSet and Job pods, and bare ones.
+const noDeployment = "(no deployment)"
+
+// GroupKey identifies the Deployment a Pods row belongs to in the
+// by-deployment browse, by context/namespace/deployment; pods no Deployment
+// owns share their namespace's "" deployment.
+func GroupKey(row msgs.RowData) string {
+	ctx, _ := row[msgs.PodKeyContext].(string)
+	ns, _ := row[msgs.PodKeyNamespace].(string)
+	deployment, _ := row[msgs.PodKeyDeployment].(string)
+	return ctx + "/" + ns + "/" + deployment
+}
+
+// ToggleByDeployment switches between the flat pod list and browsing by
+// deployment: a row per Deployment first (with its running/total pods and
+// their restarts), then, after OpenGroup, just that Deployment's pods —
+// where a namespace of hundreds of pods from many apps is a short list.
+func (p *PodPage) ToggleByDeployment() {
+	p.byDeployment = !p.byDeployment
+	p.group = ""
+	p.cursorIdx = 0
+	p.filter = rowFilter{}
+	p.applyRows()
+}
+
+// ByDeployment reports whether the tab is browsing by deployment.
+func (p *PodPage) ByDeployment() bool {
+	return p.byDeployment
+}
+
+// OpenGroup lists the pods of the Deployment under the cursor; false if
+// the cursor isn't on a Deployment's row.
+func (p *PodPage) OpenGroup() bool {
+	if !p.byDeployment || p.group != "" || p.cursorIdx >= p.activeLen() {
+		return false
+	}
+	key, _ := p.activeRow(p.cursorIdx)[msgs.PodKeyGroup].(string)
+	if key == "" {
+		return false
+	}
+	p.group = key
+	p.groupCursor = p.cursorIdx
+	p.cursorIdx = 0
+	p.filter = rowFilter{}
+	p.applyRows()
+	return true
+}
+
+// OpenDeployment browses straight to the pods of context's Deployment
+// namespace/name, as if it had been picked from the by-deployment list:
+// Backspace goes up to that list, the cursor on it.
+func (p *PodPage) OpenDeployment(context, namespace, name string) {
+	key := GroupKey(msgs.RowData{
+		msgs.PodKeyContext:    context,
+		msgs.PodKeyNamespace:  namespace,
+		msgs.PodKeyDeployment: name,
+	})
+	p.byDeployment = true
+	p.group = ""
+	p.groupCursor = 0
+	for i, row := range p.levelRows() {
+		if row[msgs.PodKeyGroup] == key {
+			p.groupCursor = i
+			break
+		}
+	}
+	p.group = key
+	p.cursorIdx = 0
+	p.filter = rowFilter{}
+	p.applyRows()
+}
+
+// CloseGroup goes back up from a Deployment's pods to the Deployments,
+// the cursor back on the one left; false if none is open.
+func (p *PodPage) CloseGroup() bool {
+	if p.group == "" {
+		return false
+	}
+	p.group = ""
+	p.cursorIdx = p.groupCursor
+	p.filter = rowFilter{}
+	p.applyRows()
+	return true
+}
+
+// Group returns the name of the Deployment whose pods are listed, "" at
+// the top level or outside the by-deployment browse.
+func (p *PodPage) Group() string {
+	if p.group == "" {
+		return ""
+	}
+	for _, row := range p.all {
+		if GroupKey(row) == p.group {
+			return groupName(row)
+		}
+	}
+	return noDeployment
+}
+
+// levelRows returns the rows of the by-deployment level being browsed: a
+// group row per Deployment, in the order their pods arrive, or the open
+// Deployment's pods.
+func (p *PodPage) levelRows() []msgs.RowData {
+	all := scopedRows(p.all, p.scope)
+	if p.group != "" {
+		var pods []msgs.RowData
+		for _, row := range all {
+			if GroupKey(row) == p.group {
+				pods = append(pods, row)
+			}
+		}
+		return pods
+	}
+
+	type tally struct {
+		row                     msgs.RowData
+		running, total, restart int
+	}
+	var order []string
+	groups := make(map[string]*tally)
+	for _, row := range all {
+		key := GroupKey(row)
+		g, ok := groups[key]
+		if !ok {
+			g = &tally{row: msgs.RowData{
+				msgs.PodKeyGroup:     key,
+				msgs.PodKeyName:      groupName(row),
+				msgs.PodKeyNamespace: row[msgs.PodKeyNamespace],
+				msgs.PodKeyContext:   row[msgs.PodKeyContext],
+			}}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.total++
+		if row[msgs.PodKeyStatus] == "Running" {
+			g.running++
+		}
+		restarts, _ := row[msgs.PodKeyRestarts].(string)
+		n, _ := strconv.Atoi(restarts)
+		g.restart += n
+	}
+
+	rows := make([]msgs.RowData, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		g.row[msgs.PodKeyStatus] = fmt.Sprintf("%d/%d", g.running, g.total)
+		g.row[msgs.PodKeyRestarts] = strconv.Itoa(g.restart)
+		rows = append(rows, g.row)
+	}
+	return rows
+}
+
+// groupName is the name a pod's group row shows.
+func groupName(row msgs.RowData) string {
+	if deployment, _ := row[msgs.PodKeyDeployment].(string); deployment != "" {
+		return deployment
+	}
+	return noDeployment
+}
+
+// groupDisplayRow renders a Deployment's group row: "▸" where pods show
+// their checkbox, and running/total pods colored like a replica count.
+func groupDisplayRow(row msgs.RowData) btable.Row {
+	return btable.NewRow(btable.RowData{
+		msgs.PodKeyCheck:     "▸",
+		msgs.PodKeyName:      row[msgs.PodKeyName],
+		msgs.PodKeyNamespace: row[msgs.PodKeyNamespace],
+		msgs.PodKeyStatus:    btable.NewStyledCellWithStyleFunc(row[msgs.PodKeyStatus], replicaCellStyle),
+		msgs.PodKeyRestarts:  row[msgs.PodKeyRestarts],
+		msgs.PodKeyContext:   row[msgs.PodKeyContext],
+	})
+}
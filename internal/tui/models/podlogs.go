@@ -8,29 +8,52 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/logfmt"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/keys"
+	"github.com/ktails/ktails/internal/tui/msgs"
 	"github.com/ktails/ktails/internal/tui/styles"
 )
 
-// maxLogLines bounds the in-memory scrollback per source, dropping each
-// source's own oldest lines once exceeded — a noisy container can't evict a
-// quiet one's history.
-const maxLogLines = 500
+// defaultMaxLogLines bounds the in-memory scrollback per source unless
+// config.Preferences.MaxLogLines says otherwise (see SetMaxLines).
+const defaultMaxLogLines = 500
 
-// sourceColors is the rotation of Catppuccin Mocha accents used to color
-// each source's line prefix. Red/Mauve/Green/Peach are excluded: they
-// already carry other meaning elsewhere in the UI (errors, focus/selection,
-// loaded state, this pane's own title).
+// maxStructuredColWidth caps every structured-view column but the last, so
+// one oversized timestamp or level value can't push the message off-screen.
+const maxStructuredColWidth = 32
+
+// sourceColors is the rotation of accents used to color each source's
+// line prefix (see styles.AccentColors).
 func sourceColors() []color.Color {
-	p := styles.CatppuccinMocha()
-	return []color.Color{
-		p.Blue, p.Lavender, p.Sapphire, p.Sky, p.Teal,
-		p.Pink, p.Flamingo, p.Rosewater, p.Yellow, p.Maroon,
-	}
+	return styles.AccentColors()
+}
+
+// SourceColor is the prefix color of the i'th source opened in a log pane;
+// the headless tail (package tail) uses the same rotation.
+func SourceColor(i int) color.Color {
+	colors := sourceColors()
+	return colors[i%len(colors)]
+}
+
+// SourcePrefix renders a source's "pod/container |" line prefix the way the
+// merged view does.
+func SourcePrefix(label string, c color.Color) string {
+	return lipgloss.NewStyle().Foreground(c).Bold(true).Render(label+" |") + " "
+}
+
+// HighlightLine renders a log line the way the raw view does with level
+// colors on: any JSON payload highlighted, its level token colored.
+func HighlightLine(text string) string {
+	level, start, end := logfmt.DetectLevel(text)
+	return renderRawText(text, level, start, end, true, styles.CatppuccinMocha())
 }
 
 // highlightJSONLine finds the first JSON object or array embedded in a log
@@ -178,6 +201,18 @@ func colorizeJSON(s string, p styles.Palette) (string, bool) {
 type logLine struct {
 	seq  int64
 	text string
+	// time is the kubelet's timestamp for the line, zero for synthetic
+	// lines and streams that didn't carry one.
+	time time.Time
+
+	// level is detected once on arrival (see logfmt.DetectLevel); levelStart/
+	// levelEnd is the level token's span in text, -1 if it has none to
+	// highlight. synthetic marks pane-generated lines (connect notices,
+	// stream-ended banners), which the level filter never hides.
+	level      logfmt.Level
+	levelStart int
+	levelEnd   int
+	synthetic  bool
 }
 
 // logSource is one pod/container being tailed into the merged pane. It
@@ -195,17 +230,33 @@ type logSource struct {
 	lines     []logLine
 	streaming bool
 	streamErr string
+
+	// lastLevel is the level of this source's most recent leveled line,
+	// inherited by indented continuation lines (stack traces, wrapped
+	// payloads) so the level filter keeps a multi-line entry together.
+	lastLevel logfmt.Level
 }
 
 func (s *logSource) label() string {
 	return fmt.Sprintf("%s/%s", s.podName, s.container)
 }
 
+func (s *logSource) target() msgs.LogLevelTarget {
+	return msgs.LogLevelTarget{
+		SourceKey: s.key,
+		Context:   s.context,
+		Namespace: s.namespace,
+		Pod:       s.podName,
+		Container: s.container,
+	}
+}
+
 // LogPage renders a live-tailing merged log viewport for one or more
 // pod/container sources, in the shared bottom Log pane. It holds only
 // render state — opening/reading the underlying streams is orchestrated by
 // cmds + MainPage.
 type LogPage struct {
+	keyed
 	viewport viewport.Model
 
 	sources map[string]*logSource
@@ -236,13 +287,126 @@ type LogPage struct {
 	// horizontal-scroll status indicator doesn't rescan every render.
 	rawLines     []string
 	maxLineWidth int
+
+	// lineTimes holds each rawLines entry's timestamp (zero for synthetic
+	// lines; an expansion row has its line's), and lineRows the viewport
+	// row each starts on once wrapped — nil while unwrapped, where the two
+	// coincide. They let a synchronized scroll (see LogPanes) find the
+	// first line at or after a time.
+	lineTimes []time.Time
+	lineRows  []int
+
+	// structured switches JSON/logfmt lines (see internal/logfmt) from their
+	// raw highlighted text to aligned columns of fields — config-chosen,
+	// logfmt.DefaultFields otherwise. Lines that don't parse stay raw.
+	// Structured mode also shows a line cursor: cursorSeq is the highlighted
+	// line's arrival seq, or 0 to track the newest line as it arrives, and
+	// expanded unfolds that line's full payload beneath it, one field per row.
+	structured bool
+	fields     []string
+	cursorSeq  int64
+	expanded   bool
+
+	// collapse folds each source's runs of identical lines into one badged
+	// "×N" (config.Preferences.CollapseRepeats, toggled with "u"); the
+	// lines themselves stay buffered. folded is how many it hides.
+	collapse bool
+	folded   int
+
+	// cursorLine/cursorSpan locate the cursor (and its expansion rows) within
+	// rawLines; cursorRow/cursorRows are the same span in viewport rows once
+	// wrapping is applied. cursorLine is -1 outside structured mode.
+	cursorLine int
+	cursorSpan int
+	cursorRow  int
+	cursorRows int
+
+	// colorLevels is config.Preferences.ColorCodeLogs: highlight each plain
+	// line's level token, and the structured view's level column, in its
+	// severity's color. minLevel hides lines below it from the view (not the
+	// buffers — lowering it again brings them back); LevelUnknown shows all.
+	colorLevels bool
+	minLevel    logfmt.Level
+
+	// search ("/") hides lines not containing it, case-insensitively, from
+	// the view the same way; "" shows all. searchFold is it lowercased.
+	search     string
+	searchFold string
+
+	// maxLines bounds each source's scrollback, dropping that source's own
+	// oldest lines once exceeded — a noisy container can't evict a quiet
+	// one's history. follow is whether new lines scroll a bottomed-out
+	// view along with them; it starts as followByDefault
+	// (config.Preferences.FollowByDefault) and End turns it on.
+	maxLines        int
+	follow          bool
+	followByDefault bool
+
+	// timestamps prefixes each line with its kubelet timestamp
+	// (config.Preferences.ShowTimestamps, toggled with "t"). mode labels how
+	// MainPage opened the streams — previous instance, since a preset — for
+	// the header; "" for the default tail.
+	timestamps bool
+	mode       string
+
+	// location is the time zone timestamps are shown in ("Z"); nil means
+	// the local one.
+	location *time.Location
+	// stampFormat is how timestamps are rendered ("D"); now is the clock
+	// relative ones are measured against.
+	stampFormat StampFormat
+	now         func() time.Time
+
+	// selecting is a visual line selection ("v") in progress, from the line
+	// with anchorSeq to the cursor line (cursorSeq), in either view mode.
+	selecting bool
+	anchorSeq int64
+
+	// paused freezes the view ("P"): lines arriving after pausedSeq keep
+	// buffering, within maxLines, but aren't shown until it's resumed.
+	paused    bool
+	pausedSeq int64
+
+	// theme styles the per-frame chrome (Header, the empty View); line
+	// content is colored once, in refreshContent.
+	theme *styles.Theme
 }
 
 func NewLogPage() *LogPage {
+	// The viewport keeps just its ←/→ pan; every other key is matched
+	// against the shared keymap (see Update).
+	vp := viewport.New()
+	vp.KeyMap = viewport.KeyMap{Left: vp.KeyMap.Left, Right: vp.KeyMap.Right}
 	return &LogPage{
-		viewport:    viewport.New(),
+		theme:       styles.Mocha(),
+		viewport:    vp,
 		sources:     make(map[string]*logSource),
 		isolatedIdx: -1,
+		fields:      logfmt.DefaultFields,
+		cursorLine:  -1,
+		now:         time.Now,
+
+		maxLines:        defaultMaxLogLines,
+		follow:          true,
+		followByDefault: true,
+	}
+}
+
+// SetMaxLines sets the per-source scrollback limit; n <= 0 keeps the
+// default.
+func (l *LogPage) SetMaxLines(n int) {
+	if n <= 0 {
+		n = defaultMaxLogLines
+	}
+	l.maxLines = n
+}
+
+// SetFollowByDefault sets whether a freshly opened pane follows new lines
+// on its own, or stays put until End is pressed.
+func (l *LogPage) SetFollowByDefault(on bool) {
+	l.followByDefault = on
+	if len(l.order) == 0 {
+		l.follow = on
 	}
 }
 
@@ -268,14 +432,104 @@ func (l *LogPage) Keys() []string {
 	return keys
 }
 
+// ActiveSource returns the source a per-pod action applies to: the
+// isolated one, or the only one. ok is false in a merge of several.
+func (l *LogPage) ActiveSource() (target msgs.LogLevelTarget, ok bool) {
+	var src *logSource
+	switch {
+	case l.isolatedIdx >= 0 && l.isolatedIdx < len(l.order):
+		src = l.sources[l.order[l.isolatedIdx]]
+	case len(l.order) == 1:
+		src = l.sources[l.order[0]]
+	default:
+		return msgs.LogLevelTarget{}, false
+	}
+	return src.target(), true
+}
+
+// Source returns the pod container source key tails, ok false if it isn't
+// open.
+func (l *LogPage) Source(key string) (target msgs.LogLevelTarget, ok bool) {
+	src, ok := l.sources[key]
+	if !ok {
+		return msgs.LogLevelTarget{}, false
+	}
+	return src.target(), true
+}
+
+// RestartSource empties source key's scrollback for a stream MainPage is
+// reopening with different options, starting it over with notice.
+func (l *LogPage) RestartSource(key, notice string) {
+	src, ok := l.sources[key]
+	if !ok {
+		return
+	}
+	src.lines = nil
+	src.streaming = true
+	src.streamErr = ""
+	src.lastLevel = logfmt.LevelUnknown
+	l.cursorSeq = 0
+	l.expanded = false
+	l.selecting = false
+	l.appendSynthetic(src, notice)
+}
+
+// AddNotice appends a marker line to source key's buffer — e.g. where a log
+// level switch took effect, so the lines after it can be read against it.
+func (l *LogPage) AddNotice(key, text string) {
+	src, ok := l.sources[key]
+	if !ok {
+		return
+	}
+	p := styles.CatppuccinMocha()
+	l.appendSynthetic(src, lipgloss.NewStyle().Foreground(p.Sapphire).Render("── "+text+" ──"))
+}
+
+// ErrorLines counts source key's error-level lines and returns the text of
+// the last n of them. Only what's still in its scrollback is counted.
+func (l *LogPage) ErrorLines(key string, n int) (count int, last []string) {
+	src, ok := l.sources[key]
+	if !ok {
+		return 0, nil
+	}
+	for _, ln := range src.lines {
+		if ln.synthetic || ln.level < logfmt.LevelError || strings.HasPrefix(ln.text, " ") || strings.HasPrefix(ln.text, "\t") {
+			continue
+		}
+		count++
+		last = append(last, ln.text)
+		if len(last) > n {
+			last = last[1:]
+		}
+	}
+	return count, last
+}
+
+// AddSummary appends a block to source key's buffer: head as a marker
+// line like AddNotice's, then each of lines indented beneath it.
+func (l *LogPage) AddSummary(key, head string, lines []string) {
+	src, ok := l.sources[key]
+	if !ok {
+		return
+	}
+	p := styles.CatppuccinMocha()
+	l.appendSynthetic(src, lipgloss.NewStyle().Foreground(p.Sapphire).Bold(true).Render("── "+head+" ──"))
+	body := lipgloss.NewStyle().Foreground(p.Subtext0)
+	for _, line := range lines {
+		l.appendSynthetic(src, body.Render("   "+line))
+	}
+}
+
 // AddSource opens a new source in the pane, idempotently (a no-op if the
 // key is already present). Assigns the next color in the rotation.
 func (l *LogPage) AddSource(key, podName, namespace, context, container string) {
 	if _, exists := l.sources[key]; exists {
 		return
 	}
-	colors := sourceColors()
-	color := colors[len(l.order)%len(colors)]
+	if len(l.order) == 0 {
+		l.follow = l.followByDefault
+	}
+	color := SourceColor(len(l.order))
 
 	src := &logSource{
 		key:       key,
@@ -288,7 +542,35 @@ func (l *LogPage) AddSource(key, podName, namespace, context, container string)
 	}
 	l.sources[key] = src
 	l.order = append(l.order, key)
-	l.appendTo(src, fmt.Sprintf("Connecting to %s...", src.label()))
+	l.appendSynthetic(src, fmt.Sprintf("Connecting to %s...", src.label()))
+}
+
+// ReplaceSource carries source key's scrollback, color and place over to
+// newKey, tailing container in the pod podName that replaced its pod, with
+// a divider line marking where one pod's output ends and the other's
+// begins. A no-op unless key is present and newKey (if different) isn't.
+func (l *LogPage) ReplaceSource(key, newKey, podName, divider string) {
+	src, ok := l.sources[key]
+	if !ok {
+		return
+	}
+	if newKey != key {
+		if _, taken := l.sources[newKey]; taken {
+			return
+		}
+		delete(l.sources, key)
+		l.sources[newKey] = src
+		for i, k := range l.order {
+			if k == key {
+				l.order[i] = newKey
+			}
+		}
+	}
+	src.key, src.podName = newKey, podName
+	src.streaming, src.streamErr = true, ""
+	src.lastLevel = logfmt.LevelUnknown
+	p := styles.CatppuccinMocha()
+	l.appendSynthetic(src, lipgloss.NewStyle().Foreground(p.Peach).Bold(true).Render("━━ "+divider+" ━━"))
 }
 
 // RemoveSource closes and forgets a source. Isolation resets to the full
@@ -314,6 +596,11 @@ func (l *LogPage) Clear() {
 	l.sources = make(map[string]*logSource)
 	l.order = nil
 	l.isolatedIdx = -1
+	l.cursorSeq = 0
+	l.expanded = false
+	l.selecting = false
+	l.paused = false
+	l.follow = l.followByDefault
 	l.viewport.SetContent("")
 }
 
@@ -343,35 +630,61 @@ func (l *LogPage) IsolatedLabel() string {
 }
 
 // AppendLine adds a line read from source key's live stream. The viewport
-// only auto-follows to the bottom if it was already there.
+// only auto-follows to the bottom if it was already there, and following
+// is on.
 func (l *LogPage) AppendLine(key, line string) {
+	l.AppendLineAt(key, line, time.Time{})
+}
+
+// AppendLineAt is AppendLine for a line the stream timestamped at ts. The
+// line is sanitized on the way in (see textwidth.Sanitize), so a tab or a
+// stray carriage return can't throw the pane's columns and borders off.
+func (l *LogPage) AppendLineAt(key, line string, ts time.Time) {
 	src, ok := l.sources[key]
 	if !ok {
 		return
 	}
-	l.appendTo(src, line)
+	line = textwidth.Sanitize(line)
+	ln := logLine{text: line, time: ts}
+	ln.level, ln.levelStart, ln.levelEnd = logfmt.DetectLevel(line)
+	switch {
+	case ln.level != logfmt.LevelUnknown:
+		src.lastLevel = ln.level
+	case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+		ln.level = src.lastLevel
+	}
+	l.appendTo(src, ln)
+}
+
+// appendSynthetic adds a pane-generated notice to src's buffer.
+func (l *LogPage) appendSynthetic(src *logSource, text string) {
+	l.appendTo(src, logLine{text: text, levelStart: -1, levelEnd: -1, synthetic: true})
 }
 
-func (l *LogPage) appendTo(src *logSource, text string) {
+func (l *LogPage) appendTo(src *logSource, ln logLine) {
 	wasAtBottom := l.viewport.AtBottom()
 
 	l.nextSeq++
-	src.lines = append(src.lines, logLine{seq: l.nextSeq, text: text})
-	if len(src.lines) > maxLogLines {
-		src.lines = src.lines[len(src.lines)-maxLogLines:]
+	ln.seq = l.nextSeq
+	src.lines = append(src.lines, ln)
+	if len(src.lines) > l.maxLines {
+		src.lines = src.lines[len(src.lines)-l.maxLines:]
+	}
+	if l.paused {
+		return
 	}
 
 	l.refreshContent()
 
-	if wasAtBottom {
+	if wasAtBottom && l.follow {
 		l.viewport.GotoBottom()
 	}
 }
 
 // SetStreamEnded records that source key's stream stopped (server-closed or
 // errored) and appends an inline banner line for just that source,
-// preserving scrollback rather than replacing the pane. There's no
-// auto-retry — the source simply stops.
+// preserving scrollback rather than replacing the pane. MainPage calls it
+// only once it has given up reconnecting the source.
 func (l *LogPage) SetStreamEnded(key string, err error) {
 	src, ok := l.sources[key]
 	if !ok {
@@ -387,7 +700,7 @@ func (l *LogPage) SetStreamEnded(key string, err error) {
 	p := styles.CatppuccinMocha()
 	banner := lipgloss.NewStyle().Foreground(p.Red).
 		Render(fmt.Sprintf("⚠ log stream ended for %s: %s", src.label(), src.streamErr))
-	l.appendTo(src, banner)
+	l.appendSynthetic(src, banner)
 }
 
 // refreshContent rebuilds rawLines from either the isolated source or a
@@ -397,32 +710,80 @@ func (l *LogPage) SetStreamEnded(key string, err error) {
 // then hands the result to applyContent to become the viewport's content.
 func (l *LogPage) refreshContent() {
 	p := styles.CatppuccinMocha()
+	all := l.visibleLines()
 
+	l.cursorLine, l.cursorSpan = -1, 0
 	var rendered []string
-	if l.isolatedIdx >= 0 && l.isolatedIdx < len(l.order) {
-		src := l.sources[l.order[l.isolatedIdx]]
-		rendered = make([]string, len(src.lines))
-		for i, ln := range src.lines {
-			rendered[i] = highlightJSONLine(ln.text, p)
-		}
-	} else {
-		var all []logLine
-		for _, key := range l.order {
-			src := l.sources[key]
-			prefix := lipgloss.NewStyle().Foreground(src.color).Bold(true).Render(src.label() + " |")
-			for _, ln := range src.lines {
-				all = append(all, logLine{seq: ln.seq, text: prefix + " " + highlightJSONLine(ln.text, p)})
+	var times []time.Time
+	if l.structured {
+		entries := make([]logfmt.Entry, len(all))
+		parsed := make([]bool, len(all))
+		widths := make([]int, len(l.fields))
+		cursorIdx := len(all) - 1
+		for i, ln := range all {
+			entries[i], parsed[i] = logfmt.Parse(ln.text)
+			if parsed[i] {
+				for f, name := range l.fields[:len(l.fields)-1] {
+					v, _ := entries[i].Lookup(name)
+					v = textwidth.Sanitize(strings.ReplaceAll(v, "\n", " "))
+					widths[f] = max(widths[f], min(textwidth.Width(v), maxStructuredColWidth))
+				}
+			}
+			if ln.seq == l.cursorSeq {
+				cursorIdx = i
 			}
 		}
-		sort.Slice(all, func(i, j int) bool { return all[i].seq < all[j].seq })
 
+		marker := lipgloss.NewStyle().Foreground(p.Mauve).Bold(true).Render("▸ ")
+		lo, hi := l.selectionRange(all, cursorIdx)
+		for i, ln := range all {
+			text := l.renderRaw(ln.logLine, p)
+			if parsed[i] {
+				text = l.renderColumns(entries[i], widths, p)
+			}
+			text = l.stamp(ln.logLine, p) + text + repeatBadge(ln, p)
+			if i != cursorIdx {
+				rendered = append(rendered, selectionGutter(i, lo, hi, p)+ln.prefix+text)
+				times = append(times, ln.time)
+				continue
+			}
+			l.cursorLine = len(rendered)
+			rendered = append(rendered, marker+ln.prefix+text)
+			if l.expanded {
+				rendered = append(rendered, l.repeatDetail(ln, p)...)
+				rendered = append(rendered, renderPayload(entries[i], parsed[i], p)...)
+			}
+			l.cursorSpan = len(rendered) - l.cursorLine
+			for range l.cursorSpan {
+				times = append(times, ln.time)
+			}
+		}
+	} else {
+		// Raw lines only get a gutter (and a cursor) while selecting.
+		cursorIdx, lo, hi := -1, -1, -1
+		if l.selecting {
+			cursorIdx = l.cursorIndex(all)
+			lo, hi = l.selectionRange(all, cursorIdx)
+			l.cursorLine, l.cursorSpan = cursorIdx, 1
+		}
+		marker := lipgloss.NewStyle().Foreground(p.Mauve).Bold(true).Render("▸ ")
 		rendered = make([]string, len(all))
+		times = make([]time.Time, len(all))
 		for i, ln := range all {
-			rendered[i] = ln.text
+			times[i] = ln.time
+			gutter := ""
+			switch {
+			case i == cursorIdx:
+				gutter = marker
+			case l.selecting:
+				gutter = selectionGutter(i, lo, hi, p)
+			}
+			rendered[i] = gutter + ln.prefix + l.stamp(ln.logLine, p) + l.renderRaw(ln.logLine, p) + repeatBadge(ln, p)
 		}
 	}
+	l.folded = folded(all)
 
-	l.rawLines = rendered
+	l.rawLines, l.lineTimes = rendered, times
 	l.maxLineWidth = 0
 	for _, s := range rendered {
 		if w := ansi.StringWidth(s); w > l.maxLineWidth {
@@ -432,6 +793,271 @@ func (l *LogPage) refreshContent() {
 	l.applyContent()
 }
 
+// cursorIndex is the cursor line's index in lines: the one with cursorSeq,
+// else the newest.
+func (l *LogPage) cursorIndex(lines []prefixedLine) int {
+	for i, ln := range lines {
+		if ln.seq == l.cursorSeq {
+			return i
+		}
+	}
+	return len(lines) - 1
+}
+
+// selectionRange returns the span of lines between the selection's anchor
+// and the cursor at cursorIdx, inclusive; -1, -1 when not selecting. An
+// anchor since dropped from the scrollback pins the span to the oldest
+// line.
+func (l *LogPage) selectionRange(lines []prefixedLine, cursorIdx int) (lo, hi int) {
+	if !l.selecting || cursorIdx < 0 {
+		return -1, -1
+	}
+	anchorIdx := 0
+	for i, ln := range lines {
+		if ln.seq == l.anchorSeq {
+			anchorIdx = i
+			break
+		}
+	}
+	return min(anchorIdx, cursorIdx), max(anchorIdx, cursorIdx)
+}
+
+// selectionGutter marks line i as selected when it falls within lo..hi.
+func selectionGutter(i, lo, hi int, p styles.Palette) string {
+	if i >= lo && i <= hi {
+		return lipgloss.NewStyle().Foreground(p.Mauve).Render("┃ ")
+	}
+	return "  "
+}
+
+// StartSelection starts a visual line selection at the cursor line in
+// structured mode, else at the bottom line on screen; the arrows then
+// extend it, and Yank copies it.
+func (l *LogPage) StartSelection() {
+	seqs := l.visibleSeqs()
+	if len(seqs) == 0 {
+		return
+	}
+	start := l.cursorSeq
+	if !l.structured {
+		start = seqs[len(seqs)-1]
+		if !l.wrap {
+			start = seqs[min(l.viewport.YOffset()+l.viewport.Height()-1, len(seqs)-1)]
+		}
+	}
+	if start == 0 {
+		start = seqs[len(seqs)-1]
+	}
+	l.selecting = true
+	l.anchorSeq, l.cursorSeq = start, start
+	l.refreshContent()
+	l.ensureCursorVisible()
+}
+
+// Selecting reports whether a visual line selection is in progress.
+func (l *LogPage) Selecting() bool {
+	return l.selecting
+}
+
+// CancelSelection drops the selection without copying it.
+func (l *LogPage) CancelSelection() {
+	l.selecting = false
+	if !l.structured {
+		l.cursorSeq = 0
+	}
+	l.refreshContent()
+}
+
+// Yank returns the selected lines as plain text — each behind its source
+// label in a merged view, and its timestamp when those are shown — and
+// ends the selection. Without one, structured mode yanks the cursor line;
+// ok is false when there's nothing to yank.
+func (l *LogPage) Yank() (text string, lines int, ok bool) {
+	all := l.visibleLines()
+	if len(all) == 0 || !l.selecting && !l.structured {
+		return "", 0, false
+	}
+	cursorIdx := l.cursorIndex(all)
+	lo, hi := cursorIdx, cursorIdx
+	if l.selecting {
+		lo, hi = l.selectionRange(all, cursorIdx)
+	}
+
+	p := styles.CatppuccinMocha()
+	out := make([]string, 0, hi-lo+1)
+	for _, ln := range all[lo : hi+1] {
+		out = append(out, ansi.Strip(ln.prefix+l.stamp(ln.logLine, p)+ln.text[l.repeatedStamp(ln.logLine):]+repeatBadge(ln, p)))
+	}
+	if l.selecting {
+		l.CancelSelection()
+	}
+	return strings.Join(out, "\n"), len(out), true
+}
+
+// TimeSpan is the time the selected lines (or, in structured mode, the
+// cursor line) span — every shown line's without either — for querying
+// the same window elsewhere. It leaves the selection in place; ok is false
+// when none of those lines carries a timestamp.
+func (l *LogPage) TimeSpan() (from, to time.Time, ok bool) {
+	all := l.visibleLines()
+	lo, hi := 0, len(all)-1
+	if len(all) > 0 && (l.selecting || l.structured) {
+		cursorIdx := l.cursorIndex(all)
+		lo, hi = cursorIdx, cursorIdx
+		if l.selecting {
+			lo, hi = l.selectionRange(all, cursorIdx)
+		}
+	}
+	for _, ln := range all[max(lo, 0) : hi+1] {
+		if ln.time.IsZero() {
+			continue
+		}
+		if from.IsZero() || ln.time.Before(from) {
+			from = ln.time
+		}
+		if ln.time.After(to) {
+			to = ln.time
+		}
+	}
+	return from, to, !from.IsZero()
+}
+
+// prefixedLine pairs a buffered line with the source label it renders
+// behind (none while isolated) — kept apart from the text until the end so
+// structured mode can parse the line itself.
+type prefixedLine struct {
+	logLine
+	prefix string
+	// repeats is how many identical lines this one stands for while
+	// collapsed, 0 for just itself; first is when the first of them came.
+	repeats int
+	first   time.Time
+}
+
+// visibleLines returns what the viewport shows: the isolated source's lines
+// or the chronological merge of every source, minus lines below minLevel
+// or without the search text.
+func (l *LogPage) visibleLines() []prefixedLine {
+	var all []prefixedLine
+	keep := func(ln logLine) bool {
+		if l.paused && ln.seq > l.pausedSeq {
+			return false
+		}
+		if ln.synthetic {
+			return true
+		}
+		return ln.level >= l.minLevel && (l.searchFold == "" || strings.Contains(strings.ToLower(ln.text), l.searchFold))
+	}
+	if l.isolatedIdx >= 0 && l.isolatedIdx < len(l.order) {
+		for _, ln := range l.sources[l.order[l.isolatedIdx]].lines {
+			if keep(ln) {
+				all = append(all, prefixedLine{logLine: ln})
+			}
+		}
+		return l.collapsed(all)
+	}
+	for _, key := range l.order {
+		src := l.sources[key]
+		prefix := SourcePrefix(src.label(), src.color)
+		for _, ln := range src.lines {
+			if keep(ln) {
+				all = append(all, prefixedLine{logLine: ln, prefix: prefix})
+			}
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].seq < all[j].seq })
+	return l.collapsed(all)
+}
+
+// collapsed is lines with runs of repeats folded, while collapsing is on.
+func (l *LogPage) collapsed(lines []prefixedLine) []prefixedLine {
+	if !l.collapse {
+		return lines
+	}
+	return collapseRepeats(lines)
+}
+
+// levelColor is a level's highlight color; ok is false for LevelUnknown.
+func levelColor(level logfmt.Level, p styles.Palette) (c color.Color, ok bool) {
+	switch level {
+	case logfmt.LevelError:
+		return p.Red, true
+	case logfmt.LevelWarn:
+		return p.Yellow, true
+	case logfmt.LevelInfo:
+		return p.Blue, true
+	case logfmt.LevelDebug:
+		return p.Overlay1, true
+	}
+	return nil, false
+}
+
+// renderRaw is a line's unstructured rendering: JSON-highlighted, with its
+// level token colored when colorLevels is on. The token is only colored when
+// it sits outside any embedded JSON (a JSON payload keeps its syntax colors
+// — the structured view colors its level column instead).
+func (l *LogPage) renderRaw(ln logLine, p styles.Palette) string {
+	text, start, end := ln.text, ln.levelStart, ln.levelEnd
+	if n := l.repeatedStamp(ln); n > 0 {
+		text, start, end = text[n:], start-n, end-n
+		if start < 0 {
+			start, end = -1, -1
+		}
+	}
+	return renderRawText(text, ln.level, start, end, l.colorLevels, p)
+}
+
+// renderRawText is renderRaw for a line's text and detected level span.
+func renderRawText(text string, level logfmt.Level, levelStart, levelEnd int, colorLevels bool, p styles.Palette) string {
+	c, ok := levelColor(level, p)
+	if !colorLevels || !ok || levelStart < 0 || levelEnd > len(text) {
+		return highlightJSONLine(text, p)
+	}
+	if j := strings.IndexAny(text, "{["); j >= 0 && j < levelEnd {
+		return highlightJSONLine(text, p)
+	}
+	token := lipgloss.NewStyle().Foreground(c).Bold(true).Render(text[levelStart:levelEnd])
+	return text[:levelStart] + token + highlightJSONLine(text[levelEnd:], p)
+}
+
+// renderColumns lays a parsed line out as the configured fields, each but
+// the last padded to its column's width across the rendered lines. Missing
+// fields show as "-" so the columns stay aligned.
+func (l *LogPage) renderColumns(e logfmt.Entry, widths []int, p styles.Palette) string {
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	cols := make([]string, len(l.fields))
+	for i, name := range l.fields {
+		v, ok := e.Lookup(name)
+		v = textwidth.Sanitize(strings.ReplaceAll(v, "\n", " "))
+		if !ok || v == "" {
+			v = dim.Render("-")
+		}
+		if i < len(l.fields)-1 {
+			v = textwidth.PadRight(ansi.Truncate(v, widths[i], "…"), widths[i])
+		}
+		if c, ok := levelColor(logfmt.ParseLevel(strings.TrimSpace(v)), p); ok && l.colorLevels && strings.EqualFold(name, "level") {
+			v = lipgloss.NewStyle().Foreground(c).Bold(true).Render(v)
+		}
+		cols[i] = v
+	}
+	return strings.Join(cols, "  ")
+}
+
+// renderPayload is the expansion shown under the cursor line: every field
+// of the parsed entry, one per row, in the order the line carried them.
+func renderPayload(e logfmt.Entry, parsed bool, p styles.Palette) []string {
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	if !parsed {
+		return []string{dim.Render("      (not a structured line)")}
+	}
+	keyStyle := lipgloss.NewStyle().Foreground(p.Blue)
+	lines := []string{dim.Render(fmt.Sprintf("      ┌ %s, %d field(s)", e.Format, len(e.Fields)))}
+	for _, f := range e.Fields {
+		lines = append(lines, dim.Render("      │ ")+keyStyle.Render(f.Key)+dim.Render(": ")+textwidth.Sanitize(f.Value))
+	}
+	return lines
+}
+
 // applyContent renders rawLines into the viewport for the current wrap
 // state. Both paths reflow/crop the already-colored line text rather than
 // re-deriving colors, so the per-source-prefix and JSON-highlighting colors
@@ -442,28 +1068,322 @@ func (l *LogPage) refreshContent() {
 // sequences and only ever splitting on grapheme boundaries.
 func (l *LogPage) applyContent() {
 	if !l.wrap || l.viewport.Width() < 1 {
+		l.cursorRow, l.cursorRows = l.cursorLine, l.cursorSpan
+		l.lineRows = nil
 		l.viewport.SetContent(strings.Join(l.rawLines, "\n"))
 		return
 	}
 
 	wrapped := make([]string, len(l.rawLines))
+	l.lineRows = make([]int, len(l.rawLines))
+	row := 0
 	for i, s := range l.rawLines {
 		wrapped[i] = ansi.Wrap(s, l.viewport.Width(), "")
+		l.lineRows[i] = row
+		if i == l.cursorLine {
+			l.cursorRow, l.cursorRows = row, 0
+		}
+		h := strings.Count(wrapped[i], "\n") + 1
+		if l.cursorLine >= 0 && i >= l.cursorLine && i < l.cursorLine+l.cursorSpan {
+			l.cursorRows += h
+		}
+		row += h
 	}
 	l.viewport.SetContent(strings.Join(wrapped, "\n"))
 }
 
-// ToggleWrap flips soft-wrap on/off. Wrap and horizontal scroll are
+// SetTimestamps sets whether lines show their timestamps.
+func (l *LogPage) SetTimestamps(on bool) {
+	l.timestamps = on
+	l.refreshContent()
+}
+
+// ToggleTimestamps flips whether lines show their timestamps.
+func (l *LogPage) ToggleTimestamps() {
+	l.SetTimestamps(!l.timestamps)
+}
+
+// SetStampFormat sets how timestamps are rendered.
+func (l *LogPage) SetStampFormat(f StampFormat) {
+	l.stampFormat = f
+	l.refreshContent()
+}
+
+// StampFormat returns how timestamps are rendered.
+func (l *LogPage) StampFormat() StampFormat {
+	return l.stampFormat
+}
+
+// RefreshStamps re-renders relative timestamps, which age while no line
+// arrives to redraw them.
+func (l *LogPage) RefreshStamps() {
+	if l.timestamps && l.stampFormat == StampRelative {
+		l.refreshContent()
+	}
+}
+
+// SetLocation sets the time zone timestamps are shown in; nil goes back to
+// the local one.
+func (l *LogPage) SetLocation(loc *time.Location) {
+	l.location = loc
+	l.refreshContent()
+}
+
+// Location returns the time zone timestamps are shown in.
+func (l *LogPage) Location() *time.Location {
+	if l.location == nil {
+		return time.Local
+	}
+	return l.location
+}
+
+// SetMode sets the header's label for how the streams were opened, e.g.
+// "previous" or "since 1h"; "" clears it.
+func (l *LogPage) SetMode(mode string) {
+	l.mode = mode
+}
+
+// SetFields sets the columns the structured view shows, in order. An empty
+// list falls back to logfmt.DefaultFields.
+func (l *LogPage) SetFields(fields []string) {
+	if len(fields) == 0 {
+		fields = logfmt.DefaultFields
+	}
+	l.fields = fields
+	if l.structured {
+		l.refreshContent()
+	}
+}
+
+// ToggleStructured flips between raw lines and structured columns. Entering
+// structured mode starts the cursor on the newest line, tracking the tail.
+func (l *LogPage) ToggleStructured() {
+	l.structured = !l.structured
+	l.cursorSeq = 0
+	l.expanded = false
+	l.selecting = false
+	l.refreshContent()
+	if l.structured {
+		l.viewport.GotoBottom()
+	}
+}
+
+// SetColorCodeLevels turns level highlighting on or off.
+func (l *LogPage) SetColorCodeLevels(on bool) {
+	l.colorLevels = on
+	l.refreshContent()
+}
+
+// CycleMinLevel steps the level filter all -> debug -> info -> warn ->
+// error -> all. Only the view changes: every source keeps buffering every
+// line, so relaxing the filter shows no gaps.
+func (l *LogPage) CycleMinLevel() {
+	l.minLevel++
+	if l.minLevel > logfmt.LevelError {
+		l.minLevel = logfmt.LevelUnknown
+	}
+	l.refreshContent()
+}
+
+// SetMinLevel sets the level filter directly, as ":filter level>=warn"
+// does; LevelUnknown clears it.
+func (l *LogPage) SetMinLevel(level logfmt.Level) {
+	l.minLevel = level
+	l.refreshContent()
+}
+
+// MinLevel returns the level filter; LevelUnknown means no filter.
+func (l *LogPage) MinLevel() logfmt.Level {
+	return l.minLevel
+}
+
+// SetSearch shows only lines containing text, in any case, alongside the
+// level filter; "" clears it.
+func (l *LogPage) SetSearch(text string) {
+	l.search, l.searchFold = text, strings.ToLower(text)
+	l.refreshContent()
+}
+
+// Search returns the search text; "" means none.
+func (l *LogPage) Search() string {
+	return l.search
+}
+
+// Structured reports whether the structured column view is active.
+func (l *LogPage) Structured() bool {
+	return l.structured
+}
+
+// ToggleExpand unfolds (or folds) the full payload of the cursor line. The
+// expansion follows the cursor as it moves, so payloads can be browsed line
+// by line. A no-op outside structured mode.
+func (l *LogPage) ToggleExpand() {
+	if !l.structured {
+		return
+	}
+	l.expanded = !l.expanded
+	l.refreshContent()
+	l.ensureCursorVisible()
+}
+
+// moveCursor shifts the structured-view cursor by delta lines through the
+// currently rendered (merged or isolated) lines. Landing on the newest line
+// resumes tracking the tail.
+func (l *LogPage) moveCursor(delta int) {
+	seqs := l.visibleSeqs()
+	if len(seqs) == 0 {
+		return
+	}
+	idx := len(seqs) - 1
+	for i, seq := range seqs {
+		if seq == l.cursorSeq {
+			idx = i
+			break
+		}
+	}
+	idx = max(0, min(len(seqs)-1, idx+delta))
+	l.cursorSeq = seqs[idx]
+	if idx == len(seqs)-1 {
+		l.cursorSeq = 0
+	}
+	l.refreshContent()
+	l.ensureCursorVisible()
+}
+
+// visibleSeqs returns the arrival seqs of the lines currently rendered, in
+// display order.
+func (l *LogPage) visibleSeqs() []int64 {
+	lines := l.visibleLines()
+	seqs := make([]int64, len(lines))
+	for i, ln := range lines {
+		seqs[i] = ln.seq
+	}
+	return seqs
+}
+
+// ensureCursorVisible scrolls just enough to bring the cursor line and its
+// expansion into view, favoring the cursor line when both don't fit.
+func (l *LogPage) ensureCursorVisible() {
+	if l.cursorLine < 0 {
+		return
+	}
+	h := l.viewport.Height()
+	top := l.viewport.YOffset()
+	if end := l.cursorRow + l.cursorRows; end > top+h {
+		top = end - h
+	}
+	if l.cursorRow < top {
+		top = l.cursorRow
+	}
+	l.viewport.SetYOffset(top)
+}
+
+// YOffset is how many rows the view is scrolled down from the top.
+func (l *LogPage) YOffset() int {
+	return l.viewport.YOffset()
+}
+
+// SetYOffset scrolls the view to n rows from the top, clamped to the
+// content.
+func (l *LogPage) SetYOffset(n int) {
+	l.viewport.SetYOffset(n)
+}
+
+// TopTime is the timestamp of the first timestamped line from the top of
+// the view down; ok is false when none on screen or below carries one.
+func (l *LogPage) TopTime() (t time.Time, ok bool) {
+	line := l.viewport.YOffset()
+	if l.lineRows != nil {
+		line = sort.Search(len(l.lineRows), func(i int) bool { return l.lineRows[i] > line }) - 1
+	}
+	for i := max(line, 0); i < len(l.lineTimes); i++ {
+		if !l.lineTimes[i].IsZero() {
+			return l.lineTimes[i], true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ScrollToTime scrolls the view so its top line is the first one
+// timestamped at or after t — to the bottom if none is. Lines without a
+// timestamp are passed over.
+func (l *LogPage) ScrollToTime(t time.Time) {
+	line := len(l.lineTimes)
+	for i, ts := range l.lineTimes {
+		if !ts.IsZero() && !ts.Before(t) {
+			line = i
+			break
+		}
+	}
+	if line == len(l.lineTimes) {
+		l.viewport.GotoBottom()
+		return
+	}
+	if l.lineRows != nil {
+		line = l.lineRows[line]
+	}
+	l.viewport.SetYOffset(line)
+}
+
+// TogglePause freezes the view where it is, or resumes it: the lines
+// buffered meanwhile appear at once, and a view that was at the bottom
+// (and following) follows on from them.
+func (l *LogPage) TogglePause() {
+	if !l.paused {
+		l.paused, l.pausedSeq = true, l.nextSeq
+		return
+	}
+	wasAtBottom := l.viewport.AtBottom()
+	l.paused = false
+	l.refreshContent()
+	if wasAtBottom && l.follow {
+		l.viewport.GotoBottom()
+	}
+}
+
+// Paused reports whether the view is frozen, and how many buffered lines
+// it's holding back.
+func (l *LogPage) Paused() (held int, paused bool) {
+	if !l.paused {
+		return 0, false
+	}
+	for _, src := range l.sources {
+		for i := len(src.lines) - 1; i >= 0 && src.lines[i].seq > l.pausedSeq; i-- {
+			held++
+		}
+	}
+	return held, true
+}
+
+// pausedBadge is the PAUSED badge heading a frozen pane, "" otherwise.
+func (l *LogPage) pausedBadge() string {
+	held, paused := l.Paused()
+	if !paused {
+		return ""
+	}
+	badge := lipgloss.NewStyle().Background(l.theme.Palette.Yellow).Foreground(l.theme.Palette.Base).Bold(true).Padding(0, 1)
+	return badge.Render(fmt.Sprintf("⏸ PAUSED · %d new", held)) + " "
+}
+
+// SetWrap turns soft-wrap on or off. Wrap and horizontal scroll are
 // mutually exclusive, so turning wrap on resets the scroll position back to
 // the left edge — wrapped lines reflow to fit, leaving nothing to scroll to.
-func (l *LogPage) ToggleWrap() {
-	l.wrap = !l.wrap
+func (l *LogPage) SetWrap(on bool) {
+	if on == l.wrap {
+		return
+	}
+	l.wrap = on
 	if l.wrap {
 		l.viewport.SetXOffset(0)
 	}
 	l.applyContent()
 }
 
+// ToggleWrap flips soft-wrap on/off.
+func (l *LogPage) ToggleWrap() {
+	l.SetWrap(!l.wrap)
+}
+
 // Wrap reports whether soft-wrap is currently enabled.
 func (l *LogPage) Wrap() bool {
 	return l.wrap
@@ -483,10 +1403,20 @@ func (l *LogPage) ScrollStatus() (percent int, ok bool) {
 // Header renders a one-line banner summarizing the merged sources (or the
 // isolated one) and the pane's key hints.
 func (l *LogPage) Header(width int) string {
-	p := styles.CatppuccinMocha()
-	title := lipgloss.NewStyle().Foreground(p.Peach).Bold(true)
-	hint := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true)
+	title := l.theme.Peach.Bold(true)
+	hint := l.theme.Hint
 
+	full := l.pausedBadge() + title.Render(fmt.Sprintf("▾ %s", l.Label())) + "  " +
+		hint.Render("(P: pause, c: isolate/merge, w: wrap, s: structured, x: expand, L: min level, /: search, t: timestamps, Z: time zone, D: time format, u: collapse repeats, p: previous, T: since, a: hold restarts, F: follow, ↑/↓ pgup/pgdn scroll, ←/→ ⇧←/⇧→: pan, End: jump+follow, Esc back)")
+	if width <= 0 {
+		return full
+	}
+	return ansi.Truncate(full, width, "…")
+}
+
+// Label summarizes the pane for its header: the merged sources or the
+// isolated one, then each view setting that's on.
+func (l *LogPage) Label() string {
 	label := "Logs"
 	switch {
 	case l.IsolatedLabel() != "":
@@ -497,34 +1427,64 @@ func (l *LogPage) Header(width int) string {
 	if l.wrap {
 		label += "  [wrap]"
 	}
-
-	full := title.Render(fmt.Sprintf("▾ %s", label)) + "  " +
-		hint.Render("(c: isolate/merge, w: wrap, ↑/↓ pgup/pgdn scroll, ⇧←/⇧→: pan, End: jump+follow, Esc back)")
-	if width <= 0 {
-		return full
+	if l.structured {
+		label += "  [structured]"
 	}
-	return ansi.Truncate(full, width, "…")
+	if l.minLevel != logfmt.LevelUnknown {
+		label += fmt.Sprintf("  [≥%s]", l.minLevel)
+	}
+	if l.search != "" {
+		label += fmt.Sprintf("  [/%s]", l.search)
+	}
+	if l.mode != "" {
+		label += fmt.Sprintf("  [%s]", l.mode)
+	}
+	if l.collapse {
+		label += fmt.Sprintf("  [collapsed: %d folded]", l.folded)
+	}
+	switch {
+	case l.stampFormat != StampZoned:
+		label += fmt.Sprintf("  [%s]", l.stampFormat)
+	case l.location != nil:
+		label += fmt.Sprintf("  [TZ %s]", l.location)
+	}
+	if l.selecting {
+		label += "  [select: ↑/↓ extend, y copy, esc cancel]"
+	}
+	return label
 }
 
 func (l *LogPage) Update(msg tea.Msg) tea.Cmd {
-	if key, ok := msg.(tea.KeyPressMsg); ok {
-		switch key.String() {
-		case "home", "g":
-			l.viewport.GotoTop()
-			return nil
-		case "end", "G":
+	if press, ok := msg.(tea.KeyPressMsg); ok {
+		km := l.keyMap()
+		// In structured mode, or while selecting, the arrows move the line
+		// cursor instead of scrolling; the viewport follows the cursor.
+		if l.structured || l.selecting {
+			switch {
+			case key.Matches(press, km.Up), keys.Index(press, km.Scroll) == scrollUp:
+				l.moveCursor(-1)
+				return nil
+			case key.Matches(press, km.Down), keys.Index(press, km.Scroll) == scrollDown:
+				l.moveCursor(1)
+				return nil
+			}
+		}
+		switch {
+		case key.Matches(press, km.Bottom):
+			l.follow = true
 			l.viewport.GotoBottom()
 			return nil
-		case "shift+left":
-			if !l.wrap {
+		case key.Matches(press, km.Pan):
+			switch {
+			case l.wrap:
+			case keys.Index(press, km.Pan) == 0:
 				l.viewport.ScrollLeft(halfViewportStep(l.viewport.Width()))
-			}
-			return nil
-		case "shift+right":
-			if !l.wrap {
+			default:
 				l.viewport.ScrollRight(halfViewportStep(l.viewport.Width()))
 			}
 			return nil
+		case scrollViewport(&l.viewport, km, press):
+			return nil
 		}
 	}
 
@@ -559,9 +1519,8 @@ func (l *LogPage) SetFocused(f bool) {
 }
 
 func (l *LogPage) View() string {
-	p := styles.CatppuccinMocha()
 	if !l.HasContent() {
-		return lipgloss.NewStyle().Foreground(p.Overlay1).Render("No logs loaded")
+		return l.theme.Overlay1.Render("No logs loaded")
 	}
 	return l.viewport.View()
 }
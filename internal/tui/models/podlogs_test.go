@@ -1,11 +1,14 @@
 package models
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/logfmt"
 )
 
 func newTestLogPage(w, h int) *LogPage {
@@ -115,3 +118,476 @@ func TestLogPage_HorizontalScrollPreservesColorOfPrefixAndJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestLogPage_StructuredColumnsAndExpand(t *testing.T) {
+	l := newTestLogPage(120, 20)
+	l.SetFields([]string{"level", "msg"})
+	l.AppendLine("k", `{"level":"info","msg":"first","user":"ann"}`)
+	l.AppendLine("k", `level=warn msg=second`)
+
+	l.ToggleStructured()
+	if !l.Structured() {
+		t.Fatal("ToggleStructured should have enabled structured mode")
+	}
+	view := ansi.Strip(l.View())
+	if !strings.Contains(view, "info  first") || !strings.Contains(view, "warn  second") {
+		t.Fatalf("expected aligned level/msg columns, got:\n%s", view)
+	}
+	if strings.Contains(view, `"user"`) {
+		t.Fatalf("unselected fields must not render as columns, got:\n%s", view)
+	}
+
+	l.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	l.ToggleExpand()
+	view = ansi.Strip(l.View())
+	if !strings.Contains(view, "│ user: ann") {
+		t.Fatalf("expanding the cursor line should list its full payload, got:\n%s", view)
+	}
+	if strings.Contains(view, "│ msg: second") {
+		t.Fatalf("only the cursor line should be expanded, got:\n%s", view)
+	}
+}
+
+func TestLogPage_MinLevelFilterKeepsContinuationsAndNewLines(t *testing.T) {
+	l := newTestLogPage(120, 20)
+	l.AppendLine("k", "INFO starting")
+	l.AppendLine("k", "ERROR boom")
+	l.AppendLine("k", "    at main.go:12")
+
+	for l.MinLevel() != logfmt.LevelError {
+		l.CycleMinLevel()
+	}
+	l.AppendLine("k", "DEBUG later")
+	l.AppendLine("k", "ERROR again")
+
+	view := ansi.Strip(l.View())
+	for _, want := range []string{"ERROR boom", "at main.go:12", "ERROR again", "Connecting to"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q to survive the error filter, got:\n%s", want, view)
+		}
+	}
+	for _, hidden := range []string{"INFO starting", "DEBUG later"} {
+		if strings.Contains(view, hidden) {
+			t.Fatalf("expected %q to be filtered out, got:\n%s", hidden, view)
+		}
+	}
+
+	l.CycleMinLevel()
+	if view := ansi.Strip(l.View()); !strings.Contains(view, "INFO starting") {
+		t.Fatalf("clearing the filter should restore buffered lines, got:\n%s", view)
+	}
+}
+
+func TestLogPage_MaxLinesAndFollowByDefault(t *testing.T) {
+	l := NewLogPage()
+	l.SetMaxLines(100)
+	l.SetFollowByDefault(false)
+	l.SetSize(40, 5)
+	l.AddSource("k", "pod-a", "ns", "ctx", "app")
+	for i := range 150 {
+		l.AppendLine("k", fmt.Sprintf("line %d", i))
+	}
+
+	if got := len(l.sources["k"].lines); got != 100 {
+		t.Fatalf("expected scrollback capped at 100 lines, got %d", got)
+	}
+	if l.viewport.AtBottom() {
+		t.Fatal("with follow off by default, new lines must not scroll the view")
+	}
+
+	l.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	l.AppendLine("k", "after end")
+	if !l.viewport.AtBottom() {
+		t.Fatal("End should turn following on")
+	}
+}
+
+func TestLogPage_SelectAndYankLines(t *testing.T) {
+	l := newTestLogPage(80, 10)
+	for _, line := range []string{"one", "two", "three"} {
+		l.AppendLine("k", line)
+	}
+
+	if _, _, ok := l.Yank(); ok {
+		t.Fatal("expected nothing to yank before selecting in the raw view")
+	}
+
+	l.StartSelection()
+	l.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if !strings.Contains(l.View(), "┃") {
+		t.Error("expected the selection gutter in the view")
+	}
+
+	text, n, ok := l.Yank()
+	want := "pod-a/app | two\npod-a/app | three"
+	if !ok || n != 2 || text != want {
+		t.Fatalf("got %q (%d line(s), ok=%v), want %q", text, n, ok, want)
+	}
+	if l.Selecting() {
+		t.Error("expected Yank to end the selection")
+	}
+}
+
+func TestLogPage_ErrorLinesCountsEntriesNotContinuations(t *testing.T) {
+	l := newTestLogPage(80, 10)
+	l.AppendLine("k", "INFO starting")
+	for i := range 7 {
+		l.AppendLine("k", fmt.Sprintf("ERROR failure %d", i))
+		l.AppendLine("k", "    at main.go:12")
+	}
+
+	count, last := l.ErrorLines("k", 5)
+	if count != 7 {
+		t.Errorf("expected 7 error lines, got %d", count)
+	}
+	if len(last) != 5 || last[0] != "ERROR failure 2" || last[4] != "ERROR failure 6" {
+		t.Errorf("expected the last five errors, got %q", last)
+	}
+
+	l.AddSummary("k", "pod-a completed: Failed", []string{"errors: 7 line(s)"})
+	if view := ansi.Strip(l.View()); !strings.Contains(view, "── pod-a completed: Failed ──") || !strings.Contains(view, "errors: 7 line(s)") {
+		t.Errorf("expected the summary block in the view, got:\n%s", view)
+	}
+}
+
+func TestLogPage_TimestampsInChosenZone(t *testing.T) {
+	l := newTestLogPage(80, 5)
+	l.SetTimestamps(true)
+	l.AppendLineAt("k", "hello", time.Date(2026, 7, 19, 12, 30, 0, 0, time.UTC))
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	l.SetLocation(tokyo)
+	if got := ansi.Strip(l.View()); !strings.Contains(got, "21:30:00.000") {
+		t.Fatalf("expected the stamp in JST, got %q", got)
+	}
+	if got := ansi.Strip(l.Header(0)); !strings.Contains(got, "[TZ JST]") {
+		t.Errorf("expected the zone in the header, got %q", got)
+	}
+
+	l.SetLocation(time.UTC)
+	if got := ansi.Strip(l.View()); !strings.Contains(got, "12:30:00.000") {
+		t.Fatalf("expected the stamp in UTC, got %q", got)
+	}
+}
+
+func TestLogPage_StampFormatsAndRepeatedAppStamps(t *testing.T) {
+	l := newTestLogPage(100, 5)
+	at := time.Date(2026, 7, 19, 12, 30, 0, 0, time.UTC)
+	l.now = func() time.Time { return at.Add(90 * time.Second) }
+	l.SetLocation(time.FixedZone("JST", 9*60*60))
+	l.SetTimestamps(true)
+	l.AppendLineAt("k", "2026-07-19T12:30:00.123456Z INFO started", at)
+	l.AppendLineAt("k", "[2026-07-19 12:30:00,123] WARN slow", at)
+
+	view := ansi.Strip(l.View())
+	if !strings.Contains(view, "21:30:00.000 INFO started") || !strings.Contains(view, "21:30:00.000 WARN slow") {
+		t.Fatalf("expected the app's own stamps dropped behind the column, got:\n%s", view)
+	}
+
+	l.SetStampFormat(StampUTC)
+	if got := ansi.Strip(l.View()); !strings.Contains(got, "12:30:00.000Z INFO") {
+		t.Errorf("expected the stamp in UTC, got:\n%s", got)
+	}
+	if got := ansi.Strip(l.Header(0)); !strings.Contains(got, "[UTC]") {
+		t.Errorf("expected the format in the header, got %q", got)
+	}
+	l.SetStampFormat(StampRelative)
+	if got := ansi.Strip(l.View()); !strings.Contains(got, " 1m ago INFO") {
+		t.Errorf("expected a relative stamp, got:\n%s", got)
+	}
+
+	// Without the column, the line is shown as the app wrote it.
+	l.SetTimestamps(false)
+	if got := ansi.Strip(l.View()); !strings.Contains(got, "2026-07-19T12:30:00.123456Z INFO started") {
+		t.Errorf("expected the app's stamp back with timestamps off, got:\n%s", got)
+	}
+}
+
+func TestLogPage_CollapseRepeatsPerSource(t *testing.T) {
+	l := newTestLogPage(100, 10)
+	l.AddSource("j", "pod-b", "ns", "ctx", "app")
+	for range 3 {
+		l.AppendLine("k", "ERROR dial tcp: connection refused")
+		l.AppendLine("j", "INFO tick")
+	}
+	l.AppendLine("k", "INFO recovered")
+
+	l.SetCollapseRepeats(true)
+	view := ansi.Strip(l.View())
+	if got := strings.Count(view, "connection refused"); got != 1 || !strings.Contains(view, "connection refused ×3") {
+		t.Fatalf("expected one refused line marked ×3 despite pod-b's lines between, got:\n%s", view)
+	}
+	if !strings.Contains(view, "tick ×3") || !strings.Contains(view, "recovered") {
+		t.Errorf("expected pod-b's run folded and the last line kept, got:\n%s", view)
+	}
+	if got := ansi.Strip(l.Header(0)); !strings.Contains(got, "[collapsed: 4 folded]") {
+		t.Errorf("expected the folded count in the header, got %q", got)
+	}
+
+	l.ToggleCollapseRepeats()
+	if got := strings.Count(ansi.Strip(l.View()), "connection refused"); got != 3 {
+		t.Errorf("expected every line back uncollapsed, got %d", got)
+	}
+}
+
+func TestLogPage_TimeSpanOfSelectionElseEverything(t *testing.T) {
+	l := newTestLogPage(100, 10)
+	at := time.Date(2026, 7, 19, 12, 0, 0, 0, time.UTC)
+	l.AppendLine("k", "no stamp")
+	for i := range 3 {
+		l.AppendLineAt("k", fmt.Sprintf("line %d", i), at.Add(time.Duration(i)*time.Minute))
+	}
+
+	from, to, ok := l.TimeSpan()
+	if !ok || !from.Equal(at) || !to.Equal(at.Add(2*time.Minute)) {
+		t.Errorf("whole span = %v–%v (ok=%v), want 12:00–12:02", from, to, ok)
+	}
+
+	l.StartSelection()
+	l.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	from, to, ok = l.TimeSpan()
+	if !ok || !from.Equal(at.Add(time.Minute)) || !to.Equal(at.Add(2*time.Minute)) {
+		t.Errorf("selected span = %v–%v (ok=%v), want 12:01–12:02", from, to, ok)
+	}
+	if !l.Selecting() {
+		t.Error("expected TimeSpan to leave the selection in place")
+	}
+}
+
+func TestLeadingTimestamp(t *testing.T) {
+	for text, want := range map[string]int{
+		"2026-07-19T12:30:00Z msg":        len("2026-07-19T12:30:00Z "),
+		"2026-07-19T12:30:00.5+02:00 msg": len("2026-07-19T12:30:00.5+02:00 "),
+		"[2026-07-19 12:30:00] msg":       len("[2026-07-19 12:30:00] "),
+		"2026-07-19 msg":                  0,
+		"2026-07-19T12:30:00Zmsg":         0,
+		"[2026-07-19T12:30:00Z msg":       0,
+		"level=info msg=ok":               0,
+	} {
+		if got := leadingTimestamp(text); got != want {
+			t.Errorf("leadingTimestamp(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestLogPage_TabsAndCarriageReturnsKeepTheBorder(t *testing.T) {
+	l := newTestLogPage(40, 5)
+	l.AppendLine("k", "key:\tvalue\r")
+	l.AppendLine("k", "日本語\tcolumns\x1b[2K")
+
+	for _, line := range strings.Split(l.View(), "\n") {
+		if strings.ContainsAny(line, "\t\r") || strings.Contains(line, "\x1b[2K") {
+			t.Fatalf("control characters reached the view: %q", line)
+		}
+		if w := ansi.StringWidth(line); w > 40 {
+			t.Errorf("line is %d cells wide, want at most 40: %q", w, ansi.Strip(line))
+		}
+	}
+	if got := ansi.Strip(l.View()); !strings.Contains(got, "key:    value") {
+		t.Errorf("expected the tab expanded to its stop, got %q", got)
+	}
+}
+
+func TestLogPanes_SourcesLiveInOnePaneAndCloseWithIt(t *testing.T) {
+	p := NewLogPanes()
+	p.SetSize(160, 40)
+	p.AddSource("a", "pod-a", "ns", "ctx", "app")
+	if !p.Add() {
+		t.Fatal("a second pane should be added")
+	}
+	p.AddSource("b", "pod-b", "ns", "ctx", "app")
+	if moved := p.AddSource("a", "pod-a", "ns", "ctx", "app"); !moved {
+		t.Fatal("tailing a into the second pane should move it out of the first")
+	}
+	if got := p.Active().Keys(); len(got) != 2 {
+		t.Fatalf("active pane keys = %v, want a and b", got)
+	}
+
+	p.Next()
+	if got := p.Active().Keys(); len(got) != 0 {
+		t.Fatalf("first pane keys = %v, want none after the move", got)
+	}
+	p.Next()
+	p.AppendLineAt("a", "hello", time.Time{})
+	if !strings.Contains(ansi.Strip(p.View()), "hello") {
+		t.Fatal("a line for a should render in the pane holding it")
+	}
+
+	keys, ok := p.CloseActive()
+	if !ok || len(keys) != 2 {
+		t.Fatalf("CloseActive = %v, %v; want the closed pane's two keys", keys, ok)
+	}
+	if p.Len() != 1 || p.HasSource("a") {
+		t.Fatal("closing a pane should drop it and its sources")
+	}
+	for range MaxLogPanes - 1 {
+		p.Add()
+	}
+	if p.Add() {
+		t.Fatalf("more than %d panes should be refused", MaxLogPanes)
+	}
+	if _, ok := NewLogPanes().CloseActive(); ok {
+		t.Fatal("the only pane can't be closed on its own")
+	}
+}
+
+func TestLogPanes_SyncScrollFollowsTimeOrRows(t *testing.T) {
+	base := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	p := NewLogPanes()
+	p.SetSize(160, 12)
+	p.AddSource("a", "pod-a", "ns", "eu", "app")
+	p.Add()
+	p.AddSource("b", "pod-b", "ns", "us", "app")
+	// b logs every second, a every other: the same moment sits on different rows.
+	for i := range 60 {
+		p.AppendLineAt("b", fmt.Sprintf("b %d", i), base.Add(time.Duration(i)*time.Second))
+		if i%2 == 0 {
+			p.AppendLineAt("a", fmt.Sprintf("a %d", i), base.Add(time.Duration(i)*time.Second))
+		}
+	}
+	a, b := p.panes[0], p.panes[1]
+
+	bottom := a.YOffset()
+	p.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	if a.YOffset() != bottom {
+		t.Fatalf("without sync_scroll the other pane must stay put: %d → %d", bottom, a.YOffset())
+	}
+
+	p.SetSyncScroll(true)
+	p.SetTimestamps(true)
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	bt, _ := b.TopTime()
+	at, ok := a.TopTime()
+	if !ok || at.Before(bt) || at.Sub(bt) > time.Second {
+		t.Fatalf("pane a's top line is at %v, want the first at or after pane b's %v", at, bt)
+	}
+
+	p.SetTimestamps(false)
+	before := a.YOffset()
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if a.YOffset() != before+1 {
+		t.Fatalf("without timestamps the other pane should scroll by the same rows: %d → %d", before, a.YOffset())
+	}
+}
+
+func TestLogPanes_WrapIsPerPaneAndCarriesToNewOnes(t *testing.T) {
+	p := NewLogPanes()
+	p.SetSize(80, 12)
+	p.AddSource("a", "api-0", "ns", "eu", "app")
+	p.AppendLineAt("a", strings.Repeat("x", 200), time.Time{})
+	first := p.Active()
+
+	// Unwrapped, ← and → pan the long line.
+	p.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if percent, ok := first.ScrollStatus(); !ok || percent == 0 {
+		t.Fatalf("→ should pan an unwrapped line, got %d%% (ok %v)", percent, ok)
+	}
+
+	p.ToggleWrap()
+	if !first.Wrap() || !p.Wrap() {
+		t.Fatal("ToggleWrap should wrap the active pane and remember it")
+	}
+	if _, ok := first.ScrollStatus(); ok {
+		t.Error("a wrapped pane has nothing to pan")
+	}
+
+	p.Add()
+	second := p.Active()
+	if second == first || !second.Wrap() {
+		t.Fatal("a new pane should start wrapped, as last chosen")
+	}
+	p.ToggleWrap()
+	if second.Wrap() || !first.Wrap() {
+		t.Errorf("toggling the second pane changed the first: first %v, second %v", first.Wrap(), second.Wrap())
+	}
+}
+
+func TestLogPanes_CompareSharesSearchAndScrollsByTime(t *testing.T) {
+	base := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	p := NewLogPanes()
+	p.SetSize(80, 12) // too narrow for side by side, unless comparing
+	p.AddSource("a", "canary", "ns", "eu", "app")
+	if p.StartCompare() {
+		t.Fatal("one pane can't be compared")
+	}
+	p.Add()
+	p.AddSource("b", "stable", "ns", "eu", "app")
+	if !p.StartCompare() {
+		t.Fatal("two panes should compare")
+	}
+	if got := p.grid(); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("compared panes laid out %v, want side by side", got)
+	}
+	for i := range 40 {
+		p.AppendLineAt("b", fmt.Sprintf("b %d GET /health", i), base.Add(time.Duration(i)*time.Second))
+		if i%2 == 0 {
+			p.AppendLineAt("a", fmt.Sprintf("a %d GET /orders", i), base.Add(time.Duration(i)*time.Second))
+		}
+	}
+	a, b := p.panes[0], p.panes[1]
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	bt, _ := b.TopTime()
+	at, ok := a.TopTime()
+	if !ok || at.Before(bt) || at.Sub(bt) > time.Second {
+		t.Fatalf("pane a's top line is at %v, want the first at or after pane b's %v", at, bt)
+	}
+
+	p.SetSearch("ORDERS")
+	if strings.Contains(ansi.Strip(b.View()), "health") || !strings.Contains(ansi.Strip(a.View()), "GET /ord") {
+		t.Fatal("a search while comparing should narrow both panes")
+	}
+
+	p.Add()
+	if p.Comparing() {
+		t.Fatal("adding a pane should end the comparison")
+	}
+}
+
+func TestLogPage_PauseHoldsNewLinesUntilResumed(t *testing.T) {
+	l := newTestLogPage(60, 10)
+	l.AppendLine("k", "before")
+	l.TogglePause()
+	l.AppendLine("k", "during")
+
+	if held, paused := l.Paused(); !paused || held != 1 {
+		t.Fatalf("Paused() = %d, %v; want 1 line held", held, paused)
+	}
+	view := ansi.Strip(l.View())
+	if !strings.Contains(view, "before") || strings.Contains(view, "during") {
+		t.Fatalf("a paused view shows only what came before it:\n%s", view)
+	}
+	if !strings.Contains(ansi.Strip(l.Header(0)), "PAUSED · 1 new") {
+		t.Fatal("the header should carry the PAUSED badge")
+	}
+
+	l.TogglePause()
+	if !strings.Contains(ansi.Strip(l.View()), "during") {
+		t.Fatal("resuming should show the lines buffered meanwhile")
+	}
+	if _, paused := l.Paused(); paused {
+		t.Fatal("resumed")
+	}
+}
+
+func TestLogPage_ReplaceSourceKeepsScrollbackBehindADivider(t *testing.T) {
+	l := newTestLogPage(80, 10)
+	l.AppendLine("k", "old pod's last words")
+	l.ReplaceSource("k", "k2", "pod-b", "pod-a recreated as pod-b")
+	l.AppendLine("k2", "new pod's first words")
+
+	if l.HasSource("k") || !l.HasSource("k2") {
+		t.Fatal("the source should have moved to its new key")
+	}
+	view := ansi.Strip(l.View())
+	for _, want := range []string{"old pod's last words", "━━ pod-a recreated as pod-b ━━", "pod-b/app | new pod's first words"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"fmt"
+	"image/color"
+	"slices"
 	"strings"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	btable "github.com/evertras/bubble-table/table"
 	"github.com/ktails/ktails/internal/k8s"
@@ -11,11 +15,15 @@ import (
 )
 
 type PodPage struct {
+	keyed
 	Client  *k8s.Client
 	Focused bool
 	table   btable.Model
 
-	// Cache for view rendering
+	// Cache for view rendering. rows are what the table lists: every pod
+	// SetRows was given (all), or — browsing by deployment — the level
+	// being browsed (see podgroups.go).
+	all        []msgs.RowData
 	rows       []msgs.RowData
 	rowsSet    bool
 	cachedView string
@@ -33,6 +41,25 @@ type PodPage struct {
 	tableH       int
 	wideColCount int
 	scrollable   bool
+	sort         tableSort
+
+	// columns are the config names of the columns shown after Name
+	// outside wide mode (see SetColumns).
+	columns []string
+
+	// accents: the per-context bar leading each row (see rowAccents).
+	accents rowAccents
+
+	// sections group a multi-context list by context (see sections.go);
+	// scope, when set, narrows it to that one context's rows.
+	sections contextSections
+	scope    string
+
+	// byDeployment lists the pods' Deployments first, one row each, and
+	// group is the one opened (see GroupKey), "" at the top level.
+	byDeployment bool
+	group        string
+	groupCursor  int
 
 	// filter is a k9s-style "/" filter over the Name column — see rowFilter
 	// in table.go for why this exists instead of bubble-table's own filter.
@@ -56,7 +83,9 @@ func NewPodPageModel(client *k8s.Client) *PodPage {
 		checkedPods: make(map[string]bool),
 		windowSize:  defaultRowWindowSize,
 	}
-	p.table = newBubbleTable(podNarrowColumns())
+	p.columns = slices.Clone(defaultPodColumns)
+	p.sections.noun = "pod"
+	p.table = newBubbleTable(p.narrowColumns())
 	return p
 }
 
@@ -82,26 +111,27 @@ func (p *PodPage) Init() tea.Cmd {
 
 func (p *PodPage) Update(msg tea.Msg) tea.Cmd {
 	if p.Focused {
-		if key, ok := msg.(tea.KeyPressMsg); ok {
+		if press, ok := msg.(tea.KeyPressMsg); ok {
 			if p.filter.filtering {
-				p.filter.handleKey(key, len(p.rows), p.filterMatch)
+				p.filter.handleKey(p.keyMap(), press, len(p.rows), p.filterMatch)
 				p.afterFilterChange()
 				return nil
 			}
-			switch key.String() {
-			case "down", "j":
+			km := p.keyMap()
+			switch {
+			case key.Matches(press, km.Down):
 				p.moveCursor(1)
 				return nil
-			case "up", "k":
+			case key.Matches(press, km.Up):
 				p.moveCursor(-1)
 				return nil
-			case "home", "g":
+			case key.Matches(press, km.Top):
 				p.jumpTo(0)
 				return nil
-			case "end", "G":
+			case key.Matches(press, km.Bottom):
 				p.jumpTo(p.activeLen() - 1)
 				return nil
-			case "/":
+			case key.Matches(press, km.Filter):
 				p.filter.filtering = true
 				return nil
 			}
@@ -114,11 +144,31 @@ func (p *PodPage) Update(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
-// filterMatch is the rowFilter matchFn for Pods: a case-insensitive
-// substring match against the Name column.
+// filterMatch is the rowFilter matchFn for Pods: every space-separated
+// term of the query must match. A plain term is a case-insensitive
+// substring match against the Name column; "qos:" and "priority:" terms
+// match the start of the QoS or priority class column instead, so
+// "qos:best" narrows to BestEffort pods. Context section headers never
+// match.
 func (p *PodPage) filterMatch(i int) bool {
-	name, _ := p.rows[i][msgs.PodKeyName].(string)
-	return strings.Contains(strings.ToLower(name), strings.ToLower(p.filter.query))
+	row := p.rows[i]
+	if isSection(row) {
+		return false
+	}
+	for _, term := range strings.Fields(strings.ToLower(p.filter.query)) {
+		key, prefix := msgs.PodKeyName, false
+		if v, ok := strings.CutPrefix(term, "qos:"); ok {
+			key, term, prefix = msgs.PodKeyQoS, v, true
+		} else if v, ok := strings.CutPrefix(term, "priority:"); ok {
+			key, term, prefix = msgs.PodKeyPriority, v, true
+		}
+		value, _ := row[key].(string)
+		value = strings.ToLower(value)
+		if prefix && !strings.HasPrefix(value, term) || !prefix && !strings.Contains(value, term) {
+			return false
+		}
+	}
+	return true
 }
 
 // afterFilterChange re-syncs the cursor/window to the (possibly just
@@ -153,6 +203,13 @@ func (p *PodPage) FilterStatus() (query string, matches int, typing bool, ok boo
 	return p.filter.query, p.activeLen(), p.filter.filtering, true
 }
 
+// SetFilter replaces the committed "/" filter query (empty clears it), so
+// MainPage can drop a filter term without the user retyping the rest.
+func (p *PodPage) SetFilter(query string) {
+	p.filter.set(query, len(p.rows), p.filterMatch)
+	p.afterFilterChange()
+}
+
 // moveCursor shifts the cursor by delta within the active index space,
 // wrapping at either end (mirroring bubble-table's own moveHighlightUp/
 // Down), then slides the row window to keep the new cursor visible.
@@ -196,13 +253,40 @@ func (p *PodPage) jumpTo(idx int) {
 	p.invalidateView()
 }
 
+// ClickRow moves the cursor to the row drawn on line y of View, reporting
+// whether there's one there.
+func (p *PodPage) ClickRow(y int) bool {
+	pos, ok := rowAtLine(y, p.windowStart, p.activeLen())
+	if ok {
+		p.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows moves the cursor delta rows, stopping at either end rather
+// than wrapping as the arrow keys do — what the mouse wheel does.
+func (p *PodPage) ScrollRows(delta int) {
+	p.jumpTo(p.cursorIdx + delta)
+}
+
 func (p *PodPage) SetRows(rows []msgs.RowData) {
-	if p.rowsSet && rowsEqual(rows, p.rows) {
+	if p.rowsSet && rowsEqual(rows, p.all) {
 		return
 	}
 
-	p.rows = cloneRows(rows)
+	p.all = cloneRows(rows)
 	p.rowsSet = true
+	p.applyRows()
+}
+
+// applyRows rebuilds the listed rows from p.all for the current browse
+// level, or grouped by context, keeping the cursor in range.
+func (p *PodPage) applyRows() {
+	if p.byDeployment {
+		p.rows = p.levelRows()
+	} else {
+		p.rows = p.sections.apply(scopedRows(p.all, p.scope))
+	}
 	p.filter.recompute(len(p.rows), p.filterMatch)
 	if p.cursorIdx >= p.activeLen() {
 		p.cursorIdx = max(p.activeLen()-1, 0)
@@ -231,6 +315,14 @@ func (p *PodPage) pushDisplayRows() {
 	display := make([]btable.Row, 0, end-start)
 	for i := start; i < end; i++ {
 		row := p.activeRow(i)
+		if _, ok := row[msgs.PodKeyGroup]; ok {
+			display = append(display, groupDisplayRow(row))
+			continue
+		}
+		if isSection(row) {
+			display = append(display, p.sections.sectionDisplayRow(row, msgs.PodKeyCheck, p.accents))
+			continue
+		}
 		glyph := "☐"
 		if p.checkedPods[PodRowKey(row)] {
 			glyph = "☑"
@@ -240,31 +332,52 @@ func (p *PodPage) pushDisplayRows() {
 			msgs.PodKeyName:       row[msgs.PodKeyName],
 			msgs.PodKeyNamespace:  row[msgs.PodKeyNamespace],
 			msgs.PodKeyStatus:     btable.NewStyledCellWithStyleFunc(row[msgs.PodKeyStatus], statusCellStyle),
-			msgs.PodKeyRestarts:   row[msgs.PodKeyRestarts],
+			msgs.PodKeyRestarts:   restartsCell(row),
 			msgs.PodKeyAge:        row[msgs.PodKeyAge],
 			msgs.PodKeyContext:    row[msgs.PodKeyContext],
+			accentKey:             p.accents.cell(row[msgs.PodKeyContext]),
 			msgs.PodKeyContainers: row[msgs.PodKeyContainers],
 			msgs.PodKeyNode:       row[msgs.PodKeyNode],
 			msgs.PodKeyNodeIP:     row[msgs.PodKeyNodeIP],
 			msgs.PodKeyPodIP:      row[msgs.PodKeyPodIP],
-			msgs.PodKeyReady:      row[msgs.PodKeyReady],
+			msgs.PodKeyReady:      btable.NewStyledCellWithStyleFunc(row[msgs.PodKeyReady], replicaCellStyle),
+			msgs.PodKeyQoS:        row[msgs.PodKeyQoS],
+			msgs.PodKeyPriority:   row[msgs.PodKeyPriority],
+			msgs.PodKeyImage:      row[msgs.PodKeyImage],
+			msgs.PodKeyOwner:      row[msgs.PodKeyOwner],
 		}))
 	}
 	p.table = p.table.WithRows(display).WithHighlightedRow(p.cursorIdx - start)
 }
 
+// restartsCell is row's Restarts, flagged while they're climbing (see
+// msgs.PodKeyRestartRise): yellow for one restart since the last refresh,
+// red for a pod that keeps at it.
+func restartsCell(row msgs.RowData) any {
+	rise, _ := row[msgs.PodKeyRestartRise].(int32)
+	if rise <= 0 {
+		return row[msgs.PodKeyRestarts]
+	}
+	t := styles.Mocha()
+	style := t.Yellow
+	if rise > 1 {
+		style = t.Red.Bold(true)
+	}
+	return btable.NewStyledCell(fmt.Sprintf("%v ↑%d", row[msgs.PodKeyRestarts], rise), style)
+}
+
 // applyColumns rebuilds the column set for the current mode (narrow/wide),
 // auto-fitting wide-mode widths to p.rows — called on every SetRows/ToggleWideMode.
 func (p *PodPage) applyColumns() {
 	var cols []btable.Column
 	if p.wideMode {
-		cols = podWideColumns(p.rows)
+		cols = p.accents.withAccent(withSortIndicator(append([]btable.Column{paddedColumn(msgs.PodKeyCheck, "✓", checkColWidth)}, allColumns(podColumnDefs, p.rows, msgs.PodKeyName)...), p.sort))
 	} else {
-		cols = podNarrowColumns()
+		cols = p.narrowColumns()
 	}
 	p.wideColCount = len(cols)
 	p.scrollable = p.wideMode && totalColumnsWidth(cols) > p.tableW
-	p.table = p.table.WithColumns(cols).WithHorizontalFreezeColumnCount(1)
+	p.table = p.table.WithColumns(cols).WithHorizontalFreezeColumnCount(p.frozenColumns())
 	// WithTargetWidth governs flex-column sizing (narrow mode) and, if left
 	// set, forces bubble-table's own totalWidth to that value even for fixed
 	// wide-mode columns — which would silently disable scrolling. Clear it in
@@ -276,6 +389,50 @@ func (p *PodPage) applyColumns() {
 	}
 }
 
+// narrowColumns is the checkbox, Name and the chosen columns, fitted to
+// the table's width (see fitColumns).
+func (p *PodPage) narrowColumns() []btable.Column {
+	lead := p.accents.withAccent([]btable.Column{paddedColumn(msgs.PodKeyCheck, "✓", checkColWidth)})
+	return withSortIndicator(fitColumns(lead, podColumnDefs, p.columns, p.rows, msgs.PodKeyName, p.tableW), p.sort)
+}
+
+// frozenColumns is how many leading columns stay put when wide mode
+// scrolls: the checkbox, and the accent bar before it when there is one.
+func (p *PodPage) frozenColumns() int {
+	return 1 + len(p.accents.columns())
+}
+
+// SetAccents colors each row's leading bar by its context; fewer than two
+// entries hides the bar.
+func (p *PodPage) SetAccents(accents map[string]color.Color) {
+	p.accents = newRowAccents(accents)
+	p.applyColumns()
+	p.pushDisplayRows()
+	p.invalidateView()
+}
+
+// SetColumns picks the columns shown after Name outside wide mode, by
+// their config names (config.PodColumns), in order; none means the
+// defaults.
+func (p *PodPage) SetColumns(names []string) {
+	p.columns = chosenColumns(podColumnDefs, names, defaultPodColumns)
+	p.applyColumns()
+	p.invalidateView()
+}
+
+// Columns returns the columns shown after Name outside wide mode.
+func (p *PodPage) Columns() []string {
+	return slices.Clone(p.columns)
+}
+
+// SetSort marks column's header with the order the rows arrive in —
+// they're sorted in AppState, not here; "" clears the mark.
+func (p *PodPage) SetSort(column string, desc bool) {
+	p.sort = tableSort{column: column, desc: desc}
+	p.applyColumns()
+	p.invalidateView()
+}
+
 // ToggleWideMode flips wide mode for this tab (sticky until the next
 // resize) and rebuilds columns to fit the current data.
 func (p *PodPage) ToggleWideMode() {
@@ -352,7 +509,7 @@ func (p *PodPage) CheckedKeys() []string {
 // CheckedRow returns the raw (un-prefixed) row for a given check key, or
 // nil if no such row is currently loaded.
 func (p *PodPage) CheckedRow(key string) msgs.RowData {
-	for _, row := range p.rows {
+	for _, row := range p.all {
 		if PodRowKey(row) == key {
 			return row
 		}
@@ -361,8 +518,10 @@ func (p *PodPage) CheckedRow(key string) msgs.RowData {
 }
 
 func (p *PodPage) Reset() {
+	p.all = nil
 	p.rows = nil
 	p.rowsSet = false
+	p.group = ""
 	p.cursorIdx = 0
 	p.windowStart = 0
 	p.filter = rowFilter{}
@@ -395,16 +554,16 @@ func (p *PodPage) SetSize(w, h int) {
 	p.wideMode = false
 
 	st := styles.CatppuccinBubbleTableStyle()
-	p.table = newBubbleTable(podNarrowColumns()).
+	p.table = newBubbleTable(p.narrowColumns()).
 		WithMinimumHeight(h).
 		WithTargetWidth(w).
 		WithMaxTotalWidth(w).
 		HeaderStyle(st.Header).
 		HighlightStyle(st.Highlight).
 		WithBaseStyle(st.Base).
-		WithHorizontalFreezeColumnCount(1).
+		WithHorizontalFreezeColumnCount(p.frozenColumns()).
 		Focused(p.Focused)
-	p.wideColCount = len(podNarrowColumns())
+	p.wideColCount = len(p.narrowColumns())
 	p.scrollable = false
 	p.windowSize = rowWindowSizeFor(h)
 	p.windowStart = computeWindowStart(p.windowStart, p.cursorIdx, p.activeLen(), p.windowSize)
@@ -413,13 +572,50 @@ func (p *PodPage) SetSize(w, h int) {
 }
 
 // SelectedRow returns the raw (un-prefixed) row currently under the cursor,
-// or nil if there are no rows. Raw rows are what callers should read pod
+// or nil if there are no rows — or the cursor is on a Deployment's group
+// row or a context's section header, which aren't pods. Raw rows are what callers should read pod
 // identity out of — the table itself renders a checkbox-prefixed copy.
 func (p *PodPage) SelectedRow() msgs.RowData {
 	if p.cursorIdx < 0 || p.cursorIdx >= p.activeLen() {
 		return nil
 	}
-	return p.activeRow(p.cursorIdx)
+	row := p.activeRow(p.cursorIdx)
+	if _, ok := row[msgs.PodKeyGroup]; ok || isSection(row) {
+		return nil
+	}
+	return row
+}
+
+// SetContextScope lists only context's pods, as its own sub-tab of the
+// table; "" lists every context's.
+func (p *PodPage) SetContextScope(context string) {
+	if context == p.scope {
+		return
+	}
+	p.scope = context
+	p.group = ""
+	p.cursorIdx = 0
+	p.applyRows()
+}
+
+// FoldSection folds the context section the cursor is in down to its
+// header, or unfolds the folded one under the cursor, leaving the cursor
+// on the header; false if the list isn't grouped by context (a single
+// context, or browsing by deployment).
+func (p *PodPage) FoldSection() bool {
+	var cursor msgs.RowData
+	if p.cursorIdx < p.activeLen() {
+		cursor = p.activeRow(p.cursorIdx)
+	}
+	context, ok := p.sections.fold(p.rows, cursor)
+	if !ok {
+		return false
+	}
+	p.applyRows()
+	if i := sectionAt(context, p.activeLen(), p.activeRow); i >= 0 {
+		p.jumpTo(i)
+	}
+	return true
 }
 
 func (p *PodPage) invalidateView() {
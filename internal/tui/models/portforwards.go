@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// PortForwardPanel is the modal overlay listing port-forwards. Like
+// InfoPanel it only holds render state: the forwards themselves live in
+// k8s.PortForwardManager, and MainPage pushes a fresh List() whenever one
+// starts, stops or ends.
+type PortForwardPanel struct {
+	keyed
+	forwards []k8s.PortForwardInfo
+	cursor   int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewPortForwardPanel() *PortForwardPanel {
+	return &PortForwardPanel{}
+}
+
+// SetForwards replaces the listed forwards, keeping the cursor in range.
+func (p *PortForwardPanel) SetForwards(forwards []k8s.PortForwardInfo) {
+	p.forwards = forwards
+	p.cursor = max(0, min(p.cursor, len(forwards)-1))
+}
+
+// Selected returns the forward under the cursor.
+func (p *PortForwardPanel) Selected() (k8s.PortForwardInfo, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.forwards) {
+		return k8s.PortForwardInfo{}, false
+	}
+	return p.forwards[p.cursor], true
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (p *PortForwardPanel) SetSize(w, h int) {
+	p.width, p.height = w, h
+	p.innerW = max(20, w*4/5-6)
+	p.innerH = max(3, h*4/5-5)
+}
+
+func (p *PortForwardPanel) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	km := p.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		p.cursor--
+	case key.Matches(press, km.Down):
+		p.cursor++
+	case key.Matches(press, km.Top):
+		p.cursor = 0
+	case key.Matches(press, km.Bottom):
+		p.cursor = len(p.forwards) - 1
+	}
+	p.cursor = max(0, min(p.cursor, len(p.forwards)-1))
+	return nil
+}
+
+func (p *PortForwardPanel) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	activeStyle := lipgloss.NewStyle().Foreground(pal.Green)
+	endedStyle := lipgloss.NewStyle().Foreground(pal.Red)
+
+	var lines []string
+	if len(p.forwards) == 0 {
+		lines = append(lines, dim.Render("No port-forwards. Press p on a Pods or svc row to start one."))
+	}
+	// The list is short-lived and small; scroll only as far as the cursor.
+	start := max(0, p.cursor-p.innerH+1)
+	for i := start; i < len(p.forwards) && len(lines) < p.innerH; i++ {
+		f := p.forwards[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		status := activeStyle.Render("● up " + f.Uptime())
+		if !f.Active {
+			status = endedStyle.Render("✕ ended")
+			if f.Err != "" {
+				status += dim.Render(": " + f.Err)
+			}
+		}
+		target := fmt.Sprintf("%s/%s", f.Kind, f.Name)
+		if f.Kind == k8s.ForwardService {
+			target += dim.Render(" → " + f.Pod)
+		}
+		line := fmt.Sprintf("%slocalhost:%-5d → %s:%d  %s  %s", marker, f.LocalPort, target, f.RemotePort, dim.Render(f.Namespace+" ("+f.Context+")"), status)
+		lines = append(lines, ansi.Truncate(line, p.innerW, "…"))
+	}
+	for len(lines) < p.innerH {
+		lines = append(lines, "")
+	}
+
+	footer := "↑/↓ move • x stop • esc close (forwards keep running)"
+	return renderOverlayBox(p.width, p.height, p.innerW, fmt.Sprintf("Port-forwards: %d", len(p.forwards)), strings.Join(lines, "\n"), footer)
+}
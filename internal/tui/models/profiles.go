@@ -0,0 +1,117 @@
+package models
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// ProfileItem is one profile as the picker lists it: its name, and what it
+// switches to (its kubeconfig files and contexts) in a line.
+type ProfileItem struct {
+	Name   string
+	Detail string
+}
+
+// ProfilePicker is the modal overlay for switching profiles. It lists them
+// in config order with the active one marked; MainPage reads Picked() once
+// Enter confirms.
+type ProfilePicker struct {
+	keyed
+	items  []ProfileItem
+	active string
+	cursor int
+
+	width  int
+	height int
+	innerW int
+	innerH int
+}
+
+func NewProfilePicker() *ProfilePicker {
+	return &ProfilePicker{}
+}
+
+// Open resets the picker to items, the cursor on the active profile.
+func (p *ProfilePicker) Open(items []ProfileItem, active string) {
+	p.items = items
+	p.active = active
+	p.cursor = 0
+	for i, item := range items {
+		if item.Name == active {
+			p.cursor = i
+			break
+		}
+	}
+}
+
+// Picked returns the name of the profile under the cursor.
+func (p *ProfilePicker) Picked() (string, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.items) {
+		return "", false
+	}
+	return p.items[p.cursor].Name, true
+}
+
+// SetSize sizes the overlay to the space it's drawn over.
+func (p *ProfilePicker) SetSize(w, h int) {
+	p.width, p.height = w, h
+	p.innerW = max(20, min(72, w-16))
+	p.innerH = max(3, h*4/5-5)
+}
+
+func (p *ProfilePicker) Update(msg tea.Msg) tea.Cmd {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	km := p.keyMap()
+	switch {
+	case key.Matches(press, km.Up):
+		p.cursor--
+	case key.Matches(press, km.Down):
+		p.cursor++
+	case key.Matches(press, km.Top):
+		p.cursor = 0
+	case key.Matches(press, km.Bottom):
+		p.cursor = len(p.items) - 1
+	}
+	p.cursor = max(0, min(p.cursor, len(p.items)-1))
+	return nil
+}
+
+func (p *ProfilePicker) View() string {
+	pal := styles.CatppuccinMocha()
+	dim := lipgloss.NewStyle().Foreground(pal.Overlay1)
+	cursorStyle := lipgloss.NewStyle().Foreground(pal.Mauve).Bold(true)
+	activeStyle := lipgloss.NewStyle().Foreground(pal.Green)
+
+	// Two lines a profile: its name, then what it switches to.
+	var lines []string
+	start := max(0, p.cursor-(p.innerH/2)+1)
+	for i := start; i < len(p.items) && len(lines)+2 <= p.innerH; i++ {
+		item := p.items[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		name := item.Name
+		if item.Name == p.active {
+			name = activeStyle.Render(name + " ●")
+		}
+		lines = append(lines,
+			ansi.Truncate(marker+name, p.innerW, "…"),
+			ansi.Truncate("    "+dim.Render(item.Detail), p.innerW, "…"),
+		)
+	}
+	for len(lines) < p.innerH {
+		lines = append(lines, "")
+	}
+
+	footer := "enter switch (unloads every context) • esc cancel"
+	return renderOverlayBox(p.width, p.height, p.innerW, "Profiles", strings.Join(lines, "\n"), footer)
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// PromptDialog is a modal single-line text prompt (a local path to copy
+// to, ...). It only collects the text: MainPage decides what Enter does
+// with Value().
+type PromptDialog struct {
+	input textinput.Model
+	title string
+	label string
+
+	width  int
+	height int
+}
+
+func NewPromptDialog() *PromptDialog {
+	ti := textinput.New()
+	ti.Prompt = "› "
+	return &PromptDialog{input: ti}
+}
+
+// Open shows the prompt with initial text, cursor at the end.
+func (d *PromptDialog) Open(title, label, initial string) tea.Cmd {
+	d.title, d.label = title, label
+	d.input.ShowSuggestions = false
+	d.input.SetSuggestions(nil)
+	d.input.SetValue(initial)
+	d.input.CursorEnd()
+	return d.input.Focus()
+}
+
+// SetSuggestions offers completions for the open prompt: the first one
+// the text so far begins is shown after it, Tab takes it and ↓/↑ cycle
+// through the rest.
+func (d *PromptDialog) SetSuggestions(suggestions []string) {
+	d.input.ShowSuggestions = len(suggestions) > 0
+	d.input.SetSuggestions(suggestions)
+}
+
+// Value returns the entered text.
+func (d *PromptDialog) Value() string {
+	return d.input.Value()
+}
+
+// SetSize sizes the dialog to the space it's drawn over.
+func (d *PromptDialog) SetSize(w, h int) {
+	d.width, d.height = w, h
+	d.input.SetWidth(max(10, min(80, w-16)))
+}
+
+func (d *PromptDialog) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return cmd
+}
+
+func (d *PromptDialog) View() string {
+	p := styles.CatppuccinMocha()
+	innerW := d.input.Width() + 2
+	label := lipgloss.NewStyle().Foreground(p.Text).Render(ansi.Truncate(d.label, innerW, "…"))
+	body := lipgloss.JoinVertical(lipgloss.Left, label, "", d.input.View())
+	footer := "enter confirm • esc cancel"
+	if d.input.ShowSuggestions {
+		footer = "tab complete • ↓/↑ next • " + footer
+	}
+	return renderOverlayBox(d.width, d.height, innerW, d.title, body, footer)
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"strings"
 
+	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/ktails/ktails/internal/health"
 	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/textwidth"
+	"github.com/ktails/ktails/internal/tui/keys"
 	"github.com/ktails/ktails/internal/tui/styles"
 )
 
@@ -17,6 +21,7 @@ import (
 // single Kubernetes resource (Deployment, Pod, ...), shown in the shared
 // bottom Detail tab regardless of which top tab it was opened from.
 type ResourceDetailPage struct {
+	keyed
 	viewport viewport.Model
 
 	loaded  bool
@@ -80,7 +85,7 @@ func (d *ResourceDetailPage) SetDetail(detail k8s.ResourceDetail) {
 	d.loading = false
 	d.loaded = true
 	d.errMsg = ""
-	d.rawContent = d.render(detail)
+	d.rawContent = textwidth.SanitizeLines(d.render(detail))
 	d.rawLineWidth = maxLineWidth(d.rawContent)
 	d.clampHOffset()
 	d.applyHOffset()
@@ -151,6 +156,16 @@ func (d *ResourceDetailPage) Matches(kind, name, context string) bool {
 	return d.HasContent() && d.kind == kind && d.name == name && d.context == context
 }
 
+// Context is the context of the loaded resource.
+func (d *ResourceDetailPage) Context() string {
+	return d.context
+}
+
+// Resource is the kind and name of the loaded resource.
+func (d *ResourceDetailPage) Resource() (kind, name string) {
+	return d.kind, d.name
+}
+
 // Header renders a one-line banner identifying the loaded resource and the
 // pane's own close hint, meant to sit above the scrollable viewport so the
 // pane reads as a distinct region rather than a peer tab. width caps the
@@ -174,31 +189,23 @@ func (d *ResourceDetailPage) Header(width int) string {
 }
 
 func (d *ResourceDetailPage) Update(msg tea.Msg) tea.Cmd {
-	if key, ok := msg.(tea.KeyPressMsg); ok {
-		switch key.String() {
-		case "home", "g":
-			d.viewport.GotoTop()
-			return nil
-		case "end", "G":
-			d.viewport.GotoBottom()
-			return nil
-		case "shift+left":
+	if press, ok := msg.(tea.KeyPressMsg); ok {
+		km := d.keyMap()
+		if key.Matches(press, km.Pan) {
 			if !d.loaded {
 				return nil
 			}
-			d.hOffset -= halfViewportStep(d.viewport.Width())
-			d.clampHOffset()
-			d.applyHOffset()
-			return nil
-		case "shift+right":
-			if !d.loaded {
-				return nil
+			step := halfViewportStep(d.viewport.Width())
+			if keys.Index(press, km.Pan) == 0 {
+				step = -step
 			}
-			d.hOffset += halfViewportStep(d.viewport.Width())
+			d.hOffset += step
 			d.clampHOffset()
 			d.applyHOffset()
 			return nil
 		}
+		scrollViewport(&d.viewport, km, press)
+		return nil
 	}
 
 	var cmd tea.Cmd
@@ -261,7 +268,17 @@ func (d *ResourceDetailPage) render(detail k8s.ResourceDetail) string {
 		labelStyle.Render("Namespace:"), detail.Namespace,
 		labelStyle.Render("Age:"), detail.Age,
 	)
+	if detail.State != "" {
+		fmt.Fprintf(&b, "%s %s   ", labelStyle.Render("Status:"), podStatusStyle(detail.State).Bold(true).Render(detail.State))
+	}
 	fmt.Fprintln(&b, detail.Summary)
+	if detail.Health != "" {
+		fmt.Fprintf(&b, "%s %s", labelStyle.Render("Health:"), healthStyle(detail.Health).Render(detail.Health))
+		if detail.HealthReason != "" {
+			fmt.Fprint(&b, lipgloss.NewStyle().Foreground(p.Overlay1).Render("  ("+detail.HealthReason+")"))
+		}
+		fmt.Fprintln(&b)
+	}
 	fmt.Fprintln(&b)
 
 	fmt.Fprintln(&b, titleStyle.Render("Status"))
@@ -295,3 +312,18 @@ func (d *ResourceDetailPage) render(detail k8s.ResourceDetail) string {
 
 	return b.String()
 }
+
+// healthStyle colors a config health rule's status: the two well-known
+// statuses get green/red, anything a CEL rule made up stays neutral.
+func healthStyle(status string) lipgloss.Style {
+	p := styles.CatppuccinMocha()
+	switch status {
+	case health.Healthy:
+		return lipgloss.NewStyle().Foreground(p.Green)
+	case health.Degraded:
+		return lipgloss.NewStyle().Foreground(p.Red)
+	case health.Unknown:
+		return lipgloss.NewStyle().Foreground(p.Yellow)
+	}
+	return lipgloss.NewStyle().Foreground(p.Text)
+}
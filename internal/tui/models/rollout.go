@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// RolloutLines renders the info panel body for a Deployment's rollout: its
+// status as kubectl rollout status words it, the replica counts, its
+// conditions, then its revision history, newest first.
+func RolloutLines(r k8s.Rollout) []string {
+	p := styles.CatppuccinMocha()
+	headerStyle := lipgloss.NewStyle().Foreground(p.Peach).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(p.Overlay1)
+	statusStyle := lipgloss.NewStyle().Foreground(p.Yellow)
+	if r.Done {
+		statusStyle = lipgloss.NewStyle().Foreground(p.Green)
+	}
+	currentStyle := lipgloss.NewStyle().Foreground(p.Green).Bold(true)
+
+	lines := []string{statusStyle.Render(r.Status)}
+	if r.Paused {
+		lines = append(lines, statusStyle.Render("Paused: changes to the template aren't rolled out until resumed"))
+	}
+	lines = append(lines,
+		"",
+		fmt.Sprintf("Replicas: %d desired • %d current • %d updated • %d ready • %d available",
+			r.Desired, r.Current, r.Updated, r.Ready, r.Available),
+	)
+
+	if len(r.Conditions) > 0 {
+		lines = append(lines, "", headerStyle.Render("▸ Conditions"))
+		for _, cond := range r.Conditions {
+			lines = append(lines, "  "+cond)
+		}
+	}
+
+	lines = append(lines, "", headerStyle.Render("▸ History"))
+	if len(r.Revisions) == 0 {
+		return append(lines, dim.Render("  No ReplicaSets found for this deployment."))
+	}
+	lines = append(lines, dim.Render(fmt.Sprintf("  %-8s  %-8s  %-6s  %-32s  %s", "REVISION", "REPLICAS", "AGE", "REPLICASET", "CHANGE-CAUSE")))
+	for _, rev := range r.Revisions {
+		cause := rev.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		line := fmt.Sprintf("  %-8d  %-8d  %-6s  %-32s  %s", rev.Revision, rev.Replicas, shortAge(rev.Created), rev.ReplicaSet, cause)
+		if rev.Current {
+			line = currentStyle.Render(line + "  ← current")
+		}
+		lines = append(lines, line, dim.Render("            "+strings.Join(rev.Images, ", ")))
+	}
+	return lines
+}
+
+// shortAge renders the time since t as its largest unit: "45s", "12m",
+// "3h", "20d".
+func shortAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case t.IsZero():
+		return "—"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours())/24)
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+
+	"charm.land/lipgloss/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// sectionKey is the hidden row key set on a context's section header row,
+// to the context's name.
+const sectionKey = "section"
+
+// contextSections groups a multi-context table's rows under a header row
+// per context, naming it and counting its rows, and remembers the contexts
+// whose section is folded down to its header. Pods and Deployments rows
+// share their name and context keys, so one serves both tables.
+type contextSections struct {
+	noun   string // what the count counts: "pod", "deployment"
+	folded map[string]bool
+}
+
+// apply returns rows grouped by context, contexts in name order and rows in
+// the order they came within each, every group led by its header row and
+// a folded one's rows left out. Rows from fewer than two contexts come
+// back as they are.
+func (s *contextSections) apply(rows []msgs.RowData) []msgs.RowData {
+	groups := make(map[string][]msgs.RowData)
+	for _, row := range rows {
+		context, _ := row[msgs.PodKeyContext].(string)
+		groups[context] = append(groups[context], row)
+	}
+	if len(groups) < 2 {
+		return rows
+	}
+
+	contexts := make([]string, 0, len(groups))
+	for context := range groups {
+		contexts = append(contexts, context)
+	}
+	slices.Sort(contexts)
+
+	out := make([]msgs.RowData, 0, len(rows)+len(contexts))
+	for _, context := range contexts {
+		noun := s.noun
+		if len(groups[context]) != 1 {
+			noun += "s"
+		}
+		out = append(out, msgs.RowData{
+			sectionKey:         context,
+			msgs.PodKeyName:    fmt.Sprintf("%s · %d %s", context, len(groups[context]), noun),
+			msgs.PodKeyContext: context,
+		})
+		if !s.folded[context] {
+			out = append(out, groups[context]...)
+		}
+	}
+	return out
+}
+
+// toggle folds context's section down to its header, or unfolds it.
+func (s *contextSections) toggle(context string) {
+	if s.folded == nil {
+		s.folded = make(map[string]bool)
+	}
+	if s.folded[context] {
+		delete(s.folded, context)
+	} else {
+		s.folded[context] = true
+	}
+}
+
+// scopedRows is the rows of context, or all of them for "".
+func scopedRows(rows []msgs.RowData, context string) []msgs.RowData {
+	if context == "" {
+		return rows
+	}
+	var scoped []msgs.RowData
+	for _, row := range rows {
+		if row[msgs.PodKeyContext] == context {
+			scoped = append(scoped, row)
+		}
+	}
+	return scoped
+}
+
+// isSection reports whether row is a context's section header.
+func isSection(row msgs.RowData) bool {
+	_, ok := row[sectionKey]
+	return ok
+}
+
+// sectionDisplayRow renders a section header: "▾" (folded, "▸") where rows
+// have their leading glyph, under leadKey, or else before its name, and
+// the name and count in the context's accent, bold.
+func (s *contextSections) sectionDisplayRow(row msgs.RowData, leadKey string, accents rowAccents) btable.Row {
+	context, _ := row[sectionKey].(string)
+	glyph := "▾"
+	if s.folded[context] {
+		glyph = "▸"
+	}
+	st, ok := accents[context]
+	if !ok {
+		st = lipgloss.NewStyle().Foreground(styles.Mocha().Palette.Text)
+	}
+	name, _ := row[msgs.PodKeyName].(string)
+	data := btable.RowData{accentKey: accents.cell(context)}
+	if leadKey != "" {
+		data[leadKey] = glyph
+	} else {
+		name = glyph + " " + name
+	}
+	data[msgs.PodKeyName] = btable.NewStyledCell(name, st.Bold(true))
+	return btable.NewRow(data)
+}
+
+// fold folds or unfolds the section of cursor, one of rows, returning its
+// context; ok is false if rows aren't grouped into sections.
+func (s *contextSections) fold(rows []msgs.RowData, cursor msgs.RowData) (context string, ok bool) {
+	if cursor == nil || !slices.ContainsFunc(rows, isSection) {
+		return "", false
+	}
+	context, _ = cursor[msgs.PodKeyContext].(string)
+	s.toggle(context)
+	return context, true
+}
+
+// sectionAt is the position of context's header among n rows read
+// through row, -1 if it has none.
+func sectionAt(context string, n int, row func(int) msgs.RowData) int {
+	for i := range n {
+		if r := row(i); isSection(r) && r[sectionKey] == context {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
+
+func TestPodPage_GroupsRowsByContextAndFolds(t *testing.T) {
+	p := NewPodPageModel(nil)
+	p.SetSize(80, 20)
+	p.SetFocused(true)
+	rows := samplePodRows(4)
+	for i, row := range rows {
+		row[msgs.PodKeyName] = fmt.Sprintf("pod-%d", i)
+		row[msgs.PodKeyContext] = []string{"prod", "dev"}[i%2]
+	}
+	p.SetRows(rows)
+
+	var got []string
+	for _, row := range p.rows {
+		got = append(got, row[msgs.PodKeyName].(string))
+	}
+	want := []string{"dev · 2 pods", "pod-1", "pod-3", "prod · 2 pods", "pod-0", "pod-2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	if p.SelectedRow() != nil {
+		t.Error("a section header isn't a pod; SelectedRow should be nil on it")
+	}
+
+	p.jumpTo(2) // pod-3, in dev's section
+	if !p.FoldSection() {
+		t.Fatal("FoldSection found no sections")
+	}
+	if len(p.rows) != 4 || p.cursorIdx != 0 {
+		t.Errorf("folding dev left %d rows, cursor %d; want 4 rows, cursor on dev's header", len(p.rows), p.cursorIdx)
+	}
+	p.FoldSection()
+	if len(p.rows) != 6 {
+		t.Errorf("unfolding dev left %d rows, want 6", len(p.rows))
+	}
+
+	p.SetFilter("pod-0")
+	if p.activeLen() != 1 {
+		t.Errorf("filtering matched %d rows, want just pod-0 without headers", p.activeLen())
+	}
+
+	single := NewPodPageModel(nil)
+	single.SetRows(samplePodRows(3))
+	if single.FoldSection() || len(single.rows) != 3 {
+		t.Error("rows of one context shouldn't be grouped")
+	}
+}
+
+func TestDeploymentPage_ContextScopeListsOneContext(t *testing.T) {
+	d := NewDeploymentPage(nil)
+	d.SetRows([]msgs.RowData{
+		{msgs.DeployKeyName: "api", msgs.DeployKeyContext: "prod"},
+		{msgs.DeployKeyName: "web", msgs.DeployKeyContext: "dev"},
+	})
+	if len(d.rows) != 4 {
+		t.Fatalf("all contexts: %d rows, want 2 and a header each", len(d.rows))
+	}
+
+	d.SetContextScope("prod")
+	if len(d.rows) != 1 || d.SelectedRow()[msgs.DeployKeyName] != "api" {
+		t.Errorf("prod's sub-tab lists %v, want just api, ungrouped", d.rows)
+	}
+	d.SetContextScope("")
+	if len(d.rows) != 4 {
+		t.Errorf("back to all contexts: %d rows, want 4", len(d.rows))
+	}
+}
@@ -1,8 +1,10 @@
 package models
 
 import (
+	"image/color"
 	"strings"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	btable "github.com/evertras/bubble-table/table"
 	"github.com/ktails/ktails/internal/k8s"
@@ -11,6 +13,7 @@ import (
 )
 
 type ServicePage struct {
+	keyed
 	Client  *k8s.Client
 	Focused bool
 	table   btable.Model
@@ -26,6 +29,9 @@ type ServicePage struct {
 	wideColCount int
 	scrollable   bool
 
+	// accents: the per-context bar leading each row (see rowAccents).
+	accents rowAccents
+
 	// filter is a k9s-style "/" filter over the Name column — see rowFilter
 	// in table.go for why this exists instead of bubble-table's own filter.
 	filter rowFilter
@@ -41,7 +47,7 @@ type ServicePage struct {
 func NewServicePageModel(client *k8s.Client) *ServicePage {
 	return &ServicePage{
 		Client:     client,
-		table:      newBubbleTable(svcNarrowColumns()),
+		table:      newBubbleTable(svcNarrowColumns(nil, nil, 0)),
 		viewDirty:  true,
 		windowSize: defaultRowWindowSize,
 	}
@@ -53,26 +59,27 @@ func (s *ServicePage) Init() tea.Cmd {
 
 func (s *ServicePage) Update(msg tea.Msg) tea.Cmd {
 	if s.Focused {
-		if key, ok := msg.(tea.KeyPressMsg); ok {
+		if press, ok := msg.(tea.KeyPressMsg); ok {
 			if s.filter.filtering {
-				s.filter.handleKey(key, len(s.rows), s.filterMatch)
+				s.filter.handleKey(s.keyMap(), press, len(s.rows), s.filterMatch)
 				s.afterFilterChange()
 				return nil
 			}
-			switch key.String() {
-			case "down", "j":
+			km := s.keyMap()
+			switch {
+			case key.Matches(press, km.Down):
 				s.moveCursor(1)
 				return nil
-			case "up", "k":
+			case key.Matches(press, km.Up):
 				s.moveCursor(-1)
 				return nil
-			case "home", "g":
+			case key.Matches(press, km.Top):
 				s.jumpTo(0)
 				return nil
-			case "end", "G":
+			case key.Matches(press, km.Bottom):
 				s.jumpTo(s.activeLen() - 1)
 				return nil
-			case "/":
+			case key.Matches(press, km.Filter):
 				s.filter.filtering = true
 				return nil
 			}
@@ -117,6 +124,12 @@ func (s *ServicePage) FilterStatus() (query string, matches int, typing bool, ok
 	return s.filter.query, s.activeLen(), s.filter.filtering, true
 }
 
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (s *ServicePage) SetFilter(query string) {
+	s.filter.set(query, len(s.rows), s.filterMatch)
+	s.afterFilterChange()
+}
+
 // moveCursor: see PodPage.moveCursor in pods.go.
 func (s *ServicePage) moveCursor(delta int) {
 	total := s.activeLen()
@@ -154,6 +167,20 @@ func (s *ServicePage) jumpTo(idx int) {
 	s.invalidateView()
 }
 
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (s *ServicePage) ClickRow(y int) bool {
+	pos, ok := rowAtLine(y, s.windowStart, s.activeLen())
+	if ok {
+		s.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (s *ServicePage) ScrollRows(delta int) {
+	s.jumpTo(s.cursorIdx + delta)
+}
+
 func (s *ServicePage) SetRows(rows []msgs.RowData) {
 	if s.rowsSet && rowsEqual(rows, s.rows) {
 		return
@@ -196,6 +223,7 @@ func (s *ServicePage) pushDisplayRows() {
 			msgs.SvcKeyPorts:       row[msgs.SvcKeyPorts],
 			msgs.SvcKeyAge:         row[msgs.SvcKeyAge],
 			msgs.SvcKeyContext:     row[msgs.SvcKeyContext],
+			accentKey:              s.accents.cell(row[msgs.SvcKeyContext]),
 			msgs.SvcKeySelector:    row[msgs.SvcKeySelector],
 			msgs.SvcKeyExternalIP:  row[msgs.SvcKeyExternalIP],
 			msgs.SvcKeyEndpointIPs: row[msgs.SvcKeyEndpointIPs],
@@ -209,9 +237,9 @@ func (s *ServicePage) pushDisplayRows() {
 func (s *ServicePage) applyColumns() {
 	var cols []btable.Column
 	if s.wideMode {
-		cols = svcWideColumns(s.rows)
+		cols = s.accents.withAccent(svcWideColumns(s.rows))
 	} else {
-		cols = svcNarrowColumns()
+		cols = s.narrowColumns()
 	}
 	s.wideColCount = len(cols)
 	s.scrollable = s.wideMode && totalColumnsWidth(cols) > s.tableW
@@ -225,6 +253,21 @@ func (s *ServicePage) applyColumns() {
 	}
 }
 
+// narrowColumns lays out the narrow-mode columns for the table's width,
+// after the accent bar when there is one.
+func (s *ServicePage) narrowColumns() []btable.Column {
+	return svcNarrowColumns(s.accents.columns(), s.rows, s.tableW)
+}
+
+// SetAccents colors each row's leading bar by its context; see
+// PodPage.SetAccents.
+func (s *ServicePage) SetAccents(accents map[string]color.Color) {
+	s.accents = newRowAccents(accents)
+	s.applyColumns()
+	s.pushDisplayRows()
+	s.invalidateView()
+}
+
 // ToggleWideMode flips wide mode for this tab (sticky until the next
 // resize) and rebuilds columns to fit the current data.
 func (s *ServicePage) ToggleWideMode() {
@@ -292,7 +335,7 @@ func (s *ServicePage) SetSize(w, h int) {
 	s.wideMode = false
 
 	st := styles.CatppuccinBubbleTableStyle()
-	s.table = newBubbleTable(svcNarrowColumns()).
+	s.table = newBubbleTable(s.narrowColumns()).
 		WithMinimumHeight(h).
 		WithTargetWidth(w).
 		WithMaxTotalWidth(w).
@@ -300,7 +343,7 @@ func (s *ServicePage) SetSize(w, h int) {
 		HighlightStyle(st.Highlight).
 		WithBaseStyle(st.Base).
 		Focused(s.Focused)
-	s.wideColCount = len(svcNarrowColumns())
+	s.wideColCount = len(s.narrowColumns())
 	s.scrollable = false
 	s.windowSize = rowWindowSizeFor(h)
 	s.windowStart = computeWindowStart(s.windowStart, s.cursorIdx, s.activeLen(), s.windowSize)
@@ -1,13 +1,16 @@
 package models
 
 import (
-	"image/color"
+	"slices"
 	"strconv"
 	"strings"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/keys"
 	"github.com/ktails/ktails/internal/tui/msgs"
 	"github.com/ktails/ktails/internal/tui/styles"
 )
@@ -67,39 +70,47 @@ func (f *rowFilter) absolute(pos int) int {
 }
 
 // handleKey processes one keypress while filter-typing mode is on,
-// mutating the query (and re-running matchFn) as needed. Enter commits the
-// query and exits typing mode without changing it further; Esc clears the
-// query entirely and exits typing mode; Backspace/printable text edit the
-// query live.
-func (f *rowFilter) handleKey(key tea.KeyPressMsg, total int, matchFn func(i int) bool) {
-	switch key.String() {
-	case "enter":
-		f.filtering = false
-	case "esc":
+// mutating the query (and re-running matchFn) as needed. FilterKeep (Enter)
+// commits the query and exits typing mode without changing it further;
+// FilterClear (Esc) clears the query entirely and exits typing mode;
+// Backspace/printable text edit the query live.
+func (f *rowFilter) handleKey(km *keys.KeyMap, press tea.KeyPressMsg, total int, matchFn func(i int) bool) {
+	switch {
+	case key.Matches(press, km.FilterKeep):
 		f.filtering = false
-		f.query = ""
-		f.recompute(total, matchFn)
-	case "backspace":
+	case key.Matches(press, km.FilterClear):
+		f.set("", total, matchFn)
+	case press.Code == tea.KeyBackspace:
 		if f.query != "" {
 			r := []rune(f.query)
 			f.query = string(r[:len(r)-1])
 			f.recompute(total, matchFn)
 		}
 	default:
-		if key.Text != "" {
-			f.query += key.Text
+		if press.Text != "" {
+			f.query += press.Text
 			f.recompute(total, matchFn)
 		}
 	}
 }
 
+// set replaces the query outright and leaves typing mode, for filter edits
+// made from outside the table (e.g. dismissing a filter chip).
+func (f *rowFilter) set(query string, total int, matchFn func(i int) bool) {
+	f.filtering = false
+	f.query = query
+	f.recompute(total, matchFn)
+}
+
 // newBubbleTable builds a bubble-table Model with the options common to
 // every resource table: no pagination (the spec keeps today's continuous
 // full-list scroll, not discrete pages), and no border — bubble-table draws
 // a full grid border by default, which the old bubbles/table never had and
 // which just duplicates the app's own pane border around the tab content.
+// Its keymap is emptied: each table moves its own cursor by the shared
+// keymap (see keyed), and bubble-table's built-in j/k would move it too.
 func newBubbleTable(cols []btable.Column) btable.Model {
-	return btable.New(cols).WithNoPagination().Border(btable.Border{})
+	return btable.New(cols).WithNoPagination().Border(btable.Border{}).WithKeyMap(btable.KeyMap{})
 }
 
 // defaultRowWindowSize is the row-window size used before a table has ever
@@ -163,6 +174,18 @@ func windowBounds(start, total, windowSize int) (int, int) {
 	return start, end
 }
 
+// tableHeadLines is how many lines a windowed table's view draws above its
+// first row: the empty border's top line, the header and the line under it.
+const tableHeadLines = 3
+
+// rowAtLine maps line y of a windowed table's view to the row drawn there,
+// in the active index space; ok is false for the lines above the rows or
+// one past the last row.
+func rowAtLine(y, windowStart, total int) (pos int, ok bool) {
+	pos = windowStart + y - tableHeadLines
+	return pos, y >= tableHeadLines && pos < total
+}
+
 // checkColWidth is the content width (before padding) of the Pods checkbox
 // column glyph. The old bubbles/table implementation applied one shared
 // Padding(0,1) cell style across every column including the checkbox, so it
@@ -188,6 +211,78 @@ func paddedFlexColumn(key, title string, flexFactor int) btable.Column {
 	return btable.NewFlexColumn(key, title, flexFactor).WithStyle(columnPadStyle())
 }
 
+// fitWidths lays out a table's narrow-mode columns in width cells: lead
+// as given, then name and rest. Each of rest is as wide as the widest
+// value it holds, up to maxColumnWidth, and name is the one flex column,
+// so it takes whatever is left and grows and shrinks with the terminal.
+// Where that would squeeze name under minNameWidth, the widest of rest
+// give up room first, down to their titles, and then the last of them are
+// dropped; bubble-table ellipsizes the values that no longer fit. width
+// <= 0 (not yet sized) skips the squeeze.
+func fitWidths(lead []btable.Column, name columnDef, rest []columnDef, rows []msgs.RowData, width int) []btable.Column {
+	widths := make([]int, len(rest))
+	used := totalColumnsWidth(lead) + minNameWidth + 2 + len(rest) + 1
+	for i, d := range rest {
+		widths[i] = min(maxColumnWidth, widestValue(rows, d.key, d.title))
+		used += widths[i] + 2
+	}
+	for width > 0 && used > width {
+		widest := -1
+		for i, d := range rest {
+			if widths[i] > lipgloss.Width(d.title) && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		used--
+	}
+	for width > 0 && used > width && len(rest) > 0 {
+		used -= widths[len(rest)-1] + 3
+		rest = rest[:len(rest)-1]
+	}
+
+	cols := append(slices.Clone(lead), paddedFlexColumn(name.key, name.title, 1))
+	for i, d := range rest {
+		cols = append(cols, paddedColumn(d.key, d.title, widths[i]))
+	}
+	return cols
+}
+
+// tableSort is the column a table's rows are sorted by (see
+// state.SortSpec), shown as an arrow after that column's title.
+type tableSort struct {
+	column string
+	desc   bool
+}
+
+// withSortIndicator marks the sorted column's title with ▲ (ascending) or
+// ▼, widening a fixed column so the arrow doesn't push the title into an
+// ellipsis.
+func withSortIndicator(cols []btable.Column, sort tableSort) []btable.Column {
+	if sort.column == "" {
+		return cols
+	}
+	arrow := " ▲"
+	if sort.desc {
+		arrow = " ▼"
+	}
+	for i, c := range cols {
+		if c.Key() != sort.column {
+			continue
+		}
+		title := c.Title() + arrow
+		if c.IsFlex() {
+			cols[i] = paddedFlexColumn(c.Key(), title, c.FlexFactor())
+		} else {
+			cols[i] = btable.NewColumn(c.Key(), title, max(c.Width(), lipgloss.Width(title)+2)).WithStyle(c.Style())
+		}
+	}
+	return cols
+}
+
 // widestValue returns the widest string found under key across rows,
 // falling back to the header's own width — used to auto-fit wide-mode
 // column widths to whatever data is currently loaded (recomputed on every
@@ -218,36 +313,31 @@ func totalColumnsWidth(cols []btable.Column) int {
 	return total + len(cols) + 1
 }
 
-// statusColor maps a pod phase (PodInfo.Status) to its Catppuccin Mocha
-// status color, per the Status Colors spec: Running=Green, Pending=Yellow,
-// Failed/Unknown=Red, Succeeded=Overlay1 (dim). Unrecognized phases are
-// left uncolored.
-func statusColor(status string) (color.Color, bool) {
-	p := styles.CatppuccinMocha()
-	switch status {
-	case "Running":
-		return p.Green, true
-	case "Pending":
-		return p.Yellow, true
-	case "Failed", "Unknown":
-		return p.Red, true
-	case "Succeeded":
-		return p.Overlay1, true
-	default:
-		return nil, false
-	}
-}
-
 // statusCellStyle is a btable.StyledCellFunc that colors the Status cell by
-// phase (see statusColor). Cell style is applied by bubble-table at render
-// time, after content-width truncation — the whole reason this migration
-// dropped the old post-render ANSI-recoloring workaround.
+// the pod's kubectl-style status (see podStatusStyle).
 func statusCellStyle(input btable.StyledCellFuncInput) lipgloss.Style {
 	status, _ := input.Data.(string)
-	if col, ok := statusColor(status); ok {
-		return lipgloss.NewStyle().Foreground(col)
+	return podStatusStyle(status)
+}
+
+// podStatusStyle colors a pod status (k8s.PodStatus), per the Status
+// Colors spec: Running=Green, Succeeded/Completed=Overlay1 (dim), anything
+// failing (CrashLoopBackOff, ImagePullBackOff, Error, ... — see
+// k8s.PodStatusFailing)=Red, and the rest, a pod on its way up or down
+// (Pending, ContainerCreating, Init:0/1, NotReady, Terminating)=Yellow.
+func podStatusStyle(status string) lipgloss.Style {
+	t := styles.Mocha()
+	switch {
+	case status == "":
+		return t.Plain
+	case status == "Running":
+		return t.Green
+	case status == "Succeeded" || status == "Completed":
+		return t.Overlay1
+	case k8s.PodStatusFailing(status):
+		return t.Red
 	}
-	return lipgloss.NewStyle()
+	return t.Yellow
 }
 
 // replicaCellStyle is a btable.StyledCellFunc that colors a "ready/desired"
@@ -255,88 +345,63 @@ func statusCellStyle(input btable.StyledCellFuncInput) lipgloss.Style {
 // ready, yellow when partially ready, red when zero replicas are ready but
 // some are desired.
 func replicaCellStyle(input btable.StyledCellFuncInput) lipgloss.Style {
+	t := styles.Mocha()
 	cell, _ := input.Data.(string)
 	ready, desired, ok := strings.Cut(cell, "/")
 	if !ok {
-		return lipgloss.NewStyle()
+		return t.Plain
 	}
 	readyN, err := strconv.Atoi(ready)
 	if err != nil {
-		return lipgloss.NewStyle()
+		return t.Plain
 	}
 	desiredN, err := strconv.Atoi(desired)
 	if err != nil {
-		return lipgloss.NewStyle()
+		return t.Plain
 	}
 
-	p := styles.CatppuccinMocha()
-	color := p.Red
 	switch {
 	case readyN == desiredN:
-		color = p.Green
+		return t.Green
 	case readyN > 0:
-		color = p.Yellow
+		return t.Yellow
 	}
-	return lipgloss.NewStyle().Foreground(color)
+	return t.Red
 }
 
-func podNarrowColumns() []btable.Column {
-	return []btable.Column{
-		paddedColumn(msgs.PodKeyCheck, "✓", checkColWidth),
-		paddedFlexColumn(msgs.PodKeyName, "Name", 10),
-		paddedFlexColumn(msgs.PodKeyNamespace, "Namespace", 5),
-		paddedFlexColumn(msgs.PodKeyStatus, "Status", 4),
-		paddedFlexColumn(msgs.PodKeyRestarts, "Restarts", 3),
-		paddedFlexColumn(msgs.PodKeyAge, "Age", 3),
-	}
-}
-
-func podWideColumns(rows []msgs.RowData) []btable.Column {
-	return []btable.Column{
-		paddedColumn(msgs.PodKeyCheck, "✓", checkColWidth),
-		paddedColumn(msgs.PodKeyName, "Name", widestValue(rows, msgs.PodKeyName, "Name")),
-		paddedColumn(msgs.PodKeyNamespace, "Namespace", widestValue(rows, msgs.PodKeyNamespace, "Namespace")),
-		paddedColumn(msgs.PodKeyStatus, "Status", widestValue(rows, msgs.PodKeyStatus, "Status")),
-		paddedColumn(msgs.PodKeyReady, "Ready", widestValue(rows, msgs.PodKeyReady, "Ready")),
-		paddedColumn(msgs.PodKeyRestarts, "Restarts", widestValue(rows, msgs.PodKeyRestarts, "Restarts")),
-		paddedColumn(msgs.PodKeyAge, "Age", widestValue(rows, msgs.PodKeyAge, "Age")),
-		paddedColumn(msgs.PodKeyNode, "Node", widestValue(rows, msgs.PodKeyNode, "Node")),
-		paddedColumn(msgs.PodKeyNodeIP, "Node IP", widestValue(rows, msgs.PodKeyNodeIP, "Node IP")),
-		paddedColumn(msgs.PodKeyPodIP, "Pod IP", widestValue(rows, msgs.PodKeyPodIP, "Pod IP")),
-	}
-}
-
-func deploymentNarrowColumns() []btable.Column {
-	return []btable.Column{
-		paddedFlexColumn(msgs.DeployKeyName, "Name", 8),
-		paddedFlexColumn(msgs.DeployKeyAge, "Age", 3),
-		paddedFlexColumn(msgs.DeployKeyReplicas, "ReadyReplicas", 4),
-		paddedFlexColumn(msgs.DeployKeyContext, "Context", 5),
+// workloadStatusCellStyle is a btable.StyledCellFunc that colors the sts/ds
+// roll-up Status cell (see k8s.WorkloadHealthy & co.): green when healthy,
+// yellow mid-rollout or scaled away, red when pods are missing or misplaced.
+func workloadStatusCellStyle(input btable.StyledCellFuncInput) lipgloss.Style {
+	t := styles.Mocha()
+	status, _ := input.Data.(string)
+	switch status {
+	case k8s.WorkloadHealthy:
+		return t.Green
+	case k8s.WorkloadUpdating:
+		return t.Yellow
+	case k8s.WorkloadDegraded, k8s.WorkloadMisscheduled:
+		return t.Red
+	case k8s.WorkloadScaledToZero, k8s.WorkloadNoNodes:
+		return t.Overlay1
 	}
+	return t.Plain
 }
 
-func deploymentWideColumns(rows []msgs.RowData) []btable.Column {
-	return []btable.Column{
-		paddedColumn(msgs.DeployKeyName, "Name", widestValue(rows, msgs.DeployKeyName, "Name")),
-		paddedColumn(msgs.DeployKeyAge, "Age", widestValue(rows, msgs.DeployKeyAge, "Age")),
-		paddedColumn(msgs.DeployKeyReplicas, "ReadyReplicas", widestValue(rows, msgs.DeployKeyReplicas, "ReadyReplicas")),
-		paddedColumn(msgs.DeployKeyAvailable, "Available", widestValue(rows, msgs.DeployKeyAvailable, "Available")),
-		paddedColumn(msgs.DeployKeyUpdated, "Updated", widestValue(rows, msgs.DeployKeyUpdated, "Updated")),
-		paddedColumn(msgs.DeployKeyStrategy, "Strategy", widestValue(rows, msgs.DeployKeyStrategy, "Strategy")),
-		paddedColumn(msgs.DeployKeyContext, "Context", widestValue(rows, msgs.DeployKeyContext, "Context")),
-		paddedColumn(msgs.DeployKeySelector, "Selector", widestValue(rows, msgs.DeployKeySelector, "Selector")),
-	}
+// svcNarrowColumnDefs are the svc columns shown past Name outside wide
+// mode.
+var svcNarrowColumnDefs = []columnDef{
+	{key: msgs.SvcKeyNamespace, title: "Namespace"},
+	{key: msgs.SvcKeyType, title: "Type"},
+	{key: msgs.SvcKeyClusterIP, title: "ClusterIP"},
+	{key: msgs.SvcKeyPorts, title: "Ports"},
+	{key: msgs.SvcKeyAge, title: "Age"},
 }
 
-func svcNarrowColumns() []btable.Column {
-	return []btable.Column{
-		paddedFlexColumn(msgs.SvcKeyName, "Name", 28),
-		paddedFlexColumn(msgs.SvcKeyNamespace, "Namespace", 18),
-		paddedFlexColumn(msgs.SvcKeyType, "Type", 14),
-		paddedFlexColumn(msgs.SvcKeyClusterIP, "ClusterIP", 18),
-		paddedFlexColumn(msgs.SvcKeyPorts, "Ports", 12),
-		paddedFlexColumn(msgs.SvcKeyAge, "Age", 10),
-	}
+// svcNarrowColumns lays out the narrow-mode columns after lead for a table
+// width cells wide (see fitWidths).
+func svcNarrowColumns(lead []btable.Column, rows []msgs.RowData, width int) []btable.Column {
+	return fitWidths(lead, columnDef{key: msgs.SvcKeyName, title: "Name"}, svcNarrowColumnDefs, rows, width)
 }
 
 func svcWideColumns(rows []msgs.RowData) []btable.Column {
@@ -352,3 +417,40 @@ func svcWideColumns(rows []msgs.RowData) []btable.Column {
 		paddedColumn(msgs.SvcKeyEndpointIPs, "EndpointIPs", widestValue(rows, msgs.SvcKeyEndpointIPs, "EndpointIPs")),
 	}
 }
+
+// workloadNarrowColumnDefs are the sts/ds columns shown past Name outside
+// wide mode.
+var workloadNarrowColumnDefs = []columnDef{
+	{key: msgs.WorkloadKeyReady, title: "Ready"},
+	{key: msgs.WorkloadKeyStatus, title: "Status"},
+	{key: msgs.WorkloadKeyAge, title: "Age"},
+	{key: msgs.WorkloadKeyContext, title: "Context"},
+}
+
+// workloadNarrowColumns lays out the narrow-mode columns after lead for a
+// table width cells wide (see fitWidths).
+func workloadNarrowColumns(lead []btable.Column, rows []msgs.RowData, width int) []btable.Column {
+	return fitWidths(lead, columnDef{key: msgs.WorkloadKeyName, title: "Name"}, workloadNarrowColumnDefs, rows, width)
+}
+
+// workloadWideColumns adds the rollout detail columns. The Available column
+// is titled per kind: a DaemonSet's available pod count has no StatefulSet
+// equivalent, which reports pods on the current revision instead.
+func workloadWideColumns(rows []msgs.RowData, kind string) []btable.Column {
+	availableTitle := "Available"
+	if kind == "StatefulSet" {
+		availableTitle = "Current"
+	}
+	return []btable.Column{
+		paddedColumn(msgs.WorkloadKeyName, "Name", widestValue(rows, msgs.WorkloadKeyName, "Name")),
+		paddedColumn(msgs.WorkloadKeyNamespace, "Namespace", widestValue(rows, msgs.WorkloadKeyNamespace, "Namespace")),
+		paddedColumn(msgs.WorkloadKeyReady, "Ready", widestValue(rows, msgs.WorkloadKeyReady, "Ready")),
+		paddedColumn(msgs.WorkloadKeyStatus, "Status", widestValue(rows, msgs.WorkloadKeyStatus, "Status")),
+		paddedColumn(msgs.WorkloadKeyUpdated, "Updated", widestValue(rows, msgs.WorkloadKeyUpdated, "Updated")),
+		paddedColumn(msgs.WorkloadKeyAvailable, availableTitle, widestValue(rows, msgs.WorkloadKeyAvailable, availableTitle)),
+		paddedColumn(msgs.WorkloadKeyAge, "Age", widestValue(rows, msgs.WorkloadKeyAge, "Age")),
+		paddedColumn(msgs.WorkloadKeyStrategy, "Strategy", widestValue(rows, msgs.WorkloadKeyStrategy, "Strategy")),
+		paddedColumn(msgs.WorkloadKeyContext, "Context", widestValue(rows, msgs.WorkloadKeyContext, "Context")),
+		paddedColumn(msgs.WorkloadKeySelector, "Selector", widestValue(rows, msgs.WorkloadKeySelector, "Selector")),
+	}
+}
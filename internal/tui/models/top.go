@@ -0,0 +1,409 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// Sort orders for the top tab.
+const (
+	TopSortCPU    = "cpu"
+	TopSortMemory = "memory"
+)
+
+// TopPage is the top tab: pod usage from the metrics API across every
+// selected context, hottest first, like `kubectl top pod`. Enter on a pod
+// expands its per-container breakdown beneath it. Unlike the other tabs it
+// isn't watch-backed — MainPage re-fetches on entry, "r" and the refresh
+// tick — and has no wide mode, since there are no extra columns to show.
+type TopPage struct {
+	keyed
+	table btable.Model
+
+	usage    map[string][]k8s.PodUsage // by context
+	errs     map[string]string         // by context, e.g. no metrics-server
+	pods     []k8s.PodUsage            // every context's usage, sorted
+	sortBy   string
+	expanded map[string]bool // by topPodKey
+	lines    []topLine       // pods (and expanded containers) on display
+
+	cachedView string
+	viewDirty  bool
+	focused    bool
+	tableW     int
+	tableH     int
+
+	// filter is over pod names, as on the other tabs; container lines follow
+	// their pod.
+	filter rowFilter
+
+	// cursorIdx/windowStart/windowSize: see the identical fields on PodPage
+	// in pods.go. cursorIdx indexes t.lines.
+	cursorIdx   int
+	windowStart int
+	windowSize  int
+}
+
+// topLine is one row of the table: a pod, or (container >= 0) one of an
+// expanded pod's containers.
+type topLine struct {
+	pod       int
+	container int
+}
+
+func NewTopPage() *TopPage {
+	return &TopPage{
+		table:      newBubbleTable(topColumns(TopSortCPU)),
+		usage:      make(map[string][]k8s.PodUsage),
+		errs:       make(map[string]string),
+		sortBy:     TopSortCPU,
+		expanded:   make(map[string]bool),
+		viewDirty:  true,
+		windowSize: defaultRowWindowSize,
+	}
+}
+
+func (t *TopPage) Init() tea.Cmd {
+	return nil
+}
+
+func (t *TopPage) Update(msg tea.Msg) tea.Cmd {
+	if !t.focused {
+		return nil
+	}
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	if t.filter.filtering {
+		t.filter.handleKey(t.keyMap(), press, len(t.pods), t.filterMatch)
+		t.rebuild()
+		t.jumpTo(0)
+		return nil
+	}
+	km := t.keyMap()
+	switch {
+	case key.Matches(press, km.Down):
+		t.moveCursor(1)
+	case key.Matches(press, km.Up):
+		t.moveCursor(-1)
+	case key.Matches(press, km.Top):
+		t.jumpTo(0)
+	case key.Matches(press, km.Bottom):
+		t.jumpTo(len(t.lines) - 1)
+	case key.Matches(press, km.Filter):
+		t.filter.filtering = true
+	}
+	return nil
+}
+
+func (t *TopPage) filterMatch(i int) bool {
+	return strings.Contains(strings.ToLower(t.pods[i].Name), strings.ToLower(t.filter.query))
+}
+
+// FilterStatus: see PodPage.FilterStatus in pods.go.
+func (t *TopPage) FilterStatus() (query string, matches int, typing bool, ok bool) {
+	if !t.filter.filtering && t.filter.query == "" {
+		return "", 0, false, false
+	}
+	return t.filter.query, t.filter.len(len(t.pods)), t.filter.filtering, true
+}
+
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (t *TopPage) SetFilter(query string) {
+	t.filter.set(query, len(t.pods), t.filterMatch)
+	t.rebuild()
+	t.jumpTo(0)
+}
+
+// The top tab has no wide columns; these satisfy the interface MainPage
+// drives every resource tab through.
+func (t *TopPage) ToggleWideMode()                            {}
+func (t *TopPage) WideMode() bool                             { return false }
+func (t *TopPage) ScrollLeft()                                {}
+func (t *TopPage) ScrollRight()                               {}
+func (t *TopPage) ScrollStatus() (offset, total int, ok bool) { return 0, 0, false }
+
+// SetUsage replaces one context's usage, clearing any error it had.
+func (t *TopPage) SetUsage(context string, pods []k8s.PodUsage) {
+	t.usage[context] = pods
+	delete(t.errs, context)
+	t.rebuild()
+	t.applySize()
+}
+
+// SetError records why a context's usage couldn't be fetched. Its last good
+// usage, if any, is dropped rather than shown stale.
+func (t *TopPage) SetError(context, err string) {
+	delete(t.usage, context)
+	t.errs[context] = err
+	t.rebuild()
+	t.applySize()
+}
+
+// RemoveContext forgets a deselected context.
+func (t *TopPage) RemoveContext(context string) {
+	delete(t.usage, context)
+	delete(t.errs, context)
+	t.rebuild()
+	t.applySize()
+}
+
+// Len is how many pods have usage, for the status bar.
+func (t *TopPage) Len() int {
+	return len(t.pods)
+}
+
+// ToggleSort flips between sorting by CPU and by memory.
+func (t *TopPage) ToggleSort() {
+	if t.sortBy == TopSortCPU {
+		t.sortBy = TopSortMemory
+	} else {
+		t.sortBy = TopSortCPU
+	}
+	t.table = t.table.WithColumns(topColumns(t.sortBy))
+	t.rebuild()
+}
+
+// ToggleExpand shows or hides the container breakdown of the pod under the
+// cursor (or of the pod owning the container line under it).
+func (t *TopPage) ToggleExpand() {
+	if t.cursorIdx < 0 || t.cursorIdx >= len(t.lines) {
+		return
+	}
+	line := t.lines[t.cursorIdx]
+	key := topPodKey(t.pods[line.pod])
+	t.expanded[key] = !t.expanded[key]
+	t.rebuild()
+	// Keep the cursor on the pod itself, which collapsing may have moved.
+	for i, l := range t.lines {
+		if l.pod == line.pod && l.container < 0 {
+			t.cursorIdx = i
+			break
+		}
+	}
+	t.windowStart = computeWindowStart(t.windowStart, t.cursorIdx, len(t.lines), t.windowSize)
+	t.pushDisplayRows()
+}
+
+func topPodKey(p k8s.PodUsage) string {
+	return p.Context + "/" + p.Namespace + "/" + p.Name
+}
+
+// rebuild re-sorts every context's usage and re-flattens it into lines,
+// keeping the cursor on the same pod where it can.
+func (t *TopPage) rebuild() {
+	var selected string
+	if t.cursorIdx >= 0 && t.cursorIdx < len(t.lines) {
+		selected = topPodKey(t.pods[t.lines[t.cursorIdx].pod])
+	}
+
+	t.pods = t.pods[:0]
+	for _, ctx := range slices.Sorted(maps.Keys(t.usage)) {
+		t.pods = append(t.pods, t.usage[ctx]...)
+	}
+	sort.SliceStable(t.pods, func(i, j int) bool {
+		a, b := t.pods[i], t.pods[j]
+		if t.sortBy == TopSortMemory && a.MemoryBytes != b.MemoryBytes {
+			return a.MemoryBytes > b.MemoryBytes
+		}
+		if a.CPUMilli != b.CPUMilli {
+			return a.CPUMilli > b.CPUMilli
+		}
+		if a.MemoryBytes != b.MemoryBytes {
+			return a.MemoryBytes > b.MemoryBytes
+		}
+		return a.Name < b.Name
+	})
+	t.filter.recompute(len(t.pods), t.filterMatch)
+
+	t.lines = t.lines[:0]
+	for pos := 0; pos < t.filter.len(len(t.pods)); pos++ {
+		i := t.filter.absolute(pos)
+		if selected != "" && topPodKey(t.pods[i]) == selected {
+			t.cursorIdx = len(t.lines)
+		}
+		t.lines = append(t.lines, topLine{pod: i, container: -1})
+		if t.expanded[topPodKey(t.pods[i])] {
+			for c := range t.pods[i].Containers {
+				t.lines = append(t.lines, topLine{pod: i, container: c})
+			}
+		}
+	}
+	if t.cursorIdx >= len(t.lines) {
+		t.cursorIdx = max(len(t.lines)-1, 0)
+	}
+	t.windowStart = computeWindowStart(t.windowStart, t.cursorIdx, len(t.lines), t.windowSize)
+	t.pushDisplayRows()
+}
+
+// moveCursor: see PodPage.moveCursor in pods.go.
+func (t *TopPage) moveCursor(delta int) {
+	total := len(t.lines)
+	if total == 0 {
+		return
+	}
+	t.cursorIdx += delta
+	if t.cursorIdx < 0 {
+		t.cursorIdx = total - 1
+	} else if t.cursorIdx >= total {
+		t.cursorIdx = 0
+	}
+	t.windowStart = computeWindowStart(t.windowStart, t.cursorIdx, total, t.windowSize)
+	t.pushDisplayRows()
+}
+
+// jumpTo: see PodPage.jumpTo in pods.go.
+func (t *TopPage) jumpTo(idx int) {
+	total := len(t.lines)
+	if total == 0 {
+		return
+	}
+	t.cursorIdx = max(0, min(idx, total-1))
+	t.windowStart = computeWindowStart(t.windowStart, t.cursorIdx, total, t.windowSize)
+	t.pushDisplayRows()
+}
+
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (t *TopPage) ClickRow(y int) bool {
+	y -= len(t.errs) // the error lines View puts above the table
+	pos, ok := rowAtLine(y, t.windowStart, len(t.lines))
+	if ok {
+		t.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (t *TopPage) ScrollRows(delta int) {
+	t.jumpTo(t.cursorIdx + delta)
+}
+
+func (t *TopPage) pushDisplayRows() {
+	start, end := windowBounds(t.windowStart, len(t.lines), t.windowSize)
+	display := make([]btable.Row, 0, end-start)
+	for i := start; i < end; i++ {
+		line := t.lines[i]
+		pod := t.pods[line.pod]
+		if line.container < 0 {
+			marker := "▸ "
+			if t.expanded[topPodKey(pod)] {
+				marker = "▾ "
+			}
+			display = append(display, btable.NewRow(btable.RowData{
+				msgs.TopKeyName:      marker + pod.Name,
+				msgs.TopKeyNamespace: pod.Namespace,
+				msgs.TopKeyCPU:       formatCPU(pod.CPUMilli),
+				msgs.TopKeyMemory:    formatMemory(pod.MemoryBytes),
+				msgs.TopKeyContext:   pod.Context,
+			}))
+			continue
+		}
+		ctr := pod.Containers[line.container]
+		dim := styles.Mocha().Overlay1
+		display = append(display, btable.NewRow(btable.RowData{
+			msgs.TopKeyName:   "    └ " + ctr.Name,
+			msgs.TopKeyCPU:    formatCPU(ctr.CPUMilli),
+			msgs.TopKeyMemory: formatMemory(ctr.MemoryBytes),
+		}).WithStyle(dim))
+	}
+	t.table = t.table.WithRows(display).WithHighlightedRow(t.cursorIdx - start)
+	t.invalidateView()
+}
+
+// formatCPU renders millicores as kubectl top does: "250m".
+func formatCPU(milli int64) string {
+	return fmt.Sprintf("%dm", milli)
+}
+
+// formatMemory renders bytes as kubectl top does: "128Mi".
+func formatMemory(b int64) string {
+	return fmt.Sprintf("%dMi", b/(1024*1024))
+}
+
+func (t *TopPage) View() string {
+	if t.cachedView != "" && !t.viewDirty {
+		return t.cachedView
+	}
+
+	view := t.table.View()
+	if len(t.errs) > 0 {
+		errStyle := styles.Mocha().Red
+		var lines []string
+		for _, ctx := range slices.Sorted(maps.Keys(t.errs)) {
+			lines = append(lines, errStyle.Render(fmt.Sprintf("⚠ %s: %s", ctx, t.errs[ctx])))
+		}
+		view = strings.Join(lines, "\n") + "\n" + view
+	}
+	t.cachedView = view
+	t.viewDirty = false
+	return view
+}
+
+func (t *TopPage) SetFocused(f bool) {
+	t.focused = f
+	t.table = t.table.Focused(f)
+	t.invalidateView()
+}
+
+func (t *TopPage) SetSize(width, h int) {
+	if width < 10 || h < 1 {
+		return
+	}
+	t.tableW, t.tableH = width, h
+	t.applySize()
+}
+
+// applySize rebuilds the table for the current size, less one line per
+// context error shown above it.
+func (t *TopPage) applySize() {
+	if t.tableW == 0 {
+		return
+	}
+	h := max(3, t.tableH-len(t.errs))
+
+	st := styles.CatppuccinBubbleTableStyle()
+	t.table = newBubbleTable(topColumns(t.sortBy)).
+		WithMinimumHeight(h).
+		WithTargetWidth(t.tableW).
+		WithMaxTotalWidth(t.tableW).
+		HeaderStyle(st.Header).
+		HighlightStyle(st.Highlight).
+		WithBaseStyle(st.Base).
+		Focused(t.focused)
+	t.windowSize = rowWindowSizeFor(h)
+	t.windowStart = computeWindowStart(t.windowStart, t.cursorIdx, len(t.lines), t.windowSize)
+	t.pushDisplayRows()
+}
+
+func (t *TopPage) invalidateView() {
+	t.viewDirty = true
+	t.cachedView = ""
+}
+
+// topColumns marks the sorted-by column's title.
+func topColumns(sortBy string) []btable.Column {
+	cpu, mem := "CPU", "Memory"
+	if sortBy == TopSortMemory {
+		mem += " ▾"
+	} else {
+		cpu += " ▾"
+	}
+	return []btable.Column{
+		paddedFlexColumn(msgs.TopKeyName, "Name", 10),
+		paddedFlexColumn(msgs.TopKeyNamespace, "Namespace", 5),
+		paddedFlexColumn(msgs.TopKeyCPU, cpu, 3),
+		paddedFlexColumn(msgs.TopKeyMemory, mem, 3),
+		paddedFlexColumn(msgs.TopKeyContext, "Context", 5),
+	}
+}
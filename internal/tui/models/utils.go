@@ -1,6 +1,10 @@
 package models
 
-import "github.com/ktails/ktails/internal/tui/msgs"
+import (
+	"fmt"
+
+	"github.com/ktails/ktails/internal/tui/msgs"
+)
 
 // Helper functions (shared with deployment.go - consider moving to shared utils)
 func rowsEqual(a, b []msgs.RowData) bool {
@@ -56,3 +60,18 @@ func cloneRows(rows []msgs.RowData) []msgs.RowData {
 
 	return cloned
 }
+
+// HumanBytes formats a byte count the way ls -h does: "512", "4.0K",
+// "1.2M".
+func HumanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,342 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// WatchlistPage is the watch tab: the pods and deployments pinned to the
+// watchlist, a row each in the order they were pinned, with their live
+// status in whichever context they're in, loaded there or not. Like the
+// ing tab it isn't watch-backed — MainPage re-fetches each on entry, "r"
+// and the refresh tick — and has no wide mode.
+type WatchlistPage struct {
+	keyed
+	table btable.Model
+
+	entries []k8s.WatchStatus
+	errs    map[string]string // by watchKey, e.g. forbidden
+
+	cachedView string
+	viewDirty  bool
+	focused    bool
+	tableW     int
+	tableH     int
+
+	// filter is over names and contexts.
+	filter rowFilter
+
+	// cursorIdx/windowStart/windowSize: see the identical fields on PodPage
+	// in pods.go. cursorIdx is a position in the filter's index space.
+	cursorIdx   int
+	windowStart int
+	windowSize  int
+}
+
+func NewWatchlistPage() *WatchlistPage {
+	return &WatchlistPage{
+		table:      newBubbleTable(watchColumns()),
+		errs:       make(map[string]string),
+		viewDirty:  true,
+		windowSize: defaultRowWindowSize,
+	}
+}
+
+// watchKey identifies a watchlist entry.
+func watchKey(kind, context, namespace, name string) string {
+	return kind + "/" + context + "/" + namespace + "/" + name
+}
+
+func (w *WatchlistPage) Init() tea.Cmd {
+	return nil
+}
+
+func (w *WatchlistPage) Update(msg tea.Msg) tea.Cmd {
+	if !w.focused {
+		return nil
+	}
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	if w.filter.filtering {
+		w.filter.handleKey(w.keyMap(), press, len(w.entries), w.filterMatch)
+		w.jumpTo(0)
+		return nil
+	}
+	km := w.keyMap()
+	switch {
+	case key.Matches(press, km.Down):
+		w.moveCursor(1)
+	case key.Matches(press, km.Up):
+		w.moveCursor(-1)
+	case key.Matches(press, km.Top):
+		w.jumpTo(0)
+	case key.Matches(press, km.Bottom):
+		w.jumpTo(w.filter.len(len(w.entries)) - 1)
+	case key.Matches(press, km.Filter):
+		w.filter.filtering = true
+	}
+	return nil
+}
+
+func (w *WatchlistPage) filterMatch(i int) bool {
+	q := strings.ToLower(w.filter.query)
+	e := w.entries[i]
+	return strings.Contains(strings.ToLower(e.Name), q) || strings.Contains(strings.ToLower(e.Context), q)
+}
+
+// FilterStatus: see PodPage.FilterStatus in pods.go.
+func (w *WatchlistPage) FilterStatus() (query string, matches int, typing bool, ok bool) {
+	if !w.filter.filtering && w.filter.query == "" {
+		return "", 0, false, false
+	}
+	return w.filter.query, w.filter.len(len(w.entries)), w.filter.filtering, true
+}
+
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (w *WatchlistPage) SetFilter(query string) {
+	w.filter.set(query, len(w.entries), w.filterMatch)
+	w.jumpTo(0)
+}
+
+// The watch tab has no wide columns; these satisfy the interface MainPage
+// drives every resource tab through.
+func (w *WatchlistPage) ToggleWideMode()                            {}
+func (w *WatchlistPage) WideMode() bool                             { return false }
+func (w *WatchlistPage) ScrollLeft()                                {}
+func (w *WatchlistPage) ScrollRight()                               {}
+func (w *WatchlistPage) ScrollStatus() (offset, total int, ok bool) { return 0, 0, false }
+
+// SetWatchlist replaces what's watched with items, of which only the kind,
+// context, namespace and name are read. Items already listed keep their
+// last status until the next fetch; new ones show as loading.
+func (w *WatchlistPage) SetWatchlist(items []k8s.WatchStatus) {
+	known := make(map[string]k8s.WatchStatus, len(w.entries))
+	for _, e := range w.entries {
+		known[watchKey(e.Kind, e.Context, e.Namespace, e.Name)] = e
+	}
+	var selected string
+	if e, ok := w.Selected(); ok {
+		selected = watchKey(e.Kind, e.Context, e.Namespace, e.Name)
+	}
+
+	entries := make([]k8s.WatchStatus, 0, len(items))
+	errs := make(map[string]string)
+	for _, item := range items {
+		key := watchKey(item.Kind, item.Context, item.Namespace, item.Name)
+		e, ok := known[key]
+		if !ok {
+			e = k8s.WatchStatus{Kind: item.Kind, Context: item.Context, Namespace: item.Namespace, Name: item.Name, Status: "…", Ready: "…", Age: "…"}
+		}
+		if err, ok := w.errs[key]; ok {
+			errs[key] = err
+		}
+		entries = append(entries, e)
+	}
+	w.entries, w.errs = entries, errs
+	w.filter.recompute(len(w.entries), w.filterMatch)
+	w.cursorIdx = 0
+	for pos := range w.filter.len(len(w.entries)) {
+		e := w.entries[w.filter.absolute(pos)]
+		if watchKey(e.Kind, e.Context, e.Namespace, e.Name) == selected {
+			w.cursorIdx = pos
+			break
+		}
+	}
+	w.applySize()
+}
+
+// SetStatus records a fetched status on its entry, clearing any error it
+// had; a status for something no longer watched is dropped.
+func (w *WatchlistPage) SetStatus(s k8s.WatchStatus) {
+	key := watchKey(s.Kind, s.Context, s.Namespace, s.Name)
+	for i, e := range w.entries {
+		if watchKey(e.Kind, e.Context, e.Namespace, e.Name) == key {
+			w.entries[i] = s
+			delete(w.errs, key)
+			w.pushDisplayRows()
+			return
+		}
+	}
+}
+
+// SetError records why an entry's status couldn't be fetched, in place of
+// its status.
+func (w *WatchlistPage) SetError(kind, context, namespace, name, err string) {
+	key := watchKey(kind, context, namespace, name)
+	for _, e := range w.entries {
+		if watchKey(e.Kind, e.Context, e.Namespace, e.Name) == key {
+			w.errs[key] = err
+			w.pushDisplayRows()
+			return
+		}
+	}
+}
+
+// Selected returns the entry under the cursor.
+func (w *WatchlistPage) Selected() (k8s.WatchStatus, bool) {
+	total := w.filter.len(len(w.entries))
+	if w.cursorIdx < 0 || w.cursorIdx >= total {
+		return k8s.WatchStatus{}, false
+	}
+	return w.entries[w.filter.absolute(w.cursorIdx)], true
+}
+
+// Len is how many entries are listed, for the status bar.
+func (w *WatchlistPage) Len() int {
+	return len(w.entries)
+}
+
+// moveCursor: see PodPage.moveCursor in pods.go.
+func (w *WatchlistPage) moveCursor(delta int) {
+	total := w.filter.len(len(w.entries))
+	if total == 0 {
+		return
+	}
+	w.cursorIdx += delta
+	if w.cursorIdx < 0 {
+		w.cursorIdx = total - 1
+	} else if w.cursorIdx >= total {
+		w.cursorIdx = 0
+	}
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, total, w.windowSize)
+	w.pushDisplayRows()
+}
+
+// jumpTo: see PodPage.jumpTo in pods.go.
+func (w *WatchlistPage) jumpTo(idx int) {
+	total := w.filter.len(len(w.entries))
+	if total == 0 {
+		w.cursorIdx = 0
+		w.pushDisplayRows()
+		return
+	}
+	w.cursorIdx = max(0, min(idx, total-1))
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, total, w.windowSize)
+	w.pushDisplayRows()
+}
+
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (w *WatchlistPage) ClickRow(y int) bool {
+	pos, ok := rowAtLine(y, w.windowStart, w.filter.len(len(w.entries)))
+	if ok {
+		w.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (w *WatchlistPage) ScrollRows(delta int) {
+	w.jumpTo(w.cursorIdx + delta)
+}
+
+func (w *WatchlistPage) pushDisplayRows() {
+	t := styles.Mocha()
+	start, end := windowBounds(w.windowStart, w.filter.len(len(w.entries)), w.windowSize)
+	display := make([]btable.Row, 0, end-start)
+	for pos := start; pos < end; pos++ {
+		e := w.entries[w.filter.absolute(pos)]
+		status := btable.NewStyledCell(e.Status, t.Yellow)
+		switch e.Health {
+		case k8s.WatchHealthy:
+			status = btable.NewStyledCell(e.Status, t.Green)
+		case k8s.WatchFailing:
+			status = btable.NewStyledCell(e.Status, t.Red.Bold(true))
+		case k8s.WatchMissing:
+			status = btable.NewStyledCell(e.Status, t.Overlay1)
+		}
+		if err, ok := w.errs[watchKey(e.Kind, e.Context, e.Namespace, e.Name)]; ok {
+			status = btable.NewStyledCell("⚠ "+err, t.Red)
+		}
+		restarts := "…"
+		if e.Status != "…" {
+			restarts = strconv.Itoa(int(e.Restarts))
+		}
+		display = append(display, btable.NewRow(btable.RowData{
+			msgs.WatchKeyKind:      e.Kind,
+			msgs.WatchKeyName:      e.Name,
+			msgs.WatchKeyNamespace: e.Namespace,
+			msgs.WatchKeyStatus:    status,
+			msgs.WatchKeyReady:     e.Ready,
+			msgs.WatchKeyRestarts:  restarts,
+			msgs.WatchKeyAge:       e.Age,
+			msgs.WatchKeyContext:   e.Context,
+		}))
+	}
+	w.table = w.table.WithRows(display).WithHighlightedRow(w.cursorIdx - start)
+	w.invalidateView()
+}
+
+func (w *WatchlistPage) View() string {
+	if w.cachedView != "" && !w.viewDirty {
+		return w.cachedView
+	}
+	view := w.table.View()
+	if len(w.entries) == 0 {
+		view = styles.Mocha().Overlay1.Render("Nothing watched yet · w on a Pods or Deployments row pins it here, in any context")
+	}
+	w.cachedView = view
+	w.viewDirty = false
+	return view
+}
+
+func (w *WatchlistPage) SetFocused(f bool) {
+	w.focused = f
+	w.table = w.table.Focused(f)
+	w.invalidateView()
+}
+
+func (w *WatchlistPage) SetSize(width, h int) {
+	if width < 10 || h < 1 {
+		return
+	}
+	w.tableW, w.tableH = width, h
+	w.applySize()
+}
+
+// applySize: see TopPage.applySize in top.go.
+func (w *WatchlistPage) applySize() {
+	if w.tableW == 0 {
+		return
+	}
+	h := max(3, w.tableH)
+
+	st := styles.CatppuccinBubbleTableStyle()
+	w.table = newBubbleTable(watchColumns()).
+		WithMinimumHeight(h).
+		WithTargetWidth(w.tableW).
+		WithMaxTotalWidth(w.tableW).
+		HeaderStyle(st.Header).
+		HighlightStyle(st.Highlight).
+		WithBaseStyle(st.Base).
+		Focused(w.focused)
+	w.windowSize = rowWindowSizeFor(h)
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, w.filter.len(len(w.entries)), w.windowSize)
+	w.pushDisplayRows()
+}
+
+func (w *WatchlistPage) invalidateView() {
+	w.viewDirty = true
+	w.cachedView = ""
+}
+
+func watchColumns() []btable.Column {
+	return []btable.Column{
+		paddedFlexColumn(msgs.WatchKeyKind, "Kind", 3),
+		paddedFlexColumn(msgs.WatchKeyName, "Name", 8),
+		paddedFlexColumn(msgs.WatchKeyNamespace, "Namespace", 5),
+		paddedFlexColumn(msgs.WatchKeyStatus, "Status", 6),
+		paddedFlexColumn(msgs.WatchKeyReady, "Ready", 3),
+		paddedFlexColumn(msgs.WatchKeyRestarts, "Restarts", 3),
+		paddedFlexColumn(msgs.WatchKeyAge, "Age", 3),
+		paddedFlexColumn(msgs.WatchKeyContext, "Context", 5),
+	}
+}
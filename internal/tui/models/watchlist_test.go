@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/ktails/ktails/internal/k8s"
+)
+
+func TestWatchlistPage_KeepsStatusesAndCursorAcrossEdits(t *testing.T) {
+	w := NewWatchlistPage()
+	w.SetSize(80, 20)
+	w.SetFocused(true)
+	api := k8s.WatchStatus{Kind: k8s.WatchDeployment, Context: "prod", Namespace: "shop", Name: "api"}
+	worker := k8s.WatchStatus{Kind: k8s.WatchPod, Context: "staging", Namespace: "shop", Name: "worker-0"}
+	w.SetWatchlist([]k8s.WatchStatus{api, worker})
+
+	live := api
+	live.Status, live.Health, live.Restarts = "Available", k8s.WatchHealthy, 2
+	w.SetStatus(live)
+	w.SetError(worker.Kind, worker.Context, worker.Namespace, worker.Name, "forbidden")
+	w.moveCursor(1)
+
+	// Pinning another keeps what's been fetched, the error and the cursor.
+	cart := k8s.WatchStatus{Kind: k8s.WatchPod, Context: "prod", Namespace: "shop", Name: "cart-1"}
+	w.SetWatchlist([]k8s.WatchStatus{cart, api, worker})
+	if got, ok := w.Selected(); !ok || got.Name != "worker-0" {
+		t.Errorf("cursor on %q after pinning, want worker-0 still", got.Name)
+	}
+	if w.entries[0].Status != "…" {
+		t.Errorf("new entry's status = %q, want it loading", w.entries[0].Status)
+	}
+	if w.entries[1].Status != "Available" || w.entries[1].Restarts != 2 {
+		t.Errorf("api's status = %+v, want the fetched one kept", w.entries[1])
+	}
+	if w.errs[watchKey(worker.Kind, worker.Context, worker.Namespace, worker.Name)] != "forbidden" {
+		t.Error("worker-0's error was dropped on pinning another")
+	}
+
+	// Unpinning drops it; a late status for it isn't listed again.
+	w.SetWatchlist([]k8s.WatchStatus{cart, api})
+	w.SetStatus(k8s.WatchStatus{Kind: worker.Kind, Context: worker.Context, Namespace: worker.Namespace, Name: worker.Name, Status: "Running"})
+	if w.Len() != 2 {
+		t.Errorf("Len = %d after unpinning worker-0, want 2", w.Len())
+	}
+	if len(w.errs) != 0 {
+		t.Errorf("errs = %v, want worker-0's gone with it", w.errs)
+	}
+
+	w.SetFilter("staging")
+	if _, matches, _, _ := w.FilterStatus(); matches != 0 {
+		t.Errorf("filter on staging matched %d, want none left", matches)
+	}
+	w.SetFilter("prod")
+	if _, matches, _, _ := w.FilterStatus(); matches != 2 {
+		t.Errorf("filter on prod matched %d, want 2", matches)
+	}
+}
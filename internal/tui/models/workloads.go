@@ -0,0 +1,372 @@
+package models
+
+import (
+	"image/color"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	btable "github.com/evertras/bubble-table/table"
+	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/tui/msgs"
+	"github.com/ktails/ktails/internal/tui/styles"
+)
+
+// WorkloadPage is the table behind both the sts and ds tabs: StatefulSets
+// and DaemonSets share a column layout (see msgs.WorkloadKey*), so one model
+// serves both, told apart only by kind. Otherwise it mirrors DeploymentPage.
+type WorkloadPage struct {
+	keyed
+	Client *k8s.Client
+	table  btable.Model
+	kind   string // "StatefulSet" or "DaemonSet"
+
+	rows       []msgs.RowData
+	rowsSet    bool
+	cachedView string
+	viewDirty  bool
+	focused    bool
+
+	wideMode     bool
+	tableW       int
+	tableH       int
+	wideColCount int
+	scrollable   bool
+
+	// accents: the per-context bar leading each row (see rowAccents).
+	accents rowAccents
+
+	// filter is a k9s-style "/" filter over the Name column — see rowFilter
+	// in table.go for why this exists instead of bubble-table's own filter.
+	filter rowFilter
+
+	// cursorIdx/windowStart/windowSize: see the identical fields on PodPage
+	// in pods.go. cursorIdx is a position in the active index space (see
+	// activeLen/activeRow), not a raw index into w.rows.
+	cursorIdx   int
+	windowStart int
+	windowSize  int
+}
+
+// NewStatefulSetPage builds the sts tab's table.
+func NewStatefulSetPage(client *k8s.Client) *WorkloadPage {
+	return newWorkloadPage(client, "StatefulSet")
+}
+
+// NewDaemonSetPage builds the ds tab's table.
+func NewDaemonSetPage(client *k8s.Client) *WorkloadPage {
+	return newWorkloadPage(client, "DaemonSet")
+}
+
+func newWorkloadPage(client *k8s.Client, kind string) *WorkloadPage {
+	return &WorkloadPage{
+		Client:     client,
+		kind:       kind,
+		table:      newBubbleTable(workloadNarrowColumns(nil, nil, 0)),
+		viewDirty:  true,
+		windowSize: defaultRowWindowSize,
+	}
+}
+
+func (w *WorkloadPage) Init() tea.Cmd {
+	return nil
+}
+
+func (w *WorkloadPage) Update(msg tea.Msg) tea.Cmd {
+	if w.focused {
+		if press, ok := msg.(tea.KeyPressMsg); ok {
+			if w.filter.filtering {
+				w.filter.handleKey(w.keyMap(), press, len(w.rows), w.filterMatch)
+				w.afterFilterChange()
+				return nil
+			}
+			km := w.keyMap()
+			switch {
+			case key.Matches(press, km.Down):
+				w.moveCursor(1)
+				return nil
+			case key.Matches(press, km.Up):
+				w.moveCursor(-1)
+				return nil
+			case key.Matches(press, km.Top):
+				w.jumpTo(0)
+				return nil
+			case key.Matches(press, km.Bottom):
+				w.jumpTo(w.activeLen() - 1)
+				return nil
+			case key.Matches(press, km.Filter):
+				w.filter.filtering = true
+				return nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	w.table, cmd = w.table.Update(msg)
+	w.invalidateView()
+	return cmd
+}
+
+// filterMatch: see DeploymentPage.filterMatch in deployment.go.
+func (w *WorkloadPage) filterMatch(i int) bool {
+	name, _ := w.rows[i][msgs.WorkloadKeyName].(string)
+	return strings.Contains(strings.ToLower(name), strings.ToLower(w.filter.query))
+}
+
+// afterFilterChange: see PodPage.afterFilterChange in pods.go.
+func (w *WorkloadPage) afterFilterChange() {
+	w.cursorIdx = 0
+	w.windowStart = computeWindowStart(0, w.cursorIdx, w.activeLen(), w.windowSize)
+	w.pushDisplayRows()
+	w.invalidateView()
+}
+
+// activeLen/activeRow: see PodPage in pods.go.
+func (w *WorkloadPage) activeLen() int {
+	return w.filter.len(len(w.rows))
+}
+
+func (w *WorkloadPage) activeRow(pos int) msgs.RowData {
+	return w.rows[w.filter.absolute(pos)]
+}
+
+// FilterStatus: see PodPage.FilterStatus in pods.go.
+func (w *WorkloadPage) FilterStatus() (query string, matches int, typing bool, ok bool) {
+	if !w.filter.filtering && w.filter.query == "" {
+		return "", 0, false, false
+	}
+	return w.filter.query, w.activeLen(), w.filter.filtering, true
+}
+
+// SetFilter: see PodPage.SetFilter in pods.go.
+func (w *WorkloadPage) SetFilter(query string) {
+	w.filter.set(query, len(w.rows), w.filterMatch)
+	w.afterFilterChange()
+}
+
+// moveCursor: see PodPage.moveCursor in pods.go.
+func (w *WorkloadPage) moveCursor(delta int) {
+	total := w.activeLen()
+	if total == 0 {
+		return
+	}
+
+	w.cursorIdx += delta
+	if w.cursorIdx < 0 {
+		w.cursorIdx = total - 1
+	} else if w.cursorIdx >= total {
+		w.cursorIdx = 0
+	}
+
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, total, w.windowSize)
+	w.pushDisplayRows()
+	w.invalidateView()
+}
+
+// jumpTo: see PodPage.jumpTo in pods.go.
+func (w *WorkloadPage) jumpTo(idx int) {
+	total := w.activeLen()
+	if total == 0 {
+		return
+	}
+	if idx < 0 {
+		idx = 0
+	} else if idx >= total {
+		idx = total - 1
+	}
+
+	w.cursorIdx = idx
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, total, w.windowSize)
+	w.pushDisplayRows()
+	w.invalidateView()
+}
+
+// ClickRow: see PodPage.ClickRow in pods.go.
+func (w *WorkloadPage) ClickRow(y int) bool {
+	pos, ok := rowAtLine(y, w.windowStart, w.activeLen())
+	if ok {
+		w.jumpTo(pos)
+	}
+	return ok
+}
+
+// ScrollRows: see PodPage.ScrollRows in pods.go.
+func (w *WorkloadPage) ScrollRows(delta int) {
+	w.jumpTo(w.cursorIdx + delta)
+}
+
+func (w *WorkloadPage) SetRows(rows []msgs.RowData) {
+	if w.rowsSet && rowsEqual(rows, w.rows) {
+		return
+	}
+
+	w.rows = cloneRows(rows)
+	w.rowsSet = true
+	w.filter.recompute(len(w.rows), w.filterMatch)
+	if w.cursorIdx >= w.activeLen() {
+		w.cursorIdx = max(w.activeLen()-1, 0)
+	}
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, w.activeLen(), w.windowSize)
+	w.applyColumns()
+	w.pushDisplayRows()
+	if w.focused {
+		w.table = w.table.Focused(true)
+	} else {
+		w.table = w.table.Focused(false)
+	}
+	w.invalidateView()
+}
+
+// pushDisplayRows: see DeploymentPage.pushDisplayRows in deployment.go.
+// Ready is colored like a deployment's replica cell, Status by roll-up.
+func (w *WorkloadPage) pushDisplayRows() {
+	total := w.activeLen()
+	start, end := windowBounds(w.windowStart, total, w.windowSize)
+	display := make([]btable.Row, 0, end-start)
+	for i := start; i < end; i++ {
+		row := w.activeRow(i)
+		display = append(display, btable.NewRow(btable.RowData{
+			msgs.WorkloadKeyName:      row[msgs.WorkloadKeyName],
+			msgs.WorkloadKeyNamespace: row[msgs.WorkloadKeyNamespace],
+			msgs.WorkloadKeyReady:     btable.NewStyledCellWithStyleFunc(row[msgs.WorkloadKeyReady], replicaCellStyle),
+			msgs.WorkloadKeyStatus:    btable.NewStyledCellWithStyleFunc(row[msgs.WorkloadKeyStatus], workloadStatusCellStyle),
+			msgs.WorkloadKeyAge:       row[msgs.WorkloadKeyAge],
+			msgs.WorkloadKeyContext:   row[msgs.WorkloadKeyContext],
+			accentKey:                 w.accents.cell(row[msgs.WorkloadKeyContext]),
+			msgs.WorkloadKeyUpdated:   row[msgs.WorkloadKeyUpdated],
+			msgs.WorkloadKeyAvailable: row[msgs.WorkloadKeyAvailable],
+			msgs.WorkloadKeyStrategy:  row[msgs.WorkloadKeyStrategy],
+			msgs.WorkloadKeySelector:  row[msgs.WorkloadKeySelector],
+		}))
+	}
+	w.table = w.table.WithRows(display).WithHighlightedRow(w.cursorIdx - start)
+}
+
+// applyColumns rebuilds the column set for the current mode (narrow/wide),
+// auto-fitting wide-mode widths to w.rows — called on every SetRows/ToggleWideMode.
+func (w *WorkloadPage) applyColumns() {
+	var cols []btable.Column
+	if w.wideMode {
+		cols = w.accents.withAccent(workloadWideColumns(w.rows, w.kind))
+	} else {
+		cols = w.narrowColumns()
+	}
+	w.wideColCount = len(cols)
+	w.scrollable = w.wideMode && totalColumnsWidth(cols) > w.tableW
+	w.table = w.table.WithColumns(cols)
+	// See PodPage.applyColumns: WithTargetWidth must be cleared in wide mode
+	// or bubble-table forces totalWidth to it, silently disabling scroll.
+	if w.wideMode {
+		w.table = w.table.WithTargetWidth(0).WithMaxTotalWidth(w.tableW)
+	} else {
+		w.table = w.table.WithTargetWidth(w.tableW).WithMaxTotalWidth(w.tableW)
+	}
+}
+
+// narrowColumns lays out the narrow-mode columns for the table's width,
+// after the accent bar when there is one.
+func (w *WorkloadPage) narrowColumns() []btable.Column {
+	return workloadNarrowColumns(w.accents.columns(), w.rows, w.tableW)
+}
+
+// SetAccents colors each row's leading bar by its context; see
+// PodPage.SetAccents.
+func (w *WorkloadPage) SetAccents(accents map[string]color.Color) {
+	w.accents = newRowAccents(accents)
+	w.applyColumns()
+	w.pushDisplayRows()
+	w.invalidateView()
+}
+
+// ToggleWideMode flips wide mode for this tab (sticky until the next
+// resize) and rebuilds columns to fit the current data.
+func (w *WorkloadPage) ToggleWideMode() {
+	w.wideMode = !w.wideMode
+	w.applyColumns()
+	w.pushDisplayRows()
+	w.invalidateView()
+}
+
+func (w *WorkloadPage) WideMode() bool {
+	return w.wideMode
+}
+
+// ScrollStatus reports the current horizontal scroll position for the
+// status bar's "◂ col N/M ▸" indicator. ok is false when the indicator
+// should be hidden.
+func (w *WorkloadPage) ScrollStatus() (offset, total int, ok bool) {
+	if !w.wideMode || !w.scrollable {
+		return 0, 0, false
+	}
+	return w.table.GetHorizontalScrollColumnOffset() + 1, w.wideColCount, true
+}
+
+func (w *WorkloadPage) ScrollLeft() {
+	w.table = w.table.ScrollLeft()
+	w.invalidateView()
+}
+
+func (w *WorkloadPage) ScrollRight() {
+	w.table = w.table.ScrollRight()
+	w.invalidateView()
+}
+
+func (w *WorkloadPage) View() string {
+	if w.cachedView != "" && !w.viewDirty {
+		return w.cachedView
+	}
+
+	view := w.table.View()
+	w.cachedView = view
+	w.viewDirty = false
+	return view
+}
+
+// SelectedRow returns the raw (un-prefixed) row currently under the cursor,
+// or nil if there are no rows.
+func (w *WorkloadPage) SelectedRow() msgs.RowData {
+	if w.cursorIdx < 0 || w.cursorIdx >= w.activeLen() {
+		return nil
+	}
+	return w.activeRow(w.cursorIdx)
+}
+
+// Kind returns "StatefulSet" or "DaemonSet".
+func (w *WorkloadPage) Kind() string {
+	return w.kind
+}
+
+func (w *WorkloadPage) SetFocused(f bool) {
+	w.focused = f
+	w.table = w.table.Focused(f)
+	w.invalidateView()
+}
+
+func (w *WorkloadPage) SetSize(width, h int) {
+	if width < 10 || h < 1 {
+		return
+	}
+	w.tableW, w.tableH = width, h
+	w.wideMode = false
+
+	st := styles.CatppuccinBubbleTableStyle()
+	w.table = newBubbleTable(w.narrowColumns()).
+		WithMinimumHeight(h).
+		WithTargetWidth(width).
+		WithMaxTotalWidth(width).
+		HeaderStyle(st.Header).
+		HighlightStyle(st.Highlight).
+		WithBaseStyle(st.Base).
+		Focused(w.focused)
+	w.wideColCount = len(w.narrowColumns())
+	w.scrollable = false
+	w.windowSize = rowWindowSizeFor(h)
+	w.windowStart = computeWindowStart(w.windowStart, w.cursorIdx, w.activeLen(), w.windowSize)
+	w.pushDisplayRows()
+	w.invalidateView()
+}
+
+func (w *WorkloadPage) invalidateView() {
+	w.viewDirty = true
+	w.cachedView = ""
+}
@@ -3,10 +3,13 @@ package msgs
 
 import (
 	"io"
+	"time"
 
 	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/ktails/ktails/internal/config"
 	"github.com/ktails/ktails/internal/k8s"
+	"github.com/ktails/ktails/internal/metrics"
 )
 
 // RowData is a keyed row of field values for the Pods/Deployments/svc
@@ -28,7 +31,17 @@ const (
 	PodKeyNode       = "node"       // wide mode only
 	PodKeyNodeIP     = "nodeIP"     // wide mode only
 	PodKeyPodIP      = "podIP"      // wide mode only
-	PodKeyReady      = "ready"      // wide mode only, "ready/total" containers
+	PodKeyReady      = "ready"      // "ready/total" containers
+	PodKeyQoS        = "qos"        // wide mode only
+	PodKeyPriority   = "priority"   // wide mode only, priority class name
+	PodKeyImage      = "image"      // the first container's image
+	PodKeyOwner      = "owner"      // the controlling owner as kind/name ("" if none)
+	PodKeyDeployment = "deployment" // hidden, the owning Deployment ("" if none)
+	PodKeyGroup      = "group"      // hidden, set on the by-deployment browse's group rows
+	// PodKeyRestartRise is hidden: the restarts (int32) since before the
+	// pod's current run of refreshes that each saw it restart, set by
+	// state.AppState while it's climbing.
+	PodKeyRestartRise = "restart_rise"
 )
 
 // Column keys for Deployments rows (see cmds.DeploymentWatchCache.Rows).
@@ -42,6 +55,22 @@ const (
 	DeployKeyAvailable = "available" // wide mode only
 	DeployKeyUpdated   = "updated"   // wide mode only
 	DeployKeySelector  = "selector"  // wide mode only
+	DeployKeyImage     = "image"     // the pod template's container images, comma-separated
+)
+
+// Column keys for sts/ds rows (see cmds.StatefulSetWatchCache.Rows and
+// cmds.DaemonSetWatchCache.Rows) — both tabs share one column layout.
+const (
+	WorkloadKeyName      = "name"
+	WorkloadKeyNamespace = "namespace"
+	WorkloadKeyReady     = "ready"  // "ready/desired" (pods for sts, nodes for ds)
+	WorkloadKeyStatus    = "status" // roll-up, see k8s.WorkloadHealthy & co.
+	WorkloadKeyAge       = "age"
+	WorkloadKeyContext   = "context"
+	WorkloadKeyUpdated   = "updated"   // wide mode only
+	WorkloadKeyAvailable = "available" // wide mode only; sts: current replicas
+	WorkloadKeyStrategy  = "strategy"  // wide mode only
+	WorkloadKeySelector  = "selector"  // wide mode only
 )
 
 // Column keys for svc rows (see cmds.ServiceWatchCache.Rows).
@@ -58,10 +87,89 @@ const (
 	SvcKeyEndpointIPs = "endpointIPs" // wide mode only, "…" until lazily fetched
 )
 
+// Column keys for the top tab's table.
+const (
+	TopKeyName      = "name"
+	TopKeyNamespace = "namespace"
+	TopKeyCPU       = "cpu"
+	TopKeyMemory    = "memory"
+	TopKeyContext   = "context"
+)
+
+// Column keys for the nodes tab's table.
+const (
+	NodeKeyName       = "name"
+	NodeKeyStatus     = "status"
+	NodeKeyRoles      = "roles"
+	NodeKeyVersion    = "version"
+	NodeKeyCPU        = "cpu"
+	NodeKeyMemory     = "memory"
+	NodeKeyPods       = "pods"
+	NodeKeyConditions = "conditions" // problem conditions that are on, "" when healthy
+	NodeKeyAge        = "age"
+	NodeKeyContext    = "context"
+)
+
+// Column keys for the ingress tab's table.
+const (
+	IngressKeyName      = "name"
+	IngressKeyNamespace = "namespace"
+	IngressKeyClass     = "class"
+	IngressKeyHost      = "host"
+	IngressKeyPath      = "path"
+	IngressKeyBackend   = "backend"
+	IngressKeyTLS       = "tls"
+	IngressKeyAddress   = "address"
+	IngressKeyAge       = "age"
+	IngressKeyContext   = "context"
+)
+
+// Column keys for the watch tab's table.
+const (
+	WatchKeyKind      = "kind"
+	WatchKeyName      = "name"
+	WatchKeyNamespace = "namespace"
+	WatchKeyStatus    = "status"
+	WatchKeyReady     = "ready"
+	WatchKeyRestarts  = "restarts"
+	WatchKeyAge       = "age"
+	WatchKeyContext   = "context"
+)
+
+// Column keys for the custom resource tab's table. Printer columns are
+// keyed CustomKeyColumnPrefix + the column's name.
+const (
+	CustomKeyName         = "name"
+	CustomKeyNamespace    = "namespace"
+	CustomKeyAge          = "age"
+	CustomKeyContext      = "context"
+	CustomKeyColumnPrefix = "col:"
+)
+
 // ContextsSelectedMsg represents a selected context with its namespace
 type ContextsSelectedMsg struct {
 	ContextName      string
 	DefaultNamespace string
+	// Namespaces picked for the context; empty means DefaultNamespace.
+	Namespaces []string
+}
+
+// NamespacesMsg carries a context's namespace list for the namespace
+// picker, or the error listing it.
+type NamespacesMsg struct {
+	Context    string
+	Namespaces []string
+	Err        error
+}
+
+// NamespaceCheckMsg reports, for each context checked, whether Namespace
+// exists there: Found lists the contexts that have it, Missing those that
+// don't, and Errs those the check failed for.
+type NamespaceCheckMsg struct {
+	Namespace string
+	Found     []string
+	Missing   []string
+	Errs      map[string]error
 }
 
 // ServiceEndpointsMsg carries lazily-fetched Endpoint IPs (service name ->
@@ -75,12 +183,35 @@ type ServiceEndpointsMsg struct {
 	Err       error
 }
 
+// ContextHealthMsg reports a context's connectivity check: the API
+// server's version and how long it took to answer (RTT), or why it
+// couldn't be reached.
+type ContextHealthMsg struct {
+	Context string
+	Version string
+	RTT     time.Duration
+	Err     error
+}
+
+// HeartbeatTickMsg fires on the heartbeat interval, when the selected
+// contexts' connectivity is checked again; whoever handles it reschedules
+// it.
+type HeartbeatTickMsg struct{}
+
 // ContextsStateMsg represents the current state of context selections
 type ContextsStateMsg struct {
 	Selected   []ContextsSelectedMsg
 	Deselected []string // context names to remove
 }
 
+// ContextParkExpiredMsg fires when a deselected context's undo grace
+// period is up. Generation tells it from the tick of an earlier park the
+// context was since restored from.
+type ContextParkExpiredMsg struct {
+	Context    string
+	Generation int
+}
+
 // ResourceDetailMsg carries a single resource's (Deployment, Pod, ...) detail
 // data or an error from an async fetch, for the Detail tab.
 type ResourceDetailMsg struct {
@@ -89,6 +220,338 @@ type ResourceDetailMsg struct {
 	Err     error
 }
 
+// PodEnvMsg carries a pod's resolved container environments (or an error)
+// for the env InfoPanel. Context/Namespace/Pod identify the request so a
+// reply for a panel since closed or reopened on another pod is dropped.
+type PodEnvMsg struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Envs      []k8s.ContainerEnv
+	Err       error
+}
+
+// PodMetricsMsg carries a scrape of a pod's metrics endpoint boiled down
+// to a Peek (or an error) for the metrics InfoPanel, identified like
+// PodEnvMsg.
+type PodMetricsMsg struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Target    string
+	Restarts  map[string]int32
+	Peek      metrics.Peek
+	Err       error
+}
+
+// PodUsageMsg carries one context's pod usage from the metrics API (or an
+// error) for the top tab.
+type PodUsageMsg struct {
+	Context   string
+	Namespace string
+	Pods      []k8s.PodUsage
+	Err       error
+}
+
+// NodesMsg carries one context's nodes (or an error) for the nodes tab.
+type NodesMsg struct {
+	Context string
+	Nodes   []k8s.NodeInfo
+	Err     error
+}
+
+// IngressesMsg carries the Ingress routes in one context's namespace (or
+// an error) for the ingress tab.
+type IngressesMsg struct {
+	Context   string
+	Namespace string
+	Routes    []k8s.IngressRoute
+	Err       error
+}
+
+// WatchStatusMsg carries a watched pod's or deployment's live status (or
+// an error) for the watch tab.
+type WatchStatusMsg struct {
+	Kind      string
+	Context   string
+	Namespace string
+	Name      string
+	Status    k8s.WatchStatus
+	Err       error
+}
+
+// APIResourcesMsg carries the resource types the selected contexts serve
+// (or an error) for the custom resource tab's type prompt.
+type APIResourcesMsg struct {
+	Resources []k8s.APIResource
+	Err       error
+}
+
+// CustomResourcesMsg carries a type's resources in one context's namespace
+// (or an error) for the custom resource tab.
+type CustomResourcesMsg struct {
+	Context   string
+	Namespace string
+	Resource  k8s.APIResource
+	List      k8s.CustomResourceList
+	Err       error
+}
+
+// ServiceBackendsMsg carries the endpoints behind a Service (or an error)
+// for the backends panel.
+type ServiceBackendsMsg struct {
+	Context   string
+	Namespace string
+	Service   string
+	Backends  []k8s.ServiceBackend
+	Err       error
+}
+
+// PodDirListingMsg carries a container directory listing (or an error) for
+// the file browser. Generation guards against replies for a browser since
+// closed or navigated elsewhere.
+type PodDirListingMsg struct {
+	Generation int
+	Dir        string
+	Entries    []k8s.FileEntry
+	Err        error
+}
+
+// PodFileContentMsg carries a file read (cat, or tail when Tail is set)
+// from a container for the file browser's viewer, guarded like
+// PodDirListingMsg.
+type PodFileContentMsg struct {
+	Generation int
+	Path       string
+	Content    string
+	Truncated  bool
+	Tail       bool
+	Err        error
+}
+
+// PodCopyProgress is one update from an in-flight copy out of a pod: the
+// bytes received so far, then a final update with Done set (and Err on
+// failure).
+type PodCopyProgress struct {
+	Bytes int64
+	Done  bool
+	Err   error
+}
+
+// PodCopyStartedMsg hands MainPage the update channel of a copy that has
+// just started. Generation guards against a copy since superseded.
+type PodCopyStartedMsg struct {
+	Generation int
+	Updates    <-chan PodCopyProgress
+}
+
+// PodCopyProgressMsg carries one PodCopyProgress off a copy's channel.
+type PodCopyProgressMsg struct {
+	Generation int
+	PodCopyProgress
+}
+
+// PodCopyClearMsg clears a finished copy's status bar notice, unless a newer
+// copy has started since.
+type PodCopyClearMsg struct {
+	Generation int
+}
+
+// Pod lifecycle actions, as reported by PodActionMsg.
+const (
+	ActionDeletePod         = "delete pod"
+	ActionRestartDeployment = "restart deployment"
+)
+
+// PodDeploymentMsg carries the Deployment owning a pod ("" if none), looked
+// up before confirming a rollout restart from the Pods tab.
+type PodDeploymentMsg struct {
+	Context    string
+	Namespace  string
+	Pod        string
+	Deployment string
+	Err        error
+}
+
+// PodActionMsg reports a pod lifecycle action (ActionDeletePod,
+// ActionRestartDeployment) done (Err == nil) or failed. Name is the object
+// acted on.
+type PodActionMsg struct {
+	Action    string
+	Context   string
+	Namespace string
+	Name      string
+	Err       error
+}
+
+// Node actions, as reported by NodeActionMsg.
+const (
+	ActionCordonNode   = "cordon"
+	ActionUncordonNode = "uncordon"
+	ActionDrainNode    = "drain"
+)
+
+// NodeDrainPlanMsg carries the preview of draining a node (see
+// k8s.Client.PlanDrain), shown for confirming before anything's evicted.
+type NodeDrainPlanMsg struct {
+	Context string
+	Node    string
+	Plan    k8s.DrainPlan
+	Err     error
+}
+
+// NodeActionMsg reports a node action (ActionCordonNode,
+// ActionUncordonNode, ActionDrainNode) done (Err == nil) or failed.
+type NodeActionMsg struct {
+	Action  string
+	Context string
+	Node    string
+	Err     error
+}
+
+// AlertFlashEndMsg ends the status bar's flash for a fired alert, unless
+// another has fired since.
+type AlertFlashEndMsg struct {
+	Generation int
+}
+
+// PodActionClearMsg clears a finished action's status bar notice, unless a
+// newer action has finished since.
+type PodActionClearMsg struct {
+	Generation int
+}
+
+// RolloutMsg carries a Deployment's rollout status and history (or an
+// error) for the rollout panel.
+type RolloutMsg struct {
+	Context   string
+	Namespace string
+	Name      string
+	Rollout   k8s.Rollout
+	Err       error
+}
+
+// DeploymentCompareMsg carries a Deployment's compared settings in two
+// contexts (or why either couldn't be fetched) for the compare panel.
+type DeploymentCompareMsg struct {
+	Namespace string
+	Name      string
+	A, B      k8s.DeploymentSpec
+	Err       error
+}
+
+// RolloutUndoneMsg reports a Deployment rolled back to Revision (Err ==
+// nil) or why it couldn't be.
+type RolloutUndoneMsg struct {
+	Context   string
+	Namespace string
+	Name      string
+	Revision  int64
+	Err       error
+}
+
+// PortForwardStartedMsg reports a port-forward started (Err == nil; Done is
+// closed when it ends) or failed to start.
+type PortForwardStartedMsg struct {
+	Info k8s.PortForwardInfo
+	Done <-chan struct{}
+	Err  error
+}
+
+// PortForwardEndedMsg reports that forward ID ended — stopped, or its
+// connection to the pod dropped.
+type PortForwardEndedMsg struct {
+	ID int
+}
+
+// PodShellExitedMsg reports that an interactive shell session (see
+// cmds.ExecPodShellCmd) ended and the TUI has the terminal back. Err is set
+// if the session couldn't be opened or broke off.
+type PodShellExitedMsg struct {
+	Context   string
+	Namespace string
+	Pod       string
+	Err       error
+}
+
+// LogLevelTarget is the log pane source a log level switch acts on.
+type LogLevelTarget struct {
+	SourceKey string
+	Context   string
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// LogLevelSwitchMsg carries the first configured log level switch matching
+// the target's pod, with the labels it was matched on. Switch is nil when
+// none matches.
+type LogLevelSwitchMsg struct {
+	Target LogLevelTarget
+	Switch *config.LogLevelSwitch
+	Labels map[string]string
+	Err    error
+}
+
+// LogsSharedMsg reports log lines posted to the paste service at URL, or
+// the failure to.
+type LogsSharedMsg struct {
+	Lines int
+	URL   string
+	Err   error
+}
+
+// LogLevelSwitchedMsg reports a log level switch applied (Err == nil) or
+// failed. Where describes what was patched, e.g. "configmap app-logging
+// key level".
+type LogLevelSwitchedMsg struct {
+	Target LogLevelTarget
+	Level  string
+	Where  string
+	Err    error
+}
+
+// PaneTemplateMsg carries what the first configured pane template matching
+// a deployment opens: its pods as Pods rows, their containers narrowed to
+// the template's, and its events when the template shows them. Template is
+// nil when none matches.
+type PaneTemplateMsg struct {
+	Context    string
+	Namespace  string
+	Deployment string
+	Template   *config.PaneTemplate
+	Pods       []RowData
+	Events     []k8s.EventInfo
+	Err        error
+}
+
+// WorkloadEventsMsg carries a fresh list of the events a pane template's
+// events source shows.
+type WorkloadEventsMsg struct {
+	SourceKey string
+	Events    []k8s.EventInfo
+	Err       error
+}
+
+// KubeconfigChangedMsg reports a reload of the kubeconfig files after
+// they were written to.
+type KubeconfigChangedMsg struct {
+	Change k8s.KubeconfigChange
+	Err    error
+}
+
+// AccessCheckedMsg reports that a context's access reviews are cached
+// (see k8s.Client.KnownAccess).
+type AccessCheckedMsg struct {
+	Context string
+}
+
+// RetriesMsg is, by context, the latest of its API calls backing off
+// before a retry (see k8s.Client.Retries), sent whenever that changes.
+type RetriesMsg struct {
+	Retries map[string]k8s.RetryState
+}
+
 // ErrorMsg is a general error message for displaying errors to users
 type ErrorMsg struct {
 	Context string // Which context caused the error (if applicable)
@@ -108,10 +571,12 @@ type LogStreamOpenedMsg struct {
 }
 
 // LogLineMsg carries a single line read from one source's open log stream.
+// Time is the kubelet's timestamp for the line, zero if it had none.
 type LogLineMsg struct {
 	SourceKey  string
 	Generation int
 	Line       string
+	Time       time.Time
 }
 
 // LogStreamClosedMsg reports that one source's log stream ended, either
@@ -123,11 +588,26 @@ type LogStreamClosedMsg struct {
 	Err        error
 }
 
+// ResyncedMsg carries a context's rows for one resource ("Pods" or
+// "Deployments") after its watch cache was reconciled with a fresh list.
+// Generation is the watch's at the time the resync was issued.
+type ResyncedMsg struct {
+	Context    string
+	Resource   string
+	Generation int
+	Rows       []RowData
+	Err        error
+}
+
 // RefreshTickMsg fires on the auto-refresh interval, self-rescheduled by
 // whoever handles it. Watches keep table data current on their own; this
 // tick now just re-renders Age text from the local watch caches (no API
-// calls) — see MainPage's RefreshTickMsg handler.
-type RefreshTickMsg struct{}
+// calls) — see MainPage's RefreshTickMsg handler. Generation drops a tick
+// scheduled before the terminal gained or lost focus, whose interval is
+// now the wrong one.
+type RefreshTickMsg struct {
+	Generation int
+}
 
 // PodWatchOpenedMsg carries a freshly opened Pods watch for one
 // context+namespace. Generation must match that context's current
@@ -197,3 +677,45 @@ type ServiceWatchClosedMsg struct {
 	Generation int
 	Err        error
 }
+
+// StatefulSetWatchOpenedMsg mirrors PodWatchOpenedMsg for StatefulSets.
+type StatefulSetWatchOpenedMsg struct {
+	Context    string
+	Generation int
+	Watcher    watch.Interface
+}
+
+// StatefulSetWatchEventMsg mirrors PodWatchEventMsg for StatefulSets.
+type StatefulSetWatchEventMsg struct {
+	Context    string
+	Generation int
+	Rows       []RowData
+}
+
+// StatefulSetWatchClosedMsg mirrors PodWatchClosedMsg for StatefulSets.
+type StatefulSetWatchClosedMsg struct {
+	Context    string
+	Generation int
+	Err        error
+}
+
+// DaemonSetWatchOpenedMsg mirrors PodWatchOpenedMsg for DaemonSets.
+type DaemonSetWatchOpenedMsg struct {
+	Context    string
+	Generation int
+	Watcher    watch.Interface
+}
+
+// DaemonSetWatchEventMsg mirrors PodWatchEventMsg for DaemonSets.
+type DaemonSetWatchEventMsg struct {
+	Context    string
+	Generation int
+	Rows       []RowData
+}
+
+// DaemonSetWatchClosedMsg mirrors PodWatchClosedMsg for DaemonSets.
+type DaemonSetWatchClosedMsg struct {
+	Context    string
+	Generation int
+	Err        error
+}
@@ -0,0 +1,49 @@
+package styles
+
+import (
+	"hash/fnv"
+	"image/color"
+	"slices"
+)
+
+// AccentColors is the rotation of Catppuccin Mocha accents that tell
+// sources and contexts apart. Red/Mauve/Green/Peach are left out: they
+// already carry other meaning elsewhere in the UI (errors, focus/selection,
+// loaded state, the log pane's title).
+func AccentColors() []color.Color {
+	p := CatppuccinMocha()
+	return []color.Color{
+		p.Blue, p.Lavender, p.Sapphire, p.Sky, p.Teal,
+		p.Pink, p.Flamingo, p.Rosewater, p.Yellow, p.Maroon,
+	}
+}
+
+// ContextAccents assigns each context an accent from AccentColors. A
+// context's color comes from a hash of its name, so it stays the same
+// across sessions and whatever else is selected; when two names land on
+// the same slot the later one (by name) takes the next free slot. Fewer
+// than two contexts need no telling apart, so that returns nil.
+func ContextAccents(contexts []string) map[string]color.Color {
+	if len(contexts) < 2 {
+		return nil
+	}
+	colors := AccentColors()
+	names := slices.Sorted(slices.Values(contexts))
+	taken := make([]bool, len(colors))
+	accents := make(map[string]color.Color, len(names))
+	for _, name := range names {
+		if _, ok := accents[name]; ok {
+			continue
+		}
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		slot := int(h.Sum32() % uint32(len(colors)))
+		// Past len(colors) contexts every slot is taken; share from there.
+		for i := 0; i < len(colors) && taken[slot]; i++ {
+			slot = (slot + 1) % len(colors)
+		}
+		taken[slot] = true
+		accents[name] = colors[slot]
+	}
+	return accents
+}
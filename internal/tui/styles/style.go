@@ -3,6 +3,7 @@ package styles
 import (
 	"image/color"
 
+	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/list"
 	"charm.land/lipgloss/v2"
 )
@@ -37,8 +38,24 @@ type Palette struct {
 	Rosewater color.Color
 }
 
+// mocha and latte are parsed once; building a Palette parses 26 hex
+// colours, which every view calling CatppuccinMocha() used to pay per frame.
+var (
+	mocha = newMochaPalette()
+	latte = newLattePalette()
+)
+
 // CatppuccinMocha returns the Mocha palette.
 func CatppuccinMocha() Palette {
+	return mocha
+}
+
+// CatppuccinLatte returns the Latte palette.
+func CatppuccinLatte() Palette {
+	return latte
+}
+
+func newMochaPalette() Palette {
 	return Palette{
 		Base:      lipgloss.Color("#1e1e2e"),
 		Mantle:    lipgloss.Color("#181825"),
@@ -69,8 +86,7 @@ func CatppuccinMocha() Palette {
 	}
 }
 
-// CatppuccinMocha returns the Mocha palette.
-func CatppuccinLatte() Palette {
+func newLattePalette() Palette {
 	return Palette{
 		Base:      lipgloss.Color("#eff1f5"),
 		Mantle:    lipgloss.Color("#e6e9ef"),
@@ -113,7 +129,10 @@ type BubbleTableStyle struct {
 // CatppuccinBubbleTableStyle returns bubble-table styles using the
 // Catppuccin Mocha palette.
 func CatppuccinBubbleTableStyle() BubbleTableStyle {
-	p := CatppuccinMocha()
+	return mochaTheme.Table
+}
+
+func newBubbleTableStyle(p Palette) BubbleTableStyle {
 	return BubbleTableStyle{
 		Header: lipgloss.NewStyle().
 			Background(p.Surface0).
@@ -130,7 +149,10 @@ func CatppuccinBubbleTableStyle() BubbleTableStyle {
 
 // HelpBoxStyle returns a styled lipgloss style for help overlays using the palette.
 func HelpBoxStyle() lipgloss.Style {
-	p := CatppuccinMocha()
+	return mochaTheme.HelpBox
+}
+
+func newHelpBoxStyle(p Palette) lipgloss.Style {
 	return lipgloss.NewStyle().
 		Foreground(p.Text).
 		Background(p.Mantle).
@@ -139,6 +161,21 @@ func HelpBoxStyle() lipgloss.Style {
 		Padding(1, 2)
 }
 
+func newKeyHintStyles(p Palette) help.Styles {
+	keyStyle := lipgloss.NewStyle().Foreground(p.Blue)
+	descStyle := lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true)
+	sepStyle := lipgloss.NewStyle().Foreground(p.Overlay0)
+	return help.Styles{
+		Ellipsis:       sepStyle,
+		ShortKey:       keyStyle,
+		ShortDesc:      descStyle,
+		ShortSeparator: sepStyle,
+		FullKey:        keyStyle,
+		FullDesc:       descStyle,
+		FullSeparator:  sepStyle,
+	}
+}
+
 func CatppuccinMochaListStyles() list.Styles {
 	p := CatppuccinMocha()
 	return list.Styles{
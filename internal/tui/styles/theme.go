@@ -0,0 +1,109 @@
+package styles
+
+import (
+	"charm.land/bubbles/v2/help"
+	"charm.land/lipgloss/v2"
+)
+
+// Theme holds the styles views render with, built once from a palette.
+// lipgloss styles are values, so a view can take one and adjust it (Width,
+// Align, ...) without touching the shared copy.
+type Theme struct {
+	Palette Palette
+
+	// Foreground-only text styles, one per colour the views set text in.
+	Text      lipgloss.Style
+	Subtext0  lipgloss.Style
+	Subtext1  lipgloss.Style
+	Overlay0  lipgloss.Style
+	Overlay1  lipgloss.Style
+	Blue      lipgloss.Style
+	Sapphire  lipgloss.Style
+	Green     lipgloss.Style
+	Yellow    lipgloss.Style
+	Peach     lipgloss.Style
+	Red       lipgloss.Style
+	Maroon    lipgloss.Style
+	Mauve     lipgloss.Style
+	Lavender  lipgloss.Style
+	Rosewater lipgloss.Style
+
+	// Plain is the empty style, for cells left uncoloured.
+	Plain lipgloss.Style
+	// Hint is faint Overlay1: key hints, footers, secondary labels.
+	Hint lipgloss.Style
+	// Title is bold Mauve: overlay titles and the cursor marker.
+	Title lipgloss.Style
+	// PaneTitle heads the contexts pane.
+	PaneTitle lipgloss.Style
+	// Selected is the canonical cursor highlight, Base on Mauve.
+	Selected lipgloss.Style
+
+	// Loading is the inline "⏳ Loading: ..." badge above a table.
+	Loading lipgloss.Style
+
+	// Status bar segments, left to right.
+	StatusLeft  lipgloss.Style
+	StatusMid   lipgloss.Style
+	StatusRight lipgloss.Style
+	// KeyHints styles the status bar's key hints (a help.Model's short
+	// view): Blue keys, faint descriptions.
+	KeyHints help.Styles
+
+	// OverlayBox frames the modal overlays.
+	OverlayBox lipgloss.Style
+	HelpBox    lipgloss.Style
+	Table      BubbleTableStyle
+}
+
+var mochaTheme = NewTheme(mocha)
+
+// Mocha returns the shared Catppuccin Mocha theme. Callers must treat it as
+// read-only.
+func Mocha() *Theme {
+	return mochaTheme
+}
+
+// NewTheme builds every style in a Theme from p.
+func NewTheme(p Palette) *Theme {
+	return &Theme{
+		Palette: p,
+
+		Text:      lipgloss.NewStyle().Foreground(p.Text),
+		Subtext0:  lipgloss.NewStyle().Foreground(p.Subtext0),
+		Subtext1:  lipgloss.NewStyle().Foreground(p.Subtext1),
+		Overlay0:  lipgloss.NewStyle().Foreground(p.Overlay0),
+		Overlay1:  lipgloss.NewStyle().Foreground(p.Overlay1),
+		Blue:      lipgloss.NewStyle().Foreground(p.Blue),
+		Sapphire:  lipgloss.NewStyle().Foreground(p.Sapphire),
+		Green:     lipgloss.NewStyle().Foreground(p.Green),
+		Yellow:    lipgloss.NewStyle().Foreground(p.Yellow),
+		Peach:     lipgloss.NewStyle().Foreground(p.Peach),
+		Red:       lipgloss.NewStyle().Foreground(p.Red),
+		Maroon:    lipgloss.NewStyle().Foreground(p.Maroon),
+		Mauve:     lipgloss.NewStyle().Foreground(p.Mauve),
+		Lavender:  lipgloss.NewStyle().Foreground(p.Lavender),
+		Rosewater: lipgloss.NewStyle().Foreground(p.Rosewater),
+
+		Plain:     lipgloss.NewStyle(),
+		Hint:      lipgloss.NewStyle().Foreground(p.Overlay1).Faint(true),
+		Title:     lipgloss.NewStyle().Foreground(p.Mauve).Bold(true),
+		PaneTitle: lipgloss.NewStyle().Foreground(p.Flamingo).Bold(true).Padding(0, 1),
+		Selected:  lipgloss.NewStyle().Background(p.Mauve).Foreground(p.Base),
+
+		Loading: lipgloss.NewStyle().Foreground(p.Blue).Background(p.Surface0).Padding(0, 1),
+
+		StatusLeft:  lipgloss.NewStyle().Foreground(p.Rosewater).Padding(0, 1),
+		StatusMid:   lipgloss.NewStyle().Foreground(p.Sapphire).Bold(true),
+		StatusRight: lipgloss.NewStyle().Foreground(p.Green).Padding(0, 1),
+		KeyHints:    newKeyHintStyles(p),
+
+		OverlayBox: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(p.Mauve).
+			Background(p.Mantle).
+			Padding(0, 2),
+		HelpBox: newHelpBoxStyle(p),
+		Table:   newBubbleTableStyle(p),
+	}
+}
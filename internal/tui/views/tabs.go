@@ -52,3 +52,16 @@ func RenderTabHeaders(activeTab int, tabs []string, w int, blur bool) string {
 
 	return row
 }
+
+// TabAt returns the index of the tab whose header RenderTabHeaders, given
+// the same tabs and w, draws at column x; ok is false past the last one.
+func TabAt(x int, tabs []string, w int) (int, bool) {
+	if len(tabs) == 0 || x < 0 {
+		return 0, false
+	}
+	width := w/len(tabs) + styles.InactiveTabStyle.GetHorizontalBorderSize()
+	if i := x / width; i < len(tabs) {
+		return i, true
+	}
+	return 0, false
+}
@@ -0,0 +1,94 @@
+// Package version identifies the running build: the release version,
+// commit and build date goreleaser stamps in with -ldflags, e.g.
+//
+//	-X github.com/ktails/ktails/internal/version.Version=v1.2.3
+//
+// falling back to what the Go toolchain records in the binary for builds
+// made without them (go install, go build from a checkout).
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set via -ldflags by goreleaser; see the package comment.
+var (
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
+)
+
+// Info describes the running build.
+type Info struct {
+	Version   string
+	Commit    string
+	Date      string
+	GoVersion string
+	Platform  string // GOOS/GOARCH
+}
+
+// Get returns the running build's Info. Fields the linker didn't set are
+// filled from the module and VCS stamps in the binary's build info, where
+// there are any.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	var modified bool
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "none" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.Date == "unknown" {
+				info.Date = s.Value
+			}
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if modified && Commit == "none" && info.Commit != "none" {
+		info.Commit += "-dirty"
+	}
+	return info
+}
+
+// String is the one-line form `ktails version` prints.
+func (i Info) String() string {
+	return fmt.Sprintf("ktails %s (commit %s, built %s, %s %s)", i.Version, i.ShortCommit(), i.Date, i.GoVersion, i.Platform)
+}
+
+// ShortCommit is the commit abbreviated to 12 characters, as git would.
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+	return i.Commit
+}
+
+// Lines lists the build's details one per line, for the build info
+// overlay and bug reports.
+func (i Info) Lines() []string {
+	return []string{
+		"Version:    " + i.Version,
+		"Commit:     " + i.Commit,
+		"Built:      " + i.Date,
+		"Go:         " + i.GoVersion,
+		"Platform:   " + i.Platform,
+	}
+}